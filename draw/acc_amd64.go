@@ -0,0 +1,33 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !appengine && gc && go1.6 && !noasm
+// +build !appengine,gc,go1.6,!noasm
+
+package draw
+
+// haveAccumulateSIMD is whether the kernel scalers' inner loops, which weigh
+// and sum source pixels, can use SIMD instructions. On amd64, they only need
+// SSE2, which every amd64 CPU has.
+const haveAccumulateSIMD = true
+
+// accumulateRGBASIMD sets acc to the sum, over contribs, of the RGBA pixel in
+// pix at each contrib's coord times its weight. Each 8-bit channel value c is
+// converted to 16 bits, as c * 0x101, before being weighed.
+//
+//go:noescape
+func accumulateRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)
+
+// accumulateNRGBASIMD is like accumulateRGBASIMD, but for NRGBA pixels. Each
+// color channel value c is premultiplied, as c * (a * 0x101) / 0xff, before
+// being weighed, where a is the 8-bit alpha value.
+//
+//go:noescape
+func accumulateNRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)
+
+// accumulateTmpSIMD sets acc to the sum, over contribs, of tmp[coord*stride]
+// times each contrib's weight.
+//
+//go:noescape
+func accumulateTmpSIMD(acc *[4]float64, tmp [][4]float64, contribs []contrib, stride int)
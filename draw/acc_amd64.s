@@ -0,0 +1,184 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !appengine
+// +build gc
+// +build go1.6
+// +build !noasm
+
+#include "textflag.h"
+
+// All three functions keep the running sums of the red and green channels in X0,
+// and of the blue and alpha channels in X1, as pairs of float64 values. Each
+// product is added to its sum in the same order as the pure Go code does, and
+// without fused multiply-adds, so that the results are identical.
+//
+// A contrib is 16 bytes: an int32 coord, 4 bytes of padding and a float64
+// weight.
+
+// func accumulateRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)
+//
+// SI is pix. BX is the current contrib and CX is the number of contribs left.
+// X7 is zero.
+TEXT ·accumulateRGBASIMD(SB), NOSPLIT, $0-56
+	MOVQ acc+0(FP), DI
+	MOVQ pix_base+8(FP), SI
+	MOVQ contribs_base+32(FP), BX
+	MOVQ contribs_len+40(FP), CX
+
+	XORPD X0, X0
+	XORPD X1, X1
+	PXOR  X7, X7
+
+rgbaLoop:
+	CMPQ CX, $0
+	JEQ  rgbaEnd
+
+	// Load the pixel's four 8-bit channels, and widen them to 16 bits, by
+	// interleaving them with themselves, which multiplies them by 0x101, and
+	// then to 32 bits.
+	MOVLQSX   (BX), AX
+	MOVL      (SI)(AX*4), DX
+	MOVQ      DX, X2
+	PUNPCKLBW X2, X2
+	PUNPCKLWL X7, X2
+
+	// Convert to float64s: red and green in X3, blue and alpha in X4.
+	CVTPL2PD X2, X3
+	PSHUFD   $0x4e, X2, X2
+	CVTPL2PD X2, X4
+
+	// Multiply by the weight, broadcast to both halves of X5, and add.
+	MOVSD  8(BX), X5
+	SHUFPD $0, X5, X5
+	MULPD  X5, X3
+	MULPD  X5, X4
+	ADDPD  X3, X0
+	ADDPD  X4, X1
+
+	ADDQ $16, BX
+	DECQ CX
+	JMP  rgbaLoop
+
+rgbaEnd:
+	MOVUPD X0, 0(DI)
+	MOVUPD X1, 16(DI)
+	RET
+
+// nrgbaConsts are 0xff and 0x101, each as a pair of float64 values.
+DATA nrgbaConsts<>+0(SB)/8, $255.0
+DATA nrgbaConsts<>+8(SB)/8, $255.0
+DATA nrgbaConsts<>+16(SB)/8, $257.0
+DATA nrgbaConsts<>+24(SB)/8, $257.0
+GLOBL nrgbaConsts<>(SB), RODATA|NOPTR, $32
+
+// func accumulateNRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)
+//
+// The registers are as for accumulateRGBASIMD. X8 holds 0xff and X9 holds
+// 0x101. Premultiplying is done in float64: each product of a color channel
+// and the 16-bit alpha is an exact integer, and truncating its quotient by
+// 0xff gives the same value as the pure Go code's integer division.
+TEXT ·accumulateNRGBASIMD(SB), NOSPLIT, $0-56
+	MOVQ acc+0(FP), DI
+	MOVQ pix_base+8(FP), SI
+	MOVQ contribs_base+32(FP), BX
+	MOVQ contribs_len+40(FP), CX
+
+	XORPD  X0, X0
+	XORPD  X1, X1
+	PXOR   X7, X7
+	MOVUPD nrgbaConsts<>+0(SB), X8
+	MOVUPD nrgbaConsts<>+16(SB), X9
+
+nrgbaLoop:
+	CMPQ CX, $0
+	JEQ  nrgbaEnd
+
+	// Load the pixel's four 8-bit channels, and widen them to 32 bits.
+	MOVLQSX   (BX), AX
+	MOVL      (SI)(AX*4), DX
+	MOVQ      DX, X2
+	PUNPCKLBW X7, X2
+	PUNPCKLWL X7, X2
+
+	// Convert to float64s: red and green in X3, blue and alpha in X4.
+	CVTPL2PD X2, X3
+	PSHUFD   $0x4e, X2, X2
+	CVTPL2PD X2, X4
+
+	// Broadcast the 16-bit alpha, a * 0x101, to both halves of X6.
+	MOVAPD   X4, X6
+	UNPCKHPD X6, X6
+	MULPD    X9, X6
+
+	// Premultiply red, green and blue. The alpha channel is multiplied by
+	// 0xff instead of itself, so that dividing by 0xff leaves the 16-bit
+	// alpha.
+	MOVAPD    X8, X10
+	MOVSD     X4, X10
+	MULPD     X6, X3
+	MULPD     X6, X10
+	DIVPD     X8, X3
+	DIVPD     X8, X10
+	CVTTPD2PL X3, X3
+	CVTPL2PD  X3, X3
+	CVTTPD2PL X10, X10
+	CVTPL2PD  X10, X10
+
+	// Multiply by the weight, broadcast to both halves of X5, and add.
+	MOVSD  8(BX), X5
+	SHUFPD $0, X5, X5
+	MULPD  X5, X3
+	MULPD  X5, X10
+	ADDPD  X3, X0
+	ADDPD  X10, X1
+
+	ADDQ $16, BX
+	DECQ CX
+	JMP  nrgbaLoop
+
+nrgbaEnd:
+	MOVUPD X0, 0(DI)
+	MOVUPD X1, 16(DI)
+	RET
+
+// func accumulateTmpSIMD(acc *[4]float64, tmp [][4]float64, contribs []contrib, stride int)
+//
+// SI is tmp. R8 is the stride in bytes. BX is the current contrib and CX is
+// the number of contribs left.
+TEXT ·accumulateTmpSIMD(SB), NOSPLIT, $0-64
+	MOVQ acc+0(FP), DI
+	MOVQ tmp_base+8(FP), SI
+	MOVQ contribs_base+32(FP), BX
+	MOVQ contribs_len+40(FP), CX
+	MOVQ stride+56(FP), R8
+	SHLQ $5, R8
+
+	XORPD X0, X0
+	XORPD X1, X1
+
+tmpLoop:
+	CMPQ CX, $0
+	JEQ  tmpEnd
+
+	MOVLQSX (BX), AX
+	IMULQ   R8, AX
+	MOVUPD  (SI)(AX*1), X3
+	MOVUPD  16(SI)(AX*1), X4
+
+	MOVSD  8(BX), X5
+	SHUFPD $0, X5, X5
+	MULPD  X5, X3
+	MULPD  X5, X4
+	ADDPD  X3, X0
+	ADDPD  X4, X1
+
+	ADDQ $16, BX
+	DECQ CX
+	JMP  tmpLoop
+
+tmpEnd:
+	MOVUPD X0, 0(DI)
+	MOVUPD X1, 16(DI)
+	RET
@@ -0,0 +1,167 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !appengine && gc && go1.6 && !noasm
+// +build !appengine,gc,go1.6,!noasm
+
+#include "textflag.h"
+
+// All three functions keep the running sums of the red, green, blue and alpha
+// channels in F0, F1, F2 and F3. Each product is added to its sum with a fused
+// multiply-add, as the compiler does for the pure Go code on arm64, and in the
+// same order, so that the results are identical.
+//
+// A contrib is 16 bytes: an int32 coord, 4 bytes of padding and a float64
+// weight.
+
+// func accumulateRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)
+//
+// R1 is pix. R2 is the current contrib and R3 is the number of contribs left.
+// F4 is the contrib's weight. R9 is 0x101.
+TEXT ·accumulateRGBASIMD(SB), NOSPLIT, $0-56
+	MOVD acc+0(FP), R0
+	MOVD pix_base+8(FP), R1
+	MOVD contribs_base+32(FP), R2
+	MOVD contribs_len+40(FP), R3
+
+	FMOVD ZR, F0
+	FMOVD ZR, F1
+	FMOVD ZR, F2
+	FMOVD ZR, F3
+	MOVD  $0x101, R9
+
+rgbaLoop:
+	CBZ R3, rgbaEnd
+
+	// Load the pixel's four 8-bit channels.
+	MOVW  (R2), R4
+	FMOVD 8(R2), F4
+	MOVWU (R1)(R4<<2), R5
+
+	// Widen each channel to 16 bits, as c * 0x101, convert it to float64,
+	// multiply by the weight and add.
+	UBFX   $0, R5, $8, R6
+	MUL    R9, R6
+	SCVTFD R6, F5
+	FMADDD F4, F0, F5, F0
+	UBFX   $8, R5, $8, R6
+	MUL    R9, R6
+	SCVTFD R6, F5
+	FMADDD F4, F1, F5, F1
+	UBFX   $16, R5, $8, R6
+	MUL    R9, R6
+	SCVTFD R6, F5
+	FMADDD F4, F2, F5, F2
+	UBFX   $24, R5, $8, R6
+	MUL    R9, R6
+	SCVTFD R6, F5
+	FMADDD F4, F3, F5, F3
+
+	ADD $16, R2
+	SUB $1, R3
+	B   rgbaLoop
+
+rgbaEnd:
+	FSTPD (F0, F1), 0(R0)
+	FSTPD (F2, F3), 16(R0)
+	RET
+
+// func accumulateNRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)
+//
+// The registers are as for accumulateRGBASIMD. R7 is the 16-bit alpha and R10
+// is 0xff. Premultiplying is done in integers, as the pure Go code does.
+TEXT ·accumulateNRGBASIMD(SB), NOSPLIT, $0-56
+	MOVD acc+0(FP), R0
+	MOVD pix_base+8(FP), R1
+	MOVD contribs_base+32(FP), R2
+	MOVD contribs_len+40(FP), R3
+
+	FMOVD ZR, F0
+	FMOVD ZR, F1
+	FMOVD ZR, F2
+	FMOVD ZR, F3
+	MOVD  $0x101, R9
+	MOVD  $0xff, R10
+
+nrgbaLoop:
+	CBZ R3, nrgbaEnd
+
+	// Load the pixel's four 8-bit channels.
+	MOVW  (R2), R4
+	FMOVD 8(R2), F4
+	MOVWU (R1)(R4<<2), R5
+
+	// Widen the alpha to 16 bits, as a * 0x101.
+	UBFX $24, R5, $8, R7
+	MUL  R9, R7
+
+	// Premultiply each color channel, as c * (a * 0x101) / 0xff, convert it
+	// to float64, multiply by the weight and add.
+	UBFX   $0, R5, $8, R6
+	MUL    R7, R6
+	UDIV   R10, R6
+	SCVTFD R6, F5
+	FMADDD F4, F0, F5, F0
+	UBFX   $8, R5, $8, R6
+	MUL    R7, R6
+	UDIV   R10, R6
+	SCVTFD R6, F5
+	FMADDD F4, F1, F5, F1
+	UBFX   $16, R5, $8, R6
+	MUL    R7, R6
+	UDIV   R10, R6
+	SCVTFD R6, F5
+	FMADDD F4, F2, F5, F2
+	SCVTFD R7, F5
+	FMADDD F4, F3, F5, F3
+
+	ADD $16, R2
+	SUB $1, R3
+	B   nrgbaLoop
+
+nrgbaEnd:
+	FSTPD (F0, F1), 0(R0)
+	FSTPD (F2, F3), 16(R0)
+	RET
+
+// func accumulateTmpSIMD(acc *[4]float64, tmp [][4]float64, contribs []contrib, stride int)
+//
+// R1 is tmp. R8 is the stride in bytes. R2 is the current contrib and R3 is
+// the number of contribs left.
+TEXT ·accumulateTmpSIMD(SB), NOSPLIT, $0-64
+	MOVD acc+0(FP), R0
+	MOVD tmp_base+8(FP), R1
+	MOVD contribs_base+32(FP), R2
+	MOVD contribs_len+40(FP), R3
+	MOVD stride+56(FP), R8
+	LSL  $5, R8
+
+	FMOVD ZR, F0
+	FMOVD ZR, F1
+	FMOVD ZR, F2
+	FMOVD ZR, F3
+
+tmpLoop:
+	CBZ R3, tmpEnd
+
+	MOVW  (R2), R4
+	FMOVD 8(R2), F4
+	MUL   R8, R4
+	ADD   R1, R4
+	FLDPD (R4), (F5, F6)
+	FLDPD 16(R4), (F7, F8)
+
+	FMADDD F4, F0, F5, F0
+	FMADDD F4, F1, F6, F1
+	FMADDD F4, F2, F7, F2
+	FMADDD F4, F3, F8, F3
+
+	ADD $16, R2
+	SUB $1, R3
+	B   tmpLoop
+
+tmpEnd:
+	FSTPD (F0, F1), 0(R0)
+	FSTPD (F2, F3), 16(R0)
+	RET
@@ -0,0 +1,14 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !amd64 && !arm64 || appengine || !gc || !go1.6 || noasm
+// +build !amd64,!arm64 appengine !gc !go1.6 noasm
+
+package draw
+
+const haveAccumulateSIMD = false
+
+func accumulateRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)                  {}
+func accumulateNRGBASIMD(acc *[4]float64, pix []byte, contribs []contrib)                 {}
+func accumulateTmpSIMD(acc *[4]float64, tmp [][4]float64, contribs []contrib, stride int) {}
@@ -0,0 +1,128 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// accClose returns whether the SIMD sums got are close to the pure Go sums
+// want. They are computed in the same order, but the compiler may fuse the
+// pure Go code's multiplies and adds, such as when GOAMD64 is v3 or higher,
+// so they may differ in their last bits.
+func accClose(got, want [4]float64) bool {
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9*(1+math.Abs(want[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func randContribs(r *rand.Rand, n, maxCoord int) []contrib {
+	contribs := make([]contrib, n)
+	for i := range contribs {
+		contribs[i] = contrib{
+			coord:  int32(r.Intn(maxCoord)),
+			weight: r.Float64()*2 - 0.5,
+		}
+	}
+	return contribs
+}
+
+func TestAccumulateRGBASIMD(t *testing.T) {
+	if !haveAccumulateSIMD {
+		t.Skip("No SIMD implementation")
+	}
+	r := rand.New(rand.NewSource(1))
+	pix := make([]byte, 4*100)
+	fillPix(r, pix)
+	for n := 0; n < 20; n++ {
+		contribs := randContribs(r, n, 100)
+
+		var got [4]float64
+		accumulateRGBASIMD(&got, pix, contribs)
+
+		var want [4]float64
+		for _, c := range contribs {
+			for i := range want {
+				want[i] += float64(uint32(pix[4*c.coord+int32(i)])*0x101) * c.weight
+			}
+		}
+		if !accClose(got, want) {
+			t.Errorf("n=%d: got %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestAccumulateNRGBASIMD(t *testing.T) {
+	if !haveAccumulateSIMD {
+		t.Skip("No SIMD implementation")
+	}
+	r := rand.New(rand.NewSource(1))
+	pix := make([]byte, 4*100)
+	fillPix(r, pix)
+	// Include fully transparent and fully opaque pixels.
+	pix[3], pix[7] = 0x00, 0xff
+	for n := 0; n < 20; n++ {
+		contribs := randContribs(r, n, 100)
+		if n > 1 {
+			contribs[0].coord, contribs[1].coord = 0, 1
+		}
+
+		var got [4]float64
+		accumulateNRGBASIMD(&got, pix, contribs)
+
+		var want [4]float64
+		for _, c := range contribs {
+			p := pix[4*c.coord:]
+			pau := uint32(p[3]) * 0x101
+			pru := uint32(p[0]) * pau / 0xff
+			pgu := uint32(p[1]) * pau / 0xff
+			pbu := uint32(p[2]) * pau / 0xff
+			want[0] += float64(pru) * c.weight
+			want[1] += float64(pgu) * c.weight
+			want[2] += float64(pbu) * c.weight
+			want[3] += float64(pau) * c.weight
+		}
+		if !accClose(got, want) {
+			t.Errorf("n=%d: got %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestAccumulateTmpSIMD(t *testing.T) {
+	if !haveAccumulateSIMD {
+		t.Skip("No SIMD implementation")
+	}
+	r := rand.New(rand.NewSource(1))
+	const stride, rows = 7, 30
+	tmp := make([][4]float64, stride*rows)
+	for i := range tmp {
+		for j := range tmp[i] {
+			tmp[i][j] = r.Float64() * 0xffff
+		}
+	}
+	for n := 0; n < 20; n++ {
+		contribs := randContribs(r, n, rows)
+		dx := r.Intn(stride)
+
+		var got [4]float64
+		accumulateTmpSIMD(&got, tmp[dx:], contribs, stride)
+
+		var want [4]float64
+		for _, c := range contribs {
+			p := &tmp[int(c.coord)*stride+dx]
+			for i := range want {
+				want[i] += p[i] * c.weight
+			}
+		}
+		if !accClose(got, want) {
+			t.Errorf("n=%d: got %v, want %v", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"math"
+)
+
+// These separable blend modes composite src over dst, as Over does, except
+// that where both src and dst are opaque, the resulting color is a function
+// of the src and dst colors, applied to each of the red, green and blue
+// channels separately. They are as specified by the W3C's "Compositing and
+// Blending Level 1" recommendation, and are supported wherever the Porter-Duff
+// operators other than Over and Src are.
+const (
+	// Multiply multiplies the src and dst colors. The result is never
+	// lighter than either.
+	Multiply Op = Xor + 1 + iota
+	// Screen multiplies the complements of the src and dst colors. The
+	// result is never darker than either.
+	Screen
+	// Overlay is Multiply or Screen, depending on the dst color. It is
+	// HardLight with src and dst swapped.
+	Overlay
+	// Darken selects the darker of the src and dst colors.
+	Darken
+	// Lighten selects the lighter of the src and dst colors.
+	Lighten
+	// ColorDodge brightens the dst color to reflect the src color.
+	ColorDodge
+	// ColorBurn darkens the dst color to reflect the src color.
+	ColorBurn
+	// HardLight is Multiply or Screen, depending on the src color.
+	HardLight
+	// SoftLight darkens or lightens the dst color, depending on the src
+	// color. It is a softer version of HardLight.
+	SoftLight
+	// Difference subtracts the darker of the src and dst colors from the
+	// lighter.
+	Difference
+	// Exclusion is like Difference, but with lower contrast.
+	Exclusion
+)
+
+// isBlendOp returns whether op is a separable blend mode.
+func isBlendOp(op Op) bool {
+	return Multiply <= op && op <= Exclusion
+}
+
+// blendChannel returns one channel of the src over dst blend, weighted by m,
+// plus d weighted by 1-m. The s and d values are alpha-premultiplied, and all
+// of the values are scaled to [0, 0xffff].
+func blendChannel(op Op, s, d, sa, da, m uint32) uint16 {
+	fs, fd := float64(s)/0xffff, float64(d)/0xffff
+	fsa, fda := float64(sa)/0xffff, float64(da)/0xffff
+
+	// The result is (1 - da)*s + (1 - sa)*d + sa*da*B(s/sa, d/da), where B
+	// is the blend function of op, applied to non-premultiplied colors.
+	c := (1-fda)*fs + (1-fsa)*fd
+	if sa != 0 && da != 0 {
+		c += fsa * fda * blend(op, fs/fsa, fd/fda)
+	}
+
+	// Keep the result alpha-premultiplied.
+	if a := fsa + fda - fsa*fda; c > a {
+		c = a
+	} else if c < 0 {
+		c = 0
+	}
+	fm := float64(m) / 0xffff
+	return uint16((c*fm+fd*(1-fm))*0xffff + 0.5)
+}
+
+// blend returns the blend function of op applied to the non-premultiplied
+// src and dst colors s and d, in the range [0, 1].
+func blend(op Op, s, d float64) float64 {
+	switch op {
+	case Multiply:
+		return s * d
+	case Screen:
+		return s + d - s*d
+	case Overlay:
+		return hardLight(d, s)
+	case Darken:
+		return math.Min(s, d)
+	case Lighten:
+		return math.Max(s, d)
+	case ColorDodge:
+		if d == 0 {
+			return 0
+		} else if s >= 1 {
+			return 1
+		}
+		return math.Min(1, d/(1-s))
+	case ColorBurn:
+		if d >= 1 {
+			return 1
+		} else if s <= 0 {
+			return 0
+		}
+		return 1 - math.Min(1, (1-d)/s)
+	case HardLight:
+		return hardLight(s, d)
+	case SoftLight:
+		if s <= 0.5 {
+			return d - (1-2*s)*d*(1-d)
+		}
+		e := math.Sqrt(d)
+		if d <= 0.25 {
+			e = ((16*d-12)*d + 4) * d
+		}
+		return d + (2*s-1)*(e-d)
+	case Difference:
+		return math.Abs(s - d)
+	case Exclusion:
+		return s + d - 2*s*d
+	}
+	return s
+}
+
+func hardLight(s, d float64) float64 {
+	if s <= 0.5 {
+		return 2 * s * d
+	}
+	s = 2*s - 1
+	return s + d - s*d
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBlendOps(t *testing.T) {
+	src := image.NewUniform(color.RGBA{0x40, 0x80, 0xc0, 0xff})
+	testCases := []struct {
+		op   Op
+		want color.RGBA
+	}{
+		{Multiply, color.RGBA{0x40, 0x40, 0x00, 0xff}},
+		{Screen, color.RGBA{0xff, 0xc0, 0xc0, 0xff}},
+		{Overlay, color.RGBA{0xff, 0x81, 0x00, 0xff}},
+		{Darken, color.RGBA{0x40, 0x80, 0x00, 0xff}},
+		{Lighten, color.RGBA{0xff, 0x80, 0xc0, 0xff}},
+		{ColorDodge, color.RGBA{0xff, 0xff, 0x00, 0xff}},
+		{ColorBurn, color.RGBA{0xff, 0x02, 0x00, 0xff}},
+		{HardLight, color.RGBA{0x80, 0x81, 0x81, 0xff}},
+		{SoftLight, color.RGBA{0xff, 0x80, 0x00, 0xff}},
+		{Difference, color.RGBA{0xbf, 0x00, 0xc0, 0xff}},
+		{Exclusion, color.RGBA{0xbf, 0x7f, 0xc0, 0xff}},
+	}
+	for _, tc := range testCases {
+		dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetRGBA(0, 0, color.RGBA{0xff, 0x80, 0x00, 0xff})
+		Draw(dst, dst.Bounds(), src, image.Point{}, tc.op)
+		if got := dst.RGBAAt(0, 0); got != tc.want {
+			t.Errorf("op=%v: got %v, want %v", tc.op, got, tc.want)
+		}
+	}
+}
+
+// TestBlendOpsTransparent tests that every blend mode is equivalent to Over
+// when either the src or dst is transparent.
+func TestBlendOpsTransparent(t *testing.T) {
+	opaque := color.RGBA{0x40, 0x80, 0xc0, 0xff}
+	for op := Multiply; op <= Exclusion; op++ {
+		dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetRGBA(0, 0, opaque)
+		Draw(dst, dst.Bounds(), image.Transparent, image.Point{}, op)
+		if got := dst.RGBAAt(0, 0); got != opaque {
+			t.Errorf("op=%v, transparent src: got %v, want %v", op, got, opaque)
+		}
+
+		dst = image.NewRGBA(image.Rect(0, 0, 1, 1))
+		NearestNeighbor.Scale(dst, dst.Bounds(), image.NewUniform(opaque), image.Rect(0, 0, 1, 1), op, nil)
+		if got := dst.RGBAAt(0, 0); got != opaque {
+			t.Errorf("op=%v, transparent dst: got %v, want %v", op, got, opaque)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"image/color"
+
+	"golang.org/x/image/math/f64"
+)
+
+// These Porter-Duff compositing operators complement the Over and Src
+// operators of the standard library's image/draw package. They are supported
+// by this package's Draw, DrawMask, Copy, Scale and Transform functions and
+// methods, but not by the standard library's. The standard library's
+// operators are faster.
+//
+// Op is the standard library's type, so its Draw method, and any other code
+// that passes an Op to the standard library, does not support these
+// operators either, and typically treats them as Src. Call this package's
+// Draw function instead of the Draw method, such as Draw(dst, r, src, sp,
+// Clear) instead of Clear.Draw(dst, r, src, sp).
+//
+// Their values are well clear of those of Over and Src, so that they do not
+// collide with any operators that the standard library might add.
+//
+// As for Over and Src, the mask, if any, limits how much each dst pixel is
+// affected: a dst pixel becomes the composition of the src and dst pixels,
+// weighted by the mask's alpha value, plus the original dst pixel, weighted
+// by one minus that value.
+const (
+	// Clear specifies ``clear in mask''. It sets dst to transparent black.
+	Clear Op = 0x100 + iota
+	// Dst specifies ``dst in mask''. It leaves dst unchanged.
+	Dst
+	// In specifies ``(src in dst) in mask''.
+	In
+	// Out specifies ``(src out dst) in mask''.
+	Out
+	// Atop specifies ``(src atop dst) in mask''.
+	Atop
+	// DstOver specifies ``(dst over src) in mask''.
+	DstOver
+	// DstIn specifies ``(dst in src) in mask''.
+	DstIn
+	// DstOut specifies ``(dst out src) in mask''.
+	DstOut
+	// DstAtop specifies ``(dst atop src) in mask''.
+	DstAtop
+	// Xor specifies ``(src xor dst) in mask''.
+	Xor
+)
+
+// isStdOp returns whether op is supported by the standard library's
+// image/draw package.
+func isStdOp(op Op) bool {
+	return op == Over || op == Src
+}
+
+// porterDuff returns the fractions, scaled to [0, 0xffff], of the src and dst
+// colors that op combines, when their alphas are sa and da.
+func porterDuff(op Op, sa, da uint32) (fs, fd uint32) {
+	switch op {
+	case Over:
+		return 0xffff, 0xffff - sa
+	case Src:
+		return 0xffff, 0
+	case Dst:
+		return 0, 0xffff
+	case In:
+		return da, 0
+	case Out:
+		return 0xffff - da, 0
+	case Atop:
+		return da, 0xffff - sa
+	case DstOver:
+		return 0xffff - da, 0xffff
+	case DstIn:
+		return 0, sa
+	case DstOut:
+		return 0, 0xffff - sa
+	case DstAtop:
+		return 0xffff - da, sa
+	case Xor:
+		return 0xffff - da, 0xffff - sa
+	}
+	// Clear, and unknown operators.
+	return 0, 0
+}
+
+// compositeMask is like DrawMask, but supports every Op.
+func compositeMask(dst Image, r image.Rectangle, src image.Image, sp image.Point, mask image.Image, mp image.Point, op Op) {
+	// Clip r, sp and mp as the standard library's DrawMask does.
+	orig := r.Min
+	r = r.Intersect(dst.Bounds())
+	r = r.Intersect(src.Bounds().Add(orig.Sub(sp)))
+	if mask != nil {
+		r = r.Intersect(mask.Bounds().Add(orig.Sub(mp)))
+	}
+	if r.Empty() {
+		return
+	}
+	delta := r.Min.Sub(orig)
+	sp, mp = sp.Add(delta), mp.Add(delta)
+
+	out := &color.RGBA64{}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		sy, my := sp.Y+y-r.Min.Y, mp.Y+y-r.Min.Y
+		for x := r.Min.X; x < r.Max.X; x++ {
+			sx, mx := sp.X+x-r.Min.X, mp.X+x-r.Min.X
+			m := uint32(0xffff)
+			if mask != nil {
+				_, _, _, m = mask.At(mx, my).RGBA()
+				if m == 0 {
+					continue
+				}
+			}
+			sr, sg, sb, sa := src.At(sx, sy).RGBA()
+			dr, dg, db, da := dst.At(x, y).RGBA()
+			if isBlendOp(op) {
+				out.R = blendChannel(op, sr, dr, sa, da, m)
+				out.G = blendChannel(op, sg, dg, sa, da, m)
+				out.B = blendChannel(op, sb, db, sa, da, m)
+				out.A = composite(sa, da, 0xffff, 0xffff-sa, m)
+				dst.Set(x, y, out)
+				continue
+			}
+			fs, fd := porterDuff(op, sa, da)
+			out.R = composite(sr, dr, fs, fd, m)
+			out.G = composite(sg, dg, fs, fd, m)
+			out.B = composite(sb, db, fs, fd, m)
+			out.A = composite(sa, da, fs, fd, m)
+			dst.Set(x, y, out)
+		}
+	}
+}
+
+// composite returns s*fs + d*fd, weighted by m, plus d weighted by 1-m, where
+// all of the values are scaled to [0, 0xffff].
+func composite(s, d, fs, fd, m uint32) uint16 {
+	c := (uint64(s)*uint64(fs) + uint64(d)*uint64(fd)) / 0xffff
+	if c > 0xffff {
+		c = 0xffff
+	}
+	return uint16((c*uint64(m) + uint64(d)*uint64(0xffff-m)) / 0xffff)
+}
+
+// scaleComposite is like s.Scale, for the operators that the generated code
+// does not support. It scales src to a temporary image and then composites
+// that onto dst.
+func scaleComposite(s Scaler, dst Image, dr image.Rectangle, src image.Image, sr image.Rectangle, op Op, opts *Options) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	adr := dst.Bounds().Intersect(dr)
+	if adr.Empty() || sr.Empty() {
+		return
+	}
+	tmp := image.NewRGBA64(adr)
+	to := o
+	to.DstMask = nil
+	s.Scale(tmp, dr, src, sr, Src, &to)
+	compositeMask(dst, adr, tmp, adr.Min, o.DstMask, adr.Min.Add(o.DstMaskP), op)
+}
+
+// transformComposite is like t.Transform, for the operators that the
+// generated code does not support. It transforms src to a temporary image
+// and then composites that onto dst, limited to those dst pixels that
+// t.Transform would otherwise affect.
+func transformComposite(t Transformer, dst Image, s2d f64.Aff3, src image.Image, sr image.Rectangle, op Op, opts *Options) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	adr := dst.Bounds().Intersect(transformRect(&s2d, &sr))
+	if adr.Empty() || sr.Empty() {
+		return
+	}
+	tmp := image.NewRGBA64(adr)
+	to := o
+	to.DstMask = nil
+	t.Transform(tmp, s2d, src, sr, Src, &to)
+
+	// Transforming an opaque image marks the affected pixels, which are then
+	// masked by the dst mask, if any.
+	mask := image.NewAlpha(adr)
+	t.Transform(mask, s2d, image.Opaque, sr, Src, nil)
+	for y := adr.Min.Y; y < adr.Max.Y; y++ {
+		for x := adr.Min.X; x < adr.Max.X; x++ {
+			i := mask.PixOffset(x, y)
+			if mask.Pix[i] == 0 {
+				continue
+			}
+			mask.Pix[i] = 0xff
+			if o.DstMask != nil {
+				_, _, _, a := o.DstMask.At(o.DstMaskP.X+x, o.DstMaskP.Y+y).RGBA()
+				mask.Pix[i] = uint8(a >> 8)
+			}
+		}
+	}
+	compositeMask(dst, adr, tmp, adr.Min, mask, adr.Min, op)
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/math/f64"
+)
+
+func TestPorterDuffOps(t *testing.T) {
+	blue := image.NewUniform(color.RGBA{0x00, 0x00, 0xff, 0xff})
+	src := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	src.SetRGBA(0, 0, color.RGBA{0x7f, 0x00, 0x00, 0x7f})
+
+	testCases := []struct {
+		op   Op
+		want color.RGBA
+	}{
+		{Clear, color.RGBA{0x00, 0x00, 0x00, 0x00}},
+		{Dst, color.RGBA{0x00, 0x00, 0xff, 0xff}},
+		{In, color.RGBA{0x7f, 0x00, 0x00, 0x7f}},
+		{Out, color.RGBA{0x00, 0x00, 0x00, 0x00}},
+		{Atop, color.RGBA{0x7f, 0x00, 0x80, 0xff}},
+		{DstOver, color.RGBA{0x00, 0x00, 0xff, 0xff}},
+		{DstIn, color.RGBA{0x00, 0x00, 0x7f, 0x7f}},
+		{DstOut, color.RGBA{0x00, 0x00, 0x80, 0x80}},
+		{DstAtop, color.RGBA{0x00, 0x00, 0x7f, 0x7f}},
+		{Xor, color.RGBA{0x00, 0x00, 0x80, 0x80}},
+	}
+	draws := map[string]func(dst *image.RGBA, op Op){
+		"Draw": func(dst *image.RGBA, op Op) {
+			Draw(dst, dst.Bounds(), image.NewUniform(src.RGBAAt(0, 0)), image.Point{}, op)
+		},
+		"Scale": func(dst *image.RGBA, op Op) {
+			NearestNeighbor.Scale(dst, dst.Bounds(), src, src.Bounds(), op, nil)
+		},
+		"Transform": func(dst *image.RGBA, op Op) {
+			ApproxBiLinear.Transform(dst, f64.Aff3{2, 0, 0, 0, 2, 0}, src, src.Bounds(), op, nil)
+		},
+	}
+	for name, draw := range draws {
+		for _, tc := range testCases {
+			dst := image.NewRGBA(image.Rect(0, 0, 2, 2))
+			Copy(dst, image.Point{}, blue, dst.Bounds(), Src, nil)
+			draw(dst, tc.op)
+			for y := 0; y < 2; y++ {
+				for x := 0; x < 2; x++ {
+					if got := dst.RGBAAt(x, y); got != tc.want {
+						t.Errorf("%s: op=%v, (%d, %d): got %v, want %v", name, tc.op, x, y, got, tc.want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPorterDuffMask(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	Copy(dst, image.Point{}, image.NewUniform(color.RGBA{0x00, 0x00, 0xff, 0xff}), dst.Bounds(), Src, nil)
+	mask := image.NewAlpha(image.Rect(0, 0, 2, 1))
+	mask.SetAlpha(0, 0, color.Alpha{0x80})
+	DrawMask(dst, dst.Bounds(), image.Transparent, image.Point{}, mask, image.Point{}, Clear)
+
+	want := []color.RGBA{
+		{0x00, 0x00, 0x7f, 0x7f},
+		{0x00, 0x00, 0xff, 0xff},
+	}
+	for x, w := range want {
+		if got := dst.RGBAAt(x, 0); got != w {
+			t.Errorf("x=%d: got %v, want %v", x, got, w)
+		}
+	}
+}
+
+// TestOpDraw tests the Op.Draw method, which is the standard library's, and
+// so only supports Over and Src. The Draw function supports every Op.
+func TestOpDraw(t *testing.T) {
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	src := image.NewUniform(color.RGBA{0x7f, 0x00, 0x00, 0x7f})
+	testCases := []struct {
+		op                 Op
+		wantFunc, wantMeth color.RGBA
+	}{
+		{Over, color.RGBA{0xff, 0x80, 0x80, 0xff}, color.RGBA{0xff, 0x80, 0x80, 0xff}},
+		{Src, color.RGBA{0x7f, 0x00, 0x00, 0x7f}, color.RGBA{0x7f, 0x00, 0x00, 0x7f}},
+		{Clear, color.RGBA{}, color.RGBA{0x7f, 0x00, 0x00, 0x7f}},
+		{Multiply, color.RGBA{0xff, 0x80, 0x80, 0xff}, color.RGBA{0x7f, 0x00, 0x00, 0x7f}},
+	}
+	for _, tc := range testCases {
+		dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetRGBA(0, 0, white)
+		Draw(dst, dst.Bounds(), src, image.Point{}, tc.op)
+		if got := dst.RGBAAt(0, 0); got != tc.wantFunc {
+			t.Errorf("op=%v: Draw: got %v, want %v", tc.op, got, tc.wantFunc)
+		}
+
+		dst.SetRGBA(0, 0, white)
+		tc.op.Draw(dst, dst.Bounds(), src, image.Point{})
+		if got := dst.RGBAAt(0, 0); got != tc.wantMeth {
+			t.Errorf("op=%v: Op.Draw: got %v, want %v", tc.op, got, tc.wantMeth)
+		}
+	}
+}
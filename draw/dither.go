@@ -0,0 +1,253 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/math/f64"
+)
+
+// Dither is a method of choosing the colors of an *image.Paletted dst image.
+type Dither int
+
+const (
+	// NoDither chooses the palette color nearest to each pixel's color.
+	NoDither Dither = iota
+	// FloydSteinbergDither spreads each pixel's quantization error over the
+	// four neighboring pixels to its right and below it.
+	FloydSteinbergDither
+	// AtkinsonDither spreads three quarters of each pixel's quantization
+	// error over the six nearby pixels to its right and below it. It gives
+	// more contrast than FloydSteinbergDither, at the cost of detail in the
+	// lightest and darkest areas, and suits small palettes, such as for
+	// black and white images.
+	AtkinsonDither
+	// BayerDither adds an 8x8 pattern of offsets, an ordered dither matrix,
+	// to the pixels' colors before choosing the nearest palette colors. Unlike
+	// error diffusion, each pixel's color depends only on its own position
+	// and the src, so that the results are deterministic, tileable and
+	// stable from one video frame to the next. It assumes that the palette's
+	// colors are roughly evenly spaced, as for a color cube or grayscale
+	// ramp.
+	BayerDither
+)
+
+// ditherKernel is an error diffusion kernel. Each pixel's quantization error,
+// weighted by weight/div, is added to the pixel dx to the right of and dy
+// below it, for each of the kernel's terms.
+type ditherKernel struct {
+	div   int32
+	terms []ditherTerm
+}
+
+type ditherTerm struct {
+	dx, dy, weight int32
+}
+
+var ditherKernels = [...]ditherKernel{
+	FloydSteinbergDither: {16, []ditherTerm{
+		{+1, 0, 7},
+		{-1, 1, 3}, {0, 1, 5}, {+1, 1, 1},
+	}},
+	AtkinsonDither: {8, []ditherTerm{
+		{+1, 0, 1}, {+2, 0, 1},
+		{-1, 1, 1}, {0, 1, 1}, {+1, 1, 1},
+		{0, 2, 1},
+	}},
+}
+
+// ditherDst returns dst as an *image.Paletted if opts asks for it to be
+// dithered, or nil otherwise.
+func ditherDst(dst Image, opts *Options) *image.Paletted {
+	if opts == nil || opts.Dither == NoDither {
+		return nil
+	}
+	p, _ := dst.(*image.Paletted)
+	return p
+}
+
+// scaleDither is like s.Scale, when dst is to be dithered.
+func scaleDither(s Scaler, dst *image.Paletted, dr image.Rectangle, src image.Image, sr image.Rectangle, op Op, opts *Options) {
+	adr := dst.Bounds().Intersect(dr)
+	if adr.Empty() || sr.Empty() {
+		return
+	}
+	drawDither(dst, adr, src, op, opts, func(dst Image, src image.Image, op Op, opts *Options) {
+		s.Scale(dst, dr, src, sr, op, opts)
+	})
+}
+
+// transformDither is like t.Transform, when dst is to be dithered.
+func transformDither(t Transformer, dst *image.Paletted, s2d f64.Aff3, src image.Image, sr image.Rectangle, op Op, opts *Options) {
+	adr := dst.Bounds().Intersect(transformRect(&s2d, &sr))
+	if adr.Empty() || sr.Empty() {
+		return
+	}
+	drawDither(dst, adr, src, op, opts, func(dst Image, src image.Image, op Op, opts *Options) {
+		t.Transform(dst, s2d, src, sr, op, opts)
+	})
+}
+
+// drawDither calls f to draw src to a full color copy of the adr part of dst,
+// and then dithers that copy back to dst. It also calls f to draw an opaque
+// image, so that only the dst pixels that f affects are changed.
+func drawDither(dst *image.Paletted, adr image.Rectangle, src image.Image, op Op, opts *Options, f func(Image, image.Image, Op, *Options)) {
+	o := *opts
+	o.Dither = NoDither
+
+	tmp := image.NewRGBA64(adr)
+	Draw(tmp, adr, dst, adr.Min, Src)
+	f(tmp, src, op, &o)
+
+	co := o
+	co.SrcMask = nil
+	co.LinearLight = false
+	cover := image.NewAlpha(adr)
+	f(cover, image.Opaque, Src, &co)
+
+	if opts.Dither == BayerDither {
+		orderedDither(dst, adr, tmp, cover)
+	} else {
+		diffuseError(dst, adr, tmp, cover, &ditherKernels[opts.Dither])
+	}
+}
+
+// paletteValues returns the alpha-premultiplied values of p's colors.
+func paletteValues(p color.Palette) [][4]int32 {
+	pal := make([][4]int32, len(p))
+	for i, c := range p {
+		cr, cg, cb, ca := c.RGBA()
+		pal[i] = [4]int32{int32(cr), int32(cg), int32(cb), int32(ca)}
+	}
+	return pal
+}
+
+// nearest returns the index of the palette value nearest to v.
+func nearest(pal [][4]int32, v [4]int32) int {
+	best, bestSum := 0, int64(1<<63-1)
+	for i, p := range pal {
+		sum := int64(0)
+		for j := range v {
+			d := int64(v[j] - p[j])
+			sum += d * d
+		}
+		if sum < bestSum {
+			best, bestSum = i, sum
+			if sum == 0 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// diffuseError sets the pixels of dst in r, that are not transparent in
+// cover, to the palette colors nearest to those of src, adjusted by the
+// quantization errors diffused from the previous pixels.
+func diffuseError(dst *image.Paletted, r image.Rectangle, src *image.RGBA64, cover *image.Alpha, k *ditherKernel) {
+	if len(dst.Palette) == 0 {
+		return
+	}
+	pal := paletteValues(dst.Palette)
+
+	// errs[dy] holds the weighted quantization errors to add to the row dy
+	// below the current one. The slices are padded by two elements on each
+	// side, so that no kernel term diffuses error out of bounds.
+	const pad = 2
+	w := r.Dx()
+	errs := make([][][4]int32, 3)
+	for i := range errs {
+		errs[i] = make([][4]int32, w+2*pad)
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if cover.AlphaAt(x, y).A == 0 {
+				continue
+			}
+			i := x - r.Min.X + pad
+			c := src.RGBA64At(x, y)
+			v := [4]int32{int32(c.R), int32(c.G), int32(c.B), int32(c.A)}
+			for j := range v {
+				v[j] += errs[0][i][j] / k.div
+				if v[j] < 0 {
+					v[j] = 0
+				} else if v[j] > 0xffff {
+					v[j] = 0xffff
+				}
+			}
+
+			best := nearest(pal, v)
+			dst.Pix[dst.PixOffset(x, y)] = uint8(best)
+
+			for _, t := range k.terms {
+				e := &errs[t.dy][i+int(t.dx)]
+				for j := range v {
+					e[j] += (v[j] - pal[best][j]) * t.weight
+				}
+			}
+		}
+
+		errs[0], errs[1], errs[2] = errs[1], errs[2], errs[0]
+		for i := range errs[2] {
+			errs[2][i] = [4]int32{}
+		}
+	}
+}
+
+// bayer8 is the 8x8 Bayer ordered dither matrix.
+var bayer8 = [8][8]int32{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// orderedDither sets the pixels of dst in r, that are not transparent in
+// cover, to the palette colors nearest to those of src, offset by the Bayer
+// matrix entry for each pixel's position.
+func orderedDither(dst *image.Paletted, r image.Rectangle, src *image.RGBA64, cover *image.Alpha) {
+	if len(dst.Palette) == 0 {
+		return
+	}
+	pal := paletteValues(dst.Palette)
+
+	// The offsets span the gap between adjacent palette colors, estimating
+	// the palette as a color cube with levels levels per channel.
+	levels := int32(math.Cbrt(float64(len(pal))) + 0.5)
+	if levels < 2 {
+		levels = 2
+	}
+	spread := 0xffff / (levels - 1)
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		row := &bayer8[y&7]
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if cover.AlphaAt(x, y).A == 0 {
+				continue
+			}
+			// The offset is in the range (-spread/2, +spread/2).
+			offset := (2*row[x&7] + 1 - 64) * spread / 128
+			c := src.RGBA64At(x, y)
+			v := [4]int32{int32(c.R), int32(c.G), int32(c.B), int32(c.A)}
+			for j := 0; j < 3; j++ {
+				v[j] += offset
+				if v[j] < 0 {
+					v[j] = 0
+				} else if v[j] > 0xffff {
+					v[j] = 0xffff
+				}
+			}
+			dst.Pix[dst.PixOffset(x, y)] = uint8(nearest(pal, v))
+		}
+	}
+}
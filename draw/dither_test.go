@@ -0,0 +1,87 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"testing"
+
+	"golang.org/x/image/math/f64"
+)
+
+func TestDither(t *testing.T) {
+	gray := image.NewUniform(color.Gray{0x80})
+	sr := image.Rect(0, 0, 4, 4)
+	testCases := []struct {
+		dither   Dither
+		min, max int
+	}{
+		{NoDither, 0, 0},
+		{FloydSteinbergDither, 450, 574},
+		{AtkinsonDither, 1, 1023},
+		{BayerDither, 512, 512},
+	}
+	for _, tc := range testCases {
+		for _, q := range []Interpolator{NearestNeighbor, ApproxBiLinear, CatmullRom} {
+			dst := image.NewPaletted(image.Rect(0, 0, 32, 32), color.Palette{color.White, color.Black})
+			q.Scale(dst, dst.Bounds(), gray, sr, Src, &Options{Dither: tc.dither})
+			n := 0
+			for _, p := range dst.Pix {
+				if p == 1 {
+					n++
+				}
+			}
+			if n < tc.min || n > tc.max {
+				t.Errorf("dither=%d, q=%T: %d black pixels, want in [%d, %d]", tc.dither, q, n, tc.min, tc.max)
+			}
+		}
+	}
+}
+
+// TestDitherCover tests that dithering only changes those dst pixels that
+// would otherwise be changed.
+func TestDitherCover(t *testing.T) {
+	dst := image.NewPaletted(image.Rect(0, 0, 16, 16), color.Palette{color.White, color.Black, color.Transparent})
+	for i := range dst.Pix {
+		dst.Pix[i] = 2
+	}
+	gray := image.NewUniform(color.Gray{0x80})
+	opts := &Options{
+		DstMask: image.Rect(0, 0, 16, 8),
+		Dither:  FloydSteinbergDither,
+	}
+	ApproxBiLinear.Transform(dst, f64.Aff3{1, 0, 4, 0, 1, 4}, gray, image.Rect(0, 0, 8, 8), Src, opts)
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			inside := 4 <= x && x < 12 && 4 <= y && y < 8
+			if got := dst.ColorIndexAt(x, y); (got != 2) != inside {
+				t.Errorf("(%d, %d): got index %d, inside=%t", x, y, got, inside)
+			}
+		}
+	}
+}
+
+// TestBayerDitherTiles tests that ordered dithering gives the same result
+// for every 8x8 tile of a uniform src.
+func TestBayerDitherTiles(t *testing.T) {
+	dst := image.NewPaletted(image.Rect(-8, -8, 24, 24), palette.WebSafe)
+	src := image.NewUniform(color.RGBA{0x20, 0x60, 0xa0, 0xff})
+	CatmullRom.Scale(dst, dst.Bounds(), src, image.Rect(0, 0, 3, 3), Src, &Options{Dither: BayerDither})
+	seen := map[uint8]bool{}
+	for y := -8; y < 24; y++ {
+		for x := -8; x < 24; x++ {
+			got, want := dst.ColorIndexAt(x, y), dst.ColorIndexAt(x&7, y&7)
+			if got != want {
+				t.Fatalf("(%d, %d): got index %d, want %d", x, y, got, want)
+			}
+			seen[got] = true
+		}
+	}
+	if len(seen) < 2 {
+		t.Errorf("got %d distinct colors, want at least 2", len(seen))
+	}
+}
@@ -22,6 +22,10 @@ import (
 
 // Draw calls DrawMask with a nil mask.
 func Draw(dst Image, r image.Rectangle, src image.Image, sp image.Point, op Op) {
+	if !isStdOp(op) {
+		compositeMask(dst, r, src, sp, nil, image.Point{}, op)
+		return
+	}
 	draw.Draw(dst, r, src, sp, draw.Op(op))
 }
 
@@ -29,6 +33,10 @@ func Draw(dst Image, r image.Rectangle, src image.Image, sp image.Point, op Op)
 // replaces the rectangle r in dst with the result of a Porter-Duff
 // composition. A nil mask is treated as opaque.
 func DrawMask(dst Image, r image.Rectangle, src image.Image, sp image.Point, mask image.Image, mp image.Point, op Op) {
+	if !isStdOp(op) {
+		compositeMask(dst, r, src, sp, mask, mp, op)
+		return
+	}
 	draw.DrawMask(dst, r, src, sp, mask, mp, draw.Op(op))
 }
 
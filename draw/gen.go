@@ -59,9 +59,14 @@ var (
 	dsTypes = []struct{ dType, sType string }{
 		{"*image.RGBA", "*image.Gray"},
 		{"*image.RGBA", "*image.NRGBA"},
+		{"*image.RGBA", "*image.NRGBA64"},
 		{"*image.RGBA", "*image.RGBA"},
+		{"*image.RGBA", "*image.RGBA64"},
 		{"*image.RGBA", "*image.YCbCr"},
 		{"*image.RGBA", "image.Image"},
+		{"*image.RGBA64", "*image.NRGBA64"},
+		{"*image.RGBA64", "*image.RGBA64"},
+		{"*image.RGBA64", "image.Image"},
 		{"Image", "image.Image"},
 	}
 	dTypes, sTypes  []string
@@ -71,6 +76,8 @@ var (
 		"422",
 		"420",
 		"440",
+		"411",
+		"410",
 	}
 	ops = []string{"Over", "Src"}
 	// alwaysOpaque are those image.Image implementations that are always
@@ -248,6 +255,8 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 			return ";"
 		case "*image.RGBA":
 			return "d := " + pixOffset("dst", "dr.Min.X+adr.Min.X", "dr.Min.Y+int(dy)", "*4", "*dst.Stride")
+		case "*image.RGBA64":
+			return "d := " + pixOffset("dst", "dr.Min.X+adr.Min.X", "dr.Min.Y+int(dy)", "*8", "*dst.Stride")
 		}
 
 	case "preKernelOuter":
@@ -264,6 +273,8 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 			return ";"
 		case "*image.RGBA":
 			return "d := " + pixOffset("dst", "dr.Min.X+int(dx)", "dr.Min.Y+adr.Min.Y", "*4", "*dst.Stride")
+		case "*image.RGBA64":
+			return "d := " + pixOffset("dst", "dr.Min.X+int(dx)", "dr.Min.Y+adr.Min.Y", "*8", "*dst.Stride")
 		}
 
 	case "blend":
@@ -371,6 +382,19 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 					"dst.Pix[d+2] = uint8((uint32(dst.Pix[d+2])*$2a1/0xffff + $2b) >> 8)\n"+
 					"dst.Pix[d+3] = uint8((uint32(dst.Pix[d+3])*$2a1/0xffff + $2a) >> 8)",
 				)
+			case "*image.RGBA64":
+				return argf(args, ""+
+					"$2a1 := 0xffff - $2a\n"+
+					"qr := uint32(dst.Pix[d+0])<<8 | uint32(dst.Pix[d+1])\n"+
+					"qg := uint32(dst.Pix[d+2])<<8 | uint32(dst.Pix[d+3])\n"+
+					"qb := uint32(dst.Pix[d+4])<<8 | uint32(dst.Pix[d+5])\n"+
+					"qa := uint32(dst.Pix[d+6])<<8 | uint32(dst.Pix[d+7])\n"+
+					"qr = qr*$2a1/0xffff + $2r\n"+
+					"qg = qg*$2a1/0xffff + $2g\n"+
+					"qb = qb*$2a1/0xffff + $2b\n"+
+					"qa = qa*$2a1/0xffff + $2a\n"+
+					rgba64Pix("q", ""),
+				)
 			}
 
 		case "Src":
@@ -425,6 +449,8 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 						"dst.Pix[d+3] = 0xff",
 					)
 				}
+			case "*image.RGBA64":
+				return argf(args, rgba64Pix("$2", ""))
 			}
 		}
 
@@ -473,6 +499,23 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 					"dst.Pix[d+2] = uint8((uint32(dst.Pix[d+2])*$3a1/0xffff + $3b0) >> 8)\n"+
 					"dst.Pix[d+3] = uint8((uint32(dst.Pix[d+3])*$3a1/0xffff + $3a0) >> 8)",
 				)
+			case "*image.RGBA64":
+				ret = argf(args, ""+
+					"$3r0 := uint32($2($3r * $4))\n"+
+					"$3g0 := uint32($2($3g * $4))\n"+
+					"$3b0 := uint32($2($3b * $4))\n"+
+					"$3a0 := uint32($2($3a * $4))\n"+
+					"$3a1 := 0xffff - $3a0\n"+
+					"qr := uint32(dst.Pix[d+0])<<8 | uint32(dst.Pix[d+1])\n"+
+					"qg := uint32(dst.Pix[d+2])<<8 | uint32(dst.Pix[d+3])\n"+
+					"qb := uint32(dst.Pix[d+4])<<8 | uint32(dst.Pix[d+5])\n"+
+					"qa := uint32(dst.Pix[d+6])<<8 | uint32(dst.Pix[d+7])\n"+
+					"qr = qr*$3a1/0xffff + $3r0\n"+
+					"qg = qg*$3a1/0xffff + $3g0\n"+
+					"qb = qb*$3a1/0xffff + $3b0\n"+
+					"qa = qa*$3a1/0xffff + $3a0\n"+
+					rgba64Pix("q", ""),
+				)
 			}
 
 		case "Src":
@@ -527,6 +570,14 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 						"dst.Pix[d+3] = 0xff",
 					)
 				}
+			case "*image.RGBA64":
+				ret = argf(args, ""+
+					"$3r0 := $2($3r * $4)\n"+
+					"$3g0 := $2($3g * $4)\n"+
+					"$3b0 := $2($3b * $4)\n"+
+					"$3a0 := $2($3a * $4)\n"+
+					rgba64Pix("$3", "0"),
+				)
 			}
 		}
 
@@ -595,6 +646,19 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 				lhs, tmp, lhs, lhs, tmp,
 				lhs, tmp, lhs, lhs, tmp,
 			)
+		case "*image.NRGBA64":
+			fmt.Fprintf(buf, ""+
+				"%si := %s\n"+
+				"%sa%s := uint32(src.Pix[%si+6])<<8 | uint32(src.Pix[%si+7])\n"+
+				"%sr%s := (uint32(src.Pix[%si+0])<<8 | uint32(src.Pix[%si+1])) * %sa%s / 0xffff\n"+
+				"%sg%s := (uint32(src.Pix[%si+2])<<8 | uint32(src.Pix[%si+3])) * %sa%s / 0xffff\n"+
+				"%sb%s := (uint32(src.Pix[%si+4])<<8 | uint32(src.Pix[%si+5])) * %sa%s / 0xffff\n",
+				lhs, pixOffset("src", args[0], args[1], "*8", "*src.Stride"),
+				lhs, tmp, lhs, lhs,
+				lhs, tmp, lhs, lhs, lhs, tmp,
+				lhs, tmp, lhs, lhs, lhs, tmp,
+				lhs, tmp, lhs, lhs, lhs, tmp,
+			)
 		case "*image.RGBA":
 			fmt.Fprintf(buf, ""+
 				"%si := %s\n"+
@@ -608,6 +672,19 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 				lhs, tmp, lhs,
 				lhs, tmp, lhs,
 			)
+		case "*image.RGBA64":
+			fmt.Fprintf(buf, ""+
+				"%si := %s\n"+
+				"%sr%s := uint32(src.Pix[%si+0])<<8 | uint32(src.Pix[%si+1])\n"+
+				"%sg%s := uint32(src.Pix[%si+2])<<8 | uint32(src.Pix[%si+3])\n"+
+				"%sb%s := uint32(src.Pix[%si+4])<<8 | uint32(src.Pix[%si+5])\n"+
+				"%sa%s := uint32(src.Pix[%si+6])<<8 | uint32(src.Pix[%si+7])\n",
+				lhs, pixOffset("src", args[0], args[1], "*8", "*src.Stride"),
+				lhs, tmp, lhs, lhs,
+				lhs, tmp, lhs, lhs,
+				lhs, tmp, lhs, lhs,
+				lhs, tmp, lhs, lhs,
+			)
 		case "*image.YCbCr":
 			fmt.Fprintf(buf, ""+
 				"%si := %s\n"+
@@ -652,19 +729,22 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 		return strings.TrimSpace(buf.String())
 
 	case "tweakD":
-		if d.dType == "*image.RGBA" {
+		if d.dType == "*image.RGBA" || d.dType == "*image.RGBA64" {
 			return "d += dst.Stride"
 		}
 		return ";"
 
 	case "tweakDx":
-		if d.dType == "*image.RGBA" {
+		switch d.dType {
+		case "*image.RGBA":
 			return strings.Replace(prefix, "dx++", "dx, d = dx+1, d+4", 1)
+		case "*image.RGBA64":
+			return strings.Replace(prefix, "dx++", "dx, d = dx+1, d+8", 1)
 		}
 		return prefix
 
 	case "tweakDy":
-		if d.dType == "*image.RGBA" {
+		if d.dType == "*image.RGBA" || d.dType == "*image.RGBA64" {
 			return strings.Replace(prefix, "for dy, s", "for _, s", 1)
 		}
 		return prefix
@@ -683,6 +763,29 @@ func expnDollar(prefix, dollar, suffix string, d *data) string {
 		}
 		return prefix
 
+	case "simdBeginX":
+		accumulate := ""
+		switch d.sType {
+		case "*image.NRGBA":
+			accumulate = "accumulateNRGBASIMD"
+		case "*image.RGBA":
+			accumulate = "accumulateRGBASIMD"
+		default:
+			return ";"
+		}
+		return "if haveAccumulateSIMD {\n" +
+			"pi := " + pixOffset("src", "sr.Min.X", "sr.Min.Y+int(y)", "*4", "*src.Stride") + "\n" +
+			"var acc [4]float64\n" +
+			accumulate + "(&acc, src.Pix[pi:pi+int(z.sw)*4], z.horizontal.contribs[s.i:s.j])\n" +
+			"pr, pg, pb, pa = acc[0], acc[1], acc[2], acc[3]\n" +
+			"} else {"
+
+	case "simdEndX":
+		if d.sType != "*image.NRGBA" && d.sType != "*image.RGBA" {
+			return ";"
+		}
+		return "}"
+
 	case "tweakPr":
 		if d.sType == "*image.Gray" {
 			return "pr *= s.invTotalWeightFFFF"
@@ -772,6 +875,24 @@ func expnSwitchYCbCr(op, dType, template string) string {
 	return strings.Join(lines, "\n")
 }
 
+// rgba64Pix returns code to store the 16-bit values of the lhs-prefixed and
+// tmp-suffixed r, g, b and a variables in the *image.RGBA64 dst's Pix at
+// offset d.
+func rgba64Pix(lhs, tmp string) string {
+	s := "" +
+		"dst.Pix[d+0] = uint8($r@ >> 8)\n" +
+		"dst.Pix[d+1] = uint8($r@)\n" +
+		"dst.Pix[d+2] = uint8($g@ >> 8)\n" +
+		"dst.Pix[d+3] = uint8($g@)\n" +
+		"dst.Pix[d+4] = uint8($b@ >> 8)\n" +
+		"dst.Pix[d+5] = uint8($b@)\n" +
+		"dst.Pix[d+6] = uint8($a@ >> 8)\n" +
+		"dst.Pix[d+7] = uint8($a@)"
+	s = strings.Replace(s, "$", lhs, -1)
+	s = strings.Replace(s, "@", tmp, -1)
+	return s
+}
+
 func argf(args []string, s string) string {
 	if len(args) > 9 {
 		panic("too many args")
@@ -797,6 +918,10 @@ func cOffset(x, y, sratio string) string {
 		return fmt.Sprintf("((%s)/2 - src.Rect.Min.Y/2)*src.CStride + ((%s)/2 - src.Rect.Min.X/2)", y, x)
 	case "440":
 		return fmt.Sprintf("((%s)/2 - src.Rect.Min.Y/2)*src.CStride + ( %s    - src.Rect.Min.X  )", y, x)
+	case "411":
+		return fmt.Sprintf("( %s    - src.Rect.Min.Y  )*src.CStride + ((%s)/4 - src.Rect.Min.X/4)", y, x)
+	case "410":
+		return fmt.Sprintf("((%s)/2 - src.Rect.Min.Y/2)*src.CStride + ((%s)/4 - src.Rect.Min.X/4)", y, x)
 	}
 	return fmt.Sprintf("unsupported sratio %q", sratio)
 }
@@ -877,8 +1002,18 @@ func relName(s string) string {
 const (
 	codeRoot = `
 		func (z $receiver) Scale(dst Image, dr image.Rectangle, src image.Image, sr image.Rectangle, op Op, opts *Options) {
-			// Try to simplify a Scale to a Copy.
-			if dr.Size() == sr.Size() {
+			if p := ditherDst(dst, opts); p != nil {
+				scaleDither(z, p, dr, src, sr, op, opts)
+				return
+			}
+			if !isStdOp(op) {
+				scaleComposite(z, dst, dr, src, sr, op, opts)
+				return
+			}
+
+			// Try to simplify a Scale to a Copy. A Copy with a DstMask calls
+			// back into Scale, so only do so without one.
+			if dr.Size() == sr.Size() && (opts == nil || opts.DstMask == nil) {
 				Copy(dst, dr.Min, src, sr, op, opts)
 				return
 			}
@@ -920,8 +1055,18 @@ const (
 		}
 
 		func (z $receiver) Transform(dst Image, s2d f64.Aff3, src image.Image, sr image.Rectangle, op Op, opts *Options) {
-			// Try to simplify a Transform to a Copy.
-			if s2d[0] == 1 && s2d[1] == 0 && s2d[3] == 0 && s2d[4] == 1 {
+			if p := ditherDst(dst, opts); p != nil {
+				transformDither(z, p, s2d, src, sr, op, opts)
+				return
+			}
+			if !isStdOp(op) {
+				transformComposite(z, dst, s2d, src, sr, op, opts)
+				return
+			}
+
+			// Try to simplify a Transform to a Copy. A Copy with a DstMask
+			// calls back into Scale, so only do so without one.
+			if s2d[0] == 1 && s2d[1] == 0 && s2d[3] == 0 && s2d[4] == 1 && (opts == nil || opts.DstMask == nil) {
 				dx := int(s2d[2])
 				dy := int(s2d[5])
 				if float64(dx) == s2d[2] && float64(dy) == s2d[5] {
@@ -1137,6 +1282,14 @@ const (
 				z.kernel.Scale(dst, dr, src, sr, op, opts)
 				return
 			}
+			if p := ditherDst(dst, opts); p != nil {
+				scaleDither(z, p, dr, src, sr, op, opts)
+				return
+			}
+			if !isStdOp(op) {
+				scaleComposite(z, dst, dr, src, sr, op, opts)
+				return
+			}
 
 			var o Options
 			if opts != nil {
@@ -1160,6 +1313,11 @@ const (
 				return
 			}
 
+			if o.LinearLight {
+				z.scaleLinear(dst, dr, adr.Add(dr.Min), src, sr, op, &o)
+				return
+			}
+
 			// Create a temporary buffer:
 			// scaleX distributes the source image's columns over the temporary image.
 			// scaleY distributes the temporary image's rows over the destination image.
@@ -1196,6 +1354,15 @@ const (
 		}
 
 		func (q *Kernel) Transform(dst Image, s2d f64.Aff3, src image.Image, sr image.Rectangle, op Op, opts *Options) {
+			if p := ditherDst(dst, opts); p != nil {
+				transformDither(q, p, s2d, src, sr, op, opts)
+				return
+			}
+			if !isStdOp(op) {
+				transformComposite(q, dst, s2d, src, sr, op, opts)
+				return
+			}
+
 			var o Options
 			if opts != nil {
 				o = *opts
@@ -1266,9 +1433,11 @@ const (
 			for y := int32(0); y < z.sh; y++ {
 				for _, s := range z.horizontal.sources {
 					var pr, pg, pb, pa float64 $tweakVarP
+					$simdBeginX
 					for _, c := range z.horizontal.contribs[s.i:s.j] {
 						p += $srcf[sr.Min.X + int(c.coord), sr.Min.Y + int(y)] * c.weight
 					}
+					$simdEndX
 					$tweakPr
 					tmp[t] = [4]float64{
 						pr * s.invTotalWeightFFFF, $tweakP
@@ -1289,12 +1458,18 @@ const (
 				$preKernelInner
 				for dy, s := range z.vertical.sources[adr.Min.Y:adr.Max.Y] { $tweakDy
 					var pr, pg, pb, pa float64
-					for _, c := range z.vertical.contribs[s.i:s.j] {
-						p := &tmp[c.coord*z.dw+dx]
-						pr += p[0] * c.weight
-						pg += p[1] * c.weight
-						pb += p[2] * c.weight
-						pa += p[3] * c.weight
+					if haveAccumulateSIMD {
+						var acc [4]float64
+						accumulateTmpSIMD(&acc, tmp[dx:], z.vertical.contribs[s.i:s.j], int(z.dw))
+						pr, pg, pb, pa = acc[0], acc[1], acc[2], acc[3]
+					} else {
+						for _, c := range z.vertical.contribs[s.i:s.j] {
+							p := &tmp[c.coord*z.dw+dx]
+							pr += p[0] * c.weight
+							pg += p[1] * c.weight
+							pb += p[2] * c.weight
+							pa += p[3] * c.weight
+						}
 					}
 					$clampToAlpha
 					$outputf[dr.Min.X + int(dx), dr.Min.Y + int(adr.Min.Y + dy), ftou, p, s.invTotalWeight]
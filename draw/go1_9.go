@@ -44,6 +44,10 @@ type Drawer = draw.Drawer
 type Image = draw.Image
 
 // Op is a Porter-Duff compositing operator.
+//
+// Op.Draw is the standard library's method, and only supports Over and Src.
+// For this package's other operators, such as Clear and Multiply, call the
+// Draw function instead.
 type Op = draw.Op
 
 const (
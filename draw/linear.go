@@ -0,0 +1,180 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"math"
+	"sync"
+)
+
+var (
+	linearOnce sync.Once
+	// toLinear and fromLinear sample the conversions from sRGB encoded
+	// values to linear light ones, and back, at 4096 evenly spaced values
+	// from 0 to 0xffff, inclusive. Values between those samples are linearly
+	// interpolated, by lookupLinear, to give 16 bits of precision.
+	toLinear   [linearSamples + 1]uint16
+	fromLinear [linearSamples + 1]uint16
+)
+
+const linearSamples = 4096
+
+func initLinear() {
+	for i := range toLinear {
+		v := float64(i) / linearSamples
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		toLinear[i] = uint16(v*0xffff + 0.5)
+	}
+	for i := range fromLinear {
+		v := float64(i) / linearSamples
+		if v <= 0.0031308 {
+			v *= 12.92
+		} else {
+			v = 1.055*math.Pow(v, 1/2.4) - 0.055
+		}
+		fromLinear[i] = uint16(v*0xffff + 0.5)
+	}
+}
+
+// lookupLinear returns the value of the conversion sampled by t, toLinear or
+// fromLinear, at the 16-bit value c.
+func lookupLinear(t *[linearSamples + 1]uint16, c uint32) uint32 {
+	// p is c's position in t, in units of 1/16th of a sample.
+	p := c * (16 * linearSamples) / 0xffff
+	i, f := p/16, p%16
+	if f == 0 {
+		return uint32(t[i])
+	}
+	return (uint32(t[i])*(16-f) + uint32(t[i+1])*f + 8) / 16
+}
+
+// scaleLinear is like Scale, but blends the source pixels in linear light.
+// adr is the affected destination pixels, in dst space, and o is the options,
+// already adjusted as Scale does.
+func (z *kernelScaler) scaleLinear(dst Image, dr, adr image.Rectangle, src image.Image, sr image.Rectangle, op Op, o *Options) {
+	linearOnce.Do(initLinear)
+
+	// Only the source pixels that contribute to adr are read. sub scales
+	// just those pixels, in the lsrc image, to adr, with the same weights as
+	// z would.
+	dx0, dx1 := adr.Min.X-dr.Min.X, adr.Max.X-dr.Min.X
+	dy0, dy1 := adr.Min.Y-dr.Min.Y, adr.Max.Y-dr.Min.Y
+	x0, x1 := z.horizontal.span(dx0, dx1)
+	y0, y1 := z.vertical.span(dy0, dy1)
+	sub := &kernelScaler{
+		kernel:     z.kernel,
+		dw:         int32(adr.Dx()),
+		dh:         int32(adr.Dy()),
+		sw:         x1 - x0,
+		sh:         y1 - y0,
+		horizontal: z.horizontal.sub(dx0, dx1, x0),
+		vertical:   z.vertical.sub(dy0, dy1, y0),
+	}
+	need := image.Rect(int(x0), int(y0), int(x1), int(y1)).Add(sr.Min)
+
+	// Convert the source pixels to linear light. Pixels outside of the src
+	// image are transparent, as for the other paths.
+	lsrc := image.NewRGBA64(need)
+	in := need.Intersect(src.Bounds())
+	for y := in.Min.Y; y < in.Max.Y; y++ {
+		for x := in.Min.X; x < in.Max.X; x++ {
+			r, g, b, a := src.At(x, y).RGBA()
+			if a == 0 {
+				continue
+			}
+			i := lsrc.PixOffset(x, y)
+			setRGBA64(lsrc.Pix[i:i+8], linearize(r, a), linearize(g, a), linearize(b, a), a)
+		}
+	}
+
+	// Scale in linear light, without the dst mask, which is applied when
+	// compositing the result onto dst.
+	ldst := image.NewRGBA64(adr)
+	lo := *o
+	lo.DstMask, lo.LinearLight = nil, false
+	sub.Scale(ldst, adr, lsrc, need, Src, &lo)
+
+	// Convert the result back to sRGB, in place, and composite it onto dst.
+	for i := 0; i < len(ldst.Pix); i += 8 {
+		p := ldst.Pix[i : i+8]
+		a := uint32(p[6])<<8 | uint32(p[7])
+		if a == 0 {
+			continue
+		}
+		setRGBA64(p,
+			delinearize(uint32(p[0])<<8|uint32(p[1]), a),
+			delinearize(uint32(p[2])<<8|uint32(p[3]), a),
+			delinearize(uint32(p[4])<<8|uint32(p[5]), a),
+			a,
+		)
+	}
+	DrawMask(dst, adr, ldst, adr.Min, o.DstMask, adr.Min.Add(o.DstMaskP), op)
+}
+
+// linearize returns the linear light, premultiplied, value of the
+// premultiplied sRGB encoded value c whose alpha is a.
+func linearize(c, a uint32) uint32 {
+	c = c * 0xffff / a
+	if c > 0xffff {
+		c = 0xffff
+	}
+	return lookupLinear(&toLinear, c) * a / 0xffff
+}
+
+// delinearize returns the sRGB encoded, premultiplied, value of the
+// premultiplied linear light value c whose alpha is a.
+func delinearize(c, a uint32) uint32 {
+	c = c * 0xffff / a
+	if c > 0xffff {
+		c = 0xffff
+	}
+	return lookupLinear(&fromLinear, c) * a / 0xffff
+}
+
+// span returns the range [lo, hi) of the source columns (or rows) that
+// contribute to the destination columns (or rows) in [i, j).
+func (d *distrib) span(i, j int) (lo, hi int32) {
+	lo, hi = math.MaxInt32, math.MinInt32
+	for _, s := range d.sources[i:j] {
+		for _, c := range d.contribs[s.i:s.j] {
+			if lo > c.coord {
+				lo = c.coord
+			}
+			if hi < c.coord+1 {
+				hi = c.coord + 1
+			}
+		}
+	}
+	if lo >= hi {
+		return 0, 0
+	}
+	return lo, hi
+}
+
+// sub returns the distrib for the destination columns (or rows) in [i, j),
+// with the source columns (or rows) renumbered to start at lo.
+func (d *distrib) sub(i, j int, lo int32) distrib {
+	contribs := make([]contrib, len(d.contribs))
+	for k, c := range d.contribs {
+		contribs[k] = contrib{c.coord - lo, c.weight}
+	}
+	return distrib{d.sources[i:j], contribs}
+}
+
+func setRGBA64(p []byte, r, g, b, a uint32) {
+	p[0] = uint8(r >> 8)
+	p[1] = uint8(r)
+	p[2] = uint8(g >> 8)
+	p[3] = uint8(g)
+	p[4] = uint8(b >> 8)
+	p[5] = uint8(b)
+	p[6] = uint8(a >> 8)
+	p[7] = uint8(a)
+}
@@ -24,7 +24,7 @@ func Copy(dst Image, dp image.Point, src image.Image, sr image.Rectangle, op Op,
 		o = *opts
 	}
 	dr := sr.Add(dp.Sub(sr.Min))
-	if o.DstMask == nil {
+	if o.DstMask == nil && ditherDst(dst, opts) == nil {
 		DrawMask(dst, dr, src, sr.Min, o.SrcMask, o.SrcMaskP.Add(sr.Min), op)
 	} else {
 		NearestNeighbor.Scale(dst, dr, src, sr, op, opts)
@@ -91,6 +91,23 @@ type Options struct {
 	SrcMask  image.Image
 	SrcMaskP image.Point
 
+	// LinearLight is whether to blend source pixels in linear light, treating
+	// the src and dst images' colors as sRGB encoded. Blending the encoded
+	// values, as is done by default, darkens fine detail, such as when
+	// scaling down high-contrast images, but is faster.
+	//
+	// It is only supported by the Scale methods of Kernel interpolators, of
+	// the Scalers that their NewScaler methods return and of AreaAverage.
+	// Their results are rounded to 8 bits per channel.
+	LinearLight bool
+
+	// Dither is how to choose the colors of an *image.Paletted dst image.
+	// The default, NoDither, chooses the palette color nearest to each dst
+	// pixel's color. The other values give much smoother gradients, but are
+	// slower.
+	// Dither is ignored for other dst image types.
+	Dither Dither
+
 	// TODO: a smooth vs sharp edges option, for arbitrary rotations?
 }
 
@@ -100,6 +117,7 @@ type Options struct {
 // Of the interpolators provided by this package:
 //	- NearestNeighbor is fast but usually looks worst.
 //	- CatmullRom is slow but usually looks best.
+//	- Lanczos3 is slower still, and looks sharper than CatmullRom.
 //	- ApproxBiLinear has reasonable speed and quality.
 //
 // The time taken depends on the size of dr. For kernel interpolators, the
@@ -187,15 +205,133 @@ var (
 		return ((-0.5*t+2.5)*t-4)*t + 2
 	}}
 
+	// MitchellNetravali is the cubic BC-spline kernel with parameters B=1/3
+	// and C=1/3, as recommended by Mitchell and Netravali. It is very slow,
+	// and gives results a little blurrier than CatmullRom, but with less
+	// ringing around sharp edges.
+	MitchellNetravali = NewCubicKernel(1.0/3, 1.0/3)
+
+	// Lanczos2 is the Lanczos kernel with 2 lobes. It is very slow, and gives
+	// results similar to CatmullRom, but slightly sharper.
+	Lanczos2 = &Kernel{2, func(t float64) float64 {
+		return lanczos(t, 2)
+	}}
+
+	// Lanczos3 is the Lanczos kernel with 3 lobes. It is even slower, but
+	// usually gives the sharpest results, especially when scaling down
+	// photographs. Like CatmullRom, it can overshoot, giving faint halos
+	// around sharp edges.
+	//
+	// It is the default kernel of many other image processing libraries, such
+	// as ImageMagick and libvips, for scaling down.
+	Lanczos3 = &Kernel{3, func(t float64) float64 {
+		return lanczos(t, 3)
+	}}
+
 	// TODO: a Kaiser-Bessel kernel?
+
+	// AreaAverage is a box filter that sets each destination pixel to the
+	// average of the source pixels that it covers, weighted by how much of
+	// each source pixel is covered. It is the best choice for making
+	// thumbnails, or otherwise scaling down by large factors, as it is fast
+	// and, unlike the interpolators, visits every source pixel exactly as
+	// often as every other. When scaling up, it gives results similar to
+	// NearestNeighbor, but with smooth edges between the source pixels.
+	AreaAverage = Scaler(areaAverage{})
 )
 
+type areaAverage struct{}
+
+// Scale implements the Scaler interface.
+func (areaAverage) Scale(dst Image, dr image.Rectangle, src image.Image, sr image.Rectangle, op Op, opts *Options) {
+	dw, dh, sw, sh := int32(dr.Dx()), int32(dr.Dy()), int32(sr.Dx()), int32(sr.Dy())
+	z := &kernelScaler{
+		kernel:     areaAverage{},
+		dw:         dw,
+		dh:         dh,
+		sw:         sw,
+		sh:         sh,
+		horizontal: newAreaDistrib(dw, sw),
+		vertical:   newAreaDistrib(dh, sh),
+	}
+	z.Scale(dst, dr, src, sr, op, opts)
+}
+
+// newAreaDistrib returns a distrib that distributes sw source columns (or
+// rows) over dw destination columns (or rows), weighting each source column
+// by how much of it each destination column covers.
+func newAreaDistrib(dw, sw int32) distrib {
+	scale := float64(sw) / float64(dw)
+	sources := make([]source, dw)
+	contribs := make([]contrib, 0, dw+sw)
+	for x := range sources {
+		lo, hi := float64(x)*scale, float64(x+1)*scale
+		l := int32(len(contribs))
+		totalWeight := 0.0
+		for coord := int32(lo); coord < sw && float64(coord) < hi; coord++ {
+			weight := math.Min(hi, float64(coord+1)) - math.Max(lo, float64(coord))
+			if weight <= 0 {
+				continue
+			}
+			totalWeight += weight
+			contribs = append(contribs, contrib{coord, weight})
+		}
+		totalWeight = 1 / totalWeight
+		sources[x] = source{
+			i:                  l,
+			j:                  int32(len(contribs)),
+			invTotalWeight:     totalWeight,
+			invTotalWeightFFFF: totalWeight / 0xffff,
+		}
+	}
+	return distrib{sources, contribs}
+}
+
+// NewCubicKernel returns the cubic BC-spline kernel with parameters b and c.
+// See Mitchell and Netravali, "Reconstruction Filters in Computer Graphics",
+// Computer Graphics, Vol. 22, No. 4, pp. 221-228.
+//
+// Larger values of b blur more, and larger values of c ring more around sharp
+// edges. Kernels with b + 2*c = 1 are usually the best trade-off, such as
+// MitchellNetravali, with b = c = 1/3, and CatmullRom, with b = 0 and c = 0.5.
+// Other well known kernels include the cubic B-spline, with b = 1 and c = 0,
+// which is very blurry but never rings.
+func NewCubicKernel(b, c float64) *Kernel {
+	// The kernel is p(t) = p3*t*t*t + p2*t*t + p0 for t < 1, and q(t) =
+	// q3*t*t*t + q2*t*t + q1*t + q0 for 1 <= t < 2. Each coefficient is the
+	// paper's coefficient divided by 6, so that the kernel need not divide.
+	p3 := (12 - 9*b - 6*c) / 6
+	p2 := (-18 + 12*b + 6*c) / 6
+	p0 := (6 - 2*b) / 6
+	q3 := (-b - 6*c) / 6
+	q2 := (6*b + 30*c) / 6
+	q1 := (-12*b - 48*c) / 6
+	q0 := (8*b + 24*c) / 6
+	return &Kernel{2, func(t float64) float64 {
+		if t < 1 {
+			return (p3*t+p2)*t*t + p0
+		}
+		return ((q3*t+q2)*t+q1)*t + q0
+	}}
+}
+
+// lanczos returns the Lanczos kernel with a lobes, sinc(t) * sinc(t/a), at t,
+// which must be in the range [0, a).
+func lanczos(t, a float64) float64 {
+	if t == 0 {
+		return 1
+	}
+	pt := math.Pi * t
+	return a * math.Sin(pt) * math.Sin(pt/a) / (pt * pt)
+}
+
 type nnInterpolator struct{}
 
 type ablInterpolator struct{}
 
 type kernelScaler struct {
-	kernel               *Kernel
+	// kernel scales images whose sizes differ from dw, dh, sw and sh.
+	kernel               Scaler
 	dw, dh, sw, sh       int32
 	horizontal, vertical distrib
 	pool                 sync.Pool
@@ -11,6 +11,7 @@ import (
 	"image"
 	"image/color"
 	"image/png"
+	"math"
 	"math/rand"
 	"os"
 	"reflect"
@@ -231,6 +232,49 @@ func TestOps(t *testing.T) {
 	}
 }
 
+// TestRGBA64Precision tests that scaling between 16-bit images doesn't lose
+// precision by passing through 8-bit intermediates.
+func TestRGBA64Precision(t *testing.T) {
+	src := image.NewNRGBA64(image.Rect(0, 0, 2, 2))
+	src.SetNRGBA64(0, 0, color.NRGBA64{0x1234, 0x5678, 0x9abc, 0xffff})
+	src.SetNRGBA64(1, 0, color.NRGBA64{0x0001, 0x00ff, 0xff00, 0xffff})
+	src.SetNRGBA64(0, 1, color.NRGBA64{0xfedc, 0xba98, 0x7654, 0xffff})
+	src.SetNRGBA64(1, 1, color.NRGBA64{0x0101, 0x0202, 0x0303, 0xffff})
+	dst := image.NewRGBA64(image.Rect(0, 0, 4, 4))
+	NearestNeighbor.Scale(dst, dst.Bounds(), src, src.Bounds(), Src, nil)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			c := src.NRGBA64At(x/2, y/2)
+			want := color.RGBA64{c.R, c.G, c.B, c.A}
+			if got := dst.RGBA64At(x, y); got != want {
+				t.Errorf("(%d, %d): got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	// The other interpolators blend neighboring pixels, so scale a single
+	// pixel, which every dst pixel should match exactly.
+	src1 := src.SubImage(image.Rect(0, 0, 1, 1)).(*image.NRGBA64)
+	want := color.RGBA64{0x1234, 0x5678, 0x9abc, 0xffff}
+	for _, q := range []Interpolator{ApproxBiLinear, CatmullRom} {
+		for _, transform := range []bool{false, true} {
+			dst := image.NewRGBA64(image.Rect(0, 0, 4, 4))
+			if transform {
+				q.Transform(dst, f64.Aff3{4, 0, 0, 0, 4, 0}, src1, src1.Bounds(), Src, nil)
+			} else {
+				q.Scale(dst, dst.Bounds(), src1, src1.Bounds(), Src, nil)
+			}
+			for y := 0; y < 4; y++ {
+				for x := 0; x < 4; x++ {
+					if got := dst.RGBA64At(x, y); got != want {
+						t.Errorf("q=%T, transform=%t: (%d, %d): got %v, want %v", q, transform, x, y, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
 // TestNegativeWeights tests that scaling by a kernel that produces negative
 // weights, such as the Catmull-Rom kernel, doesn't produce an invalid color
 // according to Go's alpha-premultiplied model.
@@ -261,10 +305,276 @@ func TestNegativeWeights(t *testing.T) {
 		t.Fatalf("src image: %v", err)
 	}
 
-	dst := image.NewRGBA(image.Rect(0, 0, 32, 32))
-	CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), Over, nil)
-	if err := check(dst); err != nil {
-		t.Fatalf("dst image: %v", err)
+	for _, q := range []*Kernel{CatmullRom, MitchellNetravali, Lanczos3} {
+		dst := image.NewRGBA(image.Rect(0, 0, 32, 32))
+		q.Scale(dst, dst.Bounds(), src, src.Bounds(), Over, nil)
+		if err := check(dst); err != nil {
+			t.Fatalf("support %v: dst image: %v", q.Support, err)
+		}
+	}
+}
+
+func TestCubicKernel(t *testing.T) {
+	cr := NewCubicKernel(0, 0.5)
+	for i := 0; i < 40; i++ {
+		x := float64(i) / 20
+		if got, want := cr.At(x), CatmullRom.At(x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("At(%v): got %v, want %v", x, got, want)
+		}
+	}
+
+	if got, want := MitchellNetravali.At(0), 8.0/9; math.Abs(got-want) > 1e-12 {
+		t.Errorf("MitchellNetravali.At(0): got %v, want %v", got, want)
+	}
+	// The kernel is continuous at t = 1 and t = 2.
+	for _, x := range []float64{1, 2} {
+		lo, hi := MitchellNetravali.At(x-1e-9), 0.0
+		if x < 2 {
+			hi = MitchellNetravali.At(x)
+		}
+		if math.Abs(lo-hi) > 1e-6 {
+			t.Errorf("MitchellNetravali is discontinuous at %v: %v vs %v", x, lo, hi)
+		}
+	}
+
+	// A cubic B-spline is never negative.
+	bs := NewCubicKernel(1, 0)
+	for i := 0; i < 40; i++ {
+		if x := float64(i) / 20; bs.At(x) < 0 {
+			t.Errorf("B-spline At(%v): got %v, want >= 0", x, bs.At(x))
+		}
+	}
+}
+
+func TestAreaAverage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(3, 5, 3+40, 5+30))
+	fillPix(rand.New(rand.NewSource(1)), src.Pix)
+	for i := 3; i < len(src.Pix); i += 4 {
+		src.Pix[i] = 0xff
+	}
+
+	// Scaling down by an integer factor averages each block of source pixels.
+	const factor = 10
+	dst := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	AreaAverage.Scale(dst, dst.Bounds(), src, src.Bounds(), Src, nil)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			var sum [3]int
+			for sy := 0; sy < factor; sy++ {
+				for sx := 0; sx < factor; sx++ {
+					c := src.RGBAAt(3+x*factor+sx, 5+y*factor+sy)
+					sum[0] += int(c.R)
+					sum[1] += int(c.G)
+					sum[2] += int(c.B)
+				}
+			}
+			got := dst.RGBAAt(x, y)
+			for i, g := range []uint8{got.R, got.G, got.B} {
+				want := sum[i] / (factor * factor)
+				if d := int(g) - want; d < -1 || d > 1 {
+					t.Errorf("(%d, %d) channel %d: got %#02x, want %#02x", x, y, i, g, want)
+				}
+			}
+			if got.A != 0xff {
+				t.Errorf("(%d, %d): got alpha %#02x, want 0xff", x, y, got.A)
+			}
+		}
+	}
+
+	// Scaling a uniform image, by any factor, should give the same color.
+	c := color.RGBA{0x40, 0x80, 0xc0, 0xff}
+	Copy(src, src.Bounds().Min, image.NewUniform(c), src.Bounds(), Src, nil)
+	for _, dr := range []image.Rectangle{image.Rect(0, 0, 7, 3), image.Rect(0, 0, 97, 61)} {
+		dst := image.NewRGBA(dr)
+		AreaAverage.Scale(dst, dr, src, src.Bounds(), Src, nil)
+		for y := dr.Min.Y; y < dr.Max.Y; y++ {
+			for x := dr.Min.X; x < dr.Max.X; x++ {
+				if got := dst.RGBAAt(x, y); got != c {
+					t.Fatalf("dr %v: (%d, %d): got %v, want %v", dr, x, y, got, c)
+				}
+			}
+		}
+	}
+}
+
+func TestNewAreaDistrib(t *testing.T) {
+	// Scaling 5 source columns to 2 destination columns gives each
+	// destination column 2.5 source columns' worth of weight.
+	d := newAreaDistrib(2, 5)
+	want := []contrib{{0, 1}, {1, 1}, {2, 0.5}, {2, 0.5}, {3, 1}, {4, 1}}
+	if !reflect.DeepEqual(d.contribs, want) {
+		t.Errorf("contribs: got %v, want %v", d.contribs, want)
+	}
+	for i, s := range d.sources {
+		if s.invTotalWeight != 1/2.5 {
+			t.Errorf("sources[%d]: got inverse total weight %v, want %v", i, s.invTotalWeight, 1/2.5)
+		}
+	}
+}
+
+func TestLinearLight(t *testing.T) {
+	// A fine black and white checkerboard, scaled down, is mid-gray in linear
+	// light, which is 0xbc when sRGB encoded, but 0x7f or 0x80 otherwise.
+	src := image.NewGray(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			if (x+y)%2 == 0 {
+				src.SetGray(x, y, color.Gray{0xff})
+			}
+		}
+	}
+	for _, q := range []Scaler{AreaAverage, BiLinear, CatmullRom.NewScaler(4, 4, 16, 16)} {
+		for _, linear := range []bool{false, true} {
+			dst := image.NewRGBA(image.Rect(0, 0, 4, 4))
+			q.Scale(dst, dst.Bounds(), src, src.Bounds(), Src, &Options{LinearLight: linear})
+			lo, hi := uint8(0x7e), uint8(0x81)
+			if linear {
+				lo, hi = 0xbb, 0xbd
+			}
+			for i := 0; i < len(dst.Pix); i += 4 {
+				if c := dst.Pix[i]; c < lo || hi < c || dst.Pix[i+3] != 0xff {
+					t.Fatalf("linear=%t: pixel %d: got %v, want red in [%#02x, %#02x]", linear, i/4, dst.Pix[i:i+4], lo, hi)
+				}
+			}
+		}
+	}
+
+	// Every color, and alpha, survives the round trip to linear light.
+	src2 := image.NewNRGBA(image.Rect(0, 0, 256, 4))
+	for x := 0; x < 256; x++ {
+		src2.SetNRGBA(x, 0, color.NRGBA{uint8(x), uint8(255 - x), 0x80, 0xff})
+		src2.SetNRGBA(x, 1, color.NRGBA{uint8(x), 0x00, 0xff, 0xff})
+		src2.SetNRGBA(x, 2, color.NRGBA{0xff, 0x40, 0x00, uint8(x)})
+		src2.SetNRGBA(x, 3, color.NRGBA{uint8(x), uint8(x), uint8(x), 0x80})
+	}
+	dst := image.NewNRGBA(src2.Bounds())
+	AreaAverage.Scale(dst, dst.Bounds(), src2, src2.Bounds(), Src, &Options{LinearLight: true})
+	for i := range dst.Pix {
+		if d := int(dst.Pix[i]) - int(src2.Pix[i]); src2.Pix[i|3] >= 0x80 && (d < -1 || d > 1) {
+			t.Fatalf("pixel (%d, %d): got %v, want %v", i/4%256, i/1024, dst.Pix[i&^3:i&^3+4], src2.Pix[i&^3:i&^3+4])
+		}
+	}
+
+	// 16-bit colors keep more than 8 bits of precision.
+	src5 := image.NewNRGBA64(image.Rect(0, 0, 0x100, 4))
+	for x := 0; x < 0x100; x++ {
+		for y := 0; y < 4; y++ {
+			v := uint16(x<<8 | 0x80 | y<<4)
+			src5.SetNRGBA64(x, y, color.NRGBA64{v, 0xffff - v, 0x1234, 0xffff})
+		}
+	}
+	dst5 := image.NewNRGBA64(src5.Bounds())
+	AreaAverage.Scale(dst5, dst5.Bounds(), src5, src5.Bounds(), Src, &Options{LinearLight: true})
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 0x100; x++ {
+			got, want := dst5.NRGBA64At(x, y), src5.NRGBA64At(x, y)
+			for _, d := range []int{
+				int(got.R) - int(want.R),
+				int(got.G) - int(want.G),
+				int(got.B) - int(want.B),
+				int(got.A) - int(want.A),
+			} {
+				if d < -16 || d > 16 {
+					t.Fatalf("16-bit (%d, %d): got %v, want %v", x, y, got, want)
+				}
+			}
+		}
+	}
+
+	// Paletted dsts are dithered from the linear light result. The
+	// checkerboard is about 0xbc/0xff, or 74%, white in linear light, but
+	// only half white otherwise.
+	for _, linear := range []bool{false, true} {
+		dst := image.NewPaletted(image.Rect(0, 0, 8, 8), color.Palette{color.Black, color.White})
+		BiLinear.Scale(dst, dst.Bounds(), src, src.Bounds(), Src, &Options{
+			Dither:      FloydSteinbergDither,
+			LinearLight: linear,
+		})
+		n := 0
+		for _, p := range dst.Pix {
+			n += int(p)
+		}
+		lo, hi := 28, 36
+		if linear {
+			lo, hi = 43, 52
+		}
+		if n < lo || hi < n {
+			t.Errorf("dithered, linear=%t: got %d white pixels, want in [%d, %d]", linear, n, lo, hi)
+		}
+	}
+
+	// Colors that are not properly alpha-premultiplied are clamped, as for
+	// the other paths, and don't panic.
+	src4 := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := 0; i < len(src4.Pix); i += 4 {
+		copy(src4.Pix[i:i+4], []byte{0xff, 0x00, 0x00, 0x10})
+	}
+	dst4 := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	CatmullRom.Scale(dst4, dst4.Bounds(), src4, src4.Bounds(), Src, &Options{LinearLight: true})
+	for i := 0; i < len(dst4.Pix); i += 4 {
+		if got, want := dst4.Pix[i:i+4], []byte{0x10, 0x00, 0x00, 0x10}; !bytes.Equal(got, want) {
+			t.Errorf("unpremultiplied: pixel %d: got %v, want %v", i/4, got, want)
+		}
+	}
+
+	// Scaling only part of dr, or of sr, gives the same result as scaling
+	// all of it, and then clipping.
+	z := CatmullRom.NewScaler(10, 10, 16, 16)
+	full := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	z.Scale(full, full.Bounds(), src2, image.Rect(100, 0, 116, 16), Src, &Options{LinearLight: true})
+	part := image.NewRGBA(image.Rect(2, 3, 7, 5))
+	z.Scale(part, full.Bounds(), src2, image.Rect(100, 0, 116, 16), Src, &Options{LinearLight: true})
+	for y := 3; y < 5; y++ {
+		for x := 2; x < 7; x++ {
+			if got, want := part.RGBAAt(x, y), full.RGBAAt(x, y); got != want {
+				t.Errorf("clipped (%d, %d): got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	// The dst mask still applies.
+	dst3 := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	mask := image.NewAlpha(image.Rect(0, 0, 4, 4))
+	mask.SetAlpha(1, 2, color.Alpha{0xff})
+	BiLinear.Scale(dst3, dst3.Bounds(), src, src.Bounds(), Over, &Options{DstMask: mask, LinearLight: true})
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if got, want := dst3.RGBAAt(x, y).A != 0, x == 1 && y == 2; got != want {
+				t.Errorf("masked (%d, %d): got drawn %t, want %t", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestLanczos(t *testing.T) {
+	for _, q := range []*Kernel{Lanczos2, Lanczos3} {
+		if got := q.At(0); got != 1 {
+			t.Errorf("support %v: At(0): got %v, want 1", q.Support, got)
+		}
+		for i := 1; i < int(q.Support); i++ {
+			if got := q.At(float64(i)); math.Abs(got) > 1e-12 {
+				t.Errorf("support %v: At(%d): got %v, want 0", q.Support, i, got)
+			}
+		}
+		if got := q.At(0.5); got <= 0 || got >= 1 {
+			t.Errorf("support %v: At(0.5): got %v, want in (0, 1)", q.Support, got)
+		}
+
+		// Scaling a uniform image, up or down, should give the same color.
+		c := color.RGBA{0x40, 0x80, 0xc0, 0xff}
+		src := image.NewRGBA(image.Rect(0, 0, 30, 20))
+		Copy(src, image.Point{}, image.NewUniform(c), src.Bounds(), Src, nil)
+		for _, dr := range []image.Rectangle{image.Rect(0, 0, 7, 5), image.Rect(0, 0, 71, 43)} {
+			dst := image.NewRGBA(dr)
+			q.Scale(dst, dr, src, src.Bounds(), Src, nil)
+			for y := dr.Min.Y; y < dr.Max.Y; y++ {
+				for x := dr.Min.X; x < dr.Max.X; x++ {
+					if got := dst.RGBAAt(x, y); got != c {
+						t.Fatalf("support %v, dr %v: (%d, %d): got %v, want %v", q.Support, dr, x, y, got, c)
+					}
+				}
+			}
+		}
 	}
 }
 
@@ -286,6 +596,7 @@ func TestInterpClipCommute(t *testing.T) {
 		NearestNeighbor,
 		ApproxBiLinear,
 		CatmullRom,
+		Lanczos3,
 	}
 	for _, transform := range []bool{false, true} {
 		for _, q := range qs {
@@ -596,14 +907,42 @@ func srcRGBA(boundsHint image.Rectangle) (image.Image, error) {
 	return m, nil
 }
 
+func srcNRGBA64(boundsHint image.Rectangle) (image.Image, error) {
+	m := image.NewNRGBA64(boundsHint)
+	fillPix(rand.New(rand.NewSource(4)), m.Pix)
+	return m, nil
+}
+
+func srcRGBA64(boundsHint image.Rectangle) (image.Image, error) {
+	m := image.NewRGBA64(boundsHint)
+	fillPix(rand.New(rand.NewSource(5)), m.Pix)
+	// RGBA64 is alpha-premultiplied, so the R, G and B values should
+	// be <= the A values.
+	for i := 0; i < len(m.Pix); i += 8 {
+		a := uint32(m.Pix[i+6])<<8 | uint32(m.Pix[i+7])
+		for j := 0; j < 6; j += 2 {
+			c := (uint32(m.Pix[i+j])<<8 | uint32(m.Pix[i+j+1])) * a / 0xffff
+			m.Pix[i+j+0] = uint8(c >> 8)
+			m.Pix[i+j+1] = uint8(c)
+		}
+	}
+	return m, nil
+}
+
 func srcUnif(boundsHint image.Rectangle) (image.Image, error) {
 	return image.NewUniform(color.RGBA64{0x1234, 0x5555, 0x9181, 0xbeef}), nil
 }
 
 func srcYCbCr(boundsHint image.Rectangle) (image.Image, error) {
-	m := image.NewYCbCr(boundsHint, image.YCbCrSubsampleRatio420)
-	fillPix(rand.New(rand.NewSource(3)), m.Y, m.Cb, m.Cr)
-	return m, nil
+	return srcYCbCrRatio(image.YCbCrSubsampleRatio420)(boundsHint)
+}
+
+func srcYCbCrRatio(ratio image.YCbCrSubsampleRatio) func(image.Rectangle) (image.Image, error) {
+	return func(boundsHint image.Rectangle) (image.Image, error) {
+		m := image.NewYCbCr(boundsHint, ratio)
+		fillPix(rand.New(rand.NewSource(3)), m.Y, m.Cb, m.Cr)
+		return m, nil
+	}
 }
 
 func srcLarge(boundsHint image.Rectangle) (image.Image, error) {
@@ -692,12 +1031,22 @@ func BenchmarkTformNNOverUnif(b *testing.B) { benchTform(b, 200, 150, Over, srcU
 
 func BenchmarkScaleABSrcGray(b *testing.B)  { benchScale(b, 200, 150, Src, srcGray, ApproxBiLinear) }
 func BenchmarkScaleABSrcNRGBA(b *testing.B) { benchScale(b, 200, 150, Src, srcNRGBA, ApproxBiLinear) }
-func BenchmarkScaleABSrcRGBA(b *testing.B)  { benchScale(b, 200, 150, Src, srcRGBA, ApproxBiLinear) }
-func BenchmarkScaleABSrcYCbCr(b *testing.B) { benchScale(b, 200, 150, Src, srcYCbCr, ApproxBiLinear) }
+func BenchmarkScaleABSrcNRGBA64(b *testing.B) {
+	benchScale(b, 200, 150, Src, srcNRGBA64, ApproxBiLinear)
+}
+func BenchmarkScaleABSrcRGBA(b *testing.B)   { benchScale(b, 200, 150, Src, srcRGBA, ApproxBiLinear) }
+func BenchmarkScaleABSrcRGBA64(b *testing.B) { benchScale(b, 200, 150, Src, srcRGBA64, ApproxBiLinear) }
+func BenchmarkScaleABSrcYCbCr(b *testing.B)  { benchScale(b, 200, 150, Src, srcYCbCr, ApproxBiLinear) }
 
 func BenchmarkScaleABOverGray(b *testing.B)  { benchScale(b, 200, 150, Over, srcGray, ApproxBiLinear) }
 func BenchmarkScaleABOverNRGBA(b *testing.B) { benchScale(b, 200, 150, Over, srcNRGBA, ApproxBiLinear) }
-func BenchmarkScaleABOverRGBA(b *testing.B)  { benchScale(b, 200, 150, Over, srcRGBA, ApproxBiLinear) }
+func BenchmarkScaleABOverNRGBA64(b *testing.B) {
+	benchScale(b, 200, 150, Over, srcNRGBA64, ApproxBiLinear)
+}
+func BenchmarkScaleABOverRGBA(b *testing.B) { benchScale(b, 200, 150, Over, srcRGBA, ApproxBiLinear) }
+func BenchmarkScaleABOverRGBA64(b *testing.B) {
+	benchScale(b, 200, 150, Over, srcRGBA64, ApproxBiLinear)
+}
 func BenchmarkScaleABOverYCbCr(b *testing.B) { benchScale(b, 200, 150, Over, srcYCbCr, ApproxBiLinear) }
 
 func BenchmarkTformABSrcGray(b *testing.B)  { benchTform(b, 200, 150, Src, srcGray, ApproxBiLinear) }
@@ -710,15 +1059,19 @@ func BenchmarkTformABOverNRGBA(b *testing.B) { benchTform(b, 200, 150, Over, src
 func BenchmarkTformABOverRGBA(b *testing.B)  { benchTform(b, 200, 150, Over, srcRGBA, ApproxBiLinear) }
 func BenchmarkTformABOverYCbCr(b *testing.B) { benchTform(b, 200, 150, Over, srcYCbCr, ApproxBiLinear) }
 
-func BenchmarkScaleCRSrcGray(b *testing.B)  { benchScale(b, 200, 150, Src, srcGray, CatmullRom) }
-func BenchmarkScaleCRSrcNRGBA(b *testing.B) { benchScale(b, 200, 150, Src, srcNRGBA, CatmullRom) }
-func BenchmarkScaleCRSrcRGBA(b *testing.B)  { benchScale(b, 200, 150, Src, srcRGBA, CatmullRom) }
-func BenchmarkScaleCRSrcYCbCr(b *testing.B) { benchScale(b, 200, 150, Src, srcYCbCr, CatmullRom) }
-
-func BenchmarkScaleCROverGray(b *testing.B)  { benchScale(b, 200, 150, Over, srcGray, CatmullRom) }
-func BenchmarkScaleCROverNRGBA(b *testing.B) { benchScale(b, 200, 150, Over, srcNRGBA, CatmullRom) }
-func BenchmarkScaleCROverRGBA(b *testing.B)  { benchScale(b, 200, 150, Over, srcRGBA, CatmullRom) }
-func BenchmarkScaleCROverYCbCr(b *testing.B) { benchScale(b, 200, 150, Over, srcYCbCr, CatmullRom) }
+func BenchmarkScaleCRSrcGray(b *testing.B)    { benchScale(b, 200, 150, Src, srcGray, CatmullRom) }
+func BenchmarkScaleCRSrcNRGBA(b *testing.B)   { benchScale(b, 200, 150, Src, srcNRGBA, CatmullRom) }
+func BenchmarkScaleCRSrcNRGBA64(b *testing.B) { benchScale(b, 200, 150, Src, srcNRGBA64, CatmullRom) }
+func BenchmarkScaleCRSrcRGBA(b *testing.B)    { benchScale(b, 200, 150, Src, srcRGBA, CatmullRom) }
+func BenchmarkScaleCRSrcRGBA64(b *testing.B)  { benchScale(b, 200, 150, Src, srcRGBA64, CatmullRom) }
+func BenchmarkScaleCRSrcYCbCr(b *testing.B)   { benchScale(b, 200, 150, Src, srcYCbCr, CatmullRom) }
+
+func BenchmarkScaleCROverGray(b *testing.B)    { benchScale(b, 200, 150, Over, srcGray, CatmullRom) }
+func BenchmarkScaleCROverNRGBA(b *testing.B)   { benchScale(b, 200, 150, Over, srcNRGBA, CatmullRom) }
+func BenchmarkScaleCROverNRGBA64(b *testing.B) { benchScale(b, 200, 150, Over, srcNRGBA64, CatmullRom) }
+func BenchmarkScaleCROverRGBA(b *testing.B)    { benchScale(b, 200, 150, Over, srcRGBA, CatmullRom) }
+func BenchmarkScaleCROverRGBA64(b *testing.B)  { benchScale(b, 200, 150, Over, srcRGBA64, CatmullRom) }
+func BenchmarkScaleCROverYCbCr(b *testing.B)   { benchScale(b, 200, 150, Over, srcYCbCr, CatmullRom) }
 
 func BenchmarkTformCRSrcGray(b *testing.B)  { benchTform(b, 200, 150, Src, srcGray, CatmullRom) }
 func BenchmarkTformCRSrcNRGBA(b *testing.B) { benchTform(b, 200, 150, Src, srcNRGBA, CatmullRom) }
@@ -40,9 +40,16 @@ func TestFastPaths(t *testing.T) {
 	srcfs := []func(image.Rectangle) (image.Image, error){
 		srcGray,
 		srcNRGBA,
+		srcNRGBA64,
 		srcRGBA,
+		srcRGBA64,
 		srcUnif,
 		srcYCbCr,
+		srcYCbCrRatio(image.YCbCrSubsampleRatio444),
+		srcYCbCrRatio(image.YCbCrSubsampleRatio422),
+		srcYCbCrRatio(image.YCbCrSubsampleRatio440),
+		srcYCbCrRatio(image.YCbCrSubsampleRatio411),
+		srcYCbCrRatio(image.YCbCrSubsampleRatio410),
 	}
 	var srcs []image.Image
 	for _, srcf := range srcfs {
@@ -61,6 +68,16 @@ func TestFastPaths(t *testing.T) {
 		Over,
 		Src,
 	}
+	dsts := []func(image.Rectangle) (Image, []byte){
+		func(r image.Rectangle) (Image, []byte) {
+			m := image.NewRGBA(r)
+			return m, m.Pix
+		},
+		func(r image.Rectangle) (Image, []byte) {
+			m := image.NewRGBA64(r)
+			return m, m.Pix
+		},
+	}
 	blue := image.NewUniform(color.RGBA{0x11, 0x22, 0x44, 0x7f})
 
 	for _, dr := range drs {
@@ -69,23 +86,8 @@ func TestFastPaths(t *testing.T) {
 				for _, transform := range []bool{false, true} {
 					for _, q := range qs {
 						for _, op := range ops {
-							dst0 := image.NewRGBA(drs[0])
-							dst1 := image.NewRGBA(drs[0])
-							Draw(dst0, dst0.Bounds(), blue, image.Point{}, Src)
-							Draw(dstWrapper{dst1}, dst1.Bounds(), srcWrapper{blue}, image.Point{}, Src)
-
-							if transform {
-								m := transformMatrix(3.75, 2, 1)
-								q.Transform(dst0, m, src, sr, op, nil)
-								q.Transform(dstWrapper{dst1}, m, srcWrapper{src}, sr, op, nil)
-							} else {
-								q.Scale(dst0, dr, src, sr, op, nil)
-								q.Scale(dstWrapper{dst1}, dr, srcWrapper{src}, sr, op, nil)
-							}
-
-							if !bytes.Equal(dst0.Pix, dst1.Pix) {
-								t.Errorf("pix differ for dr=%v, src=%T, sr=%v, transform=%t, q=%T",
-									dr, src, sr, transform, q)
+							for _, newDst := range dsts {
+								testFastPath(t, newDst, dr, src, sr, transform, q, op, blue)
 							}
 						}
 					}
@@ -94,3 +96,33 @@ func TestFastPaths(t *testing.T) {
 		}
 	}
 }
+
+func testFastPath(t *testing.T, newDst func(image.Rectangle) (Image, []byte), dr image.Rectangle, src image.Image, sr image.Rectangle, transform bool, q Interpolator, op Op, blue image.Image) {
+	dst0, pix0 := newDst(image.Rect(0, 0, 10, 10))
+	dst1, pix1 := newDst(image.Rect(0, 0, 10, 10))
+	Draw(dst0, dst0.Bounds(), blue, image.Point{}, Src)
+	Draw(dstWrapper{dst1}, dst1.Bounds(), srcWrapper{blue}, image.Point{}, Src)
+
+	if transform {
+		m := transformMatrix(3.75, 2, 1)
+		q.Transform(dst0, m, src, sr, op, nil)
+		q.Transform(dstWrapper{dst1}, m, srcWrapper{src}, sr, op, nil)
+	} else {
+		q.Scale(dst0, dr, src, sr, op, nil)
+		q.Scale(dstWrapper{dst1}, dr, srcWrapper{src}, sr, op, nil)
+	}
+
+	if !bytes.Equal(pix0, pix1) {
+		t.Errorf("pix differ for dst=%T, dr=%v, src=%T%s, sr=%v, transform=%t, q=%T",
+			dst0, dr, src, subsampleRatio(src), sr, transform, q)
+	}
+}
+
+// subsampleRatio returns a description of src's subsample ratio, if it is an
+// *image.YCbCr.
+func subsampleRatio(src image.Image) string {
+	if m, ok := src.(*image.YCbCr); ok {
+		return " " + m.SubsampleRatio.String()
+	}
+	return ""
+}
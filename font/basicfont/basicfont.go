@@ -8,7 +8,9 @@
 package basicfont // import "golang.org/x/image/font/basicfont"
 
 import (
+	"errors"
 	"image"
+	"image/color"
 
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
@@ -72,17 +74,209 @@ type Face struct {
 	Ranges []Range
 }
 
+var (
+	errInvalidCellSize = errors.New("basicfont: invalid glyph cell size")
+	errInvalidRange    = errors.New("basicfont: invalid rune range")
+	errStripTooSmall   = errors.New("basicfont: strip image has too few glyph cells")
+)
+
+// NewFaceFromStrip returns a Face whose glyphs, for the runes in the range
+// [low, high), are cut from a strip image, as used by many console and game
+// bitmap fonts. The strip is divided into cells that are width pixels wide
+// and height pixels high, and the runes' glyphs are the cells in order from
+// left to right, and then from top to bottom, so that the strip can be a
+// single row or a grid. Each glyph's baseline is ascent pixels below the top
+// of its cell.
+//
+// A pixel's glyph coverage is its luminance, multiplied by its alpha, so the
+// glyphs should be light on a dark or transparent background. The Face's
+// advance, and the height of a line, are the cell's width and height. If the
+// range includes U+FFFD REPLACEMENT CHARACTER, its glyph is drawn for runes
+// outside of the range.
+func NewFaceFromStrip(strip image.Image, width, height, ascent int, low, high rune) (*Face, error) {
+	if width <= 0 || height <= 0 || ascent < 0 || ascent > height {
+		return nil, errInvalidCellSize
+	}
+	if low < 0 || high <= low {
+		return nil, errInvalidRange
+	}
+	b := strip.Bounds()
+	cols, rows := b.Dx()/width, b.Dy()/height
+	n := int(high - low)
+	if n > cols*rows {
+		return nil, errStripTooSmall
+	}
+
+	mask := image.NewAlpha(image.Rect(0, 0, width, n*height))
+	for i := 0; i < n; i++ {
+		sx := b.Min.X + (i%cols)*width
+		sy := b.Min.Y + (i/cols)*height
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				g := color.GrayModel.Convert(strip.At(sx+x, sy+y)).(color.Gray)
+				mask.SetAlpha(x, i*height+y, color.Alpha{A: g.Y})
+			}
+		}
+	}
+	return &Face{
+		Advance: width,
+		Width:   width,
+		Height:  height,
+		Ascent:  ascent,
+		Descent: height - ascent,
+		Mask:    mask,
+		Ranges:  []Range{{Low: low, High: high}},
+	}, nil
+}
+
+// Scale returns a copy of f whose glyphs and metrics are factor times as
+// big, such as for a Face7x13 that is readable on a high DPI display. The
+// glyphs are scaled by nearest-neighbor sampling, so they stay sharp. It
+// panics if factor is not positive.
+func Scale(f *Face, factor int) *Face {
+	if factor <= 0 {
+		panic("basicfont: non-positive scale factor")
+	}
+	b := f.Mask.Bounds()
+	mask := image.NewAlpha(image.Rectangle{Min: b.Min.Mul(factor), Max: b.Max.Mul(factor)})
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			a := color.AlphaModel.Convert(f.Mask.At(x, y)).(color.Alpha)
+			if a.A == 0 {
+				continue
+			}
+			i := mask.PixOffset(x*factor, y*factor)
+			for dy := 0; dy < factor; dy++ {
+				for dx := 0; dx < factor; dx++ {
+					mask.Pix[i+dx] = a.A
+				}
+				i += mask.Stride
+			}
+		}
+	}
+	return &Face{
+		Advance: f.Advance * factor,
+		Width:   f.Width * factor,
+		Height:  f.Height * factor,
+		Ascent:  f.Ascent * factor,
+		Descent: f.Descent * factor,
+		Left:    f.Left * factor,
+		Mask:    mask,
+		Ranges:  append([]Range(nil), f.Ranges...),
+	}
+}
+
+// Embolden returns a copy of f whose glyphs are drawn in a synthetic bold, by
+// striking each glyph twice, the second time one pixel to the right. The
+// glyphs are one pixel wider, but their advance is unchanged, so that the
+// copy can draw bold text in the same grid of cells as f, as terminal
+// emulators do.
+func Embolden(f *Face) *Face {
+	g, mask := recell(f, f.Left, f.Width+1)
+	b := mask.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := mask.Pix[mask.PixOffset(0, y):][:g.Width]
+		for x := len(row) - 1; x > 0; x-- {
+			if row[x] < row[x-1] {
+				row[x] = row[x-1]
+			}
+		}
+	}
+	return g
+}
+
+// Underline returns a copy of f whose glyphs are underlined, at the position
+// and thickness given by f's Metrics. Each glyph's underline spans its whole
+// advance, so that the underlines of adjacent glyphs, including spaces, join
+// up. If f has no descent, the underline is the bottom row of the glyphs'
+// cells.
+//
+// Underline and Embolden can be combined, in either order, as for a terminal
+// emulator's bold and underlined text.
+func Underline(f *Face) *Face {
+	left, right := f.Left, f.Left+f.Width
+	if left > 0 {
+		left = 0
+	}
+	if right < f.Advance {
+		right = f.Advance
+	}
+	g, mask := recell(f, left, right-left)
+	h := f.Ascent + f.Descent
+	if h <= 0 {
+		return g
+	}
+	y := f.Ascent + f.Metrics().UnderlinePosition.Round()
+	if y >= h {
+		y = h - 1
+	}
+	b := mask.Rect
+	for ; y < b.Max.Y; y += h {
+		if y < b.Min.Y {
+			continue
+		}
+		row := mask.Pix[mask.PixOffset(0, y):][:g.Width]
+		for x := range row {
+			row[x] = 0xff
+		}
+	}
+	return g
+}
+
+// recell returns a copy of f whose glyph cells have the given left side
+// bearing and width, and the copy's mask, which the caller may draw to. The
+// cells' ink is copied from f, at the same position relative to the dot.
+func recell(f *Face, left, width int) (*Face, *image.Alpha) {
+	b := f.Mask.Bounds()
+	mask := image.NewAlpha(image.Rect(0, b.Min.Y, width, b.Max.Y))
+	dx := f.Left - left
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := 0; x < f.Width; x++ {
+			if x+dx < 0 || width <= x+dx {
+				continue
+			}
+			a := color.AlphaModel.Convert(f.Mask.At(x, y)).(color.Alpha)
+			mask.Pix[mask.PixOffset(x+dx, y)] = a.A
+		}
+	}
+	return &Face{
+		Advance: f.Advance,
+		Width:   width,
+		Height:  f.Height,
+		Ascent:  f.Ascent,
+		Descent: f.Descent,
+		Left:    left,
+		Mask:    mask,
+		Ranges:  append([]Range(nil), f.Ranges...),
+	}, mask
+}
+
 func (f *Face) Close() error                   { return nil }
 func (f *Face) Kern(r0, r1 rune) fixed.Int26_6 { return 0 }
 
+// Metrics returns the face's metrics. Its underline is one pixel thick, and
+// is the bottom row of the descent, or the row just below the baseline if
+// there is no descent.
 func (f *Face) Metrics() font.Metrics {
+	underline := f.Descent - 1
+	if underline < 0 {
+		underline = 0
+	}
 	return font.Metrics{
-		Height:  fixed.I(f.Height),
-		Ascent:  fixed.I(f.Ascent),
-		Descent: fixed.I(f.Descent),
+		Height:             fixed.I(f.Height),
+		Ascent:             fixed.I(f.Ascent),
+		Descent:            fixed.I(f.Descent),
+		UnderlinePosition:  fixed.I(underline),
+		UnderlineThickness: fixed.I(1),
 	}
 }
 
+// RoundDot satisfies the font.RoundingFace interface. Glyphs are drawn at the
+// nearest whole pixel to the dot.
+func (f *Face) RoundDot(dot fixed.Point26_6) fixed.Point26_6 {
+	return fixed.P(dot.X.Round(), dot.Y.Round())
+}
+
 func (f *Face) Glyph(dot fixed.Point26_6, r rune) (
 	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
 
@@ -0,0 +1,168 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package basicfont
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestNewFaceFromStrip(t *testing.T) {
+	// The strip is a 2x2 grid of 3x4 cells, offset from the origin, whose
+	// i'th cell has a white pixel at (i%3, i) on a transparent background.
+	strip := image.NewRGBA(image.Rect(10, 20, 16, 28))
+	for i := 0; i < 4; i++ {
+		x := 10 + (i%2)*3 + i%3
+		y := 20 + (i/2)*4 + i
+		strip.Set(x, y, color.White)
+	}
+	f, err := NewFaceFromStrip(strip, 3, 4, 3, 'a', 'e')
+	if err != nil {
+		t.Fatalf("NewFaceFromStrip: %v", err)
+	}
+	if got, want := f.Metrics(), (font.Metrics{
+		Height:             fixed.I(4),
+		Ascent:             fixed.I(3),
+		Descent:            fixed.I(1),
+		UnderlineThickness: fixed.I(1),
+	}); got != want {
+		t.Errorf("Metrics: got %+v, want %+v", got, want)
+	}
+
+	dst := image.NewGray(image.Rect(0, 0, 12, 4))
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.White,
+		Face: f,
+		Dot:  fixed.P(0, 3),
+	}
+	d.DrawString("abcd")
+	want := []string{
+		"#...........",
+		"....#.......",
+		"........#...",
+		".........#..",
+	}
+	for y, row := range want {
+		for x, w := range row {
+			if got := dst.GrayAt(x, y).Y != 0; got != (w == '#') {
+				t.Errorf("(%d, %d): got %t, want %t", x, y, got, w == '#')
+			}
+		}
+	}
+}
+
+func TestNewFaceFromStripErrors(t *testing.T) {
+	strip := image.NewGray(image.Rect(0, 0, 16, 8))
+	testCases := []struct {
+		desc                  string
+		width, height, ascent int
+		low, high             rune
+		want                  error
+	}{
+		{"zero width", 0, 8, 6, 'a', 'b', errInvalidCellSize},
+		{"ascent too big", 8, 8, 9, 'a', 'b', errInvalidCellSize},
+		{"empty range", 8, 8, 6, 'b', 'b', errInvalidRange},
+		{"too many runes", 8, 8, 6, 'a', 'd', errStripTooSmall},
+		{"ok", 8, 8, 6, 'a', 'c', nil},
+	}
+	for _, tc := range testCases {
+		_, err := NewFaceFromStrip(strip, tc.width, tc.height, tc.ascent, tc.low, tc.high)
+		if err != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.desc, err, tc.want)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	f := Scale(Face7x13, 3)
+	if f.Advance != 21 || f.Width != 18 || f.Height != 39 || f.Ascent != 33 || f.Descent != 6 {
+		t.Fatalf("metrics: got advance %d, width %d, height %d, ascent %d, descent %d",
+			f.Advance, f.Width, f.Height, f.Ascent, f.Descent)
+	}
+
+	// Drawing a scaled glyph should give the same pixels as scaling a drawn
+	// glyph.
+	small := image.NewGray(image.Rect(0, 0, 7, 13))
+	(&font.Drawer{Dst: small, Src: image.White, Face: Face7x13, Dot: fixed.P(0, 11)}).DrawString("g")
+	big := image.NewGray(image.Rect(0, 0, 21, 39))
+	(&font.Drawer{Dst: big, Src: image.White, Face: f, Dot: fixed.P(0, 33)}).DrawString("g")
+	ink := 0
+	for y := 0; y < 39; y++ {
+		for x := 0; x < 21; x++ {
+			got, want := big.GrayAt(x, y), small.GrayAt(x/3, y/3)
+			if got != want {
+				t.Fatalf("(%d, %d): got %v, want %v", x, y, got, want)
+			}
+			if got.Y != 0 {
+				ink++
+			}
+		}
+	}
+	if ink == 0 {
+		t.Errorf("no pixels were drawn")
+	}
+}
+
+// drawGray draws s with f to a new Gray image, with the dot at (1, f.Ascent).
+func drawGray(f *Face, s string) *image.Gray {
+	dst := image.NewGray(image.Rect(0, 0, f.Advance*len(s)+2, f.Height))
+	d := &font.Drawer{Dst: dst, Src: image.White, Face: f, Dot: fixed.P(1, f.Ascent)}
+	d.DrawString(s)
+	return dst
+}
+
+func TestEmbolden(t *testing.T) {
+	const s = "Hello, world"
+	plain := drawGray(Face7x13, s)
+	bold := drawGray(Embolden(Face7x13), s)
+	ink := 0
+	for y := 0; y < plain.Rect.Dy(); y++ {
+		for x := 0; x < plain.Rect.Dx(); x++ {
+			want := plain.GrayAt(x, y).Y != 0 || (x > 0 && plain.GrayAt(x-1, y).Y != 0)
+			if got := bold.GrayAt(x, y).Y != 0; got != want {
+				t.Fatalf("(%d, %d): got %t, want %t", x, y, got, want)
+			}
+			if want {
+				ink++
+			}
+		}
+	}
+	if ink == 0 {
+		t.Errorf("no pixels were drawn")
+	}
+}
+
+func TestUnderline(t *testing.T) {
+	const s = "a b"
+	for _, f := range []*Face{Underline(Face7x13), Embolden(Underline(Face7x13)), Underline(Embolden(Face7x13))} {
+		if got, want := f.Metrics(), Face7x13.Metrics(); got != want {
+			t.Errorf("Metrics: got %+v, want %+v", got, want)
+		}
+		plain := drawGray(Face7x13, s)
+		got := drawGray(f, s)
+		// The underline is the bottom row, which is empty for these glyphs
+		// in the plain face, and spans the whole string.
+		y := f.Height - 1
+		for x := 1; x < 1+len(s)*f.Advance; x++ {
+			if plain.GrayAt(x, y).Y != 0 {
+				t.Fatalf("plain (%d, %d): got ink, want none", x, y)
+			}
+			if got.GrayAt(x, y).Y == 0 {
+				t.Errorf("underlined (%d, %d): got no ink, want ink", x, y)
+			}
+		}
+		// The glyphs themselves are still drawn.
+		for i, p := range plain.Pix {
+			if p != 0 && got.Pix[i] == 0 {
+				t.Fatalf("underlined glyphs are missing the pixel at index %d", i)
+			}
+		}
+	}
+}
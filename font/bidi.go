@@ -0,0 +1,279 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// This file implements a simplified version of the Unicode Bidirectional
+// Algorithm, as described at https://www.unicode.org/reports/tr9/
+//
+// It handles a single paragraph without explicit embeddings, overrides or
+// isolates, which is enough to draw a line of mixed left-to-right and
+// right-to-left text, such as English and Hebrew or Arabic, in the right
+// order. The runes' bidirectional character types and mirror images come
+// from the golang.org/x/text/unicode/bidi package.
+
+// bidiClass is a simplified bidirectional character type.
+type bidiClass uint8
+
+const (
+	bidiL   bidiClass = iota // Left-to-right letters.
+	bidiR                    // Right-to-left letters, other than Arabic ones.
+	bidiAL                   // Arabic letters.
+	bidiEN                   // European numbers.
+	bidiAN                   // Arabic numbers.
+	bidiNSM                  // Nonspacing marks, such as combining accents.
+	bidiWS                   // Whitespace and separators.
+	bidiON                   // Other neutrals, such as punctuation.
+)
+
+// bidiClassOf returns r's simplified bidirectional character type.
+func bidiClassOf(r rune) bidiClass {
+	p, _ := bidi.LookupRune(r)
+	switch p.Class() {
+	case bidi.L:
+		return bidiL
+	case bidi.R:
+		return bidiR
+	case bidi.AL:
+		return bidiAL
+	case bidi.EN:
+		return bidiEN
+	case bidi.AN:
+		return bidiAN
+	case bidi.NSM:
+		return bidiNSM
+	case bidi.WS, bidi.S, bidi.B:
+		return bidiWS
+	}
+	return bidiON
+}
+
+// bidiMirror returns r's mirror image, which is drawn instead of r in
+// right-to-left text, if r is a paired bracket, or else r itself.
+func bidiMirror(r rune) rune {
+	if p, _ := bidi.LookupRune(r); p.IsBracket() {
+		// ReverseString replaces each bracket with its counterpart.
+		r, _ = utf8.DecodeRuneInString(bidi.ReverseString(string(r)))
+	}
+	return r
+}
+
+// bidiLevels returns the resolved embedding level of each rune. Even levels
+// are left-to-right and odd levels are right-to-left.
+func bidiLevels(runes []rune, rtl bool) []uint8 {
+	base := uint8(0)
+	if rtl {
+		base = 1
+	}
+	classes := make([]bidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = bidiClassOf(r)
+	}
+
+	// W1: nonspacing marks take the type of the rune before them, or the
+	// paragraph's direction at its start.
+	for i, c := range classes {
+		if c != bidiNSM {
+			continue
+		}
+		switch {
+		case i > 0:
+			classes[i] = classes[i-1]
+		case rtl:
+			classes[i] = bidiR
+		default:
+			classes[i] = bidiL
+		}
+	}
+
+	// W2 and W3: European numbers after Arabic letters are Arabic numbers,
+	// and Arabic letters are right-to-left letters.
+	arabic := false
+	for i, c := range classes {
+		switch c {
+		case bidiL, bidiR:
+			arabic = false
+		case bidiAL:
+			arabic = true
+			classes[i] = bidiR
+		case bidiEN:
+			if arabic {
+				classes[i] = bidiAN
+			}
+		}
+	}
+
+	// N1 and N2: a sequence of neutrals takes the direction of the
+	// surrounding strong text, if both sides agree, and the paragraph's
+	// direction otherwise. Arabic numbers count as right-to-left, and
+	// European numbers take the direction of the strong text before them.
+	dir := func(i int) (isR, ok bool) {
+		switch classes[i] {
+		case bidiL:
+			return false, true
+		case bidiR, bidiAN:
+			return true, true
+		case bidiEN:
+			return precedingStrongIsR(classes[:i], rtl), true
+		}
+		return false, false
+	}
+	resolved := make([]bidiClass, len(classes))
+	copy(resolved, classes)
+	for i := 0; i < len(classes); {
+		if _, ok := dir(i); ok {
+			i++
+			continue
+		}
+		j := i
+		for j < len(classes) {
+			if _, ok := dir(j); ok {
+				break
+			}
+			j++
+		}
+		before, after := rtl, rtl
+		if i > 0 {
+			before, _ = dir(i - 1)
+		}
+		if j < len(classes) {
+			after, _ = dir(j)
+		}
+		c := bidiL
+		if before && after || before != after && rtl {
+			c = bidiR
+		}
+		for k := i; k < j; k++ {
+			resolved[k] = c
+		}
+		i = j
+	}
+
+	// I1 and I2: resolve the implicit levels.
+	levels := make([]uint8, len(runes))
+	for i, c := range resolved {
+		levels[i] = base
+		switch {
+		case base == 0 && c == bidiR:
+			levels[i] = 1
+		case base == 0 && (c == bidiEN || c == bidiAN):
+			levels[i] = 2
+		case base == 1 && (c == bidiL || c == bidiEN || c == bidiAN):
+			levels[i] = 2
+		}
+	}
+
+	// L1: trailing whitespace is at the paragraph's level.
+	for i := len(classes) - 1; i >= 0 && classes[i] == bidiWS; i-- {
+		levels[i] = base
+	}
+	return levels
+}
+
+// precedingStrongIsR returns whether the last strong class in classes is
+// right-to-left, or rtl if there is no strong class.
+func precedingStrongIsR(classes []bidiClass, rtl bool) bool {
+	for i := len(classes) - 1; i >= 0; i-- {
+		switch classes[i] {
+		case bidiL:
+			return false
+		case bidiR:
+			return true
+		}
+	}
+	return rtl
+}
+
+// VisualOrder returns s reordered from logical order, the order in which the
+// text is read, to visual order, the order in which it is drawn from left to
+// right, as per a simplified Unicode Bidirectional Algorithm. Brackets in
+// right-to-left text are replaced by their mirror images, so that, for
+// example, a '(' opens a parenthetical in Hebrew text, on its right, and
+// nonspacing marks, such as Hebrew points, still follow the runes they modify.
+//
+// The paragraph direction is that of the first strongly directional
+// character: right to left if it is Hebrew or Arabic, for example, and left
+// to right otherwise. Explicit directional embeddings, overrides and isolates
+// are not supported.
+func VisualOrder(s string) string {
+	runes := []rune(s)
+	rtl := false
+	for _, r := range runes {
+		if c := bidiClassOf(r); c == bidiL || c == bidiR || c == bidiAL {
+			rtl = c != bidiL
+			break
+		}
+	}
+	levels := bidiLevels(runes, rtl)
+
+	// L4: mirror characters at odd levels.
+	for i, r := range runes {
+		if levels[i]&1 != 0 {
+			runes[i] = bidiMirror(r)
+		}
+	}
+
+	// L2: from the highest level to the lowest odd level, reverse any
+	// contiguous sequence of runes at that level or higher.
+	var highest, lowestOdd uint8 = 0, 0xff
+	for _, l := range levels {
+		if l > highest {
+			highest = l
+		}
+		if l&1 != 0 && l < lowestOdd {
+			lowestOdd = l
+		}
+	}
+	for l := highest; l >= lowestOdd && l > 0; l-- {
+		for i := 0; i < len(runes); {
+			if levels[i] < l {
+				i++
+				continue
+			}
+			j := i
+			for j < len(runes) && levels[j] >= l {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				runes[a], runes[b] = runes[b], runes[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+			i = j
+		}
+	}
+
+	// L3: reversing a right-to-left run moves its nonspacing marks before
+	// the runes that they modify, so move them back after those runes.
+	for i := 0; i < len(runes); {
+		if levels[i]&1 == 0 || bidiClassOf(runes[i]) != bidiNSM {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && levels[j] == levels[i] && bidiClassOf(runes[j]) == bidiNSM {
+			j++
+		}
+		if j < len(runes) && levels[j] == levels[i] {
+			base := runes[j]
+			copy(runes[i+1:j+1], runes[i:j])
+			runes[i] = base
+			j++
+		}
+		i = j
+	}
+	return string(runes)
+}
+
+// DrawBidiString is like DrawString, but first reorders s from logical to
+// visual order, as per VisualOrder, so that mixed left-to-right and
+// right-to-left text is drawn correctly.
+func (d *Drawer) DrawBidiString(s string) {
+	d.DrawString(VisualOrder(s))
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"testing"
+)
+
+func TestVisualOrder(t *testing.T) {
+	const (
+		alef  = "א" // Hebrew letter alef.
+		bet   = "ב" // Hebrew letter bet.
+		gimel = "ג" // Hebrew letter gimel.
+	)
+	testCases := []struct {
+		s, want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"abc 123", "abc 123"},
+		{alef + bet + gimel, gimel + bet + alef},
+		{"abc " + alef + bet + " def", "abc " + bet + alef + " def"},
+		// Neutrals between left-to-right and right-to-left text take the
+		// paragraph's direction.
+		{alef + bet + " abc " + gimel, gimel + " abc " + bet + alef},
+		// Numbers keep their left-to-right order.
+		{alef + " 123 " + bet, bet + " 123 " + alef},
+		// Brackets are mirrored in right-to-left text.
+		{alef + " (" + bet + ")", "(" + bet + ") " + alef},
+		{"a (" + alef + bet + ")", "a (" + bet + alef + ")"},
+		// Trailing whitespace is at the right-to-left paragraph's end, on
+		// its left.
+		{alef + bet + "  ", "  " + bet + alef},
+		// Nonspacing marks follow the runes they modify, and take their
+		// direction.
+		{alef + "\u05b8" + bet, bet + alef + "\u05b8"},
+		{alef + " e\u0301", "e\u0301 " + alef},
+	}
+	for _, tc := range testCases {
+		if got := VisualOrder(tc.s); got != tc.want {
+			t.Errorf("VisualOrder(%q): got %q, want %q", tc.s, got, tc.want)
+		}
+	}
+}
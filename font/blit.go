@@ -0,0 +1,121 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/draw"
+)
+
+// drawGlyph draws a glyph mask, as returned by Face.Glyph, to the drawer's Dst
+// in the drawer's Src color. It is equivalent to
+//
+//	draw.DrawMask(d.Dst, dr, d.Src, image.Point{}, mask, maskp, draw.Over)
+//
+// but is faster for the most common case of an *image.Uniform source, an
+// *image.Alpha mask and an *image.RGBA or *image.NRGBA destination.
+func (d *Drawer) drawGlyph(dr image.Rectangle, mask image.Image, maskp image.Point) {
+	src, ok0 := d.Src.(*image.Uniform)
+	m, ok1 := mask.(*image.Alpha)
+	if ok0 && ok1 {
+		switch dst := d.Dst.(type) {
+		case *image.RGBA:
+			if r, mp, ok := clipGlyph(dst.Rect, dr, m, maskp); ok {
+				drawGlyphRGBA(dst, r, src, m, mp)
+			}
+			return
+		case *image.NRGBA:
+			if r, mp, ok := clipGlyph(dst.Rect, dr, m, maskp); ok {
+				drawGlyphNRGBA(dst, r, src, m, mp)
+			}
+			return
+		}
+	}
+	draw.DrawMask(d.Dst, dr, d.Src, image.Point{}, mask, maskp, draw.Over)
+}
+
+// clipGlyph clips the destination rectangle dr, whose top-left corner
+// corresponds to the mask's point mp, to the destination bounds b and to the
+// mask's bounds. It returns !ok if nothing is left to draw.
+func clipGlyph(b, dr image.Rectangle, m *image.Alpha, mp image.Point) (image.Rectangle, image.Point, bool) {
+	r := dr.Intersect(b).Intersect(m.Rect.Add(dr.Min.Sub(mp)))
+	if r.Empty() {
+		return image.Rectangle{}, image.Point{}, false
+	}
+	return r, mp.Add(r.Min.Sub(dr.Min)), true
+}
+
+// drawGlyphRGBA draws src through the mask m onto dst, with the Over
+// operator, as the standard library's image/draw package does.
+func drawGlyphRGBA(dst *image.RGBA, r image.Rectangle, src *image.Uniform, m *image.Alpha, mp image.Point) {
+	const max = 0xffff
+	sr, sg, sb, sa := src.RGBA()
+	i0 := dst.PixOffset(r.Min.X, r.Min.Y)
+	mi0 := m.PixOffset(mp.X, mp.Y)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		d := dst.Pix[i0 : i0+4*r.Dx()]
+		for x, ma := range m.Pix[mi0 : mi0+r.Dx()] {
+			if ma == 0 {
+				continue
+			}
+			a16 := uint32(ma) * 0x101
+			a := (max - (sa * a16 / max)) * 0x101
+			p := d[4*x : 4*x+4 : 4*x+4]
+			p[0] = uint8((uint32(p[0])*a + sr*a16) / max >> 8)
+			p[1] = uint8((uint32(p[1])*a + sg*a16) / max >> 8)
+			p[2] = uint8((uint32(p[2])*a + sb*a16) / max >> 8)
+			p[3] = uint8((uint32(p[3])*a + sa*a16) / max >> 8)
+		}
+		i0 += dst.Stride
+		mi0 += m.Stride
+	}
+}
+
+// drawGlyphNRGBA draws src through the mask m onto dst, with the Over
+// operator, as the standard library's image/draw package does.
+func drawGlyphNRGBA(dst *image.NRGBA, r image.Rectangle, src *image.Uniform, m *image.Alpha, mp image.Point) {
+	const max = 0xffff
+	sr, sg, sb, sa := src.RGBA()
+	i0 := dst.PixOffset(r.Min.X, r.Min.Y)
+	mi0 := m.PixOffset(mp.X, mp.Y)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		d := dst.Pix[i0 : i0+4*r.Dx()]
+		for x, ma := range m.Pix[mi0 : mi0+r.Dx()] {
+			if ma == 0 {
+				continue
+			}
+			p := d[4*x : 4*x+4 : 4*x+4]
+			// Convert the destination to premultiplied alpha, as
+			// color.NRGBA.RGBA does.
+			da := uint32(p[3]) * 0x101
+			dr := uint32(p[0]) * 0x101 * uint32(p[3]) / 0xff
+			dg := uint32(p[1]) * 0x101 * uint32(p[3]) / 0xff
+			db := uint32(p[2]) * 0x101 * uint32(p[3]) / 0xff
+
+			a16 := uint32(ma) * 0x101
+			a := max - (sa * a16 / max)
+			dr = (dr*a + sr*a16) / max
+			dg = (dg*a + sg*a16) / max
+			db = (db*a + sb*a16) / max
+			da = (da*a + sa*a16) / max
+
+			// Convert back to non-premultiplied alpha, as color.NRGBAModel
+			// does.
+			switch da {
+			case 0:
+				p[0], p[1], p[2], p[3] = 0, 0, 0, 0
+			case max:
+				p[0], p[1], p[2], p[3] = uint8(dr>>8), uint8(dg>>8), uint8(db>>8), 0xff
+			default:
+				p[0] = uint8(dr * max / da >> 8)
+				p[1] = uint8(dg * max / da >> 8)
+				p[2] = uint8(db * max / da >> 8)
+				p[3] = uint8(da >> 8)
+			}
+		}
+		i0 += dst.Stride
+		mi0 += m.Stride
+	}
+}
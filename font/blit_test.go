@@ -0,0 +1,123 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// alphaFace is a Face whose glyphs are all the same *image.Alpha mask.
+type alphaFace struct {
+	rangeFace
+	mask *image.Alpha
+}
+
+func (f alphaFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	p := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor() - f.mask.Rect.Dy()}
+	dr := image.Rectangle{Min: p, Max: p.Add(f.mask.Rect.Size())}
+	return dr, f.mask, f.mask.Rect.Min, f.advance, true
+}
+
+func newAlphaFace(rng *rand.Rand) alphaFace {
+	mask := image.NewAlpha(image.Rect(3, 4, 13, 18))
+	for i := range mask.Pix {
+		switch rng.Intn(3) {
+		case 0:
+			mask.Pix[i] = 0
+		case 1:
+			mask.Pix[i] = 0xff
+		default:
+			mask.Pix[i] = uint8(rng.Intn(256))
+		}
+	}
+	return alphaFace{rangeFace: rangeFace{advance: fixed.I(8), closed: new(int)}, mask: mask}
+}
+
+func TestDrawGlyph(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	face := newAlphaFace(rng)
+	srcs := []color.Color{
+		color.NRGBA{0x40, 0x80, 0xc0, 0xff},
+		color.NRGBA{0x40, 0x80, 0xc0, 0x80},
+		color.Transparent,
+	}
+	dsts := []func() draw.Image{
+		func() draw.Image { return image.NewRGBA(image.Rect(0, 0, 40, 20)) },
+		func() draw.Image { return image.NewNRGBA(image.Rect(0, 0, 40, 20)) },
+	}
+	for _, newDst := range dsts {
+		for _, src := range srcs {
+			got, want := newDst(), newDst()
+			for _, dst := range []draw.Image{got, want} {
+				// Fill the destinations with the same random pixels.
+				rng := rand.New(rand.NewSource(2))
+				b := dst.Bounds()
+				for y := b.Min.Y; y < b.Max.Y; y++ {
+					for x := b.Min.X; x < b.Max.X; x++ {
+						dst.Set(x, y, color.NRGBA{
+							uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)),
+						})
+					}
+				}
+			}
+			// The string runs off the destination's left and right edges.
+			d := &Drawer{Dst: got, Src: image.NewUniform(src), Face: face, Dot: fixed.P(-4, 16)}
+			d.DrawString("abcdef")
+			d = &Drawer{Dst: want, Src: image.NewUniform(src), Face: face, Dot: fixed.P(-4, 16)}
+			dot := d.Dot
+			for i := 0; i < 6; i++ {
+				dr, mask, maskp, advance, _ := face.Glyph(dot, 'a')
+				draw.DrawMask(want, dr, d.Src, image.Point{}, mask, maskp, draw.Over)
+				dot.X += advance
+			}
+			if !bytes.Equal(pix(got), pix(want)) {
+				t.Errorf("%T, %v: pixels differ from draw.DrawMask", got, src)
+			}
+		}
+	}
+}
+
+func pix(m image.Image) []byte {
+	switch m := m.(type) {
+	case *image.RGBA:
+		return m.Pix
+	case *image.NRGBA:
+		return m.Pix
+	}
+	panic("unsupported image type")
+}
+
+func benchmarkDrawString(b *testing.B, dst draw.Image) {
+	d := &Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(color.NRGBA{0x40, 0x80, 0xc0, 0xff}),
+		Face: newAlphaFace(rand.New(rand.NewSource(1))),
+	}
+	const s = "The quick brown fox jumps over the lazy dog."
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Dot = fixed.P(0, 16)
+		d.DrawString(s)
+	}
+}
+
+func BenchmarkDrawStringRGBA(b *testing.B) {
+	benchmarkDrawString(b, image.NewRGBA(image.Rect(0, 0, 400, 20)))
+}
+
+func BenchmarkDrawStringNRGBA(b *testing.B) {
+	benchmarkDrawString(b, image.NewNRGBA(image.Rect(0, 0, 400, 20)))
+}
+
+func BenchmarkDrawStringGray(b *testing.B) {
+	benchmarkDrawString(b, image.NewGray(image.Rect(0, 0, 400, 20)))
+}
@@ -0,0 +1,285 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"container/list"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultCacheSize is the byte budget of a Cache whose NewCache maxBytes
+// argument is not positive.
+const DefaultCacheSize = 1 << 20
+
+// cacheEntryOverhead is the approximate size, in bytes, of a cache entry
+// other than its mask's pixels.
+const cacheEntryOverhead = 128
+
+// Cache is a Face that memoizes the Glyph, GlyphBounds and GlyphAdvance
+// results of another Face. It is safe for concurrent use by multiple
+// goroutines, even if the wrapped Face is not, as long as the wrapped Face is
+// not also used directly.
+//
+// The Cache also forwards the optional ClusterFace, ColorFace,
+// DecorationFace, RoundingFace and VerticalFace methods to the wrapped Face, without caching
+// their results.
+//
+// Cached glyph masks are copied, so they stay valid after later calls, and
+// are keyed by the sub-pixel position of the dot as well as the rune. When the
+// cache exceeds its byte budget, the least recently used results are evicted.
+type Cache struct {
+	mu       sync.Mutex
+	f        Face
+	maxBytes int
+	nBytes   int
+
+	// entries maps a cacheKey to an element of the lru list, whose value is
+	// a *cacheEntry. The front of the list is the most recently used entry.
+	entries map[cacheKey]*list.Element
+	lru     list.List
+}
+
+// cacheKind is the Face method whose result a cacheEntry holds.
+type cacheKind uint8
+
+const (
+	cacheGlyph cacheKind = iota
+	cacheGlyphBounds
+	cacheGlyphAdvance
+)
+
+// cacheKey is the key of a cached result. For Glyph results, subpixel is the
+// dot's offset within its pixel.
+type cacheKey struct {
+	kind     cacheKind
+	r        rune
+	subpixel fixed.Point26_6
+}
+
+type cacheEntry struct {
+	key cacheKey
+	ok  bool
+
+	// dr is relative to the dot's whole pixel position.
+	dr      image.Rectangle
+	mask    *image.Alpha
+	bounds  fixed.Rectangle26_6
+	advance fixed.Int26_6
+}
+
+func (e *cacheEntry) size() int {
+	n := cacheEntryOverhead
+	if e.mask != nil {
+		n += len(e.mask.Pix)
+	}
+	return n
+}
+
+// NewCache returns a Cache that wraps f, whose cached results take up about
+// maxBytes of memory at most. If maxBytes is not positive, DefaultCacheSize is
+// used.
+func NewCache(f Face, maxBytes int) *Cache {
+	if maxBytes <= 0 {
+		maxBytes = DefaultCacheSize
+	}
+	return &Cache{
+		f:        f,
+		maxBytes: maxBytes,
+		entries:  map[cacheKey]*list.Element{},
+	}
+}
+
+// Close implements the Face interface. It closes the wrapped Face and empties
+// the cache.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[cacheKey]*list.Element{}
+	c.lru.Init()
+	c.nBytes = 0
+	return c.f.Close()
+}
+
+// Glyph implements the Face interface.
+func (c *Cache) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	origin := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor()}
+	k := cacheKey{
+		kind:     cacheGlyph,
+		r:        r,
+		subpixel: fixed.Point26_6{X: dot.X & 63, Y: dot.Y & 63},
+	}
+	e := c.lookup(k)
+	if e == nil {
+		e = &cacheEntry{key: k}
+		var m image.Image
+		var mp image.Point
+		e.dr, m, mp, e.advance, e.ok = c.f.Glyph(dot, r)
+		if e.ok {
+			e.mask = image.NewAlpha(image.Rectangle{Max: e.dr.Size()})
+			draw.Draw(e.mask, e.mask.Rect, m, mp, draw.Src)
+			e.dr = e.dr.Sub(origin)
+		}
+		c.add(e)
+	}
+	if !e.ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	return e.dr.Add(origin), e.mask, image.Point{}, e.advance, true
+}
+
+// GlyphBounds implements the Face interface.
+func (c *Cache) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := cacheKey{kind: cacheGlyphBounds, r: r}
+	e := c.lookup(k)
+	if e == nil {
+		e = &cacheEntry{key: k}
+		e.bounds, e.advance, e.ok = c.f.GlyphBounds(r)
+		c.add(e)
+	}
+	return e.bounds, e.advance, e.ok
+}
+
+// GlyphAdvance implements the Face interface.
+func (c *Cache) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := cacheKey{kind: cacheGlyphAdvance, r: r}
+	e := c.lookup(k)
+	if e == nil {
+		e = &cacheEntry{key: k}
+		e.advance, e.ok = c.f.GlyphAdvance(r)
+		c.add(e)
+	}
+	return e.advance, e.ok
+}
+
+// Kern implements the Face interface. Kerns are not cached.
+func (c *Cache) Kern(r0, r1 rune) fixed.Int26_6 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.f.Kern(r0, r1)
+}
+
+// Metrics implements the Face interface.
+func (c *Cache) Metrics() Metrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.f.Metrics()
+}
+
+// RoundDot satisfies the RoundingFace interface. It returns the wrapped
+// Face's rounding of dot, if it is a RoundingFace, or else dot unchanged.
+func (c *Cache) RoundDot(dot fixed.Point26_6) fixed.Point26_6 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rf, ok := c.f.(RoundingFace); ok {
+		return rf.RoundDot(dot)
+	}
+	return dot
+}
+
+func (c *Cache) roundRuneDot(dot fixed.Point26_6, r rune) fixed.Point26_6 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return roundDot(c.f, dot, r)
+}
+
+// ClusterGlyph satisfies the ClusterFace interface. It returns the wrapped
+// Face's glyph for the cluster, if it is a ClusterFace. Cluster glyphs are
+// not cached.
+func (c *Cache) ClusterGlyph(dot fixed.Point26_6, cluster string) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return clusterGlyph(c.f, dot, cluster)
+}
+
+// ClusterAdvance satisfies the ClusterFace interface.
+func (c *Cache) ClusterAdvance(cluster string) (advance fixed.Int26_6, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return clusterAdvance(c.f, cluster)
+}
+
+// ColorGlyph satisfies the ColorFace interface. It returns the wrapped Face's
+// color glyph, if it is a ColorFace. Color glyphs are not cached.
+func (c *Cache) ColorGlyph(dot fixed.Point26_6, r rune, fg color.Color) (
+	dr image.Rectangle, src image.Image, sp image.Point, advance fixed.Int26_6, ok bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cf, isColor := c.f.(ColorFace); isColor {
+		return cf.ColorGlyph(dot, r, fg)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+// DecorationMetrics satisfies the DecorationFace interface. It returns the
+// wrapped Face's decoration metrics, if it is a DecorationFace, or else the
+// zero value.
+func (c *Cache) DecorationMetrics() DecorationMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if df, ok := c.f.(DecorationFace); ok {
+		return df.DecorationMetrics()
+	}
+	return DecorationMetrics{}
+}
+
+// VerticalGlyph satisfies the VerticalFace interface. It returns the wrapped
+// Face's vertical glyph, or its horizontal glyph stacked as per
+// Drawer.DrawStringVertical if it is not a VerticalFace. Vertical glyphs are
+// not cached.
+func (c *Cache) VerticalGlyph(dot fixed.Point26_6, r rune) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return verticalGlyph(c.f, dot, r)
+}
+
+// VerticalGlyphAdvance satisfies the VerticalFace interface.
+func (c *Cache) VerticalGlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return verticalGlyphAdvance(c.f, r)
+}
+
+// lookup returns the cached entry for k, or nil if there is none.
+func (c *Cache) lookup(k cacheKey) *cacheEntry {
+	el, ok := c.entries[k]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry)
+}
+
+// add adds e to the cache, evicting the least recently used entries if the
+// cache is over its budget.
+func (c *Cache) add(e *cacheEntry) {
+	c.entries[e.key] = c.lru.PushFront(e)
+	c.nBytes += e.size()
+	for c.nBytes > c.maxBytes && c.lru.Len() > 1 {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		old := el.Value.(*cacheEntry)
+		delete(c.entries, old.key)
+		c.nBytes -= old.size()
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"sync"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// countingFace is a Face that counts the calls to its Glyph method, and whose
+// masks are overwritten by each call, like those of some real faces.
+type countingFace struct {
+	rangeFace
+	glyphs int
+	mask   *image.Alpha
+}
+
+func (f *countingFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	f.glyphs++
+	dr, _, _, advance, ok := f.rangeFace.Glyph(dot, r)
+	if !ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	// The mask's alpha is the rune, and its origin is not at (0, 0).
+	f.mask = image.NewAlpha(image.Rect(100, 100, 100+dr.Dx(), 100+dr.Dy()))
+	for i := range f.mask.Pix {
+		f.mask.Pix[i] = uint8(r)
+	}
+	return dr, f.mask, f.mask.Rect.Min, advance, true
+}
+
+func TestCache(t *testing.T) {
+	f := &countingFace{rangeFace: rangeFace{lo: 'a', hi: 'z', advance: fixed.I(5), closed: new(int)}}
+	c := NewCache(f, 1000)
+
+	dr, mask, maskp, advance, ok := c.Glyph(fixed.P(10, 20), 'a')
+	if !ok || dr != image.Rect(10, 20, 15, 21) || advance != fixed.I(5) {
+		t.Fatalf("Glyph: got %v, %v, %v", dr, advance, ok)
+	}
+	// The cached mask is a copy, which later calls to the wrapped face do not
+	// change, and it is reused for a dot at another whole pixel position.
+	c.Glyph(fixed.P(0, 0), 'b')
+	if _, a, _, _ := mask.At(maskp.X, maskp.Y).RGBA(); a>>8 != 'a' {
+		t.Errorf("mask alpha: got %#x, want %#x", a>>8, 'a')
+	}
+	dr, _, _, _, _ = c.Glyph(fixed.P(30, 40), 'a')
+	if dr != image.Rect(30, 40, 35, 41) {
+		t.Errorf("second Glyph: got %v", dr)
+	}
+	if f.glyphs != 2 {
+		t.Errorf("wrapped Glyph calls: got %d, want 2", f.glyphs)
+	}
+	// Another sub-pixel position is another mask.
+	c.Glyph(fixed.Point26_6{X: 32}, 'a')
+	if f.glyphs != 3 {
+		t.Errorf("wrapped Glyph calls: got %d, want 3", f.glyphs)
+	}
+	// Missing glyphs are cached too.
+	for i := 0; i < 2; i++ {
+		if _, _, _, _, ok := c.Glyph(fixed.P(0, 0), 'A'); ok {
+			t.Errorf("Glyph('A'): got ok")
+		}
+	}
+	if f.glyphs != 4 {
+		t.Errorf("wrapped Glyph calls: got %d, want 4", f.glyphs)
+	}
+
+	// Filling the cache evicts the least recently used entries.
+	for r := 'c'; r <= 'z'; r++ {
+		c.Glyph(fixed.P(0, 0), r)
+	}
+	if c.nBytes > c.maxBytes {
+		t.Errorf("cache size: got %d, want at most %d", c.nBytes, c.maxBytes)
+	}
+	f.glyphs = 0
+	c.Glyph(fixed.P(10, 20), 'a')
+	if f.glyphs != 1 {
+		t.Errorf("wrapped Glyph calls after eviction: got %d, want 1", f.glyphs)
+	}
+
+	if err := c.Close(); err != nil || *f.closed != 1 {
+		t.Errorf("Close: got %v, %d closes", err, *f.closed)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(&countingFace{rangeFace: rangeFace{lo: 'a', hi: 'z', advance: fixed.I(5), closed: new(int)}}, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 'a'; r <= 'z'; r++ {
+				c.Glyph(fixed.P(0, 0), r)
+				c.GlyphBounds(r)
+				c.GlyphAdvance(r)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// ColorFace is a Face that can also draw color glyphs, such as emoji.
+//
+// A Drawer whose Face is a ColorFace draws a rune's color glyph, if it has
+// one, instead of masking its Src with the rune's glyph.
+type ColorFace interface {
+	Face
+
+	// ColorGlyph returns the draw.Draw parameters (dr, src, sp) to draw r's
+	// color glyph at the sub-pixel destination location dot, with the
+	// draw.Over operator, and that glyph's advance width. fg is the text's
+	// foreground color, which some color glyphs use for some of their parts.
+	//
+	// It returns !ok if the face does not contain a color glyph for r, in
+	// which case r's glyph, if any, should be drawn as per the Glyph method.
+	//
+	// As for Glyph, the contents of the src image returned by one ColorGlyph
+	// call may change after the next call.
+	ColorGlyph(dot fixed.Point26_6, r rune, fg color.Color) (
+		dr image.Rectangle, src image.Image, sp image.Point, advance fixed.Int26_6, ok bool)
+}
+
+// drawRune draws c's glyph at dot. The glyph is c's color glyph if the Face
+// is a ColorFace that has one. It returns the pixels drawn to and an image
+// whose alpha channel is the glyph's coverage of them, and !ok if the Face
+// has no glyph for c.
+func (d *Drawer) drawRune(dot fixed.Point26_6, c rune) (dr image.Rectangle, ink image.Image, inkp image.Point, advance fixed.Int26_6, ok bool) {
+	if cf, isColor := d.Face.(ColorFace); isColor {
+		if dr, src, sp, advance, ok := cf.ColorGlyph(dot, c, d.foreground()); ok {
+			draw.Draw(d.Dst, dr, src, sp, draw.Over)
+			return dr, src, sp, advance, true
+		}
+	}
+	dr, mask, maskp, advance, ok := d.Face.Glyph(dot, c)
+	if !ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	d.drawGlyph(dr, mask, maskp)
+	return dr, mask, maskp, advance, true
+}
+
+// foreground returns the text color to pass to ColorGlyph: the drawer's Src
+// if it is uniform, and opaque black otherwise.
+func (d *Drawer) foreground() color.Color {
+	if u, ok := d.Src.(*image.Uniform); ok {
+		return u.C
+	}
+	return color.Black
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// colorFace is a rangeFace whose 'a' glyph is a red color glyph, as wide as
+// the advance and two pixels tall. It records the foreground colors that it
+// is passed.
+type colorFace struct {
+	rangeFace
+	fgs *[]color.Color
+}
+
+func (f colorFace) ColorGlyph(dot fixed.Point26_6, r rune, fg color.Color) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	*f.fgs = append(*f.fgs, fg)
+	if r != 'a' {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	p := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor() - 1}
+	dr := image.Rectangle{Min: p, Max: p.Add(image.Pt(f.advance.Floor(), 2))}
+	return dr, image.NewUniform(color.RGBA{0xff, 0x00, 0x00, 0xff}), image.Point{}, f.advance, true
+}
+
+func TestDrawerColorFace(t *testing.T) {
+	var fgs []color.Color
+	blue := color.RGBA{0x00, 0x00, 0xff, 0xff}
+	dst := image.NewRGBA(image.Rect(0, 0, 12, 4))
+	d := &Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(blue),
+		Face: colorFace{rangeFace{lo: 'a', hi: 'z', advance: fixed.I(4), closed: new(int)}, &fgs},
+		Dot:  fixed.P(0, 2),
+	}
+	d.DrawString("bab")
+
+	if len(fgs) != 3 {
+		t.Fatalf("ColorGlyph calls: got %d, want 3", len(fgs))
+	}
+	for i, fg := range fgs {
+		if fg != color.Color(blue) {
+			t.Errorf("ColorGlyph call #%d: got fg %v, want %v", i, fg, blue)
+		}
+	}
+	want := []string{
+		"............",
+		"....rrrr....",
+		"bbbbrrrrbbbb",
+		"............",
+	}
+	for y, row := range want {
+		for x, w := range row {
+			var c color.RGBA
+			switch w {
+			case 'r':
+				c = color.RGBA{0xff, 0x00, 0x00, 0xff}
+			case 'b':
+				c = blue
+			}
+			if got := dst.RGBAAt(x, y); got != c {
+				t.Errorf("(%d, %d): got %v, want %v", x, y, got, c)
+			}
+		}
+	}
+	if got, want := d.Dot.X, fixed.I(12); got != want {
+		t.Errorf("dot: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/draw"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// DecorationMetrics holds the metrics for drawing underlines and
+// strikethroughs. As for Metrics, the y axis increases down.
+type DecorationMetrics struct {
+	// UnderlineOffset is the distance from the baseline down to the top of
+	// the underline, and is typically positive. UnderlineThickness is the
+	// underline's thickness.
+	UnderlineOffset    fixed.Int26_6
+	UnderlineThickness fixed.Int26_6
+
+	// StrikethroughOffset is the distance from the baseline down to the top
+	// of the strikethrough, and is typically negative.
+	// StrikethroughThickness is the strikethrough's thickness.
+	StrikethroughOffset    fixed.Int26_6
+	StrikethroughThickness fixed.Int26_6
+}
+
+// DecorationFace is a Face that also provides underline and strikethrough
+// metrics.
+type DecorationFace interface {
+	Face
+
+	// DecorationMetrics returns the metrics for drawing underlines and
+	// strikethroughs.
+	DecorationMetrics() DecorationMetrics
+}
+
+// Decoration is a set of lines that a Drawer draws along with text.
+type Decoration uint8
+
+const (
+	// DecorationUnderline is a line below the baseline.
+	DecorationUnderline Decoration = 1 << iota
+	// DecorationStrikethrough is a line through the middle of lowercase
+	// letters.
+	DecorationStrikethrough
+	// DecorationOverline is a line at the ascent, above the text.
+	DecorationOverline
+)
+
+// decorationMetrics returns the drawer's Face's decoration metrics, or
+// metrics derived from its Metrics if it is not a DecorationFace. The
+// underline is as per the Metrics' underline fields, if they are set.
+func (d *Drawer) decorationMetrics() DecorationMetrics {
+	if df, ok := d.Face.(DecorationFace); ok {
+		if m := df.DecorationMetrics(); m.UnderlineThickness > 0 {
+			return m
+		}
+	}
+	m := d.Face.Metrics()
+	t := (m.Ascent + m.Descent) / 16
+	if t < fixed.I(1) {
+		t = fixed.I(1)
+	}
+	dm := DecorationMetrics{
+		UnderlineOffset:        m.Descent / 2,
+		UnderlineThickness:     t,
+		StrikethroughOffset:    -m.Ascent/4 - t/2,
+		StrikethroughThickness: t,
+	}
+	if m.UnderlineThickness > 0 {
+		dm.UnderlineOffset = m.UnderlinePosition
+		dm.UnderlineThickness = m.UnderlineThickness
+	}
+	return dm
+}
+
+// decorator draws a Drawer's decorations for one DrawString or DrawBytes
+// call. It is created before any glyphs are drawn, told about each glyph, and
+// then draws the decorations.
+type decorator struct {
+	d        *Drawer
+	m        DecorationMetrics
+	x0       fixed.Int26_6
+	baseline fixed.Int26_6
+
+	// underline is the underline's pixels. gaps are the ranges of columns,
+	// [gaps[2*i], gaps[2*i+1]), where the underline is interrupted so that
+	// it does not cross the glyphs' descenders.
+	underline image.Rectangle
+	gaps      []int
+}
+
+// newDecorator returns a decorator for text drawn from the drawer's dot, or
+// nil if the drawer has no decorations.
+func (d *Drawer) newDecorator() *decorator {
+	if d.Decorations == 0 {
+		return nil
+	}
+	z := &decorator{
+		d:        d,
+		m:        d.decorationMetrics(),
+		x0:       d.Dot.X,
+		baseline: d.Dot.Y,
+	}
+	z.underline = z.band(z.m.UnderlineOffset, z.m.UnderlineThickness)
+	return z
+}
+
+// band returns the pixel rows for a line of the given offset below the
+// baseline and thickness, spanning all columns.
+func (z *decorator) band(offset, thickness fixed.Int26_6) image.Rectangle {
+	y0 := (z.baseline + offset).Round()
+	h := thickness.Round()
+	if h < 1 {
+		h = 1
+	}
+	return image.Rect(-1<<30, y0, 1<<30, y0+h)
+}
+
+// glyph notes a drawn glyph's mask, so that the underline can skip its ink.
+func (z *decorator) glyph(dr image.Rectangle, mask image.Image, maskp image.Point) {
+	if z.d.Decorations&DecorationUnderline == 0 || !z.d.DecorationSkipInk {
+		return
+	}
+	// Leave a gap, as wide as the underline is thick, around the ink.
+	gap := z.underline.Dy()
+	band := z.underline
+	band.Min.Y -= gap
+	band.Max.Y += gap
+	r := dr.Intersect(band)
+	inked := -1
+	for x := r.Min.X; x <= r.Max.X; x++ {
+		ink := false
+		for y := r.Min.Y; y < r.Max.Y && x < r.Max.X; y++ {
+			p := maskp.Add(image.Point{X: x, Y: y}.Sub(dr.Min))
+			if _, _, _, a := mask.At(p.X, p.Y).RGBA(); a != 0 {
+				ink = true
+				break
+			}
+		}
+		if ink && inked < 0 {
+			inked = x
+		} else if !ink && inked >= 0 {
+			z.gaps = append(z.gaps, inked-gap, x+gap)
+			inked = -1
+		}
+	}
+}
+
+// draw draws the decorations from where the text started to x1.
+func (z *decorator) draw(x1 fixed.Int26_6) {
+	px0, px1 := z.x0.Round(), x1.Round()
+	fill := func(r image.Rectangle) {
+		r.Min.X, r.Max.X = px0, px1
+		draw.Draw(z.d.Dst, r, z.d.Src, image.Point{}, draw.Over)
+	}
+	if z.d.Decorations&DecorationUnderline != 0 {
+		// Draw the underline between the gaps.
+		x := px0
+		for x < px1 {
+			end := px1
+			for i := 0; i < len(z.gaps); i += 2 {
+				if g0, g1 := z.gaps[i], z.gaps[i+1]; g0 <= x && x < g1 {
+					x, end = g1, x
+					break
+				} else if x < g0 && g0 < end {
+					end = g0
+				}
+			}
+			if x > end {
+				// x was in a gap and has moved to its end.
+				continue
+			}
+			r := z.underline
+			r.Min.X, r.Max.X = x, end
+			draw.Draw(z.d.Dst, r, z.d.Src, image.Point{}, draw.Over)
+			x = end
+		}
+	}
+	if z.d.Decorations&DecorationStrikethrough != 0 {
+		fill(z.band(z.m.StrikethroughOffset, z.m.StrikethroughThickness))
+	}
+	if z.d.Decorations&DecorationOverline != 0 {
+		fill(z.band(-z.d.Face.Metrics().Ascent, z.m.UnderlineThickness))
+	}
+}
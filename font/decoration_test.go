@@ -0,0 +1,149 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// descenderFace is a Face whose glyphs are 6x5 pixel blocks sitting on the
+// baseline, 2 pixels after the dot. The 'g' glyph also has a 2 pixel wide
+// descender, 4 pixels deep, at its right edge.
+type descenderFace struct {
+	rangeFace
+}
+
+func (f descenderFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	mask := image.NewAlpha(image.Rect(0, -5, 6, 4))
+	for y := -5; y < 4; y++ {
+		for x := 0; x < 6; x++ {
+			if y < 0 || (r == 'g' && x >= 4) {
+				mask.SetAlpha(x, y, color.Alpha{0xff})
+			}
+		}
+	}
+	p := image.Point{X: dot.X.Floor() + 2, Y: dot.Y.Floor()}
+	return mask.Rect.Add(p), mask, mask.Rect.Min, f.advance, true
+}
+
+func (f descenderFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	if r == 'g' {
+		return fixed.R(2, -5, 8, 4), f.advance, true
+	}
+	return fixed.R(2, -5, 8, 0), f.advance, true
+}
+
+// decorationFace is a descenderFace with explicit DecorationMetrics.
+type decorationFace struct {
+	descenderFace
+	m DecorationMetrics
+}
+
+func (f decorationFace) DecorationMetrics() DecorationMetrics {
+	return f.m
+}
+
+func TestDrawerDecorations(t *testing.T) {
+	df := descenderFace{rangeFace{
+		lo: 'a', hi: 'z', advance: fixed.I(10),
+		metrics: Metrics{Height: fixed.I(12), Ascent: fixed.I(8), Descent: fixed.I(4)},
+		closed:  new(int),
+	}}
+	underlined := df
+	underlined.metrics.UnderlinePosition = fixed.I(3)
+	underlined.metrics.UnderlineThickness = fixed.I(2)
+	explicit := decorationFace{df, DecorationMetrics{
+		UnderlineOffset:        fixed.I(2),
+		UnderlineThickness:     fixed.I(1),
+		StrikethroughOffset:    -fixed.I(4),
+		StrikethroughThickness: fixed.I(2),
+	}}
+
+	// The text is drawn from x=2 to x=22 with its baseline at y=10. The 'g'
+	// descender occupies columns 18 and 19 and rows 10 to 13.
+	const (
+		blank  = "........................"
+		full   = "..####################.."
+		glyphs = "....######....######...."
+		stem   = "..................##...."
+	)
+	testCases := []struct {
+		desc    string
+		face    Face
+		decs    Decoration
+		skipInk bool
+		rows    map[int]string
+	}{{
+		desc: "none",
+		face: explicit,
+		rows: map[int]string{2: blank, 6: glyphs, 11: stem, 12: stem},
+	}, {
+		desc: "underline",
+		face: explicit,
+		decs: DecorationUnderline,
+		rows: map[int]string{11: stem, 12: full, 13: stem},
+	}, {
+		desc:    "underline skip ink",
+		face:    explicit,
+		decs:    DecorationUnderline,
+		skipInk: true,
+		rows:    map[int]string{11: stem, 12: "..###############.##.#..", 13: stem},
+	}, {
+		desc: "strikethrough",
+		face: explicit,
+		decs: DecorationStrikethrough,
+		rows: map[int]string{5: glyphs, 6: full, 7: full, 8: glyphs, 12: stem},
+	}, {
+		desc: "overline",
+		face: explicit,
+		decs: DecorationOverline,
+		rows: map[int]string{1: blank, 2: full, 3: blank},
+	}, {
+		desc: "fallback metrics",
+		face: df,
+		decs: DecorationUnderline | DecorationStrikethrough,
+		rows: map[int]string{7: glyphs, 8: full, 9: glyphs, 11: stem, 12: full, 13: stem},
+	}, {
+		desc: "metrics underline",
+		face: underlined,
+		decs: DecorationUnderline,
+		rows: map[int]string{12: stem, 13: full, 14: full, 15: blank},
+	}}
+
+	for _, tc := range testCases {
+		dst := image.NewGray(image.Rect(0, 0, 24, 16))
+		d := &Drawer{
+			Dst:               dst,
+			Src:               image.White,
+			Face:              tc.face,
+			Dot:               fixed.P(2, 10),
+			Decorations:       tc.decs,
+			DecorationSkipInk: tc.skipInk,
+		}
+		d.DrawString("ag")
+		for y, want := range tc.rows {
+			got := make([]byte, dst.Rect.Dx())
+			for x := range got {
+				got[x] = '.'
+				if dst.GrayAt(x, y).Y != 0 {
+					got[x] = '#'
+				}
+			}
+			if string(got) != want {
+				t.Errorf("%s: row %d:\ngot  %s\nwant %s", tc.desc, y, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/color"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// FallbackOptions are optional arguments to NewFallbackFace.
+type FallbackOptions struct {
+	// Face, if non-nil, draws the runes that the wrapped face has no glyph
+	// for, if it has glyphs for them.
+	Face Face
+
+	// Replacement is whether to draw the wrapped face's U+FFFD REPLACEMENT
+	// CHARACTER glyph, if it has one, for the runes that neither the wrapped
+	// face nor the fallback Face have glyphs for.
+	Replacement bool
+
+	// Missing, if non-nil, is called with each rune that the wrapped face has
+	// no glyph for, whenever its glyph, bounds or advance is asked for. It
+	// can be called more than once for the same rune, even when drawing a
+	// single string, as measuring and drawing both ask for advances.
+	Missing func(r rune)
+}
+
+// NewFallbackFace returns a Face that draws a visible glyph for every rune
+// that f has no glyph for, instead of nothing. Such a rune is drawn with, in
+// order of preference, the Face given in opts, f's replacement character as
+// per opts, and a box containing the rune's code point in hexadecimal
+// digits, as for a missing glyph "tofu" box. The box's digits are only drawn
+// if the face's ascent is at least 16 pixels; at smaller sizes, it is empty.
+//
+// Control characters, such as '\n', are never substituted, as they are not
+// meant to be drawn. The fallback face's Metrics are those of f, and pairs of
+// runes are only kerned if both glyphs come from f or both come from the
+// Face given in opts. Closing the fallback face closes f, but not the Face
+// given in opts. opts may be nil.
+//
+// As for NewMultiFace, the fallback face draws color glyphs, grapheme
+// clusters and vertical text with the faces that support them, and its DecorationMetrics are those of f,
+// if it is a DecorationFace.
+func NewFallbackFace(f Face, opts *FallbackOptions) Face {
+	z := &fallbackFace{f: f}
+	if opts != nil {
+		z.opts = *opts
+	}
+	return z
+}
+
+type fallbackFace struct {
+	f    Face
+	opts FallbackOptions
+}
+
+// The sources of a fallbackFace's glyphs.
+const (
+	fromFace = iota
+	fromFallback
+	fromReplacement
+	fromBox
+	fromNowhere
+)
+
+// source returns where r's glyph comes from. If report is true, it calls the
+// Missing hook if f has no glyph for r.
+func (z *fallbackFace) source(r rune, report bool) int {
+	if _, ok := z.f.GlyphAdvance(r); ok {
+		return fromFace
+	}
+	if unicode.IsControl(r) {
+		return fromNowhere
+	}
+	if report && z.opts.Missing != nil {
+		z.opts.Missing(r)
+	}
+	if z.opts.Face != nil {
+		if _, ok := z.opts.Face.GlyphAdvance(r); ok {
+			return fromFallback
+		}
+	}
+	if z.opts.Replacement {
+		if _, ok := z.f.GlyphAdvance('\ufffd'); ok {
+			return fromReplacement
+		}
+	}
+	return fromBox
+}
+
+func (z *fallbackFace) Close() error {
+	return z.f.Close()
+}
+
+func (z *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	switch z.source(r, true) {
+	case fromFace:
+		return z.f.Glyph(dot, r)
+	case fromFallback:
+		return z.opts.Face.Glyph(dot, r)
+	case fromReplacement:
+		return z.f.Glyph(dot, '\ufffd')
+	case fromBox:
+		m, advance := z.box(r)
+		p := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor()}
+		return m.Rect.Add(p), m, m.Rect.Min, advance, true
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+func (z *fallbackFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	switch z.source(r, true) {
+	case fromFace:
+		return z.f.GlyphBounds(r)
+	case fromFallback:
+		return z.opts.Face.GlyphBounds(r)
+	case fromReplacement:
+		return z.f.GlyphBounds('\ufffd')
+	case fromBox:
+		m, advance := z.box(r)
+		return fixed.R(m.Rect.Min.X, m.Rect.Min.Y, m.Rect.Max.X, m.Rect.Max.Y), advance, true
+	}
+	return fixed.Rectangle26_6{}, 0, false
+}
+
+func (z *fallbackFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	switch z.source(r, true) {
+	case fromFace:
+		return z.f.GlyphAdvance(r)
+	case fromFallback:
+		return z.opts.Face.GlyphAdvance(r)
+	case fromReplacement:
+		return z.f.GlyphAdvance('\ufffd')
+	case fromBox:
+		_, advance := z.box(r)
+		return advance, true
+	}
+	return 0, false
+}
+
+func (z *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	s := z.source(r0, false)
+	if s != z.source(r1, false) {
+		return 0
+	}
+	switch s {
+	case fromFace:
+		return z.f.Kern(r0, r1)
+	case fromFallback:
+		return z.opts.Face.Kern(r0, r1)
+	}
+	return 0
+}
+
+func (z *fallbackFace) Metrics() Metrics {
+	return z.f.Metrics()
+}
+
+// ColorGlyph draws r's color glyph with the face that r's glyph comes from,
+// if that face is a ColorFace.
+func (z *fallbackFace) ColorGlyph(dot fixed.Point26_6, r rune, fg color.Color) (
+	dr image.Rectangle, src image.Image, sp image.Point, advance fixed.Int26_6, ok bool) {
+
+	var f Face
+	switch z.source(r, false) {
+	case fromFace:
+		f = z.f
+	case fromFallback:
+		f = z.opts.Face
+	case fromReplacement:
+		f, r = z.f, '\ufffd'
+	}
+	if cf, isColor := f.(ColorFace); isColor {
+		return cf.ColorGlyph(dot, r, fg)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+// DecorationMetrics returns the wrapped face's decoration metrics, if it is a
+// DecorationFace, or else the zero value.
+func (z *fallbackFace) DecorationMetrics() DecorationMetrics {
+	if df, ok := z.f.(DecorationFace); ok {
+		return df.DecorationMetrics()
+	}
+	return DecorationMetrics{}
+}
+
+// VerticalGlyph draws r's vertical glyph with the face that r's glyph comes
+// from. Missing glyph boxes are centered on the line.
+func (z *fallbackFace) VerticalGlyph(dot fixed.Point26_6, r rune) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	switch z.source(r, true) {
+	case fromFace:
+		return verticalGlyph(z.f, dot, r)
+	case fromFallback:
+		return verticalGlyph(z.opts.Face, dot, r)
+	case fromReplacement:
+		return verticalGlyph(z.f, dot, '\ufffd')
+	case fromBox:
+		return stackedGlyph(z, dot, r)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+func (z *fallbackFace) VerticalGlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	switch z.source(r, true) {
+	case fromFace:
+		return verticalGlyphAdvance(z.f, r)
+	case fromFallback:
+		return verticalGlyphAdvance(z.opts.Face, r)
+	case fromReplacement:
+		return verticalGlyphAdvance(z.f, '\ufffd')
+	case fromBox:
+		m := z.f.Metrics()
+		return m.Ascent + m.Descent, true
+	}
+	return 0, false
+}
+
+// ClusterGlyph draws the cluster with the face that its first rune's glyph
+// comes from, if that face is a ClusterFace.
+func (z *fallbackFace) ClusterGlyph(dot fixed.Point26_6, cluster string) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	r, _ := utf8.DecodeRuneInString(cluster)
+	switch z.source(r, true) {
+	case fromFace:
+		return clusterGlyph(z.f, dot, cluster)
+	case fromFallback:
+		return clusterGlyph(z.opts.Face, dot, cluster)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+func (z *fallbackFace) ClusterAdvance(cluster string) (advance fixed.Int26_6, ok bool) {
+	r, _ := utf8.DecodeRuneInString(cluster)
+	switch z.source(r, true) {
+	case fromFace:
+		return clusterAdvance(z.f, cluster)
+	case fromFallback:
+		return clusterAdvance(z.opts.Face, cluster)
+	}
+	return 0, false
+}
+
+// RoundDot rounds dot as the wrapped face does, if it is a RoundingFace. The
+// Drawer's Bound methods round each glyph's dot as the face that draws it
+// does.
+func (z *fallbackFace) RoundDot(dot fixed.Point26_6) fixed.Point26_6 {
+	if rf, ok := z.f.(RoundingFace); ok {
+		return rf.RoundDot(dot)
+	}
+	return dot
+}
+
+func (z *fallbackFace) roundRuneDot(dot fixed.Point26_6, r rune) fixed.Point26_6 {
+	switch z.source(r, false) {
+	case fromFace:
+		return roundDot(z.f, dot, r)
+	case fromFallback:
+		return roundDot(z.opts.Face, dot, r)
+	case fromReplacement:
+		return roundDot(z.f, dot, '\ufffd')
+	case fromBox:
+		return fixed.P(dot.X.Floor(), dot.Y.Floor())
+	}
+	return dot
+}
+
+// hexDigitGlyphs are 3x5 pixel glyphs for the hexadecimal digits, one row of
+// three pixels after another, with '1' for a set pixel.
+var hexDigitGlyphs = [16]string{
+	"111101101101111", // 0
+	"010110010010111", // 1
+	"111001111100111", // 2
+	"111001111001111", // 3
+	"101101111001001", // 4
+	"111100111001111", // 5
+	"111100111101111", // 6
+	"111001001001001", // 7
+	"111101111101111", // 8
+	"111101111001111", // 9
+	"111101111101101", // A
+	"110101110101110", // B
+	"111100100100111", // C
+	"110101101101110", // D
+	"111100111100111", // E
+	"111100111100100", // F
+}
+
+// box returns the mask, relative to the dot, and the advance of r's missing
+// glyph box.
+//
+// The box contains r's code point as two rows of two or three digits, each
+// 3x5 units, with a unit's gap between digits, and a unit of padding and a
+// unit of border around them. It is 15 units high, and there is a unit of
+// space on either side of it. A unit is 1/16th of the face's ascent.
+func (z *fallbackFace) box(r rune) (*image.Alpha, fixed.Int26_6) {
+	const h = 15
+	ascent := z.f.Metrics().Ascent.Floor()
+	s := ascent / (h + 1)
+	if s < 1 {
+		// Draw an empty box, as tall as the ascent and about half as wide.
+		bh := ascent
+		if bh < 3 {
+			bh = 3
+		}
+		bw := (bh + 1) / 2
+		if bw < 3 {
+			bw = 3
+		}
+		m := image.NewAlpha(image.Rect(1, -bh, 1+bw, 0))
+		strokeBox(m, m.Rect, 1)
+		return m, fixed.I(bw + 2)
+	}
+
+	cols := 2
+	if r > 0xffff {
+		cols = 3
+	}
+	w := 4*cols + 3
+	m := image.NewAlpha(image.Rect(s, -h*s, (w+1)*s, 0))
+	strokeBox(m, m.Rect, s)
+
+	// Draw the digits, most significant first.
+	for i := 0; i < 2*cols; i++ {
+		digit := hexDigitGlyphs[int(r>>uint(4*(2*cols-1-i)))&0xf]
+		// x and y are the digit's top-left corner, in units.
+		x, y := 2+4*(i%cols), 2+6*(i/cols)
+		for j := 0; j < len(digit); j++ {
+			if digit[j] != '1' {
+				continue
+			}
+			px := m.Rect.Min.X + (x+j%3)*s
+			py := m.Rect.Min.Y + (y+j/3)*s
+			fillAlpha(m, image.Rect(px, py, px+s, py+s))
+		}
+	}
+	return m, fixed.I((w + 2) * s)
+}
+
+// strokeBox draws the outline of r, with the given thickness, onto m.
+func strokeBox(m *image.Alpha, r image.Rectangle, thickness int) {
+	fillAlpha(m, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+thickness))
+	fillAlpha(m, image.Rect(r.Min.X, r.Max.Y-thickness, r.Max.X, r.Max.Y))
+	fillAlpha(m, image.Rect(r.Min.X, r.Min.Y, r.Min.X+thickness, r.Max.Y))
+	fillAlpha(m, image.Rect(r.Max.X-thickness, r.Min.Y, r.Max.X, r.Max.Y))
+}
+
+// fillAlpha sets the pixels of m in r to be opaque.
+func fillAlpha(m *image.Alpha, r image.Rectangle) {
+	r = r.Intersect(m.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		i := m.PixOffset(r.Min.X, y)
+		for j := range m.Pix[i : i+r.Dx()] {
+			m.Pix[i+j] = 0xff
+		}
+	}
+}
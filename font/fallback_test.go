@@ -0,0 +1,131 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestFallbackFaceBox(t *testing.T) {
+	var missing []rune
+	lower := rangeFace{
+		lo: 'a', hi: 'z', advance: fixed.I(5), kern: -fixed.I(1),
+		metrics: Metrics{Height: fixed.I(20), Ascent: fixed.I(16), Descent: fixed.I(4)},
+		closed:  new(int),
+	}
+	f := NewFallbackFace(lower, &FallbackOptions{
+		Missing: func(r rune) { missing = append(missing, r) },
+	})
+
+	if a, ok := f.GlyphAdvance('a'); !ok || a != fixed.I(5) {
+		t.Errorf("GlyphAdvance('a'): got %v, %t, want 5:00, true", a, ok)
+	}
+	if a, ok := f.GlyphAdvance('\n'); ok {
+		t.Errorf("GlyphAdvance('\\n'): got %v, %t, want !ok", a, ok)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing: got %q, want none", missing)
+	}
+
+	dr, mask, maskp, advance, ok := f.Glyph(fixed.P(10, 20), 'A')
+	if !ok {
+		t.Fatalf("Glyph('A'): got !ok")
+	}
+	if want := image.Rect(11, 5, 22, 20); dr != want {
+		t.Errorf("Glyph('A'): dr: got %v, want %v", dr, want)
+	}
+	if advance != fixed.I(13) {
+		t.Errorf("Glyph('A'): advance: got %v, want 13:00", advance)
+	}
+	if string(missing) != "A" {
+		t.Errorf("missing: got %q, want \"A\"", missing)
+	}
+	// 'A' is U+0041.
+	want := strings.Join([]string{
+		"###########",
+		"#.........#",
+		"#.###.###.#",
+		"#.#.#.#.#.#",
+		"#.#.#.#.#.#",
+		"#.#.#.#.#.#",
+		"#.###.###.#",
+		"#.........#",
+		"#.#.#..#..#",
+		"#.#.#.##..#",
+		"#.###..#..#",
+		"#...#..#..#",
+		"#...#.###.#",
+		"#.........#",
+		"###########",
+	}, "\n")
+	var got []string
+	for y := 0; y < dr.Dy(); y++ {
+		row := make([]byte, dr.Dx())
+		for x := range row {
+			row[x] = '.'
+			if _, _, _, a := mask.At(maskp.X+x, maskp.Y+y).RGBA(); a != 0 {
+				row[x] = '#'
+			}
+		}
+		got = append(got, string(row))
+	}
+	if g := strings.Join(got, "\n"); g != want {
+		t.Errorf("Glyph('A'): mask:\ngot:\n%s\nwant:\n%s", g, want)
+	}
+
+	if b, a, ok := f.GlyphBounds('A'); !ok || b != fixed.R(1, -15, 12, 0) || a != fixed.I(13) {
+		t.Errorf("GlyphBounds('A'): got %v, %v, %t", b, a, ok)
+	}
+	// Supplementary runes have six digits, in three columns.
+	if a, ok := f.GlyphAdvance(0x1f600); !ok || a != fixed.I(17) {
+		t.Errorf("GlyphAdvance(U+1F600): got %v, %t, want 17:00, true", a, ok)
+	}
+	if k := f.Kern('a', 'b'); k != -fixed.I(1) {
+		t.Errorf("Kern('a', 'b'): got %v, want -1:00", k)
+	}
+	if k := f.Kern('a', 'A'); k != 0 {
+		t.Errorf("Kern('a', 'A'): got %v, want 0", k)
+	}
+
+	// A small face's box is empty.
+	small := lower
+	small.metrics.Ascent = fixed.I(8)
+	f = NewFallbackFace(small, nil)
+	if dr, _, _, advance, _ := f.Glyph(fixed.P(0, 10), 'A'); dr != image.Rect(1, 2, 5, 10) || advance != fixed.I(6) {
+		t.Errorf("small Glyph('A'): got %v, %v, want (1,2)-(5,10), 6:00", dr, advance)
+	}
+}
+
+func TestFallbackFaceOptions(t *testing.T) {
+	primary := rangeFace{
+		lo: 'a', hi: '\ufffd', advance: fixed.I(5),
+		metrics: Metrics{Ascent: fixed.I(16)},
+		closed:  new(int),
+	}
+	upper := rangeFace{lo: 'A', hi: 'Z', advance: fixed.I(7), closed: new(int)}
+
+	testCases := []struct {
+		desc string
+		opts FallbackOptions
+		r    rune
+		want fixed.Int26_6
+	}{
+		{"face", FallbackOptions{Face: upper}, 'A', fixed.I(7)},
+		{"replacement", FallbackOptions{Replacement: true}, 'A', fixed.I(5)},
+		{"face before replacement", FallbackOptions{Face: upper, Replacement: true}, 'A', fixed.I(7)},
+		{"replacement after face", FallbackOptions{Face: upper, Replacement: true}, '0', fixed.I(5)},
+		{"box", FallbackOptions{Face: upper}, '0', fixed.I(13)},
+	}
+	for _, tc := range testCases {
+		f := NewFallbackFace(primary, &tc.opts)
+		if a, ok := f.GlyphAdvance(tc.r); !ok || a != tc.want {
+			t.Errorf("%s: got %v, %t, want %v, true", tc.desc, a, ok, tc.want)
+		}
+	}
+}
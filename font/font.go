@@ -86,6 +86,13 @@ type Metrics struct {
 	// value is typically positive, even though a descender goes below the
 	// baseline.
 	Descent fixed.Int26_6
+
+	// UnderlinePosition is the distance from the baseline down to the top of
+	// an underline, and is typically positive. UnderlineThickness is the
+	// underline's thickness. Both are zero if the face does not know where to
+	// draw an underline.
+	UnderlinePosition  fixed.Int26_6
+	UnderlineThickness fixed.Int26_6
 }
 
 // Drawer draws text on a destination image.
@@ -105,6 +112,35 @@ type Drawer struct {
 	// may affect pixels below and to the left of the dot.
 	Dot fixed.Point26_6
 
+	// LetterSpacing is extra advance added after every glyph, also known as
+	// tracking. WordSpacing is extra advance added after every space, on top
+	// of the LetterSpacing. Negative values bring glyphs closer together.
+	LetterSpacing fixed.Int26_6
+	WordSpacing   fixed.Int26_6
+
+	// TabStops are the positions, in increasing order, that a '\t' moves the
+	// dot to, relative to the dot at the start of each DrawString or
+	// DrawBytes call. Past the last of them, or if there are none, there is a
+	// tab stop every TabWidth, which defaults to eight times the advance of a
+	// space. Tabs are never drawn as glyphs.
+	TabStops []fixed.Int26_6
+	TabWidth fixed.Int26_6
+
+	// Decorations are the lines, such as an underline, that DrawString and
+	// DrawBytes draw along with the text, from the dot at the start of the
+	// call to the dot at its end, in the Src color. Their positions and
+	// thicknesses come from the Face's DecorationMetrics if it is a
+	// DecorationFace, and are otherwise derived from its Metrics. If
+	// DecorationSkipInk is set, the underline is interrupted wherever it
+	// would cross or touch a glyph, such as the descenders of a 'g' or 'y'.
+	Decorations       Decoration
+	DecorationSkipInk bool
+
+	// Quantization is how the dot is rounded before drawing each glyph in
+	// DrawString, DrawBytes and DrawStringClusters. The zero value leaves
+	// any rounding to the Face.
+	Quantization Quantization
+
 	// TODO: Clip image.Image?
 	// TODO: SrcP image.Point for Src images other than *image.Uniform? How
 	// does it get updated during DrawString?
@@ -129,44 +165,75 @@ type Drawer struct {
 //
 // It is equivalent to DrawString(string(s)) but may be more efficient.
 func (d *Drawer) DrawBytes(s []byte) {
-	prevC := rune(-1)
-	for len(s) > 0 {
-		c, size := utf8.DecodeRune(s)
-		s = s[size:]
-		if prevC >= 0 {
-			d.Dot.X += d.Face.Kern(prevC, c)
-		}
-		dr, mask, maskp, advance, ok := d.Face.Glyph(d.Dot, c)
-		if !ok {
-			// TODO: is falling back on the U+FFFD glyph the responsibility of
-			// the Drawer or the Face?
-			// TODO: set prevC = '\ufffd'?
-			continue
-		}
-		draw.DrawMask(d.Dst, dr, d.Src, image.Point{}, mask, maskp, draw.Over)
-		d.Dot.X += advance
-		prevC = c
+	if s == nil {
+		s = []byte{}
 	}
+	d.draw("", s)
 }
 
 // DrawString draws s at the dot and advances the dot's location.
+//
+// If the drawer's Face is a ColorFace, runes that have color glyphs are drawn
+// in those colors instead of in the Src color.
 func (d *Drawer) DrawString(s string) {
-	prevC := rune(-1)
-	for _, c := range s {
-		if prevC >= 0 {
-			d.Dot.X += d.Face.Kern(prevC, c)
+	d.draw(s, nil)
+}
+
+// draw draws b, if it is non-nil, or else s, and advances the dot.
+func (d *Drawer) draw(s string, b []byte) {
+	z := d.newDecorator()
+	it := d.glyphs(s, b, func(c rune, dot fixed.Point26_6) (fixed.Int26_6, bool) {
+		dr, ink, inkp, advance, ok := d.drawRune(dot, c)
+		if ok && z != nil {
+			z.glyph(dr, ink, inkp)
 		}
-		dr, mask, maskp, advance, ok := d.Face.Glyph(d.Dot, c)
-		if !ok {
-			// TODO: is falling back on the U+FFFD glyph the responsibility of
-			// the Drawer or the Face?
-			// TODO: set prevC = '\ufffd'?
-			continue
+		// TODO: if !ok, is falling back on the U+FFFD glyph the
+		// responsibility of the Drawer or the Face?
+		return advance, ok
+	})
+	for it.Next() {
+	}
+	d.Dot = it.Dot()
+	if z != nil {
+		z.draw(d.Dot.X)
+	}
+}
+
+// spacing returns the extra advance after the glyph for c, as per the drawer's
+// LetterSpacing and WordSpacing.
+func (d *Drawer) spacing(c rune) fixed.Int26_6 {
+	if isWordSpace(c) {
+		return d.LetterSpacing + d.WordSpacing
+	}
+	return d.LetterSpacing
+}
+
+// isWordSpace returns whether c is a space between words, which WordSpacing
+// applies to.
+func isWordSpace(c rune) bool {
+	return c == ' ' || c == '\u00a0'
+}
+
+// nextTabStop returns the first tab stop after x, where both are relative to
+// the start of the string being drawn.
+func (d *Drawer) nextTabStop(x fixed.Int26_6) fixed.Int26_6 {
+	for _, stop := range d.TabStops {
+		if stop > x {
+			return stop
 		}
-		draw.DrawMask(d.Dst, dr, d.Src, image.Point{}, mask, maskp, draw.Over)
-		d.Dot.X += advance
-		prevC = c
 	}
+	w := d.TabWidth
+	if w <= 0 {
+		a, ok := d.Face.GlyphAdvance(' ')
+		if !ok || a <= 0 {
+			return x
+		}
+		w = 8 * a
+	}
+	if x < 0 {
+		return 0
+	}
+	return (x/w + 1) * w
 }
 
 // BoundBytes returns the bounding box of s, drawn at the drawer dot, as well as
@@ -174,31 +241,59 @@ func (d *Drawer) DrawString(s string) {
 //
 // It is equivalent to BoundBytes(string(s)) but may be more efficient.
 func (d *Drawer) BoundBytes(s []byte) (bounds fixed.Rectangle26_6, advance fixed.Int26_6) {
-	bounds, advance = BoundBytes(d.Face, s)
-	bounds.Min = bounds.Min.Add(d.Dot)
-	bounds.Max = bounds.Max.Add(d.Dot)
-	return
+	if s == nil {
+		s = []byte{}
+	}
+	return d.bound("", s)
 }
 
 // BoundString returns the bounding box of s, drawn at the drawer dot, as well
 // as the advance.
+//
+// The glyphs are positioned exactly as DrawString would draw them, including
+// kerning, spacing, tab stops, the drawer's Quantization and, if the Face is
+// a RoundingFace, the Face's rounding.
 func (d *Drawer) BoundString(s string) (bounds fixed.Rectangle26_6, advance fixed.Int26_6) {
-	bounds, advance = BoundString(d.Face, s)
-	bounds.Min = bounds.Min.Add(d.Dot)
-	bounds.Max = bounds.Max.Add(d.Dot)
-	return
+	return d.bound(s, nil)
+}
+
+// bound returns the bounding box and advance of b, if it is non-nil, or else
+// of s.
+func (d *Drawer) bound(s string, b []byte) (bounds fixed.Rectangle26_6, advance fixed.Int26_6) {
+	it := d.glyphs(s, b, func(c rune, dot fixed.Point26_6) (fixed.Int26_6, bool) {
+		gb, a, ok := d.Face.GlyphBounds(c)
+		if ok {
+			bounds = bounds.Union(gb.Add(roundDot(d.Face, dot, c)))
+		}
+		return a, ok
+	})
+	for it.Next() {
+	}
+	if bounds.Empty() {
+		bounds = fixed.Rectangle26_6{Min: d.Dot, Max: d.Dot}
+	}
+	return bounds, it.Dot().X - d.Dot.X
 }
 
 // MeasureBytes returns how far dot would advance by drawing s.
 //
 // It is equivalent to MeasureString(string(s)) but may be more efficient.
 func (d *Drawer) MeasureBytes(s []byte) (advance fixed.Int26_6) {
-	return MeasureBytes(d.Face, s)
+	if s == nil {
+		s = []byte{}
+	}
+	it := d.glyphs("", s, nil)
+	for it.Next() {
+	}
+	return it.Dot().X - d.Dot.X
 }
 
 // MeasureString returns how far dot would advance by drawing s.
 func (d *Drawer) MeasureString(s string) (advance fixed.Int26_6) {
-	return MeasureString(d.Face, s)
+	it := d.Glyphs(s)
+	for it.Next() {
+	}
+	return it.Dot().X - d.Dot.X
 }
 
 // BoundBytes returns the bounding box of s with f, drawn at a dot equal to the
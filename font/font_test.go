@@ -5,6 +5,7 @@
 package font
 
 import (
+	"bytes"
 	"image"
 	"strings"
 	"testing"
@@ -63,3 +64,151 @@ func TestBound(t *testing.T) {
 		}
 	}
 }
+
+func TestDrawerSpacing(t *testing.T) {
+	d := &Drawer{
+		Face:          toyFace{},
+		LetterSpacing: fixed.I(1),
+		WordSpacing:   fixed.I(3),
+	}
+	const s = "x x"
+	// Each of the three glyphs gets the letter spacing, and the space also
+	// gets the word spacing.
+	wantAdvance := 3*toyAdvance + 3*fixed.I(1) + fixed.I(3)
+	if got := d.MeasureString(s); got != wantAdvance {
+		t.Errorf("MeasureString: got %v, want %v", got, wantAdvance)
+	}
+	if got := d.MeasureBytes([]byte(s)); got != wantAdvance {
+		t.Errorf("MeasureBytes: got %v, want %v", got, wantAdvance)
+	}
+	wantBound := fixed.Rectangle26_6{Min: fixed.P(2, 0), Max: fixed.P(31, 1)}
+	gotBound, gotAdvance := d.BoundString(s)
+	if gotBound != wantBound || gotAdvance != wantAdvance {
+		t.Errorf("BoundString: got %v, %v, want %v, %v", gotBound, gotAdvance, wantBound, wantAdvance)
+	}
+	gotBound, gotAdvance = d.BoundBytes([]byte(s))
+	if gotBound != wantBound || gotAdvance != wantAdvance {
+		t.Errorf("BoundBytes: got %v, %v, want %v, %v", gotBound, gotAdvance, wantBound, wantAdvance)
+	}
+}
+
+func TestDrawerTabStops(t *testing.T) {
+	testCases := []struct {
+		s        string
+		tabStops []fixed.Int26_6
+		tabWidth fixed.Int26_6
+		want     fixed.Int26_6
+	}{
+		// By default, tab stops are every eight spaces.
+		{"\t", nil, 0, 8 * toyAdvance},
+		{"x\tx", nil, 0, 9 * toyAdvance},
+		{"xxxxxxxx\tx", nil, 0, 17 * toyAdvance},
+		{"x\tx", nil, fixed.I(25), fixed.I(25) + toyAdvance},
+		{"x\tx\tx", nil, fixed.I(25), fixed.I(60)},
+		// Explicit tab stops come before the regular ones.
+		{"x\tx\tx", []fixed.Int26_6{fixed.I(15)}, fixed.I(50), fixed.I(60)},
+		{"x\tx\tx\tx", []fixed.Int26_6{fixed.I(15), fixed.I(20)}, fixed.I(50), fixed.I(110)},
+	}
+	for _, tc := range testCases {
+		d := &Drawer{Face: toyFace{}, TabStops: tc.tabStops, TabWidth: tc.tabWidth}
+		if got := d.MeasureString(tc.s); got != tc.want {
+			t.Errorf("%q: MeasureString: got %v, want %v", tc.s, got, tc.want)
+		}
+		if got := d.MeasureBytes([]byte(tc.s)); got != tc.want {
+			t.Errorf("%q: MeasureBytes: got %v, want %v", tc.s, got, tc.want)
+		}
+		if _, got := d.BoundString(tc.s); got != tc.want {
+			t.Errorf("%q: BoundString: got %v, want %v", tc.s, got, tc.want)
+		}
+	}
+}
+
+// floorFace is a descenderFace that, like it, draws glyphs at the dot rounded
+// down to a whole pixel, and says so.
+type floorFace struct {
+	descenderFace
+}
+
+func (floorFace) RoundDot(dot fixed.Point26_6) fixed.Point26_6 {
+	return fixed.P(dot.X.Floor(), dot.Y.Floor())
+}
+
+func TestDrawerBoundMatchesDraw(t *testing.T) {
+	df := descenderFace{rangeFace{
+		lo: ' ', hi: 'z', advance: fixed.I(10) + 13, kern: -21,
+		metrics: Metrics{Height: fixed.I(12), Ascent: fixed.I(8), Descent: fixed.I(4)},
+		closed:  new(int),
+	}}
+	// digits has no glyphs for s, so that a multi-face draws s with its
+	// second face.
+	digits := rangeFace{lo: '0', hi: '9', advance: fixed.I(5), closed: new(int)}
+	const s = "ag\tga gag"
+	testCases := []struct {
+		face Face
+		// rounding is whether the face rounds the dot as floorFace does, so
+		// that the bounds are exact.
+		rounding bool
+	}{
+		{df, false},
+		{floorFace{df}, true},
+		{NewCache(floorFace{df}, 0), true},
+		{NewMultiFace(floorFace{df}), true},
+		{NewMultiFace(digits, floorFace{df}), true},
+		{NewFallbackFace(floorFace{df}, nil), true},
+		{NewFallbackFace(digits, &FallbackOptions{Face: floorFace{df}}), true},
+	}
+	for _, tc := range testCases {
+		for _, q := range []Quantization{QuantizationNone, QuantizationPixel, QuantizationQuarterPixel} {
+			face, rounding := tc.face, tc.rounding
+			d := &Drawer{
+				Src:           image.White,
+				Face:          face,
+				LetterSpacing: 7,
+				WordSpacing:   fixed.I(2),
+				Quantization:  q,
+			}
+			newDst := func() *image.Gray {
+				d.Dot = fixed.Point26_6{X: fixed.I(3) + 37, Y: fixed.I(10) + 50}
+				dst := image.NewGray(image.Rect(0, 0, 160, 20))
+				d.Dst = dst
+				return dst
+			}
+
+			dst := newDst()
+			bounds, advance := d.BoundString(s)
+			if b, a := d.BoundBytes([]byte(s)); b != bounds || a != advance {
+				t.Errorf("rounding=%t, q=%d: BoundBytes: got %v, %v, want %v, %v", rounding, q, b, a, bounds, advance)
+			}
+			if a := d.MeasureBytes([]byte(s)); a != advance {
+				t.Errorf("rounding=%t, q=%d: MeasureBytes: got %v, want %v", rounding, q, a, advance)
+			}
+			start := d.Dot.X
+			d.DrawString(s)
+			if a := d.Dot.X - start; a != advance {
+				t.Errorf("rounding=%t, q=%d: DrawString: got advance %v, want %v", rounding, q, a, advance)
+			}
+			other := newDst()
+			d.DrawBytes([]byte(s))
+			if !bytes.Equal(dst.Pix, other.Pix) {
+				t.Errorf("rounding=%t, q=%d: DrawBytes and DrawString differ", rounding, q)
+			}
+
+			ink := image.Rectangle{}
+			for y := 0; y < dst.Rect.Dy(); y++ {
+				for x := 0; x < dst.Rect.Dx(); x++ {
+					if dst.GrayAt(x, y).Y != 0 {
+						ink = ink.Union(image.Rect(x, y, x+1, y+1))
+					}
+				}
+			}
+			r := image.Rect(bounds.Min.X.Floor(), bounds.Min.Y.Floor(), bounds.Max.X.Ceil(), bounds.Max.Y.Ceil())
+			if !ink.In(r) {
+				t.Errorf("face=%T, q=%d: ink %v is not within bounds %v", face, q, ink, r)
+			}
+			// With a RoundingFace, the bounds are exact.
+			if rounding && ink != r {
+				t.Errorf("face=%T, q=%d: ink %v does not match bounds %v", face, q, ink, r)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// PositionedGlyph is a rune of a string, positioned as a Drawer would draw it.
+type PositionedGlyph struct {
+	// Rune is the rune, and Offset is its byte offset in the string.
+	Rune   rune
+	Offset int
+
+	// Dot is where the rune's glyph is drawn, after kerning and as per the
+	// drawer's Quantization.
+	Dot fixed.Point26_6
+
+	// Kern is the kerning between the previous rune and this one, which is
+	// included in Dot.
+	Kern fixed.Int26_6
+
+	// Advance is how far the dot moves after this rune, including the
+	// drawer's LetterSpacing and WordSpacing. For a '\t', it is the distance
+	// to the next tab stop.
+	Advance fixed.Int26_6
+
+	// Drawn is whether a glyph is drawn for the rune. It is false for tabs
+	// and for runes that the Face has no glyph for, whose Advance is zero.
+	Drawn bool
+}
+
+// GlyphIterator iterates over the positioned glyphs of a string. It is
+// returned by Drawer.Glyphs.
+type GlyphIterator struct {
+	d     *Drawer
+	s     string
+	b     []byte
+	i     int
+	x0    fixed.Int26_6
+	dot   fixed.Point26_6
+	prevC rune
+	g     PositionedGlyph
+
+	// glyph, if non-nil, is called for each rune, with the dot that its
+	// glyph is drawn at, instead of the Face's GlyphAdvance method. It lets
+	// the Drawer's Draw, Bound and Measure methods share this loop, so that
+	// they position glyphs identically.
+	glyph func(c rune, dot fixed.Point26_6) (advance fixed.Int26_6, ok bool)
+}
+
+// Glyphs returns an iterator over the runes of s, positioned as DrawString
+// would draw them, starting at the dot, but without drawing them or moving
+// the dot. It can be used for hit testing, for finding selection rectangles
+// or for custom rendering, with the same positions as DrawString and
+// MeasureString:
+//
+//	it := d.Glyphs(s)
+//	for it.Next() {
+//		g := it.Glyph()
+//		// Do something with g.
+//	}
+func (d *Drawer) Glyphs(s string) *GlyphIterator {
+	return d.glyphs(s, nil, nil)
+}
+
+// glyphs returns an iterator over the runes of b, if it is non-nil, or else
+// of s, that calls glyph, if it is non-nil, for each rune.
+func (d *Drawer) glyphs(s string, b []byte, glyph func(c rune, dot fixed.Point26_6) (fixed.Int26_6, bool)) *GlyphIterator {
+	return &GlyphIterator{
+		d:     d,
+		s:     s,
+		b:     b,
+		x0:    d.Dot.X,
+		dot:   d.Dot,
+		prevC: -1,
+		glyph: glyph,
+	}
+}
+
+// Next advances the iterator to the next rune, which is then available
+// through the Glyph method. It returns false when there are no more runes.
+func (it *GlyphIterator) Next() bool {
+	var c rune
+	var size int
+	if it.b != nil {
+		if it.i >= len(it.b) {
+			return false
+		}
+		c, size = utf8.DecodeRune(it.b[it.i:])
+	} else {
+		if it.i >= len(it.s) {
+			return false
+		}
+		c, size = utf8.DecodeRuneInString(it.s[it.i:])
+	}
+	it.g = PositionedGlyph{Rune: c, Offset: it.i}
+	it.i += size
+
+	if c == '\t' {
+		it.g.Dot = it.dot
+		it.dot.X = it.x0 + it.d.nextTabStop(it.dot.X-it.x0)
+		it.g.Advance = it.dot.X - it.g.Dot.X
+		it.prevC = -1
+		return true
+	}
+	if it.prevC >= 0 {
+		it.g.Kern = it.d.Face.Kern(it.prevC, c)
+		it.dot.X += it.g.Kern
+	}
+	it.g.Dot = it.d.glyphDot(it.dot)
+	var a fixed.Int26_6
+	var ok bool
+	if it.glyph != nil {
+		a, ok = it.glyph(c, it.g.Dot)
+	} else {
+		a, ok = it.d.Face.GlyphAdvance(c)
+	}
+	if !ok {
+		return true
+	}
+	it.g.Advance = a + it.d.spacing(c)
+	it.g.Drawn = true
+	it.dot.X += it.g.Advance
+	it.prevC = c
+	return true
+}
+
+// Glyph returns the current positioned glyph.
+func (it *GlyphIterator) Glyph() PositionedGlyph {
+	return it.g
+}
+
+// Dot returns where the dot would be after drawing the runes so far.
+func (it *GlyphIterator) Dot() fixed.Point26_6 {
+	return it.dot
+}
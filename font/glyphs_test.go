@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestGlyphs(t *testing.T) {
+	d := &Drawer{
+		Dst:           image.NewAlpha(image.Rect(0, 0, 100, 20)),
+		Src:           image.Opaque,
+		Face:          rangeFace{lo: 'a', hi: 'z', advance: fixed.I(5), kern: -fixed.I(1), closed: new(int)},
+		Dot:           fixed.P(10, 10),
+		LetterSpacing: fixed.I(2),
+		TabWidth:      fixed.I(20),
+	}
+	// The tab stops are relative to the start of the string, and the face has
+	// no glyph for 'ÿ'.
+	const s = "ab\tÿc"
+	want := []PositionedGlyph{
+		{Rune: 'a', Offset: 0, Dot: fixed.P(10, 10), Advance: fixed.I(7), Drawn: true},
+		{Rune: 'b', Offset: 1, Dot: fixed.P(16, 10), Kern: -fixed.I(1), Advance: fixed.I(7), Drawn: true},
+		{Rune: '\t', Offset: 2, Dot: fixed.P(23, 10), Advance: fixed.I(7)},
+		{Rune: 'ÿ', Offset: 3, Dot: fixed.P(30, 10)},
+		{Rune: 'c', Offset: 5, Dot: fixed.P(30, 10), Advance: fixed.I(7), Drawn: true},
+	}
+	var got []PositionedGlyph
+	it := d.Glyphs(s)
+	for it.Next() {
+		got = append(got, it.Glyph())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d glyphs, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("glyph #%d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// The iterator agrees with MeasureString and DrawString, and does not
+	// move the dot.
+	if got, want := it.Dot(), fixed.P(37, 10); got != want {
+		t.Errorf("iterator dot: got %v, want %v", got, want)
+	}
+	if got, want := d.MeasureString(s), fixed.I(27); got != want {
+		t.Errorf("MeasureString: got %v, want %v", got, want)
+	}
+	d.DrawString(s)
+	if got, want := d.Dot, fixed.P(37, 10); got != want {
+		t.Errorf("DrawString dot: got %v, want %v", got, want)
+	}
+}
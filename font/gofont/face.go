@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gofont
+
+import (
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+)
+
+// parsed holds each of the family's fonts once it has been parsed, so that
+// each font's TTF data is parsed at most once per process.
+var parsed [len(fonts)]struct {
+	once sync.Once
+	f    *sfnt.Font
+	err  error
+}
+
+// Parse returns f's TrueType data, parsed. The fonts listed by All and Match
+// are parsed only once per process, and the result shared by every call, as
+// an sfnt.Font is safe for concurrent use.
+func (f Font) Parse() (*sfnt.Font, error) {
+	for i := range fonts {
+		if !sameData(f.TTF, fonts[i].TTF) {
+			continue
+		}
+		p := &parsed[i]
+		p.once.Do(func() {
+			p.f, p.err = sfnt.Parse(fonts[i].TTF)
+		})
+		return p.f, p.err
+	}
+	return sfnt.Parse(f.TTF)
+}
+
+// Face returns a new face for f, at the given size, in points, and DPI, and
+// with full hinting. It is shorthand for parsing f and calling opentype's
+// NewFace, and the returned face should be closed when no longer needed.
+func (f Font) Face(size, dpi float64) (font.Face, error) {
+	sf, err := f.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(sf, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     dpi,
+		Hinting: font.HintingFull,
+	})
+}
+
+// sameData returns whether a and b are the same slice of bytes, not merely
+// equal ones.
+func sameData(a, b []byte) bool {
+	return len(a) == len(b) && len(a) > 0 && &a[0] == &b[0]
+}
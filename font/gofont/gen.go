@@ -9,9 +9,8 @@ package main
 // This program generates the subdirectories of Go packages that contain []byte
 // versions of the TrueType font files under ./ttfs.
 //
-// Currently, "go run gen.go" needs to be run manually. This isn't done by the
-// usual "go generate" mechanism as there isn't any other Go code in this
-// directory (excluding sub-directories) to attach a "go:generate" line to.
+// It is run by "go generate" in this directory, via the "go:generate" line in
+// gofont.go.
 //
 // In any case, code generation should only need to happen when the underlying
 // TTF files change, which isn't expected to happen frequently.
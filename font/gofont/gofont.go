@@ -0,0 +1,100 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:generate go run gen.go
+
+// Package gofont lists the fonts of the Go font family, whose TrueType data
+// are provided by this package's sub-directories, such as gofont/goregular.
+//
+// A Font's Face method returns a face for that font at a given size, parsing
+// the font's data only once per process.
+//
+// Importing this package links in every font of the family. Programs that need
+// only one or two of them should import those sub-directories directly.
+//
+// See https://blog.golang.org/go-fonts for details.
+package gofont
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/gomediumitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Font is one font of the Go font family.
+type Font struct {
+	// Name is the font's full name, such as "Go Mono Bold Italic".
+	Name string
+	// Weight and Style are the font's weight and style.
+	Weight font.Weight
+	Style  font.Style
+	// Mono is whether the font is fixed-width, as opposed to proportional.
+	Mono bool
+	// TTF is the font's TrueType data.
+	TTF []byte
+}
+
+var fonts = [...]Font{
+	{"Go Regular", font.WeightNormal, font.StyleNormal, false, goregular.TTF},
+	{"Go Italic", font.WeightNormal, font.StyleItalic, false, goitalic.TTF},
+	{"Go Medium", font.WeightMedium, font.StyleNormal, false, gomedium.TTF},
+	{"Go Medium Italic", font.WeightMedium, font.StyleItalic, false, gomediumitalic.TTF},
+	{"Go Bold", font.WeightBold, font.StyleNormal, false, gobold.TTF},
+	{"Go Bold Italic", font.WeightBold, font.StyleItalic, false, gobolditalic.TTF},
+	{"Go Mono", font.WeightNormal, font.StyleNormal, true, gomono.TTF},
+	{"Go Mono Italic", font.WeightNormal, font.StyleItalic, true, gomonoitalic.TTF},
+	{"Go Mono Bold", font.WeightBold, font.StyleNormal, true, gomonobold.TTF},
+	{"Go Mono Bold Italic", font.WeightBold, font.StyleItalic, true, gomonobolditalic.TTF},
+}
+
+// All returns every font of the Go font family, proportional fonts first, in
+// order of increasing weight, with each font's normal style before its italic
+// style.
+//
+// The returned slice is a copy, which the caller may modify, but the fonts'
+// TTF data are shared and should not be modified.
+func All() []Font {
+	all := make([]Font, len(fonts))
+	copy(all, fonts[:])
+	return all
+}
+
+// Match returns the font of the Go font family that best matches the given
+// weight, style and mono flag. A match must be fixed-width if and only if
+// mono is true. Among those, a font of the given style is preferred, and then
+// the font of the closest weight, with ties going to the heavier font. An
+// oblique style matches an italic font.
+func Match(weight font.Weight, style font.Style, mono bool) Font {
+	if style == font.StyleOblique {
+		style = font.StyleItalic
+	}
+	best, bestScore := -1, 0
+	for i, f := range fonts {
+		if f.Mono != mono {
+			continue
+		}
+		d := int(f.Weight - weight)
+		if d < 0 {
+			// Break ties in favor of the heavier font.
+			d = 1 - 2*d
+		} else {
+			d = 2 * d
+		}
+		if f.Style != style {
+			d += 1000
+		}
+		if best < 0 || d < bestScore {
+			best, bestScore = i, d
+		}
+	}
+	return fonts[best]
+}
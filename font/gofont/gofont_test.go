@@ -0,0 +1,98 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gofont
+
+import (
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+)
+
+func TestAll(t *testing.T) {
+	all := All()
+	if len(all) != 10 {
+		t.Fatalf("got %d fonts, want 10", len(all))
+	}
+	for _, f := range all {
+		g, err := sfnt.Parse(f.TTF)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", f.Name, err)
+			continue
+		}
+		name, err := g.Name(nil, sfnt.NameIDFull)
+		if err != nil {
+			t.Errorf("%s: Name: %v", f.Name, err)
+			continue
+		}
+		if name != f.Name {
+			t.Errorf("%s: got full name %q", f.Name, name)
+		}
+	}
+
+	all[0].Name = "modified"
+	if All()[0].Name != "Go Regular" {
+		t.Errorf("modifying All's result modified the package's list")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		weight font.Weight
+		style  font.Style
+		mono   bool
+		want   string
+	}{
+		{font.WeightNormal, font.StyleNormal, false, "Go Regular"},
+		{font.WeightBold, font.StyleItalic, false, "Go Bold Italic"},
+		{font.WeightMedium, font.StyleNormal, true, "Go Mono"},
+		{font.WeightThin, font.StyleNormal, false, "Go Regular"},
+		{font.WeightSemiBold, font.StyleOblique, false, "Go Bold Italic"},
+		{font.WeightBlack, font.StyleNormal, true, "Go Mono Bold"},
+		{font.WeightLight, font.StyleItalic, true, "Go Mono Italic"},
+	}
+	for _, tc := range testCases {
+		if got := Match(tc.weight, tc.style, tc.mono).Name; got != tc.want {
+			t.Errorf("Match(%v, %v, %t): got %q, want %q", tc.weight, tc.style, tc.mono, got, tc.want)
+		}
+	}
+}
+
+func TestFace(t *testing.T) {
+	f := Match(font.WeightBold, font.StyleNormal, false)
+	sf0, err := f.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	sf1, err := All()[4].Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if sf0 != sf1 {
+		t.Errorf("Parse: got different fonts for the same font's data")
+	}
+
+	face, err := f.Face(12, 72)
+	if err != nil {
+		t.Fatalf("Face: %v", err)
+	}
+	defer face.Close()
+	if got := face.Metrics().Height; got.Ceil() < 12 || got.Ceil() > 16 {
+		t.Errorf("12pt at 72 DPI: got line height %v", got)
+	}
+	if _, ok := face.GlyphAdvance('G'); !ok {
+		t.Errorf("GlyphAdvance('G'): got !ok, want ok")
+	}
+
+	// A copy of the data is parsed anew, not looked up in the cache.
+	copied := Font{TTF: append([]byte(nil), f.TTF...)}
+	sf2, err := copied.Parse()
+	if err != nil {
+		t.Fatalf("Parse copy: %v", err)
+	}
+	if sf2 == sf0 {
+		t.Errorf("Parse copy: got the cached font")
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// ClusterFace is a Face that can draw a grapheme cluster of more than one
+// rune as a single glyph, such as an emoji zero width joiner sequence or a
+// letter followed by combining marks, typically by shaping the cluster with
+// the font's layout tables.
+type ClusterFace interface {
+	Face
+
+	// ClusterGlyph is like Glyph, but for a grapheme cluster. It returns !ok
+	// if the face cannot draw the cluster as one glyph.
+	ClusterGlyph(dot fixed.Point26_6, cluster string) (
+		dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool)
+
+	// ClusterAdvance is like GlyphAdvance, but for a grapheme cluster. It
+	// returns !ok if the face cannot draw the cluster as one glyph.
+	ClusterAdvance(cluster string) (advance fixed.Int26_6, ok bool)
+}
+
+// clusterGlyph is like ClusterFace.ClusterGlyph, returning !ok if f is not a
+// ClusterFace.
+func clusterGlyph(f Face, dot fixed.Point26_6, cluster string) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	if cf, isCluster := f.(ClusterFace); isCluster {
+		return cf.ClusterGlyph(dot, cluster)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+// clusterAdvance is like ClusterFace.ClusterAdvance, returning !ok if f is
+// not a ClusterFace.
+func clusterAdvance(f Face, cluster string) (advance fixed.Int26_6, ok bool) {
+	if cf, isCluster := f.(ClusterFace); isCluster {
+		return cf.ClusterAdvance(cluster)
+	}
+	return 0, false
+}
+
+// NextGraphemeCluster returns the length in bytes of the first grapheme
+// cluster of s: what a user thinks of as a single character, such as a
+// letter and its combining accents, or an emoji and its modifiers. It
+// returns 0 if s is empty.
+//
+// It implements a simplified version of the extended grapheme cluster rules
+// at https://www.unicode.org/reports/tr29/, which handles combining marks,
+// variation selectors, emoji modifiers and tags, emoji zero width joiner
+// sequences, regional indicator pairs (flags) and "\r\n", but not Hangul
+// syllables made of conjoining jamo or prepended concatenation marks.
+func NextGraphemeCluster(s string) int {
+	if s == "" {
+		return 0
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	if r == '\r' && len(s) > 1 && s[1] == '\n' {
+		return 2
+	}
+	if isControl(r) {
+		return n
+	}
+	pictographic := isPictographic(r)
+	regional := isRegionalIndicator(r)
+	for n < len(s) {
+		c, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case isControl(c):
+			return n
+		case isGraphemeExtend(c):
+			// Extenders, including ZWJ, don't change whether an emoji
+			// sequence continues.
+		case regional && isRegionalIndicator(c):
+			// A flag is a pair of regional indicators.
+			regional = false
+		case pictographic && isPictographic(c) && lastRune(s[:n]) == zeroWidthJoiner:
+			// An emoji zero width joiner sequence, such as a family.
+		default:
+			return n
+		}
+		n += size
+	}
+	return n
+}
+
+const zeroWidthJoiner = '\u200d'
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+// isControl returns whether r is a control character, which is always a
+// grapheme cluster on its own.
+func isControl(r rune) bool {
+	return !isGraphemeExtend(r) && unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp)
+}
+
+// isGraphemeExtend returns whether r extends the grapheme cluster before it.
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner, r == '\u200c': // Zero width joiner and non-joiner.
+		return true
+	case 0xfe00 <= r && r <= 0xfe0f, 0xe0100 <= r && r <= 0xe01ef: // Variation selectors.
+		return true
+	case 0x1f3fb <= r && r <= 0x1f3ff: // Emoji skin tone modifiers.
+		return true
+	case 0xe0020 <= r && r <= 0xe007f: // Tags, as used by subdivision flags.
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return 0x1f1e6 <= r && r <= 0x1f1ff
+}
+
+// isPictographic approximates whether r has the Extended_Pictographic
+// property, which emoji have.
+func isPictographic(r rune) bool {
+	switch {
+	case r == 0x00a9, r == 0x00ae, r == 0x203c, r == 0x2049, r == 0x2122, r == 0x2139:
+		return true
+	case 0x2194 <= r && r <= 0x21aa, 0x231a <= r && r <= 0x23ff:
+		return true
+	case 0x2600 <= r && r <= 0x27bf, 0x2b05 <= r && r <= 0x2b55:
+		return true
+	case 0x1f000 <= r && r <= 0x1faff && !isRegionalIndicator(r) && !(0x1f3fb <= r && r <= 0x1f3ff):
+		return true
+	}
+	return false
+}
+
+// DrawStringClusters is like DrawString, but draws s one grapheme cluster, as
+// per NextGraphemeCluster, at a time, so that a cluster of several runes is
+// one glyph with one advance.
+//
+// If the drawer's Face is a ClusterFace that can draw a cluster, the glyph
+// comes from its ClusterGlyph method. Otherwise, the cluster's first rune is
+// drawn, followed by any combining marks, which are drawn at the dot after
+// that rune but do not move the dot. Other runes in the cluster, such as the
+// rest of an emoji sequence, are not drawn. Clusters are kerned by their
+// first runes.
+func (d *Drawer) DrawStringClusters(s string) {
+	cf, _ := d.Face.(ClusterFace)
+	prevC := rune(-1)
+	for len(s) > 0 {
+		n := NextGraphemeCluster(s)
+		cluster := s[:n]
+		s = s[n:]
+		c, size := utf8.DecodeRuneInString(cluster)
+
+		if prevC >= 0 {
+			d.Dot.X += d.Face.Kern(prevC, c)
+		}
+		if size < len(cluster) && cf != nil {
+			if dr, mask, maskp, advance, ok := cf.ClusterGlyph(d.glyphDot(d.Dot), cluster); ok {
+				d.drawGlyph(dr, mask, maskp)
+				d.Dot.X += advance + d.spacing(c)
+				prevC = c
+				continue
+			}
+		}
+		_, _, _, advance, ok := d.drawRune(d.glyphDot(d.Dot), c)
+		if !ok {
+			continue
+		}
+		d.Dot.X += advance
+		for _, m := range cluster[size:] {
+			if !unicode.In(m, unicode.Mn, unicode.Me) {
+				continue
+			}
+			if dr, mask, maskp, _, ok := d.Face.Glyph(d.glyphDot(d.Dot), m); ok {
+				d.drawGlyph(dr, mask, maskp)
+			}
+		}
+		d.Dot.X += d.spacing(c)
+		prevC = c
+	}
+}
+
+// MeasureStringClusters returns how far dot would advance by drawing s with
+// DrawStringClusters.
+func (d *Drawer) MeasureStringClusters(s string) (advance fixed.Int26_6) {
+	cf, _ := d.Face.(ClusterFace)
+	prevC := rune(-1)
+	for len(s) > 0 {
+		n := NextGraphemeCluster(s)
+		cluster := s[:n]
+		s = s[n:]
+		c, size := utf8.DecodeRuneInString(cluster)
+
+		if prevC >= 0 {
+			advance += d.Face.Kern(prevC, c)
+		}
+		a, ok := fixed.Int26_6(0), false
+		if size < len(cluster) && cf != nil {
+			a, ok = cf.ClusterAdvance(cluster)
+		}
+		if !ok {
+			if a, ok = d.Face.GlyphAdvance(c); !ok {
+				continue
+			}
+		}
+		advance += a + d.spacing(c)
+		prevC = c
+	}
+	return advance
+}
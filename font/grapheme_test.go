@@ -0,0 +1,107 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestNextGraphemeCluster(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want []string
+	}{
+		{"", nil},
+		{"abc", []string{"a", "b", "c"}},
+		{"a\r\nb\n", []string{"a", "\r\n", "b", "\n"}},
+		// Combining marks.
+		{"e\u0301e\u0301\u0323x", []string{"e\u0301", "e\u0301\u0323", "x"}},
+		// A family emoji zero width joiner sequence, with skin tones.
+		{"\U0001f468\U0001f3fb\u200d\U0001f469\u200d\U0001f467!", []string{"\U0001f468\U0001f3fb\u200d\U0001f469\u200d\U0001f467", "!"}},
+		// A zero width joiner does not join letters.
+		{"a\u200db", []string{"a\u200d", "b"}},
+		// Variation selectors.
+		{"\u2764\ufe0f\u2764", []string{"\u2764\ufe0f", "\u2764"}},
+		// Flags are pairs of regional indicators.
+		{"\U0001f1ec\U0001f1e7\U0001f1eb\U0001f1f7\U0001f1ec", []string{"\U0001f1ec\U0001f1e7", "\U0001f1eb\U0001f1f7", "\U0001f1ec"}},
+		// Controls are clusters on their own.
+		{"a\u0000\u0301", []string{"a", "\u0000", "\u0301"}},
+	}
+	for _, tc := range testCases {
+		var got []string
+		for s := tc.s; len(s) > 0; {
+			n := NextGraphemeCluster(s)
+			got = append(got, s[:n])
+			s = s[n:]
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%+q: got %+q, want %+q", tc.s, got, tc.want)
+		}
+	}
+}
+
+// clusterFace is a ClusterFace that draws every cluster of more than one
+// rune as a glyph 9 pixels wide.
+type clusterFace struct {
+	rangeFace
+}
+
+func (f clusterFace) ClusterGlyph(dot fixed.Point26_6, cluster string) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	p := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor()}
+	return image.Rectangle{Min: p, Max: p.Add(image.Pt(9, 1))}, image.Opaque, image.Point{}, fixed.I(9), true
+}
+
+func (f clusterFace) ClusterAdvance(cluster string) (fixed.Int26_6, bool) {
+	return fixed.I(9), true
+}
+
+func TestDrawStringClusters(t *testing.T) {
+	// s is an 'a' with a combining acute accent, followed by a 'b'.
+	face := rangeFace{lo: 'a', hi: 'z', advance: fixed.I(5), closed: new(int)}
+	testCases := []struct {
+		desc       string
+		face       Face
+		want       fixed.Int26_6
+		wantPixels int
+	}{
+		// The face has no glyph for the accent, which is not drawn.
+		{"Face", face, fixed.I(10), 10},
+		{"ClusterFace", clusterFace{face}, fixed.I(14), 14},
+		// Composed and cached faces forward ClusterGlyph.
+		{"MultiFace", NewMultiFace(clusterFace{face}), fixed.I(14), 14},
+		{"FallbackFace", NewFallbackFace(clusterFace{face}, nil), fixed.I(14), 14},
+		{"Cache", NewCache(clusterFace{face}, 0), fixed.I(14), 14},
+	}
+	for _, tc := range testCases {
+		dst := image.NewAlpha(image.Rect(0, 0, 32, 32))
+		d := &Drawer{
+			Dst:  dst,
+			Src:  image.Opaque,
+			Face: tc.face,
+			Dot:  fixed.P(0, 10),
+		}
+		const s = "a\u0301b"
+		if got := d.MeasureStringClusters(s); got != tc.want {
+			t.Errorf("%s: MeasureStringClusters: got %v, want %v", tc.desc, got, tc.want)
+		}
+		d.DrawStringClusters(s)
+		if d.Dot.X != tc.want {
+			t.Errorf("%s: dot: got %v, want %v", tc.desc, d.Dot.X, tc.want)
+		}
+		n := 0
+		for _, a := range dst.Pix {
+			if a != 0 {
+				n++
+			}
+		}
+		if n != tc.wantPixels {
+			t.Errorf("%s: opaque pixels: got %d, want %d", tc.desc, n, tc.wantPixels)
+		}
+	}
+}
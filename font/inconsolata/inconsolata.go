@@ -22,6 +22,11 @@ import (
 	"golang.org/x/image/font/basicfont"
 )
 
+// TODO: provide faces of any size, via font/opentype, from embedded []byte
+// versions of InconsolataGo-Regular.ttf and InconsolataGo-Bold.ttf, generated
+// as for the gofont packages. The 8x16 faces below would stay as the fast path
+// for that size.
+
 // Regular8x16 is a regular weight, 8x16 font face.
 var Regular8x16 *basicfont.Face = &regular8x16
 
@@ -0,0 +1,113 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"strings"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// DrawStringJustified is like DrawString, but draws the line s exactly width
+// wide, so that it is flush with both margins, by adding extra space to its
+// word gaps: after each space and no-break space, the runes that WordSpacing
+// applies to. If letters is true, the extra space is instead spread over
+// every gap between two glyphs, including the word gaps.
+//
+// Spaces and mandatory line breaks, such as newlines, at the end of s are not
+// drawn. If s is already at least width wide, or has no gaps to stretch, it
+// is drawn as per DrawString. Tabs are drawn as per DrawString, and the gap
+// before the glyph after a tab is never stretched.
+//
+// To justify a paragraph, break it into lines with LineBreaks, and draw each
+// line but the last with DrawStringJustified:
+//
+//	breaks := font.LineBreaks(d.Face, s, width)
+//	start := 0
+//	for _, end := range append(breaks, len(s)) {
+//		d.Dot = fixed.Point26_6{X: x, Y: y}
+//		if end == len(s) {
+//			d.DrawString(s[start:])
+//		} else {
+//			d.DrawStringJustified(s[start:end], width, false)
+//		}
+//		start, y = end, y+d.Face.Metrics().Height
+//	}
+func (d *Drawer) DrawStringJustified(s string, width fixed.Int26_6, letters bool) {
+	s = strings.TrimRightFunc(s, func(r rune) bool {
+		return isBreakingSpace(r) || isMandatoryBreak(r)
+	})
+	extra := width - d.MeasureString(s)
+	gaps := fixed.Int26_6(d.justificationGaps(s, letters))
+	if extra <= 0 || gaps == 0 {
+		d.DrawString(s)
+		return
+	}
+	// Each gap gets extra/gaps, and the first extra%gaps gaps get one more
+	// 1/64th of a pixel, so that the line is exactly width wide.
+	share, rem := extra/gaps, extra%gaps
+	stretch := func() fixed.Int26_6 {
+		if rem > 0 {
+			rem--
+			return share + 1
+		}
+		return share
+	}
+
+	x0 := d.Dot.X
+	z := d.newDecorator()
+	prevC := rune(-1)
+	for _, c := range s {
+		if c == '\t' {
+			d.Dot.X = x0 + d.nextTabStop(d.Dot.X-x0)
+			prevC = -1
+			continue
+		}
+		if prevC >= 0 {
+			d.Dot.X += d.Face.Kern(prevC, c)
+			if letters {
+				d.Dot.X += stretch()
+			}
+		}
+		dr, ink, inkp, advance, ok := d.drawRune(d.glyphDot(d.Dot), c)
+		if !ok {
+			continue
+		}
+		if z != nil {
+			z.glyph(dr, ink, inkp)
+		}
+		d.Dot.X += advance + d.spacing(c)
+		if !letters && isWordSpace(c) {
+			d.Dot.X += stretch()
+		}
+		prevC = c
+	}
+	if z != nil {
+		z.draw(d.Dot.X)
+	}
+}
+
+// justificationGaps returns the number of gaps in s that DrawStringJustified
+// stretches.
+func (d *Drawer) justificationGaps(s string, letters bool) (n int) {
+	prev := false
+	for _, c := range s {
+		if c == '\t' {
+			prev = false
+			continue
+		}
+		if letters && prev {
+			n++
+		}
+		if _, ok := d.Face.GlyphAdvance(c); !ok {
+			continue
+		}
+		if !letters && isWordSpace(c) {
+			n++
+		}
+		prev = true
+	}
+	return n
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestDrawStringJustified(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		s       string
+		width   fixed.Int26_6
+		letters bool
+		// dots are the glyphs' dots, and end is the final dot, in 1/64ths
+		// of a pixel.
+		dots []fixed.Int26_6
+		end  fixed.Int26_6
+	}{{
+		desc:  "words",
+		s:     "ab cd ef  \n",
+		width: fixed.I(50),
+		dots:  []fixed.Int26_6{0, 320, 640, 1280, 1600, 1920, 2560, 2880},
+		end:   3200,
+	}, {
+		desc:    "letters",
+		s:       "ab cd ef",
+		width:   fixed.I(50),
+		letters: true,
+		dots:    []fixed.Int26_6{0, 412, 824, 1236, 1647, 2058, 2469, 2880},
+		end:     3200,
+	}, {
+		desc:  "no word gaps",
+		s:     "abcdef",
+		width: fixed.I(50),
+		dots:  []fixed.Int26_6{0, 320, 640, 960, 1280, 1600},
+		end:   1920,
+	}, {
+		desc:  "too wide",
+		s:     "ab cd ef",
+		width: fixed.I(30),
+		dots:  []fixed.Int26_6{0, 320, 640, 960, 1280, 1600, 1920, 2240},
+		end:   2560,
+	}}
+
+	for _, tc := range testCases {
+		var dots []fixed.Int26_6
+		d := &Drawer{
+			Dst:  image.NewGray(image.Rect(0, 0, 64, 4)),
+			Src:  image.White,
+			Face: dotFace{rangeFace{lo: ' ', hi: 'z', advance: fixed.I(5), closed: new(int)}, &dots},
+			Dot:  fixed.P(0, 2),
+		}
+		d.DrawStringJustified(tc.s, tc.width, tc.letters)
+		if len(dots) != len(tc.dots) {
+			t.Errorf("%s: got %d glyphs, want %d", tc.desc, len(dots), len(tc.dots))
+			continue
+		}
+		for i := range dots {
+			if dots[i] != tc.dots[i] {
+				t.Errorf("%s: glyph #%d: got dot %d, want %d", tc.desc, i, dots[i], tc.dots[i])
+			}
+		}
+		if d.Dot.X != tc.end {
+			t.Errorf("%s: final dot: got %d, want %d", tc.desc, d.Dot.X, tc.end)
+		}
+	}
+}
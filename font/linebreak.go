@@ -0,0 +1,134 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// LineBreaks returns where to break s into lines that are each at most
+// maxAdvance wide when drawn with f. It returns the byte offsets, in
+// increasing order, at which the second and subsequent lines start, so that
+// the first line is s[:breaks[0]] and the last line is s[breaks[len-1]:]. It
+// returns no offsets if s fits on one line.
+//
+// Lines are broken greedily, at line break opportunities such as after spaces
+// and hyphens and between ideographs, as per a simplified version of the
+// Unicode Line Breaking Algorithm described at
+// https://www.unicode.org/reports/tr14/. Newlines and the other mandatory line
+// breaks always end a line.
+//
+// Each line's width is measured as for MeasureString, including the kerning
+// between its runes. Spaces at the end of a line are part of that line but do
+// not count towards its width. A word that is wider than maxAdvance on its own
+// is broken between two of its runes.
+func LineBreaks(f Face, s string, maxAdvance fixed.Int26_6) (breaks []int) {
+	for lineStart := 0; lineStart < len(s); {
+		next := lineBreak(f, s, lineStart, maxAdvance)
+		if next == len(s) {
+			break
+		}
+		breaks = append(breaks, next)
+		lineStart = next
+	}
+	return breaks
+}
+
+// lineBreak returns the byte offset at which the line starting at s[lineStart]
+// ends, or len(s) if the rest of s fits on that line.
+func lineBreak(f Face, s string, lineStart int, maxAdvance fixed.Int26_6) int {
+	var (
+		advance  fixed.Int26_6
+		prevC    = rune(-1)
+		prevR    = rune(-1)
+		lastOpp  = -1
+		hasGlyph = false
+	)
+	for i := lineStart; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if prevR >= 0 && isLineBreakOpportunity(prevR, c) {
+			lastOpp = i
+		}
+		if isMandatoryBreak(c) {
+			if c == '\r' && i+size < len(s) && s[i+size] == '\n' {
+				size++
+			}
+			return i + size
+		}
+
+		a, ok := f.GlyphAdvance(c)
+		if ok {
+			if prevC >= 0 {
+				a += f.Kern(prevC, c)
+			}
+			// Trailing spaces hang past the end of the line, so only a
+			// non-space can make the line too wide.
+			if advance+a > maxAdvance && !isBreakingSpace(c) && hasGlyph {
+				if lastOpp > lineStart {
+					return lastOpp
+				}
+				return i
+			}
+			advance += a
+			prevC = c
+			hasGlyph = true
+		}
+		prevR = c
+		i += size
+	}
+	return len(s)
+}
+
+// isMandatoryBreak returns whether a line must end after r.
+func isMandatoryBreak(r rune) bool {
+	switch r {
+	case '\n', '\v', '\f', '\r', '\u0085', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
+
+// isBreakingSpace returns whether r is a space that a line can be broken
+// after. No-break spaces are not.
+func isBreakingSpace(r rune) bool {
+	switch r {
+	case '\u00a0', '\u2007', '\u202f': // No-break spaces.
+		return false
+	}
+	return unicode.Is(unicode.Zs, r) || r == '\t'
+}
+
+// isIdeographic returns whether r is from a script, such as Chinese, that
+// can be broken between any two of its characters.
+func isIdeographic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// isLineBreakOpportunity returns whether a line can be broken between the
+// runes r0 and r1.
+func isLineBreakOpportunity(r0, r1 rune) bool {
+	switch {
+	case isBreakingSpace(r1):
+		// Never break before a space, so that spaces stay at the end of the
+		// line that they follow.
+		return false
+	case isBreakingSpace(r0), r0 == '\u200b': // Zero width space.
+		return true
+	case r0 == '-', r0 == '\u2010', r0 == '\u2013':
+		// Break after a hyphen or en dash, but not in a negative number.
+		return unicode.IsLetter(r1)
+	case r0 == '\u2014': // Em dash.
+		return true
+	case isIdeographic(r0) || isIdeographic(r1):
+		// Don't break before closing punctuation or after opening
+		// punctuation.
+		return !unicode.In(r1, unicode.Pe, unicode.Pf, unicode.Po) &&
+			!unicode.In(r0, unicode.Ps, unicode.Pi)
+	}
+	return false
+}
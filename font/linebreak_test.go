@@ -0,0 +1,47 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestLineBreaks(t *testing.T) {
+	testCases := []struct {
+		s          string
+		maxAdvance int // In units of toyAdvance.
+		want       []int
+	}{
+		{"", 5, nil},
+		{"hello", 5, nil},
+		{"hello world", 11, nil},
+		// The space hangs off the end of the first line.
+		{"hello world", 5, []int{6}},
+		{"hello world", 8, []int{6}},
+		{"a b c d e", 3, []int{4, 8}},
+		// Words that are too wide are broken.
+		{"abcdefgh", 3, []int{3, 6}},
+		{"ab abcdefgh", 3, []int{3, 6, 9}},
+		// Newlines always break.
+		{"a\nb", 5, []int{2}},
+		{"a\r\nb\n\nc", 5, []int{3, 5, 6}},
+		// Break after hyphens, but not before digits or at no-break spaces.
+		{"well-known", 7, []int{5}},
+		{"x -1234", 6, []int{2}},
+		{"a\u00a0b c", 4, []int{5}},
+		// Break between ideographs, but not before closing punctuation.
+		{"日本語", 2, []int{6}},
+		{"日本。", 2, []int{3}},
+	}
+	for _, tc := range testCases {
+		got := LineBreaks(toyFace{}, tc.s, fixed.Int26_6(tc.maxAdvance)*toyAdvance)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("LineBreaks(%q, %d): got %v, want %v", tc.s, tc.maxAdvance, got, tc.want)
+		}
+	}
+}
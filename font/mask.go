@@ -0,0 +1,42 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// StringMask returns a newly allocated mask of s drawn with f, with the dot
+// starting at the origin, and how far the dot advances. The mask's bounds are
+// the smallest rectangle that contains every pixel that DrawString would
+// draw to, so that its top-left corner is typically above and possibly to the
+// left of the origin. Drawing the mask, through a source color, at a point p
+// is equivalent to calling DrawString with the dot at p.
+//
+// This is useful for texture atlases and GPU pipelines, which want text as a
+// mask rather than drawn onto a destination image.
+func StringMask(f Face, s string) (mask *image.Alpha, advance fixed.Int26_6) {
+	d := &Drawer{Face: f}
+
+	// Find the union of the glyphs' rectangles.
+	var r image.Rectangle
+	it := d.Glyphs(s)
+	for it.Next() {
+		g := it.Glyph()
+		if !g.Drawn {
+			continue
+		}
+		if dr, _, _, _, ok := f.Glyph(g.Dot, g.Rune); ok {
+			r = r.Union(dr)
+		}
+	}
+
+	d.Dst = image.NewAlpha(r)
+	d.Src = image.Opaque
+	d.DrawString(s)
+	return d.Dst.(*image.Alpha), d.Dot.X
+}
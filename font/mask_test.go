@@ -0,0 +1,46 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestStringMask(t *testing.T) {
+	f := descenderFace{rangeFace{
+		lo: 'a', hi: 'z', advance: fixed.I(10), kern: -fixed.I(1),
+		metrics: Metrics{Height: fixed.I(12), Ascent: fixed.I(8), Descent: fixed.I(4)},
+		closed:  new(int),
+	}}
+
+	mask, advance := StringMask(f, "ag")
+	if want := image.Rect(2, -5, 17, 4); mask.Rect != want {
+		t.Errorf("bounds: got %v, want %v", mask.Rect, want)
+	}
+	if want := fixed.I(19); advance != want {
+		t.Errorf("advance: got %v, want %v", advance, want)
+	}
+
+	// Drawing the mask should be the same as drawing the string.
+	p := image.Point{X: 5, Y: 10}
+	got := image.NewGray(image.Rect(0, 0, 32, 16))
+	draw.DrawMask(got, mask.Rect.Add(p), image.White, image.Point{}, mask, mask.Rect.Min, draw.Over)
+	want := image.NewGray(got.Rect)
+	d := &Drawer{Dst: want, Src: image.White, Face: f, Dot: fixed.P(p.X, p.Y)}
+	d.DrawString("ag")
+	if !bytes.Equal(got.Pix, want.Pix) {
+		t.Errorf("drawing the mask differs from drawing the string")
+	}
+
+	mask, advance = StringMask(f, "")
+	if !mask.Rect.Empty() || advance != 0 {
+		t.Errorf("empty string: got %v, %v, want empty, 0", mask.Rect, advance)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"image/color"
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// NewMultiFace returns a Face that draws each rune with the first of the
+// given faces that contains a glyph for it. For example, the faces could be a
+// Latin face followed by CJK and emoji faces, so that mixed text can be drawn
+// by a single Drawer.DrawString call.
+//
+// The multi-face's Metrics are those of the first face, so that line spacing
+// does not depend on which faces the text uses. Pairs of runes are only
+// kerned if their glyphs come from the same face.
+//
+// The multi-face draws color glyphs, grapheme clusters and vertical text with
+// the faces that support them, as if it were a ColorFace, a ClusterFace and a
+// VerticalFace, and its
+// DecorationMetrics are those of the first face, if it is a DecorationFace.
+//
+// Closing the multi-face closes each of the faces.
+func NewMultiFace(faces ...Face) Face {
+	return &multiFace{faces: append([]Face(nil), faces...)}
+}
+
+type multiFace struct {
+	faces []Face
+}
+
+// index returns the index of the first face that contains a glyph for r, or
+// -1 if there is no such face.
+func (m *multiFace) index(r rune) int {
+	for i, f := range m.faces {
+		if _, ok := f.GlyphAdvance(r); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *multiFace) Close() (retErr error) {
+	for _, f := range m.faces {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+	return retErr
+}
+
+func (m *multiFace) Glyph(dot fixed.Point26_6, r rune) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	for _, f := range m.faces {
+		if dr, mask, maskp, advance, ok = f.Glyph(dot, r); ok {
+			return dr, mask, maskp, advance, true
+		}
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+func (m *multiFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	for _, f := range m.faces {
+		if bounds, advance, ok = f.GlyphBounds(r); ok {
+			return bounds, advance, true
+		}
+	}
+	return fixed.Rectangle26_6{}, 0, false
+}
+
+func (m *multiFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	for _, f := range m.faces {
+		if advance, ok = f.GlyphAdvance(r); ok {
+			return advance, true
+		}
+	}
+	return 0, false
+}
+
+func (m *multiFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	i := m.index(r0)
+	if i < 0 || i != m.index(r1) {
+		return 0
+	}
+	return m.faces[i].Kern(r0, r1)
+}
+
+func (m *multiFace) Metrics() Metrics {
+	if len(m.faces) == 0 {
+		return Metrics{}
+	}
+	return m.faces[0].Metrics()
+}
+
+// RoundDot rounds dot as the first face does, if it is a RoundingFace. The
+// Drawer's Bound methods round each glyph's dot as the face that draws it
+// does.
+func (m *multiFace) RoundDot(dot fixed.Point26_6) fixed.Point26_6 {
+	if len(m.faces) > 0 {
+		if rf, ok := m.faces[0].(RoundingFace); ok {
+			return rf.RoundDot(dot)
+		}
+	}
+	return dot
+}
+
+func (m *multiFace) roundRuneDot(dot fixed.Point26_6, r rune) fixed.Point26_6 {
+	i := m.index(r)
+	if i < 0 {
+		return dot
+	}
+	return roundDot(m.faces[i], dot, r)
+}
+
+// ColorGlyph draws r's color glyph with the face that has r's glyph, if that
+// face is a ColorFace.
+func (m *multiFace) ColorGlyph(dot fixed.Point26_6, r rune, fg color.Color) (
+	dr image.Rectangle, src image.Image, sp image.Point, advance fixed.Int26_6, ok bool) {
+
+	if i := m.index(r); i >= 0 {
+		if cf, isColor := m.faces[i].(ColorFace); isColor {
+			return cf.ColorGlyph(dot, r, fg)
+		}
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+// DecorationMetrics returns the first face's decoration metrics, if it is a
+// DecorationFace, or else the zero value.
+func (m *multiFace) DecorationMetrics() DecorationMetrics {
+	if len(m.faces) > 0 {
+		if df, ok := m.faces[0].(DecorationFace); ok {
+			return df.DecorationMetrics()
+		}
+	}
+	return DecorationMetrics{}
+}
+
+// VerticalGlyph draws r's vertical glyph with the face that has r's glyph.
+func (m *multiFace) VerticalGlyph(dot fixed.Point26_6, r rune) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	if i := m.index(r); i >= 0 {
+		return verticalGlyph(m.faces[i], dot, r)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+func (m *multiFace) VerticalGlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	if i := m.index(r); i >= 0 {
+		return verticalGlyphAdvance(m.faces[i], r)
+	}
+	return 0, false
+}
+
+// ClusterGlyph draws the cluster with the face that has its first rune's
+// glyph, if that face is a ClusterFace.
+func (m *multiFace) ClusterGlyph(dot fixed.Point26_6, cluster string) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+
+	r, _ := utf8.DecodeRuneInString(cluster)
+	if i := m.index(r); i >= 0 {
+		return clusterGlyph(m.faces[i], dot, cluster)
+	}
+	return image.Rectangle{}, nil, image.Point{}, 0, false
+}
+
+func (m *multiFace) ClusterAdvance(cluster string) (advance fixed.Int26_6, ok bool) {
+	r, _ := utf8.DecodeRuneInString(cluster)
+	if i := m.index(r); i >= 0 {
+		return clusterAdvance(m.faces[i], cluster)
+	}
+	return 0, false
+}
@@ -0,0 +1,199 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// rangeFace is a Face that contains glyphs for the runes in [lo, hi], whose
+// advances are all the same.
+type rangeFace struct {
+	lo, hi   rune
+	advance  fixed.Int26_6
+	kern     fixed.Int26_6
+	metrics  Metrics
+	closeErr error
+	closed   *int
+}
+
+func (f rangeFace) Close() error {
+	*f.closed++
+	return f.closeErr
+}
+
+func (f rangeFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	p := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor()}
+	dr := image.Rectangle{Min: p, Max: p.Add(image.Pt(f.advance.Floor(), 1))}
+	return dr, image.Opaque, image.Point{}, f.advance, true
+}
+
+func (f rangeFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	return fixed.Rectangle26_6{Max: fixed.Point26_6{X: f.advance, Y: fixed.I(1)}}, f.advance, true
+}
+
+func (f rangeFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return 0, false
+	}
+	return f.advance, true
+}
+
+func (f rangeFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	return f.kern
+}
+
+func (f rangeFace) Metrics() Metrics {
+	return f.metrics
+}
+
+func TestMultiFace(t *testing.T) {
+	closed := 0
+	errClose := errors.New("close error")
+	latin := rangeFace{
+		lo: 'a', hi: 'z', advance: fixed.I(5), kern: -fixed.I(1),
+		metrics: Metrics{Height: fixed.I(12), Ascent: fixed.I(10), Descent: fixed.I(2)},
+		closed:  &closed,
+	}
+	cjk := rangeFace{
+		lo: 0x4e00, hi: 0x9fff, advance: fixed.I(12), kern: -fixed.I(2),
+		metrics:  Metrics{Height: fixed.I(16), Ascent: fixed.I(13), Descent: fixed.I(3)},
+		closeErr: errClose,
+		closed:   &closed,
+	}
+	// The first face to contain a glyph wins, so the second Latin face is
+	// never used.
+	other := rangeFace{lo: 'a', hi: 'z', advance: fixed.I(7), closed: &closed}
+	f := NewMultiFace(latin, cjk, other)
+
+	if got, want := f.Metrics(), latin.metrics; got != want {
+		t.Errorf("Metrics: got %v, want %v", got, want)
+	}
+	testCases := []struct {
+		r           rune
+		wantAdvance fixed.Int26_6
+		wantOK      bool
+	}{
+		{'a', fixed.I(5), true},
+		{'中', fixed.I(12), true},
+		{'!', 0, false},
+	}
+	for _, tc := range testCases {
+		if advance, ok := f.GlyphAdvance(tc.r); advance != tc.wantAdvance || ok != tc.wantOK {
+			t.Errorf("%q: GlyphAdvance: got %v, %t, want %v, %t", tc.r, advance, ok, tc.wantAdvance, tc.wantOK)
+		}
+		if _, advance, ok := f.GlyphBounds(tc.r); advance != tc.wantAdvance || ok != tc.wantOK {
+			t.Errorf("%q: GlyphBounds: got %v, %t, want %v, %t", tc.r, advance, ok, tc.wantAdvance, tc.wantOK)
+		}
+		if _, _, _, advance, ok := f.Glyph(fixed.P(0, 0), tc.r); advance != tc.wantAdvance || ok != tc.wantOK {
+			t.Errorf("%q: Glyph: got %v, %t, want %v, %t", tc.r, advance, ok, tc.wantAdvance, tc.wantOK)
+		}
+	}
+
+	kernTestCases := []struct {
+		r0, r1 rune
+		want   fixed.Int26_6
+	}{
+		{'a', 'b', -fixed.I(1)},
+		{'中', '文', -fixed.I(2)},
+		{'a', '中', 0},
+		{'!', 'a', 0},
+	}
+	for _, tc := range kernTestCases {
+		if got := f.Kern(tc.r0, tc.r1); got != tc.want {
+			t.Errorf("Kern(%q, %q): got %v, want %v", tc.r0, tc.r1, got, tc.want)
+		}
+	}
+
+	// Mixed text is measured with each rune's face. The Latin pair is kerned
+	// but the unknown rune is skipped.
+	if got, want := MeasureString(f, "ab中!"), fixed.I(5+5-1+12); got != want {
+		t.Errorf("MeasureString: got %v, want %v", got, want)
+	}
+
+	if err := f.Close(); err != errClose {
+		t.Errorf("Close: got %v, want %v", err, errClose)
+	}
+	if closed != 3 {
+		t.Errorf("Close: closed %d faces, want 3", closed)
+	}
+}
+
+// TestMultiFaceOptionalInterfaces tests that a multi-face, and a fallback
+// face, draw color glyphs, vertical glyphs and decorations with the faces
+// that support them.
+func TestMultiFaceOptionalInterfaces(t *testing.T) {
+	digits := rangeFace{
+		lo: '0', hi: '9', advance: fixed.I(4),
+		metrics: Metrics{Ascent: fixed.I(6), Descent: fixed.I(2)},
+		closed:  new(int),
+	}
+	var fgs []color.Color
+	emoji := colorFace{rangeFace{lo: 'a', hi: 'z', advance: fixed.I(4), closed: new(int)}, &fgs}
+	faces := []Face{
+		NewMultiFace(digits, emoji),
+		NewFallbackFace(digits, &FallbackOptions{Face: emoji}),
+		NewCache(NewMultiFace(digits, emoji), 0),
+	}
+	for _, f := range faces {
+		fgs = nil
+		dst := image.NewRGBA(image.Rect(0, 0, 8, 4))
+		d := &Drawer{Dst: dst, Src: image.White, Face: f, Dot: fixed.P(0, 2)}
+		d.DrawString("1a")
+		if len(fgs) != 1 {
+			t.Errorf("%T: ColorGlyph calls: got %d, want 1", f, len(fgs))
+		}
+		if got, want := dst.RGBAAt(5, 1), (color.RGBA{0xff, 0x00, 0x00, 0xff}); got != want {
+			t.Errorf("%T: color glyph: got %v, want %v", f, got, want)
+		}
+		if got, want := dst.RGBAAt(1, 2), (color.RGBA{0xff, 0xff, 0xff, 0xff}); got != want {
+			t.Errorf("%T: mask glyph: got %v, want %v", f, got, want)
+		}
+	}
+
+	vertical := verticalFace{rangeFace{lo: 'a', hi: 'z', advance: fixed.I(4), closed: new(int)}}
+	faces = []Face{
+		NewMultiFace(digits, vertical),
+		NewFallbackFace(digits, &FallbackOptions{Face: vertical}),
+		NewCache(NewMultiFace(digits, vertical), 0),
+	}
+	for _, f := range faces {
+		d := &Drawer{Face: f}
+		// '1' is stacked one line height, 8 pixels, below the dot, and 'a'
+		// is a vertical glyph that advances 4 pixels.
+		if got, want := d.MeasureStringVertical("1a"), fixed.I(12); got != want {
+			t.Errorf("%T: MeasureStringVertical: got %v, want %v", f, got, want)
+		}
+	}
+
+	dm := DecorationMetrics{UnderlineOffset: fixed.I(3), UnderlineThickness: fixed.I(2)}
+	decorated := decorationFace{descenderFace{digits}, dm}
+	faces = []Face{
+		NewMultiFace(decorated, emoji),
+		NewFallbackFace(decorated, nil),
+		NewCache(decorated, 0),
+	}
+	for _, f := range faces {
+		df, ok := f.(DecorationFace)
+		if !ok {
+			t.Errorf("%T: not a DecorationFace", f)
+			continue
+		}
+		if got := df.DecorationMetrics(); got != dm {
+			t.Errorf("%T: DecorationMetrics: got %v, want %v", f, got, dm)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	stddraw "image/draw"
+	_ "image/jpeg" // Register the decoder for sbix "jpg " images.
+	_ "image/png"  // Register the decoder for sbix and CBDT "png " images.
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// ColorFace is an alias for font.ColorFace.
+type ColorFace = font.ColorFace
+
+var _ ColorFace = (*Face)(nil)
+
+// ColorGlyph satisfies the ColorFace interface.
+//
+// Color glyphs are drawn from, in order of preference, the sfnt.Font's COLR
+// and CPAL tables, using the first palette and the version 0 layers, and its
+// sbix and CBDT bitmaps, scaled to the face's size. The glyph origin is as
+// per the Glyph method.
+func (f *Face) ColorGlyph(dot fixed.Point26_6, r rune, fg color.Color) (dr image.Rectangle, src image.Image, sp image.Point, advance fixed.Int26_6, ok bool) {
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil || x == 0 {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	dotX := (dot.X + f.phase/2) &^ (f.phase - 1)
+	fgRGBA := color.RGBAModel.Convert(fg).(color.RGBA)
+	m, err := f.colorGlyph(x, dotX&63, fgRGBA)
+	if err != nil || m == nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	origin := image.Point{X: dotX.Floor(), Y: dot.Y.Round()}
+	return m.color.Rect.Add(origin), m.color, m.color.Rect.Min, m.advance, true
+}
+
+// colorGlyph returns the x'th glyph's color image, rendering and caching it
+// if it isn't already cached. It returns nil if x is not a color glyph.
+func (f *Face) colorGlyph(x sfnt.GlyphIndex, subpixel fixed.Int26_6, fg color.RGBA) (*cachedMask, error) {
+	k := maskKey{x: x, ppem: f.scale, subpixel: subpixel, hinting: f.hinting, color: true, fg: fg}
+	if e, ok := f.masks[k]; ok {
+		f.lru.MoveToFront(e)
+		return e.Value.(*cachedMask), nil
+	}
+
+	img, err := f.renderColorLayers(x, subpixel, fg)
+	if err != nil {
+		return nil, err
+	}
+	if img == nil {
+		img, err = f.renderBitmap(x, subpixel)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if img == nil {
+		return nil, nil
+	}
+	advance, err := f.glyphAdvance(x)
+	if err != nil {
+		return nil, err
+	}
+	m := &cachedMask{key: k, color: img, advance: advance}
+	f.cache(m)
+	return m, nil
+}
+
+// renderColorLayers draws the x'th glyph's COLR layers. It returns nil if x
+// has no layers.
+func (f *Face) renderColorLayers(x sfnt.GlyphIndex, subpixel fixed.Int26_6, fg color.RGBA) (*image.RGBA, error) {
+	layers, err := f.f.ColorLayers(&f.buf, x)
+	if err != nil || len(layers) == 0 {
+		return nil, err
+	}
+	palette, err := f.f.Palette(&f.buf, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rasterize the layers' masks, which are also cached, before drawing
+	// them, to find the overall bounds.
+	masks := make([]*image.Alpha, len(layers))
+	var r image.Rectangle
+	for i, l := range layers {
+		m, err := f.glyphMask(l.GlyphIndex, subpixel)
+		if err != nil {
+			return nil, err
+		}
+		masks[i] = m.mask
+		r = r.Union(m.mask.Rect)
+	}
+
+	dst := image.NewRGBA(r)
+	for i, l := range layers {
+		var c color.Color = fg
+		if l.PaletteIndex != sfnt.ForegroundPaletteIndex {
+			if int(l.PaletteIndex) >= len(palette) {
+				return nil, errInvalidPaletteIndex
+			}
+			c = palette[l.PaletteIndex]
+		}
+		mr := masks[i].Rect
+		stddraw.DrawMask(dst, mr, image.NewUniform(c), image.Point{}, masks[i], mr.Min, stddraw.Over)
+	}
+	return dst, nil
+}
+
+// renderBitmap decodes and scales the x'th glyph's sbix or CBDT bitmap. It
+// returns nil if x has no bitmap.
+func (f *Face) renderBitmap(x sfnt.GlyphIndex, subpixel fixed.Int26_6) (*image.RGBA, error) {
+	g, err := f.f.SbixGlyph(&f.buf, x, f.scale)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		g, err = f.f.CBDTGlyph(&f.buf, x, f.scale)
+		if err != nil || g == nil {
+			return nil, err
+		}
+	}
+	src, _, err := image.Decode(bytes.NewReader(g.Data))
+	if err != nil {
+		return nil, err
+	}
+	if g.PPEM <= 0 {
+		return nil, errInvalidBitmapStrike
+	}
+
+	// Scale from the strike's pixels to the face's pixels. The bitmap's
+	// origin is its bottom left corner, with the y axis increasing up.
+	s := float64(f.scale) / float64(64*g.PPEM)
+	sx := s * f.xScale
+	sb := src.Bounds()
+	left := float64(g.OriginX)*sx + float64(subpixel)/64
+	bottom := float64(g.OriginY) * s
+	r := image.Rect(
+		round(left),
+		round(-bottom-float64(sb.Dy())*s),
+		round(left+float64(sb.Dx())*sx),
+		round(-bottom),
+	)
+	dst := image.NewRGBA(r)
+	draw.ApproxBiLinear.Scale(dst, r, src, sb, draw.Src, nil)
+	return dst, nil
+}
+
+func round(x float64) int {
+	if x < 0 {
+		return -int(0.5 - x)
+	}
+	return int(0.5 + x)
+}
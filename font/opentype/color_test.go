@@ -0,0 +1,156 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// withTables returns goregular with the given tables added.
+func withTables(t *testing.T, tables map[string][]byte) *sfnt.Font {
+	f := parseGoRegular(t)
+	b, err := sfnt.NewBuilder(f)
+	if err != nil {
+		t.Fatalf("NewBuilder: %v", err)
+	}
+	for tag, data := range tables {
+		b.SetTable(sfnt.MustParseTag(tag), data)
+	}
+	data, err := b.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	f, err = sfnt.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return f
+}
+
+func be16(b []byte, vs ...int) []byte {
+	for _, v := range vs {
+		b = append(b, byte(v>>8), byte(v))
+	}
+	return b
+}
+
+func glyphIndex(t *testing.T, f *sfnt.Font, r rune) sfnt.GlyphIndex {
+	x, err := f.GlyphIndex(nil, r)
+	if err != nil || x == 0 {
+		t.Fatalf("GlyphIndex(%q): got %d, %v", r, x, err)
+	}
+	return x
+}
+
+func TestColorGlyphCOLR(t *testing.T) {
+	f := parseGoRegular(t)
+	bigO, smallO := glyphIndex(t, f, 'O'), glyphIndex(t, f, 'o')
+
+	// The 'O' glyph is a red 'O' layer and a foreground colored 'o' layer.
+	colr := be16(nil, 0, 1, 0, 14, 0, 20, 2)
+	colr = be16(colr, int(bigO), 0, 2)
+	colr = be16(colr, int(bigO), 0, int(smallO), sfnt.ForegroundPaletteIndex)
+	cpal := be16(nil, 0, 1, 1, 1, 0, 14, 0)
+	cpal = append(cpal, 0x00, 0x00, 0xff, 0xff) // Red, in BGRA order.
+	f = withTables(t, map[string][]byte{"COLR": colr, "CPAL": cpal})
+
+	face, err := NewFace(f, &FaceOptions{Size: 32, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	blue := color.RGBA{0x00, 0x00, 0xff, 0xff}
+	dr, src, sp, advance, ok := face.ColorGlyph(fixed.P(10, 40), 'O', blue)
+	if !ok {
+		t.Fatalf("ColorGlyph('O'): got !ok")
+	}
+	if _, wantAdvance, _ := face.GlyphBounds('O'); advance != wantAdvance {
+		t.Errorf("advance: got %v, want %v", advance, wantAdvance)
+	}
+	maskDR, _, _, _, _ := face.Glyph(fixed.P(10, 40), 'O')
+	if dr != maskDR {
+		t.Errorf("dr: got %v, want %v", dr, maskDR)
+	}
+	var red, fg int
+	for y := 0; y < dr.Dy(); y++ {
+		for x := 0; x < dr.Dx(); x++ {
+			switch src.At(sp.X+x, sp.Y+y) {
+			case color.RGBA{0xff, 0x00, 0x00, 0xff}:
+				red++
+			case blue:
+				fg++
+			}
+		}
+	}
+	if red == 0 || fg == 0 {
+		t.Errorf("got %d red and %d blue pixels, want both non-zero", red, fg)
+	}
+
+	if _, _, _, _, ok := face.ColorGlyph(fixed.P(10, 40), 'A', blue); ok {
+		t.Errorf("ColorGlyph('A'): got ok, want !ok")
+	}
+}
+
+func TestColorGlyphSbix(t *testing.T) {
+	f := parseGoRegular(t)
+	bigA := glyphIndex(t, f, 'A')
+
+	// A 4x4 green image, for a 16 ppem strike.
+	m := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range m.Pix {
+		m.Pix[i] = 0xff
+		if i%4 == 0 || i%4 == 2 {
+			m.Pix[i] = 0x00
+		}
+	}
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, m); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	glyph := be16(nil, 0, 0)
+	glyph = append(glyph, "png "...)
+	glyph = append(glyph, pngData.Bytes()...)
+
+	numGlyphs := f.NumGlyphs()
+	strike := be16(nil, 16, 72)
+	dataOffset := 4 + 4*(numGlyphs+1)
+	for i := 0; i <= numGlyphs; i++ {
+		o := dataOffset
+		if i > int(bigA) {
+			o += len(glyph)
+		}
+		strike = append(strike, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(strike[len(strike)-4:], uint32(o))
+	}
+	strike = append(strike, glyph...)
+	sbix := be16(nil, 1, 0, 0, 1, 0, 12)
+	sbix = append(sbix, strike...)
+	f = withTables(t, map[string][]byte{"sbix": sbix})
+
+	face, err := NewFace(f, &FaceOptions{Size: 32, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	dr, src, sp, _, ok := face.ColorGlyph(fixed.P(10, 40), 'A', color.Black)
+	if !ok {
+		t.Fatalf("ColorGlyph('A'): got !ok")
+	}
+	if want := image.Rect(10, 32, 18, 40); dr != want {
+		t.Errorf("dr: got %v, want %v", dr, want)
+	}
+	if got, want := src.At(sp.X+4, sp.Y+4), (color.RGBA{0x00, 0xff, 0x00, 0xff}); got != want {
+		t.Errorf("color: got %v, want %v", got, want)
+	}
+	if _, _, _, _, ok := face.ColorGlyph(fixed.P(10, 40), 'B', color.Black); ok {
+		t.Errorf("ColorGlyph('B'): got ok, want !ok")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// DecorationMetrics is an alias for font.DecorationMetrics.
+type DecorationMetrics = font.DecorationMetrics
+
+// DecorationFace is an alias for font.DecorationFace.
+type DecorationFace = font.DecorationFace
+
+var _ DecorationFace = (*Face)(nil)
+
+// DecorationMetrics satisfies the DecorationFace interface.
+//
+// The underline metrics come from the sfnt.Font's post table, and the
+// strikethrough metrics from its OS/2 table. For fonts without OS/2
+// strikeout metrics, the strikethrough is as thick as the underline, and its
+// center is a quarter of the ascent above the baseline. When hinting, the
+// metrics are rounded to whole pixels, and the thicknesses are at least one
+// pixel.
+func (f *Face) DecorationMetrics() DecorationMetrics {
+	d, err := f.f.DecorationMetrics(&f.buf)
+	if err != nil {
+		return DecorationMetrics{}
+	}
+	upem := fixed.Int26_6(f.f.UnitsPerEm())
+	m := DecorationMetrics{
+		StrikethroughOffset:    scale(-fixed.Int26_6(d.StrikeoutPosition)*f.scale, upem),
+		StrikethroughThickness: scale(fixed.Int26_6(d.StrikeoutSize)*f.scale, upem),
+	}
+	m.UnderlineOffset, m.UnderlineThickness = f.underline(d)
+	if d.StrikeoutSize <= 0 {
+		m.StrikethroughThickness = scale(fixed.Int26_6(d.UnderlineThickness)*f.scale, upem)
+		m.StrikethroughOffset = -f.Metrics().Ascent/4 - m.StrikethroughThickness/2
+	}
+	if f.hinting != font.HintingNone {
+		m.StrikethroughOffset = fixed.I(m.StrikethroughOffset.Round())
+		m.StrikethroughThickness = fixed.I(m.StrikethroughThickness.Round())
+		if m.StrikethroughThickness < fixed.I(1) {
+			m.StrikethroughThickness = fixed.I(1)
+		}
+	}
+	return m
+}
+
+// underline returns the underline's offset below the baseline and its
+// thickness, scaled from the font's post table metrics d. When hinting, they
+// are rounded to whole pixels, and the thickness is at least one pixel.
+func (f *Face) underline(d sfnt.DecorationMetrics) (offset, thickness fixed.Int26_6) {
+	upem := fixed.Int26_6(f.f.UnitsPerEm())
+	offset = scale(-fixed.Int26_6(d.UnderlinePosition)*f.scale, upem)
+	thickness = scale(fixed.Int26_6(d.UnderlineThickness)*f.scale, upem)
+	if f.hinting != font.HintingNone {
+		offset = fixed.I(offset.Round())
+		thickness = fixed.I(thickness.Round())
+		if thickness < fixed.I(1) {
+			thickness = fixed.I(1)
+		}
+	}
+	return offset, thickness
+}
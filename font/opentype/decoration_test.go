@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestDecorationMetrics(t *testing.T) {
+	f := parseGoRegular(t)
+	// Go Regular has 2048 units per em, so at 2048 pixels per em, the metrics
+	// are the same as the font's post and OS/2 values, in pixels.
+	testCases := []struct {
+		size    float64
+		hinting font.Hinting
+		want    DecorationMetrics
+	}{{
+		size: 2048,
+		want: DecorationMetrics{
+			UnderlineOffset:        fixed.I(125),
+			UnderlineThickness:     fixed.I(50),
+			StrikethroughOffset:    fixed.I(-512),
+			StrikethroughThickness: fixed.I(102),
+		},
+	}, {
+		// 12/2048 of 125, 50, 512 and 102 units.
+		size: 12,
+		want: DecorationMetrics{
+			UnderlineOffset:        47,
+			UnderlineThickness:     19,
+			StrikethroughOffset:    -192,
+			StrikethroughThickness: 38,
+		},
+	}, {
+		size:    12,
+		hinting: font.HintingFull,
+		want: DecorationMetrics{
+			UnderlineOffset:        fixed.I(1),
+			UnderlineThickness:     fixed.I(1),
+			StrikethroughOffset:    fixed.I(-3),
+			StrikethroughThickness: fixed.I(1),
+		},
+	}}
+
+	for _, tc := range testCases {
+		face, err := NewFace(f, &FaceOptions{Size: tc.size, DPI: 72, Hinting: tc.hinting})
+		if err != nil {
+			t.Fatalf("NewFace: %v", err)
+		}
+		if got := face.DecorationMetrics(); got != tc.want {
+			t.Errorf("size=%v, hinting=%v:\ngot  %+v\nwant %+v", tc.size, tc.hinting, got, tc.want)
+		}
+	}
+}
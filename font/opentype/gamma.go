@@ -0,0 +1,35 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"math"
+)
+
+// gammaTable maps linear coverage values to gamma corrected ones.
+type gammaTable [256]uint8
+
+// newGammaTable returns the table for the given gamma. It returns nil if the
+// gamma is zero or one, meaning that coverage is linear.
+func newGammaTable(gamma float64) *gammaTable {
+	if gamma == 0 || gamma == 1 {
+		return nil
+	}
+	t := new(gammaTable)
+	for i := range t {
+		t[i] = uint8(math.Round(0xff * math.Pow(float64(i)/0xff, 1/gamma)))
+	}
+	return t
+}
+
+// apply replaces each of pix's coverage values by its gamma corrected value.
+func (t *gammaTable) apply(pix []uint8) {
+	if t == nil {
+		return
+	}
+	for i, v := range pix {
+		pix[i] = t[v]
+	}
+}
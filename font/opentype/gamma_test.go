@@ -0,0 +1,53 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestGamma(t *testing.T) {
+	f := parseGoRegular(t)
+	if _, err := NewFace(f, &FaceOptions{Size: 12, DPI: 72, Gamma: -1}); err == nil {
+		t.Errorf("negative gamma: got nil error, want non-nil")
+	}
+	if g := newGammaTable(1); g != nil {
+		t.Errorf("gamma 1: got non-nil table, want nil")
+	}
+
+	glyph := func(gamma float64) *image.Alpha {
+		face, err := NewFace(f, &FaceOptions{Size: 24, DPI: 72, Gamma: gamma})
+		if err != nil {
+			t.Fatalf("NewFace: %v", err)
+		}
+		_, mask, _, _, _ := face.Glyph(fixed.P(0, 0), 'e')
+		return mask.(*image.Alpha)
+	}
+	linear, heavy, light := glyph(0), glyph(2.2), glyph(1/2.2)
+	if len(heavy.Pix) != len(linear.Pix) || len(light.Pix) != len(linear.Pix) {
+		t.Fatalf("mask sizes differ")
+	}
+	partial := 0
+	for i, v := range linear.Pix {
+		if v == 0x00 || v == 0xff {
+			// Zero and full coverage are unchanged.
+			if heavy.Pix[i] != v || light.Pix[i] != v {
+				t.Errorf("pixel %d: got %#02x and %#02x, want %#02x", i, heavy.Pix[i], light.Pix[i], v)
+			}
+			continue
+		}
+		partial++
+		if !(light.Pix[i] <= v && v <= heavy.Pix[i]) {
+			t.Errorf("pixel %d: got %#02x (light) and %#02x (heavy), want them either side of %#02x",
+				i, light.Pix[i], heavy.Pix[i], v)
+		}
+	}
+	if partial == 0 {
+		t.Errorf("got no partially covered pixels")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"image"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// SubpixelOrder is the horizontal order of an LCD display's red, green and
+// blue subpixels.
+type SubpixelOrder int
+
+const (
+	// SubpixelOrderNone means to not render for an LCD display.
+	SubpixelOrderNone SubpixelOrder = iota
+	// SubpixelOrderRGB means that red is on the left and blue on the right.
+	SubpixelOrderRGB
+	// SubpixelOrderBGR means that blue is on the left and red on the right.
+	SubpixelOrderBGR
+)
+
+// lcdFilter is the weights, out of 256, of the filter that is applied to the
+// horizontally oversampled coverage, to reduce color fringes. It is FreeType's
+// default LCD filter.
+var lcdFilter = [5]uint32{0x08, 0x4d, 0x56, 0x4d, 0x08}
+
+// LCDGlyph is like Glyph, but for LCD displays with the subpixel order given
+// by FaceOptions.SubpixelOrder. The glyph is rasterized at 3 times the
+// horizontal resolution, and the mask's red, green and blue channels hold
+// the coverage of each pixel's red, green and blue subpixels. Its alpha
+// channel holds the maximum of the three.
+//
+// To draw the glyph in a color c, blend each destination channel towards c's
+// channel by the mask's corresponding channel.
+//
+// It returns !ok if the face's SubpixelOrder is SubpixelOrderNone.
+func (f *Face) LCDGlyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask *image.RGBA, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	if f.subpixelOrder == SubpixelOrderNone {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil || x == 0 {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	dotX := (dot.X + f.phase/2) &^ (f.phase - 1)
+	m, err := f.lcdMask(x, dotX&63)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	origin := image.Point{X: dotX.Floor(), Y: dot.Y.Round()}
+	return m.color.Rect.Add(origin), m.color, m.color.Rect.Min, m.advance, true
+}
+
+// lcdMask returns the x'th glyph's LCD mask, rasterizing and caching it if it
+// isn't already cached.
+func (f *Face) lcdMask(x sfnt.GlyphIndex, subpixel fixed.Int26_6) (*cachedMask, error) {
+	k := maskKey{x: x, ppem: f.scale, subpixel: subpixel, hinting: f.hinting, lcd: true}
+	if e, ok := f.masks[k]; ok {
+		f.lru.MoveToFront(e)
+		return e.Value.(*cachedMask), nil
+	}
+
+	advance, err := f.glyphAdvance(x)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := f.loadGlyph(x)
+	if err != nil {
+		return nil, err
+	}
+	segments = f.strokeGlyph(segments)
+
+	// The filter spreads each subpixel's coverage to its neighbors, up to 2
+	// subpixels away, so widen the bounds by a pixel on each side.
+	b := sfnt.Segments(segments).Bounds()
+	r := image.Rect(
+		(b.Min.X+subpixel).Floor()-1,
+		(-b.Max.Y).Floor(),
+		(b.Max.X+subpixel).Ceil()+1,
+		(-b.Min.Y).Ceil(),
+	)
+	if b == (fixed.Rectangle26_6{}) {
+		r = image.Rectangle{}
+	}
+	for i := range segments {
+		args := &segments[i].Args
+		for j := 0; j < len(args); j += 2 {
+			args[j] *= 3
+		}
+	}
+	r3 := image.Rect(3*r.Min.X, r.Min.Y, 3*r.Max.X, r.Max.Y)
+	oversampled := f.rasterize(segments, 3*float32(subpixel)/64, 0, r3)
+
+	dst := image.NewRGBA(r)
+	red, blue := 0, 2
+	if f.subpixelOrder == SubpixelOrderBGR {
+		red, blue = 2, 0
+	}
+	w3 := r3.Dx()
+	for y := 0; y < r.Dy(); y++ {
+		src := oversampled.Pix[y*oversampled.Stride:][:w3]
+		pix := dst.Pix[y*dst.Stride:][:4*r.Dx()]
+		for px := 0; px < r.Dx(); px++ {
+			var c [3]uint8
+			for s := range c {
+				i, v := 3*px+s, uint32(0)
+				for k, weight := range lcdFilter {
+					if j := i + k - 2; 0 <= j && j < w3 {
+						v += weight * uint32(src[j])
+					}
+				}
+				if v > 0xff*0x100 {
+					v = 0xff * 0x100
+				}
+				c[s] = uint8(v >> 8)
+			}
+			a := c[0]
+			if a < c[1] {
+				a = c[1]
+			}
+			if a < c[2] {
+				a = c[2]
+			}
+			pix[4*px+0] = c[red]
+			pix[4*px+1] = c[1]
+			pix[4*px+2] = c[blue]
+			pix[4*px+3] = a
+		}
+	}
+
+	f.gamma.apply(dst.Pix)
+
+	m := &cachedMask{key: k, color: dst, advance: advance}
+	f.cache(m)
+	return m, nil
+}
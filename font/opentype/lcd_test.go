@@ -0,0 +1,79 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestLCDGlyph(t *testing.T) {
+	f := parseGoRegular(t)
+	dot := fixed.P(10, 40)
+
+	face, err := NewFace(f, &FaceOptions{Size: 24, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	if _, _, _, _, ok := face.LCDGlyph(dot, 'v'); ok {
+		t.Errorf("SubpixelOrderNone: got ok, want !ok")
+	}
+	dr, mask, maskp, advance, _ := face.Glyph(dot, 'v')
+	var alphaSum int
+	for y := 0; y < dr.Dy(); y++ {
+		for x := 0; x < dr.Dx(); x++ {
+			alphaSum += int(mask.(*image.Alpha).AlphaAt(maskp.X+x, maskp.Y+y).A)
+		}
+	}
+
+	var masks [2]*image.RGBA
+	for i, order := range []SubpixelOrder{SubpixelOrderRGB, SubpixelOrderBGR} {
+		face, err := NewFace(f, &FaceOptions{Size: 24, DPI: 72, SubpixelOrder: order})
+		if err != nil {
+			t.Fatalf("NewFace: %v", err)
+		}
+		lcdDR, lcdMask, lcdMaskp, lcdAdvance, ok := face.LCDGlyph(dot, 'v')
+		if !ok {
+			t.Fatalf("order %d: got !ok", order)
+		}
+		if lcdAdvance != advance {
+			t.Errorf("order %d: advance: got %v, want %v", order, lcdAdvance, advance)
+		}
+		if want := image.Rect(dr.Min.X-1, dr.Min.Y, dr.Max.X+1, dr.Max.Y); lcdDR != want {
+			t.Errorf("order %d: dr: got %v, want %v", order, lcdDR, want)
+		}
+		masks[i] = lcdMask
+
+		// The filter's weights sum to 1, so the total coverage is about the
+		// same as the non-LCD mask's.
+		var sum [3]int
+		for y := 0; y < lcdDR.Dy(); y++ {
+			for x := 0; x < lcdDR.Dx(); x++ {
+				c := lcdMask.RGBAAt(lcdMaskp.X+x, lcdMaskp.Y+y)
+				sum[0] += int(c.R)
+				sum[1] += int(c.G)
+				sum[2] += int(c.B)
+				if c.A < c.R || c.A < c.G || c.A < c.B {
+					t.Fatalf("order %d: (%d, %d): alpha %d is less than a color channel", order, x, y, c.A)
+				}
+			}
+		}
+		for c, s := range sum {
+			if d := s - alphaSum; d < -alphaSum/10 || alphaSum/10 < d {
+				t.Errorf("order %d: channel %d: coverage %d, want about %d", order, c, s, alphaSum)
+			}
+		}
+	}
+
+	// The BGR mask is the RGB mask with red and blue swapped.
+	rgb, bgr := masks[0], masks[1]
+	for i := 0; i < len(rgb.Pix); i += 4 {
+		if rgb.Pix[i+0] != bgr.Pix[i+2] || rgb.Pix[i+1] != bgr.Pix[i+1] || rgb.Pix[i+2] != bgr.Pix[i+0] {
+			t.Fatalf("pixel %d: RGB %v and BGR %v are not swapped", i/4, rgb.Pix[i:i+4], bgr.Pix[i:i+4])
+		}
+	}
+}
@@ -0,0 +1,565 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package opentype implements a glyph rasterizer for TTF (TrueType Fonts) and
+// OTF (OpenType Fonts).
+//
+// This package provides a font.Face implementation. For direct access to the
+// underlying font data, such as glyph outlines and metrics in font units, see
+// the sibling golang.org/x/image/font/sfnt package.
+package opentype // import "golang.org/x/image/font/opentype"
+
+import (
+	"container/list"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// FaceOptions describes the possible options given to NewFace when
+// creating a new font.Face from a sfnt.Font.
+type FaceOptions struct {
+	Size float64 // Size is the font size in points
+	DPI  float64 // DPI is the dots per inch resolution
+
+	// XDPI and YDPI, if non-zero, override DPI for the horizontal and
+	// vertical resolutions, for devices with non-square pixels or for
+	// anisotropic scaling. Glyphs are scaled to Size*YDPI/72 pixels per em
+	// vertically and Size*XDPI/72 horizontally. When they differ,
+	// font.HintingFull is treated as font.HintingVertical, as the font's
+	// horizontal grid-fitting assumes square pixels.
+	XDPI float64
+	YDPI float64
+
+	// Hinting selects how to quantize a vector font's glyph nodes.
+	//
+	// font.HintingFull grid-fits glyph outlines on both axes, by running the
+	// font's TrueType hinting instructions or, for fonts without them, by
+	// autohinting. It also rounds advances and kerns to whole pixels. As a
+	// horizontally grid-fitted glyph only lines up with the pixel grid at
+	// whole pixel positions, SubpixelPhases is then ignored and glyph origins
+	// are rounded to whole pixels. font.HintingVertical only grid-fits the y
+	// axis, and is compatible with SubpixelPhases.
+	Hinting font.Hinting
+
+	// SubpixelPhases is the number of horizontal positions within a pixel
+	// that glyphs are rasterized at, such as 4 for quarter-pixel positioning.
+	// It must be zero or a power of two no greater than 64. Zero and one mean
+	// that glyph origins are rounded to whole pixels.
+	//
+	// More phases give more even spacing, especially at small sizes, but
+	// each glyph may be rasterized and cached once per phase.
+	SubpixelPhases int
+
+	// NamedInstance and Variations select an instance of a variable font,
+	// such as its "Bold" or "Condensed" instance. NamedInstance is the
+	// subfamily name of one of the font's named instances, as per
+	// sfnt.Font.NamedInstances, or empty for the default instance. Variations
+	// are applied after, and so override, the named instance's coordinates.
+	//
+	// Both are ignored for fonts that are not variable fonts, except that
+	// NewFace returns an error if there is no such named instance.
+	NamedInstance string
+	Variations    []sfnt.Variation
+
+	// SyntheticBold and SyntheticOblique emulate bold and oblique faces, for
+	// font families that lack real bold or italic fonts. Synthetic bold glyph
+	// outlines are emboldened, and their advances widened, by 1/24 of an em.
+	// Synthetic oblique glyphs are slanted by a horizontal shear of 0.2, or
+	// about 11 degrees. These match FreeType's synthetic styles.
+	SyntheticBold    bool
+	SyntheticOblique bool
+
+	// SubpixelOrder is the subpixel order of the LCD display that the
+	// Face.LCDGlyph method renders for. If it is SubpixelOrderNone, the
+	// default, LCDGlyph is not supported.
+	SubpixelOrder SubpixelOrder
+
+	// StrokeWidth, if positive, is the width in pixels of the stroke that
+	// glyphs are drawn with, instead of being filled. The stroke is centered
+	// on the glyph outline, so that the glyph bounds grow by half of the
+	// width on each side. Advances are unchanged. It must not be negative.
+	StrokeWidth float64
+
+	// Gamma is the gamma of the curve applied to the antialiased coverage of
+	// glyph masks and LCD masks: a coverage of c, between 0 and 1, becomes
+	// c raised to the power 1/Gamma. Gamma greater than 1 makes edges
+	// heavier, which suits light text on a dark background. Gamma less than
+	// 1 makes edges lighter, which suits dark text on a light background.
+	// Zero, the default, and one mean linear coverage. It must not be
+	// negative.
+	Gamma float64
+}
+
+func defaultFaceOptions() *FaceOptions {
+	return &FaceOptions{
+		Size:    12,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	}
+}
+
+var (
+	errInvalidBitmapStrike   = errors.New("opentype: invalid bitmap strike")
+	errInvalidDPI            = errors.New("opentype: invalid DPI")
+	errInvalidGamma          = errors.New("opentype: invalid gamma")
+	errInvalidPaletteIndex   = errors.New("opentype: invalid palette index")
+	errInvalidStrokeWidth    = errors.New("opentype: invalid stroke width")
+	errInvalidSubpixelPhases = errors.New("opentype: invalid number of subpixel phases")
+	errNamedInstanceNotFound = errors.New("opentype: named instance not found")
+)
+
+// maxCachedMasks is the maximum number of rasterized glyph masks that a Face
+// caches.
+const maxCachedMasks = 256
+
+// Face implements the font.Face interface for sfnt.Font values.
+//
+// A rune that the font maps to its missing glyph, glyph index 0, is not
+// contained in the face: the Glyph, GlyphBounds and GlyphAdvance methods
+// return !ok for it, so that a font.NewMultiFace can fall back to another
+// face.
+//
+// Rasterized glyph masks are cached, so that drawing the same glyph again is
+// cheap. Like other font.Face implementations, a Face is not safe for
+// concurrent use by multiple goroutines.
+type Face struct {
+	f       *sfnt.Font
+	hinting font.Hinting
+	// scale is the vertical number of pixels per em, and xScale is the ratio
+	// of the horizontal to the vertical resolution. xScale is 1 for square
+	// pixels.
+	scale   fixed.Int26_6
+	xScale  float64
+	bold    bool
+	oblique bool
+
+	subpixelOrder SubpixelOrder
+	// phase is the width of a subpixel phase, as a 26.6 fixed point number.
+	// It is 64 for whole pixel positioning.
+	phase fixed.Int26_6
+
+	// gamma is nil for linear coverage.
+	gamma *gammaTable
+
+	// stroke is the stroke width, in 26.6 fixed point pixels, or zero if
+	// glyphs are filled. strokeBuf holds the most recently stroked glyph.
+	stroke    float64
+	strokeBuf []sfnt.Segment
+
+	metrics    font.Metrics
+	metricsSet bool
+
+	buf  sfnt.Buffer
+	rast vector.Rasterizer
+
+	// masks maps a maskKey to an element of the lru list, whose value is a
+	// *cachedMask. The front of the list is the most recently used mask.
+	masks map[maskKey]*list.Element
+	lru   list.List
+}
+
+// maskKey is the key of a cached glyph mask. subpixel is the horizontal
+// offset of the glyph origin within its pixel. For color glyphs, color is
+// true and fg is the foreground color. For LCD masks, lcd is true.
+type maskKey struct {
+	x        sfnt.GlyphIndex
+	ppem     fixed.Int26_6
+	subpixel fixed.Int26_6
+	hinting  font.Hinting
+	color    bool
+	fg       color.RGBA
+	lcd      bool
+}
+
+// cachedMask is a rasterized glyph, either an alpha mask or, for a color
+// glyph or LCD mask, an RGBA image. The image's bounds are relative to the
+// glyph origin, which is at (0, 0), with the y axis increasing down.
+type cachedMask struct {
+	key     maskKey
+	mask    *image.Alpha
+	color   *image.RGBA
+	advance fixed.Int26_6
+}
+
+// NewFace returns a new font.Face for the given sfnt.Font.
+//
+// If opts is nil, sensible defaults will be used.
+func NewFace(f *sfnt.Font, opts *FaceOptions) (*Face, error) {
+	if opts == nil {
+		opts = defaultFaceOptions()
+	}
+	vs, err := faceVariations(f, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) != 0 {
+		f = f.WithVariations(vs...)
+	}
+	phases := opts.SubpixelPhases
+	if phases == 0 {
+		phases = 1
+	}
+	if phases < 0 || phases > 64 || phases&(phases-1) != 0 {
+		return nil, errInvalidSubpixelPhases
+	}
+	xdpi, ydpi := opts.XDPI, opts.YDPI
+	if xdpi == 0 {
+		xdpi = opts.DPI
+	}
+	if ydpi == 0 {
+		ydpi = opts.DPI
+	}
+	if xdpi < 0 || ydpi < 0 {
+		return nil, errInvalidDPI
+	}
+	if opts.Gamma < 0 || math.IsNaN(opts.Gamma) {
+		return nil, errInvalidGamma
+	}
+	if opts.StrokeWidth < 0 || math.IsNaN(opts.StrokeWidth) {
+		return nil, errInvalidStrokeWidth
+	}
+	xScale := 1.0
+	if xdpi != ydpi && ydpi != 0 {
+		xScale = xdpi / ydpi
+	}
+	hinting := opts.Hinting
+	if hinting == font.HintingFull && xScale != 1 {
+		hinting = font.HintingVertical
+	}
+	if hinting == font.HintingFull {
+		phases = 1
+	}
+	face := &Face{
+		f:       f,
+		hinting: hinting,
+		scale:   fixed.Int26_6(0.5 + (opts.Size * ydpi * 64 / 72)),
+		xScale:  xScale,
+		phase:   fixed.Int26_6(64 / phases),
+		bold:    opts.SyntheticBold,
+		oblique: opts.SyntheticOblique,
+		gamma:   newGammaTable(opts.Gamma),
+		stroke:  64 * opts.StrokeWidth,
+		masks:   map[maskKey]*list.Element{},
+
+		subpixelOrder: opts.SubpixelOrder,
+	}
+	return face, nil
+}
+
+// NewFaceFromCollection returns a new font.Face for the index'th font in
+// the given sfnt.Collection, as per NewFace. The font shares the collection's
+// source data, including any tables that it has in common with the other
+// fonts in the collection.
+//
+// It returns sfnt.ErrNotFound if index is out of range.
+func NewFaceFromCollection(coll *sfnt.Collection, index int, opts *FaceOptions) (*Face, error) {
+	f, err := coll.Font(index)
+	if err != nil {
+		return nil, err
+	}
+	return NewFace(f, opts)
+}
+
+// faceVariations returns the variations that select the variable font
+// instance described by opts.
+func faceVariations(f *sfnt.Font, opts *FaceOptions) ([]sfnt.Variation, error) {
+	if opts.NamedInstance == "" {
+		return opts.Variations, nil
+	}
+	instances, err := f.NamedInstances(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, inst := range instances {
+		if inst.Name == opts.NamedInstance {
+			vs := append([]sfnt.Variation(nil), inst.Variations...)
+			return append(vs, opts.Variations...), nil
+		}
+	}
+	return nil, errNamedInstanceNotFound
+}
+
+// Close satisfies the font.Face interface.
+func (f *Face) Close() error {
+	return nil
+}
+
+// Metrics satisfies the font.Face interface.
+func (f *Face) Metrics() font.Metrics {
+	if !f.metricsSet {
+		hhea, err := f.f.HorizontalHeader(&f.buf)
+		if err == nil {
+			upem := fixed.Int26_6(f.f.UnitsPerEm())
+			ascent := scale(fixed.Int26_6(hhea.Ascent)*f.scale, upem)
+			descent := scale(-fixed.Int26_6(hhea.Descent)*f.scale, upem)
+			lineGap := scale(fixed.Int26_6(hhea.LineGap)*f.scale, upem)
+			if f.hinting != font.HintingNone {
+				ascent = fixed.I(ascent.Round())
+				descent = fixed.I(descent.Round())
+				lineGap = fixed.I(lineGap.Round())
+			}
+			f.metrics = font.Metrics{
+				Height:  ascent + descent + lineGap,
+				Ascent:  ascent,
+				Descent: descent,
+			}
+			if d, err := f.f.DecorationMetrics(&f.buf); err == nil {
+				f.metrics.UnderlinePosition, f.metrics.UnderlineThickness = f.underline(d)
+			}
+		}
+		f.metricsSet = true
+	}
+	return f.metrics
+}
+
+// RoundDot satisfies the font.RoundingFace interface. The dot is rounded
+// horizontally to the nearest subpixel phase and vertically to the nearest
+// pixel.
+func (f *Face) RoundDot(dot fixed.Point26_6) fixed.Point26_6 {
+	return fixed.Point26_6{
+		X: (dot.X + f.phase/2) &^ (f.phase - 1),
+		Y: fixed.I(dot.Y.Round()),
+	}
+}
+
+var _ font.RoundingFace = (*Face)(nil)
+
+// scale returns x divided by unitsPerEm, rounded to the nearest integer.
+func scale(x, unitsPerEm fixed.Int26_6) fixed.Int26_6 {
+	if x >= 0 {
+		x += unitsPerEm / 2
+	} else {
+		x -= unitsPerEm / 2
+	}
+	return x / unitsPerEm
+}
+
+// Kern satisfies the font.Face interface.
+func (f *Face) Kern(r0, r1 rune) fixed.Int26_6 {
+	x0, _ := f.f.GlyphIndex(&f.buf, r0)
+	x1, _ := f.f.GlyphIndex(&f.buf, r1)
+	k, err := f.f.Kern(&f.buf, x0, x1, f.scale, f.hinting)
+	if err != nil {
+		return 0
+	}
+	return f.scaleX(k)
+}
+
+// scaleX scales the horizontal distance x, in pixels at the vertical
+// resolution, to the horizontal resolution.
+func (f *Face) scaleX(x fixed.Int26_6) fixed.Int26_6 {
+	if f.xScale == 1 {
+		return x
+	}
+	return fixed.Int26_6(math.Round(f.xScale * float64(x)))
+}
+
+// Glyph satisfies the font.Face interface.
+//
+// The glyph origin is the dot rounded vertically to the nearest whole pixel
+// and horizontally to the nearest subpixel phase, as per
+// FaceOptions.SubpixelPhases.
+func (f *Face) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil || x == 0 {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	// Split the rounded dot.X into whole pixels and a subpixel phase.
+	dotX := (dot.X + f.phase/2) &^ (f.phase - 1)
+	m, err := f.glyphMask(x, dotX&63)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	origin := image.Point{X: dotX.Floor(), Y: dot.Y.Round()}
+	return m.mask.Rect.Add(origin), m.mask, m.mask.Rect.Min, m.advance, true
+}
+
+// glyphMask returns the x'th glyph's mask, rasterized with the glyph origin
+// offset horizontally by subpixel, rasterizing and caching it if it isn't
+// already cached.
+func (f *Face) glyphMask(x sfnt.GlyphIndex, subpixel fixed.Int26_6) (*cachedMask, error) {
+	k := maskKey{x: x, ppem: f.scale, subpixel: subpixel, hinting: f.hinting}
+	if e, ok := f.masks[k]; ok {
+		f.lru.MoveToFront(e)
+		return e.Value.(*cachedMask), nil
+	}
+
+	// Call glyphAdvance before loadGlyph, as the returned segments are only
+	// valid until the next call that uses f.buf.
+	advance, err := f.glyphAdvance(x)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := f.loadGlyph(x)
+	if err != nil {
+		return nil, err
+	}
+	segments = f.strokeGlyph(segments)
+
+	// Quantize the sub-pixel bounds to integer pixels, flipping the y axis.
+	b := sfnt.Segments(segments).Bounds()
+	r := image.Rect(
+		(b.Min.X + subpixel).Floor(),
+		(-b.Max.Y).Floor(),
+		(b.Max.X + subpixel).Ceil(),
+		(-b.Min.Y).Ceil(),
+	)
+	m := &cachedMask{
+		key:     k,
+		mask:    f.rasterize(segments, float32(subpixel)/64, 0, r),
+		advance: advance,
+	}
+	f.gamma.apply(m.mask.Pix)
+
+	f.cache(m)
+	return m, nil
+}
+
+// cache adds m to the cache, evicting the least recently used masks if the
+// cache is full.
+func (f *Face) cache(m *cachedMask) {
+	f.masks[m.key] = f.lru.PushFront(m)
+	for f.lru.Len() > maxCachedMasks {
+		e := f.lru.Back()
+		f.lru.Remove(e)
+		delete(f.masks, e.Value.(*cachedMask).key)
+	}
+}
+
+// GlyphBounds satisfies the font.Face interface.
+func (f *Face) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil || x == 0 {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	advance, err = f.glyphAdvance(x)
+	if err != nil {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	if f.bold || f.oblique || f.xScale != 1 {
+		// The synthetic styles and non-square pixels change the outline's
+		// bounds.
+		segments, err := f.loadGlyph(x)
+		if err != nil {
+			return fixed.Rectangle26_6{}, 0, false
+		}
+		bounds = sfnt.Segments(segments).Bounds()
+	} else {
+		bounds, err = f.f.GlyphBounds(&f.buf, x, f.scale, f.hinting)
+		if err != nil {
+			return fixed.Rectangle26_6{}, 0, false
+		}
+	}
+	if f.stroke != 0 && bounds != (fixed.Rectangle26_6{}) {
+		h := fixed.Int26_6(math.Ceil(f.stroke / 2))
+		bounds.Min.X, bounds.Min.Y = bounds.Min.X-h, bounds.Min.Y-h
+		bounds.Max.X, bounds.Max.Y = bounds.Max.X+h, bounds.Max.Y+h
+	}
+	// The sfnt package's y axis increases up, and the font package's y axis
+	// increases down.
+	bounds.Min.Y, bounds.Max.Y = -bounds.Max.Y, -bounds.Min.Y
+	return bounds, advance, true
+}
+
+// GlyphOutline returns r's glyph outline, scaled to the face's size and
+// hinted, synthetically styled and scaled to the horizontal resolution as
+// per the face's options, and that glyph's advance width.
+//
+// The segments' coordinates are in 26.6 fixed point pixels, relative to the
+// glyph origin. As for GlyphBounds, the y axis increases down, unlike the
+// sfnt package's y axis. The returned slice is not re-used by later calls.
+func (f *Face) GlyphOutline(r rune) (segments []sfnt.Segment, advance fixed.Int26_6, ok bool) {
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil || x == 0 {
+		return nil, 0, false
+	}
+	advance, err = f.glyphAdvance(x)
+	if err != nil {
+		return nil, 0, false
+	}
+	loaded, err := f.loadGlyph(x)
+	if err != nil {
+		return nil, 0, false
+	}
+	segments = make([]sfnt.Segment, len(loaded))
+	for i, s := range loaded {
+		for j := 1; j < len(s.Args); j += 2 {
+			s.Args[j] = -s.Args[j]
+		}
+		segments[i] = s
+	}
+	return segments, advance, true
+}
+
+// GlyphAdvance satisfies the font.Face interface.
+func (f *Face) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil || x == 0 {
+		return 0, false
+	}
+	advance, err = f.glyphAdvance(x)
+	if err != nil {
+		return 0, false
+	}
+	return advance, true
+}
+
+var _ font.Face = (*Face)(nil)
+
+// rasterize draws the segments to a new mask. The glyph origin is at (ox, oy)
+// in the mask's pixel coordinates, whose y axis increases down, and the
+// mask's bounds are r.
+func (f *Face) rasterize(segments []sfnt.Segment, ox, oy float32, r image.Rectangle) *image.Alpha {
+	mask := image.NewAlpha(r)
+	if r.Empty() {
+		return mask
+	}
+	// The rasterizer's coordinates are relative to r.Min.
+	ox -= float32(r.Min.X)
+	oy -= float32(r.Min.Y)
+	f.rast.Reset(r.Dx(), r.Dy())
+	f.rast.DrawOp = draw.Src
+	for _, s := range segments {
+		// The divisions by 64 below is because the s.Args values have type
+		// fixed.Int26_6, a 26.6 fixed point number, and 1<<6 == 64.
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			f.rast.MoveTo(
+				ox+float32(s.Args[0])/64,
+				oy-float32(s.Args[1])/64,
+			)
+		case sfnt.SegmentOpLineTo:
+			f.rast.LineTo(
+				ox+float32(s.Args[0])/64,
+				oy-float32(s.Args[1])/64,
+			)
+		case sfnt.SegmentOpQuadTo:
+			f.rast.QuadTo(
+				ox+float32(s.Args[0])/64,
+				oy-float32(s.Args[1])/64,
+				ox+float32(s.Args[2])/64,
+				oy-float32(s.Args[3])/64,
+			)
+		case sfnt.SegmentOpCubeTo:
+			f.rast.CubeTo(
+				ox+float32(s.Args[0])/64,
+				oy-float32(s.Args[1])/64,
+				ox+float32(s.Args[2])/64,
+				oy-float32(s.Args[3])/64,
+				ox+float32(s.Args[4])/64,
+				oy-float32(s.Args[5])/64,
+			)
+		}
+	}
+	f.rast.Draw(mask, mask.Rect, image.Opaque, mask.Rect.Min)
+	return mask
+}
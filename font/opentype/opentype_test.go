@@ -0,0 +1,500 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"encoding/binary"
+	"image"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func parseGoRegular(t *testing.T) *sfnt.Font {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return f
+}
+
+func TestFaceGlyph(t *testing.T) {
+	face, err := NewFace(parseGoRegular(t), &FaceOptions{
+		Size: 32,
+		DPI:  72,
+	})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+
+	dot := fixed.Point26_6{X: fixed.I(10) + 20, Y: fixed.I(40)}
+	dr, mask, maskp, advance, ok := face.Glyph(dot, 'G')
+	if !ok {
+		t.Fatalf("Glyph: got !ok")
+	}
+	bounds, wantAdvance, ok := face.GlyphBounds('G')
+	if !ok {
+		t.Fatalf("GlyphBounds: got !ok")
+	}
+	if advance != wantAdvance {
+		t.Errorf("advance: got %v, want %v", advance, wantAdvance)
+	}
+	wantDR := image.Rect(
+		10+bounds.Min.X.Floor(), 40+bounds.Min.Y.Floor(),
+		10+bounds.Max.X.Ceil(), 40+bounds.Max.Y.Ceil(),
+	)
+	if dr != wantDR {
+		t.Errorf("dr: got %v, want %v", dr, wantDR)
+	}
+	if !dr.Sub(dr.Min).Add(maskp).In(mask.Bounds()) {
+		t.Errorf("mask bounds %v do not contain %v at %v", mask.Bounds(), dr, maskp)
+	}
+	opaque := 0
+	for y := maskp.Y; y < maskp.Y+dr.Dy(); y++ {
+		for x := maskp.X; x < maskp.X+dr.Dx(); x++ {
+			if _, _, _, a := mask.At(x, y).RGBA(); a == 0xffff {
+				opaque++
+			}
+		}
+	}
+	if opaque == 0 {
+		t.Errorf("mask has no opaque pixels")
+	}
+
+	// Drawing the same glyph elsewhere re-uses the cached mask.
+	dr1, mask1, _, _, _ := face.Glyph(fixed.P(100, 100), 'G')
+	if mask1 != mask {
+		t.Errorf("mask was not cached")
+	}
+	if got, want := dr1.Min.Sub(dr.Min), image.Pt(90, 60); got != want {
+		t.Errorf("dr offset: got %v, want %v", got, want)
+	}
+
+	// An empty glyph, such as a space, has an empty mask.
+	dr, _, _, advance, ok = face.Glyph(dot, ' ')
+	if !ok || !dr.Empty() || advance == 0 {
+		t.Errorf("space: got %v, %v, %t, want an empty rectangle and a non-zero advance", dr, advance, ok)
+	}
+}
+
+func TestFaceSubpixelPhases(t *testing.T) {
+	f := parseGoRegular(t)
+	if _, err := NewFace(f, &FaceOptions{Size: 12, DPI: 72, SubpixelPhases: 3}); err == nil {
+		t.Errorf("3 subpixel phases: got nil error, want non-nil")
+	}
+	face, err := NewFace(f, &FaceOptions{Size: 12, DPI: 72, SubpixelPhases: 4})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+
+	testCases := []struct {
+		dotX      fixed.Int26_6
+		wantX     int
+		wantPhase fixed.Int26_6
+	}{
+		{fixed.I(10) + 0, 10, 0},
+		{fixed.I(10) + 7, 10, 0},
+		{fixed.I(10) + 9, 10, 16},
+		{fixed.I(10) + 36, 10, 32},
+		{fixed.I(10) + 50, 10, 48},
+		{fixed.I(10) + 60, 11, 0},
+	}
+	masks := map[fixed.Int26_6]image.Image{}
+	for _, tc := range testCases {
+		dr, mask, maskp, _, ok := face.Glyph(fixed.Point26_6{X: tc.dotX, Y: fixed.I(20)}, 'l')
+		if !ok {
+			t.Fatalf("dotX=%v: Glyph: got !ok", tc.dotX)
+		}
+		// The mask's bounds are relative to the whole-pixel glyph origin.
+		if got := dr.Min.X - maskp.X; got != tc.wantX {
+			t.Errorf("dotX=%v: origin x: got %d, want %d", tc.dotX, got, tc.wantX)
+		}
+		if m, ok := masks[tc.wantPhase]; ok && m != mask {
+			t.Errorf("dotX=%v: phase %v mask was not re-used", tc.dotX, tc.wantPhase)
+		}
+		masks[tc.wantPhase] = mask
+	}
+	if len(masks) != 4 || len(face.masks) != 4 {
+		t.Errorf("got %d distinct masks, %d cached, want 4", len(masks), len(face.masks))
+	}
+}
+
+func TestFaceMaskCache(t *testing.T) {
+	face, err := NewFace(parseGoRegular(t), nil)
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	last := rune(-1)
+	for r := rune(0x20); r < 0x20+2*maxCachedMasks; r++ {
+		if _, _, _, _, ok := face.Glyph(fixed.Point26_6{}, r); ok {
+			last = r
+		}
+	}
+	if n := face.lru.Len(); n != maxCachedMasks || len(face.masks) != maxCachedMasks {
+		t.Errorf("cache size: got %d, %d, want %d", n, len(face.masks), maxCachedMasks)
+	}
+	// The most recently used glyph is still cached.
+	x, err := face.f.GlyphIndex(nil, last)
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	if _, ok := face.masks[maskKey{x: x, ppem: face.scale}]; !ok {
+		t.Errorf("most recently used glyph was evicted")
+	}
+}
+
+func TestFaceMetrics(t *testing.T) {
+	face, err := NewFace(parseGoRegular(t), &FaceOptions{
+		Size:    12,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	// Go Regular has 2048 units per em, an ascent of 1935, a descent of -432
+	// and no line gap, which are 11.34 and 2.53 pixels at 12 ppem. Its
+	// underline is 125 units below the baseline and 50 units thick, which
+	// round to 1 pixel.
+	want := font.Metrics{
+		Height:             fixed.I(14),
+		Ascent:             fixed.I(11),
+		Descent:            fixed.I(3),
+		UnderlinePosition:  fixed.I(1),
+		UnderlineThickness: fixed.I(1),
+	}
+	if got := face.Metrics(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFaceVariations(t *testing.T) {
+	// An fvar table with a "wght" axis from 100 to 900, and a named instance
+	// at 700 whose subfamily name ID, 2, is "Regular" in Go Regular's name
+	// table.
+	fvar := be16(nil, 1, 0, 16, 2, 1, 20, 1, 8)
+	fvar = append(fvar, "wght"...)
+	fvar = be16(fvar, 100, 0, 400, 0, 900, 0, 0, 256)
+	fvar = be16(fvar, 2, 0, 700, 0)
+	f := withTables(t, map[string][]byte{"fvar": fvar})
+	wght := sfnt.MustParseTag("wght")
+
+	testCases := []struct {
+		desc    string
+		opts    FaceOptions
+		want    []sfnt.Variation
+		wantErr bool
+	}{{
+		desc: "default",
+	}, {
+		desc: "variations",
+		opts: FaceOptions{Variations: []sfnt.Variation{{Tag: wght, Value: 300}}},
+		want: []sfnt.Variation{{Tag: wght, Value: 300}},
+	}, {
+		desc: "named instance",
+		opts: FaceOptions{NamedInstance: "Regular"},
+		want: []sfnt.Variation{{Tag: wght, Value: 700}},
+	}, {
+		desc: "named instance and variations",
+		opts: FaceOptions{
+			NamedInstance: "Regular",
+			Variations:    []sfnt.Variation{{Tag: wght, Value: 300}},
+		},
+		want: []sfnt.Variation{{Tag: wght, Value: 700}, {Tag: wght, Value: 300}},
+	}, {
+		desc:    "no such named instance",
+		opts:    FaceOptions{NamedInstance: "Black"},
+		wantErr: true,
+	}}
+	for _, tc := range testCases {
+		got, err := faceVariations(f, &tc.opts)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tc.desc, err, tc.wantErr)
+			continue
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.desc, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want %v", tc.desc, got, tc.want)
+				break
+			}
+		}
+
+		opts := tc.opts
+		opts.Size, opts.DPI = 12, 72
+		if _, err := NewFace(f, &opts); (err != nil) != tc.wantErr {
+			t.Errorf("%s: NewFace: got error %v, want error %t", tc.desc, err, tc.wantErr)
+		}
+	}
+}
+
+func TestFaceHintingFull(t *testing.T) {
+	f := parseGoRegular(t)
+	vertical, err := NewFace(f, &FaceOptions{Size: 9, DPI: 72, Hinting: font.HintingVertical, SubpixelPhases: 4})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	full, err := NewFace(f, &FaceOptions{Size: 9, DPI: 72, Hinting: font.HintingFull, SubpixelPhases: 4})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+
+	// Full hinting runs Go Regular's hinting instructions, which also move
+	// some of the 9 pixel 'l' glyph's points horizontally.
+	x, err := f.GlyphIndex(nil, 'l')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	vSegments, err := vertical.loadGlyph(x)
+	if err != nil {
+		t.Fatalf("loadGlyph: %v", err)
+	}
+	vSegments = append([]sfnt.Segment(nil), vSegments...)
+	fSegments, err := full.loadGlyph(x)
+	if err != nil {
+		t.Fatalf("loadGlyph: %v", err)
+	}
+	sameX := len(fSegments) == len(vSegments)
+	for i := 0; sameX && i < len(fSegments); i++ {
+		for j := 0; j < len(fSegments[i].Args); j += 2 {
+			sameX = sameX && fSegments[i].Args[j] == vSegments[i].Args[j]
+		}
+	}
+	if sameX {
+		t.Errorf("full and vertical hinting: got the same x coordinates, want some to differ")
+	}
+
+	// Full hinting rounds advances to whole pixels, and glyph origins to
+	// whole pixels despite SubpixelPhases.
+	for r := 'a'; r <= 'z'; r++ {
+		if advance, _ := full.GlyphAdvance(r); advance&63 != 0 {
+			t.Errorf("%q: advance %v is not a whole number of pixels", r, advance)
+		}
+	}
+	_, mask0, _, _, _ := full.Glyph(fixed.P(10, 20), 'm')
+	dr, mask1, maskp, _, _ := full.Glyph(fixed.Point26_6{X: fixed.I(10) + 20, Y: fixed.I(20)}, 'm')
+	if mask1 != mask0 {
+		t.Errorf("subpixel offset: got a different mask, want the same mask")
+	}
+	if got := dr.Min.X - maskp.X; got != 10 {
+		t.Errorf("subpixel offset: origin x: got %d, want 10", got)
+	}
+}
+
+func TestFaceXYDPI(t *testing.T) {
+	f := parseGoRegular(t)
+	if _, err := NewFace(f, &FaceOptions{Size: 12, DPI: 72, XDPI: -1}); err == nil {
+		t.Errorf("negative XDPI: got nil error, want non-nil")
+	}
+	square, err := NewFace(f, &FaceOptions{Size: 24, DPI: 72, Hinting: font.HintingVertical})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	// DPI is overridden on both axes.
+	wide, err := NewFace(f, &FaceOptions{Size: 24, DPI: 96, XDPI: 144, YDPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	if wide.hinting != font.HintingVertical {
+		t.Errorf("hinting: got %v, want %v", wide.hinting, font.HintingVertical)
+	}
+	if got, want := wide.Metrics(), square.Metrics(); got != want {
+		t.Errorf("Metrics: got %+v, want %+v", got, want)
+	}
+
+	// Horizontal distances are twice as wide, to within rounding.
+	near := func(got, want fixed.Int26_6) bool {
+		return want-1 <= got && got <= want+1
+	}
+	for _, r := range "Gil" {
+		sBounds, sAdvance, _ := square.GlyphBounds(r)
+		wBounds, wAdvance, _ := wide.GlyphBounds(r)
+		if !near(wAdvance, 2*sAdvance) {
+			t.Errorf("%q: advance: got %v, want about %v", r, wAdvance, 2*sAdvance)
+		}
+		if !near(wBounds.Min.X, 2*sBounds.Min.X) || !near(wBounds.Max.X, 2*sBounds.Max.X) {
+			t.Errorf("%q: bounds: got %v, want about twice as wide as %v", r, wBounds, sBounds)
+		}
+		if wBounds.Min.Y != sBounds.Min.Y || wBounds.Max.Y != sBounds.Max.Y {
+			t.Errorf("%q: bounds: got %v, want the same height as %v", r, wBounds, sBounds)
+		}
+	}
+	if k := square.Kern('A', 'V'); !near(wide.Kern('A', 'V'), 2*k) {
+		t.Errorf("Kern: got %v, want about %v", wide.Kern('A', 'V'), 2*k)
+	}
+}
+
+// testCollection returns TTC data for the SFNT fonts in srcs.
+func testCollection(srcs ...[]byte) []byte {
+	header := 12 + 4*len(srcs)
+	ttc := make([]byte, header)
+	copy(ttc, "ttcf")
+	binary.BigEndian.PutUint32(ttc[4:], 0x00010000)
+	binary.BigEndian.PutUint32(ttc[8:], uint32(len(srcs)))
+	for i, src := range srcs {
+		base := len(ttc)
+		binary.BigEndian.PutUint32(ttc[12+4*i:], uint32(base))
+		ttc = append(ttc, src...)
+		// Table record offsets are relative to the start of the TTC data.
+		numTables := int(binary.BigEndian.Uint16(ttc[base+4:]))
+		for j := 0; j < numTables; j++ {
+			o := ttc[base+12+16*j+8:]
+			binary.BigEndian.PutUint32(o, binary.BigEndian.Uint32(o)+uint32(base))
+		}
+		for len(ttc)%4 != 0 {
+			ttc = append(ttc, 0)
+		}
+	}
+	return ttc
+}
+
+func TestNewFaceFromCollection(t *testing.T) {
+	coll, err := sfnt.ParseCollection(testCollection(goregular.TTF, gobold.TTF))
+	if err != nil {
+		t.Fatalf("ParseCollection: %v", err)
+	}
+	opts := &FaceOptions{Size: 24, DPI: 72}
+	if _, err := NewFaceFromCollection(coll, 2, opts); err != sfnt.ErrNotFound {
+		t.Errorf("index 2: got %v, want %v", err, sfnt.ErrNotFound)
+	}
+	for i, src := range [][]byte{goregular.TTF, gobold.TTF} {
+		f, err := sfnt.Parse(src)
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		want, err := NewFace(f, opts)
+		if err != nil {
+			t.Fatalf("NewFace: %v", err)
+		}
+		got, err := NewFaceFromCollection(coll, i, opts)
+		if err != nil {
+			t.Fatalf("NewFaceFromCollection: %v", err)
+		}
+		if g, w := got.Metrics(), want.Metrics(); g != w {
+			t.Errorf("font %d: Metrics: got %+v, want %+v", i, g, w)
+		}
+		gBounds, gAdvance, _ := got.GlyphBounds('W')
+		wBounds, wAdvance, _ := want.GlyphBounds('W')
+		if gBounds != wBounds || gAdvance != wAdvance {
+			t.Errorf("font %d: GlyphBounds: got %v, %v, want %v, %v", i, gBounds, gAdvance, wBounds, wAdvance)
+		}
+	}
+}
+
+func TestFaceGlyphOutline(t *testing.T) {
+	face, err := NewFace(parseGoRegular(t), &FaceOptions{Size: 24, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	segments, advance, ok := face.GlyphOutline('G')
+	if !ok {
+		t.Fatalf("GlyphOutline: got !ok")
+	}
+	bounds, wantAdvance, _ := face.GlyphBounds('G')
+	if advance != wantAdvance {
+		t.Errorf("advance: got %v, want %v", advance, wantAdvance)
+	}
+	if len(segments) == 0 || segments[0].Op != sfnt.SegmentOpMoveTo {
+		t.Fatalf("segments: got %v, want a MoveTo first", segments)
+	}
+	// The outline's on-curve points are within the glyph bounds, whose y axis
+	// increases down.
+	for i, s := range segments {
+		n := 0
+		switch s.Op {
+		case sfnt.SegmentOpQuadTo:
+			n = 2
+		case sfnt.SegmentOpCubeTo:
+			n = 4
+		}
+		x, y := s.Args[n], s.Args[n+1]
+		if x < bounds.Min.X || bounds.Max.X < x || y < bounds.Min.Y || bounds.Max.Y < y {
+			p := fixed.Point26_6{X: x, Y: y}
+			t.Errorf("segment #%d: point %v is outside the bounds %v", i, p, bounds)
+		}
+	}
+
+	// The returned segments are not re-used.
+	want := append([]sfnt.Segment(nil), segments...)
+	face.GlyphOutline('o')
+	face.Glyph(fixed.P(0, 0), 'x')
+	for i := range want {
+		if segments[i] != want[i] {
+			t.Fatalf("segment #%d: changed from %v to %v", i, want[i], segments[i])
+		}
+	}
+}
+
+func TestFaceMissingGlyph(t *testing.T) {
+	face, err := NewFace(parseGoRegular(t), nil)
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	// Go Regular has no CJK glyphs.
+	const r = '中'
+	if _, _, _, _, ok := face.Glyph(fixed.Point26_6{}, r); ok {
+		t.Errorf("Glyph: got ok, want !ok")
+	}
+	if _, _, ok := face.GlyphBounds(r); ok {
+		t.Errorf("GlyphBounds: got ok, want !ok")
+	}
+	if _, ok := face.GlyphAdvance(r); ok {
+		t.Errorf("GlyphAdvance: got ok, want !ok")
+	}
+}
+
+func TestBoundStringMatchesDrawString(t *testing.T) {
+	f := parseGoRegular(t)
+	// The strings have kerning pairs, ascenders and descenders.
+	const s = "AVATAR Wolf, jumpy Typography!"
+	for _, hinting := range []font.Hinting{font.HintingNone, font.HintingFull} {
+		face, err := NewFace(f, &FaceOptions{Size: 17, DPI: 72, Hinting: hinting})
+		if err != nil {
+			t.Fatalf("NewFace: %v", err)
+		}
+		for _, q := range []font.Quantization{font.QuantizationNone, font.QuantizationPixel, font.QuantizationQuarterPixel} {
+			for _, dotX := range []fixed.Int26_6{fixed.I(10), fixed.I(10) + 21, fixed.I(10) + 45} {
+				dst := image.NewAlpha(image.Rect(0, 0, 400, 40))
+				d := &font.Drawer{
+					Dst:          dst,
+					Src:          image.Opaque,
+					Face:         face,
+					Dot:          fixed.Point26_6{X: dotX, Y: fixed.I(25) + 13},
+					Quantization: q,
+				}
+				bounds, advance := d.BoundString(s)
+				if got, want := d.MeasureString(s), advance; got != want {
+					t.Errorf("hinting=%v, q=%d, x=%v: MeasureString: got %v, BoundString: %v", hinting, q, dotX, got, want)
+				}
+				start := d.Dot.X
+				d.DrawString(s)
+				if got := d.Dot.X - start; got != advance {
+					t.Errorf("hinting=%v, q=%d, x=%v: DrawString advance: got %v, BoundString: %v", hinting, q, dotX, got, advance)
+				}
+
+				// Every pixel drawn should be within the bounds, rounded out.
+				r := image.Rect(bounds.Min.X.Floor(), bounds.Min.Y.Floor(), bounds.Max.X.Ceil(), bounds.Max.Y.Ceil())
+				ink := image.Rectangle{}
+				for y := dst.Rect.Min.Y; y < dst.Rect.Max.Y; y++ {
+					for x := dst.Rect.Min.X; x < dst.Rect.Max.X; x++ {
+						if dst.AlphaAt(x, y).A != 0 {
+							ink = ink.Union(image.Rect(x, y, x+1, y+1))
+						}
+					}
+				}
+				if !ink.In(r) {
+					t.Errorf("hinting=%v, q=%d, x=%v: ink %v is not within bounds %v", hinting, q, dotX, ink, r)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"math"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// maxFlattenedLength is the maximum length, in 26.6 fixed point pixels, of
+// the line segments that approximate a curve that is stroked.
+const maxFlattenedLength = 2 * 64
+
+// strokeGlyph returns the stroke of a glyph's segments, if the face's glyphs
+// are stroked, or the segments themselves otherwise. The stroke is only valid
+// until the next call.
+func (f *Face) strokeGlyph(segments []sfnt.Segment) []sfnt.Segment {
+	if f.stroke == 0 {
+		return segments
+	}
+	f.strokeBuf = strokeSegments(f.strokeBuf[:0], segments, f.stroke)
+	return f.strokeBuf
+}
+
+// strokeSegments appends to dst segments whose filled area is the stroke, of
+// the given width, of src's contours. The stroke is centered on the contours
+// and has bevel joins. Both dst and src are in 26.6 fixed point pixels.
+//
+// The stroke is a union of polygons, one per line segment and one per join,
+// that all have the same orientation, so that their coverage adds up where
+// they overlap instead of cancelling out.
+func strokeSegments(dst, src []sfnt.Segment, width float64) []sfnt.Segment {
+	type point [2]float64
+	half := width / 2
+
+	polygon := func(ps ...point) {
+		area := 0.0
+		for k, p := range ps {
+			q := ps[(k+1)%len(ps)]
+			area += p[0]*q[1] - q[0]*p[1]
+		}
+		if area < 0 {
+			for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+				ps[i], ps[j] = ps[j], ps[i]
+			}
+		}
+		for k, p := range append(ps, ps[0]) {
+			op := sfnt.SegmentOpLineTo
+			if k == 0 {
+				op = sfnt.SegmentOpMoveTo
+			}
+			dst = append(dst, sfnt.Segment{Op: op, Args: [6]fixed.Int26_6{
+				fixed.Int26_6(math.Round(p[0])),
+				fixed.Int26_6(math.Round(p[1])),
+			}})
+		}
+	}
+
+	var contour []point
+	flush := func() {
+		n := len(contour)
+		if n > 1 && contour[n-1] == contour[0] {
+			n--
+		}
+		for k := 0; k < n && n > 1; k++ {
+			p, q, r := contour[k], contour[(k+1)%n], contour[(k+2)%n]
+			// The edges' unit normals.
+			nx, ny, _ := unit(q[1]-p[1], p[0]-q[0])
+			mx, my, _ := unit(r[1]-q[1], q[0]-r[0])
+			polygon(
+				point{p[0] + nx*half, p[1] + ny*half},
+				point{q[0] + nx*half, q[1] + ny*half},
+				point{q[0] - nx*half, q[1] - ny*half},
+				point{p[0] - nx*half, p[1] - ny*half},
+			)
+			// Fill the gaps, on both sides, between this edge and the next.
+			if nx != mx || ny != my {
+				polygon(q, point{q[0] + nx*half, q[1] + ny*half}, point{q[0] + mx*half, q[1] + my*half})
+				polygon(q, point{q[0] - nx*half, q[1] - ny*half}, point{q[0] - mx*half, q[1] - my*half})
+			}
+		}
+		contour = contour[:0]
+	}
+	lineTo := func(p point) {
+		if n := len(contour); n == 0 || contour[n-1] != p {
+			contour = append(contour, p)
+		}
+	}
+
+	var pen point
+	for _, s := range src {
+		a := [3]point{
+			{float64(s.Args[0]), float64(s.Args[1])},
+			{float64(s.Args[2]), float64(s.Args[3])},
+			{float64(s.Args[4]), float64(s.Args[5])},
+		}
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			flush()
+			lineTo(a[0])
+			pen = a[0]
+		case sfnt.SegmentOpLineTo:
+			lineTo(a[0])
+			pen = a[0]
+		case sfnt.SegmentOpQuadTo:
+			l := math.Hypot(a[0][0]-pen[0], a[0][1]-pen[1]) + math.Hypot(a[1][0]-a[0][0], a[1][1]-a[0][1])
+			n := flattenedPieces(l)
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				lineTo(point{
+					u*u*pen[0] + 2*u*t*a[0][0] + t*t*a[1][0],
+					u*u*pen[1] + 2*u*t*a[0][1] + t*t*a[1][1],
+				})
+			}
+			pen = a[1]
+		case sfnt.SegmentOpCubeTo:
+			l := math.Hypot(a[0][0]-pen[0], a[0][1]-pen[1]) + math.Hypot(a[1][0]-a[0][0], a[1][1]-a[0][1]) +
+				math.Hypot(a[2][0]-a[1][0], a[2][1]-a[1][1])
+			n := flattenedPieces(l)
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				lineTo(point{
+					u*u*u*pen[0] + 3*u*u*t*a[0][0] + 3*u*t*t*a[1][0] + t*t*t*a[2][0],
+					u*u*u*pen[1] + 3*u*u*t*a[0][1] + 3*u*t*t*a[1][1] + t*t*t*a[2][1],
+				})
+			}
+			pen = a[2]
+		}
+	}
+	flush()
+	return dst
+}
+
+// flattenedPieces returns how many line segments approximate a curve whose
+// control polygon has length l, in 26.6 fixed point pixels.
+func flattenedPieces(l float64) int {
+	n := int(math.Ceil(l / maxFlattenedLength))
+	if n < 1 {
+		return 1
+	} else if n > 32 {
+		return 32
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestStrokeSegments(t *testing.T) {
+	// A 10 pixel square, from (0, 0) to (10, 10), with the y axis increasing
+	// up, and a quadratic curve for its top edge.
+	square := []sfnt.Segment{
+		{Op: sfnt.SegmentOpMoveTo, Args: [6]fixed.Int26_6{0, 0}},
+		{Op: sfnt.SegmentOpLineTo, Args: [6]fixed.Int26_6{640, 0}},
+		{Op: sfnt.SegmentOpLineTo, Args: [6]fixed.Int26_6{640, 640}},
+		{Op: sfnt.SegmentOpQuadTo, Args: [6]fixed.Int26_6{320, 640, 0, 640}},
+		{Op: sfnt.SegmentOpLineTo, Args: [6]fixed.Int26_6{0, 0}},
+	}
+	for _, reverse := range []bool{false, true} {
+		src := square
+		if reverse {
+			// The same square, clockwise instead of counter-clockwise.
+			src = []sfnt.Segment{
+				{Op: sfnt.SegmentOpMoveTo, Args: [6]fixed.Int26_6{0, 0}},
+				{Op: sfnt.SegmentOpLineTo, Args: [6]fixed.Int26_6{0, 640}},
+				{Op: sfnt.SegmentOpQuadTo, Args: [6]fixed.Int26_6{320, 640, 640, 640}},
+				{Op: sfnt.SegmentOpLineTo, Args: [6]fixed.Int26_6{640, 0}},
+				{Op: sfnt.SegmentOpLineTo, Args: [6]fixed.Int26_6{0, 0}},
+			}
+		}
+		segments := strokeSegments(nil, src, 2*64)
+		if got, want := sfnt.Segments(segments).Bounds(), (fixed.Rectangle26_6{
+			Min: fixed.P(-1, -1),
+			Max: fixed.P(11, 11),
+		}); got != want {
+			t.Errorf("reverse=%t: bounds: got %v, want %v", reverse, got, want)
+		}
+
+		// Rasterize with the glyph origin at (1, 11), so that the stroke is
+		// from (0, 0) to (12, 12) with the y axis increasing down.
+		face := &Face{}
+		mask := face.rasterize(segments, 1, 11, image.Rect(0, 0, 12, 12))
+		testCases := []struct {
+			x, y int
+			want uint8
+		}{
+			{0, 0, 0x80},  // A corner, which is bevelled.
+			{6, 0, 0xff},  // The middle of the top edge.
+			{0, 6, 0xff},  // The middle of the left edge.
+			{11, 6, 0xff}, // The middle of the right edge.
+			{6, 11, 0xff}, // The middle of the bottom edge.
+			{6, 6, 0x00},  // The center.
+			{3, 3, 0x00},  // Inside, near a corner.
+		}
+		for _, tc := range testCases {
+			if got := mask.AlphaAt(tc.x, tc.y).A; got != tc.want {
+				t.Errorf("reverse=%t: (%d, %d): got %#02x, want %#02x", reverse, tc.x, tc.y, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestFaceStrokeWidth(t *testing.T) {
+	f := parseGoRegular(t)
+	if _, err := NewFace(f, &FaceOptions{Size: 12, DPI: 72, StrokeWidth: -1}); err == nil {
+		t.Errorf("negative stroke width: got nil error, want non-nil")
+	}
+	filled, err := NewFace(f, &FaceOptions{Size: 48, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	stroked, err := NewFace(f, &FaceOptions{Size: 48, DPI: 72, StrokeWidth: 2})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+
+	fBounds, fAdvance, _ := filled.GlyphBounds('l')
+	sBounds, sAdvance, _ := stroked.GlyphBounds('l')
+	if sAdvance != fAdvance {
+		t.Errorf("advance: got %v, want %v", sAdvance, fAdvance)
+	}
+	if want := (fixed.Rectangle26_6{
+		Min: fBounds.Min.Sub(fixed.P(1, 1)),
+		Max: fBounds.Max.Add(fixed.P(1, 1)),
+	}); sBounds != want {
+		t.Errorf("bounds: got %v, want %v", sBounds, want)
+	}
+	if b, _, _ := stroked.GlyphBounds(' '); b != (fixed.Rectangle26_6{}) {
+		t.Errorf("space bounds: got %v, want empty", b)
+	}
+
+	// The middle of the 'I' glyph's stem is filled, but not stroked.
+	middle := func(face *Face) uint8 {
+		dr, mask, maskp, _, _ := face.Glyph(fixed.P(0, 0), 'I')
+		p := maskp.Add(image.Pt(dr.Dx()/2, dr.Dy()/2))
+		return mask.(*image.Alpha).AlphaAt(p.X, p.Y).A
+	}
+	if got := middle(filled); got != 0xff {
+		t.Errorf("filled: got %#02x, want 0xff", got)
+	}
+	if got := middle(stroked); got != 0x00 {
+		t.Errorf("stroked: got %#02x, want 0x00", got)
+	}
+}
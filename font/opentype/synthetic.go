@@ -0,0 +1,178 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// boldStrengthDivisor is such that synthetic bold glyphs are emboldened
+	// by 1/24 of an em, as FreeType's FT_GlyphSlot_Embolden does.
+	boldStrengthDivisor = 24
+
+	// obliqueShear is the horizontal shear of synthetic oblique glyphs, as
+	// FreeType's FT_GlyphSlot_Oblique does. It slants glyphs by about 11
+	// degrees.
+	obliqueShear = 0.2
+)
+
+// boldStrength returns how much wider, and taller, synthetic bold glyphs are.
+// With full hinting, it is a whole number of pixels, so that advances stay
+// whole numbers of pixels.
+func (f *Face) boldStrength() fixed.Int26_6 {
+	if !f.bold {
+		return 0
+	}
+	s := f.scale / boldStrengthDivisor
+	if f.hinting == font.HintingFull {
+		s = fixed.I(s.Round())
+	}
+	return s
+}
+
+// loadGlyph returns the x'th glyph's scaled outline, with any synthetic bold
+// and oblique styles applied after scaling to the horizontal resolution. The
+// segments are only valid until the next call that uses f.buf.
+func (f *Face) loadGlyph(x sfnt.GlyphIndex) ([]sfnt.Segment, error) {
+	segments, err := f.f.LoadGlyph(&f.buf, x, f.scale, &sfnt.LoadGlyphOptions{
+		Hinting: f.hinting,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if f.xScale != 1 {
+		for i := range segments {
+			args := &segments[i].Args
+			for j := 0; j < len(args); j += 2 {
+				args[j] = f.scaleX(args[j])
+			}
+		}
+	}
+	if s := f.boldStrength(); s != 0 {
+		embolden(segments, float64(s))
+	}
+	if f.oblique {
+		for i := range segments {
+			args := &segments[i].Args
+			for j := 0; j < len(args); j += 2 {
+				args[j] += fixed.Int26_6(math.Round(obliqueShear * float64(args[j+1])))
+			}
+		}
+	}
+	return segments, nil
+}
+
+// glyphAdvance returns the x'th glyph's advance, at the horizontal resolution
+// and including any synthetic bold style's extra width.
+func (f *Face) glyphAdvance(x sfnt.GlyphIndex) (fixed.Int26_6, error) {
+	advance, err := f.f.GlyphAdvance(&f.buf, x, f.scale, f.hinting)
+	if err != nil {
+		return 0, err
+	}
+	return f.scaleX(advance) + f.boldStrength(), nil
+}
+
+// embolden moves the segments' points outwards by strength/2, along the
+// bisectors of the adjacent edges' normals, and then translates them by
+// strength/2 right and up, so that the glyph's left and bottom edges stay in
+// place. Counters, such as the hole in an 'o', become smaller. It is like
+// FreeType's FT_Outline_EmboldenXY.
+func embolden(segments []sfnt.Segment, strength float64) {
+	// Collect each contour's points, including off-curve control points.
+	type point struct {
+		x, y float64
+		i, j int // The point's segment index and argument index.
+	}
+	var (
+		contours [][]point
+		area     float64
+	)
+	for i := 0; i < len(segments); {
+		var c []point
+		for first := true; i < len(segments) && (first || segments[i].Op != sfnt.SegmentOpMoveTo); i++ {
+			first = false
+			n := 2
+			switch segments[i].Op {
+			case sfnt.SegmentOpQuadTo:
+				n = 4
+			case sfnt.SegmentOpCubeTo:
+				n = 6
+			}
+			for j := 0; j < n; j += 2 {
+				c = append(c, point{
+					x: float64(segments[i].Args[j+0]),
+					y: float64(segments[i].Args[j+1]),
+					i: i,
+					j: j,
+				})
+			}
+		}
+		for k, p := range c {
+			q := c[(k+1)%len(c)]
+			area += p.x*q.y - q.x*p.y
+		}
+		contours = append(contours, c)
+	}
+
+	// With the y axis increasing up, the outside of a counter-clockwise
+	// (positive area) outline is on the right of each edge.
+	side := 1.0
+	if area < 0 {
+		side = -1
+	}
+	half := strength / 2
+	for _, c := range contours {
+		n := len(c)
+		// The contour is implicitly closed, so a final point that repeats
+		// the first point is ignored when finding neighbors.
+		if n > 1 && c[n-1].x == c[0].x && c[n-1].y == c[0].y {
+			n--
+		}
+		if n < 2 {
+			continue
+		}
+		shifts := make([][2]float64, n)
+		for k := 0; k < n; k++ {
+			p, prev, next := c[k], c[(k+n-1)%n], c[(k+1)%n]
+			inX, inY, inOK := unit(p.x-prev.x, p.y-prev.y)
+			outX, outY, outOK := unit(next.x-p.x, next.y-p.y)
+			if !inOK {
+				inX, inY = outX, outY
+			} else if !outOK {
+				outX, outY = inX, inY
+			}
+			// The edges' outward normals.
+			n0x, n0y := side*inY, -side*inX
+			n1x, n1y := side*outY, -side*outX
+			d := 1 + n0x*n1x + n0y*n1y
+			if d < 0.25 {
+				// Limit the miter length of very sharp corners.
+				d = 0.25
+			}
+			shifts[k] = [2]float64{(n0x + n1x) / d * half, (n0y + n1y) / d * half}
+		}
+		for k, p := range c {
+			s := shifts[k%n]
+			args := &segments[p.i].Args
+			args[p.j+0] = fixed.Int26_6(math.Round(p.x + s[0] + half))
+			args[p.j+1] = fixed.Int26_6(math.Round(p.y + s[1] + half))
+		}
+	}
+}
+
+// unit returns the unit vector in the direction of (x, y), and whether that
+// vector is non-zero.
+func unit(x, y float64) (float64, float64, bool) {
+	l := math.Hypot(x, y)
+	if l == 0 {
+		return 0, 0, false
+	}
+	return x / l, y / l, true
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestEmbolden(t *testing.T) {
+	square := func(clockwise bool) []sfnt.Segment {
+		pts := [][2]fixed.Int26_6{{0, 0}, {640, 0}, {640, 640}, {0, 640}}
+		if clockwise {
+			pts[1], pts[3] = pts[3], pts[1]
+		}
+		var segments []sfnt.Segment
+		for i, p := range pts {
+			op := sfnt.SegmentOpLineTo
+			if i == 0 {
+				op = sfnt.SegmentOpMoveTo
+			}
+			segments = append(segments, sfnt.Segment{Op: op, Args: [6]fixed.Int26_6{p[0], p[1]}})
+		}
+		return segments
+	}
+
+	for _, clockwise := range []bool{false, true} {
+		segments := square(clockwise)
+		embolden(segments, 64)
+		// The square grows by 64 to the right and up.
+		if got, want := sfnt.Segments(segments).Bounds(), (fixed.Rectangle26_6{
+			Min: fixed.Point26_6{X: 0, Y: 0},
+			Max: fixed.Point26_6{X: 704, Y: 704},
+		}); got != want {
+			t.Errorf("clockwise=%t: got %v, want %v", clockwise, got, want)
+		}
+	}
+
+	// A counter, inside an outer contour, shrinks.
+	segments := append(square(false), square(true)...)
+	for i := 4; i < 8; i++ {
+		for j := 0; j < 2; j++ {
+			segments[i].Args[j] = 192 + segments[i].Args[j]/4
+		}
+	}
+	embolden(segments, 64)
+	if got, want := sfnt.Segments(segments[4:]).Bounds(), (fixed.Rectangle26_6{
+		Min: fixed.Point26_6{X: 256, Y: 256},
+		Max: fixed.Point26_6{X: 352, Y: 352},
+	}); got != want {
+		t.Errorf("counter: got %v, want %v", got, want)
+	}
+}
+
+func TestSyntheticStyles(t *testing.T) {
+	f := parseGoRegular(t)
+	regular, err := NewFace(f, &FaceOptions{Size: 48, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	bold, err := NewFace(f, &FaceOptions{Size: 48, DPI: 72, SyntheticBold: true})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	oblique, err := NewFace(f, &FaceOptions{Size: 48, DPI: 72, SyntheticOblique: true})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+
+	rBounds, rAdvance, _ := regular.GlyphBounds('l')
+	bBounds, bAdvance, _ := bold.GlyphBounds('l')
+	oBounds, oAdvance, _ := oblique.GlyphBounds('l')
+	// The strength is 48 pixels / 24, or 2 pixels.
+	if got, want := bAdvance-rAdvance, fixed.I(2); got != want {
+		t.Errorf("bold advance: got %v more, want %v more", got, want)
+	}
+	if got, want := (bBounds.Max.X-bBounds.Min.X)-(rBounds.Max.X-rBounds.Min.X), fixed.I(2); got != want {
+		t.Errorf("bold width: got %v more, want %v more", got, want)
+	}
+	if oAdvance != rAdvance {
+		t.Errorf("oblique advance: got %v, want %v", oAdvance, rAdvance)
+	}
+	if oBounds.Min.Y != rBounds.Min.Y || oBounds.Max.Y != rBounds.Max.Y {
+		t.Errorf("oblique bounds: got %v, want the same height as %v", oBounds, rBounds)
+	}
+	// The oblique glyph's points are slanted to the right, by 0.2 times their
+	// height above the baseline.
+	x, err := f.GlyphIndex(nil, 'l')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	rSegments, err := regular.loadGlyph(x)
+	if err != nil {
+		t.Fatalf("loadGlyph: %v", err)
+	}
+	rSegments = append([]sfnt.Segment(nil), rSegments...)
+	oSegments, err := oblique.loadGlyph(x)
+	if err != nil {
+		t.Fatalf("loadGlyph: %v", err)
+	}
+	for i, s := range rSegments {
+		rx, ry := s.Args[0], s.Args[1]
+		if got, want := oSegments[i].Args[0], rx+fixed.Int26_6(math.Round(0.2*float64(ry))); got != want {
+			t.Errorf("oblique segment #%d: got x=%v, want %v", i, got, want)
+		}
+	}
+
+	// Bold glyphs have more ink.
+	ink := func(face *Face) (sum int) {
+		dr, mask, maskp, _, _ := face.Glyph(fixed.P(0, 0), 'l')
+		a := mask.(*image.Alpha)
+		for y := 0; y < dr.Dy(); y++ {
+			for x := 0; x < dr.Dx(); x++ {
+				sum += int(a.AlphaAt(maskp.X+x, maskp.Y+y).A)
+			}
+		}
+		return sum
+	}
+	if r, b := ink(regular), ink(bold); b <= r {
+		t.Errorf("ink: got %d for bold, %d for regular, want more for bold", b, r)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// VerticalGlyph satisfies the font.VerticalFace interface.
+//
+// The glyph is the font's vertical alternate of r's glyph, from the GSUB
+// table's "vrt2" or "vert" feature, if it has one. It is placed, and the dot
+// advanced, as per the font's vmtx table, if it has one. Otherwise, the
+// glyph's top is at the face's ascent below the dot and the advance is the
+// ascent plus the descent.
+func (f *Face) VerticalGlyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	x, err := f.verticalGlyphIndex(r)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	offset, advance, err := f.verticalMetrics(x)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	dot = dot.Add(offset)
+	// Split the rounded dot.X into whole pixels and a subpixel phase.
+	dotX := (dot.X + f.phase/2) &^ (f.phase - 1)
+	m, err := f.glyphMask(x, dotX&63)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	origin := image.Point{X: dotX.Floor(), Y: dot.Y.Round()}
+	return m.mask.Rect.Add(origin), m.mask, m.mask.Rect.Min, advance, true
+}
+
+// VerticalGlyphAdvance satisfies the font.VerticalFace interface.
+func (f *Face) VerticalGlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	x, err := f.verticalGlyphIndex(r)
+	if err != nil {
+		return 0, false
+	}
+	_, advance, err = f.verticalMetrics(x)
+	if err != nil {
+		return 0, false
+	}
+	return advance, true
+}
+
+var _ font.VerticalFace = (*Face)(nil)
+
+// verticalGlyphIndex returns the glyph index for r in vertical text. It
+// returns an error if the font maps r to its missing glyph.
+func (f *Face) verticalGlyphIndex(r rune) (sfnt.GlyphIndex, error) {
+	x, err := f.f.GlyphIndex(&f.buf, r)
+	if err != nil {
+		return 0, err
+	}
+	if x == 0 {
+		return 0, sfnt.ErrNotFound
+	}
+	return f.f.VerticalGlyph(&f.buf, x)
+}
+
+// verticalMetrics returns the offset from the x'th glyph's vertical origin to
+// its horizontal origin, with the y axis increasing down, and its vertical
+// advance.
+func (f *Face) verticalMetrics(x sfnt.GlyphIndex) (offset fixed.Point26_6, advance fixed.Int26_6, err error) {
+	hAdvance, err := f.glyphAdvance(x)
+	if err != nil {
+		return fixed.Point26_6{}, 0, err
+	}
+	offset.X = -hAdvance / 2
+
+	advance, err = f.f.GlyphVerticalAdvance(&f.buf, x, f.scale, f.hinting)
+	if err == sfnt.ErrNotFound {
+		// Without vertical metrics, stack the glyphs one em box apart.
+		m := f.Metrics()
+		offset.Y = m.Ascent
+		return offset, m.Ascent + m.Descent, nil
+	} else if err != nil {
+		return fixed.Point26_6{}, 0, err
+	}
+	// The top side bearing is the distance from the vertical origin to the
+	// top of the glyph's bounding box, whose y axis increases up.
+	tsb, err := f.f.GlyphTopSideBearing(&f.buf, x, f.scale, f.hinting)
+	if err != nil {
+		return fixed.Point26_6{}, 0, err
+	}
+	bounds, err := f.f.GlyphBounds(&f.buf, x, f.scale, f.hinting)
+	if err != nil {
+		return fixed.Point26_6{}, 0, err
+	}
+	offset.Y = tsb + bounds.Max.Y
+	return offset, advance, nil
+}
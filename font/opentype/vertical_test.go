@@ -0,0 +1,60 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opentype
+
+import (
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestFaceVerticalGlyph(t *testing.T) {
+	// Without vertical metrics, glyphs are one em box apart.
+	face, err := NewFace(parseGoRegular(t), &FaceOptions{Size: 32, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	m := face.Metrics()
+	if got, ok := face.VerticalGlyphAdvance('H'); !ok || got != m.Ascent+m.Descent {
+		t.Errorf("no vmtx: VerticalGlyphAdvance: got %v, %t, want %v", got, ok, m.Ascent+m.Descent)
+	}
+
+	// Give every glyph an advance height of 1 em and a top side bearing of
+	// 200 units, or 3.125 pixels at 32 ppem.
+	f := parseGoRegular(t)
+	vhea := make([]byte, 36)
+	copy(vhea, be16(nil, 0x0001, 0x1000, 1024, -1024, 0))
+	copy(vhea[34:], be16(nil, 1))
+	vmtx := be16(nil, 2048, 200)
+	for i := 1; i < f.NumGlyphs(); i++ {
+		vmtx = be16(vmtx, 200)
+	}
+	face, err = NewFace(withTables(t, map[string][]byte{
+		"vhea": vhea,
+		"vmtx": vmtx,
+	}), &FaceOptions{Size: 32, DPI: 72})
+	if err != nil {
+		t.Fatalf("NewFace: %v", err)
+	}
+	if got, ok := face.VerticalGlyphAdvance('H'); !ok || got != fixed.I(32) {
+		t.Errorf("VerticalGlyphAdvance: got %v, %t, want %v", got, ok, fixed.I(32))
+	}
+	dr, _, _, advance, ok := face.VerticalGlyph(fixed.P(50, 10), 'H')
+	if !ok || advance != fixed.I(32) {
+		t.Fatalf("VerticalGlyph: got %v, %t, want %v", advance, ok, fixed.I(32))
+	}
+	// The glyph is centered horizontally on the dot, and its top is the top
+	// side bearing below the dot, give or take rounding.
+	if mid := (dr.Min.X + dr.Max.X) / 2; mid < 49 || 51 < mid {
+		t.Errorf("dr: got %v, want centered on x = 50", dr)
+	}
+	if dr.Min.Y < 12 || 14 < dr.Min.Y {
+		t.Errorf("dr: got %v, want top at y = 13", dr)
+	}
+
+	if _, ok := face.VerticalGlyphAdvance('\U0001f600'); ok {
+		t.Errorf("missing glyph: VerticalGlyphAdvance: got ok")
+	}
+}
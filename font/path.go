@@ -0,0 +1,165 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/image/math/f64"
+	"golang.org/x/image/math/fixed"
+)
+
+// Path is a sequence of connected line segments and Bézier curves, in pixels,
+// that text can be drawn along by DrawStringPath. The zero value is a path
+// that starts at the origin and has no segments.
+//
+// Curves are approximated by line segments, which is not noticeable for
+// curves that are much larger than a glyph.
+type Path struct {
+	// points is the flattened path, and lengths[i] is the length of the path
+	// from points[0] to points[i].
+	points  []f64.Vec2
+	lengths []float64
+}
+
+// MoveTo starts the path at (x, y), discarding any previous segments.
+func (p *Path) MoveTo(x, y float64) {
+	p.points = append(p.points[:0], f64.Vec2{x, y})
+	p.lengths = append(p.lengths[:0], 0)
+}
+
+// LineTo adds a line segment from the end of the path to (x, y).
+func (p *Path) LineTo(x, y float64) {
+	if len(p.points) == 0 {
+		p.MoveTo(0, 0)
+	}
+	q := p.points[len(p.points)-1]
+	l := math.Hypot(x-q[0], y-q[1])
+	if l == 0 {
+		return
+	}
+	p.points = append(p.points, f64.Vec2{x, y})
+	p.lengths = append(p.lengths, p.lengths[len(p.lengths)-1]+l)
+}
+
+// QuadTo adds a quadratic Bézier curve from the end of the path to (cx, cy),
+// with (bx, by) being the off-curve control point.
+func (p *Path) QuadTo(bx, by, cx, cy float64) {
+	a := p.end()
+	n := pathPieces(math.Hypot(bx-a[0], by-a[1]) + math.Hypot(cx-bx, cy-by))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		p.LineTo(
+			u*u*a[0]+2*u*t*bx+t*t*cx,
+			u*u*a[1]+2*u*t*by+t*t*cy,
+		)
+	}
+}
+
+// CubeTo adds a cubic Bézier curve from the end of the path to (dx, dy), with
+// (bx, by) and (cx, cy) being the off-curve control points.
+func (p *Path) CubeTo(bx, by, cx, cy, dx, dy float64) {
+	a := p.end()
+	n := pathPieces(math.Hypot(bx-a[0], by-a[1]) + math.Hypot(cx-bx, cy-by) + math.Hypot(dx-cx, dy-cy))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		p.LineTo(
+			u*u*u*a[0]+3*u*u*t*bx+3*u*t*t*cx+t*t*t*dx,
+			u*u*u*a[1]+3*u*u*t*by+3*u*t*t*cy+t*t*t*dy,
+		)
+	}
+}
+
+// Length returns the length of the path. For example, text that is w wide
+// is centered on the path when drawn at an offset of (p.Length() - w) / 2.
+func (p *Path) Length() fixed.Int26_6 {
+	if len(p.lengths) == 0 {
+		return 0
+	}
+	return floatToFix(p.lengths[len(p.lengths)-1])
+}
+
+func (p *Path) end() f64.Vec2 {
+	if len(p.points) == 0 {
+		p.MoveTo(0, 0)
+	}
+	return p.points[len(p.points)-1]
+}
+
+// pathPieces returns how many line segments approximate a curve whose
+// control polygon has length l, in pixels.
+func pathPieces(l float64) int {
+	n := int(math.Ceil(l / 2))
+	if n < 1 {
+		return 1
+	} else if n > 64 {
+		return 64
+	}
+	return n
+}
+
+// at returns the point at distance t along the path, and the unit tangent
+// there. Points before the start or after the end of the path are on the
+// extension of the first or last segment.
+func (p *Path) at(t float64) (point, tangent f64.Vec2) {
+	if len(p.points) < 2 {
+		return p.end(), f64.Vec2{1, 0}
+	}
+	// i is the index of the segment from points[i] to points[i+1].
+	i := sort.SearchFloat64s(p.lengths, t) - 1
+	if i < 0 {
+		i = 0
+	} else if i > len(p.points)-2 {
+		i = len(p.points) - 2
+	}
+	a, b := p.points[i], p.points[i+1]
+	l := p.lengths[i+1] - p.lengths[i]
+	tangent = f64.Vec2{(b[0] - a[0]) / l, (b[1] - a[1]) / l}
+	d := t - p.lengths[i]
+	return f64.Vec2{a[0] + d*tangent[0], a[1] + d*tangent[1]}, tangent
+}
+
+// DrawStringPath draws s along the path p, starting at offset along p. Each
+// glyph's baseline is placed on the path, rotated to follow the path's
+// direction at the glyph's horizontal center. Glyphs that go past either end
+// of p are placed on the extension of p's first or last segment.
+//
+// The distances between glyphs along the path are their advances, as for
+// DrawString, including kerning, spacing and tabs. The dot is not used or
+// changed. As for DrawStringTransform, the glyph masks are transformed with
+// the draw package's ApproxBiLinear interpolator.
+func (d *Drawer) DrawStringPath(s string, p *Path, offset fixed.Int26_6) {
+	x := fixed.Int26_6(0)
+	prevC := rune(-1)
+	for _, c := range s {
+		if c == '\t' {
+			x = d.nextTabStop(x)
+			prevC = -1
+			continue
+		}
+		if prevC >= 0 {
+			x += d.Face.Kern(prevC, c)
+		}
+		dr, mask, maskp, advance, ok := d.Face.Glyph(fixed.Point26_6{X: x}, c)
+		if !ok {
+			continue
+		}
+		// The glyph is laid out with its dot at (x, 0), and its center on
+		// the baseline is rotated about and moved to the path's point at
+		// the same distance.
+		mid := fixToFloat(x + advance/2)
+		q, u := p.at(fixToFloat(offset) + mid)
+		s2d := f64.Aff3{
+			u[0], -u[1], q[0] - u[0]*mid,
+			u[1], u[0], q[1] - u[1]*mid,
+		}
+		d.drawGlyphTransform(s2d, dr, mask, maskp)
+		x += advance + d.spacing(c)
+		prevC = c
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func TestPathLength(t *testing.T) {
+	var p Path
+	if got := p.Length(); got != 0 {
+		t.Errorf("empty path: got %v, want 0", got)
+	}
+	p.MoveTo(10, 10)
+	p.LineTo(13, 14)
+	p.LineTo(13, 24)
+	if got, want := p.Length(), fixed.I(15); got != want {
+		t.Errorf("lines: got %v, want %v", got, want)
+	}
+
+	// A quarter circle of radius 100, approximated by a cubic Bézier curve.
+	const k = 0.5522847498 * 100
+	p.MoveTo(100, 0)
+	p.CubeTo(100, k, k, 100, 0, 100)
+	got, want := float64(p.Length())/64, math.Pi*100/2
+	if math.Abs(got-want) > 0.1 {
+		t.Errorf("quarter circle: got %v, want %v", got, want)
+	}
+}
+
+func TestDrawStringPath(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		path   func(p *Path)
+		offset fixed.Int26_6
+		opaque []image.Point
+		clear  []image.Point
+	}{{
+		desc:   "horizontal",
+		path:   func(p *Path) { p.MoveTo(5, 10); p.LineTo(40, 10) },
+		opaque: []image.Point{{5, 10}, {14, 10}},
+		clear:  []image.Point{{4, 10}, {15, 10}, {5, 9}},
+	}, {
+		desc:   "offset",
+		path:   func(p *Path) { p.MoveTo(5, 10); p.LineTo(40, 10) },
+		offset: fixed.I(3),
+		opaque: []image.Point{{8, 10}, {17, 10}},
+		clear:  []image.Point{{7, 10}, {18, 10}},
+	}, {
+		// The glyphs below the baseline are on the left of a path going down.
+		desc:   "vertical",
+		path:   func(p *Path) { p.MoveTo(10, 0); p.LineTo(10, 40) },
+		opaque: []image.Point{{9, 2}, {9, 8}},
+		clear:  []image.Point{{10, 2}, {9, 12}},
+	}, {
+		// Past the end of the path, the glyphs follow its last segment.
+		desc:   "corner",
+		path:   func(p *Path) { p.MoveTo(0, 10); p.LineTo(5, 10); p.LineTo(5, 20) },
+		opaque: []image.Point{{2, 10}, {4, 12}},
+		clear:  []image.Point{{8, 10}, {5, 12}, {4, 17}},
+	}}
+	for _, tc := range testCases {
+		dst := image.NewAlpha(image.Rect(0, 0, 32, 32))
+		d := &Drawer{
+			Dst:  dst,
+			Src:  image.Opaque,
+			Face: rangeFace{lo: 'a', hi: 'z', advance: fixed.I(5), closed: new(int)},
+		}
+		var p Path
+		tc.path(&p)
+		d.DrawStringPath("ab", &p, tc.offset)
+		for _, q := range tc.opaque {
+			if a := dst.AlphaAt(q.X, q.Y).A; a != 0xff {
+				t.Errorf("%s: alpha at %v: got %#02x, want 0xff", tc.desc, q, a)
+			}
+		}
+		for _, q := range tc.clear {
+			if a := dst.AlphaAt(q.X, q.Y).A; a != 0 {
+				t.Errorf("%s: alpha at %v: got %#02x, want 0x00", tc.desc, q, a)
+			}
+		}
+		if d.Dot != (fixed.Point26_6{}) {
+			t.Errorf("%s: dot: got %v, want zero", tc.desc, d.Dot)
+		}
+	}
+}
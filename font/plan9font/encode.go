@@ -0,0 +1,291 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plan9font
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Range maps a range of runes to the glyphs of a subfont file, as one line of
+// a Plan 9 font file.
+type Range struct {
+	// Low and High are the first and last runes of the range. Both ends are
+	// inclusive.
+	Low, High rune
+	// Offset is the index, in the subfont, of the glyph for the Low rune.
+	Offset int
+	// Filename is the subfont file's name, relative to the font file.
+	Filename string
+}
+
+// EncodeFont writes a Plan 9 font file, which gives the font's height and
+// ascent, in pixels, and the subfont files that hold the glyphs for ranges of
+// runes. If ranges overlap, the first match wins.
+func EncodeFont(w io.Writer, height, ascent int, ranges []Range) error {
+	if height < 0 || 0xffff < height || ascent < 0 || 0xffff < ascent {
+		return errors.New("plan9font: invalid font height or ascent")
+	}
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%d %d\n", height, ascent)
+	for _, r := range ranges {
+		if r.Low < 0 || r.High < r.Low || r.Offset < 0 {
+			return fmt.Errorf("plan9font: invalid range %#x-%#x", r.Low, r.High)
+		}
+		if r.Filename == "" || strings.ContainsAny(r.Filename, "\n") || r.Filename[0] <= ' ' {
+			return fmt.Errorf("plan9font: invalid subfont filename %q", r.Filename)
+		}
+		fmt.Fprintf(buf, "0x%04x\t0x%04x\t%d\t%s\n", r.Low, r.High, r.Offset, r.Filename)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// EncodeSubfont writes a Plan 9 subfont file holding f's glyphs for the runes
+// from lo to hi inclusive, so that ParseSubfont(data, lo) returns a face that
+// draws those glyphs as f does. Runes that f has no glyph for get empty
+// glyphs with no advance.
+//
+// depth is the number of bits per pixel of the subfont's image, which must be
+// 1, for bi-level glyphs, or 2, for four levels of gray. The glyphs are drawn
+// with the dot at whole pixel positions, and their advances are rounded to
+// whole pixels. The subfont's height is f's line height, and its ascent is
+// f's ascent, rounded up to whole pixels.
+func EncodeSubfont(w io.Writer, f font.Face, lo, hi rune, depth int) error {
+	if depth != 1 && depth != 2 {
+		return fmt.Errorf("plan9font: unsupported depth %d", depth)
+	}
+	if lo < 0 || hi < lo {
+		return fmt.Errorf("plan9font: invalid range %#x-%#x", lo, hi)
+	}
+	metrics := f.Metrics()
+	ascent := metrics.Ascent.Ceil()
+	height := metrics.Height.Ceil()
+	if h := ascent + metrics.Descent.Ceil(); height < h {
+		height = h
+	}
+	if height > 0xff {
+		return errors.New("plan9font: subfont is too tall")
+	}
+
+	// Find each glyph's columns in the subfont image, and the image's width.
+	type glyph struct {
+		dr    image.Rectangle
+		mask  image.Image
+		maskp image.Point
+		fc    fontchar
+	}
+	n := int(hi-lo) + 1
+	glyphs := make([]glyph, n)
+	x := 0
+	dot := fixed.P(0, ascent)
+	for i := range glyphs {
+		g := &glyphs[i]
+		g.fc.x = uint32(x)
+		dr, mask, maskp, advance, ok := f.Glyph(dot, lo+rune(i))
+		if !ok {
+			continue
+		}
+		a := advance.Round()
+		if a < 0 || 0xff < a {
+			return fmt.Errorf("plan9font: advance of %#x is out of range", lo+rune(i))
+		}
+		g.fc.width = uint8(a)
+		if dr.Min.X < -0x80 || 0x7f < dr.Min.X {
+			return fmt.Errorf("plan9font: left side bearing of %#x is out of range", lo+rune(i))
+		}
+		g.fc.left = int8(dr.Min.X)
+		g.dr, g.mask, g.maskp = dr, mask, maskp
+		x += dr.Dx()
+		if x > 0xffff {
+			return errors.New("plan9font: subfont is too wide")
+		}
+	}
+
+	// Draw the glyphs, and find their first and last rows with ink.
+	img := image.NewAlpha(image.Rect(0, 0, x, height))
+	for i := range glyphs {
+		g := &glyphs[i]
+		if g.mask == nil {
+			continue
+		}
+		top, bottom := height, 0
+		for y := g.dr.Min.Y; y < g.dr.Max.Y; y++ {
+			for dx := 0; dx < g.dr.Dx(); dx++ {
+				_, _, _, a := g.mask.At(g.maskp.X+dx, g.maskp.Y+y-g.dr.Min.Y).RGBA()
+				if a == 0 {
+					continue
+				}
+				if y < 0 || height <= y {
+					return fmt.Errorf("plan9font: glyph for %#x does not fit in the subfont's height", lo+rune(i))
+				}
+				img.Pix[img.PixOffset(int(g.fc.x)+dx, y)] = uint8(a >> 8)
+				if top > y {
+					top = y
+				}
+				if bottom < y+1 {
+					bottom = y + 1
+				}
+			}
+		}
+		if top < bottom {
+			g.fc.top, g.fc.bottom = uint8(top), uint8(bottom)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	writeImage(buf, img, depth)
+	fmt.Fprintf(buf, "%11d %11d %11d ", n, height, ascent)
+	for _, g := range glyphs {
+		writeFontchar(buf, g.fc)
+	}
+	writeFontchar(buf, fontchar{x: uint32(x)})
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeFontchar(buf *bytes.Buffer, fc fontchar) {
+	buf.Write([]byte{
+		uint8(fc.x),
+		uint8(fc.x >> 8),
+		fc.top,
+		fc.bottom,
+		uint8(fc.left),
+		fc.width,
+	})
+}
+
+// compBlockSize is the most compressed data in a band of an image. Plan 9's
+// readers use buffers of this size.
+const compBlockSize = 6000
+
+// writeImage writes m as a compressed Plan 9 image, whose pixels are depth
+// bits of gray.
+func writeImage(buf *bytes.Buffer, m *image.Alpha, depth int) {
+	r := m.Rect
+	p := &plan9Image{
+		depth: depth,
+		width: bytesPerLine(r, depth),
+		rect:  r,
+	}
+	p.pix = make([]byte, p.width*r.Dy())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			p.set(x, y, m.Pix[m.PixOffset(x, y)])
+		}
+	}
+
+	buf.Write(compressed)
+	fmt.Fprintf(buf, "%11s %11d %11d %11d %11d ", fmt.Sprintf("k%d", depth), r.Min.X, r.Min.Y, r.Max.X, r.Max.Y)
+
+	c := &compressor{}
+	miny := r.Min.Y
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		line := p.pix[p.byteoffset(r.Min.X, y):][:p.width]
+		outLen, histLen := len(c.out), len(c.hist)
+		c.compressLine(line)
+		if len(c.out) > compBlockSize && y > miny {
+			// Start a new band with this line.
+			c.out, c.hist = c.out[:outLen], c.hist[:histLen]
+			fmt.Fprintf(buf, "%11d %11d ", y, len(c.out))
+			buf.Write(c.out)
+			c.out, c.hist, miny = c.out[:0], c.hist[:0], y
+			c.compressLine(line)
+		}
+	}
+	if miny < r.Max.Y {
+		fmt.Fprintf(buf, "%11d %11d ", r.Max.Y, len(c.out))
+		buf.Write(c.out)
+	}
+}
+
+// set sets the pixel at (x, y) to the gray level of the alpha value a.
+func (m *plan9Image) set(x, y int, a uint8) {
+	i := m.byteoffset(x, y)
+	switch m.depth {
+	case 1:
+		if a >= 0x80 {
+			m.pix[i] |= 1 << uint(7-x&7)
+		}
+	case 2:
+		// Round to the nearest of 0x00, 0x55, 0xaa and 0xff.
+		v := (uint(a) + 0x2a) / 0x55
+		m.pix[i] |= uint8(v << (6 - uint(x&3)<<1))
+	}
+}
+
+// compressor compresses the scan lines of one band of an image, in the format
+// that decompress reads.
+type compressor struct {
+	out  []byte // Compressed data.
+	hist []byte // Uncompressed data, for finding repeated strings.
+}
+
+// compLongestMatch is the longest string that one code can repeat.
+const compLongestMatch = compShortestMatch + 0x7f>>2
+
+func (c *compressor) compressLine(line []byte) {
+	// lit is the index in c.out of the current run of literal bytes' code,
+	// or -1 if there is no such run. Neither runs nor repeated strings span
+	// scan lines.
+	lit := -1
+	for i := 0; i < len(line); {
+		maxLen := len(line) - i
+		if maxLen > compLongestMatch {
+			maxLen = compLongestMatch
+		}
+		// Find the longest string that starts in the window and matches
+		// line[i:]. It can overlap line[i:] itself.
+		bestLen, bestOffs := 0, 0
+		if maxLen >= compShortestMatch {
+			start := len(c.hist) - compWindowSize
+			if start < 0 {
+				start = 0
+			}
+			for j := start; j < len(c.hist); j++ {
+				n := 0
+				for ; n < maxLen; n++ {
+					k, src := j+n, byte(0)
+					if k < len(c.hist) {
+						src = c.hist[k]
+					} else {
+						src = line[i+k-len(c.hist)]
+					}
+					if src != line[i+n] {
+						break
+					}
+				}
+				if n > bestLen {
+					bestLen, bestOffs = n, len(c.hist)-j
+				}
+			}
+		}
+
+		if bestLen >= compShortestMatch {
+			code := (bestLen-compShortestMatch)<<2 | (bestOffs-1)>>8
+			c.out = append(c.out, uint8(code), uint8(bestOffs-1))
+			c.hist = append(c.hist, line[i:i+bestLen]...)
+			i += bestLen
+			lit = -1
+			continue
+		}
+		if lit < 0 || c.out[lit] == 0xff {
+			c.out = append(c.out, 0x80)
+			lit = len(c.out) - 1
+		} else {
+			c.out[lit]++
+		}
+		c.out = append(c.out, line[i])
+		c.hist = append(c.hist, line[i])
+		i++
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package plan9font
+
+import (
+	"io/fs"
+	"path"
+
+	"golang.org/x/image/font"
+)
+
+// OpenFont parses the Plan 9 font file with the given name in fsys.
+//
+// The font file's subfont filenames are relative to the directory holding the
+// font file, and may use ".." to refer to sibling directories, but they must
+// not leave fsys. As for ParseFont, each subfont file is read from fsys only
+// when the returned face first needs one of its glyphs, so that a font that
+// covers much of Unicode with hundreds of subfont files is quick to open.
+func OpenFont(fsys fs.FS, name string) (font.Face, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	dir := path.Dir(name)
+	return ParseFont(data, func(relFilename string) ([]byte, error) {
+		return fs.ReadFile(fsys, path.Join(dir, relFilename))
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package plan9font
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+// countingFS is an fs.FS that records the names of the files opened.
+type countingFS struct {
+	fs.FS
+	opened map[string]int
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	c.opened[name]++
+	return c.FS.Open(name)
+}
+
+func TestOpenFont(t *testing.T) {
+	fsys := &countingFS{FS: os.DirFS("../testdata"), opened: map[string]int{}}
+	f, err := OpenFont(fsys, "fixed/unicode.7x13.font")
+	if err != nil {
+		t.Fatalf("OpenFont: %v", err)
+	}
+	if len(fsys.opened) != 1 {
+		t.Errorf("after OpenFont: got %d files opened, want 1", len(fsys.opened))
+	}
+
+	for _, r := range []rune{'A', 'B', '\u00e9', '\u0101', '\u0102'} {
+		if _, ok := f.GlyphAdvance(r); !ok {
+			t.Errorf("GlyphAdvance(%U): got !ok, want ok", r)
+		}
+	}
+	for _, name := range []string{"fixed/7x13.0000", "fixed/7x13.0100"} {
+		if n := fsys.opened[name]; n != 1 {
+			t.Errorf("%s: opened %d times, want 1", name, n)
+		}
+	}
+	if n := fsys.opened["fixed/7x13.0200"]; n != 0 {
+		t.Errorf("fixed/7x13.0200: opened %d times, want 0", n)
+	}
+
+	if _, err := OpenFont(fsys, "fixed/no-such.font"); err == nil {
+		t.Errorf("missing font file: got nil error, want non-nil")
+	}
+}
+
+func TestOpenFontSharedSubfont(t *testing.T) {
+	data, err := os.ReadFile("../testdata/fixed/7x13.0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := &countingFS{
+		FS: fstest.MapFS{
+			// Both ranges use the same subfont file, the second one for
+			// upper case letters only.
+			"fonts/a.font":    {Data: []byte("13 11\n0x0000 0x00FF ../sub/x.0000\n0x0100 0x0119 0x41 ../sub/x.0000\n")},
+			"sub/x.0000":      {Data: data},
+			"sub/unused.0000": {Data: data},
+		},
+		opened: map[string]int{},
+	}
+	f, err := OpenFont(fsys, "fonts/a.font")
+	if err != nil {
+		t.Fatalf("OpenFont: %v", err)
+	}
+	b0, a0, ok0 := f.GlyphBounds('C')
+	b1, a1, ok1 := f.GlyphBounds(0x0102)
+	if !ok0 || !ok1 || b0 != b1 || a0 != a1 {
+		t.Errorf("U+0102: got %v, %v, %t, want %v, %v, %t", b1, a1, ok1, b0, a0, ok0)
+	}
+	if n := fsys.opened["sub/x.0000"]; n != 1 {
+		t.Errorf("sub/x.0000: opened %d times, want 1", n)
+	}
+}
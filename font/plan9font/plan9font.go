@@ -138,6 +138,10 @@ type face struct {
 	ascent     int
 	readFile   func(relFilename string) ([]byte, error)
 	runeRanges []runeRange
+	// subfaces caches the subfont files loaded so far, keyed by relative
+	// filename, so that rune ranges that share a subfont file read and
+	// parse it only once. A nil value means that the file is bad.
+	subfaces map[string]*subface
 }
 
 func (f *face) Close() error                   { return nil }
@@ -213,19 +217,16 @@ func (f *face) subface(r rune) (*subface, rune) {
 				continue
 			}
 			if x.subface == nil {
-				data, err := f.readSubfontFile(x.relFilename)
-				if err != nil {
-					log.Printf("plan9font: couldn't read subfont %q: %v", x.relFilename, err)
+				sub := f.loadSubface(x.relFilename)
+				if sub == nil {
 					x.bad = true
 					continue
 				}
-				sub, err := ParseSubfont(data, x.lo-x.offset)
-				if err != nil {
-					log.Printf("plan9font: couldn't parse subfont %q: %v", x.relFilename, err)
-					x.bad = true
-					continue
-				}
-				x.subface = sub.(*subface)
+				// The range may map its lo rune to a different glyph than
+				// other ranges sharing the subfont file do.
+				s := *sub
+				s.firstRune = x.lo - x.offset
+				x.subface = &s
 			}
 			return x.subface, rr
 		}
@@ -233,11 +234,39 @@ func (f *face) subface(r rune) (*subface, rune) {
 	return nil, 0
 }
 
+// loadSubface returns the subfont file with the given relative filename,
+// reading and parsing it if it is not already cached. It returns nil if the
+// file cannot be read or parsed.
+func (f *face) loadSubface(relFilename string) *subface {
+	if sub, ok := f.subfaces[relFilename]; ok {
+		return sub
+	}
+	if f.subfaces == nil {
+		f.subfaces = map[string]*subface{}
+	}
+	f.subfaces[relFilename] = nil
+	data, err := f.readSubfontFile(relFilename)
+	if err != nil {
+		log.Printf("plan9font: couldn't read subfont %q: %v", relFilename, err)
+		return nil
+	}
+	sub, err := ParseSubfont(data, 0)
+	if err != nil {
+		log.Printf("plan9font: couldn't parse subfont %q: %v", relFilename, err)
+		return nil
+	}
+	f.subfaces[relFilename] = sub.(*subface)
+	return sub.(*subface)
+}
+
 // ParseFont parses a Plan 9 font file. data is the contents of that font file,
 // which gives relative filenames for subfont files. readFile returns the
 // contents of those subfont files. It is similar to io/ioutil's ReadFile
 // function, except that it takes a relative filename instead of an absolute
 // one.
+//
+// Subfont files are read when the returned face first needs one of their
+// glyphs, and each file is read at most once.
 func ParseFont(data []byte, readFile func(relFilename string) ([]byte, error)) (font.Face, error) {
 	f := &face{
 		readFile: readFile,
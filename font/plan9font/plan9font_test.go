@@ -5,9 +5,16 @@
 package plan9font
 
 import (
+	"bytes"
+	"fmt"
+	"image"
 	"io/ioutil"
+	"math/rand"
 	"path/filepath"
 	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
 )
 
 func BenchmarkParseSubfont(b *testing.B) {
@@ -22,3 +29,134 @@ func BenchmarkParseSubfont(b *testing.B) {
 		}
 	}
 }
+
+// drawString draws s with f to a new Gray image of the given size.
+func drawString(f font.Face, s string, width, height int) *image.Gray {
+	dst := image.NewGray(image.Rect(0, 0, width, height))
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.White,
+		Face: f,
+		Dot:  fixed.P(1, f.Metrics().Ascent.Ceil()),
+	}
+	d.DrawString(s)
+	return dst
+}
+
+func TestEncodeSubfont(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/fixed/7x13.0000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig, err := ParseSubfont(data, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, depth := range []int{1, 2} {
+		buf := &bytes.Buffer{}
+		if err := EncodeSubfont(buf, orig, 0x20, 0x7e, depth); err != nil {
+			t.Fatalf("depth %d: EncodeSubfont: %v", depth, err)
+		}
+		f, err := ParseSubfont(buf.Bytes(), 0x20)
+		if err != nil {
+			t.Fatalf("depth %d: ParseSubfont: %v", depth, err)
+		}
+		if got, want := f.Metrics(), orig.Metrics(); got != want {
+			t.Errorf("depth %d: Metrics: got %+v, want %+v", depth, got, want)
+		}
+		const s = "The quick brown fox jumps over the lazy dog. {~}"
+		got := drawString(f, s, 7*len(s)+2, 15)
+		want := drawString(orig, s, 7*len(s)+2, 15)
+		if !bytes.Equal(got.Pix, want.Pix) {
+			t.Errorf("depth %d: re-encoded subfont draws different pixels", depth)
+		}
+		if _, ok := f.GlyphAdvance(0x7f); ok {
+			t.Errorf("depth %d: GlyphAdvance(0x7f): got ok, want !ok", depth)
+		}
+	}
+
+	if err := EncodeSubfont(ioutil.Discard, orig, 0x20, 0x7e, 8); err == nil {
+		t.Errorf("depth 8: got nil error, want non-nil")
+	}
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := make([]byte, 3000)
+	rng.Read(random)
+	repetitive := make([]byte, 3000)
+	for i := range repetitive {
+		repetitive[i] = uint8(i % 7 * (i / 100))
+	}
+	sparse := make([]byte, 3000)
+	for i := 0; i < len(sparse); i += 37 {
+		sparse[i] = uint8(i)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		data []byte
+	}{
+		{"random", random},
+		{"repetitive", repetitive},
+		{"sparse", sparse},
+	} {
+		r := image.Rect(0, 0, 8*100, len(tc.data)/100)
+		m := &plan9Image{depth: 1, width: 100, rect: r, pix: make([]byte, len(tc.data))}
+		c := &compressor{}
+		for y := 0; y < r.Dy(); y++ {
+			c.compressLine(tc.data[y*100 : (y+1)*100])
+		}
+		if err := decompress(m, r, c.out); err != nil {
+			t.Errorf("%s: decompress: %v", tc.desc, err)
+			continue
+		}
+		if !bytes.Equal(m.pix, tc.data) {
+			t.Errorf("%s: round trip mismatch", tc.desc)
+		}
+		if tc.desc != "random" && len(c.out) >= len(tc.data)/2 {
+			t.Errorf("%s: compressed %d bytes to %d", tc.desc, len(tc.data), len(c.out))
+		}
+	}
+}
+
+func TestEncodeFont(t *testing.T) {
+	subfonts := map[string][]byte{}
+	for _, name := range []string{"7x13.0000", "7x13.0100"} {
+		data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/fixed/" + name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		subfonts[name] = data
+	}
+	buf := &bytes.Buffer{}
+	err := EncodeFont(buf, 13, 11, []Range{
+		{Low: 0x0000, High: 0x00ff, Filename: "7x13.0000"},
+		{Low: 0x0100, High: 0x01ff, Filename: "7x13.0100"},
+	})
+	if err != nil {
+		t.Fatalf("EncodeFont: %v", err)
+	}
+	f, err := ParseFont(buf.Bytes(), func(name string) ([]byte, error) {
+		if data, ok := subfonts[name]; ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf("no such subfont %q", name)
+	})
+	if err != nil {
+		t.Fatalf("ParseFont: %v", err)
+	}
+	if got, want := f.Metrics().Ascent, fixed.I(11); got != want {
+		t.Errorf("ascent: got %v, want %v", got, want)
+	}
+	for _, r := range []rune{'A', '\u0101'} {
+		if _, ok := f.GlyphAdvance(r); !ok {
+			t.Errorf("GlyphAdvance(%U): got !ok, want ok", r)
+		}
+	}
+
+	if err := EncodeFont(ioutil.Discard, 13, 11, []Range{{Low: 0, High: 0xff, Filename: "a\nb"}}); err == nil {
+		t.Errorf("bad filename: got nil error, want non-nil")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"golang.org/x/image/math/fixed"
+)
+
+// Quantization is how a Drawer rounds the dot's horizontal position before
+// asking its Face for each glyph.
+//
+// Coarser quantization means fewer distinct subpixel positions for a Face to
+// rasterize and cache a glyph at, which is faster, but each glyph can be
+// drawn up to a pixel, or a quarter of a pixel, to the left of its exact
+// position. The dot itself is never rounded, so rounding errors do not
+// accumulate along a line of text.
+type Quantization uint8
+
+const (
+	// QuantizationNone passes the dot to the Face unchanged, leaving any
+	// rounding to the Face.
+	QuantizationNone Quantization = iota
+	// QuantizationPixel rounds the dot down to a whole pixel.
+	QuantizationPixel
+	// QuantizationQuarterPixel rounds the dot down to a quarter of a pixel.
+	QuantizationQuarterPixel
+)
+
+// RoundingFace is a Face whose Glyph method rounds the dot, for example to a
+// whole pixel or to a subpixel phase, before drawing a glyph there. The
+// Drawer's Bound methods use it to position each glyph's bounds exactly where
+// its glyph is drawn.
+type RoundingFace interface {
+	Face
+
+	// RoundDot returns where the Glyph method, when passed dot, draws the
+	// glyph's origin.
+	RoundDot(dot fixed.Point26_6) fixed.Point26_6
+}
+
+// runeRoundingFace is a Face, such as one returned by NewMultiFace, whose
+// Glyph method rounds the dot differently depending on the rune.
+type runeRoundingFace interface {
+	roundRuneDot(dot fixed.Point26_6, r rune) fixed.Point26_6
+}
+
+// roundDot returns where f's Glyph method, when passed dot and r, draws the
+// glyph's origin.
+func roundDot(f Face, dot fixed.Point26_6, r rune) fixed.Point26_6 {
+	switch f := f.(type) {
+	case runeRoundingFace:
+		return f.roundRuneDot(dot, r)
+	case RoundingFace:
+		return f.RoundDot(dot)
+	}
+	return dot
+}
+
+// glyphDot returns where the drawer draws a glyph with the dot at p, as per
+// its Quantization.
+func (d *Drawer) glyphDot(p fixed.Point26_6) fixed.Point26_6 {
+	switch d.Quantization {
+	case QuantizationPixel:
+		p.X &^= 63
+	case QuantizationQuarterPixel:
+		p.X &^= 15
+	}
+	return p
+}
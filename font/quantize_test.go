@@ -0,0 +1,67 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// dotFace is a rangeFace that records the dots that its glyphs are drawn at.
+type dotFace struct {
+	rangeFace
+	dots *[]fixed.Int26_6
+}
+
+func (f dotFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	*f.dots = append(*f.dots, dot.X)
+	return f.rangeFace.Glyph(dot, r)
+}
+
+func TestDrawerQuantization(t *testing.T) {
+	testCases := []struct {
+		q    Quantization
+		want []fixed.Int26_6
+		// next is where Glyphs positions the second glyph of a string drawn
+		// after the first.
+		next fixed.Int26_6
+	}{
+		{QuantizationNone, []fixed.Int26_6{3, 3 + 90, 3 + 180, 3 + 270}, 3 + 450},
+		{QuantizationPixel, []fixed.Int26_6{0, 64, 128, 256}, 448},
+		{QuantizationQuarterPixel, []fixed.Int26_6{0, 80, 176, 272}, 448},
+	}
+	for _, tc := range testCases {
+		var dots []fixed.Int26_6
+		d := &Drawer{
+			Dst:          image.NewGray(image.Rect(0, 0, 16, 4)),
+			Src:          image.White,
+			Face:         dotFace{rangeFace{lo: 'a', hi: 'z', advance: 90, closed: new(int)}, &dots},
+			Dot:          fixed.Point26_6{X: 3, Y: fixed.I(2)},
+			Quantization: tc.q,
+		}
+		d.DrawString("abcd")
+		if len(dots) != len(tc.want) {
+			t.Errorf("q=%d: got %d glyphs, want %d", tc.q, len(dots), len(tc.want))
+			continue
+		}
+		for i := range dots {
+			if dots[i] != tc.want[i] {
+				t.Errorf("q=%d: glyph #%d: got dot %v, want %v", tc.q, i, dots[i], tc.want[i])
+			}
+		}
+		// The dot itself is not rounded.
+		if got, want := d.Dot.X, fixed.Int26_6(3+360); got != want {
+			t.Errorf("q=%d: final dot: got %v, want %v", tc.q, got, want)
+		}
+		it := d.Glyphs("ab")
+		it.Next()
+		it.Next()
+		if got := it.Glyph().Dot.X; got != tc.next {
+			t.Errorf("q=%d: Glyphs: got dot %v, want %v", tc.q, got, tc.next)
+		}
+	}
+}
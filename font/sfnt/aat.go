@@ -0,0 +1,586 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// This file implements some of the Apple Advanced Typography (AAT) tables, as
+// described at
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6.html
+//
+// Apple fonts, such as macOS system fonts, may have kerx and morx tables
+// instead of kern, GPOS and GSUB tables, and a trak table for size-specific
+// letter spacing.
+
+// kerxSubtable is a supported kerx subtable: a horizontal subtable of format 0
+// (ordered pairs) or format 2 (class based). Its table's offset and length
+// include the subtable header.
+type kerxSubtable struct {
+	format uint8
+	table
+}
+
+func (f *Font) parseKerx(buf []byte) ([]byte, error) {
+	// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6kerx.html
+
+	if f.kerx.length == 0 {
+		return buf, nil
+	}
+	const headerSize, subtableHeaderSize = 8, 12
+	if f.kerx.length < headerSize {
+		return nil, errInvalidKerxTable
+	}
+	buf, err := f.src.view(buf, int(f.kerx.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if version := u16(buf); version < 2 {
+		// Version 0 and 1 tables are not kerx tables. Kerning is optional,
+		// so ignore them.
+		return buf, nil
+	}
+	nTables := u32(buf[4:])
+
+	offset := uint32(headerSize)
+	for ; nTables > 0; nTables-- {
+		if f.kerx.length-offset < subtableHeaderSize {
+			return nil, errInvalidKerxTable
+		}
+		buf, err = f.src.view(buf, int(f.kerx.offset+offset), subtableHeaderSize)
+		if err != nil {
+			return nil, err
+		}
+		length := u32(buf)
+		coverage := u32(buf[4:])
+		if length < subtableHeaderSize || f.kerx.length-offset < length {
+			return nil, errInvalidKerxTable
+		}
+
+		// The coverage flags are 0x80000000 for vertical, 0x40000000 for
+		// cross-stream and 0x20000000 for variation subtables. Subtables in
+		// other formats, such as the state table formats 1 and 4, are
+		// skipped.
+		if format := uint8(coverage); coverage&0xe0000000 == 0 && (format == 0 || format == 2) {
+			f.cached.kerxSubtables = append(f.cached.kerxSubtables, kerxSubtable{
+				format: format,
+				table:  table{f.kerx.offset + offset, length},
+			})
+		}
+		offset += length
+	}
+	return buf, nil
+}
+
+// kerxKern returns the horizontal adjustment, in font units, for the kerning
+// pair (x0, x1) from the kerx table. Each subtable's adjustment is summed.
+func (f *Font) kerxKern(b *Buffer, x0, x1 GlyphIndex) (int32, error) {
+	kern := int32(0)
+	for _, s := range f.cached.kerxSubtables {
+		var (
+			k   int32
+			err error
+		)
+		if s.format == 0 {
+			k, err = f.kerxFormat0Kern(b, s.table, x0, x1)
+		} else {
+			k, err = f.kerxFormat2Kern(b, s.table, x0, x1)
+		}
+		if err != nil {
+			return 0, err
+		}
+		kern += k
+	}
+	return kern, nil
+}
+
+func (f *Font) kerxFormat0Kern(b *Buffer, t table, x0, x1 GlyphIndex) (int32, error) {
+	const headerSize, entrySize = 12 + 16, 6
+	if t.length < headerSize {
+		return 0, errInvalidKerxTable
+	}
+	buf, err := b.view(&f.src, int(t.offset), headerSize)
+	if err != nil {
+		return 0, err
+	}
+	nPairs := u32(buf[12:])
+	if nPairs > (t.length-headerSize)/entrySize {
+		return 0, errInvalidKerxTable
+	}
+
+	key := uint32(x0)<<16 | uint32(x1)
+	for lo, hi := uint32(0), nPairs; lo < hi; {
+		i := (lo + hi) / 2
+		buf, err := b.view(&f.src, int(t.offset+headerSize+i*entrySize), entrySize)
+		if err != nil {
+			return 0, err
+		}
+		k := u32(buf)
+		if k < key {
+			lo = i + 1
+		} else if k > key {
+			hi = i
+		} else {
+			return int32(int16(u16(buf[4:]))), nil
+		}
+	}
+	return 0, nil
+}
+
+// kerxFormat2Kern is like kerxFormat0Kern for a format 2 subtable. Unlike a
+// kern table's format 2 subtable, the class values are offsets relative to
+// the start of the kerning array, and the class tables are AAT lookup tables.
+// Glyphs that a class table does not cover are in class 0.
+func (f *Font) kerxFormat2Kern(b *Buffer, t table, x0, x1 GlyphIndex) (int32, error) {
+	const headerSize = 12 + 16
+	if t.length < headerSize {
+		return 0, errInvalidKerxTable
+	}
+	buf, err := b.view(&f.src, int(t.offset), headerSize)
+	if err != nil {
+		return 0, err
+	}
+	leftClass := u32(buf[16:])
+	rightClass := u32(buf[20:])
+	array := u32(buf[24:])
+	if leftClass > t.length || rightClass > t.length || array > t.length {
+		return 0, errInvalidKerxTable
+	}
+
+	left, _, err := f.aatLookup(b, table{t.offset + leftClass, t.length - leftClass}, x0, errInvalidKerxTable)
+	if err != nil {
+		return 0, err
+	}
+	right, _, err := f.aatLookup(b, table{t.offset + rightClass, t.length - rightClass}, x1, errInvalidKerxTable)
+	if err != nil {
+		return 0, err
+	}
+	o := array + uint32(left) + uint32(right)
+	if o > t.length || t.length-o < 2 {
+		return 0, errInvalidKerxTable
+	}
+	buf, err = b.view(&f.src, int(t.offset+o), 2)
+	if err != nil {
+		return 0, err
+	}
+	return int32(int16(u16(buf))), nil
+}
+
+func (f *Font) parseMorx(buf []byte) ([]byte, error) {
+	// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6morx.html
+
+	if f.morx.length == 0 {
+		return buf, nil
+	}
+	const headerSize, chainHeaderSize, featureSize, subtableHeaderSize = 8, 16, 12, 12
+	if f.morx.length < headerSize {
+		return nil, errInvalidMorxTable
+	}
+	buf, err := f.src.view(buf, int(f.morx.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if version := u16(buf); version != 2 && version != 3 {
+		return buf, nil
+	}
+	nChains := u32(buf[4:])
+
+	offset := uint32(headerSize)
+	for ; nChains > 0; nChains-- {
+		if f.morx.length-offset < chainHeaderSize {
+			return nil, errInvalidMorxTable
+		}
+		buf, err = f.src.view(buf, int(f.morx.offset+offset), chainHeaderSize)
+		if err != nil {
+			return nil, err
+		}
+		defaultFlags := u32(buf)
+		chainLength := u32(buf[4:])
+		nFeatureEntries := u32(buf[8:])
+		nSubtables := u32(buf[12:])
+		if chainLength < chainHeaderSize || f.morx.length-offset < chainLength ||
+			nFeatureEntries > (chainLength-chainHeaderSize)/featureSize {
+			return nil, errInvalidMorxTable
+		}
+
+		// Only the features that are enabled by default are applied, so the
+		// feature entries, which map feature settings to flags, are skipped.
+		o := chainHeaderSize + featureSize*nFeatureEntries
+		for ; nSubtables > 0; nSubtables-- {
+			if chainLength-o < subtableHeaderSize {
+				return nil, errInvalidMorxTable
+			}
+			buf, err = f.src.view(buf, int(f.morx.offset+offset+o), subtableHeaderSize)
+			if err != nil {
+				return nil, err
+			}
+			length := u32(buf)
+			coverage := u32(buf[4:])
+			subFeatureFlags := u32(buf[8:])
+			if length < subtableHeaderSize || chainLength-o < length {
+				return nil, errInvalidMorxTable
+			}
+
+			// Type 4 subtables are non-contextual substitutions. The coverage
+			// flag 0x80000000 means vertical text only, unless 0x20000000
+			// means both orientations.
+			//
+			// TODO: support ligature (type 2) subtables, which need a state
+			// machine to be run over a sequence of glyphs.
+			const typeNoncontextual = 4
+			if coverage&0xff == typeNoncontextual && coverage&0xa0000000 != 0x80000000 &&
+				subFeatureFlags&defaultFlags != 0 {
+				f.cached.morxSubtables = append(f.cached.morxSubtables, table{
+					f.morx.offset + offset + o + subtableHeaderSize,
+					length - subtableHeaderSize,
+				})
+			}
+			o += length
+		}
+		offset += chainLength
+	}
+	return buf, nil
+}
+
+// MorxSubstituteGlyph returns the glyph that x is replaced with by the morx
+// table's non-contextual substitutions that are enabled by default, such as a
+// font's preferred alternate forms. It returns x if no substitution applies.
+//
+// Contextual, ligature and insertion subtables are not applied.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) MorxSubstituteGlyph(b *Buffer, x GlyphIndex) (GlyphIndex, error) {
+	if err := f.skippedTable("morx"); err != nil {
+		return 0, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return 0, ErrNotFound
+	}
+	if f.cached.morxSubtables == nil {
+		return x, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	for _, t := range f.cached.morxSubtables {
+		y, ok, err := f.aatLookup(b, t, x, errInvalidMorxTable)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			x = GlyphIndex(y)
+		}
+	}
+	return x, nil
+}
+
+// aatLookup returns the value for x in the AAT lookup table t, and whether t
+// has a value for x. The lookup table formats are described at
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6Tables.html
+//
+// Only 16-bit values are supported.
+func (f *Font) aatLookup(b *Buffer, t table, x GlyphIndex, errInvalid error) (uint16, bool, error) {
+	if t.length < 2 {
+		return 0, false, errInvalid
+	}
+	buf, err := b.view(&f.src, int(t.offset), 2)
+	if err != nil {
+		return 0, false, err
+	}
+	switch format := u16(buf); format {
+	case 0:
+		// Simple array format: a value for every glyph.
+		if int(x) >= f.NumGlyphs() {
+			return 0, false, nil
+		}
+		return f.aatLookupU16(b, t, 2+2*uint32(x), errInvalid)
+
+	case 2, 4, 6:
+		// Segment single, segment array and single table formats: a binary
+		// searchable array of units. Segments start with their last and
+		// first glyphs. Single table entries start with their glyph.
+		const headerSize = 12
+		if t.length < headerSize {
+			return 0, false, errInvalid
+		}
+		buf, err = b.view(&f.src, int(t.offset), headerSize)
+		if err != nil {
+			return 0, false, err
+		}
+		unitSize := uint32(u16(buf[2:]))
+		nUnits := uint32(u16(buf[4:]))
+		minUnitSize := uint32(6)
+		if format == 6 {
+			minUnitSize = 4
+		}
+		if unitSize < minUnitSize || nUnits > (t.length-headerSize)/unitSize {
+			return 0, false, errInvalid
+		}
+		for lo, hi := uint32(0), nUnits; lo < hi; {
+			i := (lo + hi) / 2
+			u := headerSize + i*unitSize
+			buf, err = b.view(&f.src, int(t.offset+u), int(minUnitSize))
+			if err != nil {
+				return 0, false, err
+			}
+			last, first := GlyphIndex(u16(buf)), GlyphIndex(u16(buf[2:]))
+			if format == 6 {
+				first = last
+			}
+			if x > last {
+				lo = i + 1
+			} else if x < first {
+				hi = i
+			} else if format == 2 {
+				return u16(buf[4:]), true, nil
+			} else if format == 6 {
+				return u16(buf[2:]), true, nil
+			} else {
+				// The segment's value is the offset, from the start of the
+				// lookup table, of an array of values.
+				return f.aatLookupU16(b, t, uint32(u16(buf[4:]))+2*uint32(x-first), errInvalid)
+			}
+		}
+		return 0, false, nil
+
+	case 8, 10:
+		// Trimmed array formats: a value for each of a range of glyphs.
+		// Format 10 has a unit size, of which only 2 is supported.
+		headerSize := uint32(6)
+		if format == 10 {
+			headerSize = 8
+		}
+		if t.length < headerSize {
+			return 0, false, errInvalid
+		}
+		buf, err = b.view(&f.src, int(t.offset), int(headerSize))
+		if err != nil {
+			return 0, false, err
+		}
+		if format == 10 {
+			if u16(buf[2:]) != 2 {
+				return 0, false, errInvalid
+			}
+			buf = buf[2:]
+		}
+		firstGlyph, glyphCount := GlyphIndex(u16(buf[2:])), uint32(u16(buf[4:]))
+		if x < firstGlyph || uint32(x-firstGlyph) >= glyphCount {
+			return 0, false, nil
+		}
+		return f.aatLookupU16(b, t, headerSize+2*uint32(x-firstGlyph), errInvalid)
+	}
+	return 0, false, errInvalid
+}
+
+// aatLookupU16 returns the 16-bit value at the offset o, relative to the start
+// of the AAT lookup table t.
+func (f *Font) aatLookupU16(b *Buffer, t table, o uint32, errInvalid error) (uint16, bool, error) {
+	if o > t.length || t.length-o < 2 {
+		return 0, false, errInvalid
+	}
+	buf, err := b.view(&f.src, int(t.offset+o), 2)
+	if err != nil {
+		return 0, false, err
+	}
+	return u16(buf), true, nil
+}
+
+// These constants are not part of the specifications, but are limitations used
+// by this implementation.
+const (
+	maxTrakTracks = 64
+	maxTrakSizes  = 64
+)
+
+// Track is one of a trak table's tracks.
+type Track struct {
+	// Value is the track's value. The normal track is 0. Negative values,
+	// conventionally -1, are tighter and positive values, conventionally 1,
+	// are looser.
+	Value float64
+	// NameID is the name table entry for the track's name, such as "Tight".
+	NameID NameID
+}
+
+// trakInfo holds the trak table's horizontal tracking data.
+type trakInfo struct {
+	tracks []Track
+	// sizes are the point sizes, as 16.16 fixed point numbers, that the
+	// tracking values are given for.
+	sizes []int32
+	// values holds the tracking values, in font units, with one row of
+	// len(sizes) values for each track.
+	values []int16
+}
+
+func (f *Font) parseTrak(buf []byte) ([]byte, error) {
+	// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6trak.html
+
+	if f.trak.length == 0 {
+		return buf, nil
+	}
+	const headerSize, trackDataHeaderSize, entrySize = 12, 8, 8
+	if f.trak.length < headerSize {
+		return nil, errInvalidTrakTable
+	}
+	buf, err := f.src.view(buf, int(f.trak.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if version, format := u32(buf), u16(buf[4:]); version != 0x00010000 || format != 0 {
+		return nil, errUnsupportedTrakTable
+	}
+	// Vertical tracking, at the offset in buf[8:], is not supported.
+	horizOffset := uint32(u16(buf[6:]))
+	if horizOffset == 0 {
+		return buf, nil
+	}
+	if horizOffset > f.trak.length || f.trak.length-horizOffset < trackDataHeaderSize {
+		return nil, errInvalidTrakTable
+	}
+	buf, err = f.src.view(buf, int(f.trak.offset+horizOffset), trackDataHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+	nTracks := uint32(u16(buf))
+	nSizes := uint32(u16(buf[2:]))
+	sizeTableOffset := u32(buf[4:])
+	if nTracks == 0 || nSizes == 0 {
+		return buf, nil
+	}
+	if nTracks > maxTrakTracks || nSizes > maxTrakSizes {
+		return nil, errUnsupportedTrakTable
+	}
+	if sizeTableOffset > f.trak.length || (f.trak.length-sizeTableOffset)/4 < nSizes {
+		return nil, errInvalidTrakTable
+	}
+
+	t := trakInfo{
+		tracks: make([]Track, nTracks),
+		sizes:  make([]int32, nSizes),
+		values: make([]int16, nTracks*nSizes),
+	}
+	buf, err = f.src.view(buf, int(f.trak.offset+sizeTableOffset), int(4*nSizes))
+	if err != nil {
+		return nil, err
+	}
+	for i := range t.sizes {
+		t.sizes[i] = int32(u32(buf[4*i:]))
+		if i > 0 && t.sizes[i] <= t.sizes[i-1] {
+			return nil, errInvalidTrakTable
+		}
+	}
+
+	entries := horizOffset + trackDataHeaderSize
+	if (f.trak.length-entries)/entrySize < nTracks {
+		return nil, errInvalidTrakTable
+	}
+	for i := range t.tracks {
+		buf, err = f.src.view(buf, int(f.trak.offset+entries+entrySize*uint32(i)), entrySize)
+		if err != nil {
+			return nil, err
+		}
+		t.tracks[i] = Track{
+			Value:  float64(int32(u32(buf))) / 0x10000,
+			NameID: NameID(u16(buf[4:])),
+		}
+		if i > 0 && t.tracks[i].Value <= t.tracks[i-1].Value {
+			return nil, errInvalidTrakTable
+		}
+		o := uint32(u16(buf[6:]))
+		if o > f.trak.length || (f.trak.length-o)/2 < nSizes {
+			return nil, errInvalidTrakTable
+		}
+		buf, err = f.src.view(buf, int(f.trak.offset+o), int(2*nSizes))
+		if err != nil {
+			return nil, err
+		}
+		for j := range t.sizes {
+			t.values[uint32(i)*nSizes+uint32(j)] = int16(u16(buf[2*j:]))
+		}
+	}
+	f.cached.trak = t
+	return buf, nil
+}
+
+// Tracks returns the trak table's horizontal tracks, in increasing order of
+// their values. It returns nil if the font has no trak table.
+//
+// The returned slice must not be modified.
+func (f *Font) Tracks(b *Buffer) ([]Track, error) {
+	if err := f.skippedTable("trak"); err != nil {
+		return nil, err
+	}
+	return f.cached.trak.tracks, nil
+}
+
+// Tracking returns the horizontal tracking, also known as letter spacing, to
+// add to every glyph's advance width for the given track and size. A track of
+// 0 is the normal track. ppem is the number of pixels in 1 em, and is also
+// taken to be the point size, as for a 72 DPI display.
+//
+// Font designers typically loosen small sizes and tighten large sizes. The
+// tracking for sizes and tracks between those given by the trak table are
+// linearly interpolated, and those outside that range are clamped.
+//
+// It returns zero if the font has no trak table.
+func (f *Font) Tracking(b *Buffer, track float64, ppem fixed.Int26_6, h font.Hinting) (fixed.Int26_6, error) {
+	if err := f.skippedTable("trak"); err != nil {
+		return 0, err
+	}
+	t := &f.cached.trak
+	if len(t.tracks) == 0 {
+		return 0, nil
+	}
+
+	// Find the two tracks, i and i+1, to interpolate between, and the
+	// weight, wt, of the latter.
+	i, wt := 0, 0.0
+	if n := len(t.tracks); track >= t.tracks[n-1].Value {
+		i = n - 1
+	} else {
+		for ; i < n-1 && track >= t.tracks[i+1].Value; i++ {
+		}
+		if v0 := t.tracks[i].Value; track > v0 {
+			wt = (track - v0) / (t.tracks[i+1].Value - v0)
+		}
+	}
+
+	k := (1-wt)*f.trakValue(i, ppem) + wt*f.trakValue(i+1, ppem)
+	tracking := fixed.Int26_6(k*float64(ppem)/float64(f.cached.unitsPerEm) + 0.5)
+	if k < 0 {
+		tracking = -fixed.Int26_6(-k*float64(ppem)/float64(f.cached.unitsPerEm) + 0.5)
+	}
+	if h == font.HintingFull {
+		// Quantize the fixed.Int26_6 value to the nearest pixel.
+		tracking = (tracking + 32) &^ 63
+	}
+	return tracking, nil
+}
+
+// trakValue returns the i'th track's tracking value, in font units, at the
+// given size. If i is out of range, it returns zero, which is only ever
+// weighted by zero.
+func (f *Font) trakValue(i int, ppem fixed.Int26_6) float64 {
+	t := &f.cached.trak
+	if i >= len(t.tracks) {
+		return 0
+	}
+	values := t.values[i*len(t.sizes) : (i+1)*len(t.sizes)]
+	size := int32(ppem) << 10 // Convert from 26.6 to 16.16 fixed point.
+	if size <= t.sizes[0] {
+		return float64(values[0])
+	}
+	for j := 1; j < len(t.sizes); j++ {
+		if size <= t.sizes[j] {
+			s0, s1 := float64(t.sizes[j-1]), float64(t.sizes[j])
+			wt := (float64(size) - s0) / (s1 - s0)
+			return (1-wt)*float64(values[j-1]) + wt*float64(values[j])
+		}
+	}
+	return float64(values[len(values)-1])
+}
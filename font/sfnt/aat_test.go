@@ -0,0 +1,202 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// be32 returns the big-endian encoding of vs.
+func be32(vs ...uint32) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = appendU32(b, v)
+	}
+	return b
+}
+
+// testKerxTable returns a kerx table, for glyfTest.ttf's 5 glyphs, with format
+// 0 and format 2 subtables, whose values are summed. The state table (format
+// 1) and vertical subtables are skipped.
+func testKerxTable() []byte {
+	return concat(
+		be16(2, 0), be32(4), // version, padding, nTables.
+
+		be32(12, 0x00000001, 0), // format 1.
+
+		be32(40, 0x00000000, 0), // format 0.
+		be32(2, 12, 1, 0),       // nPairs, searchRange, entrySelector, rangeShift.
+		be16(1, 2, -10, 3, 4, -20),
+
+		be32(40, 0x80000000, 0), // vertical format 0.
+		be32(2, 12, 1, 0),
+		be16(1, 2, -99, 2, 2, -99),
+
+		be32(64, 0x00000002, 0),         // format 2.
+		be32(4, 28, 38, 56),             // rowWidth, leftClassTable, rightClassTable, array.
+		be16(8, 1, 2, 0, 4),             // leftClassTable: trimmed array.
+		be16(2, 6, 1, 6, 0, 0, 3, 2, 2), // rightClassTable: segment single.
+		be16(0, 2, 3, 4),                // array.
+	)
+}
+
+func TestKerx(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"kerx": testKerxTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		x0, x1 GlyphIndex
+		want   fixed.Int26_6
+	}{
+		{0, 0, 0},
+		{1, 2, -8},
+		{2, 0, 3},
+		{2, 2, 4},
+		{2, 3, 4},
+		{3, 4, -20},
+		{4, 1, 0},
+	}
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	var b Buffer
+	for _, tc := range testCases {
+		got, err := f.Kern(&b, tc.x0, tc.x1, ppem, font.HintingNone)
+		if err != nil {
+			t.Errorf("Kern(%d, %d): %v", tc.x0, tc.x1, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Kern(%d, %d): got %d, want %d", tc.x0, tc.x1, got, tc.want)
+		}
+	}
+}
+
+// testMorxTable returns a morx table, for glyfTest.ttf's 5 glyphs, with one
+// chain whose non-contextual subtables map glyphs 1 and 2 to glyphs 3 and 4,
+// and glyph 0 to glyph 4. A subtable that is not enabled by default, which
+// maps glyph 3 to glyph 0, and a ligature subtable are not applied.
+func testMorxTable() []byte {
+	return concat(
+		be16(2, 0), be32(1), // version, unused, nChains.
+
+		be32(0x01, 126, 1, 4), // defaultFlags, chainLength, nFeatureEntries, nSubtables.
+		be16(1, 0), be32(0x01, 0xffffffff),
+
+		be32(32, 0x00000004, 0x01),
+		be16(6, 4, 2, 8, 1, 0, 1, 3, 2, 4), // single table.
+
+		be32(22, 0x00000004, 0x02),
+		be16(10, 2, 3, 1, 0), // extended trimmed array.
+
+		be32(32, 0x00000004, 0x01),
+		be16(4, 6, 1, 6, 0, 0, 0, 0, 18, 4), // segment array.
+
+		be32(12, 0x00000002, 0x01), // ligature.
+	)
+}
+
+func TestMorxSubstituteGlyph(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"morx": testMorxTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var b Buffer
+	for x, want := range []GlyphIndex{4, 3, 4, 3, 4} {
+		got, err := f.MorxSubstituteGlyph(&b, GlyphIndex(x))
+		if err != nil {
+			t.Errorf("x=%d: %v", x, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("x=%d: got %d, want %d", x, got, want)
+		}
+	}
+	if _, err := f.MorxSubstituteGlyph(&b, 5); err != ErrNotFound {
+		t.Errorf("out of range: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+// testTrakTable returns a trak table with tight, normal and loose tracks for
+// the sizes 1024 and 2048.
+func testTrakTable() []byte {
+	return concat(
+		be32(0x00010000), be16(0, 12, 0, 0), // version, format, horizOffset, vertOffset, reserved.
+		be16(3, 2), be32(44), // nTracks, nSizes, sizeTableOffset.
+		be32(0xffff0000), be16(256, 52), // tight track.
+		be32(0x00000000), be16(257, 56), // normal track.
+		be32(0x00010000), be16(258, 60), // loose track.
+		be32(1024<<16, 2048<<16), // sizes.
+		be16(-20, -40),
+		be16(10, -10),
+		be16(40, 20),
+	)
+}
+
+func TestTracking(t *testing.T) {
+	f, err := Parse(withTables(t, goregular.TTF, map[string][]byte{
+		"trak": testTrakTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var b Buffer
+	wantTracks := []Track{{-1, 256}, {0, 257}, {1, 258}}
+	if got, err := f.Tracks(&b); err != nil {
+		t.Errorf("Tracks: %v", err)
+	} else if !reflect.DeepEqual(got, wantTracks) {
+		t.Errorf("Tracks: got %v, want %v", got, wantTracks)
+	}
+
+	// Go Regular has 2048 units per em, so the tracking is the value in font
+	// units times ppem / 2048.
+	testCases := []struct {
+		track   float64
+		ppem    int
+		hinting font.Hinting
+		want    fixed.Int26_6
+	}{
+		{0, 1024, font.HintingNone, 10 * 32},
+		{0, 512, font.HintingNone, 10 * 16},
+		{0, 1536, font.HintingNone, 0},
+		{0, 4096, font.HintingNone, -10 * 128},
+		{-1, 2048, font.HintingNone, -40 * 64},
+		{0.5, 1536, font.HintingNone, 15 * 48},
+		{0.5, 1536, font.HintingFull, 11 * 64},
+		{-2, 1024, font.HintingNone, -20 * 32},
+		{2, 1024, font.HintingNone, 40 * 32},
+	}
+	for _, tc := range testCases {
+		got, err := f.Tracking(&b, tc.track, fixed.I(tc.ppem), tc.hinting)
+		if err != nil {
+			t.Errorf("track=%v, ppem=%d, hinting=%v: %v", tc.track, tc.ppem, tc.hinting, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("track=%v, ppem=%d, hinting=%v: got %d, want %d",
+				tc.track, tc.ppem, tc.hinting, got, tc.want)
+		}
+	}
+}
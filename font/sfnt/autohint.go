@@ -0,0 +1,500 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements a lightweight autohinter, for fonts that do not have
+// their own TrueType hinting instructions. It is similar in spirit to, but
+// much simpler than, FreeType's autofitter.
+//
+// For each axis being hinted, the glyph's outline is scanned for edges:
+// straight segments or curve extrema that are (nearly) perpendicular to that
+// axis. Edges on opposite sides of the ink are paired up into stems. Edges in
+// the font's blue zones (the baseline, x-height, cap height, etc.) are snapped
+// to the pixel grid first, then stems are given a whole number of pixels'
+// width and also snapped, as are the remaining lone edges. Finally, every
+// point is moved by interpolating between the original and hinted edge
+// positions, which keeps the outline's shape between the edges.
+
+import (
+	"sort"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// blueZoneRunes are the reference runes used to measure a font's blue zones.
+// The flat rune's extreme (on-curve) y coordinate gives the zone's flat
+// position and the overshoot rune's gives how far round glyphs overshoot it.
+var blueZoneRunes = [...]struct {
+	flat, overshoot rune
+	top             bool
+}{
+	{'H', 'O', true},  // Cap height.
+	{'x', 'o', true},  // x-height.
+	{'d', 'd', true},  // Ascender.
+	{'H', 'O', false}, // Baseline.
+	{'p', 'p', false}, // Descender.
+}
+
+// autohintEdge is a straight segment or curve extremum that is perpendicular
+// to the axis being hinted.
+type autohintEdge struct {
+	// pos is the edge's unhinted position along the hinted axis, and hinted is
+	// its grid-fitted position.
+	pos, hinted fixed.Int26_6
+	// lo and hi are the edge's extent along the other axis.
+	lo, hi fixed.Int26_6
+	// inkAbove is whether the glyph's ink is on the greater coordinate side
+	// of the edge. For a horizontal stem, the bottom edge has ink above it.
+	inkAbove bool
+	done     bool
+}
+
+// autohintStem is a pair of edges with ink between them, as indexes into the
+// autohinter's edges.
+type autohintStem struct {
+	lo, hi int
+	width  fixed.Int26_6
+}
+
+// autohintAnchor maps an unhinted position along an axis to its hinted
+// position.
+type autohintAnchor struct {
+	orig, hinted fixed.Int26_6
+}
+
+type autohinter struct {
+	// font is the Font that zones were measured for.
+	font  *Font
+	zones []BlueZone
+
+	edges []autohintEdge
+	stems []autohintStem
+	// anchors are the hinted positions of the edges, for each axis.
+	anchors [2][]autohintAnchor
+}
+
+// autohinted returns whether LoadGlyph should autohint a glyph with the given
+// hinting policy. Fonts that have their own TrueType hinting instructions are
+// not autohinted.
+func (f *Font) autohinted(h font.Hinting) bool {
+	if h != font.HintingVertical && h != font.HintingFull {
+		return false
+	}
+	return f.cached.isPostScript || (f.fpgm.length == 0 && f.prep.length == 0)
+}
+
+// init measures f's blue zones, if it was not already the most recently
+// measured font. It uses b's segments as scratch space.
+func (a *autohinter) init(b *Buffer, f *Font) error {
+	if a.font == f {
+		return nil
+	}
+	a.font = nil
+	a.zones = a.zones[:0]
+	for _, r := range blueZoneRunes {
+		flat, ok, err := f.glyphExtreme(b, r.flat, r.top)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		overshoot, ok, err := f.glyphExtreme(b, r.overshoot, r.top)
+		if err != nil {
+			return err
+		}
+		if !ok || (r.top && overshoot < flat) || (!r.top && overshoot > flat) {
+			overshoot = flat
+		}
+		a.zones = append(a.zones, BlueZone{
+			Flat:      flat,
+			Overshoot: overshoot,
+			Top:       r.top,
+		})
+	}
+	a.font = f
+	return nil
+}
+
+// glyphExtreme returns the maximum (if top) or minimum y coordinate, in font
+// units, of the on-curve points of r's glyph. ok is false if the font has no
+// glyph, or an empty glyph, for r.
+func (f *Font) glyphExtreme(b *Buffer, r rune, top bool) (y Units, ok bool, err error) {
+	x, err := f.GlyphIndex(b, r)
+	if err != nil || x == 0 {
+		return 0, false, err
+	}
+	// Loading the glyph at a ppem of unitsPerEm gives segments in font units.
+	segments, err := f.LoadGlyph(b, x, fixed.Int26_6(f.cached.unitsPerEm), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, s := range segments {
+		p := segmentEnd(s)
+		if !ok || (top && Units(p.Y) > y) || (!top && Units(p.Y) < y) {
+			y, ok = Units(p.Y), true
+		}
+	}
+	return y, ok, nil
+}
+
+// segmentEnd returns the last point of s.
+func segmentEnd(s Segment) fixed.Point26_6 {
+	n := 0
+	switch s.Op {
+	case SegmentOpQuadTo:
+		n = 2
+	case SegmentOpCubeTo:
+		n = 4
+	}
+	return fixed.Point26_6{X: s.Args[n+0], Y: s.Args[n+1]}
+}
+
+// hint grid-fits the scaled segments in place. The y axis is always hinted.
+// The x axis is hinted if full is true.
+func (a *autohinter) hint(segments []Segment, ppem fixed.Int26_6, full bool) {
+	if len(segments) == 0 {
+		return
+	}
+	ccw := signedArea(segments) > 0
+	a.anchors[0] = a.anchors[0][:0]
+	if full {
+		a.fitAxis(segments, ppem, 0, ccw)
+	}
+	a.fitAxis(segments, ppem, 1, ccw)
+
+	for i := range segments {
+		s := &segments[i]
+		n := 2
+		switch s.Op {
+		case SegmentOpQuadTo:
+			n = 4
+		case SegmentOpCubeTo:
+			n = 6
+		}
+		for j := 0; j < n; j++ {
+			s.Args[j] = interpolate(a.anchors[j&1], s.Args[j])
+		}
+	}
+}
+
+// fitAxis sets the anchors for one axis (0 for x, 1 for y) of the unhinted
+// segments.
+func (a *autohinter) fitAxis(segments []Segment, ppem fixed.Int26_6, axis int, ccw bool) {
+	a.anchors[axis] = a.anchors[axis][:0]
+	a.findEdges(segments, ppem, axis, ccw)
+	if len(a.edges) == 0 {
+		return
+	}
+	a.findStems(ppem)
+
+	if axis == 1 {
+		a.snapToBlueZones(ppem)
+	}
+	for _, s := range a.stems {
+		lo, hi := &a.edges[s.lo], &a.edges[s.hi]
+		w := autohintRound(s.width)
+		if w < 64 {
+			w = 64
+		}
+		switch {
+		case lo.done && hi.done:
+			// No-op.
+		case lo.done:
+			hi.hinted = lo.hinted + w
+		case hi.done:
+			lo.hinted = hi.hinted - w
+		default:
+			lo.hinted = autohintRound((lo.pos+hi.pos)/2 - w/2)
+			hi.hinted = lo.hinted + w
+		}
+		lo.done, hi.done = true, true
+	}
+	for i := range a.edges {
+		if e := &a.edges[i]; !e.done {
+			e.hinted = autohintRound(e.pos)
+		}
+	}
+
+	// Build the monotonic mapping from unhinted to hinted positions. The edges
+	// are already sorted by pos.
+	anchors := a.anchors[axis]
+	for _, e := range a.edges {
+		if n := len(anchors); n > 0 {
+			prev := anchors[n-1]
+			if e.pos == prev.orig {
+				continue
+			}
+			if e.hinted < prev.hinted {
+				e.hinted = prev.hinted
+			}
+		}
+		anchors = append(anchors, autohintAnchor{e.pos, e.hinted})
+	}
+	a.anchors[axis] = anchors
+}
+
+// findEdges sets a.edges to the edges perpendicular to the given axis, sorted
+// by position.
+func (a *autohinter) findEdges(segments []Segment, ppem fixed.Int26_6, axis int, ccw bool) {
+	a.edges = a.edges[:0]
+	// minLength is the minimum length of a straight edge, 1/20th of an em.
+	minLength := ppem / 20
+	if minLength < 1 {
+		minLength = 1
+	}
+
+	var start, cur [2]fixed.Int26_6
+	for i, s := range segments {
+		switch s.Op {
+		case SegmentOpMoveTo:
+			if i > 0 {
+				a.addLine(cur, start, axis, ccw, minLength)
+			}
+			start = [2]fixed.Int26_6{s.Args[0], s.Args[1]}
+			cur = start
+		case SegmentOpLineTo:
+			p := [2]fixed.Int26_6{s.Args[0], s.Args[1]}
+			a.addLine(cur, p, axis, ccw, minLength)
+			cur = p
+		case SegmentOpQuadTo:
+			c := [2]fixed.Int26_6{s.Args[0], s.Args[1]}
+			p := [2]fixed.Int26_6{s.Args[2], s.Args[3]}
+			a.addCurveEnd(cur, cur, c, axis, ccw)
+			a.addCurveEnd(p, c, p, axis, ccw)
+			cur = p
+		case SegmentOpCubeTo:
+			c0 := [2]fixed.Int26_6{s.Args[0], s.Args[1]}
+			c1 := [2]fixed.Int26_6{s.Args[2], s.Args[3]}
+			p := [2]fixed.Int26_6{s.Args[4], s.Args[5]}
+			a.addCurveEnd(cur, cur, c0, axis, ccw)
+			a.addCurveEnd(p, c1, p, axis, ccw)
+			cur = p
+		}
+	}
+	a.addLine(cur, start, axis, ccw, minLength)
+
+	sort.Slice(a.edges, func(i, j int) bool {
+		ei, ej := &a.edges[i], &a.edges[j]
+		if ei.pos != ej.pos {
+			return ei.pos < ej.pos
+		}
+		return !ei.inkAbove && ej.inkAbove
+	})
+
+	// Merge edges at the same position with ink on the same side, such as
+	// the two curves meeting at the top of an 'o'.
+	merged := a.edges[:0]
+	for _, e := range a.edges {
+		if n := len(merged); n > 0 {
+			if m := &merged[n-1]; m.pos == e.pos && m.inkAbove == e.inkAbove {
+				if m.lo > e.lo {
+					m.lo = e.lo
+				}
+				if m.hi < e.hi {
+					m.hi = e.hi
+				}
+				continue
+			}
+		}
+		merged = append(merged, e)
+	}
+	a.edges = merged
+}
+
+// addLine adds an edge for the line from p to q, if it is long enough and
+// nearly perpendicular to the axis.
+func (a *autohinter) addLine(p, q [2]fixed.Int26_6, axis int, ccw bool, minLength fixed.Int26_6) {
+	other := 1 - axis
+	d := q[other] - p[other]
+	if autohintAbs(d) < minLength || 8*autohintAbs(q[axis]-p[axis]) > autohintAbs(d) {
+		return
+	}
+	a.addEdge((p[axis]+q[axis])/2, p[other], q[other], d, axis, ccw)
+}
+
+// addCurveEnd adds an edge for the curve end point p, if the curve's tangent
+// at p, from t0 to t1, is nearly perpendicular to the axis. Such a point is an
+// extremum of the curve along that axis.
+func (a *autohinter) addCurveEnd(p, t0, t1 [2]fixed.Int26_6, axis int, ccw bool) {
+	other := 1 - axis
+	d := t1[other] - t0[other]
+	if d == 0 || 8*autohintAbs(t1[axis]-t0[axis]) > autohintAbs(d) {
+		return
+	}
+	a.addEdge(p[axis], t0[other], t1[other], d, axis, ccw)
+}
+
+// addEdge adds an edge at pos, extending from lo to hi along the other axis.
+// d is the direction of travel along the other axis, which determines which
+// side of the edge the ink is on.
+func (a *autohinter) addEdge(pos, lo, hi, d fixed.Int26_6, axis int, ccw bool) {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	// For a clockwise contour (in the y-up coordinate system), the ink is on
+	// the right side of the direction of travel.
+	if axis == 1 {
+		d = -d
+	}
+	if ccw {
+		d = -d
+	}
+	a.edges = append(a.edges, autohintEdge{
+		pos:      pos,
+		lo:       lo,
+		hi:       hi,
+		inkAbove: d > 0,
+	})
+}
+
+// findStems sets a.stems to pairs of overlapping edges with ink between them,
+// narrowest first. Each edge is in at most one stem.
+func (a *autohinter) findStems(ppem fixed.Int26_6) {
+	// maxWidth is the maximum width of a stem, 1/4 of an em.
+	maxWidth := ppem / 4
+
+	a.stems = a.stems[:0]
+	for i, lo := range a.edges {
+		if !lo.inkAbove {
+			continue
+		}
+		for j := i + 1; j < len(a.edges); j++ {
+			hi := a.edges[j]
+			if hi.pos-lo.pos > maxWidth {
+				break
+			}
+			if hi.inkAbove || hi.pos == lo.pos || hi.hi < lo.lo || lo.hi < hi.lo {
+				continue
+			}
+			a.stems = append(a.stems, autohintStem{i, j, hi.pos - lo.pos})
+		}
+	}
+	sort.SliceStable(a.stems, func(i, j int) bool {
+		return a.stems[i].width < a.stems[j].width
+	})
+
+	used := make([]bool, len(a.edges))
+	stems := a.stems[:0]
+	for _, s := range a.stems {
+		if used[s.lo] || used[s.hi] {
+			continue
+		}
+		used[s.lo], used[s.hi] = true, true
+		stems = append(stems, s)
+	}
+	a.stems = stems
+}
+
+// snapToBlueZones grid-fits the edges that are in one of the font's blue
+// zones.
+func (a *autohinter) snapToBlueZones(ppem fixed.Int26_6) {
+	upem := a.font.cached.unitsPerEm
+	// tolerance is how far outside a zone an edge can be, 1/40th of an em.
+	tolerance := ppem / 40
+
+	for i := range a.edges {
+		e := &a.edges[i]
+		best, bestDist := -1, fixed.Int26_6(0)
+		for j, z := range a.zones {
+			// A top zone holds edges with ink below them. A bottom zone holds
+			// edges with ink above them.
+			if z.Top == e.inkAbove {
+				continue
+			}
+			flat := scale(fixed.Int26_6(z.Flat)*ppem, upem)
+			overshoot := scale(fixed.Int26_6(z.Overshoot)*ppem, upem)
+			lo, hi := flat, overshoot
+			if !z.Top {
+				lo, hi = hi, lo
+			}
+			if e.pos < lo-tolerance || hi+tolerance < e.pos {
+				continue
+			}
+			if dist := autohintAbs(e.pos - flat); best < 0 || dist < bestDist {
+				best, bestDist = j, dist
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		z := a.zones[best]
+		flat := scale(fixed.Int26_6(z.Flat)*ppem, upem)
+		overshoot := scale(fixed.Int26_6(z.Overshoot)*ppem, upem) - flat
+		e.hinted = autohintRound(flat)
+		// Overshoots of less than half a pixel are suppressed, so that round
+		// and flat glyphs line up at small sizes.
+		if autohintAbs(overshoot) >= 32 && autohintAbs(e.pos-flat) > autohintAbs(overshoot)/2 {
+			e.hinted += autohintRound(overshoot)
+		}
+		e.done = true
+	}
+}
+
+// interpolate maps an unhinted position to a hinted one, interpolating
+// linearly between the anchors, which are sorted by orig, and shifting
+// positions outside of them.
+func interpolate(anchors []autohintAnchor, x fixed.Int26_6) fixed.Int26_6 {
+	n := len(anchors)
+	if n == 0 {
+		return x
+	}
+	i := sort.Search(n, func(i int) bool { return anchors[i].orig >= x })
+	switch {
+	case i == n:
+		return x + anchors[n-1].hinted - anchors[n-1].orig
+	case anchors[i].orig == x:
+		return anchors[i].hinted
+	case i == 0:
+		return x + anchors[0].hinted - anchors[0].orig
+	}
+	a0, a1 := anchors[i-1], anchors[i]
+	return a0.hinted + fixed.Int26_6(mulDiv(int32(x-a0.orig), int32(a1.hinted-a0.hinted), int32(a1.orig-a0.orig)))
+}
+
+// signedArea returns twice the signed area enclosed by the segments' control
+// polygons. It is positive if the outer contours are counter-clockwise in the
+// y-up coordinate system.
+func signedArea(segments []Segment) int64 {
+	area := int64(0)
+	var start, cur fixed.Point26_6
+	add := func(p fixed.Point26_6) {
+		area += int64(cur.X)*int64(p.Y) - int64(p.X)*int64(cur.Y)
+		cur = p
+	}
+	for _, s := range segments {
+		switch s.Op {
+		case SegmentOpMoveTo:
+			add(start)
+			start = fixed.Point26_6{X: s.Args[0], Y: s.Args[1]}
+			cur = start
+		case SegmentOpLineTo:
+			add(fixed.Point26_6{X: s.Args[0], Y: s.Args[1]})
+		case SegmentOpQuadTo:
+			add(fixed.Point26_6{X: s.Args[0], Y: s.Args[1]})
+			add(fixed.Point26_6{X: s.Args[2], Y: s.Args[3]})
+		case SegmentOpCubeTo:
+			add(fixed.Point26_6{X: s.Args[0], Y: s.Args[1]})
+			add(fixed.Point26_6{X: s.Args[2], Y: s.Args[3]})
+			add(fixed.Point26_6{X: s.Args[4], Y: s.Args[5]})
+		}
+	}
+	add(start)
+	return area
+}
+
+// autohintRound rounds x to the nearest whole pixel.
+func autohintRound(x fixed.Int26_6) fixed.Int26_6 {
+	return (x + 32) &^ 63
+}
+
+func autohintAbs(x fixed.Int26_6) fixed.Int26_6 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
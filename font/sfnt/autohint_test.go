@@ -0,0 +1,123 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// unhintedGoRegular returns the Go Regular font with its TrueType hinting
+// instructions ignored, so that it is autohinted.
+func unhintedGoRegular(t *testing.T) *Font {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	f.fpgm.length, f.prep.length = 0, 0
+	return f
+}
+
+func TestAutohintStraightEdges(t *testing.T) {
+	fonts := []*Font{unhintedGoRegular(t)}
+	for _, name := range []string{"CFFTest.otf", "glyfTest.ttf"} {
+		data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/" + name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse: %v", name, err)
+		}
+		fonts = append(fonts, f)
+	}
+
+	b := &Buffer{}
+	for i, f := range fonts {
+		for x := GlyphIndex(0); int(x) < f.NumGlyphs() && x < 200; x++ {
+			for _, ppem := range []fixed.Int26_6{fixed.I(9), fixed.I(12), fixed.I(17), 20*64 + 32} {
+				want, err := f.LoadGlyph(nil, x, ppem, nil)
+				if err != nil {
+					t.Fatalf("font #%d, x=%d: LoadGlyph: %v", i, x, err)
+				}
+				for _, h := range []font.Hinting{font.HintingVertical, font.HintingFull} {
+					got, err := f.LoadGlyph(b, x, ppem, &LoadGlyphOptions{Hinting: h})
+					if err != nil {
+						t.Fatalf("font #%d, x=%d: LoadGlyph: %v", i, x, err)
+					}
+					if len(got) != len(want) {
+						t.Fatalf("font #%d, x=%d: got %d segments, want %d", i, x, len(got), len(want))
+					}
+					checkAutohintedEdges(t, got, want, ppem, h == font.HintingFull)
+				}
+			}
+		}
+	}
+}
+
+// checkAutohintedEdges checks that the long, exactly horizontal (and, if
+// full, vertical) lines in the unhinted segments are on the pixel grid in the
+// hinted segments. If not full, the x coordinates should be unchanged.
+func checkAutohintedEdges(t *testing.T, hinted, unhinted []Segment, ppem fixed.Int26_6, full bool) {
+	t.Helper()
+	var p, q fixed.Point26_6
+	for i, s := range unhinted {
+		p, q = q, segmentEnd(s)
+		if s.Op != SegmentOpLineTo {
+			continue
+		}
+		h := segmentEnd(hinted[i])
+		if p.Y == q.Y && autohintAbs(q.X-p.X) >= ppem/20 && h.Y&63 != 0 {
+			t.Errorf("segment #%d: horizontal edge at y=%v was hinted to %v", i, q.Y, h.Y)
+		}
+		if full && p.X == q.X && autohintAbs(q.Y-p.Y) >= ppem/20 && h.X&63 != 0 {
+			t.Errorf("segment #%d: vertical edge at x=%v was hinted to %v", i, q.X, h.X)
+		}
+		if !full && h.X != q.X {
+			t.Errorf("segment #%d: x coordinate %v was hinted to %v", i, q.X, h.X)
+		}
+	}
+}
+
+func TestAutohintBlueZones(t *testing.T) {
+	f := unhintedGoRegular(t)
+	b := &Buffer{}
+	for _, ppem := range []fixed.Int26_6{fixed.I(9), fixed.I(12), fixed.I(16)} {
+		var tops [2]fixed.Int26_6
+		for i, r := range "xo" {
+			x, err := f.GlyphIndex(b, r)
+			if err != nil {
+				t.Fatalf("GlyphIndex: %v", err)
+			}
+			segments, err := f.LoadGlyph(b, x, ppem, &LoadGlyphOptions{Hinting: font.HintingVertical})
+			if err != nil {
+				t.Fatalf("LoadGlyph: %v", err)
+			}
+			bottom := fixed.Int26_6(0)
+			for j, s := range segments {
+				p := segmentEnd(s)
+				if j == 0 || p.Y > tops[i] {
+					tops[i] = p.Y
+				}
+				if j == 0 || p.Y < bottom {
+					bottom = p.Y
+				}
+			}
+			if bottom != 0 {
+				t.Errorf("ppem=%v, r=%q: bottom: got %v, want 0", ppem, r, bottom)
+			}
+		}
+		// At these sizes, the overshoot of 'o' is less than half a pixel, so
+		// it should be suppressed.
+		if tops[0] != tops[1] || tops[0]&63 != 0 {
+			t.Errorf("ppem=%v: x-height: got %v for 'x' and %v for 'o', want equal whole pixels", ppem, tops[0], tops[1])
+		}
+	}
+}
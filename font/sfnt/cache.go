@@ -0,0 +1,180 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"container/list"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// CachedFont is a Font with a size-bounded cache of glyph indexes, advances
+// and glyph outlines, which can be shared by multiple goroutines.
+//
+// Unlike the Font methods, the CachedFont methods don't take a *Buffer
+// argument, and it is safe to call them concurrently.
+type CachedFont struct {
+	f    *Font
+	pool sync.Pool
+
+	mu       sync.Mutex
+	maxItems int
+	items    map[cacheKey]*list.Element
+	lru      list.List
+}
+
+// cacheKind is the kind of value in a CachedFont's cache.
+type cacheKind uint8
+
+const (
+	cacheKindGlyphIndex cacheKind = iota
+	cacheKindAdvance
+	cacheKindSegments
+)
+
+// cacheKey is the key of a cached value. For cacheKindGlyphIndex, x is the
+// rune. For cacheKindSegments, the segments are in font units, so ppem and
+// hinting are zero.
+type cacheKey struct {
+	kind    cacheKind
+	x       uint32
+	ppem    fixed.Int26_6
+	hinting font.Hinting
+}
+
+// cacheItem is an element of a CachedFont's lru list.
+type cacheItem struct {
+	key   cacheKey
+	value interface{}
+}
+
+// NewCachedFont returns a CachedFont for f that holds up to maxItems cached
+// values, evicting the least recently used values first. Each glyph index,
+// advance and outline counts as one item. A non-positive maxItems means a
+// default of 1024.
+func NewCachedFont(f *Font, maxItems int) *CachedFont {
+	if maxItems <= 0 {
+		maxItems = 1024
+	}
+	c := &CachedFont{
+		f:        f,
+		maxItems: maxItems,
+		items:    map[cacheKey]*list.Element{},
+	}
+	c.pool.New = func() interface{} { return &Buffer{} }
+	return c
+}
+
+// Font returns the underlying Font.
+func (c *CachedFont) Font() *Font { return c.f }
+
+func (c *CachedFont) get(k cacheKey) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[k]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*cacheItem).value, true
+}
+
+func (c *CachedFont) put(k cacheKey, v interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[k]; ok {
+		// Another goroutine added the same value concurrently.
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.items[k] = c.lru.PushFront(&cacheItem{k, v})
+	for c.lru.Len() > c.maxItems {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.items, e.Value.(*cacheItem).key)
+	}
+}
+
+// GlyphIndex is like Font.GlyphIndex.
+func (c *CachedFont) GlyphIndex(r rune) (GlyphIndex, error) {
+	k := cacheKey{kind: cacheKindGlyphIndex, x: uint32(r)}
+	if v, ok := c.get(k); ok {
+		return v.(GlyphIndex), nil
+	}
+	b := c.pool.Get().(*Buffer)
+	x, err := c.f.GlyphIndex(b, r)
+	c.pool.Put(b)
+	if err != nil {
+		return 0, err
+	}
+	c.put(k, x)
+	return x, nil
+}
+
+// GlyphAdvance is like Font.GlyphAdvance.
+func (c *CachedFont) GlyphAdvance(x GlyphIndex, ppem fixed.Int26_6, h font.Hinting) (fixed.Int26_6, error) {
+	k := cacheKey{kind: cacheKindAdvance, x: uint32(x), ppem: ppem, hinting: h}
+	if v, ok := c.get(k); ok {
+		return v.(fixed.Int26_6), nil
+	}
+	b := c.pool.Get().(*Buffer)
+	advance, err := c.f.GlyphAdvance(b, x, ppem, h)
+	c.pool.Put(b)
+	if err != nil {
+		return 0, err
+	}
+	c.put(k, advance)
+	return advance, nil
+}
+
+// LoadGlyph is like Font.LoadGlyph, except that the returned segments are
+// not invalidated by later calls, and the caller may modify them.
+//
+// Unhinted outlines are cached in font units and scaled on each call, so that
+// they are shared by all ppem values. Hinted outlines, and outlines with
+// overlaps removed, are not cached.
+func (c *CachedFont) LoadGlyph(x GlyphIndex, ppem fixed.Int26_6, opts *LoadGlyphOptions) ([]Segment, error) {
+	if opts != nil && (opts.Hinting != font.HintingNone || opts.RemoveOverlaps) {
+		b := c.pool.Get().(*Buffer)
+		segments, err := c.f.LoadGlyph(b, x, ppem, opts)
+		if err == nil {
+			segments = append([]Segment(nil), segments...)
+		}
+		c.pool.Put(b)
+		return segments, err
+	}
+
+	k := cacheKey{kind: cacheKindSegments, x: uint32(x)}
+	v, ok := c.get(k)
+	if !ok {
+		b := c.pool.Get().(*Buffer)
+		// A ppem of unitsPerEm 26.6 fixed point units gives coordinates in
+		// font units.
+		segments, err := c.f.LoadGlyph(b, x, fixed.Int26_6(c.f.cached.unitsPerEm), nil)
+		if err == nil {
+			segments = append([]Segment(nil), segments...)
+		}
+		c.pool.Put(b)
+		if err != nil {
+			return nil, err
+		}
+		c.put(k, segments)
+		v = segments
+	}
+
+	segments := append([]Segment(nil), v.([]Segment)...)
+	for i := range segments {
+		s := &segments[i]
+		for j := range s.Args {
+			s.Args[j] = scale(s.Args[j]*ppem, c.f.cached.unitsPerEm)
+		}
+	}
+	if opts != nil && opts.Transform != nil {
+		transformSegments(segments, opts.Transform)
+	}
+	return segments, nil
+}
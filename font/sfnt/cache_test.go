@@ -0,0 +1,103 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"sync"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/f64"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestCachedFont(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	c := NewCachedFont(f, 16)
+	var b Buffer
+
+	for _, ppem := range []fixed.Int26_6{fixed.I(12), fixed.I(13) + 20, fixed.I(100)} {
+		for _, r := range "Hello, world" {
+			want, err := f.GlyphIndex(&b, r)
+			if err != nil {
+				t.Fatalf("GlyphIndex(%q): %v", r, err)
+			}
+			x, err := c.GlyphIndex(r)
+			if err != nil || x != want {
+				t.Fatalf("CachedFont.GlyphIndex(%q): got %d, %v, want %d", r, x, err, want)
+			}
+
+			wantAdvance, err := f.GlyphAdvance(&b, x, ppem, font.HintingFull)
+			if err != nil {
+				t.Fatalf("GlyphAdvance(%d): %v", x, err)
+			}
+			advance, err := c.GlyphAdvance(x, ppem, font.HintingFull)
+			if err != nil || advance != wantAdvance {
+				t.Errorf("CachedFont.GlyphAdvance(%d, %v): got %v, %v, want %v", x, ppem, advance, err, wantAdvance)
+			}
+
+			for _, opts := range []*LoadGlyphOptions{
+				nil,
+				{Transform: &f64.Aff3{1, 0.5, 0, 0, 1, 0}},
+				{Hinting: font.HintingFull},
+			} {
+				want, err := f.LoadGlyph(&b, x, ppem, opts)
+				if err != nil {
+					t.Fatalf("LoadGlyph(%d): %v", x, err)
+				}
+				got, err := c.LoadGlyph(x, ppem, opts)
+				if err != nil {
+					t.Errorf("CachedFont.LoadGlyph(%d): %v", x, err)
+					continue
+				}
+				if err := checkSegmentsEqual(got, want); err != nil {
+					t.Errorf("CachedFont.LoadGlyph(%d, %v, %v): %v", x, ppem, opts, err)
+				}
+			}
+		}
+	}
+
+	if n := c.lru.Len(); n != 16 || len(c.items) != 16 {
+		t.Errorf("cache size: got %d, %d, want 16", n, len(c.items))
+	}
+	if _, err := c.LoadGlyph(GlyphIndex(f.NumGlyphs()), fixed.I(12), nil); err != ErrNotFound {
+		t.Errorf("out of range glyph: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestCachedFontConcurrency(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	c := NewCachedFont(f, 8)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range "The quick brown fox jumps over the lazy dog" {
+				x, err := c.GlyphIndex(r)
+				if err != nil {
+					t.Errorf("GlyphIndex(%q): %v", r, err)
+					return
+				}
+				if _, err := c.GlyphAdvance(x, fixed.I(12), font.HintingNone); err != nil {
+					t.Errorf("GlyphAdvance(%d): %v", x, err)
+					return
+				}
+				if _, err := c.LoadGlyph(x, fixed.I(12), nil); err != nil {
+					t.Errorf("LoadGlyph(%d): %v", x, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
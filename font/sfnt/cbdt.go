@@ -0,0 +1,350 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"golang.org/x/image/math/fixed"
+)
+
+// This file implements the CBDT (Color Bitmap Data) and CBLC (Color Bitmap
+// Location) tables, as described at
+// https://www.microsoft.com/typography/otspec/cbdt.htm and
+// https://www.microsoft.com/typography/otspec/cblc.htm
+
+// BitmapStrike is a set of embedded bitmap glyphs designed for one size.
+type BitmapStrike struct {
+	// PPEMX and PPEMY are the strike's horizontal and vertical number of
+	// pixels per em.
+	PPEMX, PPEMY int
+	// BitDepth is the number of bits per pixel. It is 32 for color bitmaps.
+	BitDepth int
+	// Ascender and Descender are the strike's horizontal line metrics, in
+	// pixels. Descender is typically negative.
+	Ascender, Descender int
+	// MaxWidth is the maximum pixel width of the strike's glyphs.
+	MaxWidth int
+	// FirstGlyph and LastGlyph are the lowest and highest glyph indexes in the
+	// strike. The strike may not hold every glyph in that range.
+	FirstGlyph, LastGlyph GlyphIndex
+}
+
+// cblcStrike is a parsed CBLC table BitmapSize record.
+type cblcStrike struct {
+	BitmapStrike
+	indexSubTables    uint32
+	numIndexSubTables uint32
+}
+
+// cbdtLocation is the location of a glyph's image in the CBDT table.
+type cbdtLocation struct {
+	imageFormat uint16
+	// offset and length are relative to the start of the CBDT table.
+	offset, length uint32
+	// bigMetrics holds the glyph's metrics, for index formats that record
+	// them in the CBLC table instead of the CBDT table.
+	bigMetrics    [8]byte
+	hasBigMetrics bool
+}
+
+func (f *Font) parseCBLC(buf []byte) ([]byte, error) {
+	if f.cblc.length == 0 {
+		return buf, nil
+	}
+	if f.cbdt.length < 4 {
+		return nil, errInvalidCBDTTable
+	}
+	const headerSize, strikeSize = 8, 48
+	if f.cblc.length < headerSize {
+		return nil, errInvalidCBLCTable
+	}
+	buf, err := f.src.view(buf, int(f.cblc.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if u16(buf) != 3 {
+		return nil, errUnsupportedCBLCTable
+	}
+	numStrikes := u32(buf[4:])
+	if numStrikes > (f.cblc.length-headerSize)/strikeSize {
+		return nil, errInvalidCBLCTable
+	}
+	buf, err = f.src.view(buf, int(f.cblc.offset)+headerSize, strikeSize*int(numStrikes))
+	if err != nil {
+		return nil, err
+	}
+	strikes := make([]cblcStrike, numStrikes)
+	for i := range strikes {
+		p := buf[strikeSize*i:]
+		s := cblcStrike{
+			BitmapStrike: BitmapStrike{
+				PPEMX:      int(p[44]),
+				PPEMY:      int(p[45]),
+				BitDepth:   int(p[46]),
+				Ascender:   int(int8(p[16])),
+				Descender:  int(int8(p[17])),
+				MaxWidth:   int(p[18]),
+				FirstGlyph: GlyphIndex(u16(p[40:])),
+				LastGlyph:  GlyphIndex(u16(p[42:])),
+			},
+			indexSubTables:    u32(p),
+			numIndexSubTables: u32(p[8:]),
+		}
+		const entrySize = 8
+		if s.indexSubTables > f.cblc.length ||
+			s.numIndexSubTables > (f.cblc.length-s.indexSubTables)/entrySize {
+			return nil, errInvalidCBLCTable
+		}
+		strikes[i] = s
+	}
+	f.cached.cblcStrikes = strikes
+	return buf, nil
+}
+
+// CBDTStrikes returns the strikes of the CBLC table.
+func (f *Font) CBDTStrikes() []BitmapStrike {
+	if len(f.cached.cblcStrikes) == 0 {
+		return nil
+	}
+	ret := make([]BitmapStrike, len(f.cached.cblcStrikes))
+	for i, s := range f.cached.cblcStrikes {
+		ret[i] = s.BitmapStrike
+	}
+	return ret
+}
+
+// CBDTGlyph returns the glyph x's color bitmap image from the CBDT table, from
+// the strike that best matches ppem, the number of pixels in 1 em. The best
+// match is the smallest strike at least as large as ppem, if there is one,
+// and the largest strike otherwise. Strikes that have no image for x are
+// ignored.
+//
+// The image data is PNG encoded. It returns nil if x has no CBDT image. Such
+// a glyph should be drawn from its outline, if any.
+//
+// If b is non-nil, the image data becomes invalid to use once b is re-used.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) CBDTGlyph(b *Buffer, x GlyphIndex, ppem fixed.Int26_6) (*BitmapGlyph, error) {
+	if err := f.skippedTable("CBLC"); err != nil {
+		return nil, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return nil, ErrNotFound
+	}
+	if len(f.cached.cblcStrikes) == 0 {
+		return nil, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	var (
+		best     cbdtLocation
+		bestPPEM = -1
+	)
+	for i := range f.cached.cblcStrikes {
+		s := &f.cached.cblcStrikes[i]
+		if x < s.FirstGlyph || s.LastGlyph < x || !betterStrike(bestPPEM, s.PPEMY, ppem) {
+			continue
+		}
+		loc, ok, err := f.cblcLocate(b, s, x)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			best, bestPPEM = loc, s.PPEMY
+		}
+	}
+	if bestPPEM < 0 {
+		return nil, nil
+	}
+
+	// Each image format has a different header, before the PNG data's length
+	// and the PNG data itself.
+	var headerSize uint32
+	switch best.imageFormat {
+	case 17:
+		headerSize = 5 + 4
+	case 18:
+		headerSize = 8 + 4
+	case 19:
+		if !best.hasBigMetrics {
+			return nil, errInvalidCBLCTable
+		}
+		headerSize = 4
+	default:
+		return nil, errUnsupportedCBDTTable
+	}
+	if best.offset > f.cbdt.length || best.length > f.cbdt.length-best.offset || best.length < headerSize {
+		return nil, errInvalidCBDTTable
+	}
+	buf, err := b.view(&f.src, int(f.cbdt.offset+best.offset), int(best.length))
+	if err != nil {
+		return nil, err
+	}
+	n := u32(buf[headerSize-4:])
+	if n > best.length-headerSize {
+		return nil, errInvalidCBDTTable
+	}
+	buf = buf[:headerSize+n]
+
+	g := &BitmapGlyph{
+		Format: tagPNG,
+		Data:   buf[headerSize:],
+		PPEM:   bestPPEM,
+	}
+	// Both small and big glyph metrics start with the height, width,
+	// horizontal bearings and horizontal advance. The bearings are the offset
+	// of the image's top left corner from the glyph origin.
+	m := buf
+	if best.imageFormat == 19 {
+		m = best.bigMetrics[:]
+	}
+	g.Height = int(m[0])
+	g.Width = int(m[1])
+	g.OriginX = int(int8(m[2]))
+	g.OriginY = int(int8(m[3])) - g.Height
+	g.Advance = int(m[4])
+	return g, nil
+}
+
+var tagPNG = MustParseTag("png")
+
+// cblcLocate returns the location of x's image in the CBDT table for the
+// strike s, and whether the strike has an image for x.
+func (f *Font) cblcLocate(b *Buffer, s *cblcStrike, x GlyphIndex) (cbdtLocation, bool, error) {
+	const entrySize = 8
+	for i := uint32(0); i < s.numIndexSubTables; i++ {
+		buf, err := b.view(&f.src, int(f.cblc.offset+s.indexSubTables+entrySize*i), entrySize)
+		if err != nil {
+			return cbdtLocation{}, false, err
+		}
+		first, last := GlyphIndex(u16(buf)), GlyphIndex(u16(buf[2:]))
+		if x < first || last < x {
+			continue
+		}
+		o := s.indexSubTables + u32(buf[4:])
+		return f.cblcLocateInSubTable(b, o, first, x)
+	}
+	return cbdtLocation{}, false, nil
+}
+
+// cblcLocateInSubTable is like cblcLocate, for the IndexSubTable at the
+// offset o, relative to the start of the CBLC table, whose first glyph is
+// first.
+func (f *Font) cblcLocateInSubTable(b *Buffer, o uint32, first, x GlyphIndex) (cbdtLocation, bool, error) {
+	const headerSize = 8
+	view := func(o, n uint32) ([]byte, error) {
+		if o > f.cblc.length || n > f.cblc.length-o {
+			return nil, errInvalidCBLCTable
+		}
+		return b.view(&f.src, int(f.cblc.offset+o), int(n))
+	}
+	buf, err := view(o, headerSize)
+	if err != nil {
+		return cbdtLocation{}, false, err
+	}
+	indexFormat := u16(buf)
+	loc := cbdtLocation{imageFormat: u16(buf[2:])}
+	imageData := u32(buf[4:])
+	o += headerSize
+
+	var lo, hi uint32
+	switch indexFormat {
+	case 1, 3:
+		// Per-glyph offsets, as 32-bit or 16-bit values.
+		size := uint32(4)
+		if indexFormat == 3 {
+			size = 2
+		}
+		buf, err := view(o+size*uint32(x-first), 2*size)
+		if err != nil {
+			return cbdtLocation{}, false, err
+		}
+		if size == 4 {
+			lo, hi = u32(buf), u32(buf[4:])
+		} else {
+			lo, hi = uint32(u16(buf)), uint32(u16(buf[2:]))
+		}
+
+	case 2:
+		// Constant image size and metrics.
+		buf, err := view(o, 12)
+		if err != nil {
+			return cbdtLocation{}, false, err
+		}
+		size := u32(buf)
+		copy(loc.bigMetrics[:], buf[4:])
+		loc.hasBigMetrics = true
+		lo = size * uint32(x-first)
+		hi = lo + size
+
+	case 4:
+		// Sparse glyph indexes, with per-glyph offsets.
+		buf, err := view(o, 4)
+		if err != nil {
+			return cbdtLocation{}, false, err
+		}
+		n := u32(buf)
+		const pairSize = 4
+		for i, j := uint32(0), n; i < j; {
+			h := (i + j) / 2
+			buf, err := view(o+4+pairSize*h, 2*pairSize)
+			if err != nil {
+				return cbdtLocation{}, false, err
+			}
+			if g := GlyphIndex(u16(buf)); x < g {
+				j = h
+			} else if x > g {
+				i = h + 1
+			} else {
+				lo, hi = uint32(u16(buf[2:])), uint32(u16(buf[6:]))
+				break
+			}
+		}
+
+	case 5:
+		// Sparse glyph indexes, with constant image size and metrics.
+		buf, err := view(o, 16)
+		if err != nil {
+			return cbdtLocation{}, false, err
+		}
+		size, n := u32(buf), u32(buf[12:])
+		copy(loc.bigMetrics[:], buf[4:])
+		loc.hasBigMetrics = true
+		for i, j := uint32(0), n; i < j; {
+			h := (i + j) / 2
+			buf, err := view(o+16+2*h, 2)
+			if err != nil {
+				return cbdtLocation{}, false, err
+			}
+			if g := GlyphIndex(u16(buf)); x < g {
+				j = h
+			} else if x > g {
+				i = h + 1
+			} else {
+				lo = size * h
+				hi = lo + size
+				break
+			}
+		}
+
+	default:
+		return cbdtLocation{}, false, errUnsupportedCBLCTable
+	}
+
+	if lo > hi {
+		return cbdtLocation{}, false, errInvalidCBLCTable
+	}
+	if lo == hi {
+		return cbdtLocation{}, false, nil
+	}
+	loc.offset = imageData + lo
+	loc.length = hi - lo
+	if loc.offset < imageData {
+		return cbdtLocation{}, false, errInvalidCBLCTable
+	}
+	return loc, true, nil
+}
@@ -5,9 +5,15 @@
 package sfnt
 
 import (
+	"unicode/utf16"
 	"unicode/utf8"
 
+	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
 )
 
 // Platform IDs and Platform Specific IDs as per
@@ -17,17 +23,26 @@ const (
 	pidMacintosh = 1
 	pidWindows   = 3
 
-	psidUnicode2BMPOnly        = 3
-	psidUnicode2FullRepertoire = 4
+	psidUnicode2BMPOnly               = 3
+	psidUnicode2FullRepertoire        = 4
+	psidUnicodeFullRepertoireFormat13 = 6
 	// Note that FontForge may generate a bogus Platform Specific ID (value 10)
 	// for the Unicode Platform ID (value 0). See
 	// https://github.com/fontforge/fontforge/issues/2728
 
-	psidMacintoshRoman = 0
+	psidMacintoshRoman              = 0
+	psidMacintoshJapanese           = 1
+	psidMacintoshChineseTraditional = 2
+	psidMacintoshKorean             = 3
+	psidMacintoshChineseSimplified  = 25
 
-	psidWindowsSymbol = 0
-	psidWindowsUCS2   = 1
-	psidWindowsUCS4   = 10
+	psidWindowsSymbol   = 0
+	psidWindowsUCS2     = 1
+	psidWindowsShiftJIS = 2
+	psidWindowsPRC      = 3
+	psidWindowsBig5     = 4
+	psidWindowsWansung  = 5
+	psidWindowsUCS4     = 10
 )
 
 // platformEncodingWidth returns the number of bytes per character assumed by
@@ -44,6 +59,9 @@ const (
 // the legacy encodings if e.g. their repertoire is limited to the BMP, for
 // greater compatibility with older software, or because the resultant file
 // size can be smaller.
+//
+// Legacy CJK fonts may use a multi-byte encoding such as Shift JIS, which
+// takes up to 2 bytes per character. See legacyCmapEncoding.
 func platformEncodingWidth(pid, psid uint16) int {
 	switch pid {
 	case pidUnicode:
@@ -52,6 +70,8 @@ func platformEncodingWidth(pid, psid uint16) int {
 			return 2
 		case psidUnicode2FullRepertoire:
 			return 4
+		case psidUnicodeFullRepertoireFormat13:
+			return 4
 		}
 
 	case pidMacintosh:
@@ -70,9 +90,47 @@ func platformEncodingWidth(pid, psid uint16) int {
 			return 4
 		}
 	}
+	if legacyCmapEncoding(pid, psid) != nil {
+		return 2
+	}
 	return 0
 }
 
+// legacyCmapEncoding returns the multi-byte encoding used by the given
+// Platform ID and Platform Specific ID, or nil if it is not a supported legacy
+// CJK encoding.
+//
+// Such encodings are only supported for cmap format 2, as that format is
+// designed for them. Runes are first converted to that encoding's bytes.
+func legacyCmapEncoding(pid, psid uint16) encoding.Encoding {
+	switch pid {
+	case pidMacintosh:
+		switch psid {
+		case psidMacintoshJapanese:
+			return japanese.ShiftJIS
+		case psidMacintoshChineseTraditional:
+			return traditionalchinese.Big5
+		case psidMacintoshKorean:
+			return korean.EUCKR
+		case psidMacintoshChineseSimplified:
+			return simplifiedchinese.GBK
+		}
+
+	case pidWindows:
+		switch psid {
+		case psidWindowsShiftJIS:
+			return japanese.ShiftJIS
+		case psidWindowsPRC:
+			return simplifiedchinese.GBK
+		case psidWindowsBig5:
+			return traditionalchinese.Big5
+		case psidWindowsWansung:
+			return korean.EUCKR
+		}
+	}
+	return nil
+}
+
 // The various cmap formats are described at
 // https://www.microsoft.com/typography/otspec/cmap.htm
 
@@ -80,22 +138,26 @@ var supportedCmapFormat = func(format, pid, psid uint16) bool {
 	switch format {
 	case 0:
 		return pid == pidMacintosh && psid == psidMacintoshRoman
-	case 4:
-		return true
-	case 12:
-		return true
+	case 2:
+		return legacyCmapEncoding(pid, psid) != nil
+	case 4, 8, 10, 12, 13:
+		return legacyCmapEncoding(pid, psid) == nil
 	}
 	return false
 }
 
-func (f *Font) makeCachedGlyphIndex(buf []byte, offset, length uint32, format uint16) ([]byte, error) {
+func (f *Font) makeCachedGlyphIndex(buf []byte, offset, length uint32, format, pid, psid uint16) ([]byte, error) {
 	switch format {
 	case 0:
 		return f.makeCachedGlyphIndexFormat0(buf, offset, length)
+	case 2:
+		return f.makeCachedGlyphIndexFormat2(buf, offset, length, legacyCmapEncoding(pid, psid))
 	case 4:
 		return f.makeCachedGlyphIndexFormat4(buf, offset, length)
-	case 12:
-		return f.makeCachedGlyphIndexFormat12(buf, offset, length)
+	case 10:
+		return f.makeCachedGlyphIndexFormat10(buf, offset, length)
+	case 8, 12, 13:
+		return f.makeCachedGlyphIndexFormat12(buf, offset, length, format)
 	}
 	panic("unreachable")
 }
@@ -130,6 +192,92 @@ func (f *Font) makeCachedGlyphIndexFormat0(buf []byte, offset, length uint32) ([
 	return buf, nil
 }
 
+func (f *Font) makeCachedGlyphIndexFormat2(buf []byte, offset, length uint32, enc encoding.Encoding) ([]byte, error) {
+	const headerSize = 6 + 2*256
+	if length < headerSize || offset+length > f.cmap.length {
+		return nil, errInvalidCmapTable
+	}
+	var err error
+	buf, err = f.src.view(buf, int(f.cmap.offset+offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each subHeaderKeys entry is 8 times an index into the subHeaders.
+	var keys [256]uint16
+	numSubHeaders := uint16(0)
+	for i := range keys {
+		keys[i] = u16(buf[6+2*i:]) / 8
+		if numSubHeaders <= keys[i] {
+			numSubHeaders = keys[i] + 1
+		}
+	}
+	offset += headerSize
+
+	eLength := 8 * uint32(numSubHeaders)
+	if headerSize+eLength > length {
+		return nil, errInvalidCmapTable
+	}
+	buf, err = f.src.view(buf, int(f.cmap.offset+offset), int(eLength))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cmapSubHeader, numSubHeaders)
+	for i := range entries {
+		// The idRangeOffset is relative to the idRangeOffset field itself.
+		entries[i] = cmapSubHeader{
+			firstCode:  u16(buf[8*i+0:]),
+			entryCount: u16(buf[8*i+2:]),
+			delta:      u16(buf[8*i+4:]),
+			base:       offset + uint32(8*i+6) + uint32(u16(buf[8*i+6:])),
+		}
+	}
+	// Glyph indexes are looked up only within the subtable.
+	end := offset - headerSize + length
+
+	f.cached.glyphIndex = func(f *Font, b *Buffer, r rune) (GlyphIndex, error) {
+		// As for format 0, the encoder is allocated on every call so that
+		// this closure is goroutine-safe.
+		var dst, src [utf8.UTFMax]byte
+		n := utf8.EncodeRune(src[:], r)
+		nDst, _, err := enc.NewEncoder().Transform(dst[:], src[:n], true)
+		if err != nil || nDst == 0 || nDst > 2 {
+			// The source rune r is not representable in the legacy encoding.
+			return 0, nil
+		}
+
+		// Single-byte characters use the first subHeader. The first byte of
+		// a two-byte character selects a different subHeader.
+		k, c := keys[dst[0]], uint16(dst[0])
+		if nDst == 2 {
+			if k == 0 {
+				return 0, nil
+			}
+			c = uint16(dst[1])
+		} else if k != 0 {
+			return 0, nil
+		}
+		entry := &entries[k]
+		if c < entry.firstCode || c-entry.firstCode >= entry.entryCount {
+			return 0, nil
+		}
+		offset := entry.base + 2*uint32(c-entry.firstCode)
+		if offset > end || offset+2 > end {
+			return 0, errInvalidCmapTable
+		}
+		x, err := b.view(&f.src, int(f.cmap.offset+offset), 2)
+		if err != nil {
+			return 0, err
+		}
+		if g := u16(x); g != 0 {
+			return GlyphIndex(g + entry.delta), nil
+		}
+		return 0, nil
+	}
+	return buf, nil
+}
+
 func (f *Font) makeCachedGlyphIndexFormat4(buf []byte, offset, length uint32) ([]byte, error) {
 	const headerSize = 14
 	if offset+headerSize > f.cmap.length {
@@ -205,8 +353,8 @@ func (f *Font) makeCachedGlyphIndexFormat4(buf []byte, offset, length uint32) ([
 	return buf, nil
 }
 
-func (f *Font) makeCachedGlyphIndexFormat12(buf []byte, offset, _ uint32) ([]byte, error) {
-	const headerSize = 16
+func (f *Font) makeCachedGlyphIndexFormat10(buf []byte, offset, _ uint32) ([]byte, error) {
+	const headerSize = 20
 	if offset+headerSize > f.cmap.length {
 		return nil, errInvalidCmapTable
 	}
@@ -221,7 +369,51 @@ func (f *Font) makeCachedGlyphIndexFormat12(buf []byte, offset, _ uint32) ([]byt
 	}
 	offset += headerSize
 
-	numGroups := u32(buf[12:])
+	start := u32(buf[12:])
+	numChars := u32(buf[16:])
+	if numChars > (length-headerSize)/2 {
+		return nil, errInvalidCmapTable
+	}
+	indexesBase := f.cmap.offset + offset
+
+	f.cached.glyphIndex = func(f *Font, b *Buffer, r rune) (GlyphIndex, error) {
+		c := uint32(r)
+		if c < start || c-start >= numChars {
+			return 0, nil
+		}
+		x, err := b.view(&f.src, int(indexesBase+2*(c-start)), 2)
+		if err != nil {
+			return 0, err
+		}
+		return GlyphIndex(u16(x)), nil
+	}
+	return buf, nil
+}
+
+// makeCachedGlyphIndexFormat12 handles formats 8, 12 and 13, which all consist
+// of groups of consecutive character codes. Format 8 has a larger header, and
+// its 32-bit character codes are UTF-16 surrogate pairs. Format 13 maps every
+// character in a group to the same glyph.
+func (f *Font) makeCachedGlyphIndexFormat12(buf []byte, offset, _ uint32, format uint16) ([]byte, error) {
+	headerSize := uint32(16)
+	if format == 8 {
+		headerSize = 12 + 8192 + 4
+	}
+	if offset+headerSize > f.cmap.length {
+		return nil, errInvalidCmapTable
+	}
+	var err error
+	buf, err = f.src.view(buf, int(f.cmap.offset+offset), int(headerSize))
+	if err != nil {
+		return nil, err
+	}
+	length := u32(buf[4:])
+	if f.cmap.length < offset || length > f.cmap.length-offset {
+		return nil, errInvalidCmapTable
+	}
+	offset += headerSize
+
+	numGroups := u32(buf[headerSize-4:])
 	if numGroups > maxCmapSegments {
 		return nil, errUnsupportedNumberOfCmapSegments
 	}
@@ -247,6 +439,10 @@ func (f *Font) makeCachedGlyphIndexFormat12(buf []byte, offset, _ uint32) ([]byt
 
 	f.cached.glyphIndex = func(f *Font, b *Buffer, r rune) (GlyphIndex, error) {
 		c := uint32(r)
+		if format == 8 && c > 0xffff {
+			r1, r2 := utf16.EncodeRune(r)
+			c = uint32(r1)<<16 | uint32(r2)
+		}
 		for i, j := 0, len(entries); i < j; {
 			h := i + (j-i)/2
 			entry := &entries[h]
@@ -254,6 +450,8 @@ func (f *Font) makeCachedGlyphIndexFormat12(buf []byte, offset, _ uint32) ([]byt
 				j = h
 			} else if entry.end < c {
 				i = h + 1
+			} else if format == 13 {
+				return GlyphIndex(entry.delta), nil
 			} else {
 				return GlyphIndex(c - entry.start + entry.delta), nil
 			}
@@ -263,6 +461,13 @@ func (f *Font) makeCachedGlyphIndexFormat12(buf []byte, offset, _ uint32) ([]byt
 	return buf, nil
 }
 
+type cmapSubHeader struct {
+	firstCode, entryCount, delta uint16
+	// base is the offset, relative to the start of the cmap table, of the
+	// glyph index for firstCode.
+	base uint32
+}
+
 type cmapEntry16 struct {
 	end, start, delta, offset uint16
 }
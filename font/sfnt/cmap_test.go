@@ -0,0 +1,267 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"testing"
+)
+
+func cmapFormat2() []byte {
+	// Single-byte characters use subHeader 0. Two-byte Shift JIS characters
+	// with a first byte of 0x82 (mostly Hiragana) use subHeader 1.
+	b := appendU16(nil, 2)
+	b = appendU16(b, 6+2*256+2*8+2*5)
+	b = appendU16(b, 0)
+	for i := 0; i < 256; i++ {
+		key := uint16(0)
+		if i == 0x82 {
+			key = 1 * 8
+		}
+		b = appendU16(b, key)
+	}
+	// subHeader 0: 'A' and 'B' map to the glyphs at index 0 and 1.
+	b = appendU16(b, 'A')
+	b = appendU16(b, 2)
+	b = appendU16(b, 0)
+	b = appendU16(b, 2*8-6)
+	// subHeader 1: 0xa0, 0xa1 and 0xa2 map to the glyphs at index 2, 3 and
+	// 4, plus a delta of 2.
+	b = appendU16(b, 0xa0)
+	b = appendU16(b, 3)
+	b = appendU16(b, 2)
+	b = appendU16(b, 1*8-6+2*2)
+	for _, x := range []uint16{5, 6, 5, 0, 6} {
+		b = appendU16(b, x)
+	}
+	return b
+}
+
+func cmapFormat4Symbol() []byte {
+	// Two segments: U+F041 to U+F042 map to the glyphs at index 3 and 4,
+	// and the final segment maps U+FFFF.
+	b := appendU16(nil, 4)
+	b = appendU16(b, 16+8*2)
+	b = appendU16(b, 0)
+	b = appendU16(b, 2*2)
+	b = appendU16(b, 4)
+	b = appendU16(b, 1)
+	b = appendU16(b, 0)
+	for _, x := range []uint16{
+		0xf042, 0xffff, // endCode.
+		0,              // reservedPad.
+		0xf041, 0xffff, // startCode.
+		0x0fc2, 1, // idDelta: 3 - 0xf041, modulo 65536.
+		0, 0, // idRangeOffset.
+	} {
+		b = appendU16(b, x)
+	}
+	return b
+}
+
+func cmapFormat8() []byte {
+	b := appendU16(nil, 8)
+	b = appendU16(b, 0)
+	b = appendU32(b, 12+8192+4+2*12)
+	b = appendU32(b, 0)
+	is32 := make([]byte, 8192)
+	is32[0xd83d/8] |= 0x80 >> (0xd83d % 8)
+	b = append(b, is32...)
+	b = appendU32(b, 2)
+	for _, g := range [][3]uint32{
+		{'A', 'C', 10},
+		{0xd83dde00, 0xd83dde01, 20}, // U+1F600 and U+1F601 as surrogate pairs.
+	} {
+		b = appendU32(b, g[0])
+		b = appendU32(b, g[1])
+		b = appendU32(b, g[2])
+	}
+	return b
+}
+
+func cmapFormat10() []byte {
+	b := appendU16(nil, 10)
+	b = appendU16(b, 0)
+	b = appendU32(b, 20+2*3)
+	b = appendU32(b, 0)
+	b = appendU32(b, 0x1f600)
+	b = appendU32(b, 3)
+	for _, x := range []uint16{3, 0, 4} {
+		b = appendU16(b, x)
+	}
+	return b
+}
+
+func cmapFormat13() []byte {
+	b := appendU16(nil, 13)
+	b = appendU16(b, 0)
+	b = appendU32(b, 16+12)
+	b = appendU32(b, 0)
+	b = appendU32(b, 1)
+	b = appendU32(b, 0x4e00)
+	b = appendU32(b, 0x9fff)
+	b = appendU32(b, 42)
+	return b
+}
+
+func TestCmapFormats(t *testing.T) {
+	testCases := []struct {
+		format    uint16
+		pid, psid uint16
+		subtable  []byte
+		want      map[rune]GlyphIndex
+	}{{
+		format:   2,
+		pid:      pidWindows,
+		psid:     psidWindowsShiftJIS,
+		subtable: cmapFormat2(),
+		want: map[rune]GlyphIndex{
+			'@': 0,
+			'A': 5,
+			'B': 6,
+			'C': 0,
+			'あ': 7, // U+3042 HIRAGANA LETTER A is 0x82 0xa0 in Shift JIS.
+			'ぃ': 0,
+			'い': 8,
+			'う': 0,
+			'€': 0, // U+20AC EURO SIGN is not in Shift JIS.
+		},
+	}, {
+		format:   8,
+		pid:      pidWindows,
+		psid:     psidWindowsUCS4,
+		subtable: cmapFormat8(),
+		want: map[rune]GlyphIndex{
+			'A':          10,
+			'C':          12,
+			'D':          0,
+			'\U0001f600': 20,
+			'\U0001f601': 21,
+			'\U0001f602': 0,
+		},
+	}, {
+		format:   10,
+		pid:      pidUnicode,
+		psid:     psidUnicode2FullRepertoire,
+		subtable: cmapFormat10(),
+		want: map[rune]GlyphIndex{
+			'A':          0,
+			'\U0001f5ff': 0,
+			'\U0001f600': 3,
+			'\U0001f601': 0,
+			'\U0001f602': 4,
+			'\U0001f603': 0,
+		},
+	}, {
+		format:   13,
+		pid:      pidUnicode,
+		psid:     psidUnicodeFullRepertoireFormat13,
+		subtable: cmapFormat13(),
+		want: map[rune]GlyphIndex{
+			'A': 0,
+			'一': 42,
+			'文': 42,
+			'鿿': 42,
+			'ꀀ': 0,
+		},
+	}}
+
+	for _, tc := range testCases {
+		if !supportedCmapFormat(tc.format, tc.pid, tc.psid) {
+			t.Errorf("format %d: not supported", tc.format)
+			continue
+		}
+		// Prepend a cmap header with a single encoding record.
+		cmap := appendU16(nil, 0)
+		cmap = appendU16(cmap, 1)
+		cmap = appendU16(cmap, tc.pid)
+		cmap = appendU16(cmap, tc.psid)
+		cmap = appendU32(cmap, 12)
+		cmap = append(cmap, tc.subtable...)
+
+		f := &Font{
+			src:  source{b: cmap},
+			cmap: table{0, uint32(len(cmap))},
+		}
+		if _, err := f.parseCmap(nil); err != nil {
+			t.Errorf("format %d: parseCmap: %v", tc.format, err)
+			continue
+		}
+		for r, want := range tc.want {
+			got, err := f.GlyphIndex(&Buffer{}, r)
+			if err != nil {
+				t.Errorf("format %d: r=%U: %v", tc.format, r, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("format %d: r=%U: got %d, want %d", tc.format, r, got, want)
+			}
+		}
+	}
+}
+
+func TestCmapLegacyFormat4(t *testing.T) {
+	// A format 4 subtable with a legacy CJK encoding would need its runes to
+	// be encoded first, which is only done for format 2.
+	if supportedCmapFormat(4, pidWindows, psidWindowsBig5) {
+		t.Errorf("format 4 with the Big5 encoding: got supported, want unsupported")
+	}
+	if !supportedCmapFormat(2, pidMacintosh, psidMacintoshKorean) {
+		t.Errorf("format 2 with the Mac Korean encoding: got unsupported, want supported")
+	}
+}
+
+func TestCmapSymbol(t *testing.T) {
+	testCases := []struct {
+		psid uint16
+		want map[rune]GlyphIndex
+	}{{
+		psid: psidWindowsSymbol,
+		want: map[rune]GlyphIndex{
+			'@':    0,
+			'A':    3,
+			'B':    4,
+			'C':    0,
+			0xf041: 3,
+			0x141:  0,
+		},
+	}, {
+		psid: psidWindowsUCS2,
+		want: map[rune]GlyphIndex{
+			'A':    0,
+			0xf041: 3,
+		},
+	}}
+
+	for _, tc := range testCases {
+		cmap := appendU16(nil, 0)
+		cmap = appendU16(cmap, 1)
+		cmap = appendU16(cmap, pidWindows)
+		cmap = appendU16(cmap, tc.psid)
+		cmap = appendU32(cmap, 12)
+		cmap = append(cmap, cmapFormat4Symbol()...)
+
+		f := &Font{
+			src:  source{b: cmap},
+			cmap: table{0, uint32(len(cmap))},
+		}
+		if _, err := f.parseCmap(nil); err != nil {
+			t.Errorf("psid %d: parseCmap: %v", tc.psid, err)
+			continue
+		}
+		if got, want := f.IsSymbolFont(), tc.psid == psidWindowsSymbol; got != want {
+			t.Errorf("psid %d: IsSymbolFont: got %t, want %t", tc.psid, got, want)
+		}
+		for r, want := range tc.want {
+			got, err := f.SymbolGlyphIndex(&Buffer{}, r)
+			if err != nil {
+				t.Errorf("psid %d: r=%U: %v", tc.psid, r, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("psid %d: r=%U: got %d, want %d", tc.psid, r, got, want)
+			}
+		}
+	}
+}
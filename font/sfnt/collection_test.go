@@ -0,0 +1,162 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// testCollection returns TTC data for the SFNT fonts in srcs. The fonts do not
+// share any tables.
+func testCollection(t *testing.T, srcs ...[]byte) []byte {
+	var (
+		tables []taggedTable
+		fonts  []collectionFont
+	)
+	for _, src := range srcs {
+		f, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		b, err := NewBuilder(f)
+		if err != nil {
+			t.Fatalf("NewBuilder: %v", err)
+		}
+		font := collectionFont{version: u32(src)}
+		for _, tag := range b.Tags() {
+			font.tables = append(font.tables, len(tables))
+			tables = append(tables, taggedTable{tag, b.Table(tag)})
+		}
+		fonts = append(fonts, font)
+	}
+	return writeCollection(tables, fonts)
+}
+
+func TestParseCollection(t *testing.T) {
+	srcs := [][]byte{goregular.TTF, gobold.TTF}
+	ttc := testCollection(t, srcs...)
+	if _, err := Parse(ttc); err != errInvalidSingleFont {
+		t.Errorf("Parse: got %v, want %v", err, errInvalidSingleFont)
+	}
+
+	c0, err := ParseCollection(ttc)
+	if err != nil {
+		t.Fatalf("ParseCollection: %v", err)
+	}
+	c1, err := ParseCollectionReaderAt(bytes.NewReader(ttc))
+	if err != nil {
+		t.Fatalf("ParseCollectionReaderAt: %v", err)
+	}
+	for _, c := range []*Collection{c0, c1} {
+		if got, want := c.NumFonts(), len(srcs); got != want {
+			t.Fatalf("NumFonts: got %d, want %d", got, want)
+		}
+		for i, src := range srcs {
+			want, err := Parse(src)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			f, err := c.Font(i)
+			if err != nil {
+				t.Fatalf("Font(%d): %v", i, err)
+			}
+			for _, id := range []NameID{NameIDFull, NameIDVersion} {
+				got, err := f.Name(nil, id)
+				if err != nil {
+					t.Errorf("font #%d: Name: %v", i, err)
+					continue
+				}
+				if w, _ := want.Name(nil, id); got != w {
+					t.Errorf("font #%d: Name(%d): got %q, want %q", i, id, got, w)
+				}
+			}
+			if got, want := f.NumGlyphs(), want.NumGlyphs(); got != want {
+				t.Errorf("font #%d: NumGlyphs: got %d, want %d", i, got, want)
+			}
+
+			// A Builder for a font in a collection should hold that font's
+			// tables.
+			gotB, err := NewBuilder(f)
+			if err != nil {
+				t.Fatalf("font #%d: NewBuilder: %v", i, err)
+			}
+			wantB, err := NewBuilder(want)
+			if err != nil {
+				t.Fatalf("font #%d: NewBuilder: %v", i, err)
+			}
+			for _, tag := range wantB.Tags() {
+				if !bytes.Equal(gotB.Table(tag), wantB.Table(tag)) {
+					t.Errorf("font #%d: NewBuilder: table %q differs", i, tag)
+				}
+			}
+		}
+		if _, err := c.Font(len(srcs)); err != ErrNotFound {
+			t.Errorf("Font(%d): got %v, want %v", len(srcs), err, ErrNotFound)
+		}
+	}
+
+	// A single font is a collection of 1 font.
+	c, err := ParseCollection(goregular.TTF)
+	if err != nil {
+		t.Fatalf("ParseCollection (single font): %v", err)
+	}
+	if got := c.NumFonts(); got != 1 {
+		t.Errorf("NumFonts (single font): got %d, want 1", got)
+	}
+}
+
+func TestCollectionMetadata(t *testing.T) {
+	c, err := ParseCollectionReaderAt(bytes.NewReader(testCollection(t, goregular.TTF, gobold.TTF)))
+	if err != nil {
+		t.Fatalf("ParseCollectionReaderAt: %v", err)
+	}
+	testCases := []struct {
+		subfamily string
+		weight    font.Weight
+	}{
+		{"Regular", font.WeightNormal},
+		{"Bold", font.WeightSemiBold},
+	}
+	for i, tc := range testCases {
+		m, err := c.Metadata(i)
+		if err != nil {
+			t.Fatalf("Metadata(%d): %v", i, err)
+		}
+		f, err := c.Font(i)
+		if err != nil {
+			t.Fatalf("Font(%d): %v", i, err)
+		}
+		for _, id := range []NameID{NameIDFamily, NameIDSubfamily, NameIDVersion} {
+			got, err := m.Name(nil, id)
+			if err != nil {
+				t.Errorf("font #%d: Name(%d): %v", i, id, err)
+				continue
+			}
+			if want, _ := f.Name(nil, id); got != want {
+				t.Errorf("font #%d: Name(%d): got %q, want %q", i, id, got, want)
+			}
+		}
+		if got, err := m.Name(nil, NameIDSubfamily); err != nil || got != tc.subfamily {
+			t.Errorf("font #%d: subfamily: got %q, %v, want %q", i, got, err, tc.subfamily)
+		}
+		if m.Weight != tc.weight {
+			t.Errorf("font #%d: Weight: got %d, want %d", i, m.Weight, tc.weight)
+		}
+		ascent, _ := f.src.u16(nil, f.hhea, 4)
+		descent, _ := f.src.u16(nil, f.hhea, 6)
+		if m.Ascent != Units(int16(ascent)) || m.Descent != Units(int16(descent)) {
+			t.Errorf("font #%d: Ascent, Descent: got %d, %d, want %d, %d",
+				i, m.Ascent, m.Descent, int16(ascent), int16(descent))
+		}
+	}
+	if _, err := c.Metadata(2); err != ErrNotFound {
+		t.Errorf("Metadata(2): got %v, want %v", err, ErrNotFound)
+	}
+}
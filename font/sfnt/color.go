@@ -0,0 +1,214 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"image/color"
+)
+
+// This file implements the COLR (Color) and CPAL (Color Palette) tables, as
+// described at
+// https://www.microsoft.com/typography/otspec/colr.htm and
+// https://www.microsoft.com/typography/otspec/cpal.htm
+
+// ForegroundPaletteIndex is a ColorLayer's PaletteIndex that means to use the
+// text's foreground color instead of a palette color.
+const ForegroundPaletteIndex = 0xffff
+
+// ColorLayer is one layer of a color glyph. A color glyph is drawn by drawing
+// each of its layers' glyphs in order, each filled with its layer's color.
+type ColorLayer struct {
+	// GlyphIndex is the glyph whose outline is the layer's shape.
+	GlyphIndex GlyphIndex
+	// PaletteIndex is the index of the layer's color in a palette, as
+	// returned by Font.Palette, or ForegroundPaletteIndex.
+	PaletteIndex uint16
+}
+
+// colrInfo holds the location of the COLR table's sub-structures.
+type colrInfo struct {
+	numBaseGlyphs int32
+	baseGlyphs    uint32
+	numLayers     int32
+	layers        uint32
+
+	// The remaining fields are for version 1 tables. The BaseGlyphList and
+	// LayerList hold paint graphs, instead of version 0's flat layers.
+	numBaseGlyphPaints int32
+	baseGlyphPaints    uint32
+	numLayerPaints     int32
+	layerPaints        uint32
+}
+
+// cpalInfo holds the location of the CPAL table's sub-structures.
+type cpalInfo struct {
+	numPaletteEntries int32
+	numPalettes       int32
+	numColors         int32
+	colors            uint32
+}
+
+func (f *Font) parseCOLR(buf []byte) ([]byte, error) {
+	if f.colr.length == 0 {
+		return buf, nil
+	}
+	const headerSize = 14
+	if f.colr.length < headerSize {
+		return nil, errInvalidCOLRTable
+	}
+	buf, err := f.src.view(buf, int(f.colr.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	// Version 1 tables start with a version 0 header, describing the glyphs
+	// that version 0 implementations can display.
+	version := u16(buf)
+	if version > 1 {
+		return nil, errUnsupportedCOLRTable
+	}
+	c := colrInfo{
+		numBaseGlyphs: int32(u16(buf[2:])),
+		baseGlyphs:    u32(buf[4:]),
+		layers:        u32(buf[8:]),
+		numLayers:     int32(u16(buf[12:])),
+	}
+	const baseGlyphSize, layerSize = 6, 4
+	if c.baseGlyphs > f.colr.length || uint32(c.numBaseGlyphs)*baseGlyphSize > f.colr.length-c.baseGlyphs ||
+		c.layers > f.colr.length || uint32(c.numLayers)*layerSize > f.colr.length-c.layers {
+		return nil, errInvalidCOLRTable
+	}
+	if version == 1 {
+		buf, err = f.parseCOLRVersion1(buf, &c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.cached.colr = c
+	return buf, nil
+}
+
+func (f *Font) parseCPAL(buf []byte) ([]byte, error) {
+	if f.cpal.length == 0 {
+		return buf, nil
+	}
+	const headerSize = 12
+	if f.cpal.length < headerSize {
+		return nil, errInvalidCPALTable
+	}
+	buf, err := f.src.view(buf, int(f.cpal.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if version := u16(buf); version > 1 {
+		return nil, errUnsupportedCPALTable
+	}
+	c := cpalInfo{
+		numPaletteEntries: int32(u16(buf[2:])),
+		numPalettes:       int32(u16(buf[4:])),
+		numColors:         int32(u16(buf[6:])),
+		colors:            u32(buf[8:]),
+	}
+	const colorSize = 4
+	if headerSize+2*uint32(c.numPalettes) > f.cpal.length ||
+		c.colors > f.cpal.length || uint32(c.numColors)*colorSize > f.cpal.length-c.colors {
+		return nil, errInvalidCPALTable
+	}
+	f.cached.cpal = c
+	return buf, nil
+}
+
+// ColorLayers returns the layers of the color glyph x, as per the COLR
+// table's version 0 base glyph records. It returns nil if x is not a color
+// glyph, in which case it should be drawn like any other glyph.
+func (f *Font) ColorLayers(b *Buffer, x GlyphIndex) ([]ColorLayer, error) {
+	if err := f.skippedTable("COLR"); err != nil {
+		return nil, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return nil, ErrNotFound
+	}
+	c := f.cached.colr
+	if c.numBaseGlyphs == 0 {
+		return nil, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	const baseGlyphSize, layerSize = 6, 4
+	for lo, hi := int32(0), c.numBaseGlyphs; lo < hi; {
+		i := (lo + hi) / 2
+		buf, err := b.view(&f.src, int(f.colr.offset+c.baseGlyphs)+baseGlyphSize*int(i), baseGlyphSize)
+		if err != nil {
+			return nil, err
+		}
+		if g := GlyphIndex(u16(buf)); x < g {
+			hi = i
+		} else if x > g {
+			lo = i + 1
+		} else {
+			first, n := int32(u16(buf[2:])), int32(u16(buf[4:]))
+			if first+n > c.numLayers {
+				return nil, errInvalidCOLRTable
+			}
+			if n == 0 {
+				return nil, nil
+			}
+			buf, err = b.view(&f.src, int(f.colr.offset+c.layers)+layerSize*int(first), layerSize*int(n))
+			if err != nil {
+				return nil, err
+			}
+			ret := make([]ColorLayer, n)
+			for j := range ret {
+				ret[j] = ColorLayer{
+					GlyphIndex:   GlyphIndex(u16(buf[layerSize*j:])),
+					PaletteIndex: u16(buf[layerSize*j+2:]),
+				}
+			}
+			return ret, nil
+		}
+	}
+	return nil, nil
+}
+
+// NumPalettes returns the number of color palettes in the CPAL table. All of
+// the palettes have the same number of colors, and palette 0 is the default.
+func (f *Font) NumPalettes() int { return int(f.cached.cpal.numPalettes) }
+
+// Palette returns the colors of the i'th palette in the CPAL table.
+//
+// It returns ErrNotFound if i is out of range.
+func (f *Font) Palette(b *Buffer, i int) ([]color.NRGBA, error) {
+	if err := f.skippedTable("CPAL"); err != nil {
+		return nil, err
+	}
+	c := f.cached.cpal
+	if i < 0 || int32(i) >= c.numPalettes {
+		return nil, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	const headerSize, colorSize = 12, 4
+	buf, err := b.view(&f.src, int(f.cpal.offset)+headerSize+2*i, 2)
+	if err != nil {
+		return nil, err
+	}
+	first := int32(u16(buf))
+	if first+c.numPaletteEntries > c.numColors {
+		return nil, errInvalidCPALTable
+	}
+	buf, err = b.view(&f.src, int(f.cpal.offset+c.colors)+colorSize*int(first), colorSize*int(c.numPaletteEntries))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]color.NRGBA, c.numPaletteEntries)
+	for j := range ret {
+		// Color records are in BGRA order.
+		p := buf[colorSize*j:]
+		ret[j] = color.NRGBA{R: p[2], G: p[1], B: p[0], A: p[3]}
+	}
+	return ret, nil
+}
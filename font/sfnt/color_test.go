@@ -0,0 +1,416 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"image/color"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// testCOLRTable is a version 0 COLR table, for glyfTest.ttf's 5 glyphs, whose
+// glyphs 2 and 4 are color glyphs.
+var testCOLRTable = concat(
+	be16(0, 2, 0, 14, 0, 26, 3),     // version, numBaseGlyphRecords, offsets, numLayerRecords.
+	be16(2, 0, 1),                   // Glyph 2 has 1 layer, starting at layer 0.
+	be16(4, 1, 2),                   // Glyph 4 has 2 layers, starting at layer 1.
+	be16(1, 1),                      // Layer 0.
+	be16(3, 0),                      // Layer 1.
+	be16(1, ForegroundPaletteIndex), // Layer 2.
+)
+
+// testCPALTable is a version 0 CPAL table with 2 palettes of 2 colors. The
+// palettes share the color green.
+var testCPALTable = concat(
+	be16(0, 2, 2, 3, 0, 16), // version, numPaletteEntries, numPalettes, numColorRecords, offset.
+	be16(0, 1),              // colorRecordIndices.
+	[]byte{
+		0x00, 0x00, 0xff, 0xff, // Opaque red, in BGRA order.
+		0x00, 0xff, 0x00, 0x80, // Half-transparent green.
+		0xff, 0x00, 0x00, 0xff, // Opaque blue.
+	},
+)
+
+func TestColorLayers(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"COLR": testCOLRTable,
+		"CPAL": testCPALTable,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	wantLayers := [][]ColorLayer{
+		0: nil,
+		1: nil,
+		2: {{1, 1}},
+		3: nil,
+		4: {{3, 0}, {1, ForegroundPaletteIndex}},
+	}
+	var b Buffer
+	for i, want := range wantLayers {
+		got, err := f.ColorLayers(&b, GlyphIndex(i))
+		if err != nil {
+			t.Errorf("ColorLayers(%d): %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ColorLayers(%d): got %v, want %v", i, got, want)
+		}
+	}
+	if _, err := f.ColorLayers(&b, 5); err != ErrNotFound {
+		t.Errorf("ColorLayers(5): got %v, want %v", err, ErrNotFound)
+	}
+
+	if got, want := f.NumPalettes(), 2; got != want {
+		t.Fatalf("NumPalettes: got %d, want %d", got, want)
+	}
+	wantPalettes := [][]color.NRGBA{
+		{{0xff, 0x00, 0x00, 0xff}, {0x00, 0xff, 0x00, 0x80}},
+		{{0x00, 0xff, 0x00, 0x80}, {0x00, 0x00, 0xff, 0xff}},
+	}
+	for i, want := range wantPalettes {
+		got, err := f.Palette(&b, i)
+		if err != nil {
+			t.Errorf("Palette(%d): %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Palette(%d): got %v, want %v", i, got, want)
+		}
+	}
+	if _, err := f.Palette(&b, 2); err != ErrNotFound {
+		t.Errorf("Palette(2): got %v, want %v", err, ErrNotFound)
+	}
+}
+
+// testCOLRVersion1Table is a version 1 COLR table, for glyfTest.ttf's 5
+// glyphs, with paint graphs for glyphs 1, 2 and 4. Glyph 1's paint graph is
+// a cycle.
+var testCOLRVersion1Table = concat(
+	be16(1, 0, 0, 0, 0, 0, 0),            // version, version 0 fields.
+	be16(0, 34, 0, 88, 0, 0, 0, 0, 0, 0), // baseGlyphList, layerList, clipList, varIndexMap, varStore.
+
+	// BaseGlyphList, at offset 34.
+	be16(0, 3),
+	be16(1, 0, 120),
+	be16(2, 0, 22),
+	be16(4, 0, 28),
+
+	// Glyph 2: PaintColrLayers, at offset 56.
+	[]byte{1, 2, 0, 0, 0, 0},
+
+	// Glyph 4: PaintComposite, at offset 62, and its PaintColrGlyph source,
+	// PaintRotateAroundCenter backdrop and that backdrop's PaintSolid.
+	[]byte{32, 0, 0, 8, uint8(CompositeSrcOver), 0, 0, 11},
+	[]byte{11, 0, 2},
+	append([]byte{26, 0, 0, 10}, be16(0x2000, 100, 200)...),
+	append([]byte{2}, be16(1, 0x4000)...),
+
+	// LayerList, at offset 88.
+	be16(0, 2, 0, 12, 0, 18),
+
+	// Layer 0: PaintGlyph, at offset 100.
+	append([]byte{10, 0, 0, 23}, be16(1)...),
+	// Layer 1: PaintTranslate, at offset 106, and its PaintVarSolid.
+	append([]byte{14, 0, 0, 8}, be16(10, -20)...),
+	append([]byte{3}, be16(ForegroundPaletteIndex, 0x2000, 0, 0)...),
+	// Layer 0's PaintLinearGradient, at offset 123, and its ColorLine.
+	append([]byte{4, 0, 0, 16}, be16(0, 0, 100, 0, 0, 100)...),
+	append([]byte{uint8(ExtendRepeat)}, be16(2, 0, 0, 0x4000, 0x4000, 1, 0x2000)...),
+
+	// Glyph 1: PaintTranslate, at offset 154, whose child is itself.
+	append([]byte{14, 0, 0, 0}, be16(0, 0)...),
+)
+
+func TestColorPaint(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"COLR": testCOLRVersion1Table,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var b Buffer
+	if _, err := f.ColorPaint(&b, 1); err != errInvalidCOLRTable {
+		t.Errorf("glyph 1: got %v, want %v", err, errInvalidCOLRTable)
+	}
+	for _, x := range []GlyphIndex{0, 3} {
+		if got, err := f.ColorPaint(&b, x); got != nil || err != nil {
+			t.Errorf("glyph %d: got %v, %v, want nil, nil", x, got, err)
+		}
+	}
+
+	got, err := f.ColorPaint(&b, 2)
+	if err != nil {
+		t.Fatalf("glyph 2: %v", err)
+	}
+	want := Paint(&PaintLayers{Layers: []Paint{
+		&PaintGlyph{
+			GlyphIndex: 1,
+			Paint: &PaintLinearGradient{
+				ColorLine: ColorLine{
+					Extend: ExtendRepeat,
+					Stops:  []ColorStop{{0, 0, 1}, {1, 1, 0.5}},
+				},
+				X1: 100,
+				Y2: 100,
+			},
+		},
+		&PaintTransform{
+			Matrix: [6]float64{1, 0, 0, 1, 10, -20},
+			Paint:  &PaintSolid{PaletteIndex: ForegroundPaletteIndex, Alpha: 0.5},
+		},
+	}})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("glyph 2:\ngot  %#v\nwant %#v", got, want)
+	}
+
+	got, err = f.ColorPaint(&b, 4)
+	if err != nil {
+		t.Fatalf("glyph 4: %v", err)
+	}
+	// Rotating by 90 degrees around (100, 200) maps (x, y) to (300 - y, 100 +
+	// x). Round the matrix to hide floating point errors.
+	if p, ok := got.(*PaintComposite); ok {
+		if q, ok := p.Backdrop.(*PaintTransform); ok {
+			for i, v := range q.Matrix {
+				q.Matrix[i] = math.Round(v*1e6) / 1e6
+			}
+		}
+	}
+	want = &PaintComposite{
+		Mode:   CompositeSrcOver,
+		Source: &PaintColorGlyph{GlyphIndex: 2},
+		Backdrop: &PaintTransform{
+			Matrix: [6]float64{0, 1, -1, 0, 300, 100},
+			Paint:  &PaintSolid{PaletteIndex: 1, Alpha: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("glyph 4:\ngot  %#v\nwant %#v", got, want)
+	}
+}
+
+func TestSVGDocument(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	doc0 := `<svg xmlns="http://www.w3.org/2000/svg"><path id="glyph1" d="M0 0h100v100z"/></svg>`
+	doc1 := "\x1f\x8b\x08\x00"
+	svg := concat(
+		be16(0, 0, 10, 0, 0), // version, svgDocumentListOffset, reserved.
+		be16(2),              // numEntries.
+		be16(1, 2, 0, 26, 0, len(doc0)),
+		be16(4, 4, 0, 26+len(doc0), 0, len(doc1)),
+		[]byte(doc0),
+		[]byte(doc1),
+	)
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"SVG ": svg,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		x           GlyphIndex
+		want        string
+		first, last GlyphIndex
+	}{
+		{0, "", 0, 0},
+		{1, doc0, 1, 2},
+		{2, doc0, 1, 2},
+		{3, "", 0, 0},
+		{4, doc1, 4, 4},
+	}
+	var b Buffer
+	for _, tc := range testCases {
+		got, first, last, err := f.SVGDocument(&b, tc.x)
+		if err != nil {
+			t.Errorf("x=%d: %v", tc.x, err)
+			continue
+		}
+		if string(got) != tc.want || first != tc.first || last != tc.last {
+			t.Errorf("x=%d: got %q, %d, %d, want %q, %d, %d",
+				tc.x, got, first, last, tc.want, tc.first, tc.last)
+		}
+	}
+}
+
+// testSbixStrike returns an sbix strike for glyfTest.ttf's 5 glyphs. Each
+// image is an originOffsetX, originOffsetY, graphicType and data.
+func testSbixStrike(ppem int, images map[GlyphIndex][]byte) []byte {
+	const numGlyphs, headerSize = 5, 4 + 4*(5+1)
+	b := be16(ppem, 72)
+	var data []byte
+	for i := GlyphIndex(0); i <= numGlyphs; i++ {
+		b = append(b, be16(0, headerSize+len(data))...)
+		data = append(data, images[i]...)
+	}
+	return append(b, data...)
+}
+
+func TestSbixGlyph(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	strike20 := testSbixStrike(20, map[GlyphIndex][]byte{
+		1: concat(be16(1, -2), []byte("png A1")),
+		2: concat(be16(0, 0), []byte("dupe"), be16(1)),
+	})
+	strike40 := testSbixStrike(40, map[GlyphIndex][]byte{
+		1: concat(be16(3, -4), []byte("png B1")),
+		3: concat(be16(0, 0), []byte("jpg B3")),
+	})
+	// The strikes are deliberately not in ppem order.
+	sbix := concat(
+		be16(1, 1, 0, 2),                 // version, flags, numStrikes.
+		be16(0, 16, 0, 16+len(strike40)), // strikeOffsets.
+		strike40,
+		strike20,
+	)
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"sbix": sbix,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		x                GlyphIndex
+		ppem             fixed.Int26_6
+		want             string
+		originX, originY int
+		wantPPEM         int
+	}{
+		{0, 20 << 6, "", 0, 0, 0},
+		{1, 10 << 6, "png A1", 1, -2, 20},
+		{1, 20 << 6, "png A1", 1, -2, 20},
+		{1, 20<<6 + 1, "png B1", 3, -4, 40},
+		{1, 99 << 6, "png B1", 3, -4, 40},
+		{2, 99 << 6, "png A1", 1, -2, 20},
+		{3, 10 << 6, "jpg B3", 0, 0, 40},
+		{4, 10 << 6, "", 0, 0, 0},
+	}
+	var b Buffer
+	for _, tc := range testCases {
+		g, err := f.SbixGlyph(&b, tc.x, tc.ppem)
+		if err != nil {
+			t.Errorf("x=%d, ppem=%v: %v", tc.x, tc.ppem, err)
+			continue
+		}
+		if tc.want == "" {
+			if g != nil {
+				t.Errorf("x=%d, ppem=%v: got %v, want nil", tc.x, tc.ppem, g)
+			}
+			continue
+		}
+		if g == nil {
+			t.Errorf("x=%d, ppem=%v: got nil", tc.x, tc.ppem)
+			continue
+		}
+		got := g.Format.String() + string(g.Data)
+		if got != tc.want || g.OriginX != tc.originX || g.OriginY != tc.originY || g.PPEM != tc.wantPPEM || g.PPI != 72 {
+			t.Errorf("x=%d, ppem=%v: got %q, (%d, %d), %d ppem, %d ppi, want %q, (%d, %d), %d ppem, 72 ppi",
+				tc.x, tc.ppem, got, g.OriginX, g.OriginY, g.PPEM, g.PPI, tc.want, tc.originX, tc.originY, tc.wantPPEM)
+		}
+	}
+}
+
+// testCBLCStrike returns a CBLC table BitmapSize record.
+func testCBLCStrike(indexSubTables, ppem, ascender, descender, maxWidth, first, last int) []byte {
+	return concat(
+		be16(0, indexSubTables, 0, 0, 0, 1, 0, 0), // indexSubTableArrayOffset, indexTablesSize, numberOfIndexSubTables, colorRef.
+		[]byte{uint8(ascender), uint8(descender), uint8(maxWidth), 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		make([]byte, 12),
+		be16(first, last),
+		[]byte{uint8(ppem), uint8(ppem), 32, 0x01},
+	)
+}
+
+func TestCBDTGlyph(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	cbdt := concat(
+		be16(3, 0),
+		// Strike 0's glyph 1, in image format 17, at offset 4.
+		[]byte{10, 8, 1, 9, 10}, be16(0, 4), []byte("PNG1"),
+		// Strike 1's glyphs 2 and 4, in image format 19, at offset 17.
+		be16(0, 4), []byte("PNG2"),
+		be16(0, 4), []byte("PNG4"),
+	)
+	cblc := concat(
+		be16(3, 0, 0, 2),
+		testCBLCStrike(104, 32, 28, -4, 30, 1, 2),
+		testCBLCStrike(132, 64, 56, -8, 60, 2, 4),
+		// Strike 0's IndexSubTableArray and IndexSubTable format 1, at offset 104.
+		be16(1, 2, 0, 8),
+		be16(1, 17, 0, 4),
+		be16(0, 0, 0, 13, 0, 13),
+		// Strike 1's IndexSubTableArray and IndexSubTable format 5, at offset 132.
+		be16(2, 4, 0, 8),
+		be16(5, 19, 0, 17),
+		be16(0, 8),
+		[]byte{20, 16, 2, 18, 20, 0, 0, 0},
+		be16(0, 2, 2, 4),
+	)
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"CBDT": cbdt,
+		"CBLC": cblc,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	wantStrikes := []BitmapStrike{
+		{32, 32, 32, 28, -4, 30, 1, 2},
+		{64, 64, 32, 56, -8, 60, 2, 4},
+	}
+	if got := f.CBDTStrikes(); !reflect.DeepEqual(got, wantStrikes) {
+		t.Errorf("CBDTStrikes: got %v, want %v", got, wantStrikes)
+	}
+
+	testCases := []struct {
+		x    GlyphIndex
+		ppem fixed.Int26_6
+		want *BitmapGlyph
+	}{
+		{0, 16 << 6, nil},
+		{1, 16 << 6, &BitmapGlyph{tagPNG, []byte("PNG1"), 1, -1, 8, 10, 10, 32, 0}},
+		{1, 99 << 6, &BitmapGlyph{tagPNG, []byte("PNG1"), 1, -1, 8, 10, 10, 32, 0}},
+		{2, 16 << 6, &BitmapGlyph{tagPNG, []byte("PNG2"), 2, -2, 16, 20, 20, 64, 0}},
+		{3, 16 << 6, nil},
+		{4, 16 << 6, &BitmapGlyph{tagPNG, []byte("PNG4"), 2, -2, 16, 20, 20, 64, 0}},
+	}
+	var b Buffer
+	for _, tc := range testCases {
+		got, err := f.CBDTGlyph(&b, tc.x, tc.ppem)
+		if err != nil {
+			t.Errorf("x=%d, ppem=%v: %v", tc.x, tc.ppem, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("x=%d, ppem=%v: got %+v, want %+v", tc.x, tc.ppem, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,526 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"math"
+)
+
+// This file implements the paint graphs of version 1 of the COLR table, as
+// described at https://www.microsoft.com/typography/otspec/colr.htm
+
+// Paint is a node of a color glyph's paint graph. It is one of:
+//   - *PaintLayers
+//   - *PaintSolid
+//   - *PaintLinearGradient
+//   - *PaintRadialGradient
+//   - *PaintSweepGradient
+//   - *PaintGlyph
+//   - *PaintColorGlyph
+//   - *PaintTransform
+//   - *PaintComposite
+//
+// All coordinates are in font units, in the same coordinate space as the
+// segments returned by Font.LoadGlyph at a ppem equal to the font's units per
+// em.
+type Paint interface {
+	isPaint()
+}
+
+// PaintLayers paints each of its layers, in order, on top of each other.
+type PaintLayers struct {
+	Layers []Paint
+}
+
+// PaintSolid fills its area with a palette color.
+type PaintSolid struct {
+	// PaletteIndex is the index of the color in a palette, as returned by
+	// Font.Palette, or ForegroundPaletteIndex.
+	PaletteIndex uint16
+	// Alpha is multiplied with the color's alpha, and is in the range [0, 1].
+	Alpha float64
+}
+
+// PaintLinearGradient fills its area with a linear gradient. The gradient's
+// color line runs from (X0, Y0) to (X1, Y1). Lines of equal color are
+// parallel to the line from (X0, Y0) to (X2, Y2).
+type PaintLinearGradient struct {
+	ColorLine              ColorLine
+	X0, Y0, X1, Y1, X2, Y2 float64
+}
+
+// PaintRadialGradient fills its area with a radial gradient between the
+// circle centered at (X0, Y0) with radius R0 and the circle centered at (X1,
+// Y1) with radius R1.
+type PaintRadialGradient struct {
+	ColorLine  ColorLine
+	X0, Y0, R0 float64
+	X1, Y1, R1 float64
+}
+
+// PaintSweepGradient fills its area with a sweep (conic) gradient centered at
+// (CenterX, CenterY). The color line is mapped to the angles, in degrees
+// counter-clockwise from the positive x axis, from StartAngle to EndAngle.
+type PaintSweepGradient struct {
+	ColorLine            ColorLine
+	CenterX, CenterY     float64
+	StartAngle, EndAngle float64
+}
+
+// PaintGlyph paints Paint, clipped to the outline of the glyph GlyphIndex.
+type PaintGlyph struct {
+	GlyphIndex GlyphIndex
+	Paint      Paint
+}
+
+// PaintColorGlyph paints the paint graph of another color glyph, as returned
+// by Font.ColorPaint for GlyphIndex.
+type PaintColorGlyph struct {
+	GlyphIndex GlyphIndex
+}
+
+// PaintTransform paints Paint transformed by the affine transformation
+// Matrix, which is {xx, yx, xy, yy, dx, dy}. A point (x, y) is transformed to
+// (xx*x + xy*y + dx, yx*x + yy*y + dy).
+//
+// The COLR table's translate, scale, rotate and skew paints are all returned
+// as a PaintTransform.
+type PaintTransform struct {
+	Matrix [6]float64
+	Paint  Paint
+}
+
+// PaintComposite paints Source on top of Backdrop, combining the two with
+// Mode.
+type PaintComposite struct {
+	Mode     CompositeMode
+	Source   Paint
+	Backdrop Paint
+}
+
+func (*PaintLayers) isPaint()         {}
+func (*PaintSolid) isPaint()          {}
+func (*PaintLinearGradient) isPaint() {}
+func (*PaintRadialGradient) isPaint() {}
+func (*PaintSweepGradient) isPaint()  {}
+func (*PaintGlyph) isPaint()          {}
+func (*PaintColorGlyph) isPaint()     {}
+func (*PaintTransform) isPaint()      {}
+func (*PaintComposite) isPaint()      {}
+
+// ColorLine is a gradient's colors.
+type ColorLine struct {
+	Extend Extend
+	Stops  []ColorStop
+}
+
+// ColorStop is a color at a position along a gradient's color line.
+type ColorStop struct {
+	// Offset is the position along the color line, where 0 and 1 are the
+	// start and end of the line. It can be outside of the range [0, 1].
+	Offset float64
+	// PaletteIndex is the index of the color in a palette, as returned by
+	// Font.Palette, or ForegroundPaletteIndex.
+	PaletteIndex uint16
+	// Alpha is multiplied with the color's alpha, and is in the range [0, 1].
+	Alpha float64
+}
+
+// Extend is how a gradient's color line is extended beyond its first and last
+// color stops.
+type Extend uint8
+
+const (
+	ExtendPad     Extend = 0
+	ExtendRepeat  Extend = 1
+	ExtendReflect Extend = 2
+)
+
+// CompositeMode is a PaintComposite's compositing or blending mode.
+type CompositeMode uint8
+
+const (
+	CompositeClear      CompositeMode = 0
+	CompositeSrc        CompositeMode = 1
+	CompositeDest       CompositeMode = 2
+	CompositeSrcOver    CompositeMode = 3
+	CompositeDestOver   CompositeMode = 4
+	CompositeSrcIn      CompositeMode = 5
+	CompositeDestIn     CompositeMode = 6
+	CompositeSrcOut     CompositeMode = 7
+	CompositeDestOut    CompositeMode = 8
+	CompositeSrcAtop    CompositeMode = 9
+	CompositeDestAtop   CompositeMode = 10
+	CompositeXor        CompositeMode = 11
+	CompositePlus       CompositeMode = 12
+	CompositeScreen     CompositeMode = 13
+	CompositeOverlay    CompositeMode = 14
+	CompositeDarken     CompositeMode = 15
+	CompositeLighten    CompositeMode = 16
+	CompositeColorDodge CompositeMode = 17
+	CompositeColorBurn  CompositeMode = 18
+	CompositeHardLight  CompositeMode = 19
+	CompositeSoftLight  CompositeMode = 20
+	CompositeDifference CompositeMode = 21
+	CompositeExclusion  CompositeMode = 22
+	CompositeMultiply   CompositeMode = 23
+	CompositeHue        CompositeMode = 24
+	CompositeSaturation CompositeMode = 25
+	CompositeColor      CompositeMode = 26
+	CompositeLuminosity CompositeMode = 27
+)
+
+func (f *Font) parseCOLRVersion1(buf []byte, c *colrInfo) ([]byte, error) {
+	const headerSize = 34
+	if f.colr.length < headerSize {
+		return nil, errInvalidCOLRTable
+	}
+	buf, err := f.src.view(buf, int(f.colr.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	c.baseGlyphPaints = u32(buf[14:])
+	c.layerPaints = u32(buf[18:])
+
+	// The BaseGlyphList and LayerList each start with a uint32 count.
+	if c.baseGlyphPaints != 0 {
+		const recordSize = 6
+		u, err := f.src.u32(buf, f.colr, int(c.baseGlyphPaints))
+		if err != nil {
+			return nil, errInvalidCOLRTable
+		}
+		if u > (f.colr.length-c.baseGlyphPaints-4)/recordSize {
+			return nil, errInvalidCOLRTable
+		}
+		c.numBaseGlyphPaints = int32(u)
+	}
+	if c.layerPaints != 0 {
+		u, err := f.src.u32(buf, f.colr, int(c.layerPaints))
+		if err != nil {
+			return nil, errInvalidCOLRTable
+		}
+		if u > (f.colr.length-c.layerPaints-4)/4 {
+			return nil, errInvalidCOLRTable
+		}
+		c.numLayerPaints = int32(u)
+	}
+	return buf, nil
+}
+
+// ColorPaint returns the root of the paint graph of the color glyph x, as per
+// the COLR table's version 1 base glyph paint records. It returns nil if x has
+// no paint graph, in which case Font.ColorLayers may still return version 0
+// layers for x.
+//
+// Variable paints are returned with the font's default values.
+//
+// TODO: apply the COLR table's variation deltas for fonts returned by
+// Font.WithVariations.
+func (f *Font) ColorPaint(b *Buffer, x GlyphIndex) (Paint, error) {
+	if err := f.skippedTable("COLR"); err != nil {
+		return nil, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return nil, ErrNotFound
+	}
+	c := f.cached.colr
+	if c.numBaseGlyphPaints == 0 {
+		return nil, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	const recordSize = 6
+	for lo, hi := int32(0), c.numBaseGlyphPaints; lo < hi; {
+		i := (lo + hi) / 2
+		buf, err := f.viewCOLR(b, c.baseGlyphPaints+4+recordSize*uint32(i), recordSize)
+		if err != nil {
+			return nil, err
+		}
+		if g := GlyphIndex(u16(buf)); x < g {
+			hi = i
+		} else if x > g {
+			lo = i + 1
+		} else {
+			d := paintDecoder{f: f, b: b}
+			return d.decode(c.baseGlyphPaints+u32(buf[2:]), 0)
+		}
+	}
+	return nil, nil
+}
+
+// viewCOLR returns the n bytes at the offset o, relative to the start of the
+// COLR table.
+func (f *Font) viewCOLR(b *Buffer, o, n uint32) ([]byte, error) {
+	if o > f.colr.length || n > f.colr.length-o {
+		return nil, errInvalidCOLRTable
+	}
+	return b.view(&f.src, int(f.colr.offset+o), int(n))
+}
+
+// paintDecoder decodes a paint graph into a tree of Paint values.
+type paintDecoder struct {
+	f        *Font
+	b        *Buffer
+	numNodes int
+}
+
+// decode decodes the Paint table at the offset o, relative to the start of the
+// COLR table. depth is the depth of that table in the paint graph.
+func (d *paintDecoder) decode(o uint32, depth int) (Paint, error) {
+	// A paint graph can refer to a paint table more than once, and so a small
+	// graph can expand to a very large tree. It can also contain cycles.
+	if depth > maxColorPaintDepth {
+		return nil, errInvalidCOLRTable
+	}
+	if d.numNodes++; d.numNodes > maxColorPaintNodes {
+		return nil, errUnsupportedNumberOfColorPaints
+	}
+
+	buf, err := d.f.viewCOLR(d.b, o, 1)
+	if err != nil {
+		return nil, err
+	}
+	format := buf[0]
+	if format == 0 || int(format) >= len(paintFormatSizes) {
+		return nil, errUnsupportedCOLRTable
+	}
+	// The Var formats have the same layout as the preceding formats, followed
+	// by a varIndexBase, which we ignore.
+	buf, err = d.f.viewCOLR(d.b, o, paintFormatSizes[format])
+	if err != nil {
+		return nil, err
+	}
+	// Copy the fixed-size fields, as decoding child paints may re-use the
+	// Buffer's memory.
+	var p [32]byte
+	copy(p[:], buf)
+
+	child := func() (Paint, error) { return d.decode(o+u24(p[1:]), depth+1) }
+	fword := func(i int) float64 { return float64(int16(u16(p[i:]))) }
+	f2dot14 := func(i int) float64 { return f2Dot14(u16(p[i:])) }
+
+	switch format {
+	case 1: // PaintColrLayers.
+		n, first := uint32(p[1]), u32(p[2:])
+		c := d.f.cached.colr
+		if uint64(first)+uint64(n) > uint64(c.numLayerPaints) {
+			return nil, errInvalidCOLRTable
+		}
+		ret := &PaintLayers{Layers: make([]Paint, n)}
+		for i := range ret.Layers {
+			buf, err := d.f.viewCOLR(d.b, c.layerPaints+4+4*(first+uint32(i)), 4)
+			if err != nil {
+				return nil, err
+			}
+			if ret.Layers[i], err = d.decode(c.layerPaints+u32(buf), depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+
+	case 2, 3: // PaintSolid, PaintVarSolid.
+		return &PaintSolid{PaletteIndex: u16(p[1:]), Alpha: f2dot14(3)}, nil
+
+	case 4, 5: // PaintLinearGradient, PaintVarLinearGradient.
+		cl, err := d.decodeColorLine(o+u24(p[1:]), format == 5)
+		if err != nil {
+			return nil, err
+		}
+		return &PaintLinearGradient{
+			ColorLine: cl,
+			X0:        fword(4),
+			Y0:        fword(6),
+			X1:        fword(8),
+			Y1:        fword(10),
+			X2:        fword(12),
+			Y2:        fword(14),
+		}, nil
+
+	case 6, 7: // PaintRadialGradient, PaintVarRadialGradient.
+		cl, err := d.decodeColorLine(o+u24(p[1:]), format == 7)
+		if err != nil {
+			return nil, err
+		}
+		return &PaintRadialGradient{
+			ColorLine: cl,
+			X0:        fword(4),
+			Y0:        fword(6),
+			R0:        float64(u16(p[8:])),
+			X1:        fword(10),
+			Y1:        fword(12),
+			R1:        float64(u16(p[14:])),
+		}, nil
+
+	case 8, 9: // PaintSweepGradient, PaintVarSweepGradient.
+		cl, err := d.decodeColorLine(o+u24(p[1:]), format == 9)
+		if err != nil {
+			return nil, err
+		}
+		return &PaintSweepGradient{
+			ColorLine:  cl,
+			CenterX:    fword(4),
+			CenterY:    fword(6),
+			StartAngle: 180 * f2dot14(8),
+			EndAngle:   180 * f2dot14(10),
+		}, nil
+
+	case 10: // PaintGlyph.
+		c, err := child()
+		if err != nil {
+			return nil, err
+		}
+		return &PaintGlyph{GlyphIndex: GlyphIndex(u16(p[4:])), Paint: c}, nil
+
+	case 11: // PaintColrGlyph.
+		return &PaintColorGlyph{GlyphIndex: GlyphIndex(u16(p[1:]))}, nil
+
+	case 32: // PaintComposite.
+		src, err := child()
+		if err != nil {
+			return nil, err
+		}
+		backdrop, err := d.decode(o+u24(p[5:]), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if p[4] > uint8(CompositeLuminosity) {
+			return nil, errUnsupportedCOLRTable
+		}
+		return &PaintComposite{Mode: CompositeMode(p[4]), Source: src, Backdrop: backdrop}, nil
+	}
+
+	// The remaining formats, 12 to 31, are transforms.
+	var m [6]float64
+	switch format {
+	case 12, 13: // PaintTransform, PaintVarTransform.
+		t := o + u24(p[4:])
+		size := uint32(24)
+		if format == 13 {
+			size += 4
+		}
+		buf, err := d.f.viewCOLR(d.b, t, size)
+		if err != nil {
+			return nil, err
+		}
+		for i := range m {
+			m[i] = fixed16Dot16(u32(buf[4*i:]))
+		}
+	case 14, 15: // PaintTranslate, PaintVarTranslate.
+		m = [6]float64{1, 0, 0, 1, fword(4), fword(6)}
+	case 16, 17, 18, 19: // PaintScale and variants.
+		m = [6]float64{f2dot14(4), 0, 0, f2dot14(6), 0, 0}
+		if format >= 18 {
+			m = aroundCenter(m, fword(8), fword(10))
+		}
+	case 20, 21, 22, 23: // PaintScaleUniform and variants.
+		m = [6]float64{f2dot14(4), 0, 0, f2dot14(4), 0, 0}
+		if format >= 22 {
+			m = aroundCenter(m, fword(6), fword(8))
+		}
+	case 24, 25, 26, 27: // PaintRotate and variants.
+		// Angles are in units of 180 degrees, counter-clockwise.
+		sin, cos := math.Sincos(math.Pi * f2dot14(4))
+		m = [6]float64{cos, sin, -sin, cos, 0, 0}
+		if format >= 26 {
+			m = aroundCenter(m, fword(6), fword(8))
+		}
+	case 28, 29, 30, 31: // PaintSkew and variants.
+		// A positive x skew angle rotates the y axis counter-clockwise.
+		m = [6]float64{1, math.Tan(math.Pi * f2dot14(6)), -math.Tan(math.Pi * f2dot14(4)), 1, 0, 0}
+		if format >= 30 {
+			m = aroundCenter(m, fword(8), fword(10))
+		}
+	}
+	c, err := child()
+	if err != nil {
+		return nil, err
+	}
+	return &PaintTransform{Matrix: m, Paint: c}, nil
+}
+
+// paintFormatSizes are the sizes in bytes of each Paint table format, not
+// including any sub-tables.
+var paintFormatSizes = [...]uint32{
+	1:  6,
+	2:  5,
+	3:  9,
+	4:  16,
+	5:  20,
+	6:  16,
+	7:  20,
+	8:  12,
+	9:  16,
+	10: 6,
+	11: 3,
+	12: 7,
+	13: 7,
+	14: 8,
+	15: 12,
+	16: 8,
+	17: 12,
+	18: 12,
+	19: 16,
+	20: 6,
+	21: 10,
+	22: 10,
+	23: 14,
+	24: 6,
+	25: 10,
+	26: 10,
+	27: 14,
+	28: 8,
+	29: 12,
+	30: 12,
+	31: 16,
+	32: 8,
+}
+
+// decodeColorLine decodes the ColorLine, or VarColorLine if isVar, at the
+// offset o relative to the start of the COLR table.
+func (d *paintDecoder) decodeColorLine(o uint32, isVar bool) (ColorLine, error) {
+	buf, err := d.f.viewCOLR(d.b, o, 3)
+	if err != nil {
+		return ColorLine{}, err
+	}
+	extend, n := buf[0], uint32(u16(buf[1:]))
+	if extend > uint8(ExtendReflect) {
+		// "If a ColorLine in a font has an unrecognized extend value,
+		// applications should use EXTEND_PAD by default."
+		extend = uint8(ExtendPad)
+	}
+	stopSize := uint32(6)
+	if isVar {
+		stopSize += 4
+	}
+	buf, err = d.f.viewCOLR(d.b, o+3, n*stopSize)
+	if err != nil {
+		return ColorLine{}, err
+	}
+	cl := ColorLine{Extend: Extend(extend), Stops: make([]ColorStop, n)}
+	for i := range cl.Stops {
+		s := buf[stopSize*uint32(i):]
+		cl.Stops[i] = ColorStop{
+			Offset:       f2Dot14(u16(s)),
+			PaletteIndex: u16(s[2:]),
+			Alpha:        f2Dot14(u16(s[4:])),
+		}
+	}
+	return cl, nil
+}
+
+// aroundCenter returns the transformation m applied around the center (cx,
+// cy) instead of around the origin.
+func aroundCenter(m [6]float64, cx, cy float64) [6]float64 {
+	m[4] += cx - m[0]*cx - m[2]*cy
+	m[5] += cy - m[1]*cx - m[3]*cy
+	return m
+}
+
+func u24(b []byte) uint32 {
+	_ = b[2] // Bounds check hint to compiler.
+	return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])<<0
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// DecorationMetrics holds a font's suggested metrics for drawing underlines
+// and strikethroughs.
+type DecorationMetrics struct {
+	// UnderlinePosition is the distance from the baseline to the top of the
+	// underline, and is typically negative, as the underline is below the
+	// baseline. UnderlineThickness is the underline's thickness. Both come
+	// from the post table.
+	UnderlinePosition  Units
+	UnderlineThickness Units
+
+	// StrikeoutPosition is the distance from the baseline to the top of the
+	// strikeout stroke, and is typically positive. StrikeoutSize is the
+	// stroke's thickness. Both come from the OS/2 table, and are zero if the
+	// font has no OS/2 table.
+	StrikeoutPosition Units
+	StrikeoutSize     Units
+}
+
+// DecorationMetrics returns f's underline and strikeout metrics.
+func (f *Font) DecorationMetrics(b *Buffer) (DecorationMetrics, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	// https://www.microsoft.com/typography/otspec/post.htm
+	buf, err := b.view(&f.src, int(f.post.offset)+8, 4)
+	if err != nil {
+		return DecorationMetrics{}, err
+	}
+	m := DecorationMetrics{
+		UnderlinePosition:  Units(int16(u16(buf[0:]))),
+		UnderlineThickness: Units(int16(u16(buf[2:]))),
+	}
+
+	// https://www.microsoft.com/typography/otspec/os2.htm
+	if f.os2.length == 0 {
+		return m, nil
+	}
+	if f.os2.length < 30 {
+		return DecorationMetrics{}, errInvalidOS2Table
+	}
+	buf, err = b.view(&f.src, int(f.os2.offset)+26, 4)
+	if err != nil {
+		return DecorationMetrics{}, err
+	}
+	m.StrikeoutSize = Units(int16(u16(buf[0:])))
+	m.StrikeoutPosition = Units(int16(u16(buf[2:])))
+	return m, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestDecorationMetrics(t *testing.T) {
+	cffTest, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+		want DecorationMetrics
+	}{{
+		name: "goregular",
+		data: goregular.TTF,
+		want: DecorationMetrics{
+			UnderlinePosition:  -125,
+			UnderlineThickness: 50,
+			StrikeoutPosition:  512,
+			StrikeoutSize:      102,
+		},
+	}, {
+		name: "CFFTest.otf",
+		data: cffTest,
+		want: DecorationMetrics{
+			UnderlinePosition:  -125,
+			UnderlineThickness: 50,
+			StrikeoutPosition:  258,
+			StrikeoutSize:      49,
+		},
+	}}
+
+	for _, tc := range testCases {
+		f, err := Parse(tc.data)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", tc.name, err)
+			continue
+		}
+		got, err := f.DecorationMetrics(nil)
+		if err != nil {
+			t.Errorf("%s: DecorationMetrics: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", tc.name, got, tc.want)
+		}
+	}
+}
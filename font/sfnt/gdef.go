@@ -0,0 +1,97 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements the GDEF (Glyph Definition) table, as described at
+// https://www.microsoft.com/typography/otspec/gdef.htm
+
+// GlyphClass is a glyph's class in the GDEF table. The GSUB and GPOS tables'
+// lookups use it to tell marks, such as accents and vowel signs, from the
+// glyphs that they attach to.
+type GlyphClass uint16
+
+const (
+	// GlyphClassNone is the class of glyphs that the GDEF table doesn't
+	// classify, and of every glyph if the font has no GDEF table.
+	GlyphClassNone GlyphClass = 0
+	// GlyphClassBase is for single character, spacing glyphs.
+	GlyphClassBase GlyphClass = 1
+	// GlyphClassLigature is for multiple character, spacing glyphs.
+	GlyphClassLigature GlyphClass = 2
+	// GlyphClassMark is for non-spacing combining glyphs.
+	GlyphClassMark GlyphClass = 3
+	// GlyphClassComponent is for glyphs that are part of a single
+	// character.
+	GlyphClassComponent GlyphClass = 4
+)
+
+// gdefInfo holds the offsets, relative to the start of the GDEF table, of
+// its ClassDef tables. Zero means that there is no such table.
+type gdefInfo struct {
+	glyphClassDef      uint32
+	markAttachClassDef uint32
+}
+
+func (f *Font) parseGDEF(buf []byte) ([]byte, error) {
+	if f.gdef.length == 0 {
+		return buf, nil
+	}
+	// The version 1.2 and 1.3 headers extend the version 1.0 header, which is
+	// all that is needed.
+	const headerSize = 12
+	if f.gdef.length < headerSize {
+		return nil, errInvalidGDEFTable
+	}
+	buf, err := f.src.view(buf, int(f.gdef.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if u16(buf) != 1 {
+		return nil, errUnsupportedGDEFTable
+	}
+	g := gdefInfo{
+		glyphClassDef:      uint32(u16(buf[4:])),
+		markAttachClassDef: uint32(u16(buf[10:])),
+	}
+	if g.glyphClassDef >= f.gdef.length || g.markAttachClassDef >= f.gdef.length {
+		return nil, errInvalidGDEFTable
+	}
+	f.cached.gdef = g
+	return buf, nil
+}
+
+// GlyphClass returns the x'th glyph's class in the GDEF table.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) GlyphClass(b *Buffer, x GlyphIndex) (GlyphClass, error) {
+	if err := f.skippedTable("GDEF"); err != nil {
+		return 0, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return 0, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	return f.glyphClass(b, x)
+}
+
+// glyphClass is like GlyphClass, without the checks.
+func (f *Font) glyphClass(b *Buffer, x GlyphIndex) (GlyphClass, error) {
+	if f.cached.gdef.glyphClassDef == 0 {
+		return GlyphClassNone, nil
+	}
+	c, err := f.classDefValue(b, layoutTable{table: f.gdef}, f.cached.gdef.glyphClassDef, x)
+	return GlyphClass(c), err
+}
+
+// markAttachClass returns the x'th glyph's mark attachment class in the GDEF
+// table, or zero if there is no such class.
+func (f *Font) markAttachClass(b *Buffer, x GlyphIndex) (uint16, error) {
+	if f.cached.gdef.markAttachClassDef == 0 {
+		return 0, nil
+	}
+	return f.classDefValue(b, layoutTable{table: f.gdef}, f.cached.gdef.markAttachClassDef, x)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements access to a font's hinting zones, for rasterizers that
+// do their own hinting.
+
+import (
+	"sort"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// BlueZone is a range of y coordinates, in font units, whose edges are
+// snapped to a common pixel row, such as the baseline or the x-height. For a
+// top zone, Overshoot >= Flat. For a bottom zone, Overshoot <= Flat.
+type BlueZone struct {
+	// Flat is the position of flat edges, such as the top of an 'x', and
+	// Overshoot is that of round edges, such as the top of an 'o'.
+	Flat      Units
+	Overshoot Units
+	// Top is whether the zone holds edges with ink below them, such as the
+	// x-height, as opposed to edges with ink above them, such as the
+	// baseline.
+	Top bool
+}
+
+// Stem is a stem hint: the range of coordinates, along one axis, between the
+// two edges of one of a glyph's strokes. Max is usually greater than Min but,
+// for PostScript fonts' ghost hints, which mark a single edge, Max is Min-20
+// or Min-21 as per 5177.Type2.pdf section 4.1 "Hints".
+type Stem struct {
+	Min, Max Units
+}
+
+// GlyphHints are a glyph's stem hints, in font units.
+type GlyphHints struct {
+	// HStems are the horizontal stems, which are ranges of y coordinates,
+	// and VStems are the vertical stems, which are ranges of x coordinates.
+	HStems []Stem
+	VStems []Stem
+}
+
+// BlueZones returns f's blue zones.
+//
+// For fonts with PostScript outlines, these are the BlueValues and OtherBlues
+// of the (first) Private DICT. For other fonts, or if those are absent, they
+// are measured from the outlines of reference glyphs, such as 'H' and 'O' for
+// the cap height, in the same way that LoadGlyph's autohinter does.
+func (f *Font) BlueZones(b *Buffer) ([]BlueZone, error) {
+	if zones := f.cached.cff.blueZones; len(zones) != 0 {
+		return append([]BlueZone(nil), zones...), nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	var a autohinter
+	if err := a.init(b, f); err != nil {
+		return nil, err
+	}
+	return a.zones, nil
+}
+
+// LoadGlyphHints returns the stem hints for the x'th glyph.
+//
+// For fonts with PostScript outlines, these are the hstem, vstem, hstemhm and
+// vstemhm hints in the glyph's charstring. TrueType hinting instructions do
+// not have an equivalent, so for other fonts, the stems are detected from the
+// glyph's outline in the same way that LoadGlyph's autohinter does.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) LoadGlyphHints(b *Buffer, x GlyphIndex) (GlyphHints, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	if f.cached.isPostScript {
+		buf, err := f.viewGlyphData(b, x)
+		if err != nil {
+			return GlyphHints{}, err
+		}
+		h := GlyphHints{}
+		d := &b.psi.type2Charstrings
+		b.psi.cff = &f.cached.cff
+		d.initialize(f, x, b.segments[:0])
+		d.hints = &h
+		err = b.psi.run(psContextType2Charstring, buf)
+		d.hints = nil
+		if err != nil {
+			return GlyphHints{}, err
+		}
+		return h, nil
+	}
+
+	// Loading the glyph at a ppem of unitsPerEm gives segments in font units.
+	ppem := fixed.Int26_6(f.cached.unitsPerEm)
+	segments, err := f.LoadGlyph(b, x, ppem, nil)
+	if err != nil {
+		return GlyphHints{}, err
+	}
+	h := GlyphHints{}
+	if len(segments) == 0 {
+		return h, nil
+	}
+	var a autohinter
+	ccw := signedArea(segments) > 0
+	for axis, stems := range [2]*[]Stem{&h.VStems, &h.HStems} {
+		a.findEdges(segments, ppem, axis, ccw)
+		a.findStems(ppem)
+		for _, s := range a.stems {
+			*stems = append(*stems, Stem{
+				Min: Units(a.edges[s.lo].pos),
+				Max: Units(a.edges[s.hi].pos),
+			})
+		}
+		sort.Slice(*stems, func(i, j int) bool {
+			return (*stems)[i].Min < (*stems)[j].Min
+		})
+	}
+	return h, nil
+}
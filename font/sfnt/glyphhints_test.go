@@ -0,0 +1,115 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestPostScriptHints(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	gotZones, err := f.BlueZones(nil)
+	if err != nil {
+		t.Fatalf("BlueZones: %v", err)
+	}
+	wantZones := []BlueZone{
+		{Flat: 0, Overshoot: -20},
+		{Flat: 794, Overshoot: 794, Top: true},
+	}
+	if !reflect.DeepEqual(gotZones, wantZones) {
+		t.Errorf("BlueZones:\ngot  %v\nwant %v", gotZones, wantZones)
+	}
+
+	testCases := []GlyphHints{{
+		HStems: []Stem{{0, 50}, {483, 533}},
+		VStems: []Stem{{50, 100}, {400, 450}},
+	}, {
+		HStems: []Stem{{0, 100}, {700, 800}},
+		VStems: []Stem{{100, 180}, {420, 500}},
+	}, {
+		// The horizontal stem is a ghost hint, for the bottom edge at y=0.
+		HStems: []Stem{{21, 0}},
+		VStems: []Stem{{100, 300}},
+	}}
+	var b Buffer
+	for i, want := range testCases {
+		got, err := f.LoadGlyphHints(&b, GlyphIndex(i))
+		if err != nil {
+			t.Errorf("i=%d: LoadGlyphHints: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("i=%d:\ngot  %v\nwant %v", i, got, want)
+		}
+	}
+}
+
+func TestTrueTypeHints(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	gotZones, err := f.BlueZones(nil)
+	if err != nil {
+		t.Fatalf("BlueZones: %v", err)
+	}
+	wantZones := []BlueZone{
+		{Flat: 1480, Overshoot: 1517, Top: true},
+		{Flat: 1086, Overshoot: 1110, Top: true},
+		{Flat: 1579, Overshoot: 1579, Top: true},
+		{Flat: 0, Overshoot: -37},
+		{Flat: -395, Overshoot: -395},
+	}
+	if !reflect.DeepEqual(gotZones, wantZones) {
+		t.Errorf("BlueZones:\ngot  %v\nwant %v", gotZones, wantZones)
+	}
+
+	testCases := []struct {
+		r    rune
+		want GlyphHints
+	}{{
+		r: 'H',
+		want: GlyphHints{
+			HStems: []Stem{{699, 856}},
+			VStems: []Stem{{165, 375}, {1104, 1313}},
+		},
+	}, {
+		r: 'O',
+		want: GlyphHints{
+			HStems: []Stem{{-37, 120}, {1360, 1517}},
+			VStems: []Stem{{93, 318}, {1276, 1501}},
+		},
+	}}
+	var b Buffer
+	for _, tc := range testCases {
+		x, err := f.GlyphIndex(&b, tc.r)
+		if err != nil {
+			t.Errorf("r=%q: GlyphIndex: %v", tc.r, err)
+			continue
+		}
+		got, err := f.LoadGlyphHints(&b, x)
+		if err != nil {
+			t.Errorf("r=%q: LoadGlyphHints: %v", tc.r, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("r=%q:\ngot  %v\nwant %v", tc.r, got, tc.want)
+		}
+	}
+}
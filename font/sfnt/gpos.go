@@ -0,0 +1,446 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements the GPOS (Glyph Positioning) table, as described at
+// https://www.microsoft.com/typography/otspec/gpos.htm
+
+const (
+	gposLookupTypePair       = 2
+	gposLookupTypeCursive    = 3
+	gposLookupTypeMarkToBase = 4
+	gposLookupTypeMarkToMark = 6
+	gposLookupTypeExtension  = 9
+)
+
+var (
+	// tagCurs is the "curs" feature tag.
+	tagCurs = MustParseTag("curs")
+	// tagKern is the "kern" feature tag.
+	tagKern = MustParseTag("kern")
+	// tagMark is the "mark" feature tag.
+	tagMark = MustParseTag("mark")
+	// tagMkmk is the "mkmk" feature tag.
+	tagMkmk = MustParseTag("mkmk")
+)
+
+func (f *Font) parseGPOS(buf []byte) ([]byte, error) {
+	buf, lt, err := f.parseLayoutTable(buf, f.gpos, errInvalidGPOSTable)
+	if err != nil || lt.length == 0 {
+		return buf, err
+	}
+	f.cached.gpos = lt
+
+	buf, features, err := f.parseLayoutFeatures(buf, lt)
+	if err != nil {
+		return nil, err
+	}
+	// Only the feature's lookups of the given type are used. For example,
+	// only pair adjustment lookups affect the kerning between two glyphs.
+	for _, l := range [...]struct {
+		feature    Tag
+		lookupType uint16
+		dst        *[][]uint32
+	}{
+		{tagKern, gposLookupTypePair, &f.cached.gposKern},
+		{tagCurs, gposLookupTypeCursive, &f.cached.gposCursive},
+		{tagMark, gposLookupTypeMarkToBase, &f.cached.gposMarkBase},
+		{tagMkmk, gposLookupTypeMarkToMark, &f.cached.gposMarkMark},
+	} {
+		for _, i := range features[l.feature] {
+			var ls lookupSubtables
+			buf, ls, err = f.layoutLookupSubtables(buf, lt, i, gposLookupTypeExtension, errInvalidGPOSTable)
+			if err != nil {
+				return nil, err
+			}
+			if ls.lookupType == l.lookupType {
+				*l.dst = append(*l.dst, ls.offsets)
+			}
+		}
+	}
+	return buf, nil
+}
+
+// valueRecordSize returns the size in bytes of a GPOS ValueRecord with the
+// given ValueFormat.
+func valueRecordSize(format uint16) uint32 {
+	n := uint32(0)
+	for format &= 0xff; format != 0; format &= format - 1 {
+		n += 2
+	}
+	return n
+}
+
+// valueRecordXAdvance returns the XAdvance field of the ValueRecord at the
+// offset o, relative to the start of the GPOS table, with the given
+// ValueFormat. It is zero if the ValueFormat has no XAdvance field.
+func (f *Font) valueRecordXAdvance(b *Buffer, o uint32, format uint16) (int16, error) {
+	const xAdvance = 0x0004
+	if format&xAdvance == 0 {
+		return 0, nil
+	}
+	u, err := f.layoutU16(b, f.cached.gpos, o+valueRecordSize(format&(xAdvance-1)))
+	return int16(u), err
+}
+
+// gposKern returns the horizontal adjustment, in font units, for the kerning
+// pair (x0, x1) by applying the GPOS table's "kern" feature's pair adjustment
+// lookups. Each lookup's adjustment is summed.
+func (f *Font) gposKern(b *Buffer, x0, x1 GlyphIndex) (int32, error) {
+	kern := int32(0)
+	for _, subtables := range f.cached.gposKern {
+		for _, o := range subtables {
+			k, ok, err := f.gposPairAdjustment(b, o, x0, x1)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				// Only the first subtable that matches within a lookup
+				// applies.
+				kern += int32(k)
+				break
+			}
+		}
+	}
+	return kern, nil
+}
+
+// gposPairAdjustment returns the first glyph's XAdvance adjustment for the
+// pair (x0, x1) in the PairPos subtable at the offset o, relative to the start
+// of the GPOS table, and whether that subtable matched the pair.
+func (f *Font) gposPairAdjustment(b *Buffer, o uint32, x0, x1 GlyphIndex) (int16, bool, error) {
+	lt := f.cached.gpos
+	const headerSize = 10
+	if o > lt.length || lt.length-o < headerSize {
+		return 0, false, errInvalidGPOSTable
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), headerSize)
+	if err != nil {
+		return 0, false, err
+	}
+	format := u16(buf)
+	coverage := o + uint32(u16(buf[2:]))
+	valueFormat1 := u16(buf[4:])
+	valueFormat2 := u16(buf[6:])
+	recordSize := valueRecordSize(valueFormat1) + valueRecordSize(valueFormat2)
+
+	switch format {
+	case 1:
+		// https://www.microsoft.com/typography/otspec/gpos.htm#lookup-type-2-pair-adjustment-positioning-subtable
+		// "Pair Positioning Format 1: Adjustments for Glyph Pairs".
+		pairSetCount := u16(buf[8:])
+		i, ok, err := f.coverageIndex(b, lt, coverage, x0)
+		if err != nil || !ok {
+			return 0, false, err
+		}
+		if i >= int(pairSetCount) {
+			return 0, false, errInvalidGPOSTable
+		}
+		u, err := f.layoutU16(b, lt, o+headerSize+2*uint32(i))
+		if err != nil {
+			return 0, false, err
+		}
+		pairSet := o + uint32(u)
+		n, err := f.layoutU16(b, lt, pairSet)
+		if err != nil {
+			return 0, false, err
+		}
+		entrySize := 2 + recordSize
+		if uint32(n)*entrySize > lt.length-pairSet-2 {
+			return 0, false, errInvalidGPOSTable
+		}
+		for lo, hi := uint32(0), uint32(n); lo < hi; {
+			i := (lo + hi) / 2
+			e := pairSet + 2 + i*entrySize
+			g, err := f.layoutU16(b, lt, e)
+			if err != nil {
+				return 0, false, err
+			}
+			if x1 < GlyphIndex(g) {
+				hi = i
+			} else if x1 > GlyphIndex(g) {
+				lo = i + 1
+			} else {
+				k, err := f.valueRecordXAdvance(b, e+2, valueFormat1)
+				return k, err == nil, err
+			}
+		}
+		// The first glyph is covered but the pair isn't listed. Later
+		// subtables in the same lookup may still list it.
+		return 0, false, nil
+
+	case 2:
+		// https://www.microsoft.com/typography/otspec/gpos.htm#lookup-type-2-pair-adjustment-positioning-subtable
+		// "Pair Positioning Format 2: Class Pair Adjustment".
+		const format2HeaderSize = 16
+		if lt.length-o < format2HeaderSize {
+			return 0, false, errInvalidGPOSTable
+		}
+		buf, err = b.view(&f.src, int(lt.offset+o), format2HeaderSize)
+		if err != nil {
+			return 0, false, err
+		}
+		classDef1 := o + uint32(u16(buf[8:]))
+		classDef2 := o + uint32(u16(buf[10:]))
+		class1Count := uint32(u16(buf[12:]))
+		class2Count := uint32(u16(buf[14:]))
+
+		_, ok, err := f.coverageIndex(b, lt, coverage, x0)
+		if err != nil || !ok {
+			return 0, false, err
+		}
+		c1, err := f.classDefValue(b, lt, classDef1, x0)
+		if err != nil {
+			return 0, false, err
+		}
+		c2, err := f.classDefValue(b, lt, classDef2, x1)
+		if err != nil {
+			return 0, false, err
+		}
+		if uint32(c1) >= class1Count || uint32(c2) >= class2Count {
+			return 0, false, errInvalidGPOSTable
+		}
+		k, err := f.valueRecordXAdvance(b, o+format2HeaderSize+(uint32(c1)*class2Count+uint32(c2))*recordSize, valueFormat1)
+		return k, err == nil, err
+	}
+	return 0, false, errUnsupportedGPOSTable
+}
+
+// Anchor is a GPOS anchor point, in font units. Its y axis increases up.
+type Anchor struct {
+	X, Y Units
+}
+
+// CursiveAnchors are a glyph's cursive attachment anchors. Connected glyphs,
+// such as those of the Arabic script, are joined by positioning each glyph so
+// that its exit anchor coincides with the next glyph's entry anchor.
+type CursiveAnchors struct {
+	Entry, Exit       Anchor
+	HasEntry, HasExit bool
+}
+
+// CursiveAnchors returns the entry and exit anchors of the x'th glyph, from
+// the GPOS table's "curs" feature's cursive attachment lookups. The first
+// lookup that covers x applies.
+//
+// It returns a zero CursiveAnchors if no lookup covers x.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) CursiveAnchors(b *Buffer, x GlyphIndex) (CursiveAnchors, error) {
+	if err := f.skippedTable("GPOS"); err != nil {
+		return CursiveAnchors{}, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return CursiveAnchors{}, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	for _, subtables := range f.cached.gposCursive {
+		for _, o := range subtables {
+			a, ok, err := f.gposCursiveAnchors(b, o, x)
+			if err != nil {
+				return CursiveAnchors{}, err
+			}
+			if ok {
+				return a, nil
+			}
+		}
+	}
+	return CursiveAnchors{}, nil
+}
+
+// gposCursiveAnchors returns the anchors of x in the CursivePos subtable at
+// the offset o, relative to the start of the GPOS table, and whether that
+// subtable covers x.
+func (f *Font) gposCursiveAnchors(b *Buffer, o uint32, x GlyphIndex) (CursiveAnchors, bool, error) {
+	// https://www.microsoft.com/typography/otspec/gpos.htm#lookup-type-3-cursive-attachment-positioning-subtable
+	lt := f.cached.gpos
+	const headerSize, entrySize = 6, 4
+	if o > lt.length || lt.length-o < headerSize {
+		return CursiveAnchors{}, false, errInvalidGPOSTable
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), headerSize)
+	if err != nil {
+		return CursiveAnchors{}, false, err
+	}
+	if u16(buf) != 1 {
+		return CursiveAnchors{}, false, errUnsupportedGPOSTable
+	}
+	coverage := o + uint32(u16(buf[2:]))
+	entryExitCount := u16(buf[4:])
+
+	i, ok, err := f.coverageIndex(b, lt, coverage, x)
+	if err != nil || !ok {
+		return CursiveAnchors{}, false, err
+	}
+	if i >= int(entryExitCount) {
+		return CursiveAnchors{}, false, errInvalidGPOSTable
+	}
+	e := o + headerSize + entrySize*uint32(i)
+	if lt.length-o-headerSize < entrySize*uint32(i+1) {
+		return CursiveAnchors{}, false, errInvalidGPOSTable
+	}
+	buf, err = b.view(&f.src, int(lt.offset+e), entrySize)
+	if err != nil {
+		return CursiveAnchors{}, false, err
+	}
+	entryOffset, exitOffset := u16(buf), u16(buf[2:])
+
+	a := CursiveAnchors{}
+	if entryOffset != 0 {
+		if a.Entry, err = f.gposAnchor(b, o+uint32(entryOffset)); err != nil {
+			return CursiveAnchors{}, false, err
+		}
+		a.HasEntry = true
+	}
+	if exitOffset != 0 {
+		if a.Exit, err = f.gposAnchor(b, o+uint32(exitOffset)); err != nil {
+			return CursiveAnchors{}, false, err
+		}
+		a.HasExit = true
+	}
+	return a, true, nil
+}
+
+// gposAnchor returns the Anchor table at the offset o, relative to the start
+// of the GPOS table. The contour point of format 2 anchors and the device
+// tables of format 3 anchors, which only fine-tune hinted positions, are
+// ignored.
+func (f *Font) gposAnchor(b *Buffer, o uint32) (Anchor, error) {
+	lt := f.cached.gpos
+	const size = 6
+	if o > lt.length || lt.length-o < size {
+		return Anchor{}, errInvalidGPOSTable
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), size)
+	if err != nil {
+		return Anchor{}, err
+	}
+	if format := u16(buf); format < 1 || 3 < format {
+		return Anchor{}, errInvalidGPOSTable
+	}
+	return Anchor{
+		X: Units(int16(u16(buf[2:]))),
+		Y: Units(int16(u16(buf[4:]))),
+	}, nil
+}
+
+// MarkAnchors are the anchors that attach a mark glyph, such as an accent or
+// a vowel sign, to a base glyph, such as a letter, or to a preceding mark. The
+// mark is positioned so that its Mark anchor coincides with the base glyph's
+// Base anchor.
+type MarkAnchors struct {
+	Base, Mark Anchor
+}
+
+// MarkAnchors returns the anchors that attach the mark glyph to the base
+// glyph, from the GPOS table's "mark" feature's mark-to-base attachment
+// lookups. If toMark is true, base is a preceding mark glyph, and the anchors
+// are from the "mkmk" feature's mark-to-mark attachment lookups instead. The
+// first lookup that covers both glyphs applies, and ok is whether there is
+// such a lookup.
+//
+// It returns ErrNotFound if either glyph index is out of range.
+func (f *Font) MarkAnchors(b *Buffer, base, mark GlyphIndex, toMark bool) (a MarkAnchors, ok bool, err error) {
+	if err := f.skippedTable("GPOS"); err != nil {
+		return MarkAnchors{}, false, err
+	}
+	if int(base) >= f.NumGlyphs() || int(mark) >= f.NumGlyphs() {
+		return MarkAnchors{}, false, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	lookups := f.cached.gposMarkBase
+	if toMark {
+		lookups = f.cached.gposMarkMark
+	}
+	for _, subtables := range lookups {
+		for _, o := range subtables {
+			if a, ok, err = f.gposMarkAnchors(b, o, base, mark); err != nil || ok {
+				return a, ok, err
+			}
+		}
+	}
+	return MarkAnchors{}, false, nil
+}
+
+// gposMarkAnchors returns the anchors of the mark and base glyphs in the
+// MarkBasePos or MarkMarkPos subtable at the offset o, relative to the start
+// of the GPOS table, and whether that subtable covers both glyphs. The two
+// subtables have the same layout, with a MarkMarkPos subtable's second mark
+// array taking the place of the base array.
+func (f *Font) gposMarkAnchors(b *Buffer, o uint32, base, mark GlyphIndex) (MarkAnchors, bool, error) {
+	// https://www.microsoft.com/typography/otspec/gpos.htm#lookup-type-4-mark-to-base-attachment-positioning-subtable
+	lt := f.cached.gpos
+	header, err := f.layoutU16s(b, lt, o, 6)
+	if err != nil {
+		return MarkAnchors{}, false, err
+	}
+	if header[0] != 1 {
+		return MarkAnchors{}, false, errUnsupportedGPOSTable
+	}
+	markCoverage := o + uint32(header[1])
+	baseCoverage := o + uint32(header[2])
+	markClassCount := uint64(header[3])
+	markArray := o + uint32(header[4])
+	baseArray := o + uint32(header[5])
+
+	mi, ok, err := f.coverageIndex(b, lt, markCoverage, mark)
+	if err != nil || !ok {
+		return MarkAnchors{}, false, err
+	}
+	bi, ok, err := f.coverageIndex(b, lt, baseCoverage, base)
+	if err != nil || !ok {
+		return MarkAnchors{}, false, err
+	}
+
+	// The mark array is a count and then, for each mark, its class and the
+	// offset of its anchor, relative to the start of the mark array.
+	n, err := f.layoutU16(b, lt, markArray)
+	if err != nil {
+		return MarkAnchors{}, false, err
+	}
+	if mi >= int(n) {
+		return MarkAnchors{}, false, errInvalidGPOSTable
+	}
+	record, err := f.layoutU16s(b, lt, markArray+2+4*uint32(mi), 2)
+	if err != nil {
+		return MarkAnchors{}, false, err
+	}
+	class, markAnchor := uint64(record[0]), record[1]
+	if class >= markClassCount {
+		return MarkAnchors{}, false, errInvalidGPOSTable
+	}
+
+	// The base array is a count and then, for each base and mark class, the
+	// offset of the base's anchor, relative to the start of the base array.
+	// A zero offset means that the base has no anchor for that class.
+	n, err = f.layoutU16(b, lt, baseArray)
+	if err != nil {
+		return MarkAnchors{}, false, err
+	}
+	if bi >= int(n) {
+		return MarkAnchors{}, false, errInvalidGPOSTable
+	}
+	i := uint64(bi)*markClassCount + class
+	if i >= uint64(lt.length) {
+		return MarkAnchors{}, false, errInvalidGPOSTable
+	}
+	baseAnchor, err := f.layoutU16(b, lt, baseArray+2+2*uint32(i))
+	if err != nil || baseAnchor == 0 {
+		return MarkAnchors{}, false, err
+	}
+
+	a := MarkAnchors{}
+	if a.Base, err = f.gposAnchor(b, baseArray+uint32(baseAnchor)); err != nil {
+		return MarkAnchors{}, false, err
+	}
+	if a.Mark, err = f.gposAnchor(b, markArray+uint32(markAnchor)); err != nil {
+		return MarkAnchors{}, false, err
+	}
+	return a, true, nil
+}
@@ -0,0 +1,777 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements the GSUB (Glyph Substitution) table, as described at
+// https://www.microsoft.com/typography/otspec/gsub.htm
+
+const (
+	gsubLookupTypeSingle         = 1
+	gsubLookupTypeMultiple       = 2
+	gsubLookupTypeAlternate      = 3
+	gsubLookupTypeLigature       = 4
+	gsubLookupTypeContext        = 5
+	gsubLookupTypeChainedContext = 6
+	gsubLookupTypeExtension      = 7
+)
+
+// maxContextDepth is how deeply contextual lookups may nest, as each one
+// applies other lookups, possibly contextual ones, to the glyphs it matches.
+const maxContextDepth = 8
+
+func (f *Font) parseGSUB(buf []byte) ([]byte, error) {
+	buf, lt, err := f.parseLayoutTable(buf, f.gsub, errInvalidGSUBTable)
+	if err != nil || lt.length == 0 {
+		return buf, err
+	}
+	f.cached.gsub = lt
+
+	buf, f.cached.gsubFeatures, err = f.parseLayoutFeatures(buf, lt)
+	if err != nil {
+		return nil, err
+	}
+	numLookups, err := f.src.u16(buf, lt.table, int(lt.lookupList))
+	if err != nil {
+		return nil, err
+	}
+	for _, indexes := range f.cached.gsubFeatures {
+		for _, i := range indexes {
+			if i >= numLookups {
+				return nil, errInvalidGSUBTable
+			}
+		}
+	}
+	// All of the lookups are parsed, not only those used by features, as
+	// contextual lookups apply other lookups by index.
+	f.cached.gsubLookups = make([]lookupSubtables, numLookups)
+	for i := range f.cached.gsubLookups {
+		var ls lookupSubtables
+		buf, ls, err = f.layoutLookupSubtables(buf, lt, uint16(i), gsubLookupTypeExtension, errInvalidGSUBTable)
+		if err != nil {
+			return nil, err
+		}
+		// Reverse chaining contextual single substitutions are not
+		// supported. Those lookups are recorded with no subtables, and so
+		// have no effect.
+		if ls.lookupType > gsubLookupTypeExtension {
+			ls.offsets = []uint32{}
+		}
+		f.cached.gsubLookups[i] = ls
+	}
+	return buf, nil
+}
+
+// gsubLookupIndexes returns, in the order that they are to be applied, the
+// GSUB lookups used by any of the given features.
+func (f *Font) gsubLookupIndexes(features []Tag) []uint16 {
+	if len(features) == 1 {
+		return f.cached.gsubFeatures[features[0]]
+	}
+	var indexes []uint16
+	seen := map[uint16]bool{}
+	for _, tag := range features {
+		for _, i := range f.cached.gsubFeatures[tag] {
+			if !seen[i] {
+				seen[i] = true
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	sortLookupIndexes(indexes)
+	return indexes
+}
+
+// SubstituteGlyph returns the glyph that x is replaced with when the given GSUB
+// features, such as "smcp" (small capitals), "onum" (oldstyle figures) or
+// "ss01" (stylistic set 1), are enabled. It returns x if none of those
+// features replace it.
+//
+// Only single substitution and alternate substitution lookups are applied,
+// for any script and language system. For alternate substitutions, the first
+// alternate is chosen. Use GlyphAlternates to choose a different one, and
+// SubstituteGlyphs to apply the lookups that involve more than one glyph.
+func (f *Font) SubstituteGlyph(b *Buffer, x GlyphIndex, features ...Tag) (GlyphIndex, error) {
+	if err := f.skippedTable("GSUB"); err != nil {
+		return 0, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return 0, ErrNotFound
+	}
+	if f.cached.gsubFeatures == nil || len(features) == 0 {
+		return x, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	for _, i := range f.gsubLookupIndexes(features) {
+		ls := f.cached.gsubLookups[i]
+		if ls.lookupType != gsubLookupTypeSingle && ls.lookupType != gsubLookupTypeAlternate {
+			continue
+		}
+		for _, o := range ls.offsets {
+			y, ok, err := f.gsubOneToOne(b, ls.lookupType, o, x)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				// Only the first subtable that covers x within a lookup
+				// applies.
+				x = y
+				break
+			}
+		}
+	}
+	return x, nil
+}
+
+// gsubOneToOne returns the substitute for x in the single substitution or
+// alternate substitution subtable at the offset o, relative to the start of
+// the GSUB table, and whether that subtable covers x. For alternate
+// substitutions, the first alternate is chosen.
+func (f *Font) gsubOneToOne(b *Buffer, lookupType uint16, o uint32, x GlyphIndex) (GlyphIndex, bool, error) {
+	if lookupType == gsubLookupTypeSingle {
+		return f.gsubSingle(b, o, x)
+	}
+	alternates, err := f.gsubAlternates(b, nil, o, x)
+	if err != nil || len(alternates) == 0 {
+		return 0, false, err
+	}
+	return alternates[0], true, nil
+}
+
+var (
+	tagVert = MustParseTag("vert")
+	tagVrt2 = MustParseTag("vrt2")
+)
+
+// VerticalGlyph returns the glyph that x is replaced with in vertical text,
+// such as a rotated bracket or a repositioned CJK punctuation mark. It returns
+// x if x has no vertical alternate.
+//
+// The substitution comes from the GSUB table's "vrt2" (vertical alternates and
+// rotation) feature if the font has one, and its "vert" (vertical alternates)
+// feature otherwise, as "vrt2" is meant to supersede "vert".
+func (f *Font) VerticalGlyph(b *Buffer, x GlyphIndex) (GlyphIndex, error) {
+	if _, ok := f.cached.gsubFeatures[tagVrt2]; ok {
+		return f.SubstituteGlyph(b, x, tagVrt2)
+	}
+	return f.SubstituteGlyph(b, x, tagVert)
+}
+
+// GlyphAlternates returns the alternates for x that are provided by the
+// alternate substitution lookups of the given GSUB feature, typically "aalt"
+// (access all alternates), "salt" (stylistic alternates) or a character
+// variant such as "cv01". It returns nil if there are no such alternates.
+func (f *Font) GlyphAlternates(b *Buffer, x GlyphIndex, feature Tag) ([]GlyphIndex, error) {
+	if err := f.skippedTable("GSUB"); err != nil {
+		return nil, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return nil, ErrNotFound
+	}
+	if f.cached.gsubFeatures == nil {
+		return nil, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	var ret []GlyphIndex
+	for _, i := range f.cached.gsubFeatures[feature] {
+		ls := f.cached.gsubLookups[i]
+		if ls.lookupType != gsubLookupTypeAlternate {
+			continue
+		}
+		for _, o := range ls.offsets {
+			n := len(ret)
+			var err error
+			ret, err = f.gsubAlternates(b, ret, o, x)
+			if err != nil {
+				return nil, err
+			}
+			if len(ret) > n {
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+// gsubSingle returns the substitute for x in the SingleSubst subtable at the
+// offset o, relative to the start of the GSUB table, and whether that
+// subtable covers x.
+func (f *Font) gsubSingle(b *Buffer, o uint32, x GlyphIndex) (GlyphIndex, bool, error) {
+	lt := f.cached.gsub
+	const headerSize = 6
+	if o > lt.length || lt.length-o < headerSize {
+		return 0, false, errInvalidGSUBTable
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), headerSize)
+	if err != nil {
+		return 0, false, err
+	}
+	format := u16(buf)
+	coverage := o + uint32(u16(buf[2:]))
+	u := u16(buf[4:])
+
+	switch format {
+	case 1:
+		_, ok, err := f.coverageIndex(b, lt, coverage, x)
+		if err != nil || !ok {
+			return 0, false, err
+		}
+		// The deltaGlyphID is added modulo 65536.
+		return x + GlyphIndex(u), true, nil
+
+	case 2:
+		i, ok, err := f.coverageIndex(b, lt, coverage, x)
+		if err != nil || !ok {
+			return 0, false, err
+		}
+		if i >= int(u) {
+			return 0, false, errInvalidGSUBTable
+		}
+		y, err := f.layoutU16(b, lt, o+headerSize+2*uint32(i))
+		if err != nil {
+			return 0, false, err
+		}
+		return GlyphIndex(y), true, nil
+	}
+	return 0, false, errUnsupportedGSUBTable
+}
+
+// gsubAlternates appends the alternates for x in the AlternateSubst subtable
+// at the offset o, relative to the start of the GSUB table, to dst.
+func (f *Font) gsubAlternates(b *Buffer, dst []GlyphIndex, o uint32, x GlyphIndex) ([]GlyphIndex, error) {
+	lt := f.cached.gsub
+	const headerSize = 6
+	if o > lt.length || lt.length-o < headerSize {
+		return nil, errInvalidGSUBTable
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if u16(buf) != 1 {
+		return nil, errUnsupportedGSUBTable
+	}
+	coverage := o + uint32(u16(buf[2:]))
+	alternateSetCount := u16(buf[4:])
+
+	i, ok, err := f.coverageIndex(b, lt, coverage, x)
+	if err != nil || !ok {
+		return dst, err
+	}
+	if i >= int(alternateSetCount) {
+		return nil, errInvalidGSUBTable
+	}
+	u, err := f.layoutU16(b, lt, o+headerSize+2*uint32(i))
+	if err != nil {
+		return nil, err
+	}
+	alternateSet := o + uint32(u)
+	n, err := f.layoutU16(b, lt, alternateSet)
+	if err != nil {
+		return nil, err
+	}
+	if uint32(n)*2 > lt.length-alternateSet-2 {
+		return nil, errInvalidGSUBTable
+	}
+	buf, err = b.view(&f.src, int(lt.offset+alternateSet)+2, 2*int(n))
+	if err != nil {
+		return nil, err
+	}
+	for j := 0; j < int(n); j++ {
+		dst = append(dst, GlyphIndex(u16(buf[2*j:])))
+	}
+	return dst, nil
+}
+
+// LayoutGlyph is a glyph in a run of glyphs, such as a line of text, that GSUB
+// lookups are applied to.
+type LayoutGlyph struct {
+	GlyphIndex GlyphIndex
+
+	// Cluster identifies the text that the glyph is for, such as the byte
+	// offset of its rune. Substitutions keep it: a ligature has the smallest
+	// Cluster of the glyphs that it replaces, and the glyphs that replace one
+	// glyph have that glyph's Cluster.
+	Cluster int
+}
+
+// SubstituteGlyphs returns the glyphs that a run of glyphs, in logical order,
+// is replaced with when the given GSUB features, such as "liga" (standard
+// ligatures), "calt" (contextual alternates) or "half" (half forms), are
+// enabled. The glyphs' elements may be modified.
+//
+// Unlike SubstituteGlyph, it also applies the lookups that involve more than
+// one glyph: multiple substitution, ligature substitution, and contextual and
+// chained contextual substitution lookups. Reverse chaining contextual single
+// substitution lookups are not applied. The lookups are applied for any script
+// and language system, in order, each to the whole run.
+//
+// It returns ErrNotFound if a glyph index is out of range.
+func (f *Font) SubstituteGlyphs(b *Buffer, glyphs []LayoutGlyph, features ...Tag) ([]LayoutGlyph, error) {
+	if err := f.skippedTable("GSUB"); err != nil {
+		return nil, err
+	}
+	for _, g := range glyphs {
+		if int(g.GlyphIndex) >= f.NumGlyphs() {
+			return nil, ErrNotFound
+		}
+	}
+	if f.cached.gsubFeatures == nil || len(features) == 0 {
+		return glyphs, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	for _, i := range f.gsubLookupIndexes(features) {
+		for pos := 0; pos < len(glyphs); {
+			var err error
+			glyphs, pos, err = f.gsubApply(b, glyphs, pos, i, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return glyphs, nil
+}
+
+// gsubApply applies the lookup with the given index to glyphs[pos]. It
+// returns the glyphs, which may have been replaced, and the position of the
+// next glyph to apply the lookup to. depth is how deeply the lookup is nested
+// within contextual lookups.
+func (f *Font) gsubApply(b *Buffer, glyphs []LayoutGlyph, pos int, lookup uint16, depth int) ([]LayoutGlyph, int, error) {
+	if int(lookup) >= len(f.cached.gsubLookups) {
+		return nil, 0, errInvalidGSUBTable
+	}
+	ls := f.cached.gsubLookups[lookup]
+	x := glyphs[pos].GlyphIndex
+	if ignore, err := f.layoutIgnores(b, ls.flag, x); err != nil || ignore {
+		return glyphs, pos + 1, err
+	}
+	for _, o := range ls.offsets {
+		var (
+			ret  []LayoutGlyph
+			next int
+			ok   bool
+			err  error
+		)
+		switch ls.lookupType {
+		case gsubLookupTypeSingle, gsubLookupTypeAlternate:
+			var y GlyphIndex
+			if y, ok, err = f.gsubOneToOne(b, ls.lookupType, o, x); ok {
+				glyphs[pos].GlyphIndex = y
+				ret, next = glyphs, pos+1
+			}
+		case gsubLookupTypeMultiple:
+			ret, next, ok, err = f.gsubMultiple(b, glyphs, pos, o)
+		case gsubLookupTypeLigature:
+			ret, next, ok, err = f.gsubLigature(b, glyphs, pos, o, ls.flag)
+		case gsubLookupTypeContext, gsubLookupTypeChainedContext:
+			ret, next, ok, err = f.gsubContext(b, glyphs, pos, o, ls, depth)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			// Only the first subtable that matches within a lookup applies.
+			return ret, next, nil
+		}
+	}
+	return glyphs, pos + 1, nil
+}
+
+// gsubMultiple applies the MultipleSubst subtable at the offset o, relative to
+// the start of the GSUB table, to glyphs[pos], replacing it with a sequence of
+// glyphs. It returns the glyphs, the position after that sequence, and whether
+// the subtable covers glyphs[pos].
+func (f *Font) gsubMultiple(b *Buffer, glyphs []LayoutGlyph, pos int, o uint32) ([]LayoutGlyph, int, bool, error) {
+	lt := f.cached.gsub
+	header, err := f.layoutU16s(b, lt, o, 3)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if header[0] != 1 {
+		return nil, 0, false, errUnsupportedGSUBTable
+	}
+	i, ok, err := f.coverageIndex(b, lt, o+uint32(header[1]), glyphs[pos].GlyphIndex)
+	if err != nil || !ok {
+		return nil, 0, false, err
+	}
+	if i >= int(header[2]) {
+		return nil, 0, false, errInvalidGSUBTable
+	}
+	u, err := f.layoutU16(b, lt, o+6+2*uint32(i))
+	if err != nil {
+		return nil, 0, false, err
+	}
+	sequence := o + uint32(u)
+	n, err := f.layoutU16(b, lt, sequence)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	ys, err := f.layoutU16s(b, lt, sequence+2, int(n))
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	ret := make([]LayoutGlyph, 0, len(glyphs)+len(ys)-1)
+	ret = append(ret, glyphs[:pos]...)
+	for _, y := range ys {
+		ret = append(ret, LayoutGlyph{GlyphIndex: GlyphIndex(y), Cluster: glyphs[pos].Cluster})
+	}
+	ret = append(ret, glyphs[pos+1:]...)
+	return ret, pos + len(ys), true, nil
+}
+
+// gsubLigature applies the LigatureSubst subtable at the offset o, relative
+// to the start of the GSUB table, to the glyphs starting at glyphs[pos],
+// replacing them with a ligature. It returns the glyphs, the position after
+// the ligature, and whether one of the subtable's ligatures matched. flag is
+// the lookup's LookupFlag.
+func (f *Font) gsubLigature(b *Buffer, glyphs []LayoutGlyph, pos int, o uint32, flag uint16) ([]LayoutGlyph, int, bool, error) {
+	lt := f.cached.gsub
+	header, err := f.layoutU16s(b, lt, o, 3)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if header[0] != 1 {
+		return nil, 0, false, errUnsupportedGSUBTable
+	}
+	i, ok, err := f.coverageIndex(b, lt, o+uint32(header[1]), glyphs[pos].GlyphIndex)
+	if err != nil || !ok {
+		return nil, 0, false, err
+	}
+	if i >= int(header[2]) {
+		return nil, 0, false, errInvalidGSUBTable
+	}
+	u, err := f.layoutU16(b, lt, o+6+2*uint32(i))
+	if err != nil {
+		return nil, 0, false, err
+	}
+	ligatureSet := o + uint32(u)
+	n, err := f.layoutU16(b, lt, ligatureSet)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	ligatures, err := f.layoutU16s(b, lt, ligatureSet+2, int(n))
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	// The ligatures are in order of preference, typically longest first.
+	for _, u := range ligatures {
+		ligature := ligatureSet + uint32(u)
+		header, err := f.layoutU16s(b, lt, ligature, 2)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		if header[1] == 0 {
+			return nil, 0, false, errInvalidGSUBTable
+		}
+		components, err := f.layoutU16s(b, lt, ligature+4, int(header[1])-1)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		positions, ok, err := f.layoutMatch(b, glyphs, pos, 1, flag, len(components), func(j int, x GlyphIndex) (bool, error) {
+			return x == GlyphIndex(components[j]), nil
+		})
+		if err != nil {
+			return nil, 0, false, err
+		}
+		if !ok {
+			continue
+		}
+
+		// Replace the first component by the ligature and remove the others.
+		// The glyphs that the lookup skipped over, such as marks, are kept,
+		// after the ligature.
+		lig := LayoutGlyph{GlyphIndex: GlyphIndex(header[0]), Cluster: glyphs[pos].Cluster}
+		ret := make([]LayoutGlyph, 0, len(glyphs)-len(positions))
+		ret = append(ret, glyphs[:pos]...)
+		ret = append(ret, lig)
+		prev := pos + 1
+		for _, p := range positions {
+			if c := glyphs[p].Cluster; c < ret[pos].Cluster {
+				ret[pos].Cluster = c
+			}
+			ret = append(ret, glyphs[prev:p]...)
+			prev = p + 1
+		}
+		ret = append(ret, glyphs[prev:]...)
+		return ret, pos + 1, true, nil
+	}
+	return nil, 0, false, nil
+}
+
+// contextRule is a rule of a contextual or chained contextual substitution
+// subtable. Its sequences hold glyph indexes, classes or Coverage table
+// offsets, depending on the subtable's format. They are matched against the
+// glyphs before the current glyph, nearest first, the current glyph and the
+// glyphs after it, and those after the input glyphs. records holds the rule's
+// SequenceLookupRecords, as pairs of an index into input and a lookup index.
+type contextRule struct {
+	backtrack, input, lookahead []uint16
+	records                     []uint16
+}
+
+// gsubContextRule returns the rule at the offset o, relative to the start of
+// the GSUB table. If first is true, the rule's input sequence includes the
+// current glyph, as it does for format 3 subtables. Otherwise, the current
+// glyph is matched by the subtable's Coverage table and the input sequence
+// starts with the next glyph.
+func (f *Font) gsubContextRule(b *Buffer, o uint32, chained, first bool) (contextRule, error) {
+	lt := f.cached.gsub
+	p := o
+	readU16 := func() (uint16, error) {
+		u, err := f.layoutU16(b, lt, p)
+		p += 2
+		return u, err
+	}
+	readU16s := func(n int) ([]uint16, error) {
+		s, err := f.layoutU16s(b, lt, p, n)
+		p += 2 * uint32(n)
+		return s, err
+	}
+	// inputCount is the number of glyphs in the input sequence, including
+	// the current glyph.
+	inputCount := func() (int, error) {
+		n, err := readU16()
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			return 0, errInvalidGSUBTable
+		}
+		if first {
+			return int(n), nil
+		}
+		return int(n) - 1, nil
+	}
+
+	var (
+		r   contextRule
+		n   uint16
+		m   int
+		err error
+	)
+	if chained {
+		if n, err = readU16(); err != nil {
+			return contextRule{}, err
+		}
+		if r.backtrack, err = readU16s(int(n)); err != nil {
+			return contextRule{}, err
+		}
+		if m, err = inputCount(); err != nil {
+			return contextRule{}, err
+		}
+		if r.input, err = readU16s(m); err != nil {
+			return contextRule{}, err
+		}
+		if n, err = readU16(); err != nil {
+			return contextRule{}, err
+		}
+		if r.lookahead, err = readU16s(int(n)); err != nil {
+			return contextRule{}, err
+		}
+		if n, err = readU16(); err != nil {
+			return contextRule{}, err
+		}
+	} else {
+		if m, err = inputCount(); err != nil {
+			return contextRule{}, err
+		}
+		if n, err = readU16(); err != nil {
+			return contextRule{}, err
+		}
+		if r.input, err = readU16s(m); err != nil {
+			return contextRule{}, err
+		}
+	}
+	if r.records, err = readU16s(2 * int(n)); err != nil {
+		return contextRule{}, err
+	}
+	return r, nil
+}
+
+// gsubContext applies the contextual or chained contextual substitution
+// subtable at the offset o, relative to the start of the GSUB table, to the
+// glyphs starting at glyphs[pos]. It returns the glyphs, the position after
+// the matched input sequence, and whether one of the subtable's rules
+// matched. ls is the subtable's lookup.
+func (f *Font) gsubContext(b *Buffer, glyphs []LayoutGlyph, pos int, o uint32, ls lookupSubtables, depth int) ([]LayoutGlyph, int, bool, error) {
+	lt := f.cached.gsub
+	chained := ls.lookupType == gsubLookupTypeChainedContext
+	x := glyphs[pos].GlyphIndex
+	format, err := f.layoutU16(b, lt, o)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	var (
+		rules []contextRule
+		// eq returns whether the glyph y matches the value v of the
+		// backtrack (seq 0), input (seq 1) or lookahead (seq 2) sequence.
+		eq func(seq int, v uint16, y GlyphIndex) (bool, error)
+	)
+	switch format {
+	case 1, 2:
+		// Format 1 rules match glyph indexes, and are chosen by the current
+		// glyph's coverage index. Format 2 rules match classes, and are
+		// chosen by the current glyph's class, with one ClassDef table for
+		// each sequence if chained.
+		numClassDefs := 0
+		if format == 2 {
+			numClassDefs = 1
+			if chained {
+				numClassDefs = 3
+			}
+		}
+		header, err := f.layoutU16s(b, lt, o, 3+numClassDefs)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		i, ok, err := f.coverageIndex(b, lt, o+uint32(header[1]), x)
+		if err != nil || !ok {
+			return nil, 0, false, err
+		}
+		first := uint16(x)
+		if format == 1 {
+			eq = func(seq int, v uint16, y GlyphIndex) (bool, error) {
+				return y == GlyphIndex(v), nil
+			}
+		} else {
+			var classDefs [3]uint32
+			for j := range classDefs {
+				classDefs[j] = o + uint32(header[2+j%numClassDefs])
+			}
+			eq = func(seq int, v uint16, y GlyphIndex) (bool, error) {
+				c, err := f.classDefValue(b, lt, classDefs[seq], y)
+				return c == v, err
+			}
+			if first, err = f.classDefValue(b, lt, classDefs[1], x); err != nil {
+				return nil, 0, false, err
+			}
+			i = int(first)
+		}
+		numRuleSets := header[2+numClassDefs]
+		if i >= int(numRuleSets) {
+			if format == 2 {
+				// There are no rules for the current glyph's class.
+				return nil, 0, false, nil
+			}
+			return nil, 0, false, errInvalidGSUBTable
+		}
+		u, err := f.layoutU16(b, lt, o+2*uint32(3+numClassDefs+i))
+		if err != nil || u == 0 {
+			return nil, 0, false, err
+		}
+		ruleSet := o + uint32(u)
+		n, err := f.layoutU16(b, lt, ruleSet)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		offsets, err := f.layoutU16s(b, lt, ruleSet+2, int(n))
+		if err != nil {
+			return nil, 0, false, err
+		}
+		for _, u := range offsets {
+			r, err := f.gsubContextRule(b, ruleSet+uint32(u), chained, false)
+			if err != nil {
+				return nil, 0, false, err
+			}
+			r.input = append([]uint16{first}, r.input...)
+			rules = append(rules, r)
+		}
+
+	case 3:
+		// Format 3 has one rule, which matches Coverage tables.
+		r, err := f.gsubContextRule(b, o+2, chained, true)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		rules = []contextRule{r}
+		eq = func(seq int, v uint16, y GlyphIndex) (bool, error) {
+			_, ok, err := f.coverageIndex(b, lt, o+uint32(v), y)
+			return ok, err
+		}
+
+	default:
+		return nil, 0, false, errUnsupportedGSUBTable
+	}
+
+	for _, r := range rules {
+		input, ok, err := f.gsubMatchRule(b, glyphs, pos, ls.flag, r, eq)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		if !ok {
+			continue
+		}
+		if depth < maxContextDepth {
+			for k := 0; k+1 < len(r.records); k += 2 {
+				seq, lookup := int(r.records[k]), r.records[k+1]
+				if seq >= len(input) {
+					return nil, 0, false, errInvalidGSUBTable
+				}
+				if input[seq] >= len(glyphs) {
+					break
+				}
+				n := len(glyphs)
+				if glyphs, _, err = f.gsubApply(b, glyphs, input[seq], lookup, depth+1); err != nil {
+					return nil, 0, false, err
+				}
+				// Shift the positions of the later input glyphs if the lookup
+				// changed the number of glyphs.
+				if d := len(glyphs) - n; d != 0 {
+					for j := seq + 1; j < len(input); j++ {
+						input[j] += d
+					}
+				}
+			}
+		}
+		next := input[len(input)-1] + 1
+		if next <= pos {
+			next = pos + 1
+		} else if next > len(glyphs) {
+			next = len(glyphs)
+		}
+		return glyphs, next, true, nil
+	}
+	return nil, 0, false, nil
+}
+
+// gsubMatchRule returns the positions of the glyphs that match r's input
+// sequence, starting at glyphs[pos], and whether r matches, including its
+// backtrack and lookahead sequences. flag is the lookup's LookupFlag.
+func (f *Font) gsubMatchRule(b *Buffer, glyphs []LayoutGlyph, pos int, flag uint16, r contextRule, eq func(seq int, v uint16, y GlyphIndex) (bool, error)) ([]int, bool, error) {
+	if len(r.input) == 0 {
+		return nil, false, errInvalidGSUBTable
+	}
+	if ok, err := eq(1, r.input[0], glyphs[pos].GlyphIndex); err != nil || !ok {
+		return nil, false, err
+	}
+	input, ok, err := f.layoutMatch(b, glyphs, pos, 1, flag, len(r.input)-1, func(i int, y GlyphIndex) (bool, error) {
+		return eq(1, r.input[i+1], y)
+	})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	input = append([]int{pos}, input...)
+	_, ok, err = f.layoutMatch(b, glyphs, pos, -1, flag, len(r.backtrack), func(i int, y GlyphIndex) (bool, error) {
+		return eq(0, r.backtrack[i], y)
+	})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	_, ok, err = f.layoutMatch(b, glyphs, input[len(input)-1], 1, flag, len(r.lookahead), func(i int, y GlyphIndex) (bool, error) {
+		return eq(2, r.lookahead[i], y)
+	})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return input, true, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"time"
+)
+
+// This file implements the head (Font Header) table, as described at
+// https://www.microsoft.com/typography/otspec/head.htm
+
+// MacStyle is the head table's macStyle field: a set of bits describing the
+// font's style.
+type MacStyle uint16
+
+const (
+	MacStyleBold      MacStyle = 1 << 0
+	MacStyleItalic    MacStyle = 1 << 1
+	MacStyleUnderline MacStyle = 1 << 2
+	MacStyleOutline   MacStyle = 1 << 3
+	MacStyleShadow    MacStyle = 1 << 4
+	MacStyleCondensed MacStyle = 1 << 5
+	MacStyleExtended  MacStyle = 1 << 6
+)
+
+// Header holds the fields of a font's head table.
+type Header struct {
+	// FontRevision is the font's version, as set by its manufacturer.
+	FontRevision float64
+
+	// Flags holds the head table's flags field, as described in the
+	// specification.
+	Flags uint16
+
+	// UnitsPerEm is the number of units per em.
+	UnitsPerEm Units
+
+	// Created and Modified are when the font was created and last modified.
+	Created  time.Time
+	Modified time.Time
+
+	// XMin, YMin, XMax and YMax bound all of the font's glyphs.
+	XMin, YMin, XMax, YMax Units
+
+	// MacStyle describes the font's style.
+	MacStyle MacStyle
+
+	// LowestRecPPEM is the smallest readable size in pixels.
+	LowestRecPPEM int
+
+	// FontDirectionHint is deprecated, and typically 2. A value of 1 means
+	// only strongly left to right glyphs and 2 also allows neutral glyphs.
+	// Negative values mean the same for right to left glyphs.
+	FontDirectionHint int
+
+	// IndexToLocFormat is 0 for a short (16 bit) loca table and 1 for a long
+	// (32 bit) one.
+	IndexToLocFormat int
+}
+
+// longDateTimeEpoch is the number of seconds between the epoch of the head
+// table's LONGDATETIME values, 1904-01-01 00:00:00 UTC, and the Unix epoch.
+const longDateTimeEpoch = 2082844800
+
+// Header returns the fields of f's head table.
+func (f *Font) Header(b *Buffer) (Header, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	if f.head.length != 54 {
+		return Header{}, errInvalidHeadTable
+	}
+	buf, err := b.view(&f.src, int(f.head.offset), 54)
+	if err != nil {
+		return Header{}, err
+	}
+	return Header{
+		FontRevision:      fixed16Dot16(u32(buf[4:])),
+		Flags:             u16(buf[16:]),
+		UnitsPerEm:        Units(u16(buf[18:])),
+		Created:           longDateTime(buf[20:]),
+		Modified:          longDateTime(buf[28:]),
+		XMin:              Units(int16(u16(buf[36:]))),
+		YMin:              Units(int16(u16(buf[38:]))),
+		XMax:              Units(int16(u16(buf[40:]))),
+		YMax:              Units(int16(u16(buf[42:]))),
+		MacStyle:          MacStyle(u16(buf[44:])),
+		LowestRecPPEM:     int(u16(buf[46:])),
+		FontDirectionHint: int(int16(u16(buf[48:]))),
+		IndexToLocFormat:  int(int16(u16(buf[50:]))),
+	}, nil
+}
+
+// longDateTime converts the LONGDATETIME value at the start of b, a number of
+// seconds since 1904-01-01 00:00:00 UTC, to a time.Time.
+func longDateTime(b []byte) time.Time {
+	secs := int64(u32(b))<<32 | int64(u32(b[4:]))
+	return time.Unix(secs-longDateTimeEpoch, 0).UTC()
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/image/font/gofont/gobold"
+)
+
+func TestHeader(t *testing.T) {
+	f, err := Parse(gobold.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := f.Header(nil)
+	if err != nil {
+		t.Fatalf("Header: %v", err)
+	}
+	want := Header{
+		FontRevision:      2.0030059814453125,
+		Flags:             9,
+		UnitsPerEm:        2048,
+		Created:           time.Date(2016, 11, 4, 14, 0, 0, 0, time.UTC),
+		Modified:          time.Date(2016, 11, 10, 0, 35, 32, 0, time.UTC),
+		XMin:              -452,
+		YMin:              -466,
+		XMax:              2190,
+		YMax:              2193,
+		MacStyle:          MacStyleBold,
+		LowestRecPPEM:     9,
+		FontDirectionHint: 2,
+		IndexToLocFormat:  0,
+	}
+	if got != want {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements the hhea (Horizontal Header) table, as described at
+// https://www.microsoft.com/typography/otspec/hhea.htm
+
+// HorizontalHeader holds the fields of a font's hhea table, which are used
+// when laying out horizontal text.
+type HorizontalHeader struct {
+	// Ascent, Descent and LineGap are the typographic ascent, descent and
+	// line gap. Descent is typically negative. The distance between two
+	// consecutive baselines is typically Ascent - Descent + LineGap.
+	Ascent  Units
+	Descent Units
+	LineGap Units
+
+	// AdvanceWidthMax is the maximum advance width, and MinLeftSideBearing,
+	// MinRightSideBearing and XMaxExtent are the minimum left and right side
+	// bearings and the maximum (left side bearing + width) over all glyphs.
+	AdvanceWidthMax     Units
+	MinLeftSideBearing  Units
+	MinRightSideBearing Units
+	XMaxExtent          Units
+
+	// CaretSlopeRise and CaretSlopeRun are the slope of the text cursor: 1 and
+	// 0 for a vertical cursor, as for upright fonts, and a rise greater than
+	// the run for italic fonts. CaretOffset is how far the highlight of a
+	// slanted glyph should be shifted to look right, and is 0 for upright
+	// fonts.
+	CaretSlopeRise Units
+	CaretSlopeRun  Units
+	CaretOffset    Units
+
+	// NumHMetrics is the number of advance widths in the hmtx table.
+	NumHMetrics int
+}
+
+// HorizontalHeader returns the fields of f's hhea table.
+func (f *Font) HorizontalHeader(b *Buffer) (HorizontalHeader, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	if f.hhea.length != 36 {
+		return HorizontalHeader{}, errInvalidHheaTable
+	}
+	buf, err := b.view(&f.src, int(f.hhea.offset), 36)
+	if err != nil {
+		return HorizontalHeader{}, err
+	}
+	return HorizontalHeader{
+		Ascent:              Units(int16(u16(buf[4:]))),
+		Descent:             Units(int16(u16(buf[6:]))),
+		LineGap:             Units(int16(u16(buf[8:]))),
+		AdvanceWidthMax:     Units(u16(buf[10:])),
+		MinLeftSideBearing:  Units(int16(u16(buf[12:]))),
+		MinRightSideBearing: Units(int16(u16(buf[14:]))),
+		XMaxExtent:          Units(int16(u16(buf[16:]))),
+		CaretSlopeRise:      Units(int16(u16(buf[18:]))),
+		CaretSlopeRun:       Units(int16(u16(buf[20:]))),
+		CaretOffset:         Units(int16(u16(buf[22:]))),
+		NumHMetrics:         int(u16(buf[34:])),
+	}, nil
+}
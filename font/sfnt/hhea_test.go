@@ -0,0 +1,62 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestHorizontalHeader(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+		want HorizontalHeader
+	}{{
+		name: "goregular",
+		data: goregular.TTF,
+		want: HorizontalHeader{
+			Ascent:              1935,
+			Descent:             -432,
+			AdvanceWidthMax:     2240,
+			MinLeftSideBearing:  -440,
+			MinRightSideBearing: -441,
+			XMaxExtent:          2160,
+			CaretSlopeRise:      1,
+			NumHMetrics:         666,
+		},
+	}, {
+		name: "goitalic",
+		data: goitalic.TTF,
+		want: HorizontalHeader{
+			Ascent:              1935,
+			Descent:             -432,
+			AdvanceWidthMax:     2262,
+			MinLeftSideBearing:  -436,
+			MinRightSideBearing: -733,
+			XMaxExtent:          2276,
+			CaretSlopeRise:      2048,
+			CaretSlopeRun:       409,
+			NumHMetrics:         666,
+		},
+	}}
+	for _, tc := range testCases {
+		f, err := Parse(tc.data)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", tc.name, err)
+			continue
+		}
+		got, err := f.HorizontalHeader(nil)
+		if err != nil {
+			t.Errorf("%s: HorizontalHeader: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,2078 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements a TrueType hinting bytecode interpreter, as described
+// at https://www.microsoft.com/typography/otspec/ttinst.htm
+//
+// Where the specification is vague, the interpreter follows FreeType's
+// version 35 interpreter, which emulates the Windows 3.1 / Windows 95
+// rasterizer.
+
+import (
+	"math"
+	"math/bits"
+
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	twilightZone = 0
+	glyphZone    = 1
+	numZone      = 2
+)
+
+type pointType uint32
+
+const (
+	// current points are the hinted, grid-fitted points, in 26.6 pixels.
+	current pointType = 0
+	// unhinted points are the scaled but unhinted points, in 26.6 pixels.
+	unhinted pointType = 1
+	// inFontUnits points are the unscaled points, in font units.
+	inFontUnits pointType = 2
+
+	numPointType = 3
+)
+
+// TrueType hinting instruction opcodes, as listed at
+// https://www.microsoft.com/typography/otspec/ttinst.htm
+const (
+	opSVTCA0    = 0x00
+	opSVTCA1    = 0x01
+	opSPVTCA0   = 0x02
+	opSPVTCA1   = 0x03
+	opSFVTCA0   = 0x04
+	opSFVTCA1   = 0x05
+	opSPVTL0    = 0x06
+	opSPVTL1    = 0x07
+	opSFVTL0    = 0x08
+	opSFVTL1    = 0x09
+	opSPVFS     = 0x0a
+	opSFVFS     = 0x0b
+	opGPV       = 0x0c
+	opGFV       = 0x0d
+	opSFVTPV    = 0x0e
+	opISECT     = 0x0f
+	opSRP0      = 0x10
+	opSRP1      = 0x11
+	opSRP2      = 0x12
+	opSZP0      = 0x13
+	opSZP1      = 0x14
+	opSZP2      = 0x15
+	opSZPS      = 0x16
+	opSLOOP     = 0x17
+	opRTG       = 0x18
+	opRTHG      = 0x19
+	opSMD       = 0x1a
+	opELSE      = 0x1b
+	opJMPR      = 0x1c
+	opSCVTCI    = 0x1d
+	opSSWCI     = 0x1e
+	opSSW       = 0x1f
+	opDUP       = 0x20
+	opPOP       = 0x21
+	opCLEAR     = 0x22
+	opSWAP      = 0x23
+	opDEPTH     = 0x24
+	opCINDEX    = 0x25
+	opMINDEX    = 0x26
+	opALIGNPTS  = 0x27
+	opUTP       = 0x29
+	opLOOPCALL  = 0x2a
+	opCALL      = 0x2b
+	opFDEF      = 0x2c
+	opENDF      = 0x2d
+	opMDAP0     = 0x2e
+	opMDAP1     = 0x2f
+	opIUP0      = 0x30
+	opIUP1      = 0x31
+	opSHP0      = 0x32
+	opSHP1      = 0x33
+	opSHC0      = 0x34
+	opSHC1      = 0x35
+	opSHZ0      = 0x36
+	opSHZ1      = 0x37
+	opSHPIX     = 0x38
+	opIP        = 0x39
+	opMSIRP0    = 0x3a
+	opMSIRP1    = 0x3b
+	opALIGNRP   = 0x3c
+	opRTDG      = 0x3d
+	opMIAP0     = 0x3e
+	opMIAP1     = 0x3f
+	opNPUSHB    = 0x40
+	opNPUSHW    = 0x41
+	opWS        = 0x42
+	opRS        = 0x43
+	opWCVTP     = 0x44
+	opRCVT      = 0x45
+	opGC0       = 0x46
+	opGC1       = 0x47
+	opSCFS      = 0x48
+	opMD0       = 0x49
+	opMD1       = 0x4a
+	opMPPEM     = 0x4b
+	opMPS       = 0x4c
+	opFLIPON    = 0x4d
+	opFLIPOFF   = 0x4e
+	opDEBUG     = 0x4f
+	opLT        = 0x50
+	opLTEQ      = 0x51
+	opGT        = 0x52
+	opGTEQ      = 0x53
+	opEQ        = 0x54
+	opNEQ       = 0x55
+	opODD       = 0x56
+	opEVEN      = 0x57
+	opIF        = 0x58
+	opEIF       = 0x59
+	opAND       = 0x5a
+	opOR        = 0x5b
+	opNOT       = 0x5c
+	opDELTAP1   = 0x5d
+	opSDB       = 0x5e
+	opSDS       = 0x5f
+	opADD       = 0x60
+	opSUB       = 0x61
+	opDIV       = 0x62
+	opMUL       = 0x63
+	opABS       = 0x64
+	opNEG       = 0x65
+	opFLOOR     = 0x66
+	opCEILING   = 0x67
+	opROUND00   = 0x68
+	opROUND01   = 0x69
+	opROUND10   = 0x6a
+	opROUND11   = 0x6b
+	opNROUND00  = 0x6c
+	opNROUND01  = 0x6d
+	opNROUND10  = 0x6e
+	opNROUND11  = 0x6f
+	opWCVTF     = 0x70
+	opDELTAP2   = 0x71
+	opDELTAP3   = 0x72
+	opDELTAC1   = 0x73
+	opDELTAC2   = 0x74
+	opDELTAC3   = 0x75
+	opSROUND    = 0x76
+	opS45ROUND  = 0x77
+	opJROT      = 0x78
+	opJROF      = 0x79
+	opROFF      = 0x7a
+	opRUTG      = 0x7c
+	opRDTG      = 0x7d
+	opSANGW     = 0x7e
+	opAA        = 0x7f
+	opFLIPPT    = 0x80
+	opFLIPRGON  = 0x81
+	opFLIPRGOFF = 0x82
+	opSCANCTRL  = 0x85
+	opSDPVTL0   = 0x86
+	opSDPVTL1   = 0x87
+	opGETINFO   = 0x88
+	opIDEF      = 0x89
+	opROLL      = 0x8a
+	opMAX       = 0x8b
+	opMIN       = 0x8c
+	opSCANTYPE  = 0x8d
+	opINSTCTRL  = 0x8e
+	opPUSHB000  = 0xb0
+	opPUSHB001  = 0xb1
+	opPUSHB010  = 0xb2
+	opPUSHB011  = 0xb3
+	opPUSHB100  = 0xb4
+	opPUSHB101  = 0xb5
+	opPUSHB110  = 0xb6
+	opPUSHB111  = 0xb7
+	opPUSHW000  = 0xb8
+	opPUSHW001  = 0xb9
+	opPUSHW010  = 0xba
+	opPUSHW011  = 0xbb
+	opPUSHW100  = 0xbc
+	opPUSHW101  = 0xbd
+	opPUSHW110  = 0xbe
+	opPUSHW111  = 0xbf
+	opMDRP00000 = 0xc0
+	opMDRP11111 = 0xdf
+	opMIRP00000 = 0xe0
+	opMIRP11111 = 0xff
+)
+
+// popCounts are the number of stack elements that each opcode pops, not
+// counting the elements popped by instructions that loop. Opcodes past the
+// end of the table, other than MDRP and MIRP, pop nothing.
+var popCounts = [...]uint8{
+	// 0x00 - 0x0f: SVTCA0 ... ISECT.
+	0, 0, 0, 0, 0, 0, 2, 2, 2, 2, 2, 2, 0, 0, 0, 5,
+	// 0x10 - 0x1f: SRP0 ... SSW.
+	1, 1, 1, 1, 1, 1, 1, 1, 0, 0, 1, 0, 1, 1, 1, 1,
+	// 0x20 - 0x2f: DUP ... MDAP1.
+	1, 1, 0, 2, 0, 1, 1, 2, 0, 1, 2, 1, 1, 0, 1, 1,
+	// 0x30 - 0x3f: IUP0 ... MIAP1.
+	0, 0, 0, 0, 1, 1, 1, 1, 1, 0, 2, 2, 0, 0, 2, 2,
+	// 0x40 - 0x4f: NPUSHB ... DEBUG.
+	0, 0, 2, 1, 2, 1, 1, 1, 2, 2, 2, 0, 0, 0, 0, 1,
+	// 0x50 - 0x5f: LT ... SDS.
+	2, 2, 2, 2, 2, 2, 1, 1, 1, 0, 2, 2, 1, 1, 1, 1,
+	// 0x60 - 0x6f: ADD ... NROUND11.
+	2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	// 0x70 - 0x7f: WCVTF ... AA.
+	2, 1, 1, 1, 1, 1, 1, 1, 2, 2, 0, 0, 0, 0, 1, 1,
+	// 0x80 - 0x8f: FLIPPT ... INSTCTRL.
+	0, 2, 2, 0, 0, 1, 2, 2, 1, 1, 3, 2, 2, 1, 2, 0,
+}
+
+func popCount(opcode uint8) int {
+	switch {
+	case int(opcode) < len(popCounts):
+		return int(popCounts[opcode])
+	case opcode >= opMIRP00000:
+		return 2
+	case opcode >= opMDRP00000:
+		return 1
+	}
+	return 0
+}
+
+// programKind is the kind of a top-level hinting program.
+type programKind uint8
+
+const (
+	fontProgram programKind = iota
+	controlValueProgram
+	glyphProgram
+)
+
+// maxHintingSteps and maxHintingCallDepth bound the work done running a
+// (possibly malicious) hinting program.
+const (
+	maxHintingSteps     = 100000
+	maxHintingCallDepth = 32
+)
+
+// hintPoint is a point in a hinter zone.
+type hintPoint struct {
+	x, y     int32
+	on       bool
+	touchedX bool
+	touchedY bool
+}
+
+// graphicsState is the TrueType interpreter's graphics state, as described at
+// https://www.microsoft.com/typography/otspec/tt_graphics_state.htm
+type graphicsState struct {
+	// Projection vector, freedom vector and dual projection vector, as 2.14
+	// fixed point unit vectors.
+	pv, fv, dv [2]int32
+	// Reference points and zone pointers.
+	rp, zp [3]int32
+	// Control value / single width cut-in and single width value, in 26.6
+	// pixels.
+	controlValueCutIn, singleWidthCutIn, singleWidth int32
+	// Delta base and delta shift.
+	deltaBase, deltaShift int32
+	// Minimum distance, in 26.6 pixels.
+	minDist int32
+	// Loop count.
+	loop int32
+	// Rounding policy. A zero roundPeriod means that rounding is off.
+	roundPeriod, roundPhase, roundThreshold int32
+	roundSuper45                            bool
+	// Auto-flip.
+	autoFlip bool
+	// Instruction control, as set by the INSTCTRL instruction.
+	instructionControl int32
+}
+
+var globalDefaultGS = graphicsState{
+	pv:                [2]int32{0x4000, 0}, // Unit vector along the X axis.
+	fv:                [2]int32{0x4000, 0},
+	dv:                [2]int32{0x4000, 0},
+	zp:                [3]int32{glyphZone, glyphZone, glyphZone},
+	controlValueCutIn: (17 << 6) / 16, // 17/16 as a 26.6 fixed point number.
+	deltaBase:         9,
+	deltaShift:        3,
+	minDist:           1 << 6, // 1 as a 26.6 fixed point number.
+	loop:              1,
+	roundPeriod:       1 << 6, // 1 as a 26.6 fixed point number.
+	roundThreshold:    1 << 5, // 1/2 as a 26.6 fixed point number.
+	autoFlip:          true,
+}
+
+// hinter runs a Font's hinting programs: the font program (fpgm) once per
+// Font, the control value program (prep) once per Font and ppem, and each
+// glyph's instructions.
+type hinter struct {
+	stack []int32
+	top   int
+	steps int
+	kind  programKind
+
+	// functions and instructions hold the function and instruction
+	// definitions made by the FDEF and IDEF instructions.
+	functions    map[int32][]byte
+	instructions map[uint8][]byte
+
+	font *Font
+	ppem fixed.Int26_6
+	// fontOK and sizeOK are whether the font and control value programs ran
+	// successfully, for h.font and h.ppem.
+	fontOK, sizeOK bool
+
+	// scale is the 16.16 fixed point scaling factor from font units to 26.6
+	// pixels, and intPPEM is the ppem rounded to an integer.
+	scale   int32
+	intPPEM int32
+
+	gs, defaultGS graphicsState
+	points        [numZone][numPointType][]hintPoint
+	ends          []int
+	cvt, store    []int32
+
+	// fpgm is a copy of the font program, whose function definitions are
+	// slices of it. prep and glyph are copies of the control value program
+	// and of the current glyph's instructions.
+	fpgm, prep, glyph []byte
+
+	// prepCVT, prepStore and prepTwilight are the state left by the control
+	// value program, restored before running each glyph's instructions.
+	prepCVT      []int32
+	prepStore    []int32
+	prepTwilight [numPointType][]hintPoint
+
+	// dummy is the point operated on by instructions that refer to points
+	// that are out of range. Like FreeType, such references are ignored.
+	dummy hintPoint
+}
+
+// init prepares h for hinting f's glyphs at the given ppem, running the font
+// and control value programs if necessary. It returns whether hinting is
+// possible.
+func (h *hinter) init(b *Buffer, f *Font, ppem fixed.Int26_6) bool {
+	if h.font != f {
+		h.font, h.ppem = f, -1
+		h.fontOK = h.initFont(b) == nil
+	}
+	if h.ppem != ppem {
+		h.ppem = ppem
+		h.sizeOK = h.fontOK && h.initSize(b) == nil
+	}
+	return h.sizeOK
+}
+
+// initFont allocates h's memory, as per f's maxp table, and runs the font
+// program.
+func (h *hinter) initFont(b *Buffer) error {
+	f := h.font
+	// https://www.microsoft.com/typography/otspec/maxp.htm
+	if f.maxp.length < 32 {
+		return errInvalidMaxpTable
+	}
+	buf, err := b.view(&f.src, int(f.maxp.offset), 32)
+	if err != nil {
+		return err
+	}
+	maxTwilightPoints := int(u16(buf[16:]))
+	maxStorage := int(u16(buf[18:]))
+	maxStackElements := int(u16(buf[24:]))
+
+	// Like FreeType, allow for fonts that understate their stack needs.
+	if n := maxStackElements + 32; cap(h.stack) < n {
+		h.stack = make([]int32, n)
+	} else {
+		h.stack = h.stack[:n]
+	}
+	h.store = resizeInt32s(h.store, maxStorage)
+	h.prepStore = resizeInt32s(h.prepStore, maxStorage)
+	for t := range h.points[twilightZone] {
+		h.points[twilightZone][t] = resizeHintPoints(h.points[twilightZone][t], maxTwilightPoints)
+		h.prepTwilight[t] = resizeHintPoints(h.prepTwilight[t], maxTwilightPoints)
+	}
+	h.functions = map[int32][]byte{}
+	h.instructions = map[uint8][]byte{}
+
+	h.fpgm = h.fpgm[:0]
+	if f.fpgm.length != 0 {
+		buf, err := b.view(&f.src, int(f.fpgm.offset), int(f.fpgm.length))
+		if err != nil {
+			return err
+		}
+		// The function definitions refer to h.fpgm, so it must not be
+		// re-used for a different font's program.
+		h.fpgm = append([]byte(nil), buf...)
+	}
+	h.gs = globalDefaultGS
+	return h.runProgram(fontProgram, h.fpgm)
+}
+
+// initSize scales the control value table and runs the control value
+// program.
+func (h *hinter) initSize(b *Buffer) error {
+	f := h.font
+	h.scale = divFix(int32(h.ppem), int32(f.cached.unitsPerEm))
+	h.intPPEM = int32(h.ppem+32) >> 6
+
+	h.cvt = h.cvt[:0]
+	if f.cvt.length != 0 {
+		buf, err := b.view(&f.src, int(f.cvt.offset), int(f.cvt.length))
+		if err != nil {
+			return err
+		}
+		for ; len(buf) >= 2; buf = buf[2:] {
+			h.cvt = append(h.cvt, mulFix(int32(int16(u16(buf))), h.scale))
+		}
+	}
+	h.prep = h.prep[:0]
+	if f.prep.length != 0 {
+		buf, err := b.view(&f.src, int(f.prep.offset), int(f.prep.length))
+		if err != nil {
+			return err
+		}
+		h.prep = append(h.prep, buf...)
+	}
+
+	for i := range h.store {
+		h.store[i] = 0
+	}
+	for _, points := range h.points[twilightZone] {
+		for i := range points {
+			points[i] = hintPoint{}
+		}
+	}
+	h.points[glyphZone] = [numPointType][]hintPoint{}
+	h.ends = nil
+
+	h.gs = globalDefaultGS
+	if err := h.runProgram(controlValueProgram, h.prep); err != nil {
+		return err
+	}
+
+	// Like the Microsoft rasterizer, don't let the control value program
+	// change the vectors, reference points, zone pointers or loop count.
+	h.defaultGS = h.gs
+	h.defaultGS.pv = globalDefaultGS.pv
+	h.defaultGS.fv = globalDefaultGS.fv
+	h.defaultGS.dv = globalDefaultGS.dv
+	h.defaultGS.rp = globalDefaultGS.rp
+	h.defaultGS.zp = globalDefaultGS.zp
+	h.defaultGS.loop = globalDefaultGS.loop
+
+	h.prepCVT = append(h.prepCVT[:0], h.cvt...)
+	copy(h.prepStore, h.store)
+	for t, points := range h.points[twilightZone] {
+		copy(h.prepTwilight[t], points)
+	}
+	return nil
+}
+
+// runGlyph runs the glyph program, which must be a copy of the glyph's
+// instructions, on the glyph zone's points.
+func (h *hinter) runGlyph(program []byte) error {
+	// Bit 0 of the instruction control means that grid-fitting is inhibited.
+	if h.defaultGS.instructionControl&1 != 0 {
+		return nil
+	}
+	h.gs = h.defaultGS
+	// Bit 1 means that the glyph programs use the default graphics state,
+	// not the one left by the control value program.
+	if h.defaultGS.instructionControl&2 != 0 {
+		h.gs = globalDefaultGS
+		h.gs.instructionControl = h.defaultGS.instructionControl
+	}
+	h.cvt = append(h.cvt[:0], h.prepCVT...)
+	copy(h.store, h.prepStore)
+	for t, points := range h.points[twilightZone] {
+		copy(points, h.prepTwilight[t])
+	}
+	return h.runProgram(glyphProgram, program)
+}
+
+// runProgram runs a top-level program, resetting the parts of the graphics
+// state that every program starts with.
+func (h *hinter) runProgram(kind programKind, program []byte) error {
+	h.kind = kind
+	h.gs.zp = [3]int32{glyphZone, glyphZone, glyphZone}
+	h.gs.pv = [2]int32{0x4000, 0}
+	h.gs.fv = h.gs.pv
+	h.gs.dv = h.gs.pv
+	h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold, h.gs.roundSuper45 = 1<<6, 0, 1<<5, false
+	h.gs.loop = 1
+	h.top = 0
+	h.steps = 0
+	return h.run(program, 0)
+}
+
+func (h *hinter) pop() (int32, error) {
+	if h.top == 0 {
+		return 0, errInvalidHinting
+	}
+	h.top--
+	return h.stack[h.top], nil
+}
+
+func (h *hinter) push(v int32) error {
+	if h.top == len(h.stack) {
+		return errInvalidHinting
+	}
+	h.stack[h.top] = v
+	h.top++
+	return nil
+}
+
+// point returns the i'th point of the given type in the zone that the given
+// zone pointer refers to.
+func (h *hinter) point(zonePointer int, t pointType, i int32) *hintPoint {
+	points := h.points[h.gs.zp[zonePointer]][t]
+	if i < 0 || int(i) >= len(points) {
+		h.dummy = hintPoint{}
+		return &h.dummy
+	}
+	return &points[i]
+}
+
+// numPoints returns the number of points in the zone that the given zone
+// pointer refers to.
+func (h *hinter) numPoints(zonePointer int) int32 {
+	return int32(len(h.points[h.gs.zp[zonePointer]][current]))
+}
+
+// project returns the projection of (x, y) onto the projection vector.
+func (h *hinter) project(x, y int32) int32 {
+	return dotFix14(x, y, h.gs.pv[0], h.gs.pv[1])
+}
+
+// dualProject returns the projection of (x, y) onto the dual projection
+// vector.
+func (h *hinter) dualProject(x, y int32) int32 {
+	return dotFix14(x, y, h.gs.dv[0], h.gs.dv[1])
+}
+
+// orgDist returns the distance, projected onto the dual projection vector,
+// between the original positions of the i'th point in the zone referred to by
+// zone pointer zi and the j'th point in the zone referred to by zj. For glyph
+// zone points, it uses the more precise unscaled positions.
+func (h *hinter) orgDist(zi int, i int32, zj int, j int32) int32 {
+	if h.gs.zp[zi] == twilightZone || h.gs.zp[zj] == twilightZone {
+		p, q := h.point(zi, unhinted, i), h.point(zj, unhinted, j)
+		return h.dualProject(p.x-q.x, p.y-q.y)
+	}
+	p, q := h.point(zi, inFontUnits, i), h.point(zj, inFontUnits, j)
+	return mulFix(h.dualProject(p.x-q.x, p.y-q.y), h.scale)
+}
+
+// fvDotPv returns the dot product of the freedom and projection vectors,
+// avoiding values too small to divide by.
+func (h *hinter) fvDotPv() int32 {
+	d := (h.gs.fv[0]*h.gs.pv[0] + h.gs.fv[1]*h.gs.pv[1]) >> 14
+	if -0x400 < d && d < 0x400 {
+		d = 0x4000
+	}
+	return d
+}
+
+// move moves p along the freedom vector so that its projection onto the
+// projection vector changes by the given distance, touching p.
+func (h *hinter) move(p *hintPoint, distance int32) {
+	fvDotPv := h.fvDotPv()
+	if h.gs.fv[0] != 0 {
+		p.x += mulDiv(distance, h.gs.fv[0], fvDotPv)
+		p.touchedX = true
+	}
+	if h.gs.fv[1] != 0 {
+		p.y += mulDiv(distance, h.gs.fv[1], fvDotPv)
+		p.touchedY = true
+	}
+}
+
+// moveOrig is like move, for an unhinted point, and doesn't touch it.
+func (h *hinter) moveOrig(p *hintPoint, distance int32) {
+	fvDotPv := h.fvDotPv()
+	if h.gs.fv[0] != 0 {
+		p.x += mulDiv(distance, h.gs.fv[0], fvDotPv)
+	}
+	if h.gs.fv[1] != 0 {
+		p.y += mulDiv(distance, h.gs.fv[1], fvDotPv)
+	}
+}
+
+// displacement returns the displacement of the reference point used by the
+// SHP, SHC and SHZ instructions: rp2 in zone pointer 1 if opcode's low bit is
+// 0, and rp1 in zone pointer 0 otherwise.
+func (h *hinter) displacement(opcode uint8) (dx, dy int32, zone int32, ref int32) {
+	zp, ref := 1, h.gs.rp[2]
+	if opcode&1 != 0 {
+		zp, ref = 0, h.gs.rp[1]
+	}
+	c, u := h.point(zp, current, ref), h.point(zp, unhinted, ref)
+	d := h.project(c.x-u.x, c.y-u.y)
+	fvDotPv := h.fvDotPv()
+	return mulDiv(d, h.gs.fv[0], fvDotPv), mulDiv(d, h.gs.fv[1], fvDotPv), h.gs.zp[zp], ref
+}
+
+// shift moves the i'th point in the zone that zone pointer 2 refers to by
+// (dx, dy), along the freedom vector's non-zero axes.
+func (h *hinter) shift(i, dx, dy int32, touch bool) {
+	p := h.point(2, current, i)
+	if h.gs.fv[0] != 0 {
+		p.x += dx
+		p.touchedX = p.touchedX || touch
+	}
+	if h.gs.fv[1] != 0 {
+		p.y += dy
+		p.touchedY = p.touchedY || touch
+	}
+}
+
+// round rounds x according to the rounding policy.
+func (h *hinter) round(x int32) int32 {
+	period, phase, threshold := h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold
+	if period == 0 {
+		// Rounding is off.
+		return x
+	}
+	roundDown := func(v int32) int32 {
+		if h.gs.roundSuper45 {
+			return v / period * period
+		}
+		return v &^ (period - 1)
+	}
+	if x >= 0 {
+		ret := roundDown(x-phase+threshold) + phase
+		if ret < 0 {
+			ret = phase
+		}
+		return ret
+	}
+	ret := -roundDown(threshold-phase-x) - phase
+	if ret > 0 {
+		ret = -phase
+	}
+	return ret
+}
+
+// setSuperRound sets the rounding policy for the SROUND and S45ROUND
+// instructions. gridPeriod is the grid period as a 2.14 fixed point number.
+func (h *hinter) setSuperRound(selector, gridPeriod int32) {
+	var period int32
+	switch selector & 0xc0 {
+	case 0x00:
+		period = gridPeriod / 2
+	case 0x80:
+		period = gridPeriod * 2
+	default:
+		period = gridPeriod
+	}
+	phase := period * (selector & 0x30 >> 4) / 4
+	threshold := period - 1
+	if selector&0x0f != 0 {
+		threshold = (selector&0x0f - 4) * period / 8
+	}
+	// Convert from 2.14 to 26.6 fixed point.
+	h.gs.roundPeriod = period >> 8
+	h.gs.roundPhase = phase >> 8
+	h.gs.roundThreshold = threshold >> 8
+}
+
+// normalize returns the 2.14 fixed point unit vector in the direction of
+// (x, y), or of the X axis if (x, y) is zero.
+//
+// Like FreeType's FT_Vector_NormLen, it uses Newton's method on integers, so
+// that the hinted results match FreeType's to the last bit.
+func normalize(x, y int32) [2]int32 {
+	if x == 0 && y == 0 {
+		return [2]int32{0x4000, 0}
+	}
+	sx, sy := int32(1), int32(1)
+	if x < 0 {
+		x, sx = -x, -1
+	}
+	if y < 0 {
+		y, sy = -y, -1
+	}
+	if x == 0 {
+		return [2]int32{0, sy * 0x4000}
+	}
+	if y == 0 {
+		return [2]int32{sx * 0x4000, 0}
+	}
+	ux, uy := uint32(x), uint32(y)
+
+	// Estimate the length and pre-normalize by shifting, so that the new
+	// approximate length is between 2/3 and 4/3, in 16.16 fixed point.
+	estimate := func() uint32 {
+		if ux > uy {
+			return ux + uy>>1
+		}
+		return uy + ux>>1
+	}
+	l := estimate()
+	shift := 31 - (bits.Len32(l) - 1)
+	if l >= 0xaaaaaaaa>>uint(shift) {
+		shift -= 16
+	} else {
+		shift -= 15
+	}
+	if shift > 0 {
+		ux <<= uint(shift)
+		uy <<= uint(shift)
+		l = estimate()
+	} else {
+		ux >>= uint(-shift)
+		uy >>= uint(-shift)
+		l >>= uint(-shift)
+	}
+
+	// b is a lower linear approximation of the reciprocal length minus one.
+	b := 0x10000 - int32(l)
+	x, y = int32(ux), int32(uy)
+	var u, v uint32
+	for {
+		u = uint32(x + (x * b >> 16))
+		v = uint32(y + (y * b >> 16))
+		// The normalized squared length, u*u + v*v, approaches 1<<32.
+		z := -int32(u*u+v*v) / 0x200
+		z = z * ((0x10000 + b) >> 8) / 0x10000
+		b += z
+		if z <= 0 {
+			break
+		}
+	}
+	return [2]int32{sx * int32(u) / 4, sy * int32(v) / 4}
+}
+
+// lineVector returns the vector, for the SPVTL, SFVTL and SDPVTL
+// instructions, from the p2'th point in the zone referred to by zone pointer
+// 2 to the p1'th point in the zone referred to by zone pointer 1, rotated 90
+// degrees counter-clockwise if opcode's low bit is set.
+func (h *hinter) lineVector(opcode uint8, t pointType, p1, p2 int32) [2]int32 {
+	a, b := h.point(1, t, p1), h.point(2, t, p2)
+	x, y := a.x-b.x, a.y-b.y
+	if x == 0 && y == 0 {
+		return [2]int32{0x4000, 0}
+	}
+	if opcode&1 != 0 {
+		x, y = -y, x
+	}
+	return normalize(x, y)
+}
+
+// skip returns the pc of the instruction after the one at pc, skipping over
+// any pushed data.
+func skip(program []byte, pc int) (int, error) {
+	n := 1
+	switch op := program[pc]; {
+	case op == opNPUSHB:
+		if pc+1 >= len(program) {
+			return 0, errInvalidHinting
+		}
+		n = 2 + int(program[pc+1])
+	case op == opNPUSHW:
+		if pc+1 >= len(program) {
+			return 0, errInvalidHinting
+		}
+		n = 2 + 2*int(program[pc+1])
+	case opPUSHB000 <= op && op <= opPUSHB111:
+		n = 2 + int(op-opPUSHB000)
+	case opPUSHW000 <= op && op <= opPUSHW111:
+		n = 3 + 2*int(op-opPUSHW000)
+	}
+	if pc+n > len(program) {
+		return 0, errInvalidHinting
+	}
+	return pc + n, nil
+}
+
+// skipBranch returns the pc after the ELSE (if stopAtElse) or EIF instruction
+// that matches the IF or ELSE instruction at pc.
+func skipBranch(program []byte, pc int, stopAtElse bool) (int, error) {
+	depth := 1
+	for {
+		var err error
+		if pc, err = skip(program, pc); err != nil {
+			return 0, err
+		}
+		if pc >= len(program) {
+			return 0, errInvalidHinting
+		}
+		switch program[pc] {
+		case opIF:
+			depth++
+		case opELSE:
+			if depth == 1 && stopAtElse {
+				return pc + 1, nil
+			}
+		case opEIF:
+			depth--
+			if depth == 0 {
+				return pc + 1, nil
+			}
+		}
+	}
+}
+
+// skipDefinition returns the pc after the ENDF instruction that ends the FDEF
+// or IDEF instruction at pc.
+func skipDefinition(program []byte, pc int) (int, error) {
+	for {
+		var err error
+		if pc, err = skip(program, pc); err != nil {
+			return 0, err
+		}
+		if pc >= len(program) {
+			return 0, errInvalidHinting
+		}
+		switch program[pc] {
+		case opFDEF, opIDEF:
+			return 0, errInvalidHinting
+		case opENDF:
+			return pc + 1, nil
+		}
+	}
+}
+
+// run runs program. depth is the function call depth.
+func (h *hinter) run(program []byte, depth int) error {
+	if depth > maxHintingCallDepth {
+		return errUnsupportedHinting
+	}
+	for pc := 0; pc < len(program); {
+		h.steps++
+		if h.steps > maxHintingSteps {
+			return errUnsupportedHinting
+		}
+		next, err := skip(program, pc)
+		if err != nil {
+			return err
+		}
+		if h.top < popCount(program[pc]) {
+			return errInvalidHinting
+		}
+		if next, err = h.step(program, pc, next, depth); err != nil {
+			return err
+		}
+		if next < 0 {
+			// An ENDF instruction.
+			if depth == 0 {
+				return errInvalidHinting
+			}
+			return nil
+		}
+		pc = next
+	}
+	if depth != 0 {
+		// A function ended without an ENDF instruction.
+		return errInvalidHinting
+	}
+	return nil
+}
+
+// step executes the instruction at pc, returning the pc of the next
+// instruction to execute, or -1 for an ENDF instruction. next is the pc of the
+// instruction after the one at pc.
+func (h *hinter) step(program []byte, pc, next int, depth int) (int, error) {
+	opcode := program[pc]
+	gs := &h.gs
+	var err error
+	pop := func() int32 {
+		v, e := h.pop()
+		if err == nil {
+			err = e
+		}
+		return v
+	}
+	push := func(v int32) {
+		if e := h.push(v); err == nil {
+			err = e
+		}
+	}
+	bool32 := func(b bool) int32 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	switch opcode {
+	case opSVTCA0, opSVTCA1:
+		gs.pv = [2]int32{0, 0x4000}
+		if opcode&1 != 0 {
+			gs.pv = [2]int32{0x4000, 0}
+		}
+		gs.fv, gs.dv = gs.pv, gs.pv
+
+	case opSPVTCA0, opSPVTCA1:
+		gs.pv = [2]int32{0, 0x4000}
+		if opcode&1 != 0 {
+			gs.pv = [2]int32{0x4000, 0}
+		}
+		gs.dv = gs.pv
+
+	case opSFVTCA0, opSFVTCA1:
+		gs.fv = [2]int32{0, 0x4000}
+		if opcode&1 != 0 {
+			gs.fv = [2]int32{0x4000, 0}
+		}
+
+	case opSPVTL0, opSPVTL1:
+		p1, p2 := pop(), pop()
+		gs.pv = h.lineVector(opcode, current, p2, p1)
+		gs.dv = gs.pv
+
+	case opSFVTL0, opSFVTL1:
+		p1, p2 := pop(), pop()
+		gs.fv = h.lineVector(opcode, current, p2, p1)
+
+	case opSPVFS:
+		y, x := pop(), pop()
+		gs.pv = normalize(int32(int16(x)), int32(int16(y)))
+		gs.dv = gs.pv
+
+	case opSFVFS:
+		y, x := pop(), pop()
+		gs.fv = normalize(int32(int16(x)), int32(int16(y)))
+
+	case opGPV:
+		push(gs.pv[0])
+		push(gs.pv[1])
+
+	case opGFV:
+		push(gs.fv[0])
+		push(gs.fv[1])
+
+	case opSFVTPV:
+		gs.fv = gs.pv
+
+	case opISECT:
+		b1, b0, a1, a0, i := pop(), pop(), pop(), pop(), pop()
+		h.isect(i, a0, a1, b0, b1)
+
+	case opSRP0, opSRP1, opSRP2:
+		gs.rp[opcode-opSRP0] = pop()
+
+	case opSZP0, opSZP1, opSZP2, opSZPS:
+		z := pop()
+		if z != twilightZone && z != glyphZone {
+			// Like FreeType, ignore invalid zones.
+			break
+		}
+		if opcode == opSZPS {
+			gs.zp = [3]int32{z, z, z}
+		} else {
+			gs.zp[opcode-opSZP0] = z
+		}
+
+	case opSLOOP:
+		if gs.loop = pop(); gs.loop < 0 {
+			return 0, errInvalidHinting
+		} else if gs.loop > 0xffff {
+			gs.loop = 0xffff
+		}
+
+	case opRTG:
+		gs.roundPeriod, gs.roundPhase, gs.roundThreshold, gs.roundSuper45 = 1<<6, 0, 1<<5, false
+
+	case opRTHG:
+		gs.roundPeriod, gs.roundPhase, gs.roundThreshold, gs.roundSuper45 = 1<<6, 1<<5, 1<<5, false
+
+	case opSMD:
+		gs.minDist = pop()
+
+	case opELSE:
+		// Executing an ELSE means that the IF branch was taken.
+		return skipBranch(program, pc, false)
+
+	case opJMPR:
+		return h.jump(program, pc, pop(), err)
+
+	case opSCVTCI:
+		gs.controlValueCutIn = pop()
+
+	case opSSWCI:
+		gs.singleWidthCutIn = pop()
+
+	case opSSW:
+		gs.singleWidth = mulFix(pop(), h.scale)
+
+	case opDUP:
+		v := pop()
+		push(v)
+		push(v)
+
+	case opPOP:
+		pop()
+
+	case opCLEAR:
+		h.top = 0
+
+	case opSWAP:
+		a, b := pop(), pop()
+		push(a)
+		push(b)
+
+	case opDEPTH:
+		push(int32(h.top))
+
+	case opCINDEX, opMINDEX:
+		k := pop()
+		if k <= 0 || int(k) > h.top {
+			// Like FreeType, CINDEX pushes zero for an invalid index.
+			if opcode == opCINDEX {
+				push(0)
+			}
+			break
+		}
+		i := h.top - int(k)
+		v := h.stack[i]
+		if opcode == opMINDEX {
+			copy(h.stack[i:h.top-1], h.stack[i+1:h.top])
+			h.top--
+		}
+		push(v)
+
+	case opALIGNPTS:
+		p2, p1 := pop(), pop()
+		a, b := h.point(1, current, p1), h.point(0, current, p2)
+		d := h.project(b.x-a.x, b.y-a.y) / 2
+		h.move(a, d)
+		h.move(b, -d)
+
+	case opUTP:
+		p := h.point(0, current, pop())
+		if gs.fv[0] != 0 {
+			p.touchedX = false
+		}
+		if gs.fv[1] != 0 {
+			p.touchedY = false
+		}
+
+	case opLOOPCALL, opCALL:
+		f := pop()
+		count := int32(1)
+		if opcode == opLOOPCALL {
+			count = pop()
+		}
+		if err != nil {
+			return 0, err
+		}
+		body, ok := h.functions[f]
+		if !ok {
+			return 0, errInvalidHinting
+		}
+		for ; count > 0; count-- {
+			if err := h.run(body, depth+1); err != nil {
+				return 0, err
+			}
+		}
+
+	case opFDEF, opIDEF:
+		// Definitions are only allowed at the top level of the font and
+		// control value programs.
+		if depth != 0 || h.kind == glyphProgram {
+			return 0, errInvalidHinting
+		}
+		f := pop()
+		end, e := skipDefinition(program, pc)
+		if err == nil {
+			err = e
+		}
+		if err != nil {
+			return 0, err
+		}
+		if opcode == opFDEF {
+			h.functions[f] = program[pc+1 : end]
+		} else {
+			h.instructions[uint8(f)] = program[pc+1 : end]
+		}
+		return end, nil
+
+	case opENDF:
+		return -1, nil
+
+	case opMDAP0, opMDAP1:
+		i := pop()
+		p := h.point(0, current, i)
+		d := int32(0)
+		if opcode == opMDAP1 {
+			c := h.project(p.x, p.y)
+			d = h.round(c) - c
+		}
+		h.move(p, d)
+		gs.rp[0], gs.rp[1] = i, i
+
+	case opIUP0, opIUP1:
+		h.iup(opcode == opIUP1)
+
+	case opSHP0, opSHP1:
+		if !h.checkLoop() {
+			break
+		}
+		dx, dy, _, _ := h.displacement(opcode)
+		for ; gs.loop > 0 && err == nil; gs.loop-- {
+			h.shift(pop(), dx, dy, true)
+		}
+		gs.loop = 1
+
+	case opSHC0, opSHC1:
+		c := pop()
+		dx, dy, zone, ref := h.displacement(opcode)
+		start, end := int32(0), h.numPoints(2)
+		if gs.zp[2] == glyphZone {
+			if c < 0 || int(c) >= len(h.ends) {
+				break
+			}
+			if c > 0 {
+				start = int32(h.ends[c-1]) + 1
+			}
+			end = int32(h.ends[c]) + 1
+		} else if c != 0 {
+			break
+		}
+		for i := start; i < end; i++ {
+			if zone != gs.zp[2] || i != ref {
+				h.shift(i, dx, dy, true)
+			}
+		}
+
+	case opSHZ0, opSHZ1:
+		if z := pop(); z != twilightZone && z != glyphZone {
+			break
+		}
+		dx, dy, zone, ref := h.displacement(opcode)
+		// Like FreeType, shift the points in the zone that zone pointer 2
+		// refers to, excluding the glyph zone's phantom points, and don't
+		// touch them.
+		end := h.numPoints(2)
+		if gs.zp[2] == glyphZone {
+			end = 0
+			if n := len(h.ends); n > 0 {
+				end = int32(h.ends[n-1]) + 1
+			}
+		}
+		for i := int32(0); i < end; i++ {
+			if zone != gs.zp[2] || i != ref {
+				h.shift(i, dx, dy, false)
+			}
+		}
+
+	case opSHPIX:
+		d := pop()
+		if !h.checkLoop() {
+			break
+		}
+		dx, dy := mulFix14(d, gs.fv[0]), mulFix14(d, gs.fv[1])
+		for ; gs.loop > 0 && err == nil; gs.loop-- {
+			h.shift(pop(), dx, dy, true)
+		}
+		gs.loop = 1
+
+	case opIP:
+		if !h.checkLoop() {
+			break
+		}
+		h.ip(pop, &err)
+		gs.loop = 1
+
+	case opMSIRP0, opMSIRP1:
+		d, i := pop(), pop()
+		p, r := h.point(1, current, i), h.point(0, current, gs.rp[0])
+		if gs.zp[1] == twilightZone {
+			u := h.point(1, unhinted, i)
+			*u = *h.point(0, unhinted, gs.rp[0])
+			h.moveOrig(u, d)
+			p.x, p.y = u.x, u.y
+		}
+		h.move(p, d-h.project(p.x-r.x, p.y-r.y))
+		gs.rp[1], gs.rp[2] = gs.rp[0], i
+		if opcode == opMSIRP1 {
+			gs.rp[0] = i
+		}
+
+	case opALIGNRP:
+		if !h.checkLoop() {
+			break
+		}
+		r := h.point(0, current, gs.rp[0])
+		for ; gs.loop > 0 && err == nil; gs.loop-- {
+			p := h.point(1, current, pop())
+			h.move(p, -h.project(p.x-r.x, p.y-r.y))
+		}
+		gs.loop = 1
+
+	case opRTDG:
+		gs.roundPeriod, gs.roundPhase, gs.roundThreshold, gs.roundSuper45 = 1<<5, 0, 1<<4, false
+
+	case opMIAP0, opMIAP1:
+		n, i := pop(), pop()
+		d := h.readCVT(n)
+		p := h.point(0, current, i)
+		if gs.zp[0] == twilightZone {
+			u := h.point(0, unhinted, i)
+			u.x, u.y = mulFix14(d, gs.fv[0]), mulFix14(d, gs.fv[1])
+			p.x, p.y = u.x, u.y
+		}
+		c := h.project(p.x, p.y)
+		if opcode == opMIAP1 {
+			if abs32(d-c) > gs.controlValueCutIn {
+				d = c
+			}
+			d = h.round(d)
+		}
+		h.move(p, d-c)
+		gs.rp[0], gs.rp[1] = i, i
+
+	case opNPUSHB, opNPUSHW,
+		opPUSHB000, opPUSHB001, opPUSHB010, opPUSHB011,
+		opPUSHB100, opPUSHB101, opPUSHB110, opPUSHB111,
+		opPUSHW000, opPUSHW001, opPUSHW010, opPUSHW011,
+		opPUSHW100, opPUSHW101, opPUSHW110, opPUSHW111:
+		data, words := program[pc+1:next], opcode == opNPUSHW || opcode >= opPUSHW000
+		if opcode == opNPUSHB || opcode == opNPUSHW {
+			data = data[1:]
+		}
+		for ; len(data) > 0 && err == nil; data = data[1:] {
+			if words {
+				push(int32(int16(u16(data))))
+				data = data[1:]
+			} else {
+				push(int32(data[0]))
+			}
+		}
+
+	case opWS:
+		v, i := pop(), pop()
+		if 0 <= i && int(i) < len(h.store) {
+			h.store[i] = v
+		}
+
+	case opRS:
+		i := pop()
+		v := int32(0)
+		if 0 <= i && int(i) < len(h.store) {
+			v = h.store[i]
+		}
+		push(v)
+
+	case opWCVTP:
+		v, i := pop(), pop()
+		if 0 <= i && int(i) < len(h.cvt) {
+			h.cvt[i] = v
+		}
+
+	case opRCVT:
+		push(h.readCVT(pop()))
+
+	case opGC0, opGC1:
+		i := pop()
+		if opcode == opGC0 {
+			p := h.point(2, current, i)
+			push(h.project(p.x, p.y))
+		} else {
+			p := h.point(2, unhinted, i)
+			push(h.dualProject(p.x, p.y))
+		}
+
+	case opSCFS:
+		k, i := pop(), pop()
+		p := h.point(2, current, i)
+		h.move(p, k-h.project(p.x, p.y))
+		if gs.zp[2] == twilightZone {
+			u := h.point(2, unhinted, i)
+			u.x, u.y = p.x, p.y
+		}
+
+	case opMD0, opMD1:
+		k, l := pop(), pop()
+		if opcode == opMD0 {
+			p, q := h.point(0, current, l), h.point(1, current, k)
+			push(h.project(p.x-q.x, p.y-q.y))
+		} else {
+			push(h.orgDist(0, l, 1, k))
+		}
+
+	case opMPPEM, opMPS:
+		push(h.intPPEM)
+
+	case opFLIPON:
+		gs.autoFlip = true
+
+	case opFLIPOFF:
+		gs.autoFlip = false
+
+	case opDEBUG:
+		pop()
+
+	case opLT:
+		b, a := pop(), pop()
+		push(bool32(a < b))
+
+	case opLTEQ:
+		b, a := pop(), pop()
+		push(bool32(a <= b))
+
+	case opGT:
+		b, a := pop(), pop()
+		push(bool32(a > b))
+
+	case opGTEQ:
+		b, a := pop(), pop()
+		push(bool32(a >= b))
+
+	case opEQ:
+		b, a := pop(), pop()
+		push(bool32(a == b))
+
+	case opNEQ:
+		b, a := pop(), pop()
+		push(bool32(a != b))
+
+	case opODD:
+		push(bool32(h.round(pop())&127 == 64))
+
+	case opEVEN:
+		push(bool32(h.round(pop())&127 == 0))
+
+	case opIF:
+		if c := pop(); err == nil && c == 0 {
+			return skipBranch(program, pc, true)
+		}
+
+	case opEIF:
+		// No-op.
+
+	case opAND:
+		b, a := pop(), pop()
+		push(bool32(a != 0 && b != 0))
+
+	case opOR:
+		b, a := pop(), pop()
+		push(bool32(a != 0 || b != 0))
+
+	case opNOT:
+		push(bool32(pop() == 0))
+
+	case opDELTAP1, opDELTAP2, opDELTAP3, opDELTAC1, opDELTAC2, opDELTAC3:
+		n := pop()
+		base := gs.deltaBase
+		switch opcode {
+		case opDELTAP2, opDELTAC2:
+			base += 16
+		case opDELTAP3, opDELTAC3:
+			base += 32
+		}
+		for ; n > 0; n-- {
+			if h.top < 2 {
+				// Like FreeType, ignore missing arguments.
+				h.top = 0
+				break
+			}
+			i, arg := pop(), pop()
+			if base+(arg&0xf0>>4) != h.intPPEM {
+				continue
+			}
+			d := arg&0x0f - 8
+			if d >= 0 {
+				d++
+			}
+			d *= 1 << uint(6-gs.deltaShift)
+			if opcode == opDELTAP1 || opcode == opDELTAP2 || opcode == opDELTAP3 {
+				if 0 <= i && i < h.numPoints(0) {
+					h.move(h.point(0, current, i), d)
+				}
+			} else if 0 <= i && int(i) < len(h.cvt) {
+				h.cvt[i] += d
+			}
+		}
+
+	case opSDB:
+		gs.deltaBase = pop()
+
+	case opSDS:
+		if gs.deltaShift = pop(); gs.deltaShift < 0 || 6 < gs.deltaShift {
+			return 0, errInvalidHinting
+		}
+
+	case opADD:
+		b, a := pop(), pop()
+		push(a + b)
+
+	case opSUB:
+		b, a := pop(), pop()
+		push(a - b)
+
+	case opDIV:
+		b, a := pop(), pop()
+		if b == 0 {
+			return 0, errInvalidHinting
+		}
+		push(int32(int64(a) * 64 / int64(b)))
+
+	case opMUL:
+		b, a := pop(), pop()
+		push(mulDiv(a, b, 64))
+
+	case opABS:
+		push(abs32(pop()))
+
+	case opNEG:
+		push(-pop())
+
+	case opFLOOR:
+		push(pop() &^ 63)
+
+	case opCEILING:
+		push((pop() + 63) &^ 63)
+
+	case opROUND00, opROUND01, opROUND10, opROUND11:
+		push(h.round(pop()))
+
+	case opNROUND00, opNROUND01, opNROUND10, opNROUND11:
+		// No-op, as there is no engine compensation.
+
+	case opWCVTF:
+		v, i := pop(), pop()
+		if 0 <= i && int(i) < len(h.cvt) {
+			h.cvt[i] = mulFix(v, h.scale)
+		}
+
+	case opSROUND, opS45ROUND:
+		gridPeriod := int32(0x4000)
+		if opcode == opS45ROUND {
+			gridPeriod = 0x2d41 // √2/2 as a 2.14 fixed point number.
+		}
+		h.setSuperRound(pop(), gridPeriod)
+		gs.roundSuper45 = opcode == opS45ROUND
+
+	case opJROT, opJROF:
+		e, offset := pop(), pop()
+		if (e != 0) == (opcode == opJROT) {
+			return h.jump(program, pc, offset, err)
+		}
+
+	case opROFF:
+		gs.roundPeriod, gs.roundPhase, gs.roundThreshold, gs.roundSuper45 = 0, 0, 0, false
+
+	case opRUTG:
+		gs.roundPeriod, gs.roundPhase, gs.roundThreshold, gs.roundSuper45 = 1<<6, 0, 1<<6-1, false
+
+	case opRDTG:
+		gs.roundPeriod, gs.roundPhase, gs.roundThreshold, gs.roundSuper45 = 1<<6, 0, 0, false
+
+	case opSANGW, opAA, opSCANCTRL, opSCANTYPE:
+		pop()
+
+	case opFLIPPT:
+		if !h.checkLoop() {
+			break
+		}
+		for ; gs.loop > 0 && err == nil; gs.loop-- {
+			if i := pop(); 0 <= i && int(i) < len(h.points[glyphZone][current]) {
+				p := &h.points[glyphZone][current][i]
+				p.on = !p.on
+			}
+		}
+		gs.loop = 1
+
+	case opFLIPRGON, opFLIPRGOFF:
+		hi, lo := pop(), pop()
+		points := h.points[glyphZone][current]
+		if lo < 0 || hi < lo || int(hi) >= len(points) {
+			break
+		}
+		for i := lo; i <= hi; i++ {
+			points[i].on = opcode == opFLIPRGON
+		}
+
+	case opSDPVTL0, opSDPVTL1:
+		p1, p2 := pop(), pop()
+		gs.dv = h.lineVector(opcode, unhinted, p2, p1)
+		gs.pv = h.lineVector(opcode, current, p2, p1)
+
+	case opGETINFO:
+		selector, v := pop(), int32(0)
+		if selector&1 != 0 {
+			// The interpreter version, as per FreeType's version 35
+			// interpreter.
+			v = 35
+		}
+		if selector&8 != 0 && h.font.fvar.length != 0 {
+			// The font has variations.
+			v |= 1 << 10
+		}
+		if selector&32 != 0 {
+			// Hinting is for grayscale rendering.
+			v |= 1 << 12
+		}
+		push(v)
+
+	case opROLL:
+		a, b, c := pop(), pop(), pop()
+		push(b)
+		push(a)
+		push(c)
+
+	case opMAX:
+		b, a := pop(), pop()
+		if a < b {
+			a = b
+		}
+		push(a)
+
+	case opMIN:
+		b, a := pop(), pop()
+		if a > b {
+			a = b
+		}
+		push(a)
+
+	case opINSTCTRL:
+		selector, v := pop(), pop()
+		// The instruction control may only be set by the control value
+		// program.
+		if selector < 1 || 3 < selector || h.kind != controlValueProgram {
+			break
+		}
+		mask := int32(1) << uint(selector-1)
+		gs.instructionControl &^= mask
+		if v != 0 {
+			gs.instructionControl |= mask
+		}
+
+	default:
+		switch {
+		case opMDRP00000 <= opcode && opcode <= opMDRP11111:
+			h.mdrp(opcode, pop())
+		case opMIRP00000 <= opcode:
+			n, i := pop(), pop()
+			h.mirp(opcode, i, n)
+		default:
+			body, ok := h.instructions[opcode]
+			if !ok {
+				return 0, errUnsupportedHinting
+			}
+			if err := h.run(body, depth+1); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return next, err
+}
+
+// checkLoop returns whether there are enough stack elements for an
+// instruction that loops gs.loop times. Like FreeType, if there aren't, the
+// instruction is ignored and the loop count is reset.
+func (h *hinter) checkLoop() bool {
+	if h.top < int(h.gs.loop) {
+		h.gs.loop = 1
+		return false
+	}
+	return true
+}
+
+// jump returns the pc after a relative jump from pc by offset.
+func (h *hinter) jump(program []byte, pc int, offset int32, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	if offset == 0 || offset < -int32(pc) || int32(len(program)-pc) < offset {
+		return 0, errInvalidHinting
+	}
+	return pc + int(offset), nil
+}
+
+// readCVT returns the n'th control value table entry, or 0 if out of range.
+func (h *hinter) readCVT(n int32) int32 {
+	if n < 0 || int(n) >= len(h.cvt) {
+		return 0
+	}
+	return h.cvt[n]
+}
+
+// applySingleWidth returns d, replaced by the single width value if it is
+// within the single width cut-in.
+func (h *hinter) applySingleWidth(d int32) int32 {
+	if abs32(d-h.gs.singleWidth) < h.gs.singleWidthCutIn {
+		if d >= 0 {
+			return h.gs.singleWidth
+		}
+		return -h.gs.singleWidth
+	}
+	return d
+}
+
+// applyMinDist returns d, increased in magnitude to the minimum distance if
+// the opcode's minimum distance bit is set. orgDist gives the sign.
+func (h *hinter) applyMinDist(opcode uint8, d, orgDist int32) int32 {
+	if opcode&0x08 == 0 {
+		return d
+	}
+	if orgDist >= 0 {
+		if d < h.gs.minDist {
+			d = h.gs.minDist
+		}
+	} else if d > -h.gs.minDist {
+		d = -h.gs.minDist
+	}
+	return d
+}
+
+func (h *hinter) mdrp(opcode uint8, i int32) {
+	gs := &h.gs
+	orgDist := h.applySingleWidth(h.orgDist(1, i, 0, gs.rp[0]))
+	d := orgDist
+	if opcode&0x04 != 0 {
+		d = h.round(d)
+	}
+	d = h.applyMinDist(opcode, d, orgDist)
+	p, r := h.point(1, current, i), h.point(0, current, gs.rp[0])
+	h.move(p, d-h.project(p.x-r.x, p.y-r.y))
+	gs.rp[1], gs.rp[2] = gs.rp[0], i
+	if opcode&0x10 != 0 {
+		gs.rp[0] = i
+	}
+}
+
+func (h *hinter) mirp(opcode uint8, i, n int32) {
+	gs := &h.gs
+	cvtDist := h.applySingleWidth(h.readCVT(n))
+	p, r := h.point(1, current, i), h.point(0, current, gs.rp[0])
+	pu, ru := h.point(1, unhinted, i), h.point(0, unhinted, gs.rp[0])
+	if gs.zp[1] == twilightZone {
+		pu.x = ru.x + mulFix14(cvtDist, gs.fv[0])
+		pu.y = ru.y + mulFix14(cvtDist, gs.fv[1])
+		p.x, p.y = pu.x, pu.y
+	}
+	orgDist := h.dualProject(pu.x-ru.x, pu.y-ru.y)
+	curDist := h.project(p.x-r.x, p.y-r.y)
+	if gs.autoFlip && (orgDist^cvtDist) < 0 {
+		cvtDist = -cvtDist
+	}
+	d := cvtDist
+	if opcode&0x04 != 0 {
+		// Like FreeType, only apply the control value cut-in when both points
+		// are in the same zone.
+		if gs.zp[0] == gs.zp[1] && abs32(cvtDist-orgDist) > gs.controlValueCutIn {
+			d = orgDist
+		}
+		d = h.round(d)
+	}
+	d = h.applyMinDist(opcode, d, orgDist)
+	h.move(p, d-curDist)
+	gs.rp[1], gs.rp[2] = gs.rp[0], i
+	if opcode&0x10 != 0 {
+		gs.rp[0] = i
+	}
+}
+
+// ip implements the IP instruction, interpolating gs.loop points between rp1
+// and rp2. Like FreeType, the original distances of glyph zone points are
+// measured in font units, as only their ratio matters.
+func (h *hinter) ip(pop func() int32, err *error) {
+	gs := &h.gs
+	t := inFontUnits
+	if gs.zp[0] == twilightZone || gs.zp[1] == twilightZone || gs.zp[2] == twilightZone {
+		t = unhinted
+	}
+	o1, o2 := h.point(0, t, gs.rp[1]), h.point(1, t, gs.rp[2])
+	r1, r2 := h.point(0, current, gs.rp[1]), h.point(1, current, gs.rp[2])
+	oldRange := h.dualProject(o2.x-o1.x, o2.y-o1.y)
+	curRange := h.project(r2.x-r1.x, r2.y-r1.y)
+	for ; gs.loop > 0 && *err == nil; gs.loop-- {
+		i := pop()
+		o, p := h.point(2, t, i), h.point(2, current, i)
+		orgDist := h.dualProject(o.x-o1.x, o.y-o1.y)
+		curDist := h.project(p.x-r1.x, p.y-r1.y)
+		newDist := int32(0)
+		if orgDist != 0 {
+			if oldRange != 0 {
+				newDist = mulDiv(orgDist, curRange, oldRange)
+			} else {
+				// Like FreeType, which follows the Microsoft rasterizer,
+				// move the point by the difference between its original
+				// and current distances from rp1, even though for glyph
+				// zone points the former is measured in font units.
+				newDist = orgDist
+			}
+		}
+		h.move(p, newDist-curDist)
+	}
+}
+
+// isect implements the ISECT instruction, moving the i'th point to the
+// intersection of the lines a0-a1 and b0-b1.
+func (h *hinter) isect(i, a0, a1, b0, b1 int32) {
+	pa0, pa1 := h.point(1, current, a0), h.point(1, current, a1)
+	pb0, pb1 := h.point(0, current, b0), h.point(0, current, b1)
+	p := h.point(2, current, i)
+
+	dbx, dby := pb1.x-pb0.x, pb1.y-pb0.y
+	dax, day := pa1.x-pa0.x, pa1.y-pa0.y
+	dx, dy := pb0.x-pa0.x, pb0.y-pa0.y
+	discriminant := mulDiv(dax, -dby, 0x40) + mulDiv(day, dbx, 0x40)
+	dotProduct := mulDiv(dax, dbx, 0x40) + mulDiv(day, dby, 0x40)
+	// Reject grazing intersections, where the lines are within 3 degrees of
+	// parallel, and use the middle of the two lines' midpoints instead.
+	if 19*int64(abs32(discriminant)) > int64(abs32(dotProduct)) {
+		v := mulDiv(dx, -dby, 0x40) + mulDiv(dy, dbx, 0x40)
+		p.x = pa0.x + mulDiv(v, dax, discriminant)
+		p.y = pa0.y + mulDiv(v, day, discriminant)
+	} else {
+		p.x = (pa0.x + pa1.x + pb0.x + pb1.x) / 4
+		p.y = (pa0.y + pa1.y + pb0.y + pb1.y) / 4
+	}
+	p.touchedX, p.touchedY = true, true
+}
+
+// iup implements the IUP instruction, interpolating the untouched points of
+// each of the glyph's contours along the X axis (if x) or the Y axis.
+func (h *hinter) iup(x bool) {
+	cur := h.points[glyphZone][current]
+	org := h.points[glyphZone][unhinted]
+	orus := h.points[glyphZone][inFontUnits]
+	coord := func(p *hintPoint) *int32 {
+		if x {
+			return &p.x
+		}
+		return &p.y
+	}
+	touched := func(i int) bool {
+		if x {
+			return cur[i].touchedX
+		}
+		return cur[i].touchedY
+	}
+	shift := func(start, end, ref int) {
+		d := *coord(&cur[ref]) - *coord(&org[ref])
+		for i := start; i <= end; i++ {
+			if i != ref {
+				*coord(&cur[i]) += d
+			}
+		}
+	}
+	interpolate := func(start, end, ref1, ref2 int) {
+		if start > end {
+			return
+		}
+		orus1, orus2 := *coord(&orus[ref1]), *coord(&orus[ref2])
+		if orus1 > orus2 {
+			orus1, orus2 = orus2, orus1
+			ref1, ref2 = ref2, ref1
+		}
+		org1, org2 := *coord(&org[ref1]), *coord(&org[ref2])
+		cur1, cur2 := *coord(&cur[ref1]), *coord(&cur[ref2])
+		delta1, delta2 := cur1-org1, cur2-org2
+		scale := int32(0)
+		if cur1 != cur2 && orus1 != orus2 {
+			scale = divFix(cur2-cur1, orus2-orus1)
+		}
+		for i := start; i <= end; i++ {
+			v := *coord(&org[i])
+			switch {
+			case v <= org1:
+				v += delta1
+			case v >= org2:
+				v += delta2
+			case scale == 0:
+				v = cur1
+			default:
+				v = cur1 + mulFix(*coord(&orus[i])-orus1, scale)
+			}
+			*coord(&cur[i]) = v
+		}
+	}
+
+	start := 0
+	for _, end := range h.ends {
+		if end >= len(cur) {
+			break
+		}
+		i := start
+		for i <= end && !touched(i) {
+			i++
+		}
+		if i <= end {
+			first, last := i, i
+			for i++; i <= end; i++ {
+				if touched(i) {
+					interpolate(last+1, i-1, last, i)
+					last = i
+				}
+			}
+			if last == first {
+				shift(start, end, last)
+			} else {
+				interpolate(last+1, end, last, first)
+				if first > start {
+					interpolate(start, first-1, last, first)
+				}
+			}
+		}
+		start = end + 1
+	}
+}
+
+// appendHintedGlyfSegments appends the x'th glyph's segments, hinted by
+// running the font's TrueType instructions at the given ppem, to b.segments.
+//
+// It returns ok == false, and a nil error, if the glyph cannot be hinted: if
+// it is empty or compound, or if the font or control value programs failed.
+// The caller should then fall back to loading the unhinted glyph.
+func (f *Font) appendHintedGlyfSegments(b *Buffer, x GlyphIndex, ppem fixed.Int26_6) (segments []Segment, ok bool, err error) {
+	if b.hinter == nil {
+		b.hinter = &hinter{}
+	}
+	h := b.hinter
+	if !h.init(b, f, ppem) {
+		return nil, false, nil
+	}
+
+	data, err := f.viewGlyphData(b, x)
+	if err != nil {
+		return nil, false, err
+	}
+	points, ends, err := decodeGlyfPoints(b.points[:0], b.ends[:0], data)
+	if err != nil {
+		return nil, false, err
+	}
+	b.points, b.ends = points, ends
+	if len(points) == 0 {
+		return nil, false, nil
+	}
+	xMin := int32(int16(u16(data[2:])))
+	yMax := int32(int16(u16(data[8:])))
+	// The instructions follow the contour end point indexes. The data slice
+	// may be invalidated by subsequent b.view calls, so copy them.
+	// decodeGlyfPoints has already checked that they are in bounds.
+	index := glyfHeaderLen + 2*len(ends)
+	h.glyph = append(h.glyph[:0], data[index+2:index+2+int(u16(data[index:]))]...)
+
+	adv, lsb, err := f.hintingHorizontalMetrics(b, x)
+	if err != nil {
+		return nil, false, err
+	}
+	top, vadv, err := f.hintingVerticalMetrics(b, x, yMax)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Set up the glyph zone: the glyph's points followed by four phantom
+	// points for the glyph's horizontal and vertical origins and advances.
+	n := len(points)
+	for t := range h.points[glyphZone] {
+		h.points[glyphZone][t] = resizeHintPoints(h.points[glyphZone][t], n+4)
+	}
+	h.ends = ends
+	cur := h.points[glyphZone][current]
+	org := h.points[glyphZone][unhinted]
+	orus := h.points[glyphZone][inFontUnits]
+	for i, p := range points {
+		orus[i] = hintPoint{x: int32(p.x), y: int32(p.y), on: p.on}
+	}
+	orus[n+0] = hintPoint{x: xMin - lsb}
+	orus[n+1] = hintPoint{x: xMin - lsb + adv}
+	orus[n+2] = hintPoint{y: top}
+	orus[n+3] = hintPoint{y: top - vadv}
+
+	if f.cached.normalizedCoords != nil && f.gvar.length != 0 {
+		deltas, err := f.glyphVariationDeltas(b, x, points, ends, n+4)
+		if err != nil {
+			return nil, false, err
+		}
+		for i := range orus {
+			orus[i].x += int32(math.Floor(deltas[i].x + 0.5))
+			orus[i].y += int32(math.Floor(deltas[i].y + 0.5))
+		}
+	}
+
+	for i, p := range orus {
+		cur[i] = hintPoint{x: mulFix(p.x, h.scale), y: mulFix(p.y, h.scale), on: p.on}
+		org[i] = cur[i]
+	}
+	cur[n+0].x = (cur[n+0].x + 32) &^ 63
+	cur[n+1].x = (cur[n+1].x + 32) &^ 63
+	cur[n+2].y = (cur[n+2].y + 32) &^ 63
+	cur[n+3].y = (cur[n+3].y + 32) &^ 63
+
+	if len(h.glyph) > 0 {
+		// Like FreeType, ignore errors in a glyph's instructions, keeping
+		// whatever hinting was done before the error.
+		h.runGlyph(h.glyph)
+	}
+
+	// Translate the points so that the hinted horizontal origin is at x = 0.
+	if dx := cur[n].x; dx != 0 {
+		for i := range cur[:n] {
+			cur[i].x -= dx
+		}
+	}
+	segments, err = appendHintedSegments(b.segments, cur[:n], ends)
+	if err != nil {
+		return nil, false, err
+	}
+	return segments, true, nil
+}
+
+// hintingHorizontalMetrics returns the x'th glyph's advance width and left
+// side bearing, in font units.
+func (f *Font) hintingHorizontalMetrics(b *Buffer, x GlyphIndex) (adv, lsb int32, err error) {
+	y := x
+	if n := GlyphIndex(f.cached.numHMetrics - 1); y > n {
+		y = n
+	}
+	buf, err := b.view(&f.src, int(f.hmtx.offset)+4*int(y), 2)
+	if err != nil {
+		return 0, 0, err
+	}
+	adv = int32(u16(buf))
+	offset := 4*int(x) + 2
+	if n := f.cached.numHMetrics; int32(x) >= n {
+		offset = 4*int(n) + 2*(int(x)-int(n))
+	}
+	if offset+2 > int(f.hmtx.length) {
+		// Like FreeType, treat a missing left side bearing as zero.
+		return adv, 0, nil
+	}
+	buf, err = b.view(&f.src, int(f.hmtx.offset)+offset, 2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return adv, int32(int16(u16(buf))), nil
+}
+
+// hintingVerticalMetrics returns the y coordinate of the x'th glyph's vertical
+// origin and its advance height, in font units. yMax is the top of the glyph's
+// bounding box.
+//
+// Like FreeType, if the font has no vertical metrics, the origin is at the
+// OS/2 table's typographic ascender, or failing that the hhea table's
+// ascender, and the advance height is the corresponding ascender minus
+// descender.
+func (f *Font) hintingVerticalMetrics(b *Buffer, x GlyphIndex, yMax int32) (top, adv int32, err error) {
+	if f.cached.vhea.numVMetrics != 0 {
+		// The vmtx table has the same structure as the hmtx table.
+		y := x
+		if n := GlyphIndex(f.cached.vhea.numVMetrics - 1); y > n {
+			y = n
+		}
+		buf, err := b.view(&f.src, int(f.vmtx.offset)+4*int(y), 2)
+		if err != nil {
+			return 0, 0, err
+		}
+		adv = int32(u16(buf))
+		offset := 4*int(x) + 2
+		if n := f.cached.vhea.numVMetrics; int32(x) >= n {
+			offset = 4*int(n) + 2*(int(x)-int(n))
+		}
+		tsb := int32(0)
+		if offset+2 <= int(f.vmtx.length) {
+			buf, err = b.view(&f.src, int(f.vmtx.offset)+offset, 2)
+			if err != nil {
+				return 0, 0, err
+			}
+			tsb = int32(int16(u16(buf)))
+		}
+		return yMax + tsb, adv, nil
+	}
+
+	// https://www.microsoft.com/typography/otspec/os2.htm
+	// https://www.microsoft.com/typography/otspec/hhea.htm
+	t, offset := f.hhea, 4
+	if f.os2.length >= 72 {
+		t, offset = f.os2, 68
+	}
+	buf, err := b.view(&f.src, int(t.offset)+offset, 4)
+	if err != nil {
+		return 0, 0, err
+	}
+	ascender := int32(int16(u16(buf[0:])))
+	descender := int32(int16(u16(buf[2:])))
+	return ascender, abs32(ascender - descender), nil
+}
+
+// The following functions implement FreeType's fixed point arithmetic, which
+// rounds half away from zero.
+
+func abs32(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// mulDiv returns a*b/c, rounded.
+func mulDiv(a, b, c int32) int32 {
+	s, x, y, z := int64(1), int64(a), int64(b), int64(c)
+	if x < 0 {
+		x, s = -x, -s
+	}
+	if y < 0 {
+		y, s = -y, -s
+	}
+	if z < 0 {
+		z, s = -z, -s
+	}
+	d := int64(math.MaxInt32)
+	if z > 0 {
+		d = (x*y + z/2) / z
+	}
+	return int32(s * d)
+}
+
+// mulFix returns a*b, where b is a 16.16 fixed point number.
+func mulFix(a, b int32) int32 {
+	ab := int64(a) * int64(b)
+	if ab < 0 {
+		ab--
+	}
+	return int32((ab + 0x8000) >> 16)
+}
+
+// mulFix14 returns a*b, where b is a 2.14 fixed point number.
+func mulFix14(a, b int32) int32 {
+	ab := int64(a) * int64(b)
+	if ab < 0 {
+		ab--
+	}
+	return int32((ab + 0x2000) >> 14)
+}
+
+// dotFix14 returns ax*bx + ay*by, where b is a 2.14 fixed point vector.
+func dotFix14(ax, ay, bx, by int32) int32 {
+	d := int64(ax)*int64(bx) + int64(ay)*int64(by)
+	if d < 0 {
+		d--
+	}
+	return int32((d + 0x2000) >> 14)
+}
+
+// divFix returns a/b as a 16.16 fixed point number.
+func divFix(a, b int32) int32 {
+	s, x, y := int64(1), int64(a), int64(b)
+	if x < 0 {
+		x, s = -x, -s
+	}
+	if y < 0 {
+		y, s = -y, -s
+	}
+	q := int64(0x7fffffff)
+	if y > 0 {
+		q = (x<<16 + y/2) / y
+	}
+	return int32(s * q)
+}
+
+func resizeInt32s(s []int32, n int) []int32 {
+	if cap(s) < n {
+		return make([]int32, n)
+	}
+	return s[:n]
+}
+
+func resizeHintPoints(s []hintPoint, n int) []hintPoint {
+	if cap(s) < n {
+		return make([]hintPoint, n)
+	}
+	return s[:n]
+}
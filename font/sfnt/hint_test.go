@@ -0,0 +1,236 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// TestHintingGoRegular compares hinted glyph points with those produced by
+// FreeType's version 35 interpreter. See the testdata file for details.
+func TestHintingGoRegular(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/hinting/goregular.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	b := &Buffer{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, 1<<20)
+	numTests := 0
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			t.Fatalf("invalid line %q", line)
+		}
+		ppem, err := strconv.Atoi(fields[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := strconv.Atoi(fields[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := strings.Join(fields[2:], " ")
+
+		segments, err := f.LoadGlyph(b, GlyphIndex(x), fixed.I(ppem), &LoadGlyphOptions{
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			t.Errorf("ppem=%d, x=%d: LoadGlyph: %v", ppem, x, err)
+			continue
+		}
+		points := b.hinter.points[glyphZone][current]
+		points = points[:len(points)-4]
+		got := make([]string, len(points))
+		for i, p := range points {
+			on := 0
+			if p.on {
+				on = 1
+			}
+			got[i] = fmt.Sprintf("%d,%d,%d", p.x, p.y, on)
+		}
+		if got := strings.Join(got, " "); got != want {
+			t.Errorf("ppem=%d, x=%d: points:\ngot  %s\nwant %s", ppem, x, got, want)
+			continue
+		}
+
+		// The segments should start at the first (on-curve, for these glyphs)
+		// hinted point.
+		if len(segments) == 0 || segments[0].Op != SegmentOpMoveTo ||
+			segments[0].Args[0] != fixed.Int26_6(points[0].x) ||
+			segments[0].Args[1] != fixed.Int26_6(points[0].y) {
+			t.Errorf("ppem=%d, x=%d: segments do not start at the first hinted point", ppem, x)
+		}
+		numTests++
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if numTests == 0 {
+		t.Fatal("no tests")
+	}
+}
+
+func TestHintingFallback(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b := &Buffer{}
+	for x := GlyphIndex(0); int(x) < f.NumGlyphs(); x++ {
+		if _, err := f.LoadGlyph(b, x, fixed.I(20), &LoadGlyphOptions{Hinting: font.HintingFull}); err != nil {
+			t.Fatalf("x=%d: hinted LoadGlyph: %v", x, err)
+		}
+	}
+	if b.hinter != nil {
+		t.Errorf("PostScript glyphs should not be run through the TrueType hinter")
+	}
+}
+
+func TestHintingPPEMChange(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	x, err := f.GlyphIndex(nil, 'e')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	opts := &LoadGlyphOptions{Hinting: font.HintingFull}
+
+	// Re-using a Buffer for different ppems should give the same results as
+	// using a fresh Buffer.
+	b := &Buffer{}
+	for _, ppem := range []fixed.Int26_6{fixed.I(12), fixed.I(17), fixed.I(12), 17*64 + 32} {
+		got, err := f.LoadGlyph(b, x, ppem, opts)
+		if err != nil {
+			t.Fatalf("ppem=%v: LoadGlyph: %v", ppem, err)
+		}
+		want, err := f.LoadGlyph(nil, x, ppem, opts)
+		if err != nil {
+			t.Fatalf("ppem=%v: LoadGlyph: %v", ppem, err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("ppem=%v: re-used Buffer:\ngot  %v\nwant %v", ppem, got, want)
+		}
+	}
+}
+
+func TestHintingInvalidInstructions(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b := &Buffer{}
+	if _, err := f.LoadGlyph(b, 70, fixed.I(12), &LoadGlyphOptions{Hinting: font.HintingFull}); err != nil {
+		t.Fatalf("LoadGlyph: %v", err)
+	}
+	h := b.hinter
+
+	testCases := [][]byte{
+		// Pop from an empty stack.
+		{opPOP},
+		// An ENDF outside of a function definition.
+		{opENDF},
+		// Call an undefined function.
+		{opPUSHB000, 200, opCALL},
+		// An unterminated IF.
+		{opPUSHB000, 1, opIF, opDUP},
+		// A jump to before the start of the program.
+		{opPUSHB000, 0x80, opNEG, opJMPR},
+		// An infinite loop.
+		{opPUSHW000, 0xff, 0xff, opJMPR},
+		// A function definition in a glyph program.
+		{opPUSHB000, 0, opFDEF, opENDF},
+	}
+	for i, tc := range testCases {
+		if err := h.runGlyph(tc); err == nil {
+			t.Errorf("test case #%d: got nil error, want non-nil", i)
+		}
+	}
+
+	// Random instructions should never panic, and should always terminate.
+	rng := rand.New(rand.NewSource(1))
+	program := make([]byte, 256)
+	for i := 0; i < 1000; i++ {
+		rng.Read(program)
+		h.runGlyph(program)
+	}
+}
+
+func TestHintingRound(t *testing.T) {
+	h := &hinter{}
+	testCases := []struct {
+		setRound func()
+		in, want int32
+	}{
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 0, 32 }, 95, 64},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 0, 32 }, 96, 128},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 0, 32 }, -96, -128},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 0, 32 }, 10, 0},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 32, 32 }, 10, 32},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 32, 32 }, -10, -32},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 32, 0, 16 }, 50, 64},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 0, 0 }, 127, 64},
+		{func() { h.gs.roundPeriod, h.gs.roundPhase, h.gs.roundThreshold = 64, 0, 63 }, 65, 128},
+		{func() { h.gs.roundPeriod = 0 }, 65, 65},
+		// SROUND with a period of 1, a phase of 1/4 and a threshold of 1/2.
+		{func() { h.setSuperRound(0x58, 0x4000) }, 40, 16},
+		{func() { h.setSuperRound(0x58, 0x4000) }, 50, 80},
+		// S45ROUND with a period of √2/2, a phase of 0 and a threshold of
+		// period-1.
+		{func() { h.setSuperRound(0x40, 0x2d41); h.gs.roundSuper45 = true }, 1, 45},
+	}
+	for i, tc := range testCases {
+		h.gs = globalDefaultGS
+		tc.setRound()
+		if got := h.round(tc.in); got != tc.want {
+			t.Errorf("test case #%d: round(%d): got %d, want %d", i, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestHintingNormalize(t *testing.T) {
+	testCases := []struct {
+		x, y int32
+		want [2]int32
+	}{
+		{0, 0, [2]int32{0x4000, 0}},
+		{5, 0, [2]int32{0x4000, 0}},
+		{0, -5, [2]int32{0, -0x4000}},
+		{1, 1, [2]int32{0x2d41, 0x2d41}},
+		{-3, 4, [2]int32{-0x2666, 0x3333}},
+	}
+	for _, tc := range testCases {
+		if got := normalize(tc.x, tc.y); got != tc.want {
+			t.Errorf("normalize(%d, %d): got %#x, want %#x", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
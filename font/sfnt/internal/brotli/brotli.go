@@ -0,0 +1,1004 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:generate go run gen.go
+
+// Package brotli implements a decoder for the Brotli compressed data format,
+// as specified in RFC 7932.
+package brotli
+
+// This implementation favors simplicity over speed. It decodes a whole stream
+// at once, into a single []byte, so that back-references are simply indexes
+// into that []byte instead of into a ring buffer.
+
+import (
+	"errors"
+)
+
+var (
+	errInvalid = errors.New("brotli: invalid data")
+	errTooLong = errors.New("brotli: decompressed data is too long")
+)
+
+// Decode returns the decompression of the Brotli stream src. It returns an
+// error if the decompressed data would be longer than maxLen bytes.
+//
+// Any data after the end of the stream is ignored.
+func Decode(src []byte, maxLen int) ([]byte, error) {
+	d := decoder{r: bitReader{src: src}, maxLen: maxLen}
+	if err := d.decode(); err != nil {
+		return nil, err
+	}
+	return d.dst, nil
+}
+
+// bitReader reads bits from a byte slice, least significant bit first.
+type bitReader struct {
+	src   []byte
+	bits  uint64
+	nBits uint
+	err   error
+}
+
+// read returns the next n bits, for n <= 24. If there are not enough bits, it
+// sets r.err and returns 0.
+func (r *bitReader) read(n uint) uint32 {
+	for r.nBits < n {
+		if len(r.src) == 0 {
+			r.err = errInvalid
+			return 0
+		}
+		r.bits |= uint64(r.src[0]) << r.nBits
+		r.src = r.src[1:]
+		r.nBits += 8
+	}
+	v := uint32(r.bits & (1<<n - 1))
+	r.bits >>= n
+	r.nBits -= n
+	return v
+}
+
+// alignToByte skips to the next byte boundary. The skipped bits must be zero.
+func (r *bitReader) alignToByte() {
+	if n := r.nBits & 7; r.read(n) != 0 {
+		r.err = errInvalid
+	}
+}
+
+// readBytes appends the next n bytes to dst. r must be at a byte boundary.
+func (r *bitReader) readBytes(dst []byte, n int) []byte {
+	for ; n > 0 && r.nBits > 0; n-- {
+		dst = append(dst, uint8(r.read(8)))
+	}
+	if n > len(r.src) {
+		r.err = errInvalid
+		return dst
+	}
+	dst = append(dst, r.src[:n]...)
+	r.src = r.src[n:]
+	return dst
+}
+
+// readVarLenUint8 returns 1 plus the next variable length uint8 value, as per
+// RFC 7932 section 9.2.
+func (r *bitReader) readVarLenUint8() int {
+	if r.read(1) == 0 {
+		return 1
+	}
+	n := uint(r.read(3))
+	if n == 0 {
+		return 2
+	}
+	return int(r.read(n)) + 1<<n + 1
+}
+
+const maxCodeLength = 15
+
+// huffman is a canonical prefix code, as per RFC 7932 section 3.2.
+type huffman struct {
+	// counts[n] is the number of symbols whose code is n bits long.
+	counts [maxCodeLength + 1]uint16
+	// symbols are the symbols, sorted by code length and then by value.
+	symbols []uint16
+}
+
+// init initializes h from the code lengths of each symbol. A code length of
+// zero means that the symbol is not used. If exactly one symbol is used, its
+// code has zero bits.
+func (h *huffman) init(lengths []uint8) {
+	h.counts = [maxCodeLength + 1]uint16{}
+	h.symbols = h.symbols[:0]
+	for _, n := range lengths {
+		h.counts[n]++
+	}
+	h.counts[0] = 0
+	for n := uint8(1); n <= maxCodeLength; n++ {
+		for s, m := range lengths {
+			if m == n {
+				h.symbols = append(h.symbols, uint16(s))
+			}
+		}
+	}
+}
+
+// decode returns the next symbol from r.
+func (h *huffman) decode(r *bitReader) uint16 {
+	if len(h.symbols) == 1 {
+		return h.symbols[0]
+	}
+	code, first, index := 0, 0, 0
+	for n := 1; n <= maxCodeLength; n++ {
+		code |= int(r.read(1))
+		count := int(h.counts[n])
+		if code-count < first {
+			return h.symbols[index+code-first]
+		}
+		index += count
+		first += count
+		first <<= 1
+		code <<= 1
+	}
+	r.err = errInvalid
+	return 0
+}
+
+// codeLengthCodeOrder is the order of the code length code lengths, as per
+// RFC 7932 section 3.5.
+var codeLengthCodeOrder = [18]uint8{
+	1, 2, 3, 4, 0, 5, 17, 6, 16, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+}
+
+// codeLengthCodeLengths and codeLengthCodeValues decode the static prefix
+// code for the code length code lengths, indexed by the next 4 bits.
+var (
+	codeLengthCodeLengths = [16]uint8{2, 2, 2, 3, 2, 2, 2, 4, 2, 2, 2, 3, 2, 2, 2, 4}
+	codeLengthCodeValues  = [16]uint8{0, 4, 3, 2, 0, 4, 3, 1, 0, 4, 3, 2, 0, 4, 3, 5}
+)
+
+// readPrefixCode reads a prefix code over an alphabet of the given size, as
+// per RFC 7932 sections 3.4 and 3.5.
+func (d *decoder) readPrefixCode(h *huffman, alphabetSize int) error {
+	r := &d.r
+	lengths := make([]uint8, alphabetSize)
+	hskip := r.read(2)
+	if hskip == 1 {
+		// A simple prefix code.
+		alphabetBits := uint(0)
+		for (alphabetSize-1)>>alphabetBits != 0 {
+			alphabetBits++
+		}
+		numSymbols := int(r.read(2)) + 1
+		var symbols [4]uint16
+		for i := 0; i < numSymbols; i++ {
+			s := uint16(r.read(alphabetBits))
+			if int(s) >= alphabetSize {
+				return errInvalid
+			}
+			for _, t := range symbols[:i] {
+				if s == t {
+					return errInvalid
+				}
+			}
+			symbols[i] = s
+		}
+		switch numSymbols {
+		case 1:
+			h.counts = [maxCodeLength + 1]uint16{}
+			h.symbols = append(h.symbols[:0], symbols[0])
+			return r.err
+		case 2:
+			lengths[symbols[0]], lengths[symbols[1]] = 1, 1
+		case 3:
+			lengths[symbols[0]], lengths[symbols[1]], lengths[symbols[2]] = 1, 2, 2
+		case 4:
+			if r.read(1) == 0 {
+				lengths[symbols[0]], lengths[symbols[1]], lengths[symbols[2]], lengths[symbols[3]] = 2, 2, 2, 2
+			} else {
+				lengths[symbols[0]], lengths[symbols[1]], lengths[symbols[2]], lengths[symbols[3]] = 1, 2, 3, 3
+			}
+		}
+		h.init(lengths)
+		return r.err
+	}
+
+	// A complex prefix code. First, read the code length code lengths.
+	var clcLengths [18]uint8
+	space, numCodes := 32, 0
+	for i := hskip; i < 18; i++ {
+		// Peek 4 bits, as the longest code is 4 bits long.
+		var peek uint32
+		for r.nBits < 4 && len(r.src) > 0 {
+			r.bits |= uint64(r.src[0]) << r.nBits
+			r.src = r.src[1:]
+			r.nBits += 8
+		}
+		peek = uint32(r.bits & 15)
+		n := codeLengthCodeLengths[peek]
+		if uint(n) > r.nBits {
+			return errInvalid
+		}
+		r.read(uint(n))
+		v := codeLengthCodeValues[peek]
+		clcLengths[codeLengthCodeOrder[i]] = v
+		if v != 0 {
+			space -= 32 >> v
+			numCodes++
+			if space <= 0 {
+				break
+			}
+		}
+	}
+	if numCodes != 1 && space != 0 {
+		return errInvalid
+	}
+	var clc huffman
+	clc.init(clcLengths[:])
+
+	// Then, read the symbols' code lengths.
+	const repeatPreviousCodeLength, repeatZeroCodeLength = 16, 17
+	prevLength, repeat, repeatLength := uint8(8), 0, uint8(0)
+	space = 32768
+	for s := 0; s < alphabetSize && space > 0; {
+		if r.err != nil {
+			return r.err
+		}
+		c := uint8(clc.decode(r))
+		if c < repeatPreviousCodeLength {
+			repeat = 0
+			lengths[s] = c
+			s++
+			if c != 0 {
+				prevLength = c
+				space -= 32768 >> c
+			}
+			continue
+		}
+
+		extraBits, newLength := uint(2), prevLength
+		if c == repeatZeroCodeLength {
+			extraBits, newLength = 3, 0
+		}
+		if repeatLength != newLength {
+			repeat, repeatLength = 0, newLength
+		}
+		oldRepeat := repeat
+		if repeat > 0 {
+			repeat = (repeat - 2) << extraBits
+		}
+		repeat += int(r.read(extraBits)) + 3
+		delta := repeat - oldRepeat
+		if s+delta > alphabetSize {
+			return errInvalid
+		}
+		for ; delta > 0; delta-- {
+			lengths[s] = repeatLength
+			s++
+			if repeatLength != 0 {
+				space -= 32768 >> repeatLength
+			}
+		}
+	}
+	if space != 0 {
+		return errInvalid
+	}
+	h.init(lengths)
+	return r.err
+}
+
+// blockCountBases and blockCountExtraBits are the block count codes' base
+// values and extra bits, as per RFC 7932 section 6.
+var (
+	blockCountBases = [26]int{
+		1, 5, 9, 13, 17, 25, 33, 41, 49, 65, 81, 97, 113, 145, 177, 209,
+		241, 305, 369, 497, 753, 1265, 2289, 4337, 8433, 16625,
+	}
+	blockCountExtraBits = [26]uint8{
+		2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5,
+		6, 6, 7, 8, 9, 10, 11, 12, 13, 24,
+	}
+)
+
+// blockState is the block switching state for one category of symbols:
+// literals, insert-and-copy lengths or distances.
+type blockState struct {
+	numTypes  int
+	typeCode  huffman
+	countCode huffman
+	typ       int
+	prevType  int
+	count     int
+}
+
+func (d *decoder) readBlockState(s *blockState) error {
+	s.numTypes = d.r.readVarLenUint8()
+	s.typ, s.prevType = 0, 1
+	if s.numTypes < 2 {
+		s.count = 1 << 30
+		return d.r.err
+	}
+	if err := d.readPrefixCode(&s.typeCode, s.numTypes+2); err != nil {
+		return err
+	}
+	if err := d.readPrefixCode(&s.countCode, 26); err != nil {
+		return err
+	}
+	s.count = d.readBlockCount(s)
+	return d.r.err
+}
+
+func (d *decoder) readBlockCount(s *blockState) int {
+	c := s.countCode.decode(&d.r)
+	return blockCountBases[c] + int(d.r.read(uint(blockCountExtraBits[c])))
+}
+
+// switchBlock starts the next block of the category whose state is s.
+func (d *decoder) switchBlock(s *blockState) {
+	t := 0
+	switch c := int(s.typeCode.decode(&d.r)); c {
+	case 0:
+		t = s.prevType
+	case 1:
+		t = s.typ + 1
+		if t == s.numTypes {
+			t = 0
+		}
+	default:
+		t = c - 2
+	}
+	s.prevType, s.typ = s.typ, t
+	s.count = d.readBlockCount(s)
+}
+
+// readContextMap reads a context map of the given size, whose values are less
+// than numTrees, as per RFC 7932 section 7.3.
+func (d *decoder) readContextMap(size, numTrees int) ([]uint8, error) {
+	m := make([]uint8, size)
+	if numTrees < 2 {
+		return m, nil
+	}
+	r := &d.r
+	rleMax := 0
+	if r.read(1) == 1 {
+		rleMax = int(r.read(4)) + 1
+	}
+	var h huffman
+	if err := d.readPrefixCode(&h, numTrees+rleMax); err != nil {
+		return nil, err
+	}
+	for i := 0; i < size; {
+		if r.err != nil {
+			return nil, r.err
+		}
+		switch c := int(h.decode(r)); {
+		case c == 0:
+			m[i] = 0
+			i++
+		case c <= rleMax:
+			n := 1<<uint(c) + int(r.read(uint(c)))
+			if i+n > size {
+				return nil, errInvalid
+			}
+			for ; n > 0; n-- {
+				m[i] = 0
+				i++
+			}
+		default:
+			m[i] = uint8(c - rleMax)
+			i++
+		}
+	}
+	if r.read(1) == 1 {
+		// Apply the inverse move-to-front transform.
+		var mtf [256]uint8
+		for i := range mtf {
+			mtf[i] = uint8(i)
+		}
+		for i, index := range m {
+			v := mtf[index]
+			m[i] = v
+			copy(mtf[1:index+1], mtf[:index])
+			mtf[0] = v
+		}
+	}
+	return m, r.err
+}
+
+// Literal context modes, as per RFC 7932 section 7.1.
+const (
+	contextLSB6   = 0
+	contextMSB6   = 1
+	contextUTF8   = 2
+	contextSigned = 3
+)
+
+func contextID(mode uint8, p1, p2 uint8) int {
+	switch mode {
+	case contextLSB6:
+		return int(p1 & 0x3f)
+	case contextMSB6:
+		return int(p1 >> 2)
+	case contextUTF8:
+		return int(contextLUT0[p1] | contextLUT1[p2])
+	default:
+		return int(contextLUT2[p1]<<3 | contextLUT2[p2])
+	}
+}
+
+// contextLUT0, contextLUT1 and contextLUT2 are the Lut0, Lut1 and Lut2 lookup
+// tables of RFC 7932 section 7.1.
+var (
+	contextLUT0 = [256]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 4, 0, 0, 4, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		8, 12, 16, 12, 12, 20, 12, 16, 24, 28, 12, 12, 32, 12, 36, 12,
+		44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 32, 32, 24, 40, 28, 12,
+		12, 48, 52, 52, 52, 48, 52, 52, 52, 48, 52, 52, 52, 52, 52, 48,
+		52, 52, 52, 52, 52, 48, 52, 52, 52, 52, 52, 24, 12, 28, 12, 12,
+		12, 56, 60, 60, 60, 56, 60, 60, 60, 56, 60, 60, 60, 60, 60, 56,
+		60, 60, 60, 60, 60, 56, 60, 60, 60, 60, 60, 24, 12, 28, 12, 0,
+		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1,
+		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1,
+		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1,
+		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1,
+		2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
+		2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
+		2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
+		2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3,
+	}
+	contextLUT1 = [256]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1,
+		1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1,
+		1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+		3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 1, 1, 1, 1, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	}
+	contextLUT2 [256]uint8
+)
+
+func init() {
+	for i := range contextLUT2 {
+		switch {
+		case i == 0:
+			contextLUT2[i] = 0
+		case i < 16:
+			contextLUT2[i] = 1
+		case i < 64:
+			contextLUT2[i] = 2
+		case i < 128:
+			contextLUT2[i] = 3
+		case i < 192:
+			contextLUT2[i] = 4
+		case i < 240:
+			contextLUT2[i] = 5
+		case i < 255:
+			contextLUT2[i] = 6
+		default:
+			contextLUT2[i] = 7
+		}
+	}
+}
+
+// The insert-and-copy length codes, as per RFC 7932 section 5.
+var (
+	insertCodeBases = [11]uint8{0, 0, 0, 0, 8, 8, 0, 16, 8, 16, 16}
+	copyCodeBases   = [11]uint8{0, 8, 0, 8, 0, 8, 16, 0, 16, 8, 16}
+
+	insertLengthBases = [24]int{
+		0, 1, 2, 3, 4, 5, 6, 8, 10, 14, 18, 26, 34, 50, 66, 98,
+		130, 194, 322, 578, 1090, 2114, 6210, 22594,
+	}
+	insertLengthExtraBits = [24]uint8{
+		0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5,
+		6, 7, 8, 9, 10, 12, 14, 24,
+	}
+	copyLengthBases = [24]int{
+		2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 14, 18, 22, 30, 38, 54,
+		70, 102, 134, 198, 326, 582, 1094, 2118,
+	}
+	copyLengthExtraBits = [24]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4,
+		5, 5, 6, 7, 8, 9, 10, 24,
+	}
+)
+
+// The short distance codes, as per RFC 7932 section 4, are an index into the
+// last distances and a delta.
+var (
+	shortDistanceIndexes = [16]uint8{0, 1, 2, 3, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1}
+	shortDistanceDeltas  = [16]int8{0, 0, 0, 0, -1, 1, -2, 2, -3, 3, -1, 1, -2, 2, -3, 3}
+)
+
+// dictionarySizeBits is log2 of the number of static dictionary words of each
+// length, as per RFC 7932 section 8. dictionaryOffsets is the offset of the
+// first word of each length.
+var (
+	dictionarySizeBits = [25]uint8{
+		0, 0, 0, 0, 10, 10, 11, 11, 10, 10, 10, 10, 10,
+		9, 9, 8, 7, 7, 8, 7, 7, 6, 6, 5, 5,
+	}
+	dictionaryOffsets [25]int
+)
+
+func init() {
+	for n := 4; n < 24; n++ {
+		dictionaryOffsets[n+1] = dictionaryOffsets[n] + n<<dictionarySizeBits[n]
+	}
+}
+
+// Word transform types, as per RFC 7932 Appendix B.
+const (
+	transformIdentity = iota
+	transformOmitLast1
+	transformOmitLast2
+	transformOmitLast3
+	transformOmitLast4
+	transformOmitLast5
+	transformOmitLast6
+	transformOmitLast7
+	transformOmitLast8
+	transformOmitLast9
+	transformUppercaseFirst
+	transformUppercaseAll
+	transformOmitFirst1
+	transformOmitFirst2
+	transformOmitFirst3
+	transformOmitFirst4
+	transformOmitFirst5
+	transformOmitFirst6
+	transformOmitFirst7
+	transformOmitFirst8
+	transformOmitFirst9
+)
+
+// transform is a static dictionary word transform.
+type transform struct {
+	prefix string
+	typ    uint8
+	suffix string
+}
+
+// transforms are the word transforms listed in RFC 7932 Appendix B.
+var transforms = [121]transform{
+	{"", transformIdentity, ""},
+	{"", transformIdentity, " "},
+	{" ", transformIdentity, " "},
+	{"", transformOmitFirst1, ""},
+	{"", transformUppercaseFirst, " "},
+	{"", transformIdentity, " the "},
+	{" ", transformIdentity, ""},
+	{"s ", transformIdentity, " "},
+	{"", transformIdentity, " of "},
+	{"", transformUppercaseFirst, ""},
+	{"", transformIdentity, " and "},
+	{"", transformOmitFirst2, ""},
+	{"", transformOmitLast1, ""},
+	{", ", transformIdentity, " "},
+	{"", transformIdentity, ", "},
+	{" ", transformUppercaseFirst, " "},
+	{"", transformIdentity, " in "},
+	{"", transformIdentity, " to "},
+	{"e ", transformIdentity, " "},
+	{"", transformIdentity, "\""},
+	{"", transformIdentity, "."},
+	{"", transformIdentity, "\">"},
+	{"", transformIdentity, "\n"},
+	{"", transformOmitLast3, ""},
+	{"", transformIdentity, "]"},
+	{"", transformIdentity, " for "},
+	{"", transformOmitFirst3, ""},
+	{"", transformOmitLast2, ""},
+	{"", transformIdentity, " a "},
+	{"", transformIdentity, " that "},
+	{" ", transformUppercaseFirst, ""},
+	{"", transformIdentity, ". "},
+	{".", transformIdentity, ""},
+	{" ", transformIdentity, ", "},
+	{"", transformOmitFirst4, ""},
+	{"", transformIdentity, " with "},
+	{"", transformIdentity, "'"},
+	{"", transformIdentity, " from "},
+	{"", transformIdentity, " by "},
+	{"", transformOmitFirst5, ""},
+	{"", transformOmitFirst6, ""},
+	{" the ", transformIdentity, ""},
+	{"", transformOmitLast4, ""},
+	{"", transformIdentity, ". The "},
+	{"", transformUppercaseAll, ""},
+	{"", transformIdentity, " on "},
+	{"", transformIdentity, " as "},
+	{"", transformIdentity, " is "},
+	{"", transformOmitLast7, ""},
+	{"", transformOmitLast1, "ing "},
+	{"", transformIdentity, "\n\t"},
+	{"", transformIdentity, ":"},
+	{" ", transformIdentity, ". "},
+	{"", transformIdentity, "ed "},
+	{"", transformOmitFirst9, ""},
+	{"", transformOmitFirst7, ""},
+	{"", transformOmitLast6, ""},
+	{"", transformIdentity, "("},
+	{"", transformUppercaseFirst, ", "},
+	{"", transformOmitLast8, ""},
+	{"", transformIdentity, " at "},
+	{"", transformIdentity, "ly "},
+	{" the ", transformIdentity, " of "},
+	{"", transformOmitLast5, ""},
+	{"", transformOmitLast9, ""},
+	{" ", transformUppercaseFirst, ", "},
+	{"", transformUppercaseFirst, "\""},
+	{".", transformIdentity, "("},
+	{"", transformUppercaseAll, " "},
+	{"", transformUppercaseFirst, "\">"},
+	{"", transformIdentity, "=\""},
+	{" ", transformIdentity, "."},
+	{".com/", transformIdentity, ""},
+	{" the ", transformIdentity, " of the "},
+	{"", transformUppercaseFirst, "'"},
+	{"", transformIdentity, ". This "},
+	{"", transformIdentity, ","},
+	{".", transformIdentity, " "},
+	{"", transformUppercaseFirst, "("},
+	{"", transformUppercaseFirst, "."},
+	{"", transformIdentity, " not "},
+	{" ", transformIdentity, "=\""},
+	{"", transformIdentity, "er "},
+	{" ", transformUppercaseAll, " "},
+	{"", transformIdentity, "al "},
+	{" ", transformUppercaseAll, ""},
+	{"", transformIdentity, "='"},
+	{"", transformUppercaseAll, "\""},
+	{"", transformUppercaseFirst, ". "},
+	{" ", transformIdentity, "("},
+	{"", transformIdentity, "ful "},
+	{" ", transformUppercaseFirst, ". "},
+	{"", transformIdentity, "ive "},
+	{"", transformIdentity, "less "},
+	{"", transformUppercaseAll, "'"},
+	{"", transformIdentity, "est "},
+	{" ", transformUppercaseFirst, "."},
+	{"", transformUppercaseAll, "\">"},
+	{" ", transformIdentity, "='"},
+	{"", transformUppercaseFirst, ","},
+	{"", transformIdentity, "ize "},
+	{"", transformUppercaseAll, "."},
+	{"\xc2\xa0", transformIdentity, ""},
+	{" ", transformIdentity, ","},
+	{"", transformUppercaseFirst, "=\""},
+	{"", transformUppercaseAll, "=\""},
+	{"", transformIdentity, "ous "},
+	{"", transformUppercaseAll, ", "},
+	{"", transformUppercaseFirst, "='"},
+	{" ", transformUppercaseFirst, ","},
+	{" ", transformUppercaseAll, "=\""},
+	{" ", transformUppercaseAll, ", "},
+	{"", transformUppercaseAll, ","},
+	{"", transformUppercaseAll, "("},
+	{"", transformUppercaseAll, ". "},
+	{" ", transformUppercaseAll, "."},
+	{"", transformUppercaseAll, "='"},
+	{" ", transformUppercaseAll, ". "},
+	{" ", transformUppercaseFirst, "=\""},
+	{" ", transformUppercaseAll, "='"},
+	{" ", transformUppercaseFirst, "='"},
+}
+
+// appendTransformed appends the transformation of word to dst.
+func (t *transform) appendTransformed(dst []byte, word string) []byte {
+	dst = append(dst, t.prefix...)
+	switch {
+	case transformOmitLast1 <= t.typ && t.typ <= transformOmitLast9:
+		n := int(t.typ - transformOmitLast1 + 1)
+		if n > len(word) {
+			n = len(word)
+		}
+		word = word[:len(word)-n]
+	case transformOmitFirst1 <= t.typ && t.typ <= transformOmitFirst9:
+		n := int(t.typ - transformOmitFirst1 + 1)
+		if n > len(word) {
+			n = len(word)
+		}
+		word = word[n:]
+	}
+	i := len(dst)
+	dst = append(dst, word...)
+	switch t.typ {
+	case transformUppercaseFirst:
+		toUpper(dst[i:])
+	case transformUppercaseAll:
+		for p := dst[i:]; len(p) > 0; {
+			p = p[toUpper(p):]
+		}
+	}
+	return append(dst, t.suffix...)
+}
+
+// toUpper applies RFC 7932's simplified upper-casing to the first UTF-8
+// encoded character of p, returning the length of that character. The length
+// may be more than len(p), for a truncated character.
+func toUpper(p []byte) int {
+	if len(p) == 0 {
+		return 0
+	}
+	switch {
+	case p[0] < 0xc0:
+		if 'a' <= p[0] && p[0] <= 'z' {
+			p[0] ^= 0x20
+		}
+		return 1
+	case p[0] < 0xe0:
+		if len(p) >= 2 {
+			p[1] ^= 0x20
+		}
+		return 2
+	default:
+		if len(p) >= 3 {
+			p[2] ^= 0x05
+		}
+		return 3
+	}
+}
+
+type decoder struct {
+	r      bitReader
+	dst    []byte
+	maxLen int
+
+	windowSize int
+	// dists are the last four distances, most recent first.
+	dists [4]int
+}
+
+func (d *decoder) decode() error {
+	r := &d.r
+
+	// Read the stream header, as per RFC 7932 section 9.1.
+	windowBits := uint(16)
+	if r.read(1) == 1 {
+		if n := r.read(3); n != 0 {
+			windowBits = 17 + uint(n)
+		} else if n := r.read(3); n == 1 {
+			return errInvalid
+		} else if n != 0 {
+			windowBits = 8 + uint(n)
+		} else {
+			windowBits = 17
+		}
+	}
+	d.windowSize = 1<<windowBits - 16
+	d.dists = [4]int{4, 11, 15, 16}
+
+	for {
+		// Read the meta-block header, as per RFC 7932 section 9.2.
+		isLast := r.read(1) == 1
+		if isLast && r.read(1) == 1 {
+			// The last meta-block is empty.
+			return r.err
+		}
+		numNibbles := int(r.read(2)) + 4
+		if numNibbles == 7 {
+			// A metadata meta-block, which is skipped.
+			if isLast || r.read(1) != 0 {
+				return errInvalid
+			}
+			numBytes := int(r.read(2))
+			skip := 0
+			for i := 0; i < numBytes; i++ {
+				b := int(r.read(8))
+				if i == numBytes-1 && numBytes > 1 && b == 0 {
+					return errInvalid
+				}
+				skip |= b << uint(8*i)
+			}
+			if numBytes > 0 {
+				skip++
+			}
+			r.alignToByte()
+			r.readBytes(nil, skip)
+			if r.err != nil {
+				return r.err
+			}
+			continue
+		}
+		length := 0
+		for i := 0; i < numNibbles; i++ {
+			n := int(r.read(4))
+			if i == numNibbles-1 && numNibbles > 4 && n == 0 {
+				return errInvalid
+			}
+			length |= n << uint(4*i)
+		}
+		length++
+		if length > d.maxLen-len(d.dst) {
+			return errTooLong
+		}
+
+		if !isLast && r.read(1) == 1 {
+			// An uncompressed meta-block.
+			r.alignToByte()
+			d.dst = r.readBytes(d.dst, length)
+			if r.err != nil {
+				return r.err
+			}
+			continue
+		}
+		if err := d.decodeMetaBlock(length); err != nil {
+			return err
+		}
+		if isLast {
+			return nil
+		}
+	}
+}
+
+// decodeMetaBlock decodes the rest of a compressed meta-block, after its
+// length, as per RFC 7932 section 9.2 and 9.3.
+func (d *decoder) decodeMetaBlock(length int) error {
+	r := &d.r
+	var lit, cmd, dist blockState
+	if err := d.readBlockState(&lit); err != nil {
+		return err
+	}
+	if err := d.readBlockState(&cmd); err != nil {
+		return err
+	}
+	if err := d.readBlockState(&dist); err != nil {
+		return err
+	}
+	postfixBits := uint(r.read(2))
+	numDirect := int(r.read(4)) << postfixBits
+	contextModes := make([]uint8, lit.numTypes)
+	for i := range contextModes {
+		contextModes[i] = uint8(r.read(2))
+	}
+
+	numLitTrees := r.readVarLenUint8()
+	litMap, err := d.readContextMap(64*lit.numTypes, numLitTrees)
+	if err != nil {
+		return err
+	}
+	numDistTrees := r.readVarLenUint8()
+	distMap, err := d.readContextMap(4*dist.numTypes, numDistTrees)
+	if err != nil {
+		return err
+	}
+	litTrees := make([]huffman, numLitTrees)
+	for i := range litTrees {
+		if err := d.readPrefixCode(&litTrees[i], 256); err != nil {
+			return err
+		}
+	}
+	cmdTrees := make([]huffman, cmd.numTypes)
+	for i := range cmdTrees {
+		if err := d.readPrefixCode(&cmdTrees[i], 704); err != nil {
+			return err
+		}
+	}
+	distTrees := make([]huffman, numDistTrees)
+	for i := range distTrees {
+		if err := d.readPrefixCode(&distTrees[i], 16+numDirect+48<<postfixBits); err != nil {
+			return err
+		}
+	}
+
+	end := len(d.dst) + length
+	for len(d.dst) < end {
+		if r.err != nil {
+			return r.err
+		}
+
+		// Read the insert-and-copy length command.
+		if cmd.count == 0 {
+			d.switchBlock(&cmd)
+		}
+		cmd.count--
+		c := int(cmdTrees[cmd.typ].decode(r))
+		cell := c >> 6
+		insertCode := int(insertCodeBases[cell]) + (c>>3)&7
+		copyCode := int(copyCodeBases[cell]) + c&7
+		insertLength := insertLengthBases[insertCode] + int(r.read(uint(insertLengthExtraBits[insertCode])))
+		copyLength := copyLengthBases[copyCode] + int(r.read(uint(copyLengthExtraBits[copyCode])))
+
+		// Insert the literals.
+		if insertLength > end-len(d.dst) {
+			return errInvalid
+		}
+		for ; insertLength > 0; insertLength-- {
+			if lit.count == 0 {
+				d.switchBlock(&lit)
+			}
+			lit.count--
+			var p1, p2 uint8
+			if n := len(d.dst); n >= 2 {
+				p1, p2 = d.dst[n-1], d.dst[n-2]
+			} else if n == 1 {
+				p1 = d.dst[0]
+			}
+			tree := litMap[64*lit.typ+contextID(contextModes[lit.typ], p1, p2)]
+			d.dst = append(d.dst, uint8(litTrees[tree].decode(r)))
+		}
+		if len(d.dst) == end {
+			break
+		}
+
+		// Read the distance.
+		distCode, distance := 0, d.dists[0]
+		if c >= 128 {
+			if dist.count == 0 {
+				d.switchBlock(&dist)
+			}
+			dist.count--
+			ctx := 3
+			if copyLength <= 4 {
+				ctx = copyLength - 2
+			}
+			distCode = int(distTrees[distMap[4*dist.typ+ctx]].decode(r))
+			switch {
+			case distCode < 16:
+				distance = d.dists[shortDistanceIndexes[distCode]] + int(shortDistanceDeltas[distCode])
+				if distance <= 0 {
+					return errInvalid
+				}
+			case distCode < 16+numDirect:
+				distance = distCode - 15
+			default:
+				x := distCode - numDirect - 16
+				numBits := 1 + uint(x>>(postfixBits+1))
+				extra := int(r.read(numBits))
+				hcode := x >> postfixBits
+				lcode := x & (1<<postfixBits - 1)
+				offset := (2+hcode&1)<<numBits - 4
+				distance = (offset+extra)<<postfixBits + lcode + numDirect + 1
+			}
+		}
+
+		maxDistance := len(d.dst)
+		if maxDistance > d.windowSize {
+			maxDistance = d.windowSize
+		}
+		if distance > maxDistance {
+			// A static dictionary reference.
+			if copyLength < 4 || 24 < copyLength {
+				return errInvalid
+			}
+			nBits := uint(dictionarySizeBits[copyLength])
+			wordID := distance - maxDistance - 1
+			t := wordID >> nBits
+			if t >= len(transforms) {
+				return errInvalid
+			}
+			o := dictionaryOffsets[copyLength] + (wordID&(1<<nBits-1))*copyLength
+			n := len(d.dst)
+			d.dst = transforms[t].appendTransformed(d.dst, dictionary[o:o+copyLength])
+			if len(d.dst) > end {
+				d.dst = d.dst[:n]
+				return errInvalid
+			}
+			continue
+		}
+
+		// A back-reference.
+		if distCode != 0 {
+			d.dists = [4]int{distance, d.dists[0], d.dists[1], d.dists[2]}
+		}
+		if copyLength > end-len(d.dst) {
+			return errInvalid
+		}
+		for i := len(d.dst) - distance; copyLength > 0; copyLength-- {
+			d.dst = append(d.dst, d.dst[i])
+			i++
+		}
+	}
+	return r.err
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		compressed, original string
+	}{
+		{"LICENSE.q1.br", "../../../../LICENSE"},
+		{"LICENSE.q5.br", "../../../../LICENSE"},
+		{"LICENSE.q11.br", "../../../../LICENSE"},
+		{"PATENTS.q11.br", "../../../../PATENTS"},
+		{"CFFTest.otf.q9.br", "../../../testdata/CFFTest.otf"},
+		{"glyfTest.ttf.q11.br", "../../../testdata/glyfTest.ttf"},
+		{"video-001-uncompressed.tiff.q11.br", "../../../../testdata/video-001-uncompressed.tiff"},
+	}
+	for _, tc := range testCases {
+		src, err := ioutil.ReadFile(filepath.Join("testdata", tc.compressed))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.compressed, err)
+		}
+		want, err := ioutil.ReadFile(filepath.FromSlash(tc.original))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.original, err)
+		}
+		got, err := Decode(src, len(want))
+		if err != nil {
+			t.Errorf("%s: Decode: %v", tc.compressed, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: decoded data differs from %s", tc.compressed, tc.original)
+		}
+		if _, err := Decode(src, len(want)-1); err != errTooLong {
+			t.Errorf("%s: maxLen too small: got %v, want %v", tc.compressed, err, errTooLong)
+		}
+		if _, err := Decode(src[:len(src)/2], len(want)); err == nil {
+			t.Errorf("%s: truncated data: got nil error", tc.compressed)
+		}
+	}
+}
+
+func TestDecodeSmall(t *testing.T) {
+	testCases := []struct {
+		desc string
+		src  []byte
+		want string
+	}{{
+		// A stream with WBITS = 16 and a single empty last meta-block.
+		desc: "empty",
+		src:  []byte{0x06},
+		want: "",
+	}, {
+		// An uncompressed meta-block holding "hi", then an empty last
+		// meta-block. The bits are ISLAST=0, MNIBBLES=4, MLEN-1=1,
+		// ISUNCOMPRESSED=1, padding, "hi", ISLAST=1, ISLASTEMPTY=1.
+		desc: "uncompressed",
+		src:  []byte{0x10, 0x00, 0x10, 'h', 'i', 0x03},
+		want: "hi",
+	}}
+	for _, tc := range testCases {
+		got, err := Decode(tc.src, 100)
+		if err != nil {
+			t.Errorf("%s: %v", tc.desc, err)
+			continue
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,1925 @@
+// generated by go run gen.go; DO NOT EDIT
+
+package brotli
+
+// dictionary is the static dictionary, as per RFC 7932 Appendix A.
+const dictionary = "" +
+	"timedownlifeleftbackcodedatashowonlysitecityopenjustlikefreework" +
+	"textyearoverbodyloveformbookplaylivelinehelphomesidemorewordlong" +
+	"themviewfindpagedaysfullheadtermeachareafromtruemarkableuponhigh" +
+	"datelandnewsevennextcasebothpostusedmadehandherewhatnameLinkblog" +
+	"sizebaseheldmakemainuser') +holdendswithNewsreadweresigntakehave" +
+	"gameseencallpathwellplusmenufilmpartjointhislistgoodneedwayswest" +
+	"jobsmindalsologorichuseslastteamarmyfoodkingwilleastwardbestfire" +
+	"Pageknowaway.pngmovethanloadgiveselfnotemuchfeedmanyrockicononce" +
+	"lookhidediedHomerulehostajaxinfoclublawslesshalfsomesuchzone100%" +
+	"onescareTimeracebluefourweekfacehopegavehardlostwhenparkkeptpass" +
+	"shiproomHTMLplanTypedonesavekeepflaglinksoldfivetookratetownjump" +
+	"thusdarkcardfilefearstaykillthatfallautoever.comtalkshopvotedeep" +
+	"moderestturnbornbandfellroseurl(skinrolecomeactsagesmeetgold.jpg" +
+	"itemvaryfeltthensenddropViewcopy1.0\"</a>stopelseliestourpack.gif" +
+	"pastcss?graymean&gt;rideshotlatesaidroadvar feeljohnrickportfast" +
+	"'UA-dead</b>poorbilltypeU.S.woodmust2px;Inforankwidewantwalllead" +
+	"[0];paulwavesure$('#waitmassarmsgoesgainlangpaid!-- lockunitroot" +
+	"walkfirmwifexml\"songtest20pxkindrowstoolfontmailsafestarmapscore" +
+	"rainflowbabyspansays4px;6px;artsfootrealwikiheatsteptriporg/lake" +
+	"weaktoldFormcastfansbankveryrunsjulytask1px;goalgrewslowedgeid=\"" +
+	"sets5px;.js?40pxif (soonseatnonetubezerosentreedfactintogiftharm" +
+	"18pxcamehillboldzoomvoideasyringfillpeakinitcost3px;jacktagsbits" +
+	"rolleditknewnear<!--growJSONdutyNamesaleyou lotspainjazzcoldeyes" +
+	"fishwww.risktabsprev10pxrise25pxBlueding300,ballfordearnwildbox." +
+	"fairlackverspairjunetechif(!pickevil$(\"#warmlorddoespull,000idea" +
+	"drawhugespotfundburnhrefcellkeystickhourlossfuel12pxsuitdealRSS\"" +
+	"agedgreyGET\"easeaimsgirlaids8px;navygridtips#999warsladycars); }" +
+	"php?helltallwhomzh:\xe5*/\r\n 100hall.\n\nA7px;pushchat0px;crew*/</hash" +
+	"75pxflatrare && tellcampontolaidmissskiptentfinemalegetsplot400," +
+	"\r\n\r\ncoolfeet.php<br>ericmostguidbelldeschairmathatom/img&#82luck" +
+	"cent000;tinygonehtmlselldrugFREEnodenick?id=losenullvastwindRSS " +
+	"wearrelybeensamedukenasacapewishgulfT23:hitsslotgatekickblurthey" +
+	"15px''););\">msiewinsbirdsortbetaseekT18:ordstreemall60pxfarm’s" +
+	"boys[0].');\"POSTbearkids);}}marytend(UK)quadzh:\xe6-siz----prop');\r" +
+	"liftT19:viceandydebt>RSSpoolneckblowT16:doorevalT17:letsfailoral" +
+	"pollnovacolsgene —softrometillross<h3>pourfadepink<tr>mini)|!(" +
+	"minezh:\xe8barshear00);milk -->ironfreddiskwentsoilputs/js/holyT22:" +
+	"ISBNT20:adamsees<h2>json', 'contT21: RSSloopasiamoon</p>soulLINE" +
+	"fortcartT14:<h1>80px!--<9px;T04:mike:46ZniceinchYorkricezh:\xe4'));" +
+	"puremageparatonebond:37Z_of_']);000,zh:\xe7tankyardbowlbush:56ZJava" +
+	"30px\n|}\n%C3%:34ZjeffEXPIcashvisagolfsnowzh:\xe9quer.csssickmeatmin." +
+	"binddellhirepicsrent:36ZHTTP-201fotowolfEND xbox:54ZBODYdick;\n}\n" +
+	"exit:35Zvarsbeat'});diet999;anne}}</[i].Langkm²wiretoysaddsseal" +
+	"alex;\n\t}echonine.org005)tonyjewssandlegsroof000) 200winegeardogs" +
+	"bootgarycutstyletemption.xmlcockgang$('.50pxPh.Dmiscalanloandesk" +
+	"mileryanunixdisc);}\ndustclip).\n\n70px-200DVDs7]><tapedemoi++)wage" +
+	"europhiloptsholeFAQsasin-26TlabspetsURL bulkcook;}\r\nHEAD[0])abbr" +
+	"juan(198leshtwin</i>sonyguysfuckpipe|-\n!002)ndow[1];[];\nLog salt" +
+	"\r\n\t\tbangtrimbath){\r\n00px\n});ko:\xecfeesad>\rs:// [];tollplug(){\n{\r\n " +
+	".js'200pdualboat.JPG);\n}quot);\n\n');\n\r\n}\r201420152016201720182019" +
+	"2020202120222023202420252026202720282029203020312032203320342035" +
+	"2036203720132012201120102009200820072006200520042003200220012000" +
+	"1999199819971996199519941993199219911990198919881987198619851984" +
+	"1983198219811980197919781977197619751974197319721971197019691968" +
+	"1967196619651964196319621961196019591958195719561955195419531952" +
+	"1951195010001024139400009999comomásesteestaperotodohacecadaaño" +
+	"biendíaasívidacasootroforosolootracualdijosidograntipotemadebe" +
+	"algoquéestonadatrespococasabajotodasinoaguapuesunosantediceluis" +
+	"ellamayozonaamorpisoobraclicellodioshoracasiзанаомрару" +
+	"танепоотизнодотожеонихНаеебымыВы" +
+	"совывоНообПолиниРФНеМытыОнимдаЗа" +
+	"ДаНуОбтеИзейнуммТыужفيأنمامعكلأو" +
+	"رديافىهولملكاولهبسالإنهيأيقدهلثم" +
+	"بهلوليبلايبكشيامأمنتبيلنحبهممشوش" +
+	"firstvideolightworldmediawhitecloseblackrightsmallbooksplacemusi" +
+	"cfieldorderpointvalueleveltableboardhousegroupworksyearsstatetod" +
+	"aywaterstartstyledeathpowerphonenighterrorinputabouttermstitleto" +
+	"olseventlocaltimeslargewordsgamesshortspacefocusclearmodelblockg" +
+	"uideradiosharewomenagainmoneyimagenamesyounglineslatercolorgreen" +
+	"front&amp;watchforcepricerulesbeginaftervisitissueareasbelowinde" +
+	"xtotalhourslabelprintpressbuiltlinksspeedstudytradefoundsenseund" +
+	"ershownformsrangeaddedstillmovedtakenaboveflashfixedoftenothervi" +
+	"ewschecklegalriveritemsquickshapehumanexistgoingmoviethirdbasicp" +
+	"eacestagewidthloginideaswrotepagesusersdrivestorebreaksouthvoice" +
+	"sitesmonthwherebuildwhichearthforumthreesportpartyClicklowerlive" +
+	"sclasslayerentrystoryusagesoundcourtyour birthpopuptypesapplyIma" +
+	"gebeinguppernoteseveryshowsmeansextramatchtrackknownearlybegansu" +
+	"perpapernorthlearngivennamedendedTermspartsGroupbrandusingwomanf" +
+	"alsereadyaudiotakeswhile.com/livedcasesdailychildgreatjudgethose" +
+	"unitsneverbroadcoastcoverapplefilescyclesceneplansclickwritequee" +
+	"npieceemailframeolderphotolimitcachecivilscaleenterthemetheretou" +
+	"chboundroyalaskedwholesincestock namefaithheartemptyofferscopeow" +
+	"nedmightalbumthinkbloodarraymajortrustcanonunioncountvalidstoneS" +
+	"tyleLoginhappyoccurleft:freshquitefilmsgradeneedsurbanfightbasis" +
+	"hoverauto;route.htmlmixedfinalYour slidetopicbrownalonedrawnspli" +
+	"treachRightdatesmarchquotegoodsLinksdoubtasyncthumballowchiefyou" +
+	"thnovel10px;serveuntilhandsCheckSpacequeryjamesequaltwice0,000St" +
+	"artpanelsongsroundeightshiftworthpostsleadsweeksavoidthesemilesp" +
+	"lanesmartalphaplantmarksratesplaysclaimsalestextsstarswrong</h3>" +
+	"thing.org/multiheardPowerstandtokensolid(thisbringshipsstafftrie" +
+	"dcallsfullyfactsagentThis //-->adminegyptEvent15px;Emailtrue\"cro" +
+	"ssspentblogsbox\">notedleavechinasizesguest</h4>robotheavytrue,se" +
+	"vengrandcrimesignsawaredancephase><!--en_US&#39;200px_namelatine" +
+	"njoyajax.ationsmithU.S. holdspeterindianav\">chainscorecomesdoing" +
+	"priorShare1990sromanlistsjapanfallstrialowneragree</h2>abusealer" +
+	"topera\"-//WcardshillsteamsPhototruthclean.php?saintmetallouismea" +
+	"ntproofbriefrow\">genretrucklooksValueFrame.net/-->\n<try {\nvar ma" +
+	"kescostsplainadultquesttrainlaborhelpscausemagicmotortheir250pxl" +
+	"eaststepsCountcouldglasssidesfundshotelawardmouthmovesparisgives" +
+	"dutchtexasfruitnull,||[];top\">\n<!--POST\"ocean<br/>floorspeakdept" +
+	"h sizebankscatchchart20px;aligndealswould50px;url=\"parksmouseMos" +
+	"t ...</amongbrainbody none;basedcarrydraftreferpage_home.meterde" +
+	"laydreamprovejoint</tr>drugs<!-- aprilidealallenexactforthcodesl" +
+	"ogicView seemsblankports (200saved_linkgoalsgrantgreekhomesrings" +
+	"rated30px;whoseparse();\" Blocklinuxjonespixel');\">);if(-leftdavi" +
+	"dhorseFocusraiseboxesTrackement</em>bar\">.src=toweralt=\"cablehen" +
+	"ry24px;setupitalysharpminortastewantsthis.resetwheelgirls/css/10" +
+	"0%;clubsstuffbiblevotes 1000korea});\r\nbandsqueue= {};80px;cking{" +
+	"\r\n\t\taheadclockirishlike ratiostatsForm\"yahoo)[0];Aboutfinds</h1>" +
+	"debugtasksURL =cells})();12px;primetellsturns0x600.jpg\"spainbeac" +
+	"htaxesmicroangel--></giftssteve-linkbody.});\n\tmount (199FAQ</rog" +
+	"erfrankClass28px;feeds<h1><scotttests22px;drink) || lewisshall#0" +
+	"39; for lovedwaste00px;ja:\xe3\x82simon<fontreplymeetsuntercheaptightB" +
+	"rand) != dressclipsroomsonkeymobilmain.Name platefunnytreescom/\"" +
+	"1.jpgwmodeparamSTARTleft idden, 201);\n}\nform.viruschairtranswors" +
+	"tPagesitionpatch<!--\no-cacfirmstours,000 asiani++){adobe')[0]id=" +
+	"10both;menu .2.mi.png\"kevincoachChildbruce2.jpgURL)+.jpg|suitesl" +
+	"iceharry120\" sweettr>\r\nname=diegopage swiss-->\n\n#fff;\">Log.com\"t" +
+	"reatsheet) && 14px;sleepntentfiledja:\xe3\x83id=\"cName\"worseshots-box-" +
+	"delta\n&lt;bears:48Z<data-rural</a> spendbakershops= \"\";php\">ctio" +
+	"n13px;brianhellosize=o=%2F joinmaybe<img img\">, fjsimg\" \")[0]MTo" +
+	"pBType\"newlyDanskczechtrailknows</h5>faq\">zh-cn10);\n-1\");type=bl" +
+	"uestrulydavis.js';>\r\n<!steel you h2>\r\nform jesus100% menu.\r\n\t\r\nw" +
+	"alesrisksumentddingb-likteachgif\" vegasdanskeestishqipsuomisobre" +
+	"desdeentretodospuedeañosestátienehastaotrospartedondenuevohace" +
+	"rformamismomejormundoaquídíassóloayudafechatodastantomenosdat" +
+	"osotrassitiomuchoahoralugarmayorestoshorastenerantesfotosestaspa" +
+	"ísnuevasaludforosmedioquienmesespoderchileserávecesdecirjosée" +
+	"starventagrupohechoellostengoamigocosasnivelgentemismaairesjulio" +
+	"temashaciafavorjuniolibrepuntobuenoautorabrilbuenatextomarzosabe" +
+	"rlistaluegocómoenerojuegoperúhaberestoynuncamujervalorfueralib" +
+	"rogustaigualvotoscasosguíapuedosomosavisousteddebennochebuscafa" +
+	"ltaeurosseriedichocursoclavecasasleónplazolargoobrasvistaapoyoj" +
+	"untotratavistocrearcampohemoscincocargopisosordenhacenáreadisco" +
+	"pedrocercapuedapapelmenorútilclarojorgecalleponertardenadiemarc" +
+	"asigueellassiglocochemotosmadreclaserestoniñoquedapasarbancohij" +
+	"osviajepabloéstevienereinodejarfondocanalnorteletracausatomarma" +
+	"noslunesautosvillavendopesartipostengamarcollevapadreunidovamosz" +
+	"onasambosbandamariaabusomuchasubirriojavivirgradochicaallíjoven" +
+	"dichaestantalessalirsuelopesosfinesllamabuscoéstalleganegroplaz" +
+	"ahumorpagarjuntadobleislasbolsabañohablaluchaÁreadicenjugarnot" +
+	"asvalleallácargadolorabajoestégustomentemariofirmacostofichapl" +
+	"atahogarartesleyesaquelmuseobasespocosmitadcielochicomiedoganars" +
+	"antoetapadebesplayaredessietecortecoreadudasdeseoviejodeseaaguas" +
+	"&quot;domaincommonstatuseventsmastersystemactionbannerremovescro" +
+	"llupdateglobalmediumfilternumberchangeresultpublicscreenchooseno" +
+	"rmaltravelissuessourcetargetspringmodulemobileswitchphotosborder" +
+	"regionitselfsocialactivecolumnrecordfollowtitle>eitherlengthfami" +
+	"lyfriendlayoutauthorcreatereviewsummerserverplayedplayerexpandpo" +
+	"licyformatdoublepointsseriespersonlivingdesignmonthsforcesunique" +
+	"weightpeopleenergynaturesearchfigurehavingcustomoffsetletterwind" +
+	"owsubmitrendergroupsuploadhealthmethodvideosschoolfutureshadowde" +
+	"batevaluesObjectothersrightsleaguechromesimplenoticesharedending" +
+	"seasonreportonlinesquarebuttonimagesenablemovinglatestwinterFran" +
+	"ceperiodstrongrepeatLondondetailformeddemandsecurepassedtogglepl" +
+	"acesdevicestaticcitiesstreamyellowattackstreetflighthiddeninfo\">" +
+	"openedusefulvalleycausesleadersecretseconddamagesportsexceptrati" +
+	"ngsignedthingseffectfieldsstatesofficevisualeditorvolumeReportmu" +
+	"seummoviesparentaccessmostlymother\" id=\"marketgroundchancesurvey" +
+	"beforesymbolmomentspeechmotioninsidematterCenterobjectexistsmidd" +
+	"leEuropegrowthlegacymannerenoughcareeransweroriginportalclientse" +
+	"lectrandomclosedtopicscomingfatheroptionsimplyraisedescapechosen" +
+	"churchdefinereasoncorneroutputmemoryiframepolicemodelsNumberduri" +
+	"ngoffersstyleskilledlistedcalledsilvermargindeletebetterbrowseli" +
+	"mitsGlobalsinglewidgetcenterbudgetnowrapcreditclaimsenginesafety" +
+	"choicespirit-stylespreadmakingneededrussiapleaseextentScriptbrok" +
+	"enallowschargedividefactormember-basedtheoryconfigaroundworkedhe" +
+	"lpedChurchimpactshouldalwayslogo\" bottomlist\">){var prefixorange" +
+	"Header.push(couplegardenbridgelaunchReviewtakingvisionlittledati" +
+	"ngButtonbeautythemesforgotSearchanchoralmostloadedChangereturnst" +
+	"ringreloadMobileincomesupplySourceordersviewed&nbsp;courseAbout " +
+	"island<html cookiename=\"amazonmodernadvicein</a>: The dialoghous" +
+	"esBEGIN MexicostartscentreheightaddingIslandassetsEmpireSchoolef" +
+	"fortdirectnearlymanualSelect.\n\nOnejoinedmenu\">Philipawardshandle" +
+	"importOfficeregardskillsnationSportsdegreeweekly (e.g.behinddoct" +
+	"orloggedunited</b></beginsplantsassistartistissued300px|canadaag" +
+	"encyschemeremainBrazilsamplelogo\">beyond-scaleacceptservedmarine" +
+	"Footercamera</h1>\n_form\"leavesstress\" />\r\n.gif\" onloadloaderOxfo" +
+	"rdsistersurvivlistenfemaleDesignsize=\"appealtext\">levelsthankshi" +
+	"gherforcedanimalanyoneAfricaagreedrecentPeople<br />wonderprices" +
+	"turned|| {};main\">inlinesundaywrap\">failedcensusminutebeaconquot" +
+	"es150px|estateremoteemail\"linkedright;signalformal1.htmlsignuppr" +
+	"incefloat:.png\" forum.AccesspaperssoundsextendHeightsliderUTF-8\"" +
+	"&amp; Before. WithstudioownersmanageprofitjQueryannualparamsboug" +
+	"htfamousgooglelongeri++) {israelsayingdecidehome\">headerensurebr" +
+	"anchpiecesblock;statedtop\"><racingresize--&gt;pacitysexualbureau" +
+	".jpg\" 10,000obtaintitlesamount, Inc.comedymenu\" lyricstoday.inde" +
+	"edcounty_logo.FamilylookedMarketlse ifPlayerturkey);var forestgi" +
+	"vingerrorsDomain}else{insertBlog</footerlogin.fasteragents<body " +
+	"10px 0pragmafridayjuniordollarplacedcoversplugin5,000 page\">bost" +
+	"on.test(avatartested_countforumsschemaindex,filledsharesreaderal" +
+	"ert(appearSubmitline\">body\">\n* TheThoughseeingjerseyNews</verify" +
+	"expertinjurywidth=CookieSTART across_imagethreadnativepocketbox\"" +
+	">\nSystem DavidcancertablesprovedApril reallydriveritem\">more\">bo" +
+	"ardscolorscampusfirst || [];media.guitarfinishwidth:showedOther " +
+	".php\" assumelayerswilsonstoresreliefswedenCustomeasily your Stri" +
+	"ng\n\nWhiltaylorclear:resortfrenchthough\") + \"<body>buyingbrandsMe" +
+	"mbername\">oppingsector5px;\">vspacepostermajor coffeemartinmature" +
+	"happen</nav>kansaslink\">Images=falsewhile hspace0&amp; \n\nIn  pow" +
+	"erPolski-colorjordanBottomStart -count2.htmlnews\">01.jpgOnline-r" +
+	"ightmillerseniorISBN 00,000 guidesvalue)ectionrepair.xml\"  right" +
+	"s.html-blockregExp:hoverwithinvirginphones</tr>\rusing \n\tvar >');" +
+	"\n\t</td>\n</tr>\nbahasabrasilgalegomagyarpolskisrpskiردو中文\xe7\xae" +
+	"\x80体繁體信息中国我们一个公司管理论坛可以服务" +
+	"时间个人产品自己企业查看工作联系没有网站所\xe6" +
+	"\x9c\x89评论中心文章用户首页作者技术问题相关下载\xe6\x90" +
+	"\x9c索使用软件在线主题资料视频回复注册网络收藏" +
+	"内容推荐市场消息空间发布什么好友生活图片发\xe5" +
+	"\xb1\x95如果手机新闻最新方式北京提供关于更多这个\xe7\xb3" +
+	"\xbb统知道游戏广告其他发表安全第一会员进行点击" +
+	"版权电子世界设计免费教育加入活动他们商品博\xe5" +
+	"\xae\xa2现在上海如何已经留言详细社区登录本站需要\xe4\xbb" +
+	"\xb7格支持国际链接国家建设朋友阅读法律位置经济" +
+	"选择这样当前分类排行因为交易最后音乐不能通\xe8" +
+	"\xbf\x87行业科技可能设备合作大家社会研究专业全部\xe9\xa1" +
+	"\xb9目这里还是开始情况电脑文件品牌帮助文化资源" +
+	"大学学习地址浏览投资工程要求怎么时候功能主\xe8" +
+	"\xa6\x81目前资讯城市方法电影招聘声明任何健康数据\xe7\xbe" +
+	"\x8e国汽车介绍但是交流生产所以电话显示一些单位" +
+	"人员分析地图旅游工具学生系列网友帖子密码频\xe9" +
+	"\x81\x93控制地区基本全国网上重要第二喜欢进入友情\xe8\xbf" +
+	"\x99些考试发现培训以上政府成为环境香港同时娱乐" +
+	"发送一定开发作品标准欢迎解决地方一下以及责\xe4" +
+	"\xbb\xbb或者客户代表积分女人数码销售出现离线应用\xe5\x88" +
+	"\x97表不同编辑统计查询不要有关机构很多播放组织" +
+	"政策直接能力来源時間看到热门关键专区非常英\xe8" +
+	"\xaf\xad百度希望美女比较知识规定建议部门意见精彩\xe6\x97" +
+	"\xa5本提高发言方面基金处理权限影片银行还有分享" +
+	"物品经营添加专家这种话题起来业务公告记录简\xe4" +
+	"\xbb\x8b质量男人影响引用报告部分快速咨询时尚注意\xe7\x94" +
+	"\xb3请学校应该历史只是返回购买名称为了成功说明" +
+	"供应孩子专题程序一般會員只有其它保护而且今\xe5" +
+	"\xa4\xa9窗口动态状态特别认为必须更新小说我們作为\xe5\xaa" +
+	"\x92体包括那么一样国内是否根据电视学院具有过程" +
+	"由于人才出来不过正在明星故事关系标题商务输\xe5" +
+	"\x85\xa5一直基础教学了解建筑结果全球通知计划对于\xe8\x89" +
+	"\xba术相册发生真的建立等级类型经验实现制作来自" +
+	"标签以下原创无法其中個人一切指南关闭集团第\xe4" +
+	"\xb8\x89关注因此照片深圳商业广州日期高级最近综合\xe8\xa1" +
+	"\xa8示专辑行为交通评价觉得精华家庭完成感觉安装" +
+	"得到邮件制度食品虽然转载报价记者方案行政人\xe6" +
+	"\xb0\x91用品东西提出酒店然后付款热点以前完全发帖\xe8\xae" +
+	"\xbe置领导工业医院看看经典原因平台各种增加材料" +
+	"新增之后职业效果今年论文我国告诉版主修改参\xe4" +
+	"\xb8\x8e打印快乐机械观点存在精神获得利用继续你们\xe8\xbf" +
+	"\x99么模式语言能够雅虎操作风格一起科学体育短信" +
+	"条件治疗运动产业会议导航先生联盟可是問題结\xe6" +
+	"\x9e\x84作用调查資料自动负责农业访问实施接受讨论\xe9\x82" +
+	"\xa3个反馈加强女性范围服務休闲今日客服觀看参加" +
+	"的话一点保证图书有效测试移动才能决定股票不\xe6" +
+	"\x96\xad需求不得办法之间采用营销投诉目标爱情摄影\xe6\x9c" +
+	"\x89些複製文学机会数字装修购物农村全面精品其实" +
+	"事情水平提示上市谢谢普通教师上传类别歌曲拥\xe6" +
+	"\x9c\x89创新配件只要时代資訊达到人生订阅老师展示\xe5\xbf" +
+	"\x83理贴子網站主題自然级别简单改革那些来说打开" +
+	"代码删除证券节目重点次數多少规划资金找到以\xe5" +
+	"\x90\x8e大全主页最佳回答天下保障现代检查投票小时\xe6\xb2" +
+	"\x92有正常甚至代理目录公开复制金融幸福版本形成" +
+	"准备行情回到思想怎样协议认证最好产生按照服\xe8" +
+	"\xa3\x85广东动漫采购新手组图面板参考政治容易天地\xe5\x8a" +
+	"\xaa力人们升级速度人物调整流行造成文字韩国贸易" +
+	"开展相關表现影视如此美容大小报道条款心情许\xe5" +
+	"\xa4\x9a法规家居书店连接立即举报技巧奥运登入以来\xe7\x90" +
+	"\x86论事件自由中华办公妈妈真正不错全文合同价值" +
+	"别人监督具体世纪团队创业承担增长有人保持商\xe5" +
+	"\xae\xb6维修台湾左右股份答案实际电信经理生命宣传\xe4\xbb" +
+	"\xbb务正式特色下来协会只能当然重新內容指导运行" +
+	"日志賣家超过土地浙江支付推出站长杭州执行制\xe9" +
+	"\x80\xa0之一推广现场描述变化传统歌手保险课程医疗\xe7\xbb" +
+	"\x8f过过去之前收入年度杂志美丽最高登陆未来加工" +
+	"免责教程版块身体重庆出售成本形式土豆出價东\xe6" +
+	"\x96\xb9邮箱南京求职取得职位相信页面分钟网页确定\xe5\x9b" +
+	"\xbe例网址积极错误目的宝贝机关风险授权病毒宠物" +
+	"除了評論疾病及时求购站点儿童每天中央认识每\xe4" +
+	"\xb8\xaa天津字体台灣维护本页个性官方常见相机战略\xe5\xba" +
+	"\x94当律师方便校园股市房屋栏目员工导致突然道具" +
+	"本网结合档案劳动另外美元引起改变第四会计說\xe6" +
+	"\x98\x8e隐私宝宝规范消费共同忘记体系带来名字發表\xe5\xbc" +
+	"\x80放加盟受到二手大量成人数量共享区域女孩原则" +
+	"所在结束通信超级配置当时优秀性感房产遊戲出\xe5" +
+	"\x8f\xa3提交就业保健程度参数事业整个山东情感特殊\xe5\x88" +
+	"\x86類搜尋属于门户财务声音及其财经坚持干部成立" +
+	"利益考虑成都包装用戶比赛文明招商完整真是眼\xe7" +
+	"\x9d\x9b伙伴威望领域卫生优惠論壇公共良好充分符合\xe9\x99" +
+	"\x84件特点不可英文资产根本明显密碼公众民族更加" +
+	"享受同学启动适合原来问答本文美食绿色稳定终\xe4" +
+	"\xba\x8e生物供求搜狐力量严重永远写真有限竞争对象\xe8\xb4" +
+	"\xb9用不好绝对十分促进点评影音优势不少欣赏并且" +
+	"有点方向全新信用设施形象资格突破随着重大于\xe6" +
+	"\x98\xaf毕业智能化工完美商城统一出版打造產品概况\xe7\x94" +
+	"\xa8于保留因素中國存储贴图最愛长期口价理财基地" +
+	"安排武汉里面创建天空首先完善驱动下面不再诚\xe4" +
+	"\xbf\xa1意义阳光英国漂亮军事玩家群众农民即可名稱\xe5\xae" +
+	"\xb6具动画想到注明小学性能考研硬件观看清楚搞笑" +
+	"首頁黄金适用江苏真实主管阶段註冊翻译权利做\xe5" +
+	"\xa5\xbd似乎通讯施工狀態也许环保培养概念大型机票\xe7\x90" +
+	"\x86解匿名cuandoenviarmadridbuscariniciotiempoporquecuentaestado" +
+	"puedenjuegoscontraestánnombretienenperfilmaneraamigosciudadcent" +
+	"roaunquepuedesdentroprimerpreciosegúnbuenosvolverpuntossemanaha" +
+	"bíaagostonuevosunidoscarlosequiponiñosmuchosalgunacorreoimagen" +
+	"partirarribamaríahombreempleoverdadcambiomuchasfueronpasadolín" +
+	"eaparecenuevascursosestabaquierolibroscuantoaccesomiguelvarioscu" +
+	"atrotienesgruposseráneuropamediosfrenteacercademásofertacoches" +
+	"modeloitalialetrasalgúncompracualesexistecuerposiendoprensalleg" +
+	"arviajesdineromurciapodrápuestodiariopuebloquieremanuelpropiocr" +
+	"isisciertoseguromuertefuentecerrargrandeefectopartesmedidapropia" +
+	"ofrecetierrae-mailvariasformasfuturoobjetoseguirriesgonormasmism" +
+	"osúnicocaminositiosrazóndebidopruebatoledoteníajesúsesperoco" +
+	"cinaorigentiendacientocádizhablarseríalatinafuerzaestiloguerra" +
+	"entraréxitolópezagendavídeoevitarpaginametrosjavierpadresfác" +
+	"ilcabezaáreassalidaenvíojapónabusosbienestextosllevarpuedanfu" +
+	"ertecomúnclaseshumanotenidobilbaounidadestáseditarcreadoдля" +
+	"чтокакилиэтовсеегопритакещеужеКа" +
+	"кбезбылониВсеподЭтотомчемнетлетр" +
+	"азонагдемнеДляПринаснихтемктогод" +
+	"воттамСШАмаяЧтовасвамемуТакдвана" +
+	"мэтиэтуВамтехпротутнаддняВоттрин" +
+	"ейВаснимсамтотрубОнимирнееОООлиц" +
+	"этаОнанемдоммойдвеоносудकेहैक\xe0" +
+	"\xa5\x80सेकाकोऔरपरनेएककिभीइस\xe0\xa4" +
+	"\x95रतोहोआपहीयहयातकथाjagranआज" +
+	"जोअबदोगईजागएहमइनवहयेथ\xe0" +
+	"\xa5\x87थीघरजबदीकईजीवेनईनएहर\xe0\xa4" +
+	"\x89समेकमवोलेसबमईदेओरआमबस" +
+	"भरबनचलमनआगसीलीعلىإلىهذاآخ" +
+	"رعددالىهذهصورغيركانولابينعرضذلكه" +
+	"نايومقالعليانالكنحتىقبلوحةاخرفقط" +
+	"عبدركنإذاكمااحدإلافيهبعضكيفبحثوم" +
+	"نوهوأناجدالهاسلمعندليسعبرصلىمنذب" +
+	"هاأنهمثلكنتالاحيثمصرشرححولوفياذا" +
+	"لكلمرةانتالفأبوخاصأنتانهاليعضووق" +
+	"دابنخيربنتلكمشاءوهيابوقصصومارقمأ" +
+	"حدنحنعدمرأياحةكتبدونيجبمنهتحتجهة" +
+	"سنةيتمكرةغزةنفسبيتللهلناتلكقلبلم" +
+	"اعنهأولشيءنورأمافيكبكلذاترتببأنه" +
+	"مسانكبيعفقدحسنلهمشعرأهلشهرقطرطلب" +
+	"profileservicedefaulthimselfdetailscontentsupportstartedmessages" +
+	"uccessfashion<title>countryaccountcreatedstoriesresultsrunningpr" +
+	"ocesswritingobjectsvisiblewelcomearticleunknownnetworkcompanydyn" +
+	"amicbrowserprivacyproblemServicerespectdisplayrequestreservewebs" +
+	"itehistoryfriendsoptionsworkingversionmillionchannelwindow.addre" +
+	"ssvisitedweathercorrectproductedirectforwardyou canremovedsubjec" +
+	"tcontrolarchivecurrentreadinglibrarylimitedmanagerfurthersummary" +
+	"machineminutesprivatecontextprogramsocietynumberswrittenenabledt" +
+	"riggersourcesloadingelementpartnerfinallyperfectmeaningsystemske" +
+	"epingculture&quot;,journalprojectsurfaces&quot;expiresreviewsbal" +
+	"anceEnglishContentthroughPlease opinioncontactaverageprimaryvill" +
+	"ageSpanishgallerydeclinemeetingmissionpopularqualitymeasuregener" +
+	"alspeciessessionsectionwriterscounterinitialreportsfiguresmember" +
+	"sholdingdisputeearlierexpressdigitalpictureAnothermarriedtraffic" +
+	"leadingchangedcentralvictoryimages/reasonsstudiesfeaturelistingm" +
+	"ust beschoolsVersionusuallyepisodeplayinggrowingobviousoverlaypr" +
+	"esentactions</ul>\r\nwrapperalreadycertainrealitystorageanotherdes" +
+	"ktopofferedpatternunusualDigitalcapitalWebsitefailureconnectredu" +
+	"cedAndroiddecadesregular &amp; animalsreleaseAutomatgettingmetho" +
+	"dsnothingPopularcaptionletterscapturesciencelicensechangesEnglan" +
+	"d=1&amp;History = new CentralupdatedSpecialNetworkrequirecomment" +
+	"warningCollegetoolbarremainsbecauseelectedDeutschfinanceworkersq" +
+	"uicklybetweenexactlysettingdiseaseSocietyweaponsexhibit&lt;!--Co" +
+	"ntrolclassescoveredoutlineattacksdevices(windowpurposetitle=\"Mob" +
+	"ile killingshowingItaliandroppedheavilyeffects-1']);\nconfirmCurr" +
+	"entadvancesharingopeningdrawingbillionorderedGermanyrelated</for" +
+	"m>includewhetherdefinedSciencecatalogArticlebuttonslargestunifor" +
+	"mjourneysidebarChicagoholidayGeneralpassage,&quot;animatefeeling" +
+	"arrivedpassingnaturalroughly.\n\nThe but notdensityBritainChinesel" +
+	"ack oftributeIreland\" data-factorsreceivethat isLibraryhusbandin" +
+	" factaffairsCharlesradicalbroughtfindinglanding:lang=\"return lea" +
+	"dersplannedpremiumpackageAmericaEdition]&quot;Messageneed tovalu" +
+	"e=\"complexlookingstationbelievesmaller-mobilerecordswant tokind " +
+	"ofFirefoxyou aresimilarstudiedmaximumheadingrapidlyclimatekingdo" +
+	"memergedamountsfoundedpioneerformuladynastyhow to Supportrevenue" +
+	"economyResultsbrothersoldierlargelycalling.&quot;AccountEdward s" +
+	"egmentRobert effortsPacificlearnedup withheight:we haveAngelesna" +
+	"tions_searchappliedacquiremassivegranted: falsetreatedbiggestben" +
+	"efitdrivingStudiesminimumperhapsmorningsellingis usedreversevari" +
+	"ant role=\"missingachievepromotestudentsomeoneextremerestorebotto" +
+	"m:evolvedall thesitemapenglishway to  AugustsymbolsCompanymatter" +
+	"smusicalagainstserving})();\r\npaymenttroubleconceptcompareparents" +
+	"playersregionsmonitor ''The winningexploreadaptedGalleryproducea" +
+	"bilityenhancecareers). The collectSearch ancientexistedfooter ha" +
+	"ndlerprintedconsoleEasternexportswindowsChannelillegalneutralsug" +
+	"gest_headersigning.html\">settledwesterncausing-webkitclaimedJust" +
+	"icechaptervictimsThomas mozillapromisepartieseditionoutside:fals" +
+	"e,hundredOlympic_buttonauthorsreachedchronicdemandssecondsprotec" +
+	"tadoptedprepareneithergreatlygreateroverallimprovecommandspecial" +
+	"search.worshipfundingthoughthighestinsteadutilityquarterCulturet" +
+	"estingclearlyexposedBrowserliberal} catchProjectexamplehide();Fl" +
+	"oridaanswersallowedEmperordefenseseriousfreedomSeveral-buttonFur" +
+	"therout of != nulltrainedDenmarkvoid(0)/all.jspreventRequestStep" +
+	"hen\n\nWhen observe</h2>\r\nModern provide\" alt=\"borders.\n\nFor \n\nMan" +
+	"y artistspoweredperformfictiontype ofmedicalticketsopposedCounci" +
+	"lwitnessjusticeGeorge Belgium...</a>twitternotablywaitingwarfare" +
+	" Other rankingphrasesmentionsurvivescholar</p>\r\n Countryignoredl" +
+	"oss ofjust asGeorgiastrange<head><stopped1']);\r\nislandsnotablebo" +
+	"rder:list ofcarried100,000</h3>\n severalbecomesselect wedding00." +
+	"htmlmonarchoff theteacherhighly biologylife ofor evenrise of&raq" +
+	"uo;plusonehunting(thoughDouglasjoiningcirclesFor theAncientVietn" +
+	"amvehiclesuch ascrystalvalue =Windowsenjoyeda smallassumed<a id=" +
+	"\"foreign All rihow theDisplayretiredhoweverhidden;battlesseeking" +
+	"cabinetwas notlook atconductget theJanuaryhappensturninga:hoverO" +
+	"nline French lackingtypicalextractenemieseven ifgeneratdecidedar" +
+	"e not/searchbeliefs-image:locatedstatic.login\">convertviolentent" +
+	"eredfirst\">circuitFinlandchemistshe was10px;\">as suchdivided</sp" +
+	"an>will beline ofa greatmystery/index.fallingdue to railwaycolle" +
+	"gemonsterdescentit withnuclearJewish protestBritishflowerspredic" +
+	"treformsbutton who waslectureinstantsuicidegenericperiodsmarkets" +
+	"Social fishingcombinegraphicwinners<br /><by the NaturalPrivacyc" +
+	"ookiesoutcomeresolveSwedishbrieflyPersianso muchCenturydepictsco" +
+	"lumnshousingscriptsnext tobearingmappingrevisedjQuery(-width:tit" +
+	"le\">tooltipSectiondesignsTurkishyounger.match(})();\n\nburningoper" +
+	"atedegreessource=Richardcloselyplasticentries</tr>\r\ncolor:#ul id" +
+	"=\"possessrollingphysicsfailingexecutecontestlink toDefault<br />" +
+	"\n: true,chartertourismclassicproceedexplain</h1>\r\nonline.?xml ve" +
+	"helpingdiamonduse theairlineend -->).attr(readershosting#ffffffr" +
+	"ealizeVincentsignals src=\"/ProductdespitediversetellingPublic he" +
+	"ld inJoseph theatreaffects<style>a largedoesn'tlater, Elementfav" +
+	"iconcreatorHungaryAirportsee theso thatMichaelSystemsPrograms, a" +
+	"nd  width=e&quot;tradingleft\">\npersonsGolden Affairsgrammarformi" +
+	"ngdestroyidea ofcase ofoldest this is.src = cartoonregistrCommon" +
+	"sMuslimsWhat isin manymarkingrevealsIndeed,equally/show_aoutdoor" +
+	"escape(Austriageneticsystem,In the sittingHe alsoIslandsAcademy\n" +
+	"\t\t<!--Daniel bindingblock\">imposedutilizeAbraham(except{width:pu" +
+	"tting).html(|| [];\nDATA[ *kitchenmountedactual dialectmainly _bl" +
+	"ank'installexpertsif(typeIt also&copy; \">Termsborn inOptionseast" +
+	"erntalkingconcerngained ongoingjustifycriticsfactoryits ownassau" +
+	"ltinvitedlastinghis ownhref=\"/\" rel=\"developconcertdiagramdollar" +
+	"sclusterphp?id=alcohol);})();using a><span>vesselsrevivalAddress" +
+	"amateurandroidallegedillnesswalkingcentersqualifymatchesunifiede" +
+	"xtinctDefensedied in\n\t<!-- customslinkingLittle Book ofeveningmi" +
+	"n.js?are thekontakttoday's.html\" target=wearingAll Rig;\n})();rai" +
+	"sing Also, crucialabout\">declare-->\n<scfirefoxas muchappliesinde" +
+	"x, s, but type = \n\r\n<!--towardsRecordsPrivateForeignPremierchoic" +
+	"esVirtualreturnsCommentPoweredinline;povertychamberLiving volume" +
+	"sAnthonylogin\" RelatedEconomyreachescuttinggravitylife inChapter" +
+	"-shadowNotable</td>\r\n returnstadiumwidgetsvaryingtravelsheld byw" +
+	"ho arework infacultyangularwho hadairporttown of\n\nSome 'click'ch" +
+	"argeskeywordit willcity of(this);Andrew unique checkedor more300" +
+	"px; return;rsion=\"pluginswithin herselfStationFederalventurepubl" +
+	"ishsent totensionactresscome tofingersDuke ofpeople,exploitwhat " +
+	"isharmonya major\":\"httpin his menu\">\nmonthlyofficercouncilgainin" +
+	"geven inSummarydate ofloyaltyfitnessand wasemperorsupremeSecond " +
+	"hearingRussianlongestAlbertalateralset of small\">.appenddo withf" +
+	"ederalbank ofbeneathDespiteCapitalgrounds), and percentit fromcl" +
+	"osingcontainInsteadfifteenas well.yahoo.respondfighterobscureref" +
+	"lectorganic= Math.editingonline paddinga wholeonerroryear ofend " +
+	"of barrierwhen itheader home ofresumedrenamedstrong>heatingretai" +
+	"nscloudfrway of March 1knowingin partBetweenlessonsclosestvirtua" +
+	"llinks\">crossedEND -->famous awardedLicenseHealth fairly wealthy" +
+	"minimalAfricancompetelabel\">singingfarmersBrasil)discussreplaceG" +
+	"regoryfont copursuedappearsmake uproundedboth ofblockedsaw theof" +
+	"ficescoloursif(docuwhen heenforcepush(fuAugust UTF-8\">Fantasyin " +
+	"mostinjuredUsuallyfarmingclosureobject defenceuse of Medical<bod" +
+	"y>\nevidentbe usedkeyCodesixteenIslamic#000000entire widely activ" +
+	"e (typeofone cancolor =speakerextendsPhysicsterrain<tbody>funera" +
+	"lviewingmiddle cricketprophetshifteddoctorsRussell targetcompact" +
+	"algebrasocial-bulk ofman and</td>\n he left).val()false);logicalb" +
+	"ankinghome tonaming Arizonacredits);\n});\nfounderin turnCollinsbe" +
+	"fore But thechargedTitle\">CaptainspelledgoddessTag -->Adding:but" +
+	" wasRecent patientback in=false&Lincolnwe knowCounterJudaismscri" +
+	"pt altered']);\n  has theunclearEvent',both innot all\n\n<!-- placi" +
+	"nghard to centersort ofclientsstreetsBernardassertstend tofantas" +
+	"ydown inharbourFreedomjewelry/about..searchlegendsis mademodern " +
+	"only ononly toimage\" linear painterand notrarely acronymdelivers" +
+	"horter00&amp;as manywidth=\"/* <![Ctitle =of the lowest picked es" +
+	"capeduses ofpeoples PublicMatthewtacticsdamagedway forlaws ofeas" +
+	"y to windowstrong  simple}catch(seventhinfoboxwent topaintedciti" +
+	"zenI don'tretreat. Some ww.\");\nbombingmailto:made in. Many carri" +
+	"es||{};wiwork ofsynonymdefeatsfavoredopticalpageTraunless sendin" +
+	"gleft\"><comScorAll thejQuery.touristClassicfalse\" Wilhelmsuburbs" +
+	"genuinebishops.split(global followsbody ofnominalContactsecularl" +
+	"eft tochiefly-hidden-banner</li>\n\n. When in bothdismissExploreal" +
+	"ways via thespañolwelfareruling arrangecaptainhis sonrule ofhe " +
+	"tookitself,=0&amp;(calledsamplesto makecom/pagMartin Kennedyacce" +
+	"ptsfull ofhandledBesides//--></able totargetsessencehim to its b" +
+	"y common.mineralto takeways tos.org/ladvisedpenaltysimple:if the" +
+	"yLettersa shortHerbertstrikes groups.lengthflightsoverlapslowly " +
+	"lesser social </p>\n\t\tit intoranked rate oful>\r\n  attemptpair ofm" +
+	"ake itKontaktAntoniohaving ratings activestreamstrapped\").css(ho" +
+	"stilelead tolittle groups,Picture-->\r\n\r\n rows=\" objectinverse<fo" +
+	"oterCustomV><\\/scrsolvingChamberslaverywoundedwhereas!= 'undfor " +
+	"allpartly -right:Arabianbacked centuryunit ofmobile-Europe,is ho" +
+	"merisk ofdesiredClintoncost ofage of become none ofp&quot;Middle" +
+	" ead')[0Criticsstudios>&copy;group\">assemblmaking pressedwidget." +
+	"ps:\" ? rebuiltby someFormer editorsdelayedCanonichad thepushingc" +
+	"lass=\"but arepartialBabylonbottom carrierCommandits useAs withco" +
+	"ursesa thirddenotesalso inHouston20px;\">accuseddouble goal ofFam" +
+	"ous ).bind(priests Onlinein Julyst + \"gconsultdecimalhelpfulrevi" +
+	"vedis veryr'+'iptlosing femalesis alsostringsdays ofarrivalfutur" +
+	"e <objectforcingString(\" />\n\t\there isencoded.  The balloondone b" +
+	"y/commonbgcolorlaw of Indianaavoidedbut the2px 3pxjquery.after a" +
+	"policy.men andfooter-= true;for usescreen.Indian image =family,h" +
+	"ttp:// &nbsp;driverseternalsame asnoticedviewers})();\n is morese" +
+	"asonsformer the newis justconsent Searchwas thewhy theshippedbr>" +
+	"<br>width: height=made ofcuisineis thata very Admiral fixed;norm" +
+	"al MissionPress, ontariocharsettry to invaded=\"true\"spacingis mo" +
+	"sta more totallyfall of});\r\n  immensetime inset outsatisfyto fin" +
+	"ddown tolot of Playersin Junequantumnot thetime todistantFinnish" +
+	"src = (single help ofGerman law andlabeledforestscookingspace\">h" +
+	"eader-well asStanleybridges/globalCroatia About [0];\n  it, andgr" +
+	"oupedbeing a){throwhe madelighterethicalFFFFFF\"bottom\"like a emp" +
+	"loyslive inas seenprintermost ofub-linkrejectsand useimage\">succ" +
+	"eedfeedingNuclearinformato helpWomen'sNeitherMexicanprotein<tabl" +
+	"e by manyhealthylawsuitdevised.push({sellerssimply Through.cooki" +
+	"e Image(older\">us.js\"> Since universlarger open to!-- endlies in" +
+	"']);\r\n  marketwho is (\"DOMComanagedone fortypeof Kingdomprofitsp" +
+	"roposeto showcenter;made itdressedwere inmixtureprecisearisingsr" +
+	"c = 'make a securedBaptistvoting \n\t\tvar March 2grew upClimate.re" +
+	"moveskilledway the</head>face ofacting right\">to workreduceshas " +
+	"haderectedshow();action=book ofan area== \"htt<header\n<html>confo" +
+	"rmfacing cookie.rely onhosted .customhe wentbut forspread Family" +
+	" a meansout theforums.footage\">MobilClements\" id=\"as highintense" +
+	"--><!--female is seenimpliedset thea stateand hisfastestbesidesb" +
+	"utton_bounded\"><img Infoboxevents,a youngand areNative cheaperTi" +
+	"meoutand hasengineswon the(mostlyright: find a -bottomPrince are" +
+	"a ofmore ofsearch_nature,legallyperiod,land ofor withinducedprov" +
+	"ingmissilelocallyAgainstthe wayk&quot;px;\">\r\npushed abandonnumer" +
+	"alCertainIn thismore inor somename isand, incrownedISBN 0-create" +
+	"sOctobermay notcenter late inDefenceenactedwish tobroadlycooling" +
+	"onload=it. TherecoverMembersheight assumes<html>\npeople.in one =" +
+	"windowfooter_a good reklamaothers,to this_cookiepanel\">London,de" +
+	"finescrushedbaptismcoastalstatus title\" move tolost inbetter imp" +
+	"liesrivalryservers SystemPerhapses and contendflowinglasted rise" +
+	" inGenesisview ofrising seem tobut in backinghe willgiven agivin" +
+	"g cities.flow of Later all butHighwayonly bysign ofhe doesdiffer" +
+	"sbattery&amp;lasinglesthreatsintegertake onrefusedcalled =US&amp" +
+	"See thenativesby thissystem.head of:hover,lesbiansurnameand allc" +
+	"ommon/header__paramsHarvard/pixel.removalso longrole ofjointlysk" +
+	"yscraUnicodebr />\r\nAtlantanucleusCounty,purely count\">easily bui" +
+	"ld aonclicka givenpointerh&quot;events else {\nditionsnow the, wi" +
+	"th man whoorg/Webone andcavalryHe diedseattle00,000 {windowhave " +
+	"toif(windand itssolely m&quot;renewedDetroitamongsteither them i" +
+	"nSenatorUs</a><King ofFrancis-produche usedart andhim andused by" +
+	"scoringat hometo haverelatesibilityfactionBuffalolink\"><what hef" +
+	"ree toCity ofcome insectorscountedone daynervoussquare };if(goin" +
+	" whatimg\" alis onlysearch/tuesdaylooselySolomonsexual - <a hrmed" +
+	"ium\"DO NOT France,with a war andsecond take a >\r\n\r\n\r\nmarket.high" +
+	"waydone inctivity\"last\">obligedrise to\"undefimade to Early prais" +
+	"edin its for hisathleteJupiterYahoo! termed so manyreally s. The" +
+	" a woman?value=direct right\" bicycleacing=\"day andstatingRather," +
+	"higher Office are nowtimes, when a pay foron this-link\">;bordera" +
+	"round annual the Newput the.com\" takin toa brief(in thegroups.; " +
+	"widthenzymessimple in late{returntherapya pointbanninginks\">\n();" +
+	"\" rea place\\u003Caabout atr>\r\n\t\tccount gives a<SCRIPTRailwaythem" +
+	"es/toolboxById(\"xhumans,watchesin some if (wicoming formats Unde" +
+	"r but hashanded made bythan infear ofdenoted/iframeleft involtag" +
+	"ein eacha&quot;base ofIn manyundergoregimesaction </p>\r\n<ustomVa" +
+	";&gt;</importsor thatmostly &amp;re size=\"</a></ha classpassiveH" +
+	"ost = WhetherfertileVarious=[];(fucameras/></td>acts asIn some>\r" +
+	"\n\r\n<!organis <br />Beijingcatalàdeutscheuropeueuskaragaeilgesve" +
+	"nskaespañamensajeusuariotrabajoméxicopáginasiempresistemaoctu" +
+	"breduranteañadirempresamomentonuestroprimeratravésgraciasnuest" +
+	"raprocesoestadoscalidadpersonanúmeroacuerdomúsicamiembrooferta" +
+	"salgunospaísesejemploderechoademásprivadoagregarenlacesposible" +
+	"hotelessevillaprimeroúltimoeventosarchivoculturamujeresentradaa" +
+	"nuncioembargomercadograndesestudiomejoresfebrerodiseñoturismoc\xc3" +
+	"\xb3digoportadaespaciofamiliaantoniopermiteguardaralgunaspreciosalg" +
+	"uiensentidovisitastítuloconocersegundoconsejofranciaminutossegu" +
+	"ndatenemosefectosmálagasesiónrevistagranadacompraringresogarc\xc3" +
+	"\xadaacciónecuadorquienesinclusodeberámateriahombresmuestrapodrí" +
+	"amañanaúltimaestamosoficialtambienningúnsaludospodemosmejorar" +
+	"positionbusinesshomepagesecuritylanguagestandardcampaignfeatures" +
+	"categoryexternalchildrenreservedresearchexchangefavoritetemplate" +
+	"militaryindustryservicesmaterialproductsz-index:commentssoftware" +
+	"completecalendarplatformarticlesrequiredmovementquestionbuilding" +
+	"politicspossiblereligionphysicalfeedbackregisterpicturesdisabled" +
+	"protocolaudiencesettingsactivityelementslearninganythingabstract" +
+	"progressoverviewmagazineeconomictrainingpressurevarious <strong>" +
+	"propertyshoppingtogetheradvancedbehaviordownloadfeaturedfootball" +
+	"selectedLanguagedistanceremembertrackingpasswordmodifiedstudents" +
+	"directlyfightingnortherndatabasefestivalbreakinglocationinternet" +
+	"dropdownpracticeevidencefunctionmarriageresponseproblemsnegative" +
+	"programsanalysisreleasedbanner\">purchasepoliciesregionalcreative" +
+	"argumentbookmarkreferrerchemicaldivisioncallbackseparateprojects" +
+	"conflicthardwareinterestdeliverymountainobtained= false;for(var " +
+	"acceptedcapacitycomputeridentityaircraftemployedproposeddomestic" +
+	"includesprovidedhospitalverticalcollapseapproachpartnerslogo\"><a" +
+	"daughterauthor\" culturalfamilies/images/assemblypowerfulteaching" +
+	"finisheddistrictcriticalcgi-bin/purposesrequireselectionbecoming" +
+	"providesacademicexerciseactuallymedicineconstantaccidentMagazine" +
+	"documentstartingbottom\">observed: &quot;extendedpreviousSoftware" +
+	"customerdecisionstrengthdetailedslightlyplanningtextareacurrency" +
+	"everyonestraighttransferpositiveproducedheritageshippingabsolute" +
+	"receivedrelevantbutton\" violenceanywherebenefitslaunchedrecently" +
+	"alliancefollowedmultiplebulletinincludedoccurredinternal$(this)." +
+	"republic><tr><tdcongressrecordedultimatesolution<ul id=\"discover" +
+	"Home</a>websitesnetworksalthoughentirelymemorialmessagescontinue" +
+	"active\">somewhatvictoriaWestern  title=\"Locationcontractvisitors" +
+	"Downloadwithout right\">\nmeasureswidth = variableinvolvedvirginia" +
+	"normallyhappenedaccountsstandingnationalRegisterpreparedcontrols" +
+	"accuratebirthdaystrategyofficialgraphicscriminalpossiblyconsumer" +
+	"Personalspeakingvalidateachieved.jpg\" />machines</h2>\n  keywords" +
+	"friendlybrotherscombinedoriginalcomposedexpectedadequatepakistan" +
+	"follow\" valuable</label>relativebringingincreasegovernorplugins/" +
+	"List of Header\">\" name=\" (&quot;graduate</head>\ncommercemalaysia" +
+	"directormaintain;height:schedulechangingback to catholicpatterns" +
+	"color: #greatestsuppliesreliable</ul>\n\t\t<select citizensclothing" +
+	"watching<li id=\"specificcarryingsentence<center>contrastthinking" +
+	"catch(e)southernMichael merchantcarouselpadding:interior.split(\"" +
+	"lizationOctober ){returnimproved--&gt;\n\ncoveragechairman.png\" />" +
+	"subjectsRichard whateverprobablyrecoverybaseballjudgmentconnect." +
+	".css\" /> websitereporteddefault\"/></a>\r\nelectricscotlandcreation" +
+	"quantity. ISBN 0did not instance-search-\" lang=\"speakersComputer" +
+	"containsarchivesministerreactiondiscountItalianocriteriastrongly" +
+	": 'http:'script'coveringofferingappearedBritish identifyFacebook" +
+	"numerousvehiclesconcernsAmericanhandlingdiv id=\"William provider" +
+	"_contentaccuracysection andersonflexibleCategorylawrence<script>" +
+	"layout=\"approved maximumheader\"></table>Serviceshamiltoncurrent " +
+	"canadianchannels/themes//articleoptionalportugalvalue=\"\"interval" +
+	"wirelessentitledagenciesSearch\" measuredthousandspending&hellip;" +
+	"new Date\" size=\"pageNamemiddle\" \" /></a>hidden\">sequencepersonal" +
+	"overflowopinionsillinoislinks\">\n\t<title>versionssaturdayterminal" +
+	"itempropengineersectionsdesignerproposal=\"false\"Españolreleases" +
+	"submit\" er&quot;additionsymptomsorientedresourceright\"><pleasure" +
+	"stationshistory.leaving  border=contentscenter\">.\n\nSome directed" +
+	"suitablebulgaria.show();designedGeneral conceptsExampleswilliams" +
+	"Original\"><span>search\">operatorrequestsa &quot;allowingDocument" +
+	"revision. \n\nThe yourselfContact michiganEnglish columbiapriority" +
+	"printingdrinkingfacilityreturnedContent officersRussian generate" +
+	"-8859-1\"indicatefamiliar qualitymargin:0 contentviewportcontacts" +
+	"-title\">portable.length eligibleinvolvesatlanticonload=\"default." +
+	"suppliedpaymentsglossary\n\nAfter guidance</td><tdencodingmiddle\">" +
+	"came to displaysscottishjonathanmajoritywidgets.clinicalthailand" +
+	"teachers<head>\n\taffectedsupportspointer;toString</small>oklahoma" +
+	"will be investor0\" alt=\"holidaysResourcelicensed (which . After " +
+	"considervisitingexplorerprimary search\" android\"quickly meetings" +
+	"estimate;return ;color:# height=approval, &quot; checked.min.js\"" +
+	"magnetic></a></hforecast. While thursdaydvertise&eacute;hasClass" +
+	"evaluateorderingexistingpatients Online coloradoOptions\"campbell" +
+	"<!-- end</span><<br />\r\n_popups|sciences,&quot; quality Windows " +
+	"assignedheight: <b classle&quot; value=\" Companyexamples<iframe " +
+	"believespresentsmarshallpart of properly).\n\nThe taxonomymuch of " +
+	"</span>\n\" data-srtuguêsscrollTo project<head>\r\nattorneyemphasis" +
+	"sponsorsfancyboxworld's wildlifechecked=sessionsprogrammpx;font-" +
+	" Projectjournalsbelievedvacationthompsonlightingand the special " +
+	"border=0checking</tbody><button Completeclearfix\n<head>\narticle " +
+	"<sectionfindingsrole in popular  Octoberwebsite exposureused to " +
+	" changesoperatedclickingenteringcommandsinformed numbers  </div>" +
+	"creatingonSubmitmarylandcollegesanalyticlistingscontact.loggedIn" +
+	"advisorysiblingscontent\"s&quot;)s. This packagescheckboxsuggests" +
+	"pregnanttomorrowspacing=icon.pngjapanesecodebasebutton\">gambling" +
+	"such as , while </span> missourisportingtop:1px .</span>tensions" +
+	"width=\"2lazyloadnovemberused in height=\"cript\">\n&nbsp;</<tr><td " +
+	"height:2/productcountry include footer\" &lt;!-- title\"></jquery." +
+	"</form>\n(简体)(繁體)hrvatskiitalianoromânătürkçeاردو" +
+	"tambiénnoticiasmensajespersonasderechosnacionalserviciocontacto" +
+	"usuariosprogramagobiernoempresasanunciosvalenciacolombiadespués" +
+	"deportesproyectoproductopúbliconosotroshistoriapresentemillones" +
+	"mediantepreguntaanteriorrecursosproblemasantiagonuestrosopinión" +
+	"imprimirmientrasaméricavendedorsociedadrespectorealizarregistro" +
+	"palabrasinterésentoncesespecialmiembrosrealidadcórdobazaragoza" +
+	"páginassocialesbloqueargestiónalquilersistemascienciascompleto" +
+	"versióncompletaestudiospúblicaobjetivoalicantebuscadorcantidad" +
+	"entradasaccionesarchivossuperiormayoríaalemaniafunciónúltimos" +
+	"haciendoaquellosediciónfernandoambientefacebooknuestrasclientes" +
+	"procesosbastantepresentareportarcongresopublicarcomerciocontrato" +
+	"jóvenesdistritotécnicaconjuntoenergíatrabajarasturiasreciente" +
+	"utilizarboletínsalvadorcorrectatrabajosprimerosnegocioslibertad" +
+	"detallespantallapróximoalmeríaanimalesquiénescorazónsección" +
+	"buscandoopcionesexteriorconceptotodavíagaleríaescribirmedicina" +
+	"licenciaconsultaaspectoscríticadólaresjusticiadeberánperíodo" +
+	"necesitamantenerpequeñorecibidatribunaltenerifecancióncanarias" +
+	"descargadiversosmallorcarequieretécnicodeberíaviviendafinanzas" +
+	"adelantefuncionaconsejosdifícilciudadesantiguasavanzadatérmino" +
+	"unidadessánchezcampañasoftonicrevistascontienesectoresmomentos" +
+	"facultadcréditodiversassupuestofactoressegundospequeñaгода" +
+	"еслиестьбылобытьэтомЕслитогоменя" +
+	"всехэтойдажебылигодуденьэтотбыла" +
+	"себяодинсебенадосайтфотонегосвои" +
+	"свойигрытожевсемсвоюлишьэтихпока" +
+	"днейдомамиралиботемухотядвухсети" +
+	"людиделомиретебясвоевидечегоэтим" +
+	"счеттемыценысталведьтемеводытебе" +
+	"вышенамитипатомуправлицаоднагоды" +
+	"знаюмогудругвсейидеткинооднодела" +
+	"делесрокиюнявесьЕстьразанашиالله" +
+	"التيجميعخاصةالذيعليهجديدالآنالرد" +
+	"تحكمصفحةكانتاللييكونشبكةفيهابنات" +
+	"حواءأكثرخلالالحبدليلدروساضغطتكون" +
+	"هناكساحةناديالطبعليكشكرايمكنمنها" +
+	"شركةرئيسنشيطماذاالفنشبابتعبررحمة" +
+	"كافةيقولمركزكلمةأحمدقلبييعنيصورة" +
+	"طريقشاركجوالأخرىمعناابحثعروضبشكل" +
+	"مسجلبنانخالدكتابكليةبدونأيضايوجد" +
+	"فريقكتبتأفضلمطبخاكثرباركافضلاحلى" +
+	"نفسهأيامردودأنهاديناالانمعرضتعلم" +
+	"داخلممكن\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x01\x00\x01\x00\x01\x00\x02\x00\x02\x00\x02\x00\x02\x00\x04\x00\x04\x00\x04\x00\x04\x00\x00\x01\x02\x03\x04\x05\x06\a\a\x06\x05\x04\x03\x02\x01\x00" +
+	"\b\t\n\v\f\r\x0e\x0f\x0f\x0e\r\f\v\n\t\b\x10\x11\x12\x13\x14\x15\x16\x17\x17\x16\x15\x14\x13\x12\x11\x10\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f\x1f\x1e\x1d\x1c\x1b\x1a\x19\x18\xff\xff\xff\xff\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\xff\xff" +
+	"\x01\x00\x00\x00\x02\x00\x00\x00\x02\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x03\x00\x00\x00\xff\xff\x00\x01\x00\x00\x00\x01\x00\x00\xff\xff\x00\x01\x00\x00\x00\b\x00\b\x00\b\x00\b\x00\x00\x00\x01\x00\x02\x00\x03\x00\x04\x00\x05\x00\x06\x00\a" +
+	"resourcescountriesquestionsequipmentcommunityavailablehighlightD" +
+	"TD/xhtmlmarketingknowledgesomethingcontainerdirectionsubscribead" +
+	"vertisecharacter\" value=\"</select>Australia\" class=\"situationaut" +
+	"horityfollowingprimarilyoperationchallengedevelopedanonymousfunc" +
+	"tion functionscompaniesstructureagreement\" title=\"potentialeduca" +
+	"tionargumentssecondarycopyrightlanguagesexclusivecondition</form" +
+	">\r\nstatementattentionBiography} else {\nsolutionswhen the Analyti" +
+	"cstemplatesdangeroussatellitedocumentspublisherimportantprototyp" +
+	"einfluence&raquo;</effectivegenerallytransformbeautifultransport" +
+	"organizedpublishedprominentuntil thethumbnailNational .focus();o" +
+	"ver the migrationannouncedfooter\">\nexceptionless thanexpensivefo" +
+	"rmationframeworkterritoryndicationcurrentlyclassNamecriticismtra" +
+	"ditionelsewhereAlexanderappointedmaterialsbroadcastmentionedaffi" +
+	"liate</option>treatmentdifferent/default.Presidentonclick=\"biogr" +
+	"aphyotherwisepermanentFrançaisHollywoodexpansionstandards</styl" +
+	"e>\nreductionDecember preferredCambridgeopponentsBusiness confusi" +
+	"on>\n<title>presentedexplaineddoes not worldwideinterfaceposition" +
+	"snewspaper</table>\nmountainslike the essentialfinancialselection" +
+	"action=\"/abandonedEducationparseInt(stabilityunable to</title>\nr" +
+	"elationsNote thatefficientperformedtwo yearsSince thethereforewr" +
+	"apper\">alternateincreasedBattle ofperceivedtrying tonecessarypor" +
+	"trayedelectionsElizabeth</iframe>discoveryinsurances.length;lege" +
+	"ndaryGeographycandidatecorporatesometimesservices.inherited</str" +
+	"ong>CommunityreligiouslocationsCommitteebuildingsthe worldno lon" +
+	"gerbeginningreferencecannot befrequencytypicallyinto the relativ" +
+	"e;recordingpresidentinitiallytechniquethe otherit can beexistenc" +
+	"eunderlinethis timetelephoneitemscopepracticesadvantage);return " +
+	"For otherprovidingdemocracyboth the extensivesufferingsupportedc" +
+	"omputers functionpracticalsaid thatit may beEnglish</from the sc" +
+	"heduleddownloads</label>\nsuspectedmargin: 0spiritual</head>\n\nmic" +
+	"rosoftgraduallydiscussedhe becameexecutivejquery.jshouseholdconf" +
+	"irmedpurchasedliterallydestroyedup to thevariationremainingit is" +
+	" notcenturiesJapanese among thecompletedalgorithminterestsrebell" +
+	"ionundefinedencourageresizableinvolvingsensitiveuniversalprovisi" +
+	"on(althoughfeaturingconducted), which continued-header\">February" +
+	" numerous overflow:componentfragmentsexcellentcolspan=\"technical" +
+	"near the Advanced source ofexpressedHong Kong Facebookmultiple m" +
+	"echanismelevationoffensive</form>\n\tsponsoreddocument.or &quot;th" +
+	"ere arethose whomovementsprocessesdifficultsubmittedrecommendcon" +
+	"vincedpromoting\" width=\".replace(classicalcoalitionhis firstdeci" +
+	"sionsassistantindicatedevolution-wrapper\"enough toalong thedeliv" +
+	"ered-->\r\n<!--American protectedNovember </style><furnitureIntern" +
+	"et  onblur=\"suspendedrecipientbased on Moreover,abolishedcollect" +
+	"edwere madeemotionalemergencynarrativeadvocatespx;bordercommitte" +
+	"ddir=\"ltr\"employeesresearch. selectedsuccessorcustomersdisplayed" +
+	"SeptemberaddClass(Facebook suggestedand lateroperatingelaborateS" +
+	"ometimesInstitutecertainlyinstalledfollowersJerusalemthey haveco" +
+	"mputinggeneratedprovincesguaranteearbitraryrecognizewanted topx;" +
+	"width:theory ofbehaviourWhile theestimatedbegan to it becamemagn" +
+	"itudemust havemore thanDirectoryextensionsecretarynaturallyoccur" +
+	"ringvariablesgiven theplatform.</label><failed tocompoundskinds " +
+	"of societiesalongside --&gt;\n\nsouthwestthe rightradiationmay hav" +
+	"e unescape(spoken in\" href=\"/programmeonly the come fromdirector" +
+	"yburied ina similarthey were</font></Norwegianspecifiedproducing" +
+	"passenger(new DatetemporaryfictionalAfter theequationsdownload.r" +
+	"egularlydeveloperabove thelinked tophenomenaperiod oftooltip\">su" +
+	"bstanceautomaticaspect ofAmong theconnectedestimatesAir Forcesys" +
+	"tem ofobjectiveimmediatemaking itpaintingsconqueredare stillproc" +
+	"eduregrowth ofheaded byEuropean divisionsmoleculesfranchiseinten" +
+	"tionattractedchildhoodalso useddedicatedsingaporedegree offather" +
+	" ofconflicts</a></p>\ncame fromwere usednote thatreceivingExecuti" +
+	"veeven moreaccess tocommanderPoliticalmusiciansdeliciousprisoner" +
+	"sadvent ofUTF-8\" /><![CDATA[\">ContactSouthern bgcolor=\"series of" +
+	". It was in Europepermittedvalidate.appearingofficialsseriously-" +
+	"languageinitiatedextendinglong-terminflationsuch thatgetCookiema" +
+	"rked by</button>implementbut it isincreasesdown the requiringdep" +
+	"endent-->\n<!-- interviewWith the copies ofconsensuswas builtVene" +
+	"zuela(formerlythe statepersonnelstrategicfavour ofinventionWikip" +
+	"ediacontinentvirtuallywhich wasprincipleComplete identicalshow t" +
+	"hatprimitiveaway frommolecularpreciselydissolvedUnder theversion" +
+	"=\">&nbsp;</It is the This is will haveorganismssome timeFriedric" +
+	"hwas firstthe only fact thatform id=\"precedingTechnicalphysicist" +
+	"occurs innavigatorsection\">span id=\"sought tobelow thesurviving}" +
+	"</style>his deathas in thecaused bypartiallyexisting using thewa" +
+	"s givena list oflevels ofnotion ofOfficial dismissedscientistres" +
+	"emblesduplicateexplosiverecoveredall othergalleries{padding:peop" +
+	"le ofregion ofaddressesassociateimg alt=\"in modernshould bemetho" +
+	"d ofreportingtimestampneeded tothe Greatregardingseemed toviewed" +
+	" asimpact onidea thatthe Worldheight ofexpandingThese arecurrent" +
+	"\">carefullymaintainscharge ofClassicaladdressedpredictedownershi" +
+	"p<div id=\"right\">\r\nresidenceleave thecontent\">are often  })();\r\n" +
+	"probably Professor-button\" respondedsays thathad to beplaced inH" +
+	"ungarianstatus ofserves asUniversalexecutionaggregatefor whichin" +
+	"fectionagreed tohowever, popular\">placed onconstructelectoralsym" +
+	"bol ofincludingreturn toarchitectChristianprevious living ineasi" +
+	"er toprofessor\n&lt;!-- effect ofanalyticswas takenwhere thetook " +
+	"overbelief inAfrikaansas far aspreventedwork witha special<field" +
+	"setChristmasRetrieved\n\nIn the back intonortheastmagazines><stron" +
+	"g>committeegoverninggroups ofstored inestablisha generalits firs" +
+	"ttheir ownpopulatedan objectCaribbeanallow thedistrictswisconsin" +
+	"location.; width: inhabitedSocialistJanuary 1</footer>similarlyc" +
+	"hoice ofthe same specific business The first.length; desire tode" +
+	"al withsince theuserAgentconceivedindex.phpas &quot;engage inrec" +
+	"ently,few yearswere also\n<head>\n<edited byare knowncities inacce" +
+	"sskeycondemnedalso haveservices,family ofSchool ofconvertednatur" +
+	"e of languageministers</object>there is a popularsequencesadvoca" +
+	"tedThey wereany otherlocation=enter themuch morereflectedwas nam" +
+	"edoriginal a typicalwhen theyengineerscould notresidentswednesda" +
+	"ythe third productsJanuary 2what theya certainreactionsprocessor" +
+	"after histhe last contained\"></div>\n</a></td>depend onsearch\">\np" +
+	"ieces ofcompetingReferencetennesseewhich has version=</span> <</" +
+	"header>gives thehistorianvalue=\"\">padding:0view thattogether,the" +
+	" most was foundsubset ofattack onchildren,points ofpersonal posi" +
+	"tion:allegedlyClevelandwas laterand afterare givenwas stillscrol" +
+	"lingdesign ofmakes themuch lessAmericans.\n\nAfter , but theMuseum" +
+	" oflouisiana(from theminnesotaparticlesa processDominicanvolume " +
+	"ofreturningdefensive00px|righmade frommouseover\" style=\"states o" +
+	"f(which iscontinuesFranciscobuilding without awith somewho would" +
+	"a form ofa part ofbefore itknown as  Serviceslocation and oftenm" +
+	"easuringand it ispaperbackvalues of\r\n<title>= window.determineer" +
+	"&quot; played byand early</center>from thisthe threepower andof " +
+	"&quot;innerHTML<a href=\"y:inline;Church ofthe eventvery highoffi" +
+	"cial -height: content=\"/cgi-bin/to createafrikaansesperantofran\xc3" +
+	"\xa7aislatviešulietuviųČeštinačeštinaไทย日本語简体" +
+	"字繁體字한국어为什么计算机笔记本討論區服务\xe5" +
+	"\x99\xa8互联网房地产俱乐部出版社排行榜部落格进一\xe6\xad" +
+	"\xa5支付宝验证码委员会数据库消费者办公室讨论区" +
+	"深圳市播放器北京市大学生越来越管理员信息网s" +
+	"erviciosartículoargentinabarcelonacualquierpublicadoproductospo" +
+	"líticarespuestawikipediasiguientebúsquedacomunidadseguridadpri" +
+	"ncipalpreguntascontenidorespondervenezuelaproblemasdiciembrerela" +
+	"ciónnoviembresimilaresproyectosprogramasinstitutoactividadencue" +
+	"ntraeconomíaimágenescontactardescargarnecesarioatenciónteléf" +
+	"onocomisióncancionescapacidadencontraranálisisfavoritostérmin" +
+	"osprovinciaetiquetaselementosfuncionesresultadocarácterpropieda" +
+	"dprincipionecesidadmunicipalcreacióndescargaspresenciacomercial" +
+	"opinionesejercicioeditorialsalamancagonzálezdocumentopelícular" +
+	"ecientesgeneralestarragonaprácticanovedadespropuestapacientest\xc3" +
+	"\xa9cnicasobjetivoscontactosमेंलिएहैंगयास" +
+	"ाथएवंरहेकोईकुछरहाबादक\xe0" +
+	"\xa4\xb9ासभीहुएरहीमैंदिनबातdiplo" +
+	"docsसमयरूपनामपताफिरऔसततर" +
+	"हलोगहुआबारदेशहुईखेलयद\xe0" +
+	"\xa4\xbfकामवेबतीनबीचमौतसालले\xe0\xa4" +
+	"\x96जॉबमददतथानहीशहरअलगकभी" +
+	"नगरपासरातकिएउसेगयीहूँ\xe0" +
+	"\xa4\x86गेटीमखोजकारअभीगयेतुम\xe0\xa4" +
+	"\xb5ोटदेंअगरऐसेमेललगाहालऊ" +
+	"परचारऐसादेरजिसदिलबंदब\xe0" +
+	"\xa4\xa8ाहूंलाखजीतबटनमिलइसेआ\xe0\xa4" +
+	"\xa8ेनयाकुललॉगभागरेलजगहरा" +
+	"मलगेपेजहाथइसीसहीकलाठी\xe0" +
+	"\xa4\x95हाँदूरतहतसातयादआयापा\xe0\xa4" +
+	"\x95कौनशामदेखयहीरायखुदलगी" +
+	"categoriesexperience</title>\r\nCopyright javascriptconditionsever" +
+	"ything<p class=\"technologybackground<a class=\"management&copy; 2" +
+	"01javaScriptcharactersbreadcrumbthemselveshorizontalgovernmentCa" +
+	"liforniaactivitiesdiscoveredNavigationtransitionconnectionnaviga" +
+	"tionappearance</title><mcheckbox\" techniquesprotectionapparently" +
+	"as well asunt', 'UA-resolutionoperationstelevisiontranslatedWash" +
+	"ingtonnavigator. = window.impression&lt;br&gt;literaturepopulati" +
+	"onbgcolor=\"#especially content=\"productionnewsletterpropertiesde" +
+	"finitionleadershipTechnologyParliamentcomparisonul class=\".index" +
+	"Of(\"conclusiondiscussioncomponentsbiologicalRevolution_container" +
+	"understoodnoscript><permissioneach otheratmosphere onfocus=\"<for" +
+	"m id=\"processingthis.valuegenerationConferencesubsequentwell-kno" +
+	"wnvariationsreputationphenomenondisciplinelogo.png\" (document,bo" +
+	"undariesexpressionsettlementBackgroundout of theenterprise(\"http" +
+	"s:\" unescape(\"password\" democratic<a href=\"/wrapper\">\nmembership" +
+	"linguisticpx;paddingphilosophyassistanceuniversityfacilitiesreco" +
+	"gnizedpreferenceif (typeofmaintainedvocabularyhypothesis.submit(" +
+	");&amp;nbsp;annotationbehind theFoundationpublisher\"assumptionin" +
+	"troducedcorruptionscientistsexplicitlyinstead ofdimensions onCli" +
+	"ck=\"considereddepartmentoccupationsoon afterinvestmentpronounced" +
+	"identifiedexperimentManagementgeographic\" height=\"link rel=\".rep" +
+	"lace(/depressionconferencepunishmenteliminatedresistanceadaptati" +
+	"onoppositionwell knownsupplementdeterminedh1 class=\"0px;marginme" +
+	"chanicalstatisticscelebratedGovernment\n\nDuring tdevelopersartifi" +
+	"cialequivalentoriginatedCommissionattachment<span id=\"there were" +
+	"Nederlandsbeyond theregisteredjournalistfrequentlyall of thelang" +
+	"=\"en\" </style>\r\nabsolute; supportingextremely mainstream</strong" +
+	"> popularityemployment</table>\r\n colspan=\"</form>\n  conversionab" +
+	"out the </p></div>integrated\" lang=\"enPortuguesesubstituteindivi" +
+	"dualimpossiblemultimediaalmost allpx solid #apart fromsubject to" +
+	"in Englishcriticizedexcept forguidelinesoriginallyremarkablethe " +
+	"secondh2 class=\"<a title=\"(includingparametersprohibited= \"http:" +
+	"//dictionaryperceptionrevolutionfoundationpx;height:successfulsu" +
+	"pportersmillenniumhis fatherthe &quot;no-repeat;commercialindust" +
+	"rialencouragedamount of unofficialefficiencyReferencescoordinate" +
+	"disclaimerexpeditiondevelopingcalculatedsimplifiedlegitimatesubs" +
+	"tring(0\" class=\"completelyillustratefive yearsinstrumentPublishi" +
+	"ng1\" class=\"psychologyconfidencenumber of absence offocused onjo" +
+	"ined thestructurespreviously></iframe>once againbut ratherimmigr" +
+	"antsof course,a group ofLiteratureUnlike the</a>&nbsp;\nfunction " +
+	"it was theConventionautomobileProtestantaggressiveafter the Simi" +
+	"larly,\" /></div>collection\r\nfunctionvisibilitythe use ofvoluntee" +
+	"rsattractionunder the threatened*<![CDATA[importancein generalth" +
+	"e latter</form>\n</.indexOf('i = 0; i <differencedevoted totradit" +
+	"ionssearch forultimatelytournamentattributesso-called }\n</style>" +
+	"evaluationemphasizedaccessible</section>successionalong withMean" +
+	"while,industries</a><br />has becomeaspects ofTelevisionsufficie" +
+	"ntbasketballboth sidescontinuingan article<img alt=\"adventureshi" +
+	"s mothermanchesterprinciplesparticularcommentaryeffects ofdecide" +
+	"d to\"><strong>publishersJournal ofdifficultyfacilitateacceptable" +
+	"style.css\"\tfunction innovation>Copyrightsituationswould havebusi" +
+	"nessesDictionarystatementsoften usedpersistentin Januarycomprisi" +
+	"ng</title>\n\tdiplomaticcontainingperformingextensionsmay not beco" +
+	"ncept of onclick=\"It is alsofinancial making theLuxembourgadditi" +
+	"onalare calledengaged in\"script\");but it waselectroniconsubmit=\"" +
+	"\n<!-- End electricalofficiallysuggestiontop of theunlike theAust" +
+	"ralianOriginallyreferences\n</head>\r\nrecognisedinitializelimited " +
+	"toAlexandriaretirementAdventuresfour years\n\n&lt;!-- increasingde" +
+	"corationh3 class=\"origins ofobligationregulationclassified(funct" +
+	"ion(advantagesbeing the historians<base hrefrepeatedlywilling to" +
+	"comparabledesignatednominationfunctionalinside therevelationend " +
+	"of thes for the authorizedrefused totake placeautonomouscompromi" +
+	"sepolitical restauranttwo of theFebruary 2quality ofswfobject.un" +
+	"derstandnearly allwritten byinterviews\" width=\"1withdrawalfloat:" +
+	"leftis usuallycandidatesnewspapersmysteriousDepartmentbest known" +
+	"parliamentsuppressedconvenientremembereddifferent systematichas " +
+	"led topropagandacontrolledinfluencesceremonialproclaimedProtecti" +
+	"onli class=\"Scientificclass=\"no-trademarksmore than widespreadLi" +
+	"berationtook placeday of theas long asimprisonedAdditional\n<head" +
+	">\n<mLaboratoryNovember 2exceptionsIndustrialvariety offloat: lef" +
+	"During theassessmenthave been deals withStatisticsoccurrence/ul>" +
+	"</div>clearfix\">the publicmany yearswhich wereover time,synonymo" +
+	"uscontent\">\npresumablyhis familyuserAgent.unexpectedincluding ch" +
+	"allengeda minorityundefined\"belongs totaken fromin Octoberpositi" +
+	"on: said to bereligious Federation rowspan=\"only a fewmeant that" +
+	"led to the-->\r\n<div <fieldset>Archbishop class=\"nobeing usedappr" +
+	"oachesprivilegesnoscript>\nresults inmay be theEaster eggmechanis" +
+	"msreasonablePopulationCollectionselected\">noscript>\r/index.phpar" +
+	"rival of-jssdk'));managed toincompletecasualtiescompletionChrist" +
+	"iansSeptember arithmeticproceduresmight haveProductionit appears" +
+	"Philosophyfriendshipleading togiving thetoward theguaranteeddocu" +
+	"mentedcolor:#000video gamecommissionreflectingchange theassociat" +
+	"edsans-serifonkeypress; padding:He was theunderlyingtypically , " +
+	"and the srcElementsuccessivesince the should be networkingaccoun" +
+	"tinguse of thelower thanshows that</span>\n\t\tcomplaintscontinuous" +
+	"quantitiesastronomerhe did notdue to itsapplied toan averageeffo" +
+	"rts tothe futureattempt toTherefore,capabilityRepublicanwas form" +
+	"edElectronickilometerschallengespublishingthe formerindigenousdi" +
+	"rectionssubsidiaryconspiracydetails ofand in theaffordablesubsta" +
+	"ncesreason forconventionitemtype=\"absolutelysupposedlyremained a" +
+	"attractivetravellingseparatelyfocuses onelementaryapplicablefoun" +
+	"d thatstylesheetmanuscriptstands for no-repeat(sometimesCommerci" +
+	"alin Americaundertakenquarter ofan examplepersonallyindex.php?</" +
+	"button>\npercentagebest-knowncreating a\" dir=\"ltrLieutenant\n<div " +
+	"id=\"they wouldability ofmade up ofnoted thatclear thatargue that" +
+	"to anotherchildren'spurpose offormulatedbased uponthe regionsubj" +
+	"ect ofpassengerspossession.\n\nIn the Before theafterwardscurrentl" +
+	"y across thescientificcommunity.capitalismin Germanyright-wingth" +
+	"e systemSociety ofpoliticiandirection:went on toremoval of New Y" +
+	"ork apartmentsindicationduring theunless thehistoricalhad been a" +
+	"definitiveingredientattendanceCenter forprominencereadyStatestra" +
+	"tegiesbut in theas part ofconstituteclaim thatlaboratorycompatib" +
+	"lefailure of, such as began withusing the to providefeature offr" +
+	"om which/\" class=\"geologicalseveral ofdeliberateimportant holds " +
+	"thating&quot; valign=topthe Germanoutside ofnegotiatedhis career" +
+	"separationid=\"searchwas calledthe fourthrecreationother thanprev" +
+	"entionwhile the education,connectingaccuratelywere builtwas kill" +
+	"edagreementsmuch more Due to thewidth: 100some otherKingdom ofth" +
+	"e entirefamous forto connectobjectivesthe Frenchpeople andfeatur" +
+	"ed\">is said tostructuralreferendummost oftena separate->\n<div id" +
+	" Official worldwide.aria-labelthe planetand it wasd\" value=\"look" +
+	"ing atbeneficialare in themonitoringreportedlythe modernworking " +
+	"onallowed towhere the innovative</a></div>soundtracksearchFormte" +
+	"nd to beinput id=\"opening ofrestrictedadopted byaddressingtheolo" +
+	"gianmethods ofvariant ofChristian very largeautomotiveby far the" +
+	"range frompursuit offollow thebrought toin Englandagree thataccu" +
+	"sed ofcomes frompreventingdiv style=his or hertremendousfreedom " +
+	"ofconcerning0 1em 1em;Basketball/style.cssan earliereven after/\"" +
+	" title=\".com/indextaking thepittsburghcontent\">\r<script>(fturned" +
+	" outhaving the</span>\r\n occasionalbecause itstarted tophysically" +
+	"></div>\n  created byCurrently, bgcolor=\"tabindex=\"disastrousAnal" +
+	"ytics also has a><div id=\"</style>\n<called forsinger and.src = \"" +
+	"//violationsthis pointconstantlyis locatedrecordingsd from thene" +
+	"derlandsportuguêsעבריתفارسیdesarrollocomentarioeducac" +
+	"iónseptiembreregistradodirecciónubicaciónpublicidadrespuestas" +
+	"resultadosimportantereservadosartículosdiferentessiguientesrep\xc3" +
+	"\xbablicasituaciónministerioprivacidaddirectorioformaciónpoblaci\xc3" +
+	"\xb3npresidentecontenidosaccesoriostechnoratipersonalescategoríaes" +
+	"pecialesdisponibleactualidadreferenciavalladolidbibliotecarelaci" +
+	"onescalendariopolíticasanterioresdocumentosnaturalezamateriales" +
+	"diferenciaeconómicatransporterodríguezparticiparencuentrandisc" +
+	"usiónestructurafundaciónfrecuentespermanentetotalmenteможн" +
+	"обудетможетвремятакжечтобыболеео" +
+	"ченьэтогокогдапослевсегосайтечер" +
+	"езмогутсайтажизнимеждубудутПоиск" +
+	"здесьвидеосвязинужносвоейлюдейпо" +
+	"рномногодетейсвоихправатакоймест" +
+	"оимеетжизньоднойлучшепередчастич" +
+	"астьработновыхправособойпотоммен" +
+	"еечисленовыеуслугоколоназадтакое" +
+	"тогдапочтиПослетакиеновыйстоитта" +
+	"кихсразуСанктфорумКогдакнигислов" +
+	"анашейнайтисвоимсвязьлюбойчастос" +
+	"редиКромеФорумрынкесталипоисктыс" +
+	"ячмесяццентртрудасамыхрынкаНовый" +
+	"часовместафильммартастранместете" +
+	"кстнашихминутимениимеютномергоро" +
+	"дсамомэтомуконцесвоемкакойАрхивم" +
+	"نتدىإرسالرسالةالعامكتبهابرامجالي" +
+	"ومالصورجديدةالعضوإضافةالقسمالعاب" +
+	"تحميلملفاتملتقىتعديلالشعرأخبارتط" +
+	"ويرعليكمإرفاقطلباتاللغةترتيبالنا" +
+	"سالشيخمنتديالعربالقصصافلامعليهات" +
+	"حديثاللهمالعملمكتبةيمكنكالطفلفيد" +
+	"يوإدارةتاريخالصحةتسجيلالوقتعندما" +
+	"مدينةتصميمأرشيفالذينعربيةبوابةأل" +
+	"عابالسفرمشاكلتعالىالأولالسنةجامع" +
+	"ةالصحفالدينكلماتالخاصالملفأعضاءك" +
+	"تابةالخيررسائلالقلبالأدبمقاطعمرا" +
+	"سلمنطقةالكتبالرجلاشتركالقدميعطيك" +
+	"sByTagName(.jpg\" alt=\"1px solid #.gif\" alt=\"transparentinformati" +
+	"onapplication\" onclick=\"establishedadvertising.png\" alt=\"environ" +
+	"mentperformanceappropriate&amp;mdash;immediately</strong></rathe" +
+	"r thantemperaturedevelopmentcompetitionplaceholdervisibility:cop" +
+	"yright\">0\" height=\"even thoughreplacementdestinationCorporation<" +
+	"ul class=\"AssociationindividualsperspectivesetTimeout(url(http:/" +
+	"/mathematicsmargin-top:eventually description) no-repeatcollecti" +
+	"ons.JPG|thumb|participate/head><bodyfloat:left;<li class=\"hundre" +
+	"ds of\n\nHowever, compositionclear:both;cooperationwithin the labe" +
+	"l for=\"border-top:New Zealandrecommendedphotographyinteresting&l" +
+	"t;sup&gt;controversyNetherlandsalternativemaxlength=\"switzerland" +
+	"Developmentessentially\n\nAlthough </textarea>thunderbirdrepresent" +
+	"ed&amp;ndash;speculationcommunitieslegislationelectronics\n\t<div " +
+	"id=\"illustratedengineeringterritoriesauthoritiesdistributed6\" he" +
+	"ight=\"sans-serif;capable of disappearedinteractivelooking forit " +
+	"would beAfghanistanwas createdMath.floor(surroundingcan also beo" +
+	"bservationmaintenanceencountered<h2 class=\"more recentit has bee" +
+	"ninvasion of).getTime()fundamentalDespite the\"><div id=\"inspirat" +
+	"ionexaminationpreparationexplanation<input id=\"</a></span>versio" +
+	"ns ofinstrumentsbefore the  = 'http://Descriptionrelatively .sub" +
+	"string(each of theexperimentsinfluentialintegrationmany peopledu" +
+	"e to the combinationdo not haveMiddle East<noscript><copyright\" " +
+	"perhaps theinstitutionin Decemberarrangementmost famouspersonali" +
+	"tycreation oflimitationsexclusivelysovereignty-content\">\n<td cla" +
+	"ss=\"undergroundparallel todoctrine ofoccupied byterminologyRenai" +
+	"ssancea number ofsupport forexplorationrecognitionpredecessor<im" +
+	"g src=\"/<h1 class=\"publicationmay also bespecialized</fieldset>p" +
+	"rogressivemillions ofstates thatenforcementaround the one anothe" +
+	"r.parentNodeagricultureAlternativeresearcherstowards theMost of " +
+	"themany other (especially<td width=\";width:100%independent<h3 cl" +
+	"ass=\" onchange=\").addClass(interactionOne of the daughter ofacce" +
+	"ssoriesbranches of\r\n<div id=\"the largestdeclarationregulationsIn" +
+	"formationtranslationdocumentaryin order to\">\n<head>\n<\" height=\"1" +
+	"across the orientation);</script>implementedcan be seenthere was" +
+	" ademonstratecontainer\">connectionsthe Britishwas written!import" +
+	"ant;px; margin-followed byability to complicatedduring the immig" +
+	"rationalso called<h4 class=\"distinctionreplaced bygovernmentsloc" +
+	"ation ofin Novemberwhether the</p>\n</div>acquisitioncalled the p" +
+	"ersecutiondesignation{font-size:appeared ininvestigateexperience" +
+	"dmost likelywidely useddiscussionspresence of (document.extensiv" +
+	"elyIt has beenit does notcontrary toinhabitantsimprovementschola" +
+	"rshipconsumptioninstructionfor exampleone or morepx; paddingthe " +
+	"currenta series ofare usuallyrole in thepreviously derivativesev" +
+	"idence ofexperiencescolorschemestated thatcertificate</a></div>\n" +
+	" selected=\"high schoolresponse tocomfortableadoption ofthree yea" +
+	"rsthe countryin Februaryso that thepeople who provided by<param " +
+	"nameaffected byin terms ofappointmentISO-8859-1\"was born inhisto" +
+	"rical regarded asmeasurementis based on and other : function(sig" +
+	"nificantcelebrationtransmitted/js/jquery.is known astheoretical " +
+	"tabindex=\"it could be<noscript>\nhaving been\r\n<head>\r\n< &quot;The" +
+	" compilationhe had beenproduced byphilosopherconstructedintended" +
+	" toamong othercompared toto say thatEngineeringa differentreferr" +
+	"ed todifferencesbelief thatphotographsidentifyingHistory of Repu" +
+	"blic ofnecessarilyprobabilitytechnicallyleaving thespectacularfr" +
+	"action ofelectricityhead of therestaurantspartnershipemphasis on" +
+	"most recentshare with saying thatfilled withdesigned toit is oft" +
+	"en\"></iframe>as follows:merged withthrough thecommercial pointed" +
+	" outopportunityview of therequirementdivision ofprogramminghe re" +
+	"ceivedsetInterval\"></span></in New Yorkadditional compression\n\n<" +
+	"div id=\"incorporate;</script><attachEventbecame the \" target=\"_c" +
+	"arried outSome of thescience andthe time ofContainer\">maintainin" +
+	"gChristopherMuch of thewritings of\" height=\"2size of theversion " +
+	"of mixture of between theExamples ofeducationalcompetitive onsub" +
+	"mit=\"director ofdistinctive/DTD XHTML relating totendency toprov" +
+	"ince ofwhich woulddespite thescientific legislature.innerHTML al" +
+	"legationsAgriculturewas used inapproach tointelligentyears later" +
+	",sans-serifdeterminingPerformanceappearances, which is foundatio" +
+	"nsabbreviatedhigher thans from the individual composed ofsuppose" +
+	"d toclaims thatattributionfont-size:1elements ofHistorical his b" +
+	"rotherat the timeanniversarygoverned byrelated to ultimately inn" +
+	"ovationsit is stillcan only bedefinitionstoGMTStringA number ofi" +
+	"mg class=\"Eventually,was changedoccurred inneighboringdistinguis" +
+	"hwhen he wasintroducingterrestrialMany of theargues thatan Ameri" +
+	"canconquest ofwidespread were killedscreen and In order toexpect" +
+	"ed todescendantsare locatedlegislativegenerations backgroundmost" +
+	" peopleyears afterthere is nothe highestfrequently they do notar" +
+	"gued thatshowed thatpredominanttheologicalby the timeconsidering" +
+	"short-lived</span></a>can be usedvery littleone of the had alrea" +
+	"dyinterpretedcommunicatefeatures ofgovernment,</noscript>entered" +
+	" the\" height=\"3Independentpopulationslarge-scale. Although used " +
+	"in thedestructionpossibilitystarting intwo or moreexpressionssub" +
+	"ordinatelarger thanhistory and</option>\r\nContinentaleliminatingw" +
+	"ill not bepractice ofin front ofsite of theensure thatto create " +
+	"amississippipotentiallyoutstandingbetter thanwhat is nowsituated" +
+	" inmeta name=\"TraditionalsuggestionsTranslationthe form ofatmosp" +
+	"hericideologicalenterprisescalculatingeast of theremnants ofplug" +
+	"inspage/index.php?remained intransformedHe was alsowas alreadyst" +
+	"atisticalin favor ofMinistry ofmovement offormulationis required" +
+	"<link rel=\"This is the <a href=\"/popularizedinvolved inare used " +
+	"toand severalmade by theseems to belikely thatPalestiniannamed a" +
+	"fterit had beenmost commonto refer tobut this isconsecutivetempo" +
+	"rarilyIn general,conventionstakes placesubdivisionterritorialope" +
+	"rationalpermanentlywas largelyoutbreak ofin the pastfollowing a " +
+	"xmlns:og=\"><a class=\"class=\"textConversion may be usedmanufactur" +
+	"eafter beingclearfix\">\nquestion ofwas electedto become abecause " +
+	"of some peopleinspired bysuccessful a time whenmore commonamongs" +
+	"t thean officialwidth:100%;technology,was adoptedto keep thesett" +
+	"lementslive birthsindex.html\"Connecticutassigned to&amp;times;ac" +
+	"count foralign=rightthe companyalways beenreturned toinvolvement" +
+	"Because thethis period\" name=\"q\" confined toa result ofvalue=\"\" " +
+	"/>is actuallyEnvironment\r\n</head>\r\nConversely,>\n<div id=\"0\" widt" +
+	"h=\"1is probablyhave becomecontrollingthe problemcitizens ofpolit" +
+	"iciansreached theas early as:none; over<table cellvalidity ofdir" +
+	"ectly toonmousedownwhere it iswhen it wasmembers of relation toa" +
+	"ccommodatealong with In the latethe Englishdelicious\">this is no" +
+	"tthe presentif they areand finallya matter of\r\n\t</div>\r\n\r\n</scri" +
+	"pt>faster thanmajority ofafter whichcomparativeto maintainimprov" +
+	"e theawarded theer\" class=\"frameborderrestorationin the sameanal" +
+	"ysis oftheir firstDuring the continentalsequence offunction(){fo" +
+	"nt-size: work on the</script>\n<begins withjavascript:constituent" +
+	"was foundedequilibriumassume thatis given byneeds to becoordinat" +
+	"esthe variousare part ofonly in thesections ofis a commontheorie" +
+	"s ofdiscoveriesassociationedge of thestrength ofposition inprese" +
+	"nt-dayuniversallyto form thebut insteadcorporationattached tois " +
+	"commonlyreasons for &quot;the can be madewas able towhich meansb" +
+	"ut did notonMouseOveras possibleoperated bycoming fromthe primar" +
+	"yaddition offor severaltransferreda period ofare able tohowever," +
+	" itshould havemuch larger\n\t</script>adopted theproperty ofdirect" +
+	"ed byeffectivelywas broughtchildren ofProgramminglonger thanmanu" +
+	"scriptswar againstby means ofand most ofsimilar to proprietaryor" +
+	"iginatingprestigiousgrammaticalexperience.to make theIt was also" +
+	"is found incompetitorsin the U.S.replace thebrought thecalculati" +
+	"onfall of thethe generalpracticallyin honor ofreleased inresiden" +
+	"tialand some ofking of thereaction to1st Earl ofculture andprinc" +
+	"ipally</title>\n  they can beback to thesome of hisexposure toare" +
+	" similarform of theaddFavoritecitizenshippart in thepeople withi" +
+	"n practiceto continue&amp;minus;approved by the first allowed th" +
+	"eand for thefunctioningplaying thesolution toheight=\"0\" in his b" +
+	"ookmore than afollows thecreated thepresence in&nbsp;</td>nation" +
+	"alistthe idea ofa characterwere forced class=\"btndays of thefeat" +
+	"ured inshowing theinterest inin place ofturn of thethe head ofLo" +
+	"rd of thepoliticallyhas its ownEducationalapproval ofsome of the" +
+	"each other,behavior ofand becauseand anotherappeared onrecorded " +
+	"inblack&quot;may includethe world'scan lead torefers to aborder=" +
+	"\"0\" government winning theresulted in while the Washington,the s" +
+	"ubjectcity in the></div>\r\n\t\treflect theto completebecame morerad" +
+	"ioactiverejected bywithout anyhis father,which couldcopy of thet" +
+	"o indicatea politicalaccounts ofconstitutesworked wither</a></li" +
+	">of his lifeaccompaniedclientWidthprevent theLegislativedifferen" +
+	"tlytogether inhas severalfor anothertext of thefounded thee with" +
+	" the is used forchanged theusually theplace wherewhereas the> <a" +
+	" href=\"\"><a href=\"themselves,although hethat can betraditionalro" +
+	"le of theas a resultremoveChilddesigned bywest of theSome people" +
+	"production,side of thenewslettersused by thedown to theaccepted " +
+	"bylive in theattempts tooutside thefrequenciesHowever, inprogram" +
+	"mersat least inapproximatealthough itwas part ofand variousGover" +
+	"nor ofthe articleturned into><a href=\"/the economyis the mostmos" +
+	"t widelywould laterand perhapsrise to theoccurs whenunder whichc" +
+	"onditions.the westerntheory thatis producedthe city ofin which h" +
+	"eseen in thethe centralbuilding ofmany of hisarea of theis the o" +
+	"nlymost of themany of thethe WesternThere is noextended toStatis" +
+	"ticalcolspan=2 |short storypossible totopologicalcritical ofrepo" +
+	"rted toa Christiandecision tois equal toproblems ofThis can beme" +
+	"rchandisefor most ofno evidenceeditions ofelements in&quot;. The" +
+	"com/images/which makesthe processremains theliterature,is a memb" +
+	"erthe popularthe ancientproblems intime of thedefeated bybody of" +
+	" thea few yearsmuch of thethe work ofCalifornia,served as agover" +
+	"nment.concepts ofmovement in\t\t<div id=\"it\" value=\"language ofas " +
+	"they areproduced inis that theexplain thediv></div>\nHowever thel" +
+	"ead to the\t<a href=\"/was grantedpeople havecontinuallywas seen a" +
+	"sand relatedthe role ofproposed byof the besteach other.Constant" +
+	"inepeople fromdialects ofto revisionwas renameda source ofthe in" +
+	"itiallaunched inprovide theto the westwhere thereand similarbetw" +
+	"een twois also theEnglish andconditions,that it wasentitled toth" +
+	"emselves.quantity ofransparencythe same asto join thecountry and" +
+	"this is theThis led toa statementcontrast tolastIndexOfthrough h" +
+	"isis designedthe term isis providedprotect theng</a></li>The cur" +
+	"rentthe site ofsubstantialexperience,in the Westthey shouldslove" +
+	"nčinacomentariosuniversidadcondicionesactividadesexperienciatec" +
+	"nologíaproducciónpuntuaciónaplicacióncontraseñacategoríasr" +
+	"egistrarseprofesionaltratamientoregístratesecretaríaprincipale" +
+	"sprotecciónimportantesimportanciaposibilidadinteresantecrecimie" +
+	"ntonecesidadessuscribirseasociacióndisponiblesevaluaciónestudi" +
+	"antesresponsableresoluciónguadalajararegistradosoportunidadcome" +
+	"rcialesfotografíaautoridadesingenieríatelevisióncompetenciaop" +
+	"eracionesestablecidosimplementeactualmentenavegaciónconformidad" +
+	"line-height:font-family:\" : \"http://applicationslink\" href=\"spec" +
+	"ifically//<![CDATA[\nOrganizationdistribution0px; height:relation" +
+	"shipdevice-width<div class=\"<label for=\"registration</noscript>\n" +
+	"/index.html\"window.open( !important;application/independence//ww" +
+	"w.googleorganizationautocompleterequirementsconservative<form na" +
+	"me=\"intellectualmargin-left:18th centuryan importantinstitutions" +
+	"abbreviation<img class=\"organisationcivilization19th centuryarch" +
+	"itectureincorporated20th century-container\">most notably/></a></" +
+	"div>notification'undefined')Furthermore,believe thatinnerHTML = " +
+	"prior to thedramaticallyreferring tonegotiationsheadquartersSout" +
+	"h AfricaunsuccessfulPennsylvaniaAs a result,<html lang=\"&lt;/sup" +
+	"&gt;dealing withphiladelphiahistorically);</script>\npadding-top:" +
+	"experimentalgetAttributeinstructionstechnologiespart of the =fun" +
+	"ction(){subscriptionl.dtd\">\r\n<htgeographicalConstitution', funct" +
+	"ion(supported byagriculturalconstructionpublicationsfont-size: 1" +
+	"a variety of<div style=\"Encyclopediaiframe src=\"demonstratedacco" +
+	"mplisheduniversitiesDemographics);</script><dedicated toknowledg" +
+	"e ofsatisfactionparticularly</div></div>English (US)appendChild(" +
+	"transmissions. However, intelligence\" tabindex=\"float:right;Comm" +
+	"onwealthranging fromin which theat least onereproductionencyclop" +
+	"edia;font-size:1jurisdictionat that time\"><a class=\"In addition," +
+	"description+conversationcontact withis generallyr\" content=\"repr" +
+	"esenting&lt;math&gt;presentationoccasionally<img width=\"navigati" +
+	"on\">compensationchampionshipmedia=\"all\" violation ofreference to" +
+	"return true;Strict//EN\" transactionsinterventionverificationInfo" +
+	"rmation difficultiesChampionshipcapabilities<![endif]-->}\n</scri" +
+	"pt>\nChristianityfor example,Professionalrestrictionssuggest that" +
+	"was released(such as theremoveClass(unemploymentthe Americanstru" +
+	"cture of/index.html published inspan class=\"\"><a href=\"/introduc" +
+	"tionbelonging toclaimed thatconsequences<meta name=\"Guide to the" +
+	"overwhelmingagainst the concentrated,\n.nontouch observations</a>" +
+	"\n</div>\nf (document.border: 1px {font-size:1treatment of0\" heigh" +
+	"t=\"1modificationIndependencedivided intogreater thanachievements" +
+	"establishingJavaScript\" neverthelesssignificanceBroadcasting>&nb" +
+	"sp;</td>container\">\nsuch as the influence ofa particularsrc='htt" +
+	"p://navigation\" half of the substantial &nbsp;</div>advantage of" +
+	"discovery offundamental metropolitanthe opposite\" xml:lang=\"deli" +
+	"beratelyalign=centerevolution ofpreservationimprovementsbeginnin" +
+	"g inJesus ChristPublicationsdisagreementtext-align:r, function()" +
+	"similaritiesbody></html>is currentlyalphabeticalis sometimestype" +
+	"=\"image/many of the flow:hidden;available indescribe theexistenc" +
+	"e ofall over thethe Internet\t<ul class=\"installationneighborhood" +
+	"armed forcesreducing thecontinues toNonetheless,temperatures\n\t\t<" +
+	"a href=\"close to theexamples of is about the(see below).\" id=\"se" +
+	"archprofessionalis availablethe official\t\t</script>\n\n\t\t<div id=\"" +
+	"accelerationthrough the Hall of Famedescriptionstranslationsinte" +
+	"rference type='text/recent yearsin the worldvery popular{backgro" +
+	"und:traditional some of the connected toexploitationemergence of" +
+	"constitutionA History ofsignificant manufacturedexpectations><no" +
+	"script><can be foundbecause the has not beenneighbouringwithout " +
+	"the added to the\t<li class=\"instrumentalSoviet Unionacknowledged" +
+	"which can bename for theattention toattempts to developmentsIn f" +
+	"act, the<li class=\"aimplicationssuitable formuch of the coloniza" +
+	"tionpresidentialcancelBubble Informationmost of the is described" +
+	"rest of the more or lessin SeptemberIntelligencesrc=\"http://px; " +
+	"height: available tomanufacturerhuman rightslink href=\"/availabi" +
+	"lityproportionaloutside the astronomicalhuman beingsname of the " +
+	"are found inare based onsmaller thana person whoexpansion ofargu" +
+	"ing thatnow known asIn the earlyintermediatederived fromScandina" +
+	"vian</a></div>\r\nconsider thean estimatedthe National<div id=\"pag" +
+	"resulting incommissionedanalogous toare required/ul>\n</div>\nwas " +
+	"based onand became a&nbsp;&nbsp;t\" value=\"\" was capturedno more " +
+	"thanrespectivelycontinue to >\r\n<head>\r\n<were createdmore general" +
+	"information used for theindependent the Imperialcomponent ofto t" +
+	"he northinclude the Constructionside of the would not befor inst" +
+	"anceinvention ofmore complexcollectivelybackground: text-align: " +
+	"its originalinto accountthis processan extensivehowever, thethey" +
+	" are notrejected thecriticism ofduring whichprobably thethis art" +
+	"icle(function(){It should bean agreementaccidentallydiffers from" +
+	"Architecturebetter knownarrangementsinfluence onattended theiden" +
+	"tical tosouth of thepass throughxml\" title=\"weight:bold;creating" +
+	" thedisplay:nonereplaced the<img src=\"/ihttps://www.World War II" +
+	"testimonialsfound in therequired to and that thebetween the was " +
+	"designedconsists of considerablypublished bythe languageConserva" +
+	"tionconsisted ofrefer to theback to the css\" media=\"People from " +
+	"available onproved to besuggestions\"was known asvarieties oflike" +
+	"ly to becomprised ofsupport the hands of thecoupled withconnect " +
+	"and border:none;performancesbefore beinglater becamecalculations" +
+	"often calledresidents ofmeaning that><li class=\"evidence forexpl" +
+	"anationsenvironments\"></a></div>which allowsIntroductiondevelope" +
+	"d bya wide rangeon behalf ofvalign=\"top\"principle ofat the time," +
+	"</noscript>\rsaid to havein the firstwhile othershypotheticalphil" +
+	"osopherspower of thecontained inperformed byinability towere wri" +
+	"ttenspan style=\"input name=\"the questionintended forrejection of" +
+	"implies thatinvented thethe standardwas probablylink betweenprof" +
+	"essor ofinteractionschanging theIndian Ocean class=\"lastworking " +
+	"with'http://www.years beforeThis was therecreationalentering the" +
+	"measurementsan extremelyvalue of thestart of the\n</script>\n\nan e" +
+	"ffort toincrease theto the southspacing=\"0\">sufficientlythe Euro" +
+	"peanconverted toclearTimeoutdid not haveconsequentlyfor the next" +
+	"extension ofeconomic andalthough theare producedand with theinsu" +
+	"fficientgiven by thestating thatexpenditures</span></a>\nthought " +
+	"thaton the basiscellpadding=image of thereturning toinformation," +
+	"separated byassassinateds\" content=\"authority ofnorthwestern</di" +
+	"v>\n<div \"></div>\r\n  consultationcommunity ofthe nationalit shoul" +
+	"d beparticipants align=\"leftthe greatestselection ofsupernatural" +
+	"dependent onis mentionedallowing thewas inventedaccompanyinghis " +
+	"personalavailable atstudy of theon the otherexecution ofHuman Ri" +
+	"ghtsterms of theassociationsresearch andsucceeded bydefeated the" +
+	"and from thebut they arecommander ofstate of theyears of agethe " +
+	"study of<ul class=\"splace in thewhere he was<li class=\"fthere ar" +
+	"e nowhich becamehe publishedexpressed into which thecommissioner" +
+	"font-weight:territory ofextensions\">Roman Empireequal to theIn c" +
+	"ontrast,however, andis typicallyand his wife(also called><ul cla" +
+	"ss=\"effectively evolved intoseem to havewhich is thethere was no" +
+	"an excellentall of thesedescribed byIn practice,broadcastingchar" +
+	"ged withreflected insubjected tomilitary andto the pointeconomic" +
+	"allysetTargetingare actuallyvictory over();</script>continuously" +
+	"required forevolutionaryan effectivenorth of the, which was fron" +
+	"t of theor otherwisesome form ofhad not beengenerated byinformat" +
+	"ion.permitted toincludes thedevelopment,entered intothe previous" +
+	"consistentlyare known asthe field ofthis type ofgiven to thethe " +
+	"title ofcontains theinstances ofin the northdue to theirare desi" +
+	"gnedcorporationswas that theone of thesemore popularsucceeded in" +
+	"support fromin differentdominated bydesigned forownership ofand " +
+	"possiblystandardizedresponseTextwas intendedreceived theassumed " +
+	"thatareas of theprimarily inthe basis ofin the senseaccounts for" +
+	"destroyed byat least twowas declaredcould not beSecretary ofappe" +
+	"ar to bemargin-top:1/^\\s+|\\s+$/ge){throw e};the start oftwo sepa" +
+	"ratelanguage andwho had beenoperation ofdeath of thereal numbers" +
+	"\t<link rel=\"provided thethe story ofcompetitionsenglish (UK)engl" +
+	"ish (US)МонголСрпскисрпскисрпскоلعرب" +
+	"ية正體中文简体中文繁体中文有限公司人民政府" +
+	"阿里巴巴社会主义操作系统政策法规informaciónherr" +
+	"amientaselectrónicodescripciónclasificadosconocimientopublicac" +
+	"iónrelacionadasinformáticarelacionadosdepartamentotrabajadores" +
+	"directamenteayuntamientomercadoLibrecontáctenoshabitacionescump" +
+	"limientorestaurantesdisposiciónconsecuenciaelectrónicaaplicaci" +
+	"onesdesconectadoinstalaciónrealizaciónutilizaciónenciclopedia" +
+	"enfermedadesinstrumentosexperienciasinstituciónparticularessubc" +
+	"ategoriaтолькоРоссииработыбольшепрос" +
+	"томожетедругихслучаесейчасвсегда" +
+	"РоссияМоскведругиегородавопросда" +
+	"нныхдолжныименноМосквырублейМоск" +
+	"вастраныничегоработедолженуслуги" +
+	"теперьОднакопотомуработуапреляво" +
+	"общеодногосвоегостатьидругойфору" +
+	"мехорошопротивссылкакаждыйвласти" +
+	"группывместеработасказалпервыйде" +
+	"латьденьгипериодбизнесосновемоме" +
+	"нткупитьдолжнарамкахначалоРабота" +
+	"Толькосовсемвторойначаласписоксл" +
+	"ужбысистемпечатиновогопомощисайт" +
+	"овпочемупомощьдолжноссылкибыстро" +
+	"данныемногиепроектСейчасмоделита" +
+	"когоонлайнгородеверсиястранефиль" +
+	"мыуровняразныхискатьнеделюянваря" +
+	"меньшемногихданнойзначитнельзяфо" +
+	"румаТеперьмесяцазащитыЛучшиеनह\xe0\xa5" +
+	"\x80ंकरनेअपनेकियाकरेंअन्य" +
+	"क्यागाइडबारेकिसीदियाप\xe0" +
+	"\xa4\xb9लेसिंहभारतअपनीवालेसे\xe0\xa4" +
+	"\xb5ाकरतेमेरेहोनेसकतेबहुत" +
+	"साइटहोगाजानेमिनटकरताक\xe0" +
+	"\xa4\xb0नाउनकेयहाँसबसेभाषाआप\xe0\xa4" +
+	"\x95ेलियेशुरूइसकेघंटेमेरी" +
+	"सकतामेरालेकरअधिकअपनास\xe0" +
+	"\xa4\xaeाजमुझेकारणहोताकड़ीयह\xe0\xa4" +
+	"\xbeंहोटलशब्दलियाजीवनजाता" +
+	"कैसेआपकावालीदेनेपूरीप\xe0" +
+	"\xa4\xbeनीउसकेहोगीबैठकआपकीवर\xe0\xa5" +
+	"\x8dषगांवआपकोजिलाजानासहमत" +
+	"हमेंउनकीयाहूदर्जसूचीप\xe0" +
+	"\xa4\xb8ंदसवालहोनाहोतीजैसेवा\xe0\xa4" +
+	"\xaaसजनतानेताजारीघायलजिले" +
+	"नीचेजांचपत्रगूगलजातेब\xe0" +
+	"\xa4\xbeहरआपनेवाहनइसकासुबहरह\xe0\xa4" +
+	"\xa8ेइससेसहितबड़ेघटनातलाश" +
+	"पांचश्रीबड़ीहोतेसाईटश\xe0" +
+	"\xa4\xbeयदसकतीजातीवालाहजारपट\xe0\xa4" +
+	"\xa8ारखनेसड़कमिलाउसकीकेवल" +
+	"लगताखानाअर्थजहांदेखाप\xe0" +
+	"\xa4\xb9लीनियमबिनाबैंककहींकह\xe0\xa4" +
+	"\xa8ादेताहमलेकाफीजबकितुरत" +
+	"मांगवहींरोज़मिलीआरोपस\xe0" +
+	"\xa5\x87नायादवलेनेखाताकरीबउन\xe0\xa4" +
+	"\x95ाजवाबपूराबड़ासौदाशेयर" +
+	"कियेकहांअकसरबनाएवहांस\xe0" +
+	"\xa5\x8dथलमिलेलेखकविषयक्रंसम\xe0\xa5" +
+	"\x82हथानाتستطيعمشاركةبواسطةالصفحة" +
+	"مواضيعالخاصةالمزيدالعامةالكاتبال" +
+	"ردودبرنامجالدولةالعالمالموقعالعر" +
+	"بيالسريعالجوالالذهابالحياةالحقوق" +
+	"الكريمالعراقمحفوظةالثانيمشاهدةال" +
+	"مرأةالقرآنالشبابالحوارالجديدالأس" +
+	"رةالعلوممجموعةالرحمنالنقاطفلسطين" +
+	"الكويتالدنيابركاتهالرياضتحياتيبت" +
+	"وقيتالأولىالبريدالكلامالرابطالشخ" +
+	"صيسياراتالثالثالصلاةالحديثالزوار" +
+	"الخليجالجميعالعامهالجمالالساعةمش" +
+	"اهدهالرئيسالدخولالفنيةالكتابالدو" +
+	"ريالدروساستغرقتصاميمالبناتالعظيم" +
+	"entertainmentunderstanding = function().jpg\" width=\"configuratio" +
+	"n.png\" width=\"<body class=\"Math.random()contemporary United Stat" +
+	"escircumstances.appendChild(organizations<span class=\"\"><img src" +
+	"=\"/distinguishedthousands of communicationclear\"></div>investiga" +
+	"tionfavicon.ico\" margin-right:based on the Massachusettstable bo" +
+	"rder=internationalalso known aspronunciationbackground:#fpadding" +
+	"-left:For example, miscellaneous&lt;/math&gt;psychologicalin par" +
+	"ticularearch\" type=\"form method=\"as opposed toSupreme Courtoccas" +
+	"ionally Additionally,North Americapx;backgroundopportunitiesEnte" +
+	"rtainment.toLowerCase(manufacturingprofessional combined withFor" +
+	" instance,consisting of\" maxlength=\"return false;consciousnessMe" +
+	"diterraneanextraordinaryassassinationsubsequently button type=\"t" +
+	"he number ofthe original comprehensiverefers to the</ul>\n</div>\n" +
+	"philosophicallocation.hrefwas publishedSan Francisco(function(){" +
+	"\n<div id=\"mainsophisticatedmathematical /head>\r\n<bodysuggests th" +
+	"atdocumentationconcentrationrelationshipsmay have been(for examp" +
+	"le,This article in some casesparts of the definition ofGreat Bri" +
+	"tain cellpadding=equivalent toplaceholder=\"; font-size: justific" +
+	"ationbelieved thatsuffered fromattempted to leader of thecript\" " +
+	"src=\"/(function() {are available\n\t<link rel=\" src='http://intere" +
+	"sted inconventional \" alt=\"\" /></are generallyhas also beenmost " +
+	"popular correspondingcredited withtyle=\"border:</a></span></.gif" +
+	"\" width=\"<iframe src=\"table class=\"inline-block;according to tog" +
+	"ether withapproximatelyparliamentarymore and moredisplay:none;tr" +
+	"aditionallypredominantly&nbsp;|&nbsp;&nbsp;</span> cellspacing=<" +
+	"input name=\"or\" content=\"controversialproperty=\"og:/x-shockwave-" +
+	"demonstrationsurrounded byNevertheless,was the firstconsiderable" +
+	" Although the collaborationshould not beproportion of<span style" +
+	"=\"known as the shortly afterfor instance,described as /head>\n<bo" +
+	"dy starting withincreasingly the fact thatdiscussion ofmiddle of" +
+	" thean individualdifficult to point of viewhomosexualityacceptan" +
+	"ce of</span></div>manufacturersorigin of thecommonly usedimporta" +
+	"nce ofdenominationsbackground: #length of thedeterminationa sign" +
+	"ificant\" border=\"0\">revolutionaryprinciples ofis consideredwas d" +
+	"evelopedIndo-Europeanvulnerable toproponents ofare sometimesclos" +
+	"er to theNew York City name=\"searchattributed tocourse of themat" +
+	"hematicianby the end ofat the end of\" border=\"0\" technological.r" +
+	"emoveClass(branch of theevidence that![endif]-->\r\nInstitute of i" +
+	"nto a singlerespectively.and thereforeproperties ofis located in" +
+	"some of whichThere is alsocontinued to appearance of &amp;ndash;" +
+	" describes theconsiderationauthor of theindependentlyequipped wi" +
+	"thdoes not have</a><a href=\"confused with<link href=\"/at the age" +
+	" ofappear in theThese includeregardless ofcould be used style=&q" +
+	"uot;several timesrepresent thebody>\n</html>thought to bepopulati" +
+	"on ofpossibilitiespercentage ofaccess to thean attempt toproduct" +
+	"ion ofjquery/jquerytwo differentbelong to theestablishmentreplac" +
+	"ing thedescription\" determine theavailable forAccording to wide " +
+	"range of\t<div class=\"more commonlyorganisationsfunctionalitywas " +
+	"completed &amp;mdash; participationthe characteran additionalapp" +
+	"ears to befact that thean example ofsignificantlyonmouseover=\"be" +
+	"cause they async = true;problems withseems to havethe result of " +
+	"src=\"http://familiar withpossession offunction () {took place in" +
+	"and sometimessubstantially<span></span>is often usedin an attemp" +
+	"tgreat deal ofEnvironmentalsuccessfully virtually all20th centur" +
+	"y,professionalsnecessary to determined bycompatibilitybecause it" +
+	" isDictionary ofmodificationsThe followingmay refer to:Consequen" +
+	"tly,Internationalalthough somethat would beworld's firstclassifi" +
+	"ed asbottom of the(particularlyalign=\"left\" most commonlybasis f" +
+	"or thefoundation ofcontributionspopularity ofcenter of theto red" +
+	"uce thejurisdictionsapproximation onmouseout=\"New Testamentcolle" +
+	"ction of</span></a></in the Unitedfilm director-strict.dtd\">has " +
+	"been usedreturn to thealthough thischange in theseveral otherbut" +
+	" there areunprecedentedis similar toespecially inweight: bold;is" +
+	" called thecomputationalindicate thatrestricted to\t<meta name=\"a" +
+	"re typicallyconflict withHowever, the An example ofcompared with" +
+	"quantities ofrather than aconstellationnecessary forreported tha" +
+	"tspecificationpolitical and&nbsp;&nbsp;<references tothe same ye" +
+	"arGovernment ofgeneration ofhave not beenseveral yearscommitment" +
+	" to\t\t<ul class=\"visualization19th century,practitionersthat he w" +
+	"ouldand continuedoccupation ofis defined ascentre of thethe amou" +
+	"nt of><div style=\"equivalent ofdifferentiatebrought aboutmargin-" +
+	"left: automaticallythought of asSome of these\n<div class=\"input " +
+	"class=\"replaced withis one of theeducation andinfluenced byreput" +
+	"ation as\n<meta name=\"accommodation</div>\n</div>large part ofInst" +
+	"itute forthe so-called against the In this case,was appointedcla" +
+	"imed to beHowever, thisDepartment ofthe remainingeffect on thepa" +
+	"rticularly deal with the\n<div style=\"almost alwaysare currentlye" +
+	"xpression ofphilosophy offor more thancivilizationson the island" +
+	"selectedIndexcan result in\" value=\"\" />the structure /></a></div" +
+	">Many of thesecaused by theof the Unitedspan class=\"mcan be trac" +
+	"edis related tobecame one ofis frequentlyliving in thetheoretica" +
+	"llyFollowing theRevolutionarygovernment inis determinedthe polit" +
+	"icalintroduced insufficient todescription\">short storiesseparati" +
+	"on ofas to whetherknown for itswas initiallydisplay:blockis an e" +
+	"xamplethe principalconsists of arecognized as/body></html>a subs" +
+	"tantialreconstructedhead of stateresistance toundergraduateThere" +
+	" are twogravitationalare describedintentionallyserved as theclas" +
+	"s=\"headeropposition tofundamentallydominated theand the otherall" +
+	"iance withwas forced torespectively,and politicalin support ofpe" +
+	"ople in the20th century.and publishedloadChartbeatto understandm" +
+	"ember statesenvironmentalfirst half ofcountries andarchitectural" +
+	"be consideredcharacterizedclearIntervalauthoritativeFederation o" +
+	"fwas succeededand there area consequencethe Presidentalso includ" +
+	"edfree softwaresuccession ofdeveloped thewas destroyedaway from " +
+	"the;\n</script>\n<although theyfollowed by amore powerfulresulted " +
+	"in aUniversity ofHowever, manythe presidentHowever, someis thoug" +
+	"ht tountil the endwas announcedare importantalso includes><input" +
+	" type=the center of DO NOT ALTERused to referthemes/?sort=that h" +
+	"ad beenthe basis forhas developedin the summercomparativelydescr" +
+	"ibed thesuch as thosethe resultingis impossiblevarious otherSout" +
+	"h Africanhave the sameeffectivenessin which case; text-align:str" +
+	"ucture and; background:regarding thesupported theis also knownst" +
+	"yle=\"marginincluding thebahasa Melayunorsk bokmålnorsk nynorsks" +
+	"lovenščinainternacionalcalificacióncomunicaciónconstrucción" +
+	"\"><div class=\"disambiguationDomainName', 'administrationsimultan" +
+	"eouslytransportationInternational margin-bottom:responsibility<!" +
+	"[endif]-->\n</><meta name=\"implementationinfrastructurerepresenta" +
+	"tionborder-bottom:</head>\n<body>=http%3A%2F%2F<form method=\"meth" +
+	"od=\"post\" /favicon.ico\" });\n</script>\n.setAttribute(Administrati" +
+	"on= new Array();<![endif]-->\r\ndisplay:block;Unfortunately,\">&nbs" +
+	"p;</div>/favicon.ico\">='stylesheet' identification, for example," +
+	"<li><a href=\"/an alternativeas a result ofpt\"></script>\ntype=\"su" +
+	"bmit\" \n(function() {recommendationform action=\"/transformationre" +
+	"construction.style.display According to hidden\" name=\"along with" +
+	" thedocument.body.approximately Communicationspost\" action=\"mean" +
+	"ing &quot;--<![endif]-->Prime Ministercharacteristic</a> <a clas" +
+	"s=the history of onmouseover=\"the governmenthref=\"https://was or" +
+	"iginallywas introducedclassificationrepresentativeare considered" +
+	"<![endif]-->\n\ndepends on theUniversity of in contrast to placeho" +
+	"lder=\"in the case ofinternational constitutionalstyle=\"border-: " +
+	"function() {Because of the-strict.dtd\">\n<table class=\"accompanie" +
+	"d byaccount of the<script src=\"/nature of the the people in in a" +
+	"ddition tos); js.id = id\" width=\"100%\"regarding the Roman Cathol" +
+	"ican independentfollowing the .gif\" width=\"1the following discri" +
+	"minationarchaeologicalprime minister.js\"></script>combination of" +
+	" marginwidth=\"createElement(w.attachEvent(</a></td></tr>src=\"htt" +
+	"ps://aIn particular, align=\"left\" Czech RepublicUnited Kingdomco" +
+	"rrespondenceconcluded that.html\" title=\"(function () {comes from" +
+	" theapplication of<span class=\"sbelieved to beement('script'</a>" +
+	"\n</li>\n<livery different><span class=\"option value=\"(also known " +
+	"as\t<li><a href=\"><input name=\"separated fromreferred to as valig" +
+	"n=\"top\">founder of theattempting to carbon dioxide\n\n<div class=\"" +
+	"class=\"search-/body>\n</html>opportunity tocommunications</head>\r" +
+	"\n<body style=\"width:Tiếng Việtchanges in theborder-color:#0\"" +
+	" border=\"0\" </span></div><was discovered\" type=\"text\" );\n</scrip" +
+	"t>\n\nDepartment of ecclesiasticalthere has beenresulting from</bo" +
+	"dy></html>has never beenthe first timein response toautomaticall" +
+	"y </div>\n\n<div iwas consideredpercent of the\" /></a></div>collec" +
+	"tion of descended fromsection of theaccept-charsetto be confused" +
+	"member of the padding-right:translation ofinterpretation href='h" +
+	"ttp://whether or notThere are alsothere are manya small numberot" +
+	"her parts ofimpossible to  class=\"buttonlocated in the. However," +
+	" theand eventuallyAt the end of because of itsrepresents the<for" +
+	"m action=\" method=\"post\"it is possiblemore likely toan increase " +
+	"inhave also beencorresponds toannounced thatalign=\"right\">many c" +
+	"ountriesfor many yearsearliest knownbecause it waspt\"></script>\r" +
+	" valign=\"top\" inhabitants offollowing year\r\n<div class=\"million " +
+	"peoplecontroversial concerning theargue that thegovernment anda " +
+	"reference totransferred todescribing the style=\"color:although t" +
+	"herebest known forsubmit\" name=\"multiplicationmore than one reco" +
+	"gnition ofCouncil of theedition of the  <meta name=\"Entertainmen" +
+	"t away from the ;margin-right:at the time ofinvestigationsconnec" +
+	"ted withand many otheralthough it isbeginning with <span class=\"" +
+	"descendants of<span class=\"i align=\"right\"</head>\n<body aspects " +
+	"of thehas since beenEuropean Unionreminiscent ofmore difficultVi" +
+	"ce Presidentcomposition ofpassed throughmore importantfont-size:" +
+	"11pxexplanation ofthe concept ofwritten in the\t<span class=\"is o" +
+	"ne of the resemblance toon the groundswhich containsincluding th" +
+	"e defined by thepublication ofmeans that theoutside of thesuppor" +
+	"t of the<input class=\"<span class=\"t(Math.random()most prominent" +
+	"description ofConstantinoplewere published<div class=\"seappears " +
+	"in the1\" height=\"1\" most importantwhich includeswhich had beende" +
+	"struction ofthe population\n\t<div class=\"possibility ofsometimes " +
+	"usedappear to havesuccess of theintended to bepresent in thestyl" +
+	"e=\"clear:b\r\n</script>\r\n<was founded ininterview with_id\" content" +
+	"=\"capital of the\r\n<link rel=\"srelease of thepoint out thatxMLHtt" +
+	"pRequestand subsequentsecond largestvery importantspecifications" +
+	"surface of theapplied to theforeign policy_setDomainNameestablis" +
+	"hed inis believed toIn addition tomeaning of theis named afterto" +
+	" protect theis representedDeclaration ofmore efficientClassifica" +
+	"tionother forms ofhe returned to<span class=\"cperformance of(fun" +
+	"ction() {\rif and only ifregions of theleading to therelations wi" +
+	"thUnited Nationsstyle=\"height:other than theype\" content=\"Associ" +
+	"ation of\n</head>\n<bodylocated on theis referred to(including the" +
+	"concentrationsthe individualamong the mostthan any other/>\n<link" +
+	" rel=\" return false;the purpose ofthe ability to;color:#fff}\n.\n<" +
+	"span class=\"the subject ofdefinitions of>\r\n<link rel=\"claim that" +
+	" thehave developed<table width=\"celebration ofFollowing the to d" +
+	"istinguish<span class=\"btakes place inunder the namenoted that t" +
+	"he><![endif]-->\nstyle=\"margin-instead of theintroduced thethe pr" +
+	"ocess ofincreasing thedifferences inestimated thatespecially the" +
+	"/div><div id=\"was eventuallythroughout histhe differencesomethin" +
+	"g thatspan></span></significantly ></script>\r\n\r\nenvironmental to" +
+	" prevent thehave been usedespecially forunderstand theis essenti" +
+	"allywere the firstis the largesthave been made\" src=\"http://inte" +
+	"rpreted assecond half ofcrolling=\"no\" is composed ofII, Holy Rom" +
+	"anis expected tohave their owndefined as thetraditionally have d" +
+	"ifferentare often usedto ensure thatagreement withcontaining the" +
+	"are frequentlyinformation onexample is theresulting in a</a></li" +
+	"></ul> class=\"footerand especiallytype=\"button\" </span></span>wh" +
+	"ich included>\n<meta name=\"considered thecarried out byHowever, i" +
+	"t isbecame part ofin relation topopular in thethe capital ofwas " +
+	"officiallywhich has beenthe History ofalternative todifferent fr" +
+	"omto support thesuggested thatin the process  <div class=\"the fo" +
+	"undationbecause of hisconcerned withthe universityopposed to the" +
+	"the context of<span class=\"ptext\" name=\"q\"\t\t<div class=\"the scie" +
+	"ntificrepresented bymathematicianselected by thethat have been><" +
+	"div class=\"cdiv id=\"headerin particular,converted into);\n</scrip" +
+	"t>\n<philosophical srpskohrvatskitiếng ViệtРусскийру" +
+	"сскийinvestigaciónparticipaciónкоторыеобласт" +
+	"икоторыйчеловексистемыНовостикот" +
+	"орыхобластьвременикотораясегодня" +
+	"скачатьновостиУкраинывопросыкото" +
+	"ройсделатьпомощьюсредствобразомс" +
+	"тороныучастиетечениеГлавнаяистор" +
+	"иисистемарешенияСкачатьпоэтомусл" +
+	"едуетсказатьтоваровконечнорешени" +
+	"екотороеоргановкоторомРекламаالم" +
+	"نتدىمنتدياتالموضوعالبرامجالمواقع" +
+	"الرسائلمشاركاتالأعضاءالرياضةالتص" +
+	"ميمالاعضاءالنتائجالألعابالتسجيلا" +
+	"لأقسامالضغطاتالفيديوالترحيبالجدي" +
+	"دةالتعليمالأخبارالافلامالأفلامال" +
+	"تاريخالتقنيةالالعابالخواطرالمجتم" +
+	"عالديكورالسياحةعبداللهالتربيةالر" +
+	"وابطالأدبيةالاخبارالمتحدةالاغاني" +
+	"cursor:pointer;</title>\n<meta \" href=\"http://\"><span class=\"memb" +
+	"ers of the window.locationvertical-align:/a> | <a href=\"<!doctyp" +
+	"e html>media=\"screen\" <option value=\"favicon.ico\" />\n\t\t<div clas" +
+	"s=\"characteristics\" method=\"get\" /body>\n</html>\nshortcut icon\" d" +
+	"ocument.write(padding-bottom:representativessubmit\" value=\"align" +
+	"=\"center\" throughout the science fiction\n  <div class=\"submit\" c" +
+	"lass=\"one of the most valign=\"top\"><was established);\r\n</script>" +
+	"\r\nreturn false;\">).style.displaybecause of the document.cookie<f" +
+	"orm action=\"/}body{margin:0;Encyclopedia ofversion of the .creat" +
+	"eElement(name\" content=\"</div>\n</div>\n\nadministrative </body>\n</" +
+	"html>history of the \"><input type=\"portion of the as part of the" +
+	" &nbsp;<a href=\"other countries\">\n<div class=\"</span></span><In " +
+	"other words,display: block;control of the introduction of/>\n<met" +
+	"a name=\"as well as the in recent years\r\n\t<div class=\"</div>\n\t</d" +
+	"iv>\ninspired by thethe end of the compatible withbecame known as" +
+	" style=\"margin:.js\"></script>< International there have beenGerm" +
+	"an language style=\"color:#Communist Partyconsistent withborder=\"" +
+	"0\" cell marginheight=\"the majority of\" align=\"centerrelated to t" +
+	"he many different Orthodox Churchsimilar to the />\n<link rel=\"sw" +
+	"as one of the until his death})();\n</script>other languagescompa" +
+	"red to theportions of thethe Netherlandsthe most commonbackgroun" +
+	"d:url(argued that thescrolling=\"no\" included in theNorth America" +
+	"n the name of theinterpretationsthe traditionaldevelopment of fr" +
+	"equently useda collection ofvery similar tosurrounding theexampl" +
+	"e of thisalign=\"center\">would have beenimage_caption =attached t" +
+	"o thesuggesting thatin the form of involved in theis derived fro" +
+	"mnamed after theIntroduction torestrictions on style=\"width: can" +
+	" be used to the creation ofmost important information andresulte" +
+	"d in thecollapse of theThis means thatelements of thewas replace" +
+	"d byanalysis of theinspiration forregarded as themost successful" +
+	"known as &quot;a comprehensiveHistory of the were consideredretu" +
+	"rned to theare referred toUnsourced image>\n\t<div class=\"consists" +
+	" of thestopPropagationinterest in theavailability ofappears to h" +
+	"aveelectromagneticenableServices(function of theIt is important<" +
+	"/script></div>function(){var relative to theas a result of the p" +
+	"osition ofFor example, in method=\"post\" was followed by&amp;mdas" +
+	"h; thethe applicationjs\"></script>\r\nul></div></div>after the dea" +
+	"thwith respect tostyle=\"padding:is particularlydisplay:inline; t" +
+	"ype=\"submit\" is divided into中文 (简体)responsabilidadadmini" +
+	"stracióninternacionalescorrespondienteउपयोगपूर\xe0" +
+	"\xa5\x8dवहमारेलोगोंचुनावलेकि\xe0\xa4" +
+	"\xa8सरकारपुलिसखोजेंचाहिएभ" +
+	"ेजेंशामिलहमारीजागरणबन\xe0" +
+	"\xa4\xbeनेकुमारब्लॉगमालिकमहि\xe0\xa4" +
+	"\xb2ापृष्ठबढ़तेभाजपाक्लिक" +
+	"ट्रेनखिलाफदौरानमामलेम\xe0" +
+	"\xa4\xa4दानबाजारविकासक्योंचा\xe0\xa4" +
+	"\xb9तेपहुँचबतायासंवाददेखन" +
+	"ेपिछलेविशेषराज्यउत्तर\xe0" +
+	"\xa4\xaeुंबईदोनोंउपकरणपढ़ेंस\xe0\xa5" +
+	"\x8dथितफिल्ममुख्यअच्छाछूट" +
+	"तीसंगीतजाएगाविभागघण्ट\xe0" +
+	"\xa5\x87दूसरेदिनोंहत्यासेक्स\xe0\xa4" +
+	"\x97ांधीविश्वरातेंदैट्सनक" +
+	"्शासामनेअदालतबिजलीपुर\xe0" +
+	"\xa5\x82षहिंदीमित्रकवितारुपय\xe0\xa5" +
+	"\x87स्थानकरोड़मुक्तयोजनाक" +
+	"ृपयापोस्टघरेलूकार्यवि\xe0" +
+	"\xa4\x9aारसूचनामूल्यदेखेंहमे\xe0\xa4" +
+	"\xb6ास्कूलमैंनेतैयारजिसके" +
+	"rss+xml\" title=\"-type\" content=\"title\" content=\"at the same time" +
+	".js\"></script>\n<\" method=\"post\" </span></a></li>vertical-align:t" +
+	"/jquery.min.js\">.click(function( style=\"padding-})();\n</script>\n" +
+	"</span><a href=\"<a href=\"http://); return false;text-decoration:" +
+	" scrolling=\"no\" border-collapse:associated with Bahasa Indonesia" +
+	"English language<text xml:space=.gif\" border=\"0\"</body>\n</html>\n" +
+	"overflow:hidden;img src=\"http://addEventListenerresponsible for " +
+	"s.js\"></script>\n/favicon.ico\" />operating system\" style=\"width:1" +
+	"target=\"_blank\">State Universitytext-align:left;\ndocument.write(" +
+	", including the around the world);\r\n</script>\r\n<\" style=\"height:" +
+	";overflow:hiddenmore informationan internationala member of the " +
+	"one of the firstcan be found in </div>\n\t\t</div>\ndisplay: none;\">" +
+	"\" />\n<link rel=\"\n  (function() {the 15th century.preventDefault(" +
+	"large number of Byzantine Empire.jpg|thumb|left|vast majority of" +
+	"majority of the  align=\"center\">University Pressdominated by the" +
+	"Second World Wardistribution of style=\"position:the rest of the " +
+	"characterized by rel=\"nofollow\">derives from therather than the " +
+	"a combination ofstyle=\"width:100English-speakingcomputer science" +
+	"border=\"0\" alt=\"the existence ofDemocratic Party\" style=\"margin-" +
+	"For this reason,.js\"></script>\n\tsByTagName(s)[0]js\"></script>\r\n<" +
+	".js\"></script>\r\nlink rel=\"icon\" ' alt='' class='formation of the" +
+	"versions of the </a></div></div>/page>\n  <page>\n<div class=\"cont" +
+	"became the firstbahasa Indonesiaenglish (simple)Ελληνικά" +
+	"хрватскикомпанииявляетсяДобавить" +
+	"человекаразвитияИнтернетОтветить" +
+	"напримеринтернеткоторогостраницы" +
+	"качествеусловияхпроблемыполучить" +
+	"являютсянаиболеекомпаниявнимание" +
+	"средстваالمواضيعالرئيسيةالانتقال" +
+	"مشاركاتكالسياراتالمكتوبةالسعودية" +
+	"احصائياتالعالميةالصوتياتالانترنت" +
+	"التصاميمالإسلاميالمشاركةالمرئيات" +
+	"robots\" content=\"<div id=\"footer\">the United States<img src=\"htt" +
+	"p://.jpg|right|thumb|.js\"></script>\r\n<location.protocolframebord" +
+	"er=\"0\" s\" />\n<meta name=\"</a></div></div><font-weight:bold;&quot" +
+	"; and &quot;depending on the margin:0;padding:\" rel=\"nofollow\" P" +
+	"resident of the twentieth centuryevision>\n  </pageInternet Explo" +
+	"rera.async = true;\r\ninformation about<div id=\"header\">\" action=\"" +
+	"http://<a href=\"https://<div id=\"content\"</div>\r\n</div>\r\n<derive" +
+	"d from the <img src='http://according to the \n</body>\n</html>\nst" +
+	"yle=\"font-size:script language=\"Arial, Helvetica,</a><span class" +
+	"=\"</script><script political partiestd></tr></table><href=\"http:" +
+	"//www.interpretation ofrel=\"stylesheet\" document.write('<charset" +
+	"=\"utf-8\">\nbeginning of the revealed that thetelevision series\" r" +
+	"el=\"nofollow\"> target=\"_blank\">claiming that thehttp%3A%2F%2Fwww" +
+	".manifestations ofPrime Minister ofinfluenced by theclass=\"clear" +
+	"fix\">/div>\r\n</div>\r\n\r\nthree-dimensionalChurch of Englandof North" +
+	" Carolinasquare kilometres.addEventListenerdistinct from thecomm" +
+	"only known asPhonetic Alphabetdeclared that thecontrolled by the" +
+	"Benjamin Franklinrole-playing gamethe University ofin Western Eu" +
+	"ropepersonal computerProject Gutenbergregardless of thehas been " +
+	"proposedtogether with the></li><li class=\"in some countriesmin.j" +
+	"s\"></script>of the populationofficial language<img src=\"images/i" +
+	"dentified by thenatural resourcesclassification ofcan be conside" +
+	"redquantum mechanicsNevertheless, themillion years ago</body>\r\n<" +
+	"/html>\rΕλληνικά\ntake advantage ofand, according toattrib" +
+	"uted to theMicrosoft Windowsthe first centuryunder the controldi" +
+	"v class=\"headershortly after thenotable exceptiontens of thousan" +
+	"dsseveral differentaround the world.reaching militaryisolated fr" +
+	"om theopposition to thethe Old TestamentAfrican Americansinserte" +
+	"d into theseparate from themetropolitan areamakes it possibleack" +
+	"nowledged thatarguably the mosttype=\"text/css\">\nthe Internationa" +
+	"lAccording to the pe=\"text/css\" />\ncoincide with thetwo-thirds o" +
+	"f theDuring this time,during the periodannounced that hethe inte" +
+	"rnationaland more recentlybelieved that theconsciousness andform" +
+	"erly known assurrounded by thefirst appeared inoccasionally used" +
+	"position:absolute;\" target=\"_blank\" position:relative;text-align" +
+	":center;jax/libs/jquery/1.background-color:#type=\"application/an" +
+	"guage\" content=\"<meta http-equiv=\"Privacy Policy</a>e(\"%3Cscript" +
+	" src='\" target=\"_blank\">On the other hand,.jpg|thumb|right|2</di" +
+	"v><div class=\"<div style=\"float:nineteenth century</body>\r\n</htm" +
+	"l>\r\n<img src=\"http://s;text-align:centerfont-weight: bold; Accor" +
+	"ding to the difference between\" frameborder=\"0\" \" style=\"positio" +
+	"n:link href=\"http://html4/loose.dtd\">\nduring this period</td></t" +
+	"r></table>closely related tofor the first time;font-weight:bold;" +
+	"input type=\"text\" <span style=\"font-onreadystatechange\t<div clas" +
+	"s=\"cleardocument.location. For example, the a wide variety of <!" +
+	"DOCTYPE html>\r\n<&nbsp;&nbsp;&nbsp;\"><a href=\"http://style=\"float" +
+	":left;concerned with the=http%3A%2F%2Fwww.in popular culturetype" +
+	"=\"text/css\" />it is possible to Harvard Universitytylesheet\" hre" +
+	"f=\"/the main characterOxford University  name=\"keywords\" cstyle=" +
+	"\"text-align:the United Kingdomfederal government<div style=\"marg" +
+	"in depending on the description of the<div class=\"header.min.js\"" +
+	"></script>destruction of theslightly differentin accordance with" +
+	"telecommunicationsindicates that theshortly thereafterespecially" +
+	" in the European countriesHowever, there aresrc=\"http://staticsu" +
+	"ggested that the\" src=\"http://www.a large number of Telecommunic" +
+	"ations\" rel=\"nofollow\" tHoly Roman Emperoralmost exclusively\" bo" +
+	"rder=\"0\" alt=\"Secretary of Stateculminating in theCIA World Fact" +
+	"bookthe most importantanniversary of thestyle=\"background-<li><e" +
+	"m><a href=\"/the Atlantic Oceanstrictly speaking,shortly before t" +
+	"hedifferent types ofthe Ottoman Empire><img src=\"http://An Intro" +
+	"duction toconsequence of thedeparture from theConfederate States" +
+	"indigenous peoplesProceedings of theinformation on thetheories h" +
+	"ave beeninvolvement in thedivided into threeadjacent countriesis" +
+	" responsible fordissolution of thecollaboration withwidely regar" +
+	"ded ashis contemporariesfounding member ofDominican Republicgene" +
+	"rally acceptedthe possibility ofare also availableunder construc" +
+	"tionrestoration of thethe general publicis almost entirelypasses" +
+	" through thehas been suggestedcomputer and videoGermanic languag" +
+	"es according to the different from theshortly afterwardshref=\"ht" +
+	"tps://www.recent developmentBoard of Directors<div class=\"search" +
+	"| <a href=\"http://In particular, theMultiple footnotesor other s" +
+	"ubstancethousands of yearstranslation of the</div>\r\n</div>\r\n\r\n<a" +
+	" href=\"index.phpwas established inmin.js\"></script>\nparticipate " +
+	"in thea strong influencestyle=\"margin-top:represented by thegrad" +
+	"uated from theTraditionally, theElement(\"script\");However, since" +
+	" the/div>\n</div>\n<div left; margin-left:protection against0; ver" +
+	"tical-align:Unfortunately, thetype=\"image/x-icon/div>\n<div class" +
+	"=\" class=\"clearfix\"><div class=\"footer\t\t</div>\n\t\t</div>\nthe moti" +
+	"on pictureБългарскибългарскиФедерации" +
+	"несколькосообщениесообщенияпрогр" +
+	"аммыОтправитьбесплатноматериалып" +
+	"озволяетпоследниеразличныхпродук" +
+	"циипрограммаполностьюнаходитсяиз" +
+	"бранноенаселенияизменениякатегор" +
+	"ииАлександрद्वारामैनुअलप्" +
+	"रदानभारतीयअनुदेशहिन्द\xe0" +
+	"\xa5\x80इंडियादिल्लीअधिकारवी\xe0\xa4" +
+	"\xa1ियोचिट्ठेसमाचारजंक्शन" +
+	"दुनियाप्रयोगअनुसारऑनल\xe0" +
+	"\xa4\xbeइनपार्टीशर्तोंलोकसभा\xe0\xa4" +
+	"\xab़्लैशशर्तेंप्रदेशप्ले" +
+	"यरकेंद्रस्थितिउत्पादउ\xe0" +
+	"\xa4\xa8्हेंचिट्ठायात्राज्या\xe0\xa4" +
+	"\xa6ापुरानेजोड़ेंअनुवादश्" +
+	"रेणीशिक्षासरकारीसंग्र\xe0" +
+	"\xa4\xb9परिणामब्रांडबच्चोंउप\xe0\xa4" +
+	"\xb2ब्धमंत्रीसंपर्कउम्मीद" +
+	"माध्यमसहायताशब्दोंमीड\xe0" +
+	"\xa4\xbfयाआईपीएलमोबाइलसंख्या\xe0\xa4" +
+	"\x86परेशनअनुबंधबाज़ारनवीन" +
+	"तमप्रमुखप्रश्नपरिवारन\xe0" +
+	"\xa5\x81कसानसमर्थनआयोजितसोमव\xe0\xa4" +
+	"\xbeरالمشاركاتالمنتدياتالكمبيوترالم" +
+	"شاهداتعددالزوارعددالردودالإسلامي" +
+	"ةالفوتوشوبالمسابقاتالمعلوماتالمس" +
+	"لسلاتالجرافيكسالاسلاميةالاتصالات" +
+	"keywords\" content=\"w3.org/1999/xhtml\"><a target=\"_blank\" text/ht" +
+	"ml; charset=\" target=\"_blank\"><table cellpadding=\"autocomplete=\"" +
+	"off\" text-align: center;to last version by background-color: #\" " +
+	"href=\"http://www./div></div><div id=<a href=\"#\" class=\"\"><img sr" +
+	"c=\"http://cript\" src=\"http://\n<script language=\"//EN\" \"http://ww" +
+	"w.wencodeURIComponent(\" href=\"javascript:<div class=\"contentdocu" +
+	"ment.write('<scposition: absolute;script src=\"http:// style=\"mar" +
+	"gin-top:.min.js\"></script>\n</div>\n<div class=\"w3.org/1999/xhtml\"" +
+	" \n\r\n</body>\r\n</html>distinction between/\" target=\"_blank\"><link " +
+	"href=\"http://encoding=\"utf-8\"?>\nw.addEventListener?action=\"http:" +
+	"//www.icon\" href=\"http:// style=\"background:type=\"text/css\" />\nm" +
+	"eta property=\"og:t<input type=\"text\"  style=\"text-align:the deve" +
+	"lopment of tylesheet\" type=\"tehtml; charset=utf-8is considered t" +
+	"o betable width=\"100%\" In addition to the contributed to the dif" +
+	"ferences betweendevelopment of the It is important to </script>\n" +
+	"\n<script  style=\"font-size:1></span><span id=gbLibrary of Congre" +
+	"ss<img src=\"http://imEnglish translationAcademy of Sciencesdiv s" +
+	"tyle=\"display:construction of the.getElementById(id)in conjuncti" +
+	"on withElement('script'); <meta property=\"og:Български\n" +
+	" type=\"text\" name=\">Privacy Policy</a>administered by theenableS" +
+	"ingleRequeststyle=&quot;margin:</div></div></div><><img src=\"htt" +
+	"p://i style=&quot;float:referred to as the total population ofin" +
+	" Washington, D.C. style=\"background-among other things,organizat" +
+	"ion of theparticipated in thethe introduction ofidentified with " +
+	"thefictional character Oxford University misunderstanding ofTher" +
+	"e are, however,stylesheet\" href=\"/Columbia Universityexpanded to" +
+	" includeusually referred toindicating that thehave suggested tha" +
+	"taffiliated with thecorrelation betweennumber of different></td>" +
+	"</tr></table>Republic of Ireland\n</script>\n<script under the inf" +
+	"luencecontribution to theOfficial website ofheadquarters of thec" +
+	"entered around theimplications of thehave been developedFederal " +
+	"Republic ofbecame increasinglycontinuation of theNote, however, " +
+	"thatsimilar to that of capabilities of theaccordance with thepar" +
+	"ticipants in thefurther developmentunder the directionis often c" +
+	"onsideredhis younger brother</td></tr></table><a http-equiv=\"X-U" +
+	"A-physical propertiesof British Columbiahas been criticized(with" +
+	" the exceptionquestions about thepassing through the0\" cellpaddi" +
+	"ng=\"0\" thousands of peopleredirects here. Forhave children under" +
+	"%3E%3C/script%3E\"));<a href=\"http://www.<li><a href=\"http://site" +
+	"_name\" content=\"text-decoration:nonestyle=\"display: none<meta ht" +
+	"tp-equiv=\"X-new Date().getTime() type=\"image/x-icon\"</span><span" +
+	" class=\"language=\"javascriptwindow.location.href<a href=\"javascr" +
+	"ipt:-->\r\n<script type=\"t<a href='http://www.hortcut icon\" href=\"" +
+	"</div>\r\n<div class=\"<script src=\"http://\" rel=\"stylesheet\" t</di" +
+	"v>\n<script type=/a> <a href=\"http:// allowTransparency=\"X-UA-Com" +
+	"patible\" conrelationship between\n</script>\r\n<script </a></li></u" +
+	"l></div>associated with the programming language</a><a href=\"htt" +
+	"p://</a></li><li class=\"form action=\"http://<div style=\"display:" +
+	"type=\"text\" name=\"q\"<table width=\"100%\" background-position:\" bo" +
+	"rder=\"0\" width=\"rel=\"shortcut icon\" h6><ul><li><a href=\"  <meta " +
+	"http-equiv=\"css\" media=\"screen\" responsible for the \" type=\"appl" +
+	"ication/\" style=\"background-html; charset=utf-8\" allowtransparen" +
+	"cy=\"stylesheet\" type=\"te\r\n<meta http-equiv=\"></span><span class=" +
+	"\"0\" cellspacing=\"0\">;\n</script>\n<script sometimes called thedoes" +
+	" not necessarilyFor more informationat the beginning of <!DOCTYP" +
+	"E html><htmlparticularly in the type=\"hidden\" name=\"javascript:v" +
+	"oid(0);\"effectiveness of the autocomplete=\"off\" generally consid" +
+	"ered><input type=\"text\" \"></script>\r\n<scriptthroughout the world" +
+	"common misconceptionassociation with the</div>\n</div>\n<div cduri" +
+	"ng his lifetime,corresponding to thetype=\"image/x-icon\" an incre" +
+	"asing numberdiplomatic relationsare often consideredmeta charset" +
+	"=\"utf-8\" <input type=\"text\" examples include the\"><img src=\"http" +
+	"://iparticipation in thethe establishment of\n</div>\n<div class=\"" +
+	"&amp;nbsp;&amp;nbsp;to determine whetherquite different frommark" +
+	"ed the beginningdistance between thecontributions to theconflict" +
+	" between thewidely considered towas one of the firstwith varying" +
+	" degreeshave speculated that(document.getElementparticipating in" +
+	" theoriginally developedeta charset=\"utf-8\"> type=\"text/css\" />\n" +
+	"interchangeably withmore closely relatedsocial and politicalthat" +
+	" would otherwiseperpendicular to thestyle type=\"text/csstype=\"su" +
+	"bmit\" name=\"families residing indeveloping countriescomputer pro" +
+	"grammingeconomic developmentdetermination of thefor more informa" +
+	"tionon several occasionsportuguês (Europeu)Українська" +
+	"українськаРоссийскойматериаловин" +
+	"формацииуправлениянеобходимоинфо" +
+	"рмацияИнформацияРеспубликиколиче" +
+	"ствоинформациютерриториидостаточ" +
+	"ноالمتواجدونالاشتراكاتالاقتراحات" +
+	"html; charset=UTF-8\" setTimeout(function()display:inline-block;<" +
+	"input type=\"submit\" type = 'text/javascri<img src=\"http://www.\" " +
+	"\"http://www.w3.org/shortcut icon\" href=\"\" autocomplete=\"off\" </a" +
+	"></div><div class=</a></li>\n<li class=\"css\" type=\"text/css\" <for" +
+	"m action=\"http://xt/css\" href=\"http://link rel=\"alternate\" \r\n<sc" +
+	"ript type=\"text/ onclick=\"javascript:(new Date).getTime()}height" +
+	"=\"1\" width=\"1\" People's Republic of  <a href=\"http://www.text-de" +
+	"coration:underthe beginning of the </div>\n</div>\n</div>\nestablis" +
+	"hment of the </div></div></div></d#viewport{min-height:\n<script " +
+	"src=\"http://option><option value=often referred to as /option>\n<" +
+	"option valu<!DOCTYPE html>\n<!--[International Airport>\n<a href=\"" +
+	"http://www</a><a href=\"http://wภาษาไทยქართ" +
+	"ული正體中文 (繁體)निर्देशडाउन\xe0" +
+	"\xa4\xb2ोडक्षेत्रजानकारीसंबं\xe0\xa4" +
+	"\xa7ितस्थापनास्वीकारसंस्क" +
+	"रणसामग्रीचिट्ठोंविज्ञ\xe0" +
+	"\xa4\xbeनअमेरिकाविभिन्नगाडिय\xe0\xa4" +
+	"\xbeँक्योंकिसुरक्षापहुँचत" +
+	"ीप्रबंधनटिप्पणीक्रिके\xe0" +
+	"\xa4\x9fप्रारंभप्राप्तमालिको\xe0\xa4" +
+	"\x82रफ़्तारनिर्माणलिमिटेड" +
+	"description\" content=\"document.location.prot.getElementsByTagNam" +
+	"e(<!DOCTYPE html>\n<html <meta charset=\"utf-8\">:url\" content=\"htt" +
+	"p://.css\" rel=\"stylesheet\"style type=\"text/css\">type=\"text/css\" " +
+	"href=\"w3.org/1999/xhtml\" xmltype=\"text/javascript\" method=\"get\" " +
+	"action=\"link rel=\"stylesheet\"  = document.getElementtype=\"image/" +
+	"x-icon\" />cellpadding=\"0\" cellsp.css\" type=\"text/css\" </a></li><" +
+	"li><a href=\"\" width=\"1\" height=\"1\"\"><a href=\"http://www.style=\"d" +
+	"isplay:none;\">alternate\" type=\"appli-//W3C//DTD XHTML 1.0 ellspa" +
+	"cing=\"0\" cellpad type=\"hidden\" value=\"/a>&nbsp;<span role=\"s\n<in" +
+	"put type=\"hidden\" language=\"JavaScript\"  document.getElementsBg=" +
+	"\"0\" cellspacing=\"0\" ype=\"text/css\" media=\"type='text/javascript'" +
+	"with the exception of ype=\"text/css\" rel=\"st height=\"1\" width=\"1" +
+	"\" ='+encodeURIComponent(<link rel=\"alternate\" \nbody, tr, input, " +
+	"textmeta name=\"robots\" conmethod=\"post\" action=\">\n<a href=\"http:" +
+	"//www.css\" rel=\"stylesheet\" </div></div><div classlanguage=\"java" +
+	"script\">aria-hidden=\"true\">·<ript\" type=\"text/javasl=0;})();\n(f" +
+	"unction(){background-image: url(/a></li><li><a href=\"h\t\t<li><a h" +
+	"ref=\"http://ator\" aria-hidden=\"tru> <a href=\"http://www.language" +
+	"=\"javascript\" /option>\n<option value/div></div><div class=rator\"" +
+	" aria-hidden=\"tre=(new Date).getTime()português (do Brasil)ор" +
+	"ганизациивозможностьобразованияр" +
+	"егистрациивозможностиобязательна" +
+	"<!DOCTYPE html PUBLIC \"nt-Type\" content=\"text/<meta http-equiv=\"" +
+	"Conteransitional//EN\" \"http:<html xmlns=\"http://www-//W3C//DTD X" +
+	"HTML 1.0 TDTD/xhtml1-transitional//www.w3.org/TR/xhtml1/pe = 'te" +
+	"xt/javascript';<meta name=\"descriptionparentNode.insertBefore<in" +
+	"put type=\"hidden\" najs\" type=\"text/javascri(document).ready(func" +
+	"tiscript type=\"text/javasimage\" content=\"http://UA-Compatible\" c" +
+	"ontent=tml; charset=utf-8\" />\nlink rel=\"shortcut icon<link rel=\"" +
+	"stylesheet\" </script>\n<script type== document.createElemen<a tar" +
+	"get=\"_blank\" href= document.getElementsBinput type=\"text\" name=a" +
+	".type = 'text/javascrinput type=\"hidden\" namehtml; charset=utf-8" +
+	"\" />dtd\">\n<html xmlns=\"http-//W3C//DTD HTML 4.01 TentsByTagName(" +
+	"'script')input type=\"hidden\" nam<script type=\"text/javas\" style=" +
+	"\"display:none;\">document.getElementById(=document.createElement(" +
+	"' type='text/javascript'input type=\"text\" name=\"d.getElementsByT" +
+	"agName(snical\" href=\"http://www.C//DTD HTML 4.01 Transit<style t" +
+	"ype=\"text/css\">\n\n<style type=\"text/css\">ional.dtd\">\n<html xmlns=" +
+	"http-equiv=\"Content-Typeding=\"0\" cellspacing=\"0\"html; charset=ut" +
+	"f-8\" />\n style=\"display:none;\"><<li><a href=\"http://www. type='t" +
+	"ext/javascript'>деятельностисоответствии" +
+	"производствабезопасностиपुस्त\xe0" +
+	"\xa4\xbfकाकांग्रेसउन्होंनेवि\xe0\xa4" +
+	"\xa7ानसभाफिक्सिंगसुरक्षित" +
+	"कॉपीराइटविज्ञापनकार्र\xe0" +
+	"\xa4\xb5ाईसक्रियता"
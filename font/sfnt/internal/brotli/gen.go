@@ -0,0 +1,62 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+// This program generates dict.go from the RFC 7932 static dictionary, such as
+// the dictionary.bin file from https://github.com/google/brotli/ under
+// c/common/.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+)
+
+var dictFlag = flag.String("dict", "dictionary.bin", "the static dictionary file")
+
+// wantSHA256 is the static dictionary's SHA-256 hash, as per RFC 7932 Appendix
+// A.
+const wantSHA256 = "20e42eb1b511c21806d4d227d07e5dd06877d8ce7b3a817f378f313653f35c70"
+
+func main() {
+	flag.Parse()
+	data, err := ioutil.ReadFile(*dictFlag)
+	if err != nil {
+		log.Fatalf("ioutil.ReadFile: %v", err)
+	}
+	if got := fmt.Sprintf("%x", sha256.Sum256(data)); got != wantSHA256 {
+		log.Fatalf("SHA-256: got %s, want %s", got, wantSHA256)
+	}
+
+	b := new(bytes.Buffer)
+	fmt.Fprintf(b, "// generated by go run gen.go; DO NOT EDIT\n\n")
+	fmt.Fprintf(b, "package brotli\n\n")
+
+	fmt.Fprintf(b, "// dictionary is the static dictionary, as per RFC 7932 Appendix A.\n")
+	fmt.Fprintf(b, "const dictionary = \"\" +\n")
+	for s := data; ; {
+		if len(s) <= 64 {
+			fmt.Fprintf(b, "%q\n", s)
+			break
+		}
+		fmt.Fprintf(b, "%q +\n", s[:64])
+		s = s[64:]
+	}
+
+	dstUnformatted := b.Bytes()
+	dst, err := format.Source(dstUnformatted)
+	if err != nil {
+		log.Fatalf("format.Source: %v\n\n----\n%s\n----", err, dstUnformatted)
+	}
+	if err := ioutil.WriteFile("dict.go", dst, 0666); err != nil {
+		log.Fatalf("ioutil.WriteFile: %v", err)
+	}
+}
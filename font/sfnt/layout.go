@@ -0,0 +1,637 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"sort"
+)
+
+// This file implements the parts of the OpenType Layout tables that are common
+// to GPOS and GSUB, as described at
+// https://www.microsoft.com/typography/otspec/chapter2.htm
+
+// layoutTable holds the location of a GPOS or GSUB table and of its
+// ScriptList, FeatureList and LookupList. The list offsets are relative to the
+// start of the table.
+type layoutTable struct {
+	table
+	scriptList  uint32
+	featureList uint32
+	lookupList  uint32
+}
+
+// lookupSubtables are the subtables of one of a layout table's lookups, with
+// any Extension subtables resolved. The offsets are relative to the start of
+// the GPOS or GSUB table. flag is the lookup's LookupFlag.
+type lookupSubtables struct {
+	lookupType uint16
+	flag       uint16
+	offsets    []uint32
+}
+
+// The LookupFlag bits that select the glyphs that a lookup skips over, as
+// per the GDEF table's glyph classes.
+const (
+	lookupFlagIgnoreBaseGlyphs   = 0x0002
+	lookupFlagIgnoreLigatures    = 0x0004
+	lookupFlagIgnoreMarks        = 0x0008
+	lookupFlagMarkAttachmentType = 0xff00
+)
+
+// parseLayoutTable parses the header of the GPOS or GSUB table t. It returns
+// a zero layoutTable if t is empty.
+func (f *Font) parseLayoutTable(buf []byte, t table, errInvalid error) ([]byte, layoutTable, error) {
+	if t.length == 0 {
+		return buf, layoutTable{}, nil
+	}
+	const headerSize = 10
+	if t.length < headerSize {
+		return nil, layoutTable{}, errInvalid
+	}
+	buf, err := f.src.view(buf, int(t.offset), headerSize)
+	if err != nil {
+		return nil, layoutTable{}, err
+	}
+	if u16(buf) != 1 {
+		return nil, layoutTable{}, errInvalid
+	}
+	lt := layoutTable{
+		table:       t,
+		scriptList:  uint32(u16(buf[4:])),
+		featureList: uint32(u16(buf[6:])),
+		lookupList:  uint32(u16(buf[8:])),
+	}
+	if lt.scriptList >= t.length || lt.featureList >= t.length || lt.lookupList >= t.length {
+		return nil, layoutTable{}, errInvalid
+	}
+	return buf, lt, nil
+}
+
+// parseLayoutFeatures returns, for each feature tag in lt's FeatureList, the
+// indexes into lt's LookupList of the lookups used by that feature, for any
+// script and language system. The indexes are in ascending order, which is
+// the order that the lookups are to be applied.
+func (f *Font) parseLayoutFeatures(buf []byte, lt layoutTable) ([]byte, map[Tag][]uint16, error) {
+	numFeatures, err := f.src.u16(buf, lt.table, int(lt.featureList))
+	if err != nil {
+		return nil, nil, err
+	}
+	if numFeatures == 0 {
+		return buf, nil, nil
+	}
+	const recordSize = 6
+	seen := map[Tag]map[uint16]bool{}
+	for i := 0; i < int(numFeatures); i++ {
+		o := int(lt.featureList) + 2 + recordSize*i
+		if uint32(o+recordSize) > lt.length {
+			return nil, nil, errInvalidBounds
+		}
+		buf, err = f.src.view(buf, int(lt.offset)+o, recordSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		tag := Tag(u32(buf))
+		featureOffset := int(lt.featureList) + int(u16(buf[4:]))
+
+		numLookups, err := f.src.u16(buf, lt.table, featureOffset+2)
+		if err != nil {
+			return nil, nil, err
+		}
+		n := 2 * int(numLookups)
+		if uint32(featureOffset+4+n) > lt.length {
+			return nil, nil, errInvalidBounds
+		}
+		buf, err = f.src.view(buf, int(lt.offset)+featureOffset+4, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if seen[tag] == nil {
+			seen[tag] = map[uint16]bool{}
+		}
+		for j := 0; j < n; j += 2 {
+			seen[tag][u16(buf[j:])] = true
+		}
+	}
+
+	features := make(map[Tag][]uint16, len(seen))
+	for tag, m := range seen {
+		indexes := make([]uint16, 0, len(m))
+		for i := range m {
+			indexes = append(indexes, i)
+		}
+		sortLookupIndexes(indexes)
+		features[tag] = indexes
+	}
+	return buf, features, nil
+}
+
+// sortLookupIndexes sorts s in ascending order.
+func sortLookupIndexes(s []uint16) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
+
+// layoutLookupSubtables returns the subtables of the lookup with the given
+// index into lt's LookupList. extensionType is the lookup type that means an
+// Extension lookup: 9 for GPOS and 7 for GSUB.
+func (f *Font) layoutLookupSubtables(buf []byte, lt layoutTable, index, extensionType uint16, errInvalid error) ([]byte, lookupSubtables, error) {
+	numLookups, err := f.src.u16(buf, lt.table, int(lt.lookupList))
+	if err != nil {
+		return nil, lookupSubtables{}, err
+	}
+	if index >= numLookups {
+		return nil, lookupSubtables{}, errInvalid
+	}
+	u, err := f.src.u16(buf, lt.table, int(lt.lookupList)+2+2*int(index))
+	if err != nil {
+		return nil, lookupSubtables{}, err
+	}
+	lookupOffset := lt.lookupList + uint32(u)
+
+	const headerSize = 6
+	if lookupOffset+headerSize > lt.length {
+		return nil, lookupSubtables{}, errInvalid
+	}
+	buf, err = f.src.view(buf, int(lt.offset+lookupOffset), headerSize)
+	if err != nil {
+		return nil, lookupSubtables{}, err
+	}
+	ls := lookupSubtables{lookupType: u16(buf), flag: u16(buf[2:])}
+	numSubtables := int(u16(buf[4:]))
+	if lookupOffset+headerSize+2*uint32(numSubtables) > lt.length {
+		return nil, lookupSubtables{}, errInvalid
+	}
+	buf, err = f.src.view(buf, int(lt.offset+lookupOffset)+headerSize, 2*numSubtables)
+	if err != nil {
+		return nil, lookupSubtables{}, err
+	}
+	ls.offsets = make([]uint32, numSubtables)
+	for i := range ls.offsets {
+		ls.offsets[i] = lookupOffset + uint32(u16(buf[2*i:]))
+	}
+
+	if ls.lookupType != extensionType {
+		return buf, ls, nil
+	}
+	// An Extension lookup's subtables each point to a subtable of the real
+	// lookup type, using a 32-bit offset. All of them must agree on that type.
+	ls.lookupType = 0
+	for i, o := range ls.offsets {
+		const extensionSize = 8
+		if o+extensionSize > lt.length {
+			return nil, lookupSubtables{}, errInvalid
+		}
+		buf, err = f.src.view(buf, int(lt.offset+o), extensionSize)
+		if err != nil {
+			return nil, lookupSubtables{}, err
+		}
+		typ, extOffset := u16(buf[2:]), u32(buf[4:])
+		if u16(buf) != 1 || typ == extensionType || (i != 0 && typ != ls.lookupType) ||
+			extOffset >= lt.length-o {
+			return nil, lookupSubtables{}, errInvalid
+		}
+		ls.lookupType = typ
+		ls.offsets[i] = o + extOffset
+	}
+	return buf, ls, nil
+}
+
+// layoutU16 returns the uint16 at the offset o relative to the start of the
+// layout table lt.
+func (f *Font) layoutU16(b *Buffer, lt layoutTable, o uint32) (uint16, error) {
+	if o > lt.length || lt.length-o < 2 {
+		return 0, errInvalidBounds
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), 2)
+	if err != nil {
+		return 0, err
+	}
+	return u16(buf), nil
+}
+
+// layoutU16s returns the n uint16s at the offset o relative to the start of
+// the layout table lt.
+func (f *Font) layoutU16s(b *Buffer, lt layoutTable, o uint32, n int) ([]uint16, error) {
+	if o > lt.length || (lt.length-o)/2 < uint32(n) {
+		return nil, errInvalidBounds
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), 2*n)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]uint16, n)
+	for i := range ret {
+		ret[i] = u16(buf[2*i:])
+	}
+	return ret, nil
+}
+
+// layoutIgnores returns whether a lookup with the given LookupFlag skips over
+// the glyph x.
+func (f *Font) layoutIgnores(b *Buffer, flag uint16, x GlyphIndex) (bool, error) {
+	const mask = lookupFlagIgnoreBaseGlyphs | lookupFlagIgnoreLigatures |
+		lookupFlagIgnoreMarks | lookupFlagMarkAttachmentType
+	if flag&mask == 0 {
+		return false, nil
+	}
+	c, err := f.glyphClass(b, x)
+	if err != nil {
+		return false, err
+	}
+	switch c {
+	case GlyphClassBase:
+		return flag&lookupFlagIgnoreBaseGlyphs != 0, nil
+	case GlyphClassLigature:
+		return flag&lookupFlagIgnoreLigatures != 0, nil
+	case GlyphClassMark:
+		if flag&lookupFlagIgnoreMarks != 0 {
+			return true, nil
+		}
+		if t := flag >> 8; t != 0 {
+			mc, err := f.markAttachClass(b, x)
+			return mc != t, err
+		}
+	}
+	return false, nil
+}
+
+// layoutMatch matches the n glyphs that follow glyphs[pos], or that precede
+// it if step is -1, skipping over those that a lookup with the given
+// LookupFlag ignores. match is called with each glyph and its index, from 0
+// to n-1, in that order. It returns the positions of the matched glyphs, and
+// whether all n of them matched.
+func (f *Font) layoutMatch(b *Buffer, glyphs []LayoutGlyph, pos, step int, flag uint16, n int, match func(i int, x GlyphIndex) (bool, error)) ([]int, bool, error) {
+	positions := make([]int, 0, n)
+	for p := pos + step; len(positions) < n; p += step {
+		if p < 0 || len(glyphs) <= p {
+			return nil, false, nil
+		}
+		x := glyphs[p].GlyphIndex
+		if ignore, err := f.layoutIgnores(b, flag, x); err != nil {
+			return nil, false, err
+		} else if ignore {
+			continue
+		}
+		if ok, err := match(len(positions), x); err != nil || !ok {
+			return nil, false, err
+		}
+		positions = append(positions, p)
+	}
+	return positions, true, nil
+}
+
+// coverageIndex returns the index of x in the Coverage table at the offset o,
+// relative to the start of the layout table lt, and whether x is covered at
+// all.
+func (f *Font) coverageIndex(b *Buffer, lt layoutTable, o uint32, x GlyphIndex) (int, bool, error) {
+	format, err := f.layoutU16(b, lt, o)
+	if err != nil {
+		return 0, false, err
+	}
+	n, err := f.layoutU16(b, lt, o+2)
+	if err != nil {
+		return 0, false, err
+	}
+	switch format {
+	case 1:
+		const entrySize = 2
+		if uint32(n)*entrySize > lt.length-o-4 {
+			return 0, false, errInvalidBounds
+		}
+		for lo, hi := 0, int(n); lo < hi; {
+			i := (lo + hi) / 2
+			buf, err := b.view(&f.src, int(lt.offset+o)+4+entrySize*i, entrySize)
+			if err != nil {
+				return 0, false, err
+			}
+			if g := GlyphIndex(u16(buf)); x < g {
+				hi = i
+			} else if x > g {
+				lo = i + 1
+			} else {
+				return i, true, nil
+			}
+		}
+		return 0, false, nil
+
+	case 2:
+		const entrySize = 6
+		if uint32(n)*entrySize > lt.length-o-4 {
+			return 0, false, errInvalidBounds
+		}
+		for lo, hi := 0, int(n); lo < hi; {
+			i := (lo + hi) / 2
+			buf, err := b.view(&f.src, int(lt.offset+o)+4+entrySize*i, entrySize)
+			if err != nil {
+				return 0, false, err
+			}
+			if start := GlyphIndex(u16(buf)); x < start {
+				hi = i
+			} else if end := GlyphIndex(u16(buf[2:])); x > end {
+				lo = i + 1
+			} else {
+				return int(u16(buf[4:])) + int(x-start), true, nil
+			}
+		}
+		return 0, false, nil
+	}
+	return 0, false, errInvalidBounds
+}
+
+// classDefValue returns the class of x in the ClassDef table at the offset o,
+// relative to the start of the layout table lt. Glyphs that aren't listed are
+// in class 0.
+func (f *Font) classDefValue(b *Buffer, lt layoutTable, o uint32, x GlyphIndex) (uint16, error) {
+	format, err := f.layoutU16(b, lt, o)
+	if err != nil {
+		return 0, err
+	}
+	switch format {
+	case 1:
+		start, err := f.layoutU16(b, lt, o+2)
+		if err != nil {
+			return 0, err
+		}
+		n, err := f.layoutU16(b, lt, o+4)
+		if err != nil {
+			return 0, err
+		}
+		if x < GlyphIndex(start) || int(x-GlyphIndex(start)) >= int(n) {
+			return 0, nil
+		}
+		return f.layoutU16(b, lt, o+6+2*uint32(x-GlyphIndex(start)))
+
+	case 2:
+		n, err := f.layoutU16(b, lt, o+2)
+		if err != nil {
+			return 0, err
+		}
+		const entrySize = 6
+		if uint32(n)*entrySize > lt.length-o-4 {
+			return 0, errInvalidBounds
+		}
+		for lo, hi := 0, int(n); lo < hi; {
+			i := (lo + hi) / 2
+			buf, err := b.view(&f.src, int(lt.offset+o)+4+entrySize*i, entrySize)
+			if err != nil {
+				return 0, err
+			}
+			if x < GlyphIndex(u16(buf)) {
+				hi = i
+			} else if x > GlyphIndex(u16(buf[2:])) {
+				lo = i + 1
+			} else {
+				return u16(buf[4:]), nil
+			}
+		}
+		return 0, nil
+	}
+	return 0, errInvalidBounds
+}
+
+// layoutU32 returns the uint32 at the offset o relative to the start of the
+// layout table lt.
+func (f *Font) layoutU32(b *Buffer, lt layoutTable, o uint32) (uint32, error) {
+	if o > lt.length || lt.length-o < 4 {
+		return 0, errInvalidBounds
+	}
+	buf, err := b.view(&f.src, int(lt.offset+o), 4)
+	if err != nil {
+		return 0, err
+	}
+	return u32(buf), nil
+}
+
+// Script is a script supported by a font's GSUB or GPOS tables, such as
+// "latn" (Latin) or "arab" (Arabic), or the default script, "DFLT".
+//
+// See https://www.microsoft.com/typography/otspec/scripttags.htm
+type Script struct {
+	Tag Tag
+	// LanguageSystems are the script's language systems, sorted by tag. The
+	// default language system, if present, has the tag "dflt".
+	LanguageSystems []LanguageSystem
+}
+
+// LanguageSystem is a language system of a Script, such as "TRK " (Turkish).
+//
+// See https://www.microsoft.com/typography/otspec/languagetags.htm
+type LanguageSystem struct {
+	Tag Tag
+	// Features are the tags of the features enabled for the language system,
+	// sorted and without duplicates.
+	Features []Tag
+}
+
+var tagDefaultLanguageSystem = MustParseTag("dflt")
+
+// Features returns the tags of the features in f's GSUB and GPOS tables,
+// sorted and without duplicates.
+func (f *Font) Features(b *Buffer) ([]Tag, error) {
+	for _, tag := range [...]string{"GSUB", "GPOS"} {
+		if err := f.skippedTable(tag); err != nil {
+			return nil, err
+		}
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	seen := tagSet{}
+	for _, lt := range [...]layoutTable{f.cached.gsub, f.cached.gpos} {
+		if lt.length == 0 {
+			continue
+		}
+		n, err := f.layoutU16(b, lt, lt.featureList)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint16(0); i < n; i++ {
+			tag, err := f.layoutFeatureTag(b, lt, i)
+			if err != nil {
+				return nil, err
+			}
+			seen[tag] = true
+		}
+	}
+	return seen.sorted(), nil
+}
+
+// Scripts returns the scripts in f's GSUB and GPOS tables, sorted by tag. A
+// script or language system listed by both tables is returned once, with the
+// union of the two tables' features.
+func (f *Font) Scripts(b *Buffer) ([]Script, error) {
+	for _, tag := range [...]string{"GSUB", "GPOS"} {
+		if err := f.skippedTable(tag); err != nil {
+			return nil, err
+		}
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	// seen maps script tags to language system tags to feature tags.
+	seen := map[Tag]map[Tag]tagSet{}
+	for _, lt := range [...]layoutTable{f.cached.gsub, f.cached.gpos} {
+		if lt.length == 0 {
+			continue
+		}
+		if err := f.layoutScripts(b, lt, seen); err != nil {
+			return nil, err
+		}
+	}
+	if len(seen) == 0 {
+		return nil, nil
+	}
+
+	scriptTags := tagSet{}
+	for tag := range seen {
+		scriptTags[tag] = true
+	}
+	ret := make([]Script, 0, len(seen))
+	for _, scriptTag := range scriptTags.sorted() {
+		langTags := tagSet{}
+		for tag := range seen[scriptTag] {
+			langTags[tag] = true
+		}
+		s := Script{Tag: scriptTag}
+		for _, langTag := range langTags.sorted() {
+			s.LanguageSystems = append(s.LanguageSystems, LanguageSystem{
+				Tag:      langTag,
+				Features: seen[scriptTag][langTag].sorted(),
+			})
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+// layoutScripts adds the scripts, language systems and features of lt's
+// ScriptList to seen.
+func (f *Font) layoutScripts(b *Buffer, lt layoutTable, seen map[Tag]map[Tag]tagSet) error {
+	numScripts, err := f.layoutU16(b, lt, lt.scriptList)
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < uint32(numScripts); i++ {
+		const recordSize = 6
+		r := lt.scriptList + 2 + recordSize*i
+		scriptTag, err := f.layoutU32(b, lt, r)
+		if err != nil {
+			return err
+		}
+		u, err := f.layoutU16(b, lt, r+4)
+		if err != nil {
+			return err
+		}
+		script := lt.scriptList + uint32(u)
+
+		langs := seen[Tag(scriptTag)]
+		if langs == nil {
+			langs = map[Tag]tagSet{}
+			seen[Tag(scriptTag)] = langs
+		}
+
+		defaultLangSys, err := f.layoutU16(b, lt, script)
+		if err != nil {
+			return err
+		}
+		if defaultLangSys != 0 {
+			if err := f.layoutLangSys(b, lt, script+uint32(defaultLangSys), langs, tagDefaultLanguageSystem); err != nil {
+				return err
+			}
+		}
+		numLangSys, err := f.layoutU16(b, lt, script+2)
+		if err != nil {
+			return err
+		}
+		for j := uint32(0); j < uint32(numLangSys); j++ {
+			r := script + 4 + recordSize*j
+			langTag, err := f.layoutU32(b, lt, r)
+			if err != nil {
+				return err
+			}
+			u, err := f.layoutU16(b, lt, r+4)
+			if err != nil {
+				return err
+			}
+			if err := f.layoutLangSys(b, lt, script+uint32(u), langs, Tag(langTag)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// layoutLangSys adds the features of the LangSys table at the offset o,
+// relative to the start of lt, to langs[tag].
+func (f *Font) layoutLangSys(b *Buffer, lt layoutTable, o uint32, langs map[Tag]tagSet, tag Tag) error {
+	features := langs[tag]
+	if features == nil {
+		features = tagSet{}
+		langs[tag] = features
+	}
+
+	// The LangSys table is a lookupOrder offset (reserved), a
+	// requiredFeatureIndex (0xffff means none), a featureIndexCount and the
+	// featureIndices.
+	required, err := f.layoutU16(b, lt, o+2)
+	if err != nil {
+		return err
+	}
+	if required != 0xffff {
+		ft, err := f.layoutFeatureTag(b, lt, required)
+		if err != nil {
+			return err
+		}
+		features[ft] = true
+	}
+	n, err := f.layoutU16(b, lt, o+4)
+	if err != nil {
+		return err
+	}
+	for k := uint32(0); k < uint32(n); k++ {
+		i, err := f.layoutU16(b, lt, o+6+2*k)
+		if err != nil {
+			return err
+		}
+		ft, err := f.layoutFeatureTag(b, lt, i)
+		if err != nil {
+			return err
+		}
+		features[ft] = true
+	}
+	return nil
+}
+
+// layoutFeatureTag returns the tag of the i'th feature in lt's FeatureList.
+func (f *Font) layoutFeatureTag(b *Buffer, lt layoutTable, i uint16) (Tag, error) {
+	n, err := f.layoutU16(b, lt, lt.featureList)
+	if err != nil {
+		return 0, err
+	}
+	if i >= n {
+		return 0, errInvalidBounds
+	}
+	const recordSize = 6
+	u, err := f.layoutU32(b, lt, lt.featureList+2+recordSize*uint32(i))
+	return Tag(u), err
+}
+
+// tagSet is a set of tags.
+type tagSet map[Tag]bool
+
+// sorted returns the tags in s, sorted.
+func (s tagSet) sorted() []Tag {
+	if len(s) == 0 {
+		return nil
+	}
+	tags := make([]Tag, 0, len(s))
+	for tag := range s {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	return tags
+}
@@ -0,0 +1,670 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// be16 returns the big-endian encoding of vs, each value being a uint16 or an
+// int16.
+func be16(vs ...int) []byte {
+	b := make([]byte, 0, 2*len(vs))
+	for _, v := range vs {
+		b = append(b, byte(v>>8), byte(v))
+	}
+	return b
+}
+
+// testFeature is a FeatureList entry for testLayoutTable.
+type testFeature struct {
+	tag     string
+	lookups []int
+}
+
+// testLookup is a LookupList entry for testLayoutTable.
+type testLookup struct {
+	lookupType int
+	subtable   []byte
+}
+
+// testScript is a ScriptList entry for testLayoutTable. Its language systems
+// map language system tags, or "dflt" for the default language system, to
+// feature indexes.
+type testScript struct {
+	tag   string
+	langs map[string][]int
+}
+
+// testLayoutTable returns a GPOS or GSUB table with the given scripts,
+// features and lookups. Each lookup is a lookup type and one subtable, whose
+// offsets are relative to the start of that subtable.
+func testLayoutTable(scripts []testScript, features []testFeature, lookups []testLookup) []byte {
+	const headerSize = 10
+
+	scriptList := be16(len(scripts))
+	scriptTables := []byte(nil)
+	for _, sc := range scripts {
+		scriptList = append(scriptList, sc.tag...)
+		scriptList = append(scriptList, be16(2+6*len(scripts)+len(scriptTables))...)
+
+		var langTags []string
+		for tag := range sc.langs {
+			if tag != "dflt" {
+				langTags = append(langTags, tag)
+			}
+		}
+		sort.Strings(langTags)
+		langSys := func(features []int) []byte {
+			return append(be16(0, 0xffff, len(features)), be16(features...)...)
+		}
+		script := be16(0, len(langTags))
+		langSysTables := []byte(nil)
+		for _, tag := range langTags {
+			script = append(script, tag...)
+			script = append(script, be16(4+6*len(langTags)+len(langSysTables))...)
+			langSysTables = append(langSysTables, langSys(sc.langs[tag])...)
+		}
+		if features, ok := sc.langs["dflt"]; ok {
+			copy(script, be16(len(script)+len(langSysTables)))
+			langSysTables = append(langSysTables, langSys(features)...)
+		}
+		scriptTables = append(scriptTables, script...)
+		scriptTables = append(scriptTables, langSysTables...)
+	}
+	scriptList = append(scriptList, scriptTables...)
+
+	featureList := be16(len(features))
+	featureTables := []byte(nil)
+	for _, ft := range features {
+		featureList = append(featureList, ft.tag...)
+		featureList = append(featureList, be16(2+6*len(features)+len(featureTables))...)
+		featureTables = append(featureTables, be16(0, len(ft.lookups))...)
+		featureTables = append(featureTables, be16(ft.lookups...)...)
+	}
+	featureList = append(featureList, featureTables...)
+
+	lookupList := be16(len(lookups))
+	lookupTables := []byte(nil)
+	for _, l := range lookups {
+		lookupList = append(lookupList, be16(2+2*len(lookups)+len(lookupTables))...)
+		lookupTables = append(lookupTables, be16(l.lookupType, 0, 1, 8)...)
+		lookupTables = append(lookupTables, l.subtable...)
+		for len(lookupTables)&1 != 0 {
+			lookupTables = append(lookupTables, 0)
+		}
+	}
+	lookupList = append(lookupList, lookupTables...)
+
+	b := be16(1, 0, headerSize, headerSize+len(scriptList), headerSize+len(scriptList)+len(featureList))
+	b = append(b, scriptList...)
+	b = append(b, featureList...)
+	return append(b, lookupList...)
+}
+
+// testGPOSTable returns a GPOS table, for glyfTest.ttf's 5 glyphs, whose
+// "kern" feature has a Format 1 (glyph pairs) lookup and a Format 2 (class
+// pairs) lookup, the latter wrapped in an Extension lookup. The Format 2
+// lookup covers glyph 1, the same as the Format 1 lookup, and so kerning
+// pairs whose first glyph is glyph 1 are adjusted by both lookups. An
+// unrelated "mark" feature's lookup should not affect kerning.
+func testGPOSTable() []byte {
+	pairs := concat(
+		be16(1, 30, 0x0004, 0x0000, 2, 14, 24), // posFormat, coverage, valueFormats, pairSets.
+		be16(2, 2, -50, 3, -70),                // pairSet for glyph 1.
+		be16(1, 1, -30),                        // pairSet for glyph 2.
+		be16(1, 2, 1, 2),                       // coverage.
+	)
+	classes := concat(
+		be16(2, 40, 0x0005, 0x0000, 56, 66, 3, 2), // posFormat, coverage, valueFormats, classDefs, counts.
+		be16(0, 0, 99, -5),                        // class1 = 0.
+		be16(0, 0, 0, -20),                        // class1 = 1.
+		be16(0, 0, 0, 15),                         // class1 = 2.
+		be16(2, 2, 1, 1, 0, 3, 4, 1),              // coverage.
+		be16(1, 3, 2, 1, 2),                       // classDef1.
+		be16(2, 1, 1, 2, 1),                       // classDef2.
+	)
+	extension := append(be16(1, gposLookupTypePair, 0, 8), classes...)
+	unrelated := concat(
+		be16(1, 18, 0x0004, 0x0000, 1, 12),
+		be16(1, 2, -1000),
+		be16(1, 1, 1),
+	)
+	return testLayoutTable([]testScript{
+		{"DFLT", map[string][]int{"dflt": {0, 1}}},
+		{"latn", map[string][]int{"dflt": {0}, "TRK ": {0, 1}}},
+	}, []testFeature{
+		{"kern", []int{1, 0}},
+		{"mark", []int{2}},
+	}, []testLookup{
+		{gposLookupTypePair, pairs},
+		{gposLookupTypeExtension, extension},
+		{gposLookupTypePair, unrelated},
+	})
+}
+
+// concat returns the concatenation of bs.
+func concat(bs ...[]byte) []byte {
+	var ret []byte
+	for _, b := range bs {
+		ret = append(ret, b...)
+	}
+	return ret
+}
+
+func TestGPOSKern(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"GPOS": testGPOSTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		x0, x1 GlyphIndex
+		want   fixed.Int26_6
+	}{
+		{0, 1, 0},
+		{1, 1, -5},
+		{1, 2, -55},
+		{1, 3, -70},
+		{1, 4, 0},
+		{2, 1, -30},
+		{2, 2, 0},
+		{3, 0, 0},
+		{3, 1, -20},
+		{4, 2, 15},
+	}
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	var b Buffer
+	for _, tc := range testCases {
+		got, err := f.Kern(&b, tc.x0, tc.x1, ppem, font.HintingNone)
+		if err != nil {
+			t.Errorf("Kern(%d, %d): %v", tc.x0, tc.x1, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Kern(%d, %d): got %d, want %d", tc.x0, tc.x1, got, tc.want)
+		}
+	}
+}
+
+// testCursiveGPOSTable returns a GPOS table, for glyfTest.ttf's 5 glyphs,
+// whose "curs" feature has two cursive attachment lookups, the second wrapped
+// in an Extension lookup. Both lookups cover glyph 3.
+func testCursiveGPOSTable() []byte {
+	first := concat(
+		be16(1, 18, 3),        // posFormat, coverage, entryExitCount.
+		be16(0, 28, 34, 28),   // entryExitRecords for glyphs 1 and 2.
+		be16(42, 0),           // entryExitRecord for glyph 3.
+		be16(1, 3, 1, 2, 3),   // coverage.
+		be16(1, 100, 200),     // anchor format 1.
+		be16(2, -50, 300, 7),  // anchor format 2.
+		be16(3, 0, -10, 0, 0), // anchor format 3.
+	)
+	second := concat(
+		be16(1, 14, 2),      // posFormat, coverage, entryExitCount.
+		be16(22, 22, 22, 0), // entryExitRecords for glyphs 3 and 4.
+		be16(1, 2, 3, 4),    // coverage.
+		be16(1, 5, 6),       // anchor format 1.
+	)
+	return testLayoutTable([]testScript{
+		{"arab", map[string][]int{"dflt": {0}}},
+	}, []testFeature{
+		{"curs", []int{0, 1}},
+	}, []testLookup{
+		{gposLookupTypeCursive, first},
+		{gposLookupTypeExtension, append(be16(1, gposLookupTypeCursive, 0, 8), second...)},
+	})
+}
+
+func TestGPOSCursive(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"GPOS": testCursiveGPOSTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []CursiveAnchors{
+		{},
+		{Exit: Anchor{100, 200}, HasExit: true},
+		{Entry: Anchor{-50, 300}, Exit: Anchor{100, 200}, HasEntry: true, HasExit: true},
+		{Entry: Anchor{0, -10}, HasEntry: true},
+		{Entry: Anchor{5, 6}, HasEntry: true},
+	}
+	var b Buffer
+	for x, want := range testCases {
+		got, err := f.CursiveAnchors(&b, GlyphIndex(x))
+		if err != nil {
+			t.Errorf("x=%d: %v", x, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("x=%d: got %+v, want %+v", x, got, want)
+		}
+	}
+	if _, err := f.CursiveAnchors(&b, GlyphIndex(len(testCases))); err != ErrNotFound {
+		t.Errorf("out of range: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+// testGSUBTable returns a GSUB table, for glyfTest.ttf's 5 glyphs, with single
+// substitution ("smcp" and "onum"), alternate substitution ("salt") and
+// unsupported ligature substitution ("liga") features. The "onum" lookup is
+// wrapped in an Extension lookup.
+func testGSUBTable() []byte {
+	smcp := concat(
+		be16(1, 6, 1),    // substFormat, coverage, deltaGlyphID.
+		be16(1, 2, 1, 2), // coverage.
+	)
+	onum := concat(
+		be16(2, 8, 1, 4), // substFormat, coverage, substituteGlyphIDs.
+		be16(1, 1, 3),    // coverage.
+	)
+	salt := concat(
+		be16(1, 8, 1, 14), // substFormat, coverage, alternateSets.
+		be16(1, 1, 1),     // coverage.
+		be16(2, 3, 4),     // alternateSet for glyph 1.
+	)
+	liga := be16(1, 6, 0, 1, 0)
+	return testLayoutTable([]testScript{
+		{"latn", map[string][]int{"dflt": {0, 3}, "NLD ": {0, 1, 2}}},
+		{"grek", map[string][]int{"dflt": {2}}},
+	}, []testFeature{
+		{"liga", []int{3}},
+		{"onum", []int{1}},
+		{"salt", []int{2}},
+		{"smcp", []int{0}},
+	}, []testLookup{
+		{gsubLookupTypeSingle, smcp},
+		{gsubLookupTypeExtension, append(be16(1, gsubLookupTypeSingle, 0, 8), onum...)},
+		{gsubLookupTypeAlternate, salt},
+		{4, liga},
+	})
+}
+
+func TestGSUBSubstitution(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"GSUB": testGSUBTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		x        GlyphIndex
+		features string
+		want     GlyphIndex
+	}{
+		{0, "", 0},
+		{1, "", 1},
+		{1, "smcp", 2},
+		{2, "smcp", 3},
+		{3, "smcp", 3},
+		{3, "onum", 4},
+		{1, "smcp onum", 2},
+		{2, "smcp onum", 4},
+		{2, "onum smcp", 4},
+		{1, "salt", 3},
+		{1, "liga", 1},
+		{1, "c2sc", 1},
+	}
+	var b Buffer
+	for _, tc := range testCases {
+		var features []Tag
+		for _, s := range strings.Fields(tc.features) {
+			features = append(features, MustParseTag(s))
+		}
+		got, err := f.SubstituteGlyph(&b, tc.x, features...)
+		if err != nil {
+			t.Errorf("SubstituteGlyph(%d, %q): %v", tc.x, tc.features, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("SubstituteGlyph(%d, %q): got %d, want %d", tc.x, tc.features, got, tc.want)
+		}
+	}
+
+	alternatesTestCases := []struct {
+		x       GlyphIndex
+		feature string
+		want    []GlyphIndex
+	}{
+		{1, "salt", []GlyphIndex{3, 4}},
+		{2, "salt", nil},
+		{1, "smcp", nil},
+		{1, "aalt", nil},
+	}
+	for _, tc := range alternatesTestCases {
+		got, err := f.GlyphAlternates(&b, tc.x, MustParseTag(tc.feature))
+		if err != nil {
+			t.Errorf("GlyphAlternates(%d, %q): %v", tc.x, tc.feature, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("GlyphAlternates(%d, %q): got %v, want %v", tc.x, tc.feature, got, tc.want)
+		}
+	}
+}
+
+// setLookupFlag sets the LookupFlag of the i'th lookup of the GPOS or GSUB
+// table b.
+func setLookupFlag(b []byte, i int, flag int) {
+	lookupList := int(u16(b[8:]))
+	lookup := lookupList + int(u16(b[lookupList+2+2*i:]))
+	copy(b[lookup+2:], be16(flag))
+}
+
+// testGDEFTable returns a GDEF table, for glyfTest.ttf's 5 glyphs, that
+// classifies glyphs 1, 2 and 3 as base glyphs and glyph 4 as a mark.
+func testGDEFTable() []byte {
+	return concat(
+		be16(1, 0, 12, 0, 0, 0),   // version, glyphClassDef, other offsets.
+		be16(1, 1, 4, 1, 1, 1, 3), // glyphClassDef.
+	)
+}
+
+// testContextGSUBTable returns a GSUB table, for glyfTest.ttf's 5 glyphs,
+// whose features' lookups involve more than one glyph:
+//   - "liga" replaces 1 2 3 by 4, and 1 2 by 3,
+//   - "dlig" is like "liga", but ignores marks,
+//   - "ccmp" replaces 4 by 2 2,
+//   - "calt" replaces 2 by 3 between two 1s, using a chained contextual
+//     lookup of Coverage tables, and
+//   - "rclt" replaces 2 by 3 after a 3, using a contextual lookup of
+//     classes.
+func testContextGSUBTable() []byte {
+	ligature := concat(
+		be16(1, 28, 1, 8), // substFormat, coverage, ligatureSets.
+		be16(2, 6, 14),    // ligatureSet for glyph 1.
+		be16(4, 3, 2, 3),  // ligature 1 2 3.
+		be16(3, 2, 2),     // ligature 1 2.
+		be16(1, 1, 1),     // coverage.
+	)
+	multiple := concat(
+		be16(1, 14, 1, 8), // substFormat, coverage, sequences.
+		be16(2, 2, 2),     // sequence for glyph 4.
+		be16(1, 1, 4),     // coverage.
+	)
+	chained := concat(
+		be16(3, 1, 20, 1, 26, 1, 20), // substFormat, backtrack, input and lookahead coverages.
+		be16(1, 0, 3),                // seqLookupRecords.
+		be16(1, 1, 1),                // coverage of glyph 1.
+		be16(1, 1, 2),                // coverage of glyph 2.
+	)
+	single := concat(
+		be16(1, 6, 1), // substFormat, coverage, deltaGlyphID.
+		be16(1, 1, 2), // coverage.
+	)
+	context := concat(
+		be16(2, 28, 34, 3, 0, 0, 14), // substFormat, coverage, classDef, classSeqRuleSets.
+		be16(1, 4),                   // classSeqRuleSet for class 2.
+		be16(2, 1, 1, 1, 3),          // classSeqRule: input classes and seqLookupRecords.
+		be16(1, 1, 3),                // coverage.
+		be16(2, 2, 2, 2, 1, 3, 3, 2), // classDef.
+	)
+	b := testLayoutTable([]testScript{
+		{"DFLT", map[string][]int{"dflt": {0, 1, 2, 3, 4}}},
+	}, []testFeature{
+		{"calt", []int{2}},
+		{"ccmp", []int{1}},
+		{"dlig", []int{5}},
+		{"liga", []int{0}},
+		{"rclt", []int{4}},
+	}, []testLookup{
+		{gsubLookupTypeLigature, ligature},
+		{gsubLookupTypeMultiple, multiple},
+		{gsubLookupTypeChainedContext, chained},
+		{gsubLookupTypeSingle, single},
+		{gsubLookupTypeContext, context},
+		{gsubLookupTypeExtension, append(be16(1, gsubLookupTypeLigature, 0, 8), ligature...)},
+	})
+	setLookupFlag(b, 5, lookupFlagIgnoreMarks)
+	return b
+}
+
+func TestGSUBSubstituteGlyphs(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"GDEF": testGDEFTable(),
+		"GSUB": testContextGSUBTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		features     string
+		glyphs       []GlyphIndex
+		want         []GlyphIndex
+		wantClusters []int
+	}{
+		{"liga", []GlyphIndex{1, 2, 3}, []GlyphIndex{4}, []int{0}},
+		{"liga", []GlyphIndex{1, 2}, []GlyphIndex{3}, []int{0}},
+		{"liga", []GlyphIndex{1, 2, 1, 2, 3, 0}, []GlyphIndex{3, 4, 0}, []int{0, 2, 5}},
+		{"liga", []GlyphIndex{1, 4, 2}, []GlyphIndex{1, 4, 2}, []int{0, 1, 2}},
+		{"dlig", []GlyphIndex{1, 4, 2}, []GlyphIndex{3, 4}, []int{0, 1}},
+		{"ccmp", []GlyphIndex{0, 4, 1}, []GlyphIndex{0, 2, 2, 1}, []int{0, 1, 1, 2}},
+		{"calt", []GlyphIndex{1, 2, 1}, []GlyphIndex{1, 3, 1}, []int{0, 1, 2}},
+		{"calt", []GlyphIndex{0, 2, 1}, []GlyphIndex{0, 2, 1}, []int{0, 1, 2}},
+		{"calt", []GlyphIndex{1, 2, 2}, []GlyphIndex{1, 2, 2}, []int{0, 1, 2}},
+		{"rclt", []GlyphIndex{3, 2}, []GlyphIndex{3, 3}, []int{0, 1}},
+		{"rclt", []GlyphIndex{2, 2}, []GlyphIndex{2, 2}, []int{0, 1}},
+		{"rclt", []GlyphIndex{3, 3}, []GlyphIndex{3, 3}, []int{0, 1}},
+		// The "liga" lookup comes before the "ccmp" one.
+		{"ccmp liga", []GlyphIndex{1, 2, 3}, []GlyphIndex{2, 2}, []int{0, 0}},
+		{"smcp", []GlyphIndex{1, 2, 3}, []GlyphIndex{1, 2, 3}, []int{0, 1, 2}},
+	}
+	var b Buffer
+	for _, tc := range testCases {
+		var features []Tag
+		for _, s := range strings.Fields(tc.features) {
+			features = append(features, MustParseTag(s))
+		}
+		glyphs := make([]LayoutGlyph, len(tc.glyphs))
+		for i, x := range tc.glyphs {
+			glyphs[i] = LayoutGlyph{GlyphIndex: x, Cluster: i}
+		}
+		got, err := f.SubstituteGlyphs(&b, glyphs, features...)
+		if err != nil {
+			t.Errorf("%q %v: %v", tc.features, tc.glyphs, err)
+			continue
+		}
+		want := make([]LayoutGlyph, len(tc.want))
+		for i, x := range tc.want {
+			want[i] = LayoutGlyph{GlyphIndex: x, Cluster: tc.wantClusters[i]}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q %v: got %v, want %v", tc.features, tc.glyphs, got, want)
+		}
+	}
+
+	// A ligature has the smallest Cluster of its components.
+	got, err := f.SubstituteGlyphs(&b, []LayoutGlyph{{1, 5}, {2, 3}}, MustParseTag("liga"))
+	if err != nil {
+		t.Fatalf("SubstituteGlyphs: %v", err)
+	}
+	if want := []LayoutGlyph{{3, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reordered clusters: got %v, want %v", got, want)
+	}
+	if _, err := f.SubstituteGlyphs(&b, []LayoutGlyph{{5, 0}}, MustParseTag("liga")); err != ErrNotFound {
+		t.Errorf("out of range: got %v, want %v", err, ErrNotFound)
+	}
+
+	for x, want := range []GlyphClass{GlyphClassNone, GlyphClassBase, GlyphClassBase, GlyphClassBase, GlyphClassMark} {
+		got, err := f.GlyphClass(&b, GlyphIndex(x))
+		if err != nil || got != want {
+			t.Errorf("GlyphClass(%d): got %v, %v, want %v", x, got, err, want)
+		}
+	}
+}
+
+// testMarkGPOSTable returns a GPOS table, for glyfTest.ttf's 5 glyphs, whose
+// "mark" feature attaches mark glyph 4 to base glyph 1, and whose "mkmk"
+// feature attaches it to mark glyph 3. Base glyph 2 is covered, but has no
+// anchor for the mark's class.
+func testMarkGPOSTable() []byte {
+	markToBase := concat(
+		be16(1, 36, 42, 1, 12, 24), // posFormat, coverages, markClassCount, arrays.
+		be16(1, 0, 6),              // markArray.
+		be16(1, 10, 20),            // mark anchor.
+		be16(2, 6, 0),              // baseArray.
+		be16(1, 300, 400),          // base anchor.
+		be16(1, 1, 4),              // mark coverage.
+		be16(1, 2, 1, 2),           // base coverage.
+	)
+	markToMark := concat(
+		be16(1, 34, 40, 1, 12, 24), // posFormat, coverages, markClassCount, arrays.
+		be16(1, 0, 6),              // mark1Array.
+		be16(1, -5, 7),             // mark1 anchor.
+		be16(1, 4),                 // mark2Array.
+		be16(1, 50, 60),            // mark2 anchor.
+		be16(1, 1, 4),              // mark1 coverage.
+		be16(1, 1, 3),              // mark2 coverage.
+	)
+	return testLayoutTable([]testScript{
+		{"DFLT", map[string][]int{"dflt": {0, 1}}},
+	}, []testFeature{
+		{"mark", []int{0}},
+		{"mkmk", []int{1}},
+	}, []testLookup{
+		{gposLookupTypeMarkToBase, markToBase},
+		{gposLookupTypeExtension, append(be16(1, gposLookupTypeMarkToMark, 0, 8), markToMark...)},
+	})
+}
+
+func TestGPOSMarkAnchors(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"GPOS": testMarkGPOSTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		base, mark GlyphIndex
+		toMark     bool
+		want       MarkAnchors
+		wantOK     bool
+	}{
+		{1, 4, false, MarkAnchors{Base: Anchor{300, 400}, Mark: Anchor{10, 20}}, true},
+		{2, 4, false, MarkAnchors{}, false},
+		{3, 4, false, MarkAnchors{}, false},
+		{1, 3, false, MarkAnchors{}, false},
+		{3, 4, true, MarkAnchors{Base: Anchor{50, 60}, Mark: Anchor{-5, 7}}, true},
+		{1, 4, true, MarkAnchors{}, false},
+	}
+	var b Buffer
+	for _, tc := range testCases {
+		got, ok, err := f.MarkAnchors(&b, tc.base, tc.mark, tc.toMark)
+		if err != nil {
+			t.Errorf("MarkAnchors(%d, %d, %t): %v", tc.base, tc.mark, tc.toMark, err)
+			continue
+		}
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("MarkAnchors(%d, %d, %t): got %+v, %t, want %+v, %t",
+				tc.base, tc.mark, tc.toMark, got, ok, tc.want, tc.wantOK)
+		}
+	}
+	if _, _, err := f.MarkAnchors(&b, 1, 5, false); err != ErrNotFound {
+		t.Errorf("out of range: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestFeaturesAndScripts(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"GPOS": testGPOSTable(),
+		"GSUB": testGSUBTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tags := func(s string) []Tag {
+		var ret []Tag
+		for _, x := range strings.Split(s, ",") {
+			ret = append(ret, MustParseTag(x))
+		}
+		return ret
+	}
+
+	gotFeatures, err := f.Features(nil)
+	if err != nil {
+		t.Fatalf("Features: %v", err)
+	}
+	wantFeatures := tags("kern,liga,mark,onum,salt,smcp")
+	if !reflect.DeepEqual(gotFeatures, wantFeatures) {
+		t.Errorf("Features:\ngot  %v\nwant %v", gotFeatures, wantFeatures)
+	}
+
+	gotScripts, err := f.Scripts(nil)
+	if err != nil {
+		t.Fatalf("Scripts: %v", err)
+	}
+	wantScripts := []Script{{
+		Tag: MustParseTag("DFLT"),
+		LanguageSystems: []LanguageSystem{
+			{MustParseTag("dflt"), tags("kern,mark")},
+		},
+	}, {
+		Tag: MustParseTag("grek"),
+		LanguageSystems: []LanguageSystem{
+			{MustParseTag("dflt"), tags("salt")},
+		},
+	}, {
+		Tag: MustParseTag("latn"),
+		LanguageSystems: []LanguageSystem{
+			{MustParseTag("NLD "), tags("liga,onum,salt")},
+			{MustParseTag("TRK "), tags("kern,mark")},
+			{MustParseTag("dflt"), tags("kern,liga,smcp")},
+		},
+	}}
+	if !reflect.DeepEqual(gotScripts, wantScripts) {
+		t.Errorf("Scripts:\ngot  %v\nwant %v", gotScripts, wantScripts)
+	}
+
+	f, err = Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := f.Features(nil); got != nil || err != nil {
+		t.Errorf("Features (no layout tables): got %v, %v, want nil, nil", got, err)
+	}
+	if got, err := f.Scripts(nil); got != nil || err != nil {
+		t.Errorf("Scripts (no layout tables): got %v, %v, want nil, nil", got, err)
+	}
+}
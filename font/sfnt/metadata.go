@@ -0,0 +1,181 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io"
+
+	"golang.org/x/image/font"
+)
+
+// Metadata is descriptive information about a font, such as its names, weight
+// and basic metrics, as returned by ParseMetadata and Collection.Metadata.
+//
+// Its methods are safe to call concurrently, as per the Font methods.
+type Metadata struct {
+	// UnitsPerEm is the number of units per em, from the head table.
+	UnitsPerEm Units
+	// PostScript is whether the font has PostScript (CFF) outlines, as
+	// opposed to TrueType outlines.
+	PostScript bool
+
+	// Ascent, Descent and LineGap are the font's vertical metrics, from the
+	// hhea table. Descent is typically negative.
+	Ascent  Units
+	Descent Units
+	LineGap Units
+
+	// Weight, Style and Stretch come from the OS/2 table if present, or from
+	// the head table's macStyle bits otherwise.
+	Weight  font.Weight
+	Style   font.Style
+	Stretch font.Stretch
+
+	f Font
+}
+
+// Name returns the name value keyed by the given NameID.
+//
+// It returns ErrNotFound if there is no value for that key.
+func (m *Metadata) Name(b *Buffer, id NameID) (string, error) {
+	return m.f.Name(b, id)
+}
+
+// NameInLanguage returns the name value keyed by the given NameID in the given
+// BCP 47 language, as per Font.NameInLanguage.
+func (m *Metadata) NameInLanguage(b *Buffer, id NameID, lang string) (string, error) {
+	return m.f.NameInLanguage(b, id, lang)
+}
+
+// ParseMetadata parses the metadata of an SFNT font from a []byte data
+// source.
+//
+// It is much cheaper than Parse, as it only reads the table directory and the
+// head, hhea, name and OS/2 tables. It does not check that the rest of the font is
+// valid, so a nil error does not imply that Parse will succeed.
+//
+// As for Parse, the data may also be a WOFF 1.0 or WOFF 2.0 web font.
+func ParseMetadata(src []byte) (*Metadata, error) {
+	return parseMetadata(source{b: src}, 0)
+}
+
+// ParseMetadataReaderAt parses the metadata of an SFNT font from an
+// io.ReaderAt data source, as per ParseMetadata. Only the data needed is read.
+func ParseMetadataReaderAt(src io.ReaderAt) (*Metadata, error) {
+	size := int64(-1)
+	if s, ok := src.(interface{ Size() int64 }); ok {
+		size = s.Size()
+	}
+	return parseMetadata(source{r: src, size: size}, 0)
+}
+
+func parseMetadata(src source, offset int) (*Metadata, error) {
+	m := &Metadata{f: Font{src: src}}
+	f := &m.f
+	if !f.src.valid() {
+		return nil, errInvalidSourceData
+	}
+	if err := f.unwrap(); err != nil {
+		return nil, err
+	}
+	buf, err := f.initializeTables(offset, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.parseHead(buf); err != nil {
+		return nil, err
+	}
+	m.UnitsPerEm = f.cached.unitsPerEm
+	m.PostScript = f.cached.isPostScript
+	if err := m.parseMetrics(); err != nil {
+		return nil, err
+	}
+	if err := m.parseStyle(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseMetrics sets m's Ascent, Descent and LineGap.
+func (m *Metadata) parseMetrics() error {
+	// https://www.microsoft.com/typography/otspec/hhea.htm
+	f := &m.f
+	if f.hhea.length != 36 {
+		return errInvalidHheaTable
+	}
+	buf, err := f.src.view(nil, int(f.hhea.offset)+4, 6)
+	if err != nil {
+		return err
+	}
+	m.Ascent = Units(int16(u16(buf[0:])))
+	m.Descent = Units(int16(u16(buf[2:])))
+	m.LineGap = Units(int16(u16(buf[4:])))
+	return nil
+}
+
+// parseStyle sets m's Weight, Style and Stretch.
+func (m *Metadata) parseStyle() error {
+	// https://www.microsoft.com/typography/otspec/os2.htm
+	f := &m.f
+	if f.os2.length == 0 {
+		return m.parseMacStyle()
+	}
+	if f.os2.length < 64 {
+		return errInvalidOS2Table
+	}
+	buf, err := f.src.view(nil, int(f.os2.offset), 64)
+	if err != nil {
+		return err
+	}
+
+	// usWeightClass is 100 for Thin through to 900 for Black. Round to the
+	// nearest hundred.
+	if w := int(u16(buf[4:])); w != 0 {
+		m.Weight = font.Weight((w+50)/100 - 4)
+		if m.Weight < font.WeightThin {
+			m.Weight = font.WeightThin
+		} else if m.Weight > font.WeightBlack {
+			m.Weight = font.WeightBlack
+		}
+	}
+
+	// usWidthClass is 1 for UltraCondensed through to 9 for UltraExpanded.
+	if w := int(u16(buf[6:])); 1 <= w && w <= 9 {
+		m.Stretch = font.Stretch(w - 5)
+	}
+
+	// fsSelection's bit 0 means italic and bit 9 means oblique. Oblique fonts
+	// may also set the italic bit.
+	switch fsSelection := u16(buf[62:]); {
+	case fsSelection&(1<<9) != 0:
+		m.Style = font.StyleOblique
+	case fsSelection&(1<<0) != 0:
+		m.Style = font.StyleItalic
+	}
+	return nil
+}
+
+// parseMacStyle sets m's Weight, Style and Stretch from the head table's
+// macStyle field, for fonts without an OS/2 table.
+func (m *Metadata) parseMacStyle() error {
+	// https://www.microsoft.com/typography/otspec/head.htm
+	u, err := m.f.src.u16(nil, m.f.head, 44)
+	if err != nil {
+		return err
+	}
+	s := MacStyle(u)
+	if s&MacStyleBold != 0 {
+		m.Weight = font.WeightBold
+	}
+	if s&MacStyleItalic != 0 {
+		m.Style = font.StyleItalic
+	}
+	if s&MacStyleCondensed != 0 {
+		m.Stretch = font.StretchCondensed
+	} else if s&MacStyleExtended != 0 {
+		m.Stretch = font.StretchExpanded
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestParseMetadata(t *testing.T) {
+	cffTest, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name       string
+		data       []byte
+		family     string
+		postScript bool
+		weight     font.Weight
+		style      font.Style
+	}{
+		{"goregular", goregular.TTF, "Go", false, font.WeightNormal, font.StyleNormal},
+		// Go Bold's OS/2 usWeightClass is 600, not 700.
+		{"gobold", gobold.TTF, "Go", false, font.WeightSemiBold, font.StyleNormal},
+		{"goitalic", goitalic.TTF, "Go", false, font.WeightNormal, font.StyleItalic},
+		{"CFFTest.otf", cffTest, "CFFTest", true, font.WeightNormal, font.StyleItalic},
+	}
+
+	for _, tc := range testCases {
+		f, err := Parse(tc.data)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", tc.name, err)
+			continue
+		}
+
+		r := &countingReaderAt{r: bytes.NewReader(tc.data)}
+		m, err := ParseMetadataReaderAt(r)
+		if err != nil {
+			t.Errorf("%s: ParseMetadataReaderAt: %v", tc.name, err)
+			continue
+		}
+		if family, err := m.Name(nil, NameIDFamily); err != nil || family != tc.family {
+			t.Errorf("%s: family: got %q, %v, want %q, nil", tc.name, family, err, tc.family)
+		}
+		// Only the table directory and the head, hhea, name and OS/2 tables
+		// should be read.
+		limit := 12 + 16*int(u16(tc.data[4:])) + int(f.head.length+f.hhea.length+f.name.length+f.os2.length)
+		if r.n > limit {
+			t.Errorf("%s: bytes read: got %d, want <= %d", tc.name, r.n, limit)
+		}
+
+		m, err = ParseMetadata(tc.data)
+		if err != nil {
+			t.Errorf("%s: ParseMetadata: %v", tc.name, err)
+			continue
+		}
+		if got, want := m.UnitsPerEm, f.UnitsPerEm(); got != want {
+			t.Errorf("%s: UnitsPerEm: got %d, want %d", tc.name, got, want)
+		}
+		if m.PostScript != tc.postScript {
+			t.Errorf("%s: PostScript: got %t, want %t", tc.name, m.PostScript, tc.postScript)
+		}
+		if m.Weight != tc.weight {
+			t.Errorf("%s: Weight: got %d, want %d", tc.name, m.Weight, tc.weight)
+		}
+		if m.Style != tc.style {
+			t.Errorf("%s: Style: got %d, want %d", tc.name, m.Style, tc.style)
+		}
+		if m.Stretch != font.StretchNormal {
+			t.Errorf("%s: Stretch: got %d, want %d", tc.name, m.Stretch, font.StretchNormal)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// psidMacintoshRussian is the Platform Specific ID for the Mac OS Cyrillic
+// encoding. It is only used by name records, not by cmap subtables.
+const psidMacintoshRussian = 7
+
+// NameRecord is a record of the name table.
+type NameRecord struct {
+	// PlatformID, EncodingID and LanguageID are the record's Platform ID,
+	// Platform Specific ID and Language ID, as per
+	// https://www.microsoft.com/typography/otspec/name.htm
+	PlatformID uint16
+	EncodingID uint16
+	LanguageID uint16
+	// Language is the BCP 47 language tag for LanguageID, such as "en-US" or
+	// "zh-Hant". It is empty if the language is not known.
+	Language string
+	// NameID is the key of the record.
+	NameID NameID
+	// Value is the decoded value of the record. It is empty if the record's
+	// platform and encoding is not supported.
+	Value string
+}
+
+// NameRecords returns all of the name table's records, in the font's order.
+//
+// The Unicode and Windows platforms' UTF-16 encodings are supported, as are the
+// Macintosh platform's Roman, Cyrillic, Japanese, Korean and Chinese
+// encodings.
+func (f *Font) NameRecords(b *Buffer) ([]NameRecord, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	const headerSize, entrySize = 6, 12
+	if f.name.length < headerSize {
+		return nil, errInvalidNameTable
+	}
+	buf, err := b.view(&f.src, int(f.name.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	format := u16(buf)
+	numSubtables := u16(buf[2:])
+	stringOffset := u16(buf[4:])
+	recordsEnd := headerSize + entrySize*uint32(numSubtables)
+	if f.name.length < recordsEnd {
+		return nil, errInvalidNameTable
+	}
+
+	// A version 1 name table has language-tag records, after the name
+	// records, for Language IDs of 0x8000 and above.
+	var langTags []string
+	if format == 1 {
+		if f.name.length < recordsEnd+2 {
+			return nil, errInvalidNameTable
+		}
+		buf, err = b.view(&f.src, int(f.name.offset+recordsEnd), 2)
+		if err != nil {
+			return nil, err
+		}
+		langTags = make([]string, u16(buf))
+		if f.name.length < recordsEnd+2+4*uint32(len(langTags)) {
+			return nil, errInvalidNameTable
+		}
+		for i := range langTags {
+			buf, err = b.view(&f.src, int(f.name.offset+recordsEnd+2+4*uint32(i)), 4)
+			if err != nil {
+				return nil, err
+			}
+			tagLength, tagOffset := u16(buf), u16(buf[2:])
+			buf, err = b.view(&f.src, int(f.name.offset)+int(stringOffset)+int(tagOffset), int(tagLength))
+			if err != nil {
+				return nil, err
+			}
+			if langTags[i], err = stringifyUTF16(buf); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	records := make([]NameRecord, numSubtables)
+	for i := range records {
+		buf, err := b.view(&f.src, int(f.name.offset)+headerSize+entrySize*i, entrySize)
+		if err != nil {
+			return nil, err
+		}
+		r := &records[i]
+		r.PlatformID = u16(buf[0:])
+		r.EncodingID = u16(buf[2:])
+		r.LanguageID = u16(buf[4:])
+		r.NameID = NameID(u16(buf[6:]))
+		r.Language = nameLanguage(r.PlatformID, r.LanguageID, langTags)
+
+		nameLength := u16(buf[8:])
+		nameOffset := u16(buf[10:])
+		buf, err = b.view(&f.src, int(f.name.offset)+int(nameOffset)+int(stringOffset), int(nameLength))
+		if err != nil {
+			return nil, err
+		}
+		if r.Value, err = decodeName(r.PlatformID, r.EncodingID, buf); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// NameInLanguage returns the name value keyed by the given NameID in the given
+// BCP 47 language, such as "ja" or "zh-Hant".
+//
+// A record whose language tag matches lang exactly, ignoring case, is
+// preferred to one that matches only lang's primary language subtag. For
+// example, asking for "fr-CA" can return a "fr-FR" record if there is no
+// "fr-CA" record.
+//
+// It returns ErrNotFound if there is no non-empty value for that key and
+// language. Callers that want any language can fall back to Font.Name.
+func (f *Font) NameInLanguage(b *Buffer, id NameID, lang string) (string, error) {
+	records, err := f.NameRecords(b)
+	if err != nil {
+		return "", err
+	}
+	lang = strings.ToLower(lang)
+	primary := primaryLanguage(lang)
+	if primary == "" {
+		return "", ErrNotFound
+	}
+
+	best, bestRank := "", 0
+	for _, r := range records {
+		if r.NameID != id || r.Value == "" || r.Language == "" {
+			continue
+		}
+		rank := 0
+		if l := strings.ToLower(r.Language); l == lang {
+			rank = 4
+		} else if primaryLanguage(l) == primary {
+			rank = 2
+		} else {
+			continue
+		}
+		// Prefer Unicode-based records to Macintosh ones.
+		if r.PlatformID != pidMacintosh {
+			rank++
+		}
+		if rank > bestRank {
+			best, bestRank = r.Value, rank
+		}
+	}
+	if bestRank == 0 {
+		return "", ErrNotFound
+	}
+	return best, nil
+}
+
+func primaryLanguage(tag string) string {
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+// decodeName decodes a name record's value, returning "" if the platform and
+// encoding is not supported.
+func decodeName(pid, psid uint16, b []byte) (string, error) {
+	var enc encoding.Encoding
+	switch pid {
+	case pidUnicode:
+		return stringifyUTF16(b)
+
+	case pidMacintosh:
+		switch psid {
+		case psidMacintoshRoman:
+			return stringifyMacintosh(b)
+		case psidMacintoshRussian:
+			enc = charmap.MacintoshCyrillic
+		default:
+			enc = legacyCmapEncoding(pid, psid)
+		}
+
+	case pidWindows:
+		switch psid {
+		case psidWindowsSymbol, psidWindowsUCS2, psidWindowsUCS4:
+			return stringifyUTF16(b)
+		}
+	}
+	if enc == nil {
+		return "", nil
+	}
+	s, err := enc.NewDecoder().Bytes(b)
+	if err != nil {
+		return "", nil
+	}
+	return string(s), nil
+}
+
+// stringifyUTF16 is like stringifyUCS2 but also decodes surrogate pairs.
+func stringifyUTF16(b []byte) (string, error) {
+	if len(b)&1 != 0 {
+		return "", errInvalidUCS2String
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = u16(b[2*i:])
+	}
+	return string(utf16.Decode(u)), nil
+}
+
+// nameLanguage returns the BCP 47 language tag for a name record's Language
+// ID, or "" if it is not known.
+func nameLanguage(pid, lid uint16, langTags []string) string {
+	if lid >= 0x8000 {
+		if i := int(lid - 0x8000); i < len(langTags) {
+			return langTags[i]
+		}
+		return ""
+	}
+	switch pid {
+	case pidMacintosh:
+		if int(lid) < len(macintoshLanguages) {
+			return macintoshLanguages[lid]
+		}
+	case pidWindows:
+		if s, ok := windowsLanguages[lid]; ok {
+			return s
+		}
+		// Fall back to the primary language, which is the low 10 bits of
+		// the Language ID, and its default sublanguage.
+		if s, ok := windowsLanguages[0x0400|lid&0x03ff]; ok {
+			return primaryLanguage(s)
+		}
+	}
+	return ""
+}
+
+// macintoshLanguages are the Macintosh platform's Language IDs, as per
+// https://www.microsoft.com/typography/otspec/name.htm
+var macintoshLanguages = [...]string{
+	0:   "en",
+	1:   "fr",
+	2:   "de",
+	3:   "it",
+	4:   "nl",
+	5:   "sv",
+	6:   "es",
+	7:   "da",
+	8:   "pt",
+	9:   "no",
+	10:  "he",
+	11:  "ja",
+	12:  "ar",
+	13:  "fi",
+	14:  "el",
+	15:  "is",
+	16:  "mt",
+	17:  "tr",
+	18:  "hr",
+	19:  "zh-Hant",
+	20:  "ur",
+	21:  "hi",
+	22:  "th",
+	23:  "ko",
+	24:  "lt",
+	25:  "pl",
+	26:  "hu",
+	27:  "et",
+	28:  "lv",
+	29:  "se",
+	30:  "fo",
+	31:  "fa",
+	32:  "ru",
+	33:  "zh-Hans",
+	34:  "nl-BE",
+	35:  "ga",
+	36:  "sq",
+	37:  "ro",
+	38:  "cs",
+	39:  "sk",
+	40:  "sl",
+	41:  "yi",
+	42:  "sr",
+	43:  "mk",
+	44:  "bg",
+	45:  "uk",
+	46:  "be",
+	47:  "uz",
+	48:  "kk",
+	49:  "az-Cyrl",
+	50:  "az-Arab",
+	51:  "hy",
+	52:  "ka",
+	53:  "mo",
+	54:  "ky",
+	55:  "tg",
+	56:  "tk",
+	57:  "mn-Mong",
+	58:  "mn-Cyrl",
+	59:  "ps",
+	60:  "ku",
+	61:  "ks",
+	62:  "sd",
+	63:  "bo",
+	64:  "ne",
+	65:  "sa",
+	66:  "mr",
+	67:  "bn",
+	68:  "as",
+	69:  "gu",
+	70:  "pa",
+	71:  "or",
+	72:  "ml",
+	73:  "kn",
+	74:  "ta",
+	75:  "te",
+	76:  "si",
+	77:  "my",
+	78:  "km",
+	79:  "lo",
+	80:  "vi",
+	81:  "id",
+	82:  "tl",
+	83:  "ms",
+	84:  "ms-Arab",
+	85:  "am",
+	86:  "ti",
+	87:  "om",
+	88:  "so",
+	89:  "sw",
+	90:  "rw",
+	91:  "rn",
+	92:  "ny",
+	93:  "mg",
+	94:  "eo",
+	128: "cy",
+	129: "eu",
+	130: "ca",
+	131: "la",
+	132: "qu",
+	133: "gn",
+	134: "ay",
+	135: "tt",
+	136: "ug",
+	137: "dz",
+	138: "jv",
+	139: "su",
+	140: "gl",
+	141: "af",
+	142: "br",
+	143: "iu",
+	144: "gd",
+	145: "gv",
+	146: "ga",
+	147: "to",
+	148: "el-polyton",
+	149: "kl",
+	150: "az",
+}
+
+// windowsLanguages are the Windows platform's Language IDs, as per
+// https://www.microsoft.com/typography/otspec/name.htm
+//
+// Only commonly used Language IDs are listed. Every primary language listed
+// has its 0x04xx Language ID, its default sublanguage, listed.
+var windowsLanguages = map[uint16]string{
+	0x0401: "ar-SA",
+	0x0402: "bg-BG",
+	0x0403: "ca-ES",
+	0x0404: "zh-TW",
+	0x0405: "cs-CZ",
+	0x0406: "da-DK",
+	0x0407: "de-DE",
+	0x0408: "el-GR",
+	0x0409: "en-US",
+	0x040a: "es-ES",
+	0x040b: "fi-FI",
+	0x040c: "fr-FR",
+	0x040d: "he-IL",
+	0x040e: "hu-HU",
+	0x040f: "is-IS",
+	0x0410: "it-IT",
+	0x0411: "ja-JP",
+	0x0412: "ko-KR",
+	0x0413: "nl-NL",
+	0x0414: "nb-NO",
+	0x0415: "pl-PL",
+	0x0416: "pt-BR",
+	0x0418: "ro-RO",
+	0x0419: "ru-RU",
+	0x041a: "hr-HR",
+	0x041b: "sk-SK",
+	0x041c: "sq-AL",
+	0x041d: "sv-SE",
+	0x041e: "th-TH",
+	0x041f: "tr-TR",
+	0x0420: "ur-PK",
+	0x0421: "id-ID",
+	0x0422: "uk-UA",
+	0x0423: "be-BY",
+	0x0424: "sl-SI",
+	0x0425: "et-EE",
+	0x0426: "lv-LV",
+	0x0427: "lt-LT",
+	0x0429: "fa-IR",
+	0x042a: "vi-VN",
+	0x042b: "hy-AM",
+	0x042d: "eu-ES",
+	0x042f: "mk-MK",
+	0x0436: "af-ZA",
+	0x0437: "ka-GE",
+	0x0438: "fo-FO",
+	0x0439: "hi-IN",
+	0x043a: "mt-MT",
+	0x043e: "ms-MY",
+	0x043f: "kk-KZ",
+	0x0441: "sw-KE",
+	0x0445: "bn-IN",
+	0x0446: "pa-IN",
+	0x0447: "gu-IN",
+	0x0449: "ta-IN",
+	0x044a: "te-IN",
+	0x044b: "kn-IN",
+	0x044c: "ml-IN",
+	0x044e: "mr-IN",
+	0x0450: "mn-MN",
+	0x0452: "cy-GB",
+	0x0453: "km-KH",
+	0x0454: "lo-LA",
+	0x0456: "gl-ES",
+	0x045b: "si-LK",
+	0x0461: "ne-NP",
+	0x0462: "fy-NL",
+	0x046e: "lb-LU",
+	0x0804: "zh-CN",
+	0x0807: "de-CH",
+	0x0809: "en-GB",
+	0x080a: "es-MX",
+	0x080c: "fr-BE",
+	0x0810: "it-CH",
+	0x0813: "nl-BE",
+	0x0814: "nn-NO",
+	0x0816: "pt-PT",
+	0x081a: "sr-Latn-CS",
+	0x083c: "ga-IE",
+	0x0c04: "zh-HK",
+	0x0c07: "de-AT",
+	0x0c09: "en-AU",
+	0x0c0a: "es-ES",
+	0x0c0c: "fr-CA",
+	0x0c1a: "sr-Cyrl-CS",
+	0x1004: "zh-SG",
+	0x1009: "en-CA",
+	0x100c: "fr-CH",
+	0x1404: "zh-MO",
+	0x1409: "en-NZ",
+	0x1809: "en-IE",
+	0x4009: "en-IN",
+}
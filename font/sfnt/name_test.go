@@ -0,0 +1,129 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"testing"
+	"unicode/utf16"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func utf16BE(s string) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = appendU16(b, u)
+	}
+	return b
+}
+
+func TestNameRecords(t *testing.T) {
+	want := []NameRecord{
+		{pidMacintosh, psidMacintoshRoman, 0, "en", NameIDFamily, "Café"},
+		{pidMacintosh, psidMacintoshJapanese, 11, "ja", NameIDFamily, "ゴ"},
+		{pidMacintosh, psidMacintoshRussian, 32, "ru", NameIDFamily, "Го"},
+		{pidWindows, psidWindowsUCS2, 0x040c, "fr-FR", NameIDFamily, "Famille"},
+		{pidWindows, psidWindowsUCS2, 0x0411, "ja-JP", NameIDFamily, "ゴー"},
+		{pidWindows, psidWindowsUCS2, 0x2c01, "ar", NameIDFamily, "ع"},
+		{pidWindows, psidWindowsUCS2, 0x0409, "en-US", NameIDSubfamily, "\U0001f600"},
+		{pidWindows, psidWindowsUCS2, 0x8000, "de-CH", NameIDFamily, "Schrift"},
+		{pidWindows, psidWindowsShiftJIS, 0x0411, "ja-JP", NameIDFamily, ""},
+	}
+	values := [][]byte{
+		{'C', 'a', 'f', 0x8e},
+		{0x83, 0x53},
+		{0x83, 0xee},
+		utf16BE("Famille"),
+		utf16BE("ゴー"),
+		utf16BE("ع"),
+		utf16BE("\U0001f600"),
+		utf16BE("Schrift"),
+		{0x83, 0x53},
+	}
+	data := testNameTable(nil, testNameEntries{
+		records:  want,
+		values:   values,
+		langTags: []string{"de-CH"},
+	})
+	f := &Font{
+		src:  source{b: data},
+		name: table{0, uint32(len(data))},
+	}
+
+	got, err := f.NameRecords(nil)
+	if err != nil {
+		t.Fatalf("NameRecords: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NameRecords: got %d records, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("record #%d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	testCases := []struct {
+		id   NameID
+		lang string
+		want string
+	}{
+		{NameIDFamily, "fr-FR", "Famille"},
+		{NameIDFamily, "FR-ca", "Famille"},
+		{NameIDFamily, "ja", "ゴ"},
+		{NameIDFamily, "ja-JP", "ゴー"},
+		{NameIDFamily, "ru", "Го"},
+		{NameIDFamily, "ar-JO", "ع"},
+		{NameIDFamily, "de", "Schrift"},
+		{NameIDFamily, "en", "Café"},
+		{NameIDFamily, "es", ""},
+		{NameIDFamily, "", ""},
+		{NameIDSubfamily, "en-GB", "\U0001f600"},
+		{NameIDSubfamily, "fr", ""},
+	}
+	for _, tc := range testCases {
+		got, err := f.NameInLanguage(nil, tc.id, tc.lang)
+		if tc.want == "" {
+			if err != ErrNotFound {
+				t.Errorf("id=%d, lang=%q: got %q, %v, want ErrNotFound", tc.id, tc.lang, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("id=%d, lang=%q: %v", tc.id, tc.lang, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("id=%d, lang=%q: got %q, want %q", tc.id, tc.lang, got, tc.want)
+		}
+	}
+}
+
+func TestGoRegularNameRecords(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	records, err := f.NameRecords(nil)
+	if err != nil {
+		t.Fatalf("NameRecords: %v", err)
+	}
+	languages := map[string]bool{}
+	for _, r := range records {
+		languages[r.Language] = true
+		if r.NameID != NameIDFull {
+			continue
+		}
+		if r.Value != "Go Regular" {
+			t.Errorf("platform %d: full name: got %q, want %q", r.PlatformID, r.Value, "Go Regular")
+		}
+	}
+	if !languages["en"] || !languages["en-US"] || len(languages) != 2 {
+		t.Errorf("languages: got %v, want en and en-US", languages)
+	}
+	if got, err := f.NameInLanguage(nil, NameIDFamily, "en-US"); err != nil || got != "Go" {
+		t.Errorf("NameInLanguage: got %q, %v, want %q", got, err, "Go")
+	}
+}
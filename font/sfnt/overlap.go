@@ -0,0 +1,367 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements overlap removal: replacing a glyph's contours by the
+// outline of their union, under the non-zero winding rule.
+//
+// The curves are first flattened to lines. Those lines are then split at
+// their intersections, so that no two of them cross, and each resultant line
+// is kept if it is on the boundary of the filled region: if the winding
+// number is non-zero on exactly one side of it. Finally, the kept lines are
+// linked up into contours.
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// overlapFlatness is the maximum distance, in 26.6 fixed point units, between
+// a curve and the lines that approximate it.
+const overlapFlatness = 4
+
+// maxOverlapSubdivisions is the maximum number of lines that a curve is
+// flattened to.
+const maxOverlapSubdivisions = 64
+
+// overlapEdge is a line from p to q.
+type overlapEdge struct {
+	p, q fixed.Point26_6
+}
+
+// removeOverlaps returns the segments of the union of the closed contours of
+// the given segments. The result consists only of lines, with the filled
+// region to the right of each contour's direction of travel, as for
+// TrueType's clockwise outer contours.
+func removeOverlaps(segments []Segment) []Segment {
+	edges := flattenSegments(nil, segments)
+	pieces := splitEdges(edges)
+
+	// Keep the pieces on the boundary of the filled region, oriented so that
+	// the filled region is on their right. The winding numbers are those of
+	// the pieces, not the edges, as rounding the intersection points can move
+	// the pieces slightly.
+	seen := map[[2]fixed.Point26_6]bool{}
+	var kept []overlapEdge
+	for _, e := range pieces {
+		key := [2]fixed.Point26_6{e.p, e.q}
+		if lessPoint(e.q, e.p) {
+			key = [2]fixed.Point26_6{e.q, e.p}
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		dx, dy := float64(e.q.X-e.p.X), float64(e.q.Y-e.p.Y)
+		d := math.Hypot(dx, dy)
+		// (nx, ny) is a short vector to the left of the edge, in the y-up
+		// coordinate system.
+		nx, ny := -dy/d/64, dx/d/64
+		mx, my := float64(e.p.X+e.q.X)/2, float64(e.p.Y+e.q.Y)/2
+		left := windingNumber(pieces, mx+nx, my+ny) != 0
+		right := windingNumber(pieces, mx-nx, my-ny) != 0
+		if left == right {
+			continue
+		}
+		if left {
+			e.p, e.q = e.q, e.p
+		}
+		kept = append(kept, e)
+	}
+	return linkEdges(kept)
+}
+
+// flattenSegments appends to dst the lines that approximate the segments. Each
+// contour is implicitly closed.
+func flattenSegments(dst []overlapEdge, segments []Segment) []overlapEdge {
+	var start, cur fixed.Point26_6
+	lineTo := func(p fixed.Point26_6) {
+		if p != cur {
+			dst = append(dst, overlapEdge{cur, p})
+			cur = p
+		}
+	}
+	for _, s := range segments {
+		switch s.Op {
+		case SegmentOpMoveTo:
+			lineTo(start)
+			start = fixed.Point26_6{X: s.Args[0], Y: s.Args[1]}
+			cur = start
+		case SegmentOpLineTo:
+			lineTo(fixed.Point26_6{X: s.Args[0], Y: s.Args[1]})
+		case SegmentOpQuadTo:
+			p0 := cur
+			p1 := fixed.Point26_6{X: s.Args[0], Y: s.Args[1]}
+			p2 := fixed.Point26_6{X: s.Args[2], Y: s.Args[3]}
+			// The error of n lines is at most |p0 - 2*p1 + p2| / (8 * n * n).
+			dd := pointLength(p0.X-2*p1.X+p2.X, p0.Y-2*p1.Y+p2.Y)
+			n := subdivisions(dd / (8 * overlapFlatness))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				lineTo(fixed.Point26_6{
+					X: roundFloat(u*u*float64(p0.X) + 2*u*t*float64(p1.X) + t*t*float64(p2.X)),
+					Y: roundFloat(u*u*float64(p0.Y) + 2*u*t*float64(p1.Y) + t*t*float64(p2.Y)),
+				})
+			}
+		case SegmentOpCubeTo:
+			p0 := cur
+			p1 := fixed.Point26_6{X: s.Args[0], Y: s.Args[1]}
+			p2 := fixed.Point26_6{X: s.Args[2], Y: s.Args[3]}
+			p3 := fixed.Point26_6{X: s.Args[4], Y: s.Args[5]}
+			// The error of n lines is at most 3 * dd / (4 * n * n), where dd
+			// is the larger second difference of the control points.
+			dd := math.Max(
+				pointLength(p0.X-2*p1.X+p2.X, p0.Y-2*p1.Y+p2.Y),
+				pointLength(p1.X-2*p2.X+p3.X, p1.Y-2*p2.Y+p3.Y),
+			)
+			n := subdivisions(3 * dd / (4 * overlapFlatness))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				u := 1 - t
+				lineTo(fixed.Point26_6{
+					X: roundFloat(u*u*u*float64(p0.X) + 3*u*u*t*float64(p1.X) + 3*u*t*t*float64(p2.X) + t*t*t*float64(p3.X)),
+					Y: roundFloat(u*u*u*float64(p0.Y) + 3*u*u*t*float64(p1.Y) + 3*u*t*t*float64(p2.Y) + t*t*t*float64(p3.Y)),
+				})
+			}
+		}
+	}
+	lineTo(start)
+	return dst
+}
+
+// subdivisions returns the number of lines needed to flatten a curve, given
+// the square of that number.
+func subdivisions(nn float64) int {
+	n := int(math.Ceil(math.Sqrt(nn)))
+	if n < 1 {
+		return 1
+	}
+	if n > maxOverlapSubdivisions {
+		return maxOverlapSubdivisions
+	}
+	return n
+}
+
+func pointLength(x, y fixed.Int26_6) float64 {
+	return math.Hypot(float64(x), float64(y))
+}
+
+func roundFloat(x float64) fixed.Int26_6 {
+	return fixed.Int26_6(math.Floor(x + 0.5))
+}
+
+func lessPoint(p, q fixed.Point26_6) bool {
+	return p.Y < q.Y || (p.Y == q.Y && p.X < q.X)
+}
+
+// splitEdges returns the edges split at their intersections with each other.
+func splitEdges(edges []overlapEdge) []overlapEdge {
+	type split struct {
+		t float64
+		p fixed.Point26_6
+	}
+	splits := make([][]split, len(edges))
+	addSplit := func(i int, p fixed.Point26_6) {
+		e := edges[i]
+		if p == e.p || p == e.q {
+			return
+		}
+		dx, dy := float64(e.q.X-e.p.X), float64(e.q.Y-e.p.Y)
+		t := (float64(p.X-e.p.X)*dx + float64(p.Y-e.p.Y)*dy) / (dx*dx + dy*dy)
+		splits[i] = append(splits[i], split{t, p})
+	}
+
+	for i, a := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			b := edges[j]
+			if maxInt26_6(a.p.X, a.q.X) < minInt26_6(b.p.X, b.q.X) ||
+				maxInt26_6(b.p.X, b.q.X) < minInt26_6(a.p.X, a.q.X) ||
+				maxInt26_6(a.p.Y, a.q.Y) < minInt26_6(b.p.Y, b.q.Y) ||
+				maxInt26_6(b.p.Y, b.q.Y) < minInt26_6(a.p.Y, a.q.Y) {
+				continue
+			}
+			rx, ry := float64(a.q.X-a.p.X), float64(a.q.Y-a.p.Y)
+			sx, sy := float64(b.q.X-b.p.X), float64(b.q.Y-b.p.Y)
+			qpx, qpy := float64(b.p.X-a.p.X), float64(b.p.Y-a.p.Y)
+			denom := rx*sy - ry*sx
+			if denom == 0 {
+				if qpx*ry-qpy*rx != 0 {
+					// The edges are parallel but not collinear.
+					continue
+				}
+				// The edges are collinear. Split each at the other's end
+				// points that are strictly inside it.
+				for _, p := range [2]fixed.Point26_6{b.p, b.q} {
+					if t := (float64(p.X-a.p.X)*rx + float64(p.Y-a.p.Y)*ry) / (rx*rx + ry*ry); 0 < t && t < 1 {
+						addSplit(i, p)
+					}
+				}
+				for _, p := range [2]fixed.Point26_6{a.p, a.q} {
+					if u := (float64(p.X-b.p.X)*sx + float64(p.Y-b.p.Y)*sy) / (sx*sx + sy*sy); 0 < u && u < 1 {
+						addSplit(j, p)
+					}
+				}
+				continue
+			}
+			t := (qpx*sy - qpy*sx) / denom
+			u := (qpx*ry - qpy*rx) / denom
+			if t < 0 || 1 < t || u < 0 || 1 < u {
+				continue
+			}
+			p := fixed.Point26_6{
+				X: roundFloat(float64(a.p.X) + t*rx),
+				Y: roundFloat(float64(a.p.Y) + t*ry),
+			}
+			addSplit(i, p)
+			addSplit(j, p)
+		}
+	}
+
+	var pieces []overlapEdge
+	for i, e := range edges {
+		s := splits[i]
+		sort.Slice(s, func(i, j int) bool { return s[i].t < s[j].t })
+		p := e.p
+		for _, x := range s {
+			if x.p != p {
+				pieces = append(pieces, overlapEdge{p, x.p})
+				p = x.p
+			}
+		}
+		if e.q != p {
+			pieces = append(pieces, overlapEdge{p, e.q})
+		}
+	}
+	return pieces
+}
+
+func minInt26_6(x, y fixed.Int26_6) fixed.Int26_6 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func maxInt26_6(x, y fixed.Int26_6) fixed.Int26_6 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+// windingNumber returns the winding number of the edges around the point (x,
+// y), which should not be on any edge.
+func windingNumber(edges []overlapEdge, x, y float64) int {
+	w := 0
+	for _, e := range edges {
+		px, py := float64(e.p.X), float64(e.p.Y)
+		qx, qy := float64(e.q.X), float64(e.q.Y)
+		if (py <= y) == (qy <= y) {
+			continue
+		}
+		// cross is positive if (x, y) is to the left of the edge.
+		cross := (qx-px)*(y-py) - (qy-py)*(x-px)
+		if py <= y && cross > 0 {
+			w++
+		} else if qy <= y && cross < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+// linkEdges links the edges, each of which starts where another ends, into
+// contours. Points that are in the middle of a straight line are dropped.
+func linkEdges(edges []overlapEdge) []Segment {
+	sort.Slice(edges, func(i, j int) bool {
+		return lessPoint(edges[i].p, edges[j].p)
+	})
+	// outgoing returns the index of the unused edge starting where the in'th
+	// edge ends, or -1 if there is no such edge. If there is more than one,
+	// such as where two contours touch at a point, it picks the sharpest
+	// right turn, which keeps the filled region to the right and the
+	// contours separate.
+	used := make([]bool, len(edges))
+	outgoing := func(in int) int {
+		p := edges[in].q
+		dx, dy := float64(p.X-edges[in].p.X), float64(p.Y-edges[in].p.Y)
+		best, bestAngle := -1, 0.0
+		i := sort.Search(len(edges), func(i int) bool {
+			return !lessPoint(edges[i].p, p)
+		})
+		for ; i < len(edges) && edges[i].p == p; i++ {
+			if used[i] {
+				continue
+			}
+			ex, ey := float64(edges[i].q.X-p.X), float64(edges[i].q.Y-p.Y)
+			// angle is positive for a left turn and negative for a right
+			// turn.
+			angle := math.Atan2(dx*ey-dy*ex, dx*ex+dy*ey)
+			if best < 0 || angle < bestAngle {
+				best, bestAngle = i, angle
+			}
+		}
+		return best
+	}
+
+	var (
+		dst    []Segment
+		points []fixed.Point26_6
+	)
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+		points = points[:0]
+		start := edges[i].p
+		for j := i; j >= 0; {
+			used[j] = true
+			points = append(points, edges[j].p)
+			if edges[j].q == start {
+				break
+			}
+			j = outgoing(j)
+		}
+
+		// Drop collinear points, other than the start point.
+		n := 0
+		for k, p := range points {
+			if k > 0 {
+				prev := points[n-1]
+				next := start
+				if k+1 < len(points) {
+					next = points[k+1]
+				}
+				if int64(p.X-prev.X)*int64(next.Y-p.Y) == int64(p.Y-prev.Y)*int64(next.X-p.X) {
+					continue
+				}
+			}
+			points[n] = p
+			n++
+		}
+		if n < 3 {
+			continue
+		}
+		dst = append(dst, Segment{
+			Op:   SegmentOpMoveTo,
+			Args: [6]fixed.Int26_6{points[0].X, points[0].Y},
+		})
+		for _, p := range points[1:n] {
+			dst = append(dst, Segment{
+				Op:   SegmentOpLineTo,
+				Args: [6]fixed.Int26_6{p.X, p.Y},
+			})
+		}
+		dst = append(dst, Segment{
+			Op:   SegmentOpLineTo,
+			Args: [6]fixed.Int26_6{start.X, start.Y},
+		})
+	}
+	return dst
+}
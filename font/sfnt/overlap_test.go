@@ -0,0 +1,170 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// testRect returns a rectangular contour, clockwise (in the y-up coordinate
+// system) if cw is true.
+func testRect(x0, y0, x1, y1 fixed.Int26_6, cw bool) []Segment {
+	if cw {
+		return []Segment{
+			moveTo(x0, y0),
+			lineTo(x0, y1),
+			lineTo(x1, y1),
+			lineTo(x1, y0),
+			lineTo(x0, y0),
+		}
+	}
+	return []Segment{
+		moveTo(x0, y0),
+		lineTo(x1, y0),
+		lineTo(x1, y1),
+		lineTo(x0, y1),
+		lineTo(x0, y0),
+	}
+}
+
+func TestRemoveOverlaps(t *testing.T) {
+	cat := func(ss ...[]Segment) (ret []Segment) {
+		for _, s := range ss {
+			ret = append(ret, s...)
+		}
+		return ret
+	}
+
+	testCases := []struct {
+		desc string
+		in   []Segment
+		want []Segment
+	}{{
+		desc: "overlapping squares",
+		in:   cat(testRect(0, 0, 100, 100, true), testRect(50, 50, 150, 150, true)),
+		want: []Segment{
+			moveTo(0, 0),
+			lineTo(0, 100),
+			lineTo(50, 100),
+			lineTo(50, 150),
+			lineTo(150, 150),
+			lineTo(150, 50),
+			lineTo(100, 50),
+			lineTo(100, 0),
+			lineTo(0, 0),
+		},
+	}, {
+		desc: "nested squares",
+		in:   cat(testRect(0, 0, 100, 100, true), testRect(20, 20, 80, 80, true)),
+		want: testRect(0, 0, 100, 100, true),
+	}, {
+		desc: "square with a hole",
+		in:   cat(testRect(0, 0, 100, 100, true), testRect(20, 20, 80, 80, false)),
+		want: cat(testRect(0, 0, 100, 100, true), testRect(20, 20, 80, 80, false)),
+	}, {
+		desc: "counter-clockwise square",
+		in:   testRect(0, 0, 100, 100, false),
+		want: testRect(0, 0, 100, 100, true),
+	}, {
+		desc: "abutting squares",
+		in:   cat(testRect(0, 0, 100, 100, true), testRect(100, 0, 200, 100, true)),
+		want: testRect(0, 0, 200, 100, true),
+	}, {
+		desc: "self-intersecting bow tie",
+		in: []Segment{
+			moveTo(0, 0),
+			lineTo(0, 100),
+			lineTo(100, 0),
+			lineTo(100, 100),
+			lineTo(0, 0),
+		},
+		want: []Segment{
+			moveTo(0, 0),
+			lineTo(0, 100),
+			lineTo(50, 50),
+			lineTo(0, 0),
+			moveTo(100, 0),
+			lineTo(50, 50),
+			lineTo(100, 100),
+			lineTo(100, 0),
+		},
+	}}
+	for _, tc := range testCases {
+		got := removeOverlaps(tc.in)
+		if err := checkSegmentsEqual(got, tc.want); err != nil {
+			t.Errorf("%s: %v", tc.desc, err)
+		}
+	}
+}
+
+func TestRemoveOverlapsPreservesFill(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var b Buffer
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	for _, r := range "AO8&@" {
+		x, err := f.GlyphIndex(&b, r)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphIndex: %v", r, err)
+		}
+		segments, err := f.LoadGlyph(&b, x, ppem, nil)
+		if err != nil {
+			t.Fatalf("r=%q: LoadGlyph: %v", r, err)
+		}
+		// Doubling the contours, and offsetting the copy, makes them overlap.
+		var in []Segment
+		for _, dx := range []fixed.Int26_6{0, 37} {
+			for _, s := range segments {
+				n := 2
+				switch s.Op {
+				case SegmentOpQuadTo:
+					n = 4
+				case SegmentOpCubeTo:
+					n = 6
+				}
+				for j := 0; j < n; j += 2 {
+					s.Args[j] += dx
+				}
+				in = append(in, s)
+			}
+		}
+		out := removeOverlaps(in)
+
+		want := removeOverlaps(append([]Segment(nil), segments...))
+		got, err := f.LoadGlyph(&b, x, ppem, &LoadGlyphOptions{RemoveOverlaps: true})
+		if err != nil {
+			t.Fatalf("r=%q: LoadGlyph: %v", r, err)
+		}
+		if err := checkSegmentsEqual(got, want); err != nil {
+			t.Errorf("r=%q: LoadGlyph with RemoveOverlaps: %v", r, err)
+		}
+
+		before := flattenSegments(nil, in)
+		after := flattenSegments(nil, out)
+		mismatches := 0
+		for y := -500.25; y < 2000; y += 20 {
+			for x := -500.25; x < 2000; x += 20 {
+				if (windingNumber(before, x, y) != 0) != (windingNumber(after, x, y) != 0) {
+					mismatches++
+				}
+				// The result's contours are clockwise, so the winding number
+				// is -1 inside.
+				if w := windingNumber(after, x, y); w != 0 && w != -1 {
+					t.Fatalf("r=%q: winding number at (%v, %v): got %d, want 0 or -1", r, x, y, w)
+				}
+			}
+		}
+		// Rounding the intersections to the 26.6 grid can move the boundary
+		// by a fraction of a unit, so allow for a few differences.
+		if mismatches > 2 {
+			t.Errorf("r=%q: %d mismatched points", r, mismatches)
+		}
+	}
+}
@@ -61,6 +61,23 @@ const (
 	// maximum of 48 operands". Similarly, 5177.Type2.pdf Appendix B "Type 2
 	// Charstring Implementation Limits" says that "Argument stack 48".
 	psStackSize = 48
+
+	// cff2StackSize is the stack size for CFF2 DICTs and charstrings. The
+	// CFF2 specification's "Appendix B: CFF2 Charstring Implementation
+	// Limits" says that the argument stack's maximum is 513.
+	cff2StackSize = 513
+
+	// psCallStackSize is the maximum subroutine nesting depth. 5177.Type2.pdf
+	// Appendix B says that "Subr nesting, stack limit 10".
+	psCallStackSize = 10
+
+	// maxCFFFontDicts is not part of the specification, but is a limitation
+	// used by this implementation, so that Font DICT indexes fit in a uint8.
+	maxCFFFontDicts = 256
+
+	// numStandardEncodingSIDs is one more than the largest SID (String ID) in
+	// the Standard Encoding, germandbls' 149.
+	numStandardEncodingSIDs = 150
 )
 
 func bigEndian(b []byte) uint32 {
@@ -77,7 +94,124 @@ func bigEndian(b []byte) uint32 {
 	panic("unreachable")
 }
 
-// cffParser parses the CFF table from an SFNT font.
+// cffInfo holds the CFF or CFF2 table's subroutines and, for CFF2, its
+// variation data. The subroutine locations are absolute offsets in the
+// source, as per Font.cached.locations.
+type cffInfo struct {
+	cff2 bool
+
+	// gsubrs are the locations of the global subroutines.
+	gsubrs []uint32
+
+	// subrs are the locations of the local subroutines and vsindex is the
+	// default item variation data index, one element per Font DICT. Non-CID
+	// CFF fonts have a single (top level) Font DICT.
+	subrs   [][]uint32
+	vsindex []uint16
+
+	// fdSelect maps each glyph index to its Font DICT. It is nil if there is
+	// only one Font DICT.
+	fdSelect []uint8
+
+	// regions are the variation store's regions. Each region is
+	// 3*regionAxisCount values: the start, peak and end coordinates for each
+	// axis. regionIndexes are the regions used by each item variation data,
+	// as selected by the vsindex operator.
+	regionAxisCount int
+	regions         []float64
+	regionIndexes   [][]uint16
+
+	// blueZones are the first Private DICT's blue zones.
+	blueZones []BlueZone
+
+	// standardGlyphs maps the SIDs (String IDs) of the Standard Encoding's
+	// characters to glyph indexes, for the deprecated seac operator. It is
+	// nil for CIDFonts and CFF2 fonts, and for fonts whose charset is one of
+	// the predefined Expert charsets.
+	standardGlyphs []GlyphIndex
+}
+
+// fontDict returns the index of the Font DICT for the x'th glyph.
+func (c *cffInfo) fontDict(x GlyphIndex) int {
+	if int(x) < len(c.fdSelect) {
+		return int(c.fdSelect[x])
+	}
+	return 0
+}
+
+// seacGlyphs returns the base and accent glyphs of a seac composition, given
+// their Standard Encoding codes.
+func (c *cffInfo) seacGlyphs(bchar, achar int32) (base, accent GlyphIndex, err error) {
+	bsid := standardEncodingSID(bchar)
+	asid := standardEncodingSID(achar)
+	if bsid == 0 || asid == 0 || int(bsid) >= len(c.standardGlyphs) || int(asid) >= len(c.standardGlyphs) {
+		return 0, 0, errInvalidCFFTable
+	}
+	base, accent = c.standardGlyphs[bsid], c.standardGlyphs[asid]
+	if base == 0 || accent == 0 {
+		return 0, 0, errInvalidCFFTable
+	}
+	return base, accent, nil
+}
+
+// appendCFFSegments appends the segments of the x'th glyph, whose Type 2
+// Charstring or CFF2 charstring is buf, to b.segments. The segments are in
+// font units.
+func (f *Font) appendCFFSegments(b *Buffer, x GlyphIndex, buf []byte) ([]Segment, error) {
+	d := &b.psi.type2Charstrings
+	b.psi.cff = &f.cached.cff
+	d.initialize(f, x, b.segments)
+	if err := b.psi.run(psContextType2Charstring, buf); err != nil {
+		return nil, err
+	}
+	if !d.seac {
+		return d.segments, nil
+	}
+
+	// Compose the base glyph, at the origin, and the accent glyph, offset by
+	// (adx, ady). Neither can itself be a seac composition.
+	adx, ady := d.seacArgs[0], d.seacArgs[1]
+	base, accent, err := f.cached.cff.seacGlyphs(d.seacArgs[2], d.seacArgs[3])
+	if err != nil {
+		return nil, err
+	}
+	segments := d.segments
+	for i, g := range [2]GlyphIndex{base, accent} {
+		buf, err := f.viewGlyphData(b, g)
+		if err != nil {
+			return nil, err
+		}
+		n := len(segments)
+		d.initialize(f, g, segments)
+		if err := b.psi.run(psContextType2Charstring, buf); err != nil {
+			return nil, err
+		}
+		if d.seac {
+			return nil, errInvalidCFFTable
+		}
+		segments = d.segments
+		if i == 0 {
+			continue
+		}
+		for k := range segments[n:] {
+			s := &segments[n+k]
+			m := 2
+			switch s.Op {
+			case SegmentOpQuadTo:
+				m = 4
+			case SegmentOpCubeTo:
+				m = 6
+			}
+			for j := 0; j < m; j += 2 {
+				s.Args[j+0] += fixed.Int26_6(adx)
+				s.Args[j+1] += fixed.Int26_6(ady)
+			}
+		}
+	}
+	return segments, nil
+}
+
+// cffParser parses the CFF or CFF2 table from an SFNT font.
 type cffParser struct {
 	src    *source
 	base   int
@@ -85,20 +219,127 @@ type cffParser struct {
 	end    int
 	err    error
 
+	// cff2 is whether the table is a CFF2 table, as opposed to a CFF table.
+	cff2 bool
+
 	buf    []byte
 	locBuf [2]uint32
 
 	psi psInterpreter
+
+	// info holds the subroutines and variation data found by parse.
+	info cffInfo
 }
 
 func (p *cffParser) parse() (locations []uint32, err error) {
+	p.info.cff2 = p.cff2
+	p.psi.cff = &p.info
+	p.psi.topDict.initialize()
+	if p.cff2 {
+		err = p.parseCFF2Header()
+	} else {
+		err = p.parseCFFHeader()
+	}
+	if err != nil {
+		return nil, err
+	}
+	topDict := p.psi.topDict
+
+	// Parse the Global Subr INDEX, which follows the Top DICT INDEX and the
+	// String INDEX for CFF, and the Top DICT for CFF2.
+	if p.info.gsubrs, err = p.parseIndex(); err != nil {
+		return nil, err
+	}
+
+	// Parse the CharStrings INDEX, whose location was found in the Top DICT.
+	if topDict.charStrings <= 0 || int32(p.end-p.base) < topDict.charStrings {
+		return nil, errInvalidCFFTable
+	}
+	p.offset = p.base + int(topDict.charStrings)
+	count, offSize, ok := p.parseIndexHeader()
+	if !ok {
+		return nil, p.err
+	}
+	if count == 0 {
+		return nil, errInvalidCFFTable
+	}
+	locations = make([]uint32, count+1)
+	if !p.parseIndexLocations(locations, count, offSize) {
+		return nil, p.err
+	}
+
+	// The variation store is parsed before the Private DICTs, as their blend
+	// operators need to know the number of regions.
+	if topDict.vstore != 0 {
+		if !p.cff2 {
+			return nil, errInvalidCFFTable
+		}
+		if err := p.parseVariationStore(topDict.vstore); err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse the Private DICTs, for their local subroutines. CIDFonts and
+	// CFF2 fonts have a Private DICT per Font DICT in the FDArray. Other
+	// fonts have a single Private DICT.
+	if topDict.fdArray == 0 {
+		if p.cff2 {
+			return nil, errInvalidCFFTable
+		}
+		if err := p.parsePrivateDict(topDict.privateSize, topDict.privateOffset); err != nil {
+			return nil, err
+		}
+		if err := p.parseCharset(topDict.charset, count); err != nil {
+			return nil, err
+		}
+		return locations, nil
+	}
+	if topDict.fdArray < 0 || int32(p.end-p.base) < topDict.fdArray {
+		return nil, errInvalidCFFTable
+	}
+	p.offset = p.base + int(topDict.fdArray)
+	fdArray, err := p.parseIndex()
+	if err != nil {
+		return nil, err
+	}
+	if len(fdArray) < 2 {
+		return nil, errInvalidCFFTable
+	}
+	if len(fdArray)-1 > maxCFFFontDicts {
+		return nil, errUnsupportedNumberOfFontDicts
+	}
+	for i := 0; i < len(fdArray)-1; i++ {
+		p.offset = int(fdArray[i])
+		if !p.read(int(fdArray[i+1] - fdArray[i])) {
+			return nil, p.err
+		}
+		p.psi.topDict.initialize()
+		if p.err = p.psi.run(psContextTopDict, p.buf); p.err != nil {
+			return nil, p.err
+		}
+		d := p.psi.topDict
+		if err := p.parsePrivateDict(d.privateSize, d.privateOffset); err != nil {
+			return nil, err
+		}
+	}
+	if len(fdArray)-1 > 1 {
+		if err := p.parseFDSelect(topDict.fdSelect, int32(len(fdArray)-1), count); err != nil {
+			return nil, err
+		}
+	}
+	return locations, nil
+}
+
+// parseCFFHeader parses the CFF table's header, Name INDEX, Top DICT INDEX and
+// String INDEX.
+func (p *cffParser) parseCFFHeader() error {
 	// Parse header.
 	{
 		if !p.read(4) {
-			return nil, p.err
+			return p.err
 		}
 		if p.buf[0] != 1 || p.buf[1] != 0 || p.buf[2] != 4 {
-			return nil, errUnsupportedCFFVersion
+			return errUnsupportedCFFVersion
 		}
 	}
 
@@ -106,15 +347,15 @@ func (p *cffParser) parse() (locations []uint32, err error) {
 	{
 		count, offSize, ok := p.parseIndexHeader()
 		if !ok {
-			return nil, p.err
+			return p.err
 		}
 		// https://www.microsoft.com/typography/OTSPEC/cff.htm says that "The
 		// Name INDEX in the CFF must contain only one entry".
 		if count != 1 {
-			return nil, errInvalidCFFTable
+			return errInvalidCFFTable
 		}
 		if !p.parseIndexLocations(p.locBuf[:2], count, offSize) {
-			return nil, p.err
+			return p.err
 		}
 		p.offset = int(p.locBuf[1])
 	}
@@ -123,44 +364,331 @@ func (p *cffParser) parse() (locations []uint32, err error) {
 	{
 		count, offSize, ok := p.parseIndexHeader()
 		if !ok {
-			return nil, p.err
+			return p.err
 		}
 		// 5176.CFF.pdf section 8 "Top DICT INDEX" says that the count here
 		// should match the count of the Name INDEX, which is 1.
 		if count != 1 {
-			return nil, errInvalidCFFTable
+			return errInvalidCFFTable
 		}
 		if !p.parseIndexLocations(p.locBuf[:2], count, offSize) {
-			return nil, p.err
+			return p.err
 		}
 		if !p.read(int(p.locBuf[1] - p.locBuf[0])) {
-			return nil, p.err
+			return p.err
 		}
-		p.psi.topDict.initialize()
 		if p.err = p.psi.run(psContextTopDict, p.buf); p.err != nil {
-			return nil, p.err
+			return p.err
 		}
 	}
 
-	// Parse the CharStrings INDEX, whose location was found in the Top DICT.
-	if p.psi.topDict.charStrings <= 0 || int32(p.end-p.base) < p.psi.topDict.charStrings {
-		return nil, errInvalidCFFTable
+	// Skip the String INDEX. This implementation doesn't need the strings.
+	_, err := p.parseIndex()
+	return err
+}
+
+// parseCFF2Header parses the CFF2 table's header and Top DICT.
+//
+// See https://www.microsoft.com/typography/otspec/cff2.htm
+func (p *cffParser) parseCFF2Header() error {
+	if !p.read(5) {
+		return p.err
+	}
+	if p.buf[0] != 2 || p.buf[1] != 0 || p.buf[2] < 5 {
+		return errUnsupportedCFFVersion
 	}
-	p.offset = p.base + int(p.psi.topDict.charStrings)
+	topDictLength := int(u16(p.buf[3:]))
+	p.offset = p.base + int(p.buf[2])
+	if !p.read(topDictLength) {
+		return p.err
+	}
+	if p.err = p.psi.run(psContextTopDict, p.buf); p.err != nil {
+		return p.err
+	}
+	return nil
+}
+
+// parseIndex parses an INDEX at p.offset, returning the locations of its
+// objects. It returns nil for an empty INDEX. Afterwards, p.offset is the end
+// of the INDEX.
+func (p *cffParser) parseIndex() ([]uint32, error) {
 	count, offSize, ok := p.parseIndexHeader()
 	if !ok {
 		return nil, p.err
 	}
 	if count == 0 {
-		return nil, errInvalidCFFTable
+		return nil, nil
 	}
-	locations = make([]uint32, count+1)
+	locations := make([]uint32, count+1)
 	if !p.parseIndexLocations(locations, count, offSize) {
 		return nil, p.err
 	}
+	p.offset = int(locations[count])
 	return locations, nil
 }
 
+// parsePrivateDict parses the Private DICT of the given size at the given
+// offset, and the local subroutines that it refers to, appending them to
+// p.info.
+func (p *cffParser) parsePrivateDict(size, offset int32) error {
+	if size < 0 || offset < 0 || int32(p.end-p.base) < offset || int32(p.end-p.base)-offset < size {
+		return errInvalidCFFTable
+	}
+	p.offset = p.base + int(offset)
+	if !p.read(int(size)) {
+		return p.err
+	}
+	p.psi.privateDict.initialize()
+	if p.err = p.psi.run(psContextPrivateDict, p.buf); p.err != nil {
+		return p.err
+	}
+	d := p.psi.privateDict
+	if d.vsindex < 0 || (p.cff2 && int(d.vsindex) >= len(p.info.regionIndexes) && d.vsindex != 0) {
+		return errInvalidCFFTable
+	}
+
+	var subrs []uint32
+	if d.subrs != 0 {
+		// 5176.CFF.pdf section 15 "Private DICT Data" says that the Subrs
+		// offset is "relative to the beginning of the Private DICT data".
+		if d.subrs < 0 || int32(p.end-p.base)-offset < d.subrs {
+			return errInvalidCFFTable
+		}
+		p.offset = p.base + int(offset+d.subrs)
+		var err error
+		if subrs, err = p.parseIndex(); err != nil {
+			return err
+		}
+	}
+	if len(p.info.subrs) == 0 {
+		p.info.blueZones = d.blueZones
+	}
+	p.info.subrs = append(p.info.subrs, subrs)
+	p.info.vsindex = append(p.info.vsindex, uint16(d.vsindex))
+	return nil
+}
+
+// parseCharset parses the charset, which maps each of the numGlyphs glyphs to
+// its SID (String ID), to find the glyphs for the Standard Encoding's
+// characters. See 5176.CFF.pdf section 13 "Charsets".
+func (p *cffParser) parseCharset(offset, numGlyphs int32) error {
+	glyphs := make([]GlyphIndex, numStandardEncodingSIDs)
+	switch offset {
+	case 0:
+		// The ISOAdobe charset maps each glyph index to the equal SID.
+		for i := int32(1); i < numGlyphs && i < numStandardEncodingSIDs; i++ {
+			glyphs[i] = GlyphIndex(i)
+		}
+		p.info.standardGlyphs = glyphs
+		return nil
+	case 1, 2:
+		// The Expert and ExpertSubset charsets are for fonts of expert
+		// glyphs, such as small capitals, that aren't in the Standard
+		// Encoding.
+		return nil
+	}
+	if offset < 0 || int32(p.end-p.base) < offset {
+		return errInvalidCFFTable
+	}
+	p.offset = p.base + int(offset)
+	if !p.read(1) {
+		return p.err
+	}
+	// The .notdef glyph, glyph 0, is omitted from the charset.
+	switch format := p.buf[0]; format {
+	case 0:
+		if !p.read(2 * int(numGlyphs-1)) {
+			return p.err
+		}
+		for i := int32(1); i < numGlyphs; i++ {
+			if sid := u16(p.buf[2*i-2:]); sid < numStandardEncodingSIDs && glyphs[sid] == 0 {
+				glyphs[sid] = GlyphIndex(i)
+			}
+		}
+
+	case 1, 2:
+		// Format 1 has 8-bit range lengths and format 2 has 16-bit ones.
+		size := 3
+		if format == 2 {
+			size = 4
+		}
+		for i := int32(1); i < numGlyphs; {
+			if !p.read(size) {
+				return p.err
+			}
+			first := int32(u16(p.buf))
+			nLeft := int32(bigEndian(p.buf[2:size]))
+			for j := int32(0); j <= nLeft && i < numGlyphs; j, i = j+1, i+1 {
+				if sid := first + j; sid < numStandardEncodingSIDs && glyphs[sid] == 0 {
+					glyphs[sid] = GlyphIndex(i)
+				}
+			}
+		}
+
+	default:
+		return errUnsupportedCFFVersion
+	}
+	p.info.standardGlyphs = glyphs
+	return nil
+}
+
+// parseFDSelect parses the FDSelect structure, which maps each of the
+// numGlyphs glyphs to one of the numFontDicts Font DICTs.
+func (p *cffParser) parseFDSelect(offset, numFontDicts, numGlyphs int32) error {
+	if offset <= 0 || int32(p.end-p.base) < offset {
+		return errInvalidCFFTable
+	}
+	p.offset = p.base + int(offset)
+	if !p.read(1) {
+		return p.err
+	}
+	fdSelect := make([]uint8, numGlyphs)
+	switch format := p.buf[0]; format {
+	case 0:
+		if !p.read(int(numGlyphs)) {
+			return p.err
+		}
+		for i, fd := range p.buf {
+			if int32(fd) >= numFontDicts {
+				return errInvalidCFFTable
+			}
+			fdSelect[i] = fd
+		}
+
+	case 3, 4:
+		// Format 3 has 16-bit glyph indexes and 8-bit Font DICT indexes.
+		// Format 4, only used by CFF2, has 32-bit and 16-bit ones.
+		if format == 4 && !p.cff2 {
+			return errUnsupportedCFFVersion
+		}
+		gSize, fdSize := 2, 1
+		if format == 4 {
+			gSize, fdSize = 4, 2
+		}
+		if !p.read(gSize) {
+			return p.err
+		}
+		nRanges := int32(bigEndian(p.buf[:gSize]))
+		if nRanges <= 0 || int32(p.end-p.offset)/int32(gSize+fdSize) < nRanges {
+			return errInvalidCFFTable
+		}
+		if !p.read(int(nRanges)*(gSize+fdSize) + gSize) {
+			return p.err
+		}
+		buf := p.buf
+		first := bigEndian(buf[:gSize])
+		if first != 0 {
+			return errInvalidCFFTable
+		}
+		for i := int32(0); i < nRanges; i++ {
+			fd := bigEndian(buf[gSize : gSize+fdSize])
+			buf = buf[gSize+fdSize:]
+			next := bigEndian(buf[:gSize])
+			if next <= first || uint32(numGlyphs) < next || uint32(numFontDicts) <= fd {
+				return errInvalidCFFTable
+			}
+			for j := first; j < next; j++ {
+				fdSelect[j] = uint8(fd)
+			}
+			first = next
+		}
+		if first != uint32(numGlyphs) {
+			return errInvalidCFFTable
+		}
+
+	default:
+		return errUnsupportedCFFVersion
+	}
+	p.info.fdSelect = fdSelect
+	return nil
+}
+
+// parseVariationStore parses the CFF2 table's VariationStore, which is an
+// ItemVariationStore preceded by its length. Only the regions, and the
+// regions used by each item variation data, are needed by the blend
+// operator. The deltas are in the charstrings.
+//
+// See the "Item variation stores" section of
+// https://www.microsoft.com/typography/otspec/otvarcommonformats.htm
+func (p *cffParser) parseVariationStore(offset int32) error {
+	if offset < 0 || int32(p.end-p.base) < offset {
+		return errInvalidCFFTable
+	}
+	p.offset = p.base + int(offset)
+	if !p.read(10) {
+		return p.err
+	}
+	base := p.offset - 8
+	if format := u16(p.buf[2:]); format != 1 {
+		return errUnsupportedCFFVersion
+	}
+	regionListOffset := u32(p.buf[4:])
+	dataCount := int(u16(p.buf[8:]))
+	if !p.read(4 * dataCount) {
+		return p.err
+	}
+	dataOffsets := make([]uint32, dataCount)
+	for i := range dataOffsets {
+		dataOffsets[i] = u32(p.buf[4*i:])
+	}
+
+	if uint32(p.end-base) < regionListOffset {
+		return errInvalidCFFTable
+	}
+	p.offset = base + int(regionListOffset)
+	if !p.read(4) {
+		return p.err
+	}
+	axisCount := int(u16(p.buf))
+	regionCount := int(u16(p.buf[2:]))
+	if axisCount > maxVariationAxes {
+		return errUnsupportedNumberOfVariationAxes
+	}
+	if !p.read(6 * axisCount * regionCount) {
+		return p.err
+	}
+	// The RegionAxisCoordinates records are in (start, peak, end) order, and
+	// regions holds all of the starts, then the peaks, then the ends.
+	regions := make([]float64, 3*axisCount*regionCount)
+	for i := 0; i < regionCount; i++ {
+		r := regions[3*axisCount*i:]
+		for j := 0; j < axisCount; j++ {
+			b := p.buf[6*(axisCount*i+j):]
+			r[j+0*axisCount] = f2Dot14(u16(b[0:]))
+			r[j+1*axisCount] = f2Dot14(u16(b[2:]))
+			r[j+2*axisCount] = f2Dot14(u16(b[4:]))
+		}
+	}
+
+	regionIndexes := make([][]uint16, dataCount)
+	for i, o := range dataOffsets {
+		if uint32(p.end-base) < o {
+			return errInvalidCFFTable
+		}
+		p.offset = base + int(o)
+		if !p.read(6) {
+			return p.err
+		}
+		n := int(u16(p.buf[4:]))
+		if !p.read(2 * n) {
+			return p.err
+		}
+		indexes := make([]uint16, n)
+		for j := range indexes {
+			indexes[j] = u16(p.buf[2*j:])
+			if int(indexes[j]) >= regionCount {
+				return errInvalidCFFTable
+			}
+		}
+		regionIndexes[i] = indexes
+	}
+
+	p.info.regionAxisCount = axisCount
+	p.info.regions = regions
+	p.info.regionIndexes = regionIndexes
+	return nil
+}
+
 // read sets p.buf to view the n bytes from p.offset to p.offset+n. It also
 // advances p.offset by n.
 //
@@ -182,10 +710,24 @@ func (p *cffParser) read(n int) (ok bool) {
 }
 
 func (p *cffParser) parseIndexHeader() (count, offSize int32, ok bool) {
-	if !p.read(2) {
-		return 0, 0, false
+	// A CFF2 INDEX has a 32-bit count. A CFF INDEX has a 16-bit one.
+	if p.cff2 {
+		if !p.read(4) {
+			return 0, 0, false
+		}
+		// Every object has at least an offset's worth of data.
+		c := u32(p.buf[:4])
+		if uint32(p.end-p.offset) < c {
+			p.err = errInvalidCFFTable
+			return 0, 0, false
+		}
+		count = int32(c)
+	} else {
+		if !p.read(2) {
+			return 0, 0, false
+		}
+		count = int32(u16(p.buf[:2]))
 	}
-	count = int32(u16(p.buf[:2]))
 	// 5176.CFF.pdf section 5 "INDEX Data" says that "An empty INDEX is
 	// represented by a count field with a 0 value and no additional fields.
 	// Thus, the total size of an empty INDEX is 2 bytes".
@@ -257,30 +799,84 @@ type psContext uint32
 
 const (
 	psContextTopDict psContext = iota
+	psContextPrivateDict
 	psContextType2Charstring
 )
 
-// psTopDictData contains fields specific to the Top DICT context.
+// psTopDictData contains fields specific to the Top DICT context. It is also
+// used for the Font DICTs of a CIDFont's or CFF2 font's FDArray.
 type psTopDictData struct {
-	charStrings int32
+	charStrings   int32
+	fdArray       int32
+	fdSelect      int32
+	privateSize   int32
+	privateOffset int32
+	vstore        int32
+	charset       int32
 }
 
 func (d *psTopDictData) initialize() {
 	*d = psTopDictData{}
 }
 
+// psPrivateDictData contains fields specific to the Private DICT context.
+type psPrivateDictData struct {
+	subrs     int32
+	vsindex   int32
+	blueZones []BlueZone
+}
+
+func (d *psPrivateDictData) initialize() {
+	*d = psPrivateDictData{}
+}
+
 // psType2CharstringsData contains fields specific to the Type 2 Charstrings
-// context.
+// context. CFF2 charstrings are a variation of Type 2 Charstrings, without
+// widths, endchar or return operators, but with blend and vsindex operators.
 type psType2CharstringsData struct {
+	f         *Font
 	segments  []Segment
 	x, y      int32
 	hintBits  int32
 	seenWidth bool
+
+	// fontDict is the index of the glyph's Font DICT, which selects the
+	// local subroutines.
+	fontDict int
+
+	// vsindex selects the CFF2 item variation data, and scalars holds the
+	// scalar for each of its regions, if scalarsValid.
+	vsindex      int32
+	scalars      []float64
+	scalarsValid bool
+
+	// subrBufs are scratch buffers for viewing subroutines, one per level
+	// of the call stack.
+	subrBufs [psCallStackSize][]byte
+
+	// hints, if non-nil, collects the stem hints.
+	hints *GlyphHints
+
+	// seac is whether the glyph ended with the deprecated seac operator,
+	// whose adx, ady, bchar and achar arguments are in seacArgs.
+	seac     bool
+	seacArgs [4]int32
 }
 
-func (d *psType2CharstringsData) initialize(segments []Segment) {
+func (d *psType2CharstringsData) initialize(f *Font, x GlyphIndex, segments []Segment) {
+	c := &f.cached.cff
+	fontDict := c.fontDict(x)
 	*d = psType2CharstringsData{
+		f:        f,
 		segments: segments,
+		fontDict: fontDict,
+		scalars:  d.scalars[:0],
+		subrBufs: d.subrBufs,
+		// CFF2 charstrings have no width.
+		seenWidth: c.cff2,
+	}
+	if fontDict < len(c.vsindex) {
+		d.vsindex = int32(c.vsindex[fontDict])
 	}
 }
 
@@ -289,21 +885,50 @@ type psInterpreter struct {
 	ctx          psContext
 	instructions []byte
 	stack        struct {
-		a   [psStackSize]int32
+		a   [cff2StackSize]int32
+		top int32
+	}
+	// callStack holds the callers' remaining instructions while running a
+	// subroutine.
+	callStack struct {
+		a   [psCallStackSize][]byte
 		top int32
 	}
+	// cff is the CFF or CFF2 table's subroutines and variation data.
+	cff              *cffInfo
 	parseNumberBuf   [maxRealNumberStrLen]byte
 	topDict          psTopDictData
+	privateDict      psPrivateDictData
 	type2Charstrings psType2CharstringsData
 }
 
+// stackSize returns the maximum number of values on p's stack.
+func (p *psInterpreter) stackSize() int32 {
+	if p.cff != nil && p.cff.cff2 {
+		return cff2StackSize
+	}
+	return psStackSize
+}
+
 func (p *psInterpreter) run(ctx psContext, instructions []byte) error {
 	p.ctx = ctx
 	p.instructions = instructions
 	p.stack.top = 0
+	p.callStack.top = 0
 
 loop:
-	for len(p.instructions) > 0 {
+	for {
+		if len(p.instructions) == 0 {
+			if p.callStack.top == 0 {
+				break
+			}
+			// Reaching the end of a subroutine is an implicit return. CFF2
+			// has no explicit return operator.
+			p.callStack.top--
+			p.instructions = p.callStack.a[p.callStack.top]
+			continue
+		}
+
 		// Push a numeric operand on the stack, if applicable.
 		if hasResult, err := p.parseNumber(); hasResult {
 			if err != nil {
@@ -368,14 +993,14 @@ func (p *psInterpreter) parseNumber() (hasResult bool, err error) {
 		number, hasResult = int32(int16(u16(p.instructions[1:]))), true
 		p.instructions = p.instructions[3:]
 
-	case b == 29 && p.ctx == psContextTopDict:
+	case b == 29 && p.ctx != psContextType2Charstring:
 		if len(p.instructions) < 5 {
 			return true, errInvalidCFFTable
 		}
 		number, hasResult = int32(u32(p.instructions[1:])), true
 		p.instructions = p.instructions[5:]
 
-	case b == 30 && p.ctx == psContextTopDict:
+	case b == 30 && p.ctx != psContextType2Charstring:
 		// Parse a real number. This isn't listed in 5176.CFF.pdf Table 3
 		// "Operand Encoding" but that table lists integer encodings. Further
 		// down the page it says "A real number operand is provided in addition
@@ -437,15 +1062,18 @@ func (p *psInterpreter) parseNumber() (hasResult bool, err error) {
 		number, hasResult = -int32(b-251)*256-int32(b1)-108, true
 
 	case b == 255 && p.ctx == psContextType2Charstring:
+		// 5177.Type2.pdf section 3.2 "Charstring Number Encoding" says that
+		// this is "a 16-bit signed integer with 16 bits of fraction". Round
+		// it to the nearest integer.
 		if len(p.instructions) < 5 {
 			return true, errInvalidCFFTable
 		}
-		number, hasResult = int32(u32(p.instructions[1:])), true
+		number, hasResult = int32((int64(int32(u32(p.instructions[1:])))+0x8000)>>16), true
 		p.instructions = p.instructions[5:]
 	}
 
 	if hasResult {
-		if p.stack.top == psStackSize {
+		if p.stack.top == p.stackSize() {
 			return true, errInvalidCFFTable
 		}
 		p.stack.a[p.stack.top] = number
@@ -503,13 +1131,26 @@ var psOperators = [...][2][]psOperator{
 		5:  {-1, "FontBBox", nil},
 		13: {+1, "UniqueID", nil},
 		14: {-1, "XUID", nil},
-		15: {+1, "charset", nil},
+		15: {+1, "charset", func(p *psInterpreter) error {
+			p.topDict.charset = p.stack.a[p.stack.top-1]
+			return nil
+		}},
 		16: {+1, "Encoding", nil},
 		17: {+1, "CharStrings", func(p *psInterpreter) error {
 			p.topDict.charStrings = p.stack.a[p.stack.top-1]
 			return nil
 		}},
-		18: {+2, "Private", nil},
+		18: {+2, "Private", func(p *psInterpreter) error {
+			p.topDict.privateSize = p.stack.a[p.stack.top-2]
+			p.topDict.privateOffset = p.stack.a[p.stack.top-1]
+			return nil
+		}},
+		// The vstore and maxstack operators are CFF2-only.
+		24: {+1, "vstore", func(p *psInterpreter) error {
+			p.topDict.vstore = p.stack.a[p.stack.top-1]
+			return nil
+		}},
+		25: {+1, "maxstack", nil},
 	}, {
 		// 2-byte operators. The first byte is the escape byte.
 		0:  {+1, "Copyright", nil},
@@ -531,50 +1172,107 @@ var psOperators = [...][2][]psOperator{
 		33: {+1, "CIDFontType", nil},
 		34: {+1, "CIDCount", nil},
 		35: {+1, "UIDBase", nil},
-		36: {+1, "FDArray", nil},
-		37: {+1, "FDSelect", nil},
+		36: {+1, "FDArray", func(p *psInterpreter) error {
+			p.topDict.fdArray = p.stack.a[p.stack.top-1]
+			return nil
+		}},
+		37: {+1, "FDSelect", func(p *psInterpreter) error {
+			p.topDict.fdSelect = p.stack.a[p.stack.top-1]
+			return nil
+		}},
 		38: {+1, "FontName", nil},
 	}},
 
+	// The Private DICT operators are defined by 5176.CFF.pdf Table 23 "Private
+	// DICT Operators" and, for vsindex and blend, the CFF2 specification.
+	psContextPrivateDict: {{
+		// 1-byte operators.
+		6: {-2, "BlueValues", func(p *psInterpreter) error {
+			return psBlueZones(p, true)
+		}},
+		7: {-2, "OtherBlues", func(p *psInterpreter) error {
+			return psBlueZones(p, false)
+		}},
+		8:  {-2, "FamilyBlues", nil},
+		9:  {-2, "FamilyOtherBlues", nil},
+		10: {+1, "StdHW", nil},
+		11: {+1, "StdVW", nil},
+		19: {+1, "Subrs", func(p *psInterpreter) error {
+			p.privateDict.subrs = p.stack.a[p.stack.top-1]
+			return nil
+		}},
+		20: {+1, "defaultWidthX", nil},
+		21: {+1, "nominalWidthX", nil},
+		22: {+1, "vsindex", func(p *psInterpreter) error {
+			p.privateDict.vsindex = p.stack.a[p.stack.top-1]
+			return nil
+		}},
+		23: {+0, "blend", func(p *psInterpreter) error {
+			// The Private DICT's values only affect hinting, which this
+			// implementation doesn't do for PostScript fonts, so we use the
+			// default values.
+			k := 0
+			if i := int(p.privateDict.vsindex); 0 <= i && i < len(p.cff.regionIndexes) {
+				k = len(p.cff.regionIndexes[i])
+			}
+			return psBlend(p, nil, k)
+		}},
+	}, {
+		// 2-byte operators. The first byte is the escape byte.
+		9:  {+1, "BlueScale", nil},
+		10: {+1, "BlueShift", nil},
+		11: {+1, "BlueFuzz", nil},
+		12: {-2, "StemSnapH", nil},
+		13: {-2, "StemSnapV", nil},
+		14: {+1, "ForceBold", nil},
+		17: {+1, "LanguageGroup", nil},
+		18: {+1, "ExpansionFactor", nil},
+		19: {+1, "initialRandomSeed", nil},
+	}},
+
 	// The Type 2 Charstring operators are defined by 5177.Type2.pdf Appendix A
 	// "Type 2 Charstring Command Codes".
 	psContextType2Charstring: {{
 		// 1-byte operators.
 		0:  {}, // Reserved.
-		1:  {-1, "hstem", t2CStem},
+		1:  {-1, "hstem", t2CHstem},
 		2:  {}, // Reserved.
-		3:  {-1, "vstem", t2CStem},
+		3:  {-1, "vstem", t2CVstem},
 		4:  {-1, "vmoveto", t2CVmoveto},
 		5:  {-1, "rlineto", t2CRlineto},
 		6:  {-1, "hlineto", t2CHlineto},
 		7:  {-1, "vlineto", t2CVlineto},
 		8:  {-1, "rrcurveto", t2CRrcurveto},
 		9:  {}, // Reserved.
-		10: {}, // callsubr.
-		11: {}, // return.
+		10: {+1, "callsubr", t2CCallsubr},
+		11: {+0, "return", t2CReturn},
 		12: {}, // escape.
 		13: {}, // Reserved.
 		14: {-1, "endchar", t2CEndchar},
-		15: {}, // Reserved.
-		16: {}, // Reserved.
+		15: {-1, "vsindex", t2CVsindex},
+		16: {+0, "blend", t2CBlend},
 		17: {}, // Reserved.
-		18: {-1, "hstemhm", t2CStem},
+		18: {-1, "hstemhm", t2CHstem},
 		19: {-1, "hintmask", t2CMask},
 		20: {-1, "cntrmask", t2CMask},
 		21: {-1, "rmoveto", t2CRmoveto},
 		22: {-1, "hmoveto", t2CHmoveto},
-		23: {-1, "vstemhm", t2CStem},
-		24: {}, // rcurveline.
-		25: {}, // rlinecurve.
-		26: {}, // vvcurveto.
-		27: {}, // hhcurveto.
+		23: {-1, "vstemhm", t2CVstem},
+		24: {-1, "rcurveline", t2CRcurveline},
+		25: {-1, "rlinecurve", t2CRlinecurve},
+		26: {-1, "vvcurveto", t2CVvcurveto},
+		27: {-1, "hhcurveto", t2CHhcurveto},
 		28: {}, // shortint.
-		29: {}, // callgsubr.
+		29: {+1, "callgsubr", t2CCallgsubr},
 		30: {-1, "vhcurveto", t2CVhcurveto},
 		31: {-1, "hvcurveto", t2CHvcurveto},
 	}, {
 		// 2-byte operators. The first byte is the escape byte.
-		0: {}, // Reserved.
+		0:  {}, // Reserved.
+		34: {-1, "hflex", t2CHflex},
+		35: {-1, "flex", t2CFlex},
+		36: {-1, "hflex1", t2CHflex1},
+		37: {-1, "flex1", t2CFlex1},
 		// TODO: more operators.
 	}},
 }
@@ -624,24 +1322,47 @@ func t2CReadWidth(p *psInterpreter, nArgs int32) {
 	p.stack.top--
 }
 
-func t2CStem(p *psInterpreter) error {
+func t2CHstem(p *psInterpreter) error { return t2CStem(p, false) }
+func t2CVstem(p *psInterpreter) error { return t2CStem(p, true) }
+
+func t2CStem(p *psInterpreter, vertical bool) error {
 	t2CReadWidth(p, 2)
 	if p.stack.top%2 != 0 {
 		return errInvalidCFFTable
 	}
 	// We update the number of hintBits need to parse hintmask and cntrmask
 	// instructions, but this Type 2 Charstring implementation otherwise
-	// ignores the stem hints.
+	// ignores the stem hints, other than collecting them for
+	// Font.LoadGlyphHints.
 	p.type2Charstrings.hintBits += p.stack.top / 2
 	if p.type2Charstrings.hintBits > maxHintBits {
 		return errUnsupportedNumberOfHints
 	}
+	if h := p.type2Charstrings.hints; h != nil {
+		stems := &h.HStems
+		if vertical {
+			stems = &h.VStems
+		}
+		// Each stem's edge is relative to the previous stem's other edge.
+		pos := int32(0)
+		for i := int32(0); i < p.stack.top; i += 2 {
+			pos += p.stack.a[i+0]
+			*stems = append(*stems, Stem{Min: Units(pos), Max: Units(pos + p.stack.a[i+1])})
+			pos += p.stack.a[i+1]
+		}
+	}
 	return nil
 }
 
 func t2CMask(p *psInterpreter) error {
+	// 5177.Type2.pdf section 4.3 "Hint Operators" says that if hintmask or
+	// cntrmask directly follow the hstem hints, then the vstem operator
+	// "need not be included", and its arguments are on the stack.
+	t2CReadWidth(p, 2)
+	if err := t2CStem(p, true); err != nil {
+		return err
+	}
 	hintBytes := (p.type2Charstrings.hintBits + 7) / 8
-	t2CReadWidth(p, hintBytes)
 	if len(p.instructions) < int(hintBytes) {
 		return errInvalidCFFTable
 	}
@@ -852,14 +1573,337 @@ func t2CRrcurveto(p *psInterpreter) error {
 }
 
 func t2CEndchar(p *psInterpreter) error {
-	t2CReadWidth(p, 0)
-	if p.stack.top != 0 || len(p.instructions) != 0 {
-		if p.stack.top == 4 {
-			// TODO: process the implicit "seac" command as per 5177.Type2.pdf
-			// Appendix C "Compatibility and Deprecated Operators".
-			return errUnsupportedType2Charstring
+	if p.cff.cff2 {
+		return errInvalidCFFTable
+	}
+	// The optional width precedes either zero or four arguments.
+	t2CReadWidth(p, 4)
+	// The endchar operator may be in a subroutine, in which case the callers'
+	// remaining instructions are ignored.
+	if len(p.instructions) != 0 && p.callStack.top == 0 {
+		return errInvalidCFFTable
+	}
+	switch p.stack.top {
+	case 0:
+	case 4:
+		// 5177.Type2.pdf Appendix C "Compatibility and Deprecated Operators"
+		// says that endchar with four arguments is the implicit "seac"
+		// command, which composes a base and an accent glyph. The caller,
+		// Font.appendCFFSegments, does the composition.
+		if p.cff.standardGlyphs == nil {
+			return errInvalidCFFTable
 		}
+		p.type2Charstrings.seac = true
+		copy(p.type2Charstrings.seacArgs[:], p.stack.a[:4])
+	default:
 		return errInvalidCFFTable
 	}
+	p.instructions = nil
+	p.callStack.top = 0
 	return nil
 }
+
+func t2CRcurveline(p *psInterpreter) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top < 8 || (p.stack.top-2)%6 != 0 {
+		return errInvalidCFFTable
+	}
+	i := int32(0)
+	for ; i != p.stack.top-2; i += 6 {
+		t2CAppendCubeto(p,
+			p.stack.a[i+0],
+			p.stack.a[i+1],
+			p.stack.a[i+2],
+			p.stack.a[i+3],
+			p.stack.a[i+4],
+			p.stack.a[i+5],
+		)
+	}
+	p.type2Charstrings.x += p.stack.a[i+0]
+	p.type2Charstrings.y += p.stack.a[i+1]
+	t2CAppendLineto(p)
+	return nil
+}
+
+func t2CRlinecurve(p *psInterpreter) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top < 8 || (p.stack.top-6)%2 != 0 {
+		return errInvalidCFFTable
+	}
+	i := int32(0)
+	for ; i != p.stack.top-6; i += 2 {
+		p.type2Charstrings.x += p.stack.a[i+0]
+		p.type2Charstrings.y += p.stack.a[i+1]
+		t2CAppendLineto(p)
+	}
+	t2CAppendCubeto(p,
+		p.stack.a[i+0],
+		p.stack.a[i+1],
+		p.stack.a[i+2],
+		p.stack.a[i+3],
+		p.stack.a[i+4],
+		p.stack.a[i+5],
+	)
+	return nil
+}
+
+// As per 5177.Type2.pdf section 4.1 "Path Construction Operators",
+//
+// hhcurveto is:
+//	- dy1? {dxa dxb dyb dxc}+
+//
+// vvcurveto is:
+//	- dx1? {dya dxb dyb dyc}+
+
+func t2CHhcurveto(p *psInterpreter) error { return t2CCurveto4(p, false) }
+func t2CVvcurveto(p *psInterpreter) error { return t2CCurveto4(p, true) }
+
+func t2CCurveto4(p *psInterpreter, vertical bool) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top < 4 {
+		return errInvalidCFFTable
+	}
+	i, d := int32(0), int32(0)
+	if p.stack.top%4 == 1 {
+		i, d = 1, p.stack.a[0]
+	} else if p.stack.top%4 != 0 {
+		return errInvalidCFFTable
+	}
+	for ; i != p.stack.top; i += 4 {
+		if vertical {
+			t2CAppendCubeto(p, d, p.stack.a[i+0], p.stack.a[i+1], p.stack.a[i+2], 0, p.stack.a[i+3])
+		} else {
+			t2CAppendCubeto(p, p.stack.a[i+0], d, p.stack.a[i+1], p.stack.a[i+2], p.stack.a[i+3], 0)
+		}
+		d = 0
+	}
+	return nil
+}
+
+// The flex operators are defined by 5177.Type2.pdf section 4.1 "Path
+// Construction Operators". Each draws two curves. This implementation ignores
+// the flex depth, always drawing curves and never a straight line.
+
+func t2CHflex(p *psInterpreter) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top != 7 {
+		return errInvalidCFFTable
+	}
+	a := p.stack.a[:7]
+	t2CAppendCubeto(p, a[0], 0, a[1], a[2], a[3], 0)
+	t2CAppendCubeto(p, a[4], 0, a[5], -a[2], a[6], 0)
+	return nil
+}
+
+func t2CFlex(p *psInterpreter) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top != 13 {
+		return errInvalidCFFTable
+	}
+	a := p.stack.a[:12]
+	t2CAppendCubeto(p, a[0], a[1], a[2], a[3], a[4], a[5])
+	t2CAppendCubeto(p, a[6], a[7], a[8], a[9], a[10], a[11])
+	return nil
+}
+
+func t2CHflex1(p *psInterpreter) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top != 9 {
+		return errInvalidCFFTable
+	}
+	a := p.stack.a[:9]
+	t2CAppendCubeto(p, a[0], a[1], a[2], a[3], a[4], 0)
+	t2CAppendCubeto(p, a[5], 0, a[6], a[7], a[8], -(a[1] + a[3] + a[7]))
+	return nil
+}
+
+func t2CFlex1(p *psInterpreter) error {
+	if !p.type2Charstrings.seenWidth || p.stack.top != 11 {
+		return errInvalidCFFTable
+	}
+	a := p.stack.a[:11]
+	dx := a[0] + a[2] + a[4] + a[6] + a[8]
+	dy := a[1] + a[3] + a[5] + a[7] + a[9]
+	// The last point's other coordinate returns to the starting point's.
+	dx6, dy6 := a[10], -dy
+	if abs(dx) <= abs(dy) {
+		dx6, dy6 = -dx, a[10]
+	}
+	t2CAppendCubeto(p, a[0], a[1], a[2], a[3], a[4], a[5])
+	t2CAppendCubeto(p, a[6], a[7], a[8], a[9], dx6, dy6)
+	return nil
+}
+
+func abs(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func t2CCallsubr(p *psInterpreter) error {
+	var subrs []uint32
+	if i := p.type2Charstrings.fontDict; i < len(p.cff.subrs) {
+		subrs = p.cff.subrs[i]
+	}
+	return t2CCall(p, subrs)
+}
+
+func t2CCallgsubr(p *psInterpreter) error {
+	return t2CCall(p, p.cff.gsubrs)
+}
+
+// t2CCall calls the subroutine, from the given subroutine locations, whose
+// biased index is on the top of the stack. The run method pops that index.
+func t2CCall(p *psInterpreter, locations []uint32) error {
+	n := int32(len(locations) - 1)
+	if n <= 0 {
+		return errInvalidCFFTable
+	}
+	// 5177.Type2.pdf section 4.7 "Subroutine Operators" says that the
+	// subroutine number is biased, depending on the number of subroutines.
+	i := p.stack.a[p.stack.top-1]
+	switch {
+	case n < 1240:
+		i += 107
+	case n < 33900:
+		i += 1131
+	default:
+		i += 32768
+	}
+	if i < 0 || n <= i {
+		return errInvalidCFFTable
+	}
+	if p.callStack.top == psCallStackSize {
+		return errUnsupportedType2Charstring
+	}
+
+	d := &p.type2Charstrings
+	src := &d.f.src
+	depth := p.callStack.top
+	buf, err := src.view(d.subrBufs[depth], int(locations[i]), int(locations[i+1]-locations[i]))
+	if err != nil {
+		return err
+	}
+	if src.viewBufferWritable() {
+		d.subrBufs[depth] = buf
+	}
+	p.callStack.a[depth] = p.instructions
+	p.callStack.top++
+	p.instructions = buf
+	return nil
+}
+
+func t2CReturn(p *psInterpreter) error {
+	if p.cff.cff2 || p.callStack.top == 0 {
+		return errInvalidCFFTable
+	}
+	p.callStack.top--
+	p.instructions = p.callStack.a[p.callStack.top]
+	return nil
+}
+
+func t2CVsindex(p *psInterpreter) error {
+	if !p.cff.cff2 || p.stack.top != 1 {
+		return errInvalidCFFTable
+	}
+	d := &p.type2Charstrings
+	d.vsindex = p.stack.a[0]
+	d.scalarsValid = false
+	return nil
+}
+
+func t2CBlend(p *psInterpreter) error {
+	if !p.cff.cff2 {
+		return errInvalidCFFTable
+	}
+	d := &p.type2Charstrings
+	if !d.scalarsValid {
+		if d.vsindex < 0 || int(d.vsindex) >= len(p.cff.regionIndexes) {
+			return errInvalidCFFTable
+		}
+		coords := d.f.cached.normalizedCoords
+		n := p.cff.regionAxisCount
+		d.scalars = d.scalars[:0]
+		for _, r := range p.cff.regionIndexes[d.vsindex] {
+			scalar := 0.0
+			if coords != nil {
+				region := p.cff.regions[3*n*int(r):]
+				scalar = tupleScalar(coords, region[1*n:2*n], region[0*n:1*n], region[2*n:3*n], true)
+			}
+			d.scalars = append(d.scalars, scalar)
+		}
+		d.scalarsValid = true
+	}
+	return psBlend(p, d.scalars, len(d.scalars))
+}
+
+// psBlueZones implements the BlueValues and OtherBlues operators, appending
+// their zones to p.privateDict.blueZones. Their operands are a delta encoded
+// array of pairs: the bottom and top of each zone. The first BlueValues zone
+// is the baseline, a bottom zone, and the others are top zones. OtherBlues
+// zones are bottom zones.
+func psBlueZones(p *psInterpreter, blueValues bool) error {
+	if p.stack.top%2 != 0 {
+		return errInvalidCFFTable
+	}
+	v := int32(0)
+	for i := int32(0); i < p.stack.top; i += 2 {
+		lo := v + p.stack.a[i+0]
+		hi := lo + p.stack.a[i+1]
+		v = hi
+		z := BlueZone{Flat: Units(hi), Overshoot: Units(lo)}
+		if blueValues && i != 0 {
+			z = BlueZone{Flat: Units(lo), Overshoot: Units(hi), Top: true}
+		}
+		p.privateDict.blueZones = append(p.privateDict.blueZones, z)
+	}
+	return nil
+}
+
+// psBlend implements the CFF2 blend operator, which replaces n default values
+// and k deltas for each of them, followed by n, on the stack by the n blended
+// values. Each delta is multiplied by the corresponding region's scalar. A
+// nil scalars means that every scalar is zero.
+func psBlend(p *psInterpreter, scalars []float64, k int) error {
+	if p.stack.top < 1 {
+		return errInvalidCFFTable
+	}
+	p.stack.top--
+	n := p.stack.a[p.stack.top]
+	if n < 0 || p.stack.top/int32(k+1) < n {
+		return errInvalidCFFTable
+	}
+	base := p.stack.top - n*int32(k+1)
+	deltas := p.stack.a[base+n:]
+	for i := int32(0); i < n; i++ {
+		if scalars == nil {
+			continue
+		}
+		v := float64(p.stack.a[base+i])
+		for j, scalar := range scalars {
+			v += scalar * float64(deltas[int(i)*k+j])
+		}
+		p.stack.a[base+i] = int32(math.Floor(v + 0.5))
+	}
+	p.stack.top = base + n
+	return nil
+}
+
+// standardEncodingSID returns the SID (String ID) of the character with the
+// given code in the Standard Encoding, or 0 (.notdef) if there is no such
+// character. See 5176.CFF.pdf Appendix B "Predefined Encodings".
+func standardEncodingSID(code int32) int32 {
+	switch {
+	case 32 <= code && code <= 126:
+		return code - 31
+	case 161 <= code && code <= 255:
+		return int32(standardEncodingHighSIDs[code-161])
+	}
+	return 0
+}
+
+// standardEncodingHighSIDs are the Standard Encoding's SIDs for the codes 161
+// to 255 inclusive.
+var standardEncodingHighSIDs = [95]uint8{
+	96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 0,
+	111, 112, 113, 114, 0, 115, 116, 117, 118, 119, 120, 121, 122, 0, 123, 0,
+	124, 125, 126, 127, 128, 129, 130, 131, 0, 132, 133, 0, 134, 135, 136, 137,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	138, 0, 139, 0, 0, 0, 0, 140, 141, 142, 143, 0, 0, 0, 0, 0,
+	144, 0, 0, 0, 145, 0, 0, 146, 147, 148, 149, 0, 0, 0, 0,
+}
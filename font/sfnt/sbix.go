@@ -0,0 +1,200 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"golang.org/x/image/math/fixed"
+)
+
+// This file implements the sbix (Standard Bitmap Graphics) table, as described
+// at https://www.microsoft.com/typography/otspec/sbix.htm
+
+// BitmapGlyph is a glyph's embedded bitmap image, for one strike: a set of
+// bitmaps designed for one size.
+type BitmapGlyph struct {
+	// Format is the image data's format, such as "png ", "jpg " or "tiff".
+	Format Tag
+	// Data is the encoded image data.
+	Data []byte
+	// OriginX and OriginY are the offset, in pixels at the strike's PPEM, of
+	// the image's bottom left corner from the glyph origin.
+	OriginX, OriginY int
+	// Width, Height and Advance are the image's size and the glyph's
+	// horizontal advance, in pixels at the strike's PPEM. They are zero if
+	// the font does not record them, in which case the size has to be
+	// decoded from the image data.
+	Width, Height, Advance int
+	// PPEM is the strike's number of pixels per em, and PPI is its number of
+	// pixels per inch. PPI is zero if the font does not record it.
+	PPEM, PPI int
+}
+
+// betterStrike returns whether a strike with candidatePPEM pixels per em is a
+// better match for ppem than one with bestPPEM pixels per em. The best match
+// is the smallest strike at least as large as ppem, if there is one, and the
+// largest strike otherwise. A negative bestPPEM means that there is no best
+// strike so far.
+func betterStrike(bestPPEM, candidatePPEM int, ppem fixed.Int26_6) bool {
+	return bestPPEM < 0 ||
+		(fixed.Int26_6(bestPPEM<<6) < ppem && candidatePPEM > bestPPEM) ||
+		(fixed.Int26_6(candidatePPEM<<6) >= ppem && candidatePPEM < bestPPEM)
+}
+
+var tagDupe = MustParseTag("dupe")
+
+// sbixInfo holds the location of the sbix table's strikes.
+type sbixInfo struct {
+	numStrikes int32
+}
+
+func (f *Font) parseSbix(buf []byte) ([]byte, error) {
+	if f.sbix.length == 0 {
+		return buf, nil
+	}
+	const headerSize = 8
+	if f.sbix.length < headerSize {
+		return nil, errInvalidSbixTable
+	}
+	buf, err := f.src.view(buf, int(f.sbix.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if u16(buf) != 1 {
+		return nil, errUnsupportedSbixTable
+	}
+	numStrikes := u32(buf[4:])
+	if numStrikes > (f.sbix.length-headerSize)/4 {
+		return nil, errInvalidSbixTable
+	}
+	f.cached.sbix = sbixInfo{numStrikes: int32(numStrikes)}
+	return buf, nil
+}
+
+// SbixGlyph returns the glyph x's bitmap image from the sbix table, from the
+// strike that best matches ppem, the number of pixels in 1 em. The best match
+// is the smallest strike at least as large as ppem, if there is one, and the
+// largest strike otherwise. Strikes that have no image for x are ignored.
+//
+// It returns nil if x has no sbix image. Such a glyph should be drawn from its
+// outline, if any.
+//
+// If b is non-nil, the image data becomes invalid to use once b is re-used.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) SbixGlyph(b *Buffer, x GlyphIndex, ppem fixed.Int26_6) (*BitmapGlyph, error) {
+	if err := f.skippedTable("sbix"); err != nil {
+		return nil, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return nil, ErrNotFound
+	}
+	if f.cached.sbix.numStrikes == 0 {
+		return nil, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	var (
+		bestStrike uint32
+		bestPPEM   = -1
+		bestPPI    int
+	)
+	for i := int32(0); i < f.cached.sbix.numStrikes; i++ {
+		strike, strikePPEM, strikePPI, err := f.sbixStrike(b, i)
+		if err != nil {
+			return nil, err
+		}
+		if !betterStrike(bestPPEM, strikePPEM, ppem) {
+			continue
+		}
+		if ok, err := f.sbixHasGlyph(b, strike, x); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		bestStrike, bestPPEM, bestPPI = strike, strikePPEM, strikePPI
+	}
+	if bestPPEM < 0 {
+		return nil, nil
+	}
+	g, err := f.sbixGlyph(b, bestStrike, x, true)
+	if err != nil || g == nil {
+		return nil, err
+	}
+	g.PPEM, g.PPI = bestPPEM, bestPPI
+	return g, nil
+}
+
+// sbixStrike returns the offset, relative to the start of the sbix table, and
+// the ppem and ppi of the i'th strike.
+func (f *Font) sbixStrike(b *Buffer, i int32) (offset uint32, ppem, ppi int, err error) {
+	buf, err := b.view(&f.src, int(f.sbix.offset)+8+4*int(i), 4)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	offset = u32(buf)
+	if offset > f.sbix.length || f.sbix.length-offset < 4+4*uint32(f.NumGlyphs()+1) {
+		return 0, 0, 0, errInvalidSbixTable
+	}
+	buf, err = b.view(&f.src, int(f.sbix.offset+offset), 4)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return offset, int(u16(buf)), int(u16(buf[2:])), nil
+}
+
+// sbixHasGlyph returns whether the strike at the given offset, relative to
+// the start of the sbix table, has an image for x.
+func (f *Font) sbixHasGlyph(b *Buffer, strike uint32, x GlyphIndex) (bool, error) {
+	buf, err := b.view(&f.src, int(f.sbix.offset+strike)+4+4*int(x), 8)
+	if err != nil {
+		return false, err
+	}
+	return u32(buf) != u32(buf[4:]), nil
+}
+
+// sbixGlyph returns the glyph x's image in the strike at the given offset,
+// relative to the start of the sbix table. It returns nil if that strike has
+// no image for x. If followDupe, a "dupe" image is replaced by the image of
+// the glyph that it refers to.
+func (f *Font) sbixGlyph(b *Buffer, strike uint32, x GlyphIndex, followDupe bool) (*BitmapGlyph, error) {
+	buf, err := b.view(&f.src, int(f.sbix.offset+strike)+4+4*int(x), 8)
+	if err != nil {
+		return nil, err
+	}
+	lo, hi := u32(buf), u32(buf[4:])
+	if lo > hi || hi > f.sbix.length-strike {
+		return nil, errInvalidSbixTable
+	}
+	if lo == hi {
+		return nil, nil
+	}
+	const headerSize = 8
+	if hi-lo < headerSize {
+		return nil, errInvalidSbixTable
+	}
+	buf, err = b.view(&f.src, int(f.sbix.offset+strike+lo), int(hi-lo))
+	if err != nil {
+		return nil, err
+	}
+	g := &BitmapGlyph{
+		Format:  Tag(u32(buf[4:])),
+		Data:    buf[headerSize:],
+		OriginX: int(int16(u16(buf))),
+		OriginY: int(int16(u16(buf[2:]))),
+	}
+	if g.Format != tagDupe {
+		return g, nil
+	}
+	if !followDupe || len(g.Data) != 2 {
+		return nil, errInvalidSbixTable
+	}
+	y := GlyphIndex(u16(g.Data))
+	if int(y) >= f.NumGlyphs() {
+		return nil, errInvalidSbixTable
+	}
+	return f.sbixGlyph(b, strike, y, false)
+}
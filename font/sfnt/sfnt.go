@@ -21,9 +21,13 @@ package sfnt // import "golang.org/x/image/font/sfnt"
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"math"
+	"sort"
 
 	"golang.org/x/image/font"
+	"golang.org/x/image/math/f64"
 	"golang.org/x/image/math/fixed"
 	"golang.org/x/text/encoding/charmap"
 )
@@ -45,11 +49,23 @@ const (
 	// safe to call concurrently, as long as each call has a different *Buffer.
 	maxCmapSegments = 20000
 
+	// maxColorPaintDepth and maxColorPaintNodes are arbitrary, but defend
+	// against malicious COLR tables whose paint graphs contain cycles, or
+	// share sub-graphs so that they expand to very large trees.
+	maxColorPaintDepth = 64
+	maxColorPaintNodes = 64 * 1024
+
 	maxGlyphDataLength  = 64 * 1024
 	maxHintBits         = 256
+	maxNumFonts         = 256
 	maxNumTables        = 256
 	maxRealNumberStrLen = 64 // Maximum length in bytes of the "-123.456E-7" representation.
 
+	// maxVariationAxes is arbitrary, but defends against malicious fvar and
+	// gvar tables. For reference, the OpenType specification's registered
+	// axes number five, and Adobe's AdobeVFPrototype.otf has two.
+	maxVariationAxes = 64
+
 	// (maxTableOffset + maxTableLength) will not overflow an int32.
 	maxTableLength = 1 << 29
 	maxTableOffset = 1 << 29
@@ -59,36 +75,87 @@ var (
 	// ErrNotFound indicates that the requested value was not found.
 	ErrNotFound = errors.New("sfnt: not found")
 
-	errInvalidBounds        = errors.New("sfnt: invalid bounds")
-	errInvalidCFFTable      = errors.New("sfnt: invalid CFF table")
-	errInvalidCmapTable     = errors.New("sfnt: invalid cmap table")
-	errInvalidGlyphData     = errors.New("sfnt: invalid glyph data")
-	errInvalidHeadTable     = errors.New("sfnt: invalid head table")
-	errInvalidKernTable     = errors.New("sfnt: invalid kern table")
-	errInvalidLocaTable     = errors.New("sfnt: invalid loca table")
-	errInvalidLocationData  = errors.New("sfnt: invalid location data")
-	errInvalidMaxpTable     = errors.New("sfnt: invalid maxp table")
-	errInvalidNameTable     = errors.New("sfnt: invalid name table")
-	errInvalidPostTable     = errors.New("sfnt: invalid post table")
-	errInvalidSourceData    = errors.New("sfnt: invalid source data")
-	errInvalidTableOffset   = errors.New("sfnt: invalid table offset")
-	errInvalidTableTagOrder = errors.New("sfnt: invalid table tag order")
-	errInvalidUCS2String    = errors.New("sfnt: invalid UCS-2 string")
-	errInvalidVersion       = errors.New("sfnt: invalid version")
-
-	errUnsupportedCFFVersion           = errors.New("sfnt: unsupported CFF version")
-	errUnsupportedCmapEncodings        = errors.New("sfnt: unsupported cmap encodings")
-	errUnsupportedCompoundGlyph        = errors.New("sfnt: unsupported compound glyph")
-	errUnsupportedGlyphDataLength      = errors.New("sfnt: unsupported glyph data length")
-	errUnsupportedKernTable            = errors.New("sfnt: unsupported kern table")
-	errUnsupportedRealNumberEncoding   = errors.New("sfnt: unsupported real number encoding")
-	errUnsupportedNumberOfCmapSegments = errors.New("sfnt: unsupported number of cmap segments")
-	errUnsupportedNumberOfHints        = errors.New("sfnt: unsupported number of hints")
-	errUnsupportedNumberOfTables       = errors.New("sfnt: unsupported number of tables")
-	errUnsupportedPlatformEncoding     = errors.New("sfnt: unsupported platform encoding")
-	errUnsupportedPostTable            = errors.New("sfnt: unsupported post table")
-	errUnsupportedTableOffsetLength    = errors.New("sfnt: unsupported table offset or length")
-	errUnsupportedType2Charstring      = errors.New("sfnt: unsupported Type 2 Charstring")
+	errInvalidAvarTable      = errors.New("sfnt: invalid avar table")
+	errInvalidBounds         = errors.New("sfnt: invalid bounds")
+	errInvalidCBDTTable      = errors.New("sfnt: invalid CBDT table")
+	errInvalidCBLCTable      = errors.New("sfnt: invalid CBLC table")
+	errInvalidCFFTable       = errors.New("sfnt: invalid CFF table")
+	errInvalidCOLRTable      = errors.New("sfnt: invalid COLR table")
+	errInvalidCPALTable      = errors.New("sfnt: invalid CPAL table")
+	errInvalidCmapTable      = errors.New("sfnt: invalid cmap table")
+	errInvalidFontCollection = errors.New("sfnt: invalid font collection")
+	errInvalidFvarTable      = errors.New("sfnt: invalid fvar table")
+	errInvalidGDEFTable      = errors.New("sfnt: invalid GDEF table")
+	errInvalidGPOSTable      = errors.New("sfnt: invalid GPOS table")
+	errInvalidGSUBTable      = errors.New("sfnt: invalid GSUB table")
+	errInvalidGlyphData      = errors.New("sfnt: invalid glyph data")
+	errInvalidGvarTable      = errors.New("sfnt: invalid gvar table")
+	errInvalidHeadTable      = errors.New("sfnt: invalid head table")
+	errInvalidHinting        = errors.New("sfnt: invalid hinting instructions")
+	errInvalidHheaTable      = errors.New("sfnt: invalid hhea table")
+	errInvalidHmtxTable      = errors.New("sfnt: invalid hmtx table")
+	errInvalidKernTable      = errors.New("sfnt: invalid kern table")
+	errInvalidKerxTable      = errors.New("sfnt: invalid kerx table")
+	errInvalidLocaTable      = errors.New("sfnt: invalid loca table")
+	errInvalidLocationData   = errors.New("sfnt: invalid location data")
+	errInvalidMaxpTable      = errors.New("sfnt: invalid maxp table")
+	errInvalidMorxTable      = errors.New("sfnt: invalid morx table")
+	errInvalidNameTable      = errors.New("sfnt: invalid name table")
+	errInvalidOS2Table       = errors.New("sfnt: invalid OS/2 table")
+	errInvalidPostTable      = errors.New("sfnt: invalid post table")
+	errInvalidSTATTable      = errors.New("sfnt: invalid STAT table")
+	errInvalidSVGTable       = errors.New("sfnt: invalid SVG table")
+	errInvalidSbixTable      = errors.New("sfnt: invalid sbix table")
+	errInvalidSingleFont     = errors.New("sfnt: invalid single font (data is a font collection)")
+	errInvalidSourceData     = errors.New("sfnt: invalid source data")
+	errInvalidTableOffset    = errors.New("sfnt: invalid table offset")
+	errInvalidTableTagOrder  = errors.New("sfnt: invalid table tag order")
+	errInvalidUCS2String     = errors.New("sfnt: invalid UCS-2 string")
+	errInvalidTag            = errors.New("sfnt: invalid tag")
+	errInvalidTrakTable      = errors.New("sfnt: invalid trak table")
+	errInvalidVersion        = errors.New("sfnt: invalid version")
+	errInvalidVheaTable      = errors.New("sfnt: invalid vhea table")
+	errInvalidVmtxTable      = errors.New("sfnt: invalid vmtx table")
+	errInvalidWOFF           = errors.New("sfnt: invalid WOFF data")
+	errInvalidWOFF2          = errors.New("sfnt: invalid WOFF2 data")
+
+	errMissingTablePadding = errors.New("sfnt: missing table padding")
+	errOverlappingTables   = errors.New("sfnt: overlapping tables")
+
+	errUnsupportedCBDTTable             = errors.New("sfnt: unsupported CBDT table")
+	errUnsupportedCBLCTable             = errors.New("sfnt: unsupported CBLC table")
+	errUnsupportedCFFSubset             = errors.New("sfnt: unsupported CFF subset")
+	errUnsupportedCFFVersion            = errors.New("sfnt: unsupported CFF version")
+	errUnsupportedCOLRTable             = errors.New("sfnt: unsupported COLR table")
+	errUnsupportedCPALTable             = errors.New("sfnt: unsupported CPAL table")
+	errUnsupportedCmapEncodings         = errors.New("sfnt: unsupported cmap encodings")
+	errUnsupportedCompoundGlyph         = errors.New("sfnt: unsupported compound glyph")
+	errUnsupportedGDEFTable             = errors.New("sfnt: unsupported GDEF table")
+	errUnsupportedGPOSTable             = errors.New("sfnt: unsupported GPOS table")
+	errUnsupportedGSUBTable             = errors.New("sfnt: unsupported GSUB table")
+	errUnsupportedFvarTable             = errors.New("sfnt: unsupported fvar table")
+	errUnsupportedGvarTable             = errors.New("sfnt: unsupported gvar table")
+	errUnsupportedGlyphDataLength       = errors.New("sfnt: unsupported glyph data length")
+	errUnsupportedHinting               = errors.New("sfnt: unsupported hinting instructions")
+	errUnsupportedKernTable             = errors.New("sfnt: unsupported kern table")
+	errUnsupportedRealNumberEncoding    = errors.New("sfnt: unsupported real number encoding")
+	errUnsupportedSTATTable             = errors.New("sfnt: unsupported STAT table")
+	errUnsupportedSVGTable              = errors.New("sfnt: unsupported SVG table")
+	errUnsupportedSbixTable             = errors.New("sfnt: unsupported sbix table")
+	errUnsupportedNumberOfCmapSegments  = errors.New("sfnt: unsupported number of cmap segments")
+	errUnsupportedNumberOfFontDicts     = errors.New("sfnt: unsupported number of font dicts")
+	errUnsupportedNumberOfColorPaints   = errors.New("sfnt: unsupported number of color paints")
+	errUnsupportedNumberOfFonts         = errors.New("sfnt: unsupported number of fonts")
+	errUnsupportedNumberOfHints         = errors.New("sfnt: unsupported number of hints")
+	errUnsupportedNumberOfTables        = errors.New("sfnt: unsupported number of tables")
+	errUnsupportedNumberOfVariationAxes = errors.New("sfnt: unsupported number of variation axes")
+	errUnsupportedPlatformEncoding      = errors.New("sfnt: unsupported platform encoding")
+	errUnsupportedPostTable             = errors.New("sfnt: unsupported post table")
+	errUnsupportedTableOffsetLength     = errors.New("sfnt: unsupported table offset or length")
+	errUnsupportedTrakTable             = errors.New("sfnt: unsupported trak table")
+	errUnsupportedType2Charstring       = errors.New("sfnt: unsupported Type 2 Charstring")
+	errUnsupportedVheaTable             = errors.New("sfnt: unsupported vhea table")
+	errUnsupportedWOFF2                 = errors.New("sfnt: unsupported WOFF2 data")
 )
 
 // GlyphIndex is a glyph index in a Font.
@@ -128,6 +195,44 @@ const (
 	NameIDVariationsPostScriptPrefix        = 25
 )
 
+// Tag is a 4-byte identifier, such as a table name ("glyf") or a variation
+// axis name ("wght"), packed big-endian into a uint32.
+type Tag uint32
+
+// MustParseTag is like ParseTag but panics if s is not a valid tag.
+func MustParseTag(s string) Tag {
+	t, err := ParseTag(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ParseTag parses a 4-byte tag such as "wght" or "OS/2". Tags shorter than 4
+// bytes are padded with spaces, so that ParseTag("cvt") == ParseTag("cvt ").
+func ParseTag(s string) (Tag, error) {
+	if len(s) == 0 || len(s) > 4 {
+		return 0, errInvalidTag
+	}
+	t := Tag(0)
+	for i := 0; i < 4; i++ {
+		c := byte(' ')
+		if i < len(s) {
+			c = s[i]
+		}
+		if c < 0x20 || 0x7e < c {
+			return 0, errInvalidTag
+		}
+		t = t<<8 | Tag(c)
+	}
+	return t, nil
+}
+
+// String returns the tag's 4-byte string representation.
+func (t Tag) String() string {
+	return string([]byte{byte(t >> 24), byte(t >> 16), byte(t >> 8), byte(t)})
+}
+
 // Units are an integral number of abstract, scalable "font units". The em
 // square is typically 1000 or 2048 "font units". This would map to a certain
 // number (e.g. 30 pixels) of physical pixels, depending on things like the
@@ -165,6 +270,8 @@ func u32(b []byte) uint32 {
 type source struct {
 	b []byte
 	r io.ReaderAt
+	// size is the length of r's data, in bytes, or negative if unknown.
+	size int64
 
 	// TODO: add a caching layer, if we're using the io.ReaderAt? Note that
 	// this might make a source no longer safe to use concurrently.
@@ -204,7 +311,11 @@ func (s *source) view(buf []byte, offset, length int) ([]byte, error) {
 		return s.b[offset : offset+length], nil
 	}
 
-	// Read from the io.ReaderAt.
+	// Read from the io.ReaderAt. If its size is known, reject out of bounds
+	// reads before allocating a (possibly large) buffer for them.
+	if s.size >= 0 && int64(offset)+int64(length) > s.size {
+		return nil, errInvalidBounds
+	}
 	if length <= cap(buf) {
 		buf = buf[:length]
 	} else {
@@ -254,19 +365,109 @@ type table struct {
 	offset, length uint32
 }
 
-// Parse parses an SFNT font from a []byte data source.
+// Parse parses an SFNT font, such as TTF or OTF data, from a []byte data
+// source. For font collections, such as TTC data, use ParseCollection.
+//
+// The data may also be a WOFF 1.0 or WOFF 2.0 web font, whose tables are
+// decompressed into memory.
+//
+// Optional tables that can't be parsed, such as GSUB, COLR or SVG tables of
+// an unknown version, don't fail the parse. They are skipped, and the methods
+// that use them, such as SubstituteGlyph, ColorLayers or SVGDocument, return
+// a *TableError instead.
 func Parse(src []byte) (*Font, error) {
 	f := &Font{src: source{b: src}}
-	if err := f.initialize(); err != nil {
+	if err := f.initialize(0); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ParseOptions are optional arguments to ParseWithOptions.
+type ParseOptions struct {
+	// Permissive is whether to tolerate common violations of the SFNT
+	// specification that don't prevent using the font, recording them as
+	// warnings, available from Font.ParseWarnings, instead of failing. The
+	// tolerated violations are:
+	//   - table records that are not sorted by tag,
+	//   - tables that don't start on a 4-byte boundary,
+	//   - tables that extend by up to 3 bytes past the end of the data, as
+	//     the final table's padding is missing,
+	//   - tables that overlap, which Parse also accepts, and
+	//   - an invalid or unsupported kern table, which is then ignored.
+	//
+	// Other optional tables, such as GSUB, are skipped whether or not the
+	// parse is permissive. See Parse.
+	//
+	// Problems with the required tables, such as head, hhea, maxp, cmap and
+	// post, or with the glyph outlines' tables, are still errors.
+	Permissive bool
+}
+
+// TableError is an error parsing a font's table.
+type TableError struct {
+	// Tag is the table's tag.
+	Tag Tag
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TableError) Error() string {
+	return fmt.Sprintf("%v (%q table)", e.Err, e.Tag)
+}
+
+// Unwrap returns e.Err.
+func (e *TableError) Unwrap() error { return e.Err }
+
+// ParseWithOptions is like Parse, with optional arguments. A nil opts is
+// equivalent to a zero ParseOptions.
+//
+// Unlike Parse, when it fails due to a problem with a particular table, the
+// error is a *TableError that identifies that table.
+func ParseWithOptions(src []byte, opts *ParseOptions) (*Font, error) {
+	if opts == nil {
+		opts = &ParseOptions{}
+	}
+	f := &Font{src: source{b: src}}
+	f.cached.parseOptions = opts
+	if err := f.initialize(0); err != nil {
 		return nil, err
 	}
 	return f, nil
 }
 
+// ParseWarnings returns the violations of the SFNT specification that were
+// tolerated when parsing f with a permissive ParseWithOptions, and the
+// optional tables that were skipped. Each warning is a *TableError.
+func (f *Font) ParseWarnings() []error { return f.cached.parseWarnings }
+
 // ParseReaderAt parses an SFNT font from an io.ReaderAt data source.
+//
+// As for Parse, the data may also be a WOFF 1.0 or WOFF 2.0 web font.
+//
+// If src has a Size method, such as *bytes.Reader and *io.SectionReader do,
+// it is used as per ParseReaderAtSize.
 func ParseReaderAt(src io.ReaderAt) (*Font, error) {
-	f := &Font{src: source{r: src}}
-	if err := f.initialize(); err != nil {
+	size := int64(-1)
+	if s, ok := src.(interface{ Size() int64 }); ok {
+		size = s.Size()
+	}
+	return ParseReaderAtSize(src, size)
+}
+
+// ParseReaderAtSize parses an SFNT font from an io.ReaderAt data source of
+// the given size, in bytes. A negative size means that the size is unknown.
+//
+// Table data is read from src lazily, when it is needed, instead of all at
+// once, so that using a few glyphs of a large font, such as a CJK font in a
+// memory-mapped file, does not require reading the whole font into memory.
+// Reads beyond size fail without calling src's ReadAt method.
+//
+// WOFF 1.0 and WOFF 2.0 web fonts are also accepted, but their tables are
+// decompressed into memory.
+func ParseReaderAtSize(src io.ReaderAt, size int64) (*Font, error) {
+	f := &Font{src: source{r: src, size: size}}
+	if err := f.initialize(0); err != nil {
 		return nil, err
 	}
 	return f, nil
@@ -289,6 +490,9 @@ func ParseReaderAt(src io.ReaderAt) (*Font, error) {
 // The Font methods that don't take a *Buffer argument are always safe to call
 // concurrently.
 //
+// To share cached glyph indexes, advances and outlines between goroutines, use
+// a CachedFont.
+//
 // Some methods provide lengths or coordinates, e.g. bounds, font metrics and
 // control points. All of these methods take a ppem parameter, which is the
 // number of pixels in 1 em, expressed as a 26.6 fixed point value. For
@@ -302,6 +506,9 @@ func ParseReaderAt(src io.ReaderAt) (*Font, error) {
 // further scaling necessary.
 type Font struct {
 	src source
+	// offset is the offset of the font's table directory in src. It is
+	// non-zero for some of the fonts in a Collection.
+	offset int
 
 	// https://www.microsoft.com/typography/otspec/otff.htm#otttables
 	// "Required Tables".
@@ -317,40 +524,139 @@ type Font struct {
 	// https://www.microsoft.com/typography/otspec/otff.htm#otttables
 	// "Tables Related to TrueType Outlines".
 	//
-	// This implementation does not support hinting, so it does not read the
-	// cvt, fpgm gasp or prep tables.
+	// The cvt, fpgm and prep tables are interpreted when hinting. This
+	// implementation does not interpret the gasp table, but Subset copies it.
+	cvt  table
+	fpgm table
+	gasp table
 	glyf table
 	loca table
+	prep table
 
 	// https://www.microsoft.com/typography/otspec/otff.htm#otttables
 	// "Tables Related to PostScript Outlines".
 	//
-	// TODO: cff2, vorg?
-	cff table
+	// TODO: vorg?
+	cff  table
+	cff2 table
 
 	// https://www.microsoft.com/typography/otspec/otff.htm#otttables
 	// "Advanced Typographic Tables".
 	//
-	// TODO: base, gdef, gpos, gsub, jstf, math?
+	// TODO: base, jstf, math?
+	gdef table
+	gpos table
+	gsub table
+
+	// https://www.microsoft.com/typography/otspec/otff.htm#otttables
+	// "Tables Related to Color Fonts".
+	//
+	cbdt table
+	cblc table
+	colr table
+	cpal table
+	sbix table
+	svg  table
 
 	// https://www.microsoft.com/typography/otspec/otff.htm#otttables
 	// "Other OpenType Tables".
 	//
-	// TODO: hdmx, vmtx? Others?
+	// TODO: hdmx? Others?
 	kern table
+	vhea table
+	vmtx table
+
+	// https://www.microsoft.com/typography/otspec/otvaroverview.htm
+	// "OpenType Font Variations".
+	//
+	// TODO: cvar, HVAR, MVAR, VVAR?
+	avar table
+	fvar table
+	gvar table
+	stat table
+
+	// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6.html
+	// "Apple Advanced Typography Tables".
+	//
+	// TODO: ankr, feat?
+	kerx table
+	morx table
+	trak table
 
 	cached struct {
+		cblcStrikes      []cblcStrike
+		cff              cffInfo
+		colr             colrInfo
+		cpal             cpalInfo
+		gdef             gdefInfo
 		glyphIndex       func(f *Font, b *Buffer, r rune) (GlyphIndex, error)
+		gpos             layoutTable
+		gsub             layoutTable
 		indexToLocFormat bool // false means short, true means long.
 		isPostScript     bool
+		kernClasses      kernClassInfo
 		kernNumPairs     int32
 		kernOffset       int32
+		kerxSubtables    []kerxSubtable
+		morxSubtables    []table
+		numHMetrics      int32
 		postTableVersion uint32
+		sbix             sbixInfo
+		symbolCmap       bool
+		stat             statInfo
+		svg              svgInfo
+		trak             trakInfo
 		unitsPerEm       Units
+		vhea             vheaInfo
+
+		// gposKern holds the subtables of the GPOS table's "kern" feature's
+		// pair adjustment lookups, one element per lookup. gposCursive,
+		// gposMarkBase and gposMarkMark likewise hold the "curs" feature's
+		// cursive attachment lookups, the "mark" feature's mark-to-base
+		// attachment lookups and the "mkmk" feature's mark-to-mark
+		// attachment lookups.
+		gposKern     [][]uint32
+		gposCursive  [][]uint32
+		gposMarkBase [][]uint32
+		gposMarkMark [][]uint32
+
+		// gsubFeatures maps each GSUB feature tag to the indexes of its
+		// lookups. gsubLookups holds the subtables of all of the GSUB
+		// table's lookups, indexed by lookup index. Lookups that aren't
+		// supported have no subtables.
+		gsubFeatures map[Tag][]uint16
+		gsubLookups  []lookupSubtables
+
+		// variationAxes, namedInstances and avarSegments are parsed from the
+		// fvar and avar tables. There is one avarSegments element per axis,
+		// possibly nil.
+		variationAxes  []VariationAxis
+		namedInstances []fvarInstance
+		avarSegments   [][]avarMapping
+
+		// gvar holds the location of the gvar table's sub-structures.
+		gvar gvarInfo
+
+		// normalizedCoords are the variation coordinates set by
+		// Font.WithVariations, in the normalized [-1, +1] range. A nil
+		// value means the font's default instance.
+		normalizedCoords []float64
 
 		// The glyph data for the glyph index i is in
 		// src[locations[i+0]:locations[i+1]].
 		locations []uint32
+
+		// parseOptions is non-nil if f was parsed by ParseWithOptions.
+		// parseWarnings holds the violations that a permissive parse
+		// tolerated, and unsortedTables is whether one of those was that the
+		// table records are not sorted by tag.
+		parseOptions   *ParseOptions
+		parseWarnings  []error
+		unsortedTables bool
+
+		// skippedTables holds the errors of the optional tables, such as
+		// GSUB or COLR, that failed to parse and were skipped.
+		skippedTables []*TableError
 	}
 }
 
@@ -360,11 +666,14 @@ func (f *Font) NumGlyphs() int { return len(f.cached.locations) - 1 }
 // UnitsPerEm returns the number of units per em for f.
 func (f *Font) UnitsPerEm() Units { return f.cached.unitsPerEm }
 
-func (f *Font) initialize() error {
+func (f *Font) initialize(offset int) error {
 	if !f.src.valid() {
 		return errInvalidSourceData
 	}
-	buf, err := f.initializeTables(nil)
+	if err := f.unwrap(); err != nil {
+		return err
+	}
+	buf, err := f.initializeTables(offset, nil)
 	if err != nil {
 		return err
 	}
@@ -375,44 +684,135 @@ func (f *Font) initialize() error {
 
 	// TODO: make state dependencies explicit instead of implicit.
 
-	buf, err = f.parseHead(buf)
-	if err != nil {
-		return err
+	parsers := [...]struct {
+		tag   string
+		t     *table
+		parse func(buf []byte) ([]byte, error)
+		kind  tableKind
+	}{
+		{"head", &f.head, f.parseHead, tableRequired},
+		{"hhea", &f.hhea, f.parseHhea, tableRequired},
+		{"maxp", &f.maxp, f.parseMaxp, tableRequired},
+		{"cmap", &f.cmap, f.parseCmap, tableRequired},
+		{"kern", &f.kern, f.parseKern, tableOptional},
+		{"kerx", &f.kerx, f.parseKerx, tableSkippable},
+		{"morx", &f.morx, f.parseMorx, tableSkippable},
+		{"trak", &f.trak, f.parseTrak, tableSkippable},
+		{"vhea", &f.vhea, f.parseVhea, tableSkippable},
+		{"GDEF", &f.gdef, f.parseGDEF, tableSkippable},
+		{"GPOS", &f.gpos, f.parseGPOS, tableSkippable},
+		{"GSUB", &f.gsub, f.parseGSUB, tableSkippable},
+		{"COLR", &f.colr, f.parseCOLR, tableSkippable},
+		{"CPAL", &f.cpal, f.parseCPAL, tableSkippable},
+		{"SVG ", &f.svg, f.parseSVG, tableSkippable},
+		{"sbix", &f.sbix, f.parseSbix, tableSkippable},
+		{"CBLC", &f.cblc, f.parseCBLC, tableSkippable},
+		{"post", &f.post, f.parsePost, tableRequired},
+		{"fvar", &f.fvar, f.parseFvar, tableSkippable},
+		{"avar", &f.avar, f.parseAvar, tableSkippable},
+		{"STAT", &f.stat, f.parseSTAT, tableSkippable},
+		{"gvar", &f.gvar, f.parseGvar, tableSkippable},
+	}
+	opts := f.cached.parseOptions
+	for _, p := range parsers {
+		cached := f.cached
+		buf, err = p.parse(buf)
+		if err == nil {
+			continue
+		}
+		if p.kind == tableSkippable {
+			// Skip the table, undoing any partial parse. Its accessors
+			// return err instead.
+			f.cached = cached
+			e := &TableError{Tag: MustParseTag(p.tag), Err: err}
+			f.cached.skippedTables = append(f.cached.skippedTables, e)
+			f.cached.parseWarnings = append(f.cached.parseWarnings, e)
+			*p.t = table{}
+			continue
+		}
+		if opts == nil {
+			return err
+		}
+		err = &TableError{Tag: MustParseTag(p.tag), Err: err}
+		if !opts.Permissive || p.kind != tableOptional {
+			return err
+		}
+		// Ignore the optional table, undoing any partial parse.
+		f.cached = cached
+		f.cached.parseWarnings = append(f.cached.parseWarnings, err)
+		*p.t = table{}
 	}
-	buf, err = f.parseMaxp(buf)
-	if err != nil {
-		return err
+	return nil
+}
+
+// tableKind is how a failure to parse a table is handled.
+type tableKind uint8
+
+const (
+	// tableRequired tables fail the parse.
+	tableRequired tableKind = iota
+	// tableOptional tables fail the parse, unless it is permissive, in
+	// which case they are ignored.
+	tableOptional
+	// tableSkippable tables are always ignored, and the error is returned
+	// by the methods that use them.
+	tableSkippable
+)
+
+// skippedTable returns the error that the tag table failed to parse with, or
+// nil if it was parsed or is absent.
+func (f *Font) skippedTable(tag string) error {
+	for _, e := range f.cached.skippedTables {
+		if e.Tag == MustParseTag(tag) {
+			return e
+		}
 	}
-	buf, err = f.parseCmap(buf)
+	return nil
+}
+
+// unwrap replaces f's source, if it is in a web font format such as WOFF or
+// WOFF2, by the SFNT font data that it wraps.
+func (f *Font) unwrap() error {
+	buf, err := f.src.view(nil, 0, 4)
 	if err != nil {
 		return err
 	}
-	buf, err = f.parseKern(buf)
-	if err != nil {
-		return err
+	var data []byte
+	switch u32(buf) {
+	case woffSignature:
+		data, err = decodeWOFF(&f.src)
+	case woff2Signature:
+		data, err = decodeWOFF2(&f.src)
+	default:
+		return nil
 	}
-	buf, err = f.parsePost(buf)
 	if err != nil {
 		return err
 	}
+	f.src = source{b: data}
 	return nil
 }
 
-func (f *Font) initializeTables(buf []byte) ([]byte, error) {
+func (f *Font) initializeTables(offset int, buf []byte) ([]byte, error) {
 	// https://www.microsoft.com/typography/otspec/otff.htm "Organization of an
 	// OpenType Font" says that "The OpenType font starts with the Offset
 	// Table", which is 12 bytes.
-	buf, err := f.src.view(buf, 0, 12)
+	f.offset = offset
+	buf, err := f.src.view(buf, offset, 12)
 	if err != nil {
 		return nil, err
 	}
+	// When updating the cases in this switch statement, also update the
+	// Collection.initialize method.
 	switch u32(buf) {
 	default:
 		return nil, errInvalidVersion
-	case 0x00010000:
+	case 0x00010000, 0x74727565: // "\x00\x01\x00\x00", "true".
 		// No-op.
 	case 0x4f54544f: // "OTTO".
 		f.cached.isPostScript = true
+	case 0x74746366: // "ttcf".
+		return nil, errInvalidSingleFont
 	}
 	numTables := int(u16(buf[4:]))
 	if numTables > maxNumTables {
@@ -421,39 +821,103 @@ func (f *Font) initializeTables(buf []byte) ([]byte, error) {
 
 	// "The Offset Table is followed immediately by the Table Record entries...
 	// sorted in ascending order by tag", 16 bytes each.
-	buf, err = f.src.view(buf, 12, 16*numTables)
+	buf, err = f.src.view(buf, offset+12, 16*numTables)
 	if err != nil {
 		return nil, err
 	}
+	opts := f.cached.parseOptions
+	// tableErr returns err, identifying the tag table if f is being parsed
+	// by ParseWithOptions.
+	tableErr := func(tag uint32, err error) error {
+		if opts == nil {
+			return err
+		}
+		return &TableError{Tag: Tag(tag), Err: err}
+	}
+	// tolerate returns the error for a violation by the tag table, or nil if
+	// the violation is tolerated and recorded as a warning.
+	tolerate := func(tag uint32, err error) error {
+		err = tableErr(tag, err)
+		if opts == nil || !opts.Permissive {
+			return err
+		}
+		f.cached.parseWarnings = append(f.cached.parseWarnings, err)
+		return nil
+	}
+	var records []tableRecord
 	for b, first, prevTag := buf, true, uint32(0); len(b) > 0; b = b[16:] {
 		tag := u32(b)
 		if first {
 			first = false
 		} else if tag <= prevTag {
-			return nil, errInvalidTableTagOrder
+			if err := tolerate(tag, errInvalidTableTagOrder); err != nil {
+				return nil, err
+			}
+			f.cached.unsortedTables = true
 		}
 		prevTag = tag
 
 		o, n := u32(b[8:12]), u32(b[12:16])
 		if o > maxTableOffset || n > maxTableLength {
-			return nil, errUnsupportedTableOffsetLength
+			return nil, tableErr(tag, errUnsupportedTableOffsetLength)
 		}
 		// We ignore the checksums, but "all tables must begin on four byte
 		// boundries [sic]".
 		if o&3 != 0 {
-			return nil, errInvalidTableOffset
+			if err := tolerate(tag, errInvalidTableOffset); err != nil {
+				return nil, err
+			}
+		}
+		if opts != nil && opts.Permissive {
+			if m := f.clampTableLength(o, n); m != n {
+				tolerate(tag, errMissingTablePadding)
+				n = m
+			}
+			records = append(records, tableRecord{tag, table{o, n}})
 		}
 
 		// Match the 4-byte tag as a uint32. For example, "OS/2" is 0x4f532f32.
 		switch tag {
+		case 0x43424454:
+			f.cbdt = table{o, n}
+		case 0x43424c43:
+			f.cblc = table{o, n}
 		case 0x43464620:
 			f.cff = table{o, n}
+		case 0x43464632:
+			f.cff2 = table{o, n}
+		case 0x434f4c52:
+			f.colr = table{o, n}
+		case 0x4350414c:
+			f.cpal = table{o, n}
+		case 0x47444546:
+			f.gdef = table{o, n}
+		case 0x47504f53:
+			f.gpos = table{o, n}
+		case 0x47535542:
+			f.gsub = table{o, n}
 		case 0x4f532f32:
 			f.os2 = table{o, n}
+		case 0x53544154:
+			f.stat = table{o, n}
+		case 0x53564720:
+			f.svg = table{o, n}
 		case 0x636d6170:
 			f.cmap = table{o, n}
+		case 0x63767420:
+			f.cvt = table{o, n}
+		case 0x61766172:
+			f.avar = table{o, n}
+		case 0x6670676d:
+			f.fpgm = table{o, n}
+		case 0x66766172:
+			f.fvar = table{o, n}
+		case 0x67617370:
+			f.gasp = table{o, n}
 		case 0x676c7966:
 			f.glyf = table{o, n}
+		case 0x67766172:
+			f.gvar = table{o, n}
 		case 0x68656164:
 			f.head = table{o, n}
 		case 0x68686561:
@@ -462,19 +926,68 @@ func (f *Font) initializeTables(buf []byte) ([]byte, error) {
 			f.hmtx = table{o, n}
 		case 0x6b65726e:
 			f.kern = table{o, n}
+		case 0x6b657278:
+			f.kerx = table{o, n}
 		case 0x6c6f6361:
 			f.loca = table{o, n}
 		case 0x6d617870:
 			f.maxp = table{o, n}
+		case 0x6d6f7278:
+			f.morx = table{o, n}
 		case 0x6e616d65:
 			f.name = table{o, n}
 		case 0x706f7374:
 			f.post = table{o, n}
+		case 0x70726570:
+			f.prep = table{o, n}
+		case 0x73626978:
+			f.sbix = table{o, n}
+		case 0x7472616b:
+			f.trak = table{o, n}
+		case 0x76686561:
+			f.vhea = table{o, n}
+		case 0x766d7478:
+			f.vmtx = table{o, n}
+		}
+	}
+
+	if len(records) > 1 {
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].offset < records[j].offset
+		})
+		for i, prevEnd := 1, records[0].offset+records[0].length; i < len(records); i++ {
+			r := records[i]
+			if r.offset < prevEnd && r.length != 0 {
+				tolerate(r.tag, errOverlappingTables)
+			}
+			if end := r.offset + r.length; prevEnd < end {
+				prevEnd = end
+			}
 		}
 	}
 	return buf, nil
 }
 
+// tableRecord is a table and its tag.
+type tableRecord struct {
+	tag uint32
+	table
+}
+
+// clampTableLength returns the length of the table at offset o with length n,
+// reduced if the table extends by up to 3 bytes past the end of f's data. Such
+// a table is usually the last table, with its padding missing.
+func (f *Font) clampTableLength(o, n uint32) uint32 {
+	size := f.src.size
+	if f.src.b != nil {
+		size = int64(len(f.src.b))
+	}
+	if end := int64(o) + int64(n); size >= 0 && size < end && end-size <= 3 {
+		return n - uint32(end-size)
+	}
+	return n
+}
+
 func (f *Font) parseCmap(buf []byte) ([]byte, error) {
 	// https://www.microsoft.com/typography/OTSPEC/cmap.htm
 
@@ -492,14 +1005,17 @@ func (f *Font) parseCmap(buf []byte) ([]byte, error) {
 	}
 
 	var (
-		bestWidth  int
+		bestRank   int
 		bestOffset uint32
 		bestLength uint32
 		bestFormat uint16
+		bestPID    uint16
+		bestPSID   uint16
 	)
 
 	// Scan all of the subtables, picking the widest supported one. See the
-	// platformEncodingWidth comment for more discussion of width.
+	// platformEncodingWidth comment for more discussion of width. For equal
+	// widths, Unicode encodings are preferred over legacy CJK encodings.
 	for i := 0; i < numSubtables; i++ {
 		buf, err = f.src.view(buf, int(f.cmap.offset)+headerSize+entrySize*i, entrySize)
 		if err != nil {
@@ -507,8 +1023,11 @@ func (f *Font) parseCmap(buf []byte) ([]byte, error) {
 		}
 		pid := u16(buf)
 		psid := u16(buf[2:])
-		width := platformEncodingWidth(pid, psid)
-		if width <= bestWidth {
+		rank := 2 * platformEncodingWidth(pid, psid)
+		if rank != 0 && legacyCmapEncoding(pid, psid) == nil {
+			rank++
+		}
+		if rank <= bestRank {
 			continue
 		}
 		offset := u32(buf[4:])
@@ -526,16 +1045,19 @@ func (f *Font) parseCmap(buf []byte) ([]byte, error) {
 		}
 		length := uint32(u16(buf[2:]))
 
-		bestWidth = width
+		bestRank = rank
 		bestOffset = offset
 		bestLength = length
 		bestFormat = format
+		bestPID = pid
+		bestPSID = psid
 	}
 
-	if bestWidth == 0 {
+	if bestRank == 0 {
 		return nil, errUnsupportedCmapEncodings
 	}
-	return f.makeCachedGlyphIndex(buf, bestOffset, bestLength, bestFormat)
+	f.cached.symbolCmap = bestPID == pidWindows && bestPSID == psidWindowsSymbol
+	return f.makeCachedGlyphIndex(buf, bestOffset, bestLength, bestFormat, bestPID, bestPSID)
 }
 
 func (f *Font) parseHead(buf []byte) ([]byte, error) {
@@ -560,6 +1082,23 @@ func (f *Font) parseHead(buf []byte) ([]byte, error) {
 	return buf, nil
 }
 
+func (f *Font) parseHhea(buf []byte) ([]byte, error) {
+	// https://www.microsoft.com/typography/OTSPEC/hhea.htm
+
+	if f.hhea.length != 36 {
+		return nil, errInvalidHheaTable
+	}
+	u, err := f.src.u16(buf, f.hhea, 34)
+	if err != nil {
+		return nil, err
+	}
+	if u == 0 || f.hmtx.length < 4*uint32(u) {
+		return nil, errInvalidHmtxTable
+	}
+	f.cached.numHMetrics = int32(u)
+	return buf, nil
+}
+
 func (f *Font) parseKern(buf []byte) ([]byte, error) {
 	// https://www.microsoft.com/typography/otspec/kern.htm
 
@@ -579,11 +1118,7 @@ func (f *Font) parseKern(buf []byte) ([]byte, error) {
 
 	switch version := u16(buf); version {
 	case 0:
-		// TODO: support numTables != 1. Testing that requires finding such a font.
-		if numTables := int(u16(buf[2:])); numTables != 1 {
-			return nil, errUnsupportedKernTable
-		}
-		return f.parseKernVersion0(buf, offset, length)
+		return f.parseKernVersion0(buf, offset, length, int(u16(buf[2:])))
 	case 1:
 		// TODO: find such a (proprietary?) font, and support it. Both of
 		// https://www.microsoft.com/typography/otspec/kern.htm
@@ -593,8 +1128,51 @@ func (f *Font) parseKern(buf []byte) ([]byte, error) {
 	return nil, errUnsupportedKernTable
 }
 
-func (f *Font) parseKernVersion0(buf []byte, offset, length int) ([]byte, error) {
+// parseKernVersion0 finds the first of the numTables subtables that holds
+// horizontal kerning values in a supported format: format 0 (ordered pairs) or
+// format 2 (class based). Subtables in other formats, such as format 1 (state
+// tables) and format 3 (compact class based), are skipped, as are vertical,
+// cross-stream and minimum value subtables.
+//
+// TODO: sum the values of multiple supported subtables, as per the spec.
+// Testing that requires finding such a font.
+func (f *Font) parseKernVersion0(buf []byte, offset, length, numTables int) ([]byte, error) {
 	const headerSize = 6
+	for ; numTables > 0; numTables-- {
+		if length < headerSize {
+			return nil, errInvalidKernTable
+		}
+		var err error
+		buf, err = f.src.view(buf, offset, headerSize)
+		if err != nil {
+			return nil, err
+		}
+		if version := u16(buf); version != 0 {
+			return nil, errUnsupportedKernTable
+		}
+		subtableLength := int(u16(buf[2:]))
+		if subtableLength < headerSize || length < subtableLength {
+			return nil, errInvalidKernTable
+		}
+
+		// The coverage bits are 0x01 for horizontal, 0x02 for minimum values
+		// and 0x04 for cross-stream.
+		if coverageBits := buf[5]; coverageBits&0x07 == 0x01 {
+			switch format := buf[4]; format {
+			case 0:
+				return f.parseKernFormat0(buf, offset+headerSize, subtableLength-headerSize)
+			case 2:
+				return f.parseKernFormat2(buf, offset, subtableLength)
+			}
+		}
+		offset += subtableLength
+		length -= subtableLength
+	}
+	return buf, nil
+}
+
+func (f *Font) parseKernFormat0(buf []byte, offset, length int) ([]byte, error) {
+	const headerSize, entrySize = 8, 6
 	if length < headerSize {
 		return nil, errInvalidKernTable
 	}
@@ -602,32 +1180,29 @@ func (f *Font) parseKernVersion0(buf []byte, offset, length int) ([]byte, error)
 	if err != nil {
 		return nil, err
 	}
-	if version := u16(buf); version != 0 {
-		return nil, errUnsupportedKernTable
-	}
-	subtableLength := int(u16(buf[2:]))
-	if subtableLength < headerSize || length < subtableLength {
+	numPairs := u16(buf)
+	if length != headerSize+entrySize*int(numPairs) {
 		return nil, errInvalidKernTable
 	}
-	if coverageBits := buf[5]; coverageBits != 0x01 {
-		// We only support horizontal kerning.
-		return nil, errUnsupportedKernTable
-	}
-	offset += headerSize
-	length -= headerSize
-	subtableLength -= headerSize
+	f.cached.kernNumPairs = int32(numPairs)
+	f.cached.kernOffset = int32(offset) + headerSize
+	return buf, nil
+}
 
-	switch format := buf[4]; format {
-	case 0:
-		return f.parseKernFormat0(buf, offset, subtableLength)
-	case 2:
-		// TODO: find such a (proprietary?) font, and support it.
-	}
-	return nil, errUnsupportedKernTable
+// kernClassInfo holds the location of a format 2 kern subtable.
+type kernClassInfo struct {
+	// offset and length locate the subtable, including its header. A zero
+	// offset means that there is no format 2 subtable. The other fields are
+	// relative to the start of the subtable.
+	offset, length        int32
+	leftClass, rightClass int32
+	array                 int32
 }
 
-func (f *Font) parseKernFormat0(buf []byte, offset, length int) ([]byte, error) {
-	const headerSize, entrySize = 8, 6
+// parseKernFormat2 parses the format 2 kern subtable at the given offset,
+// including its 6 byte subtable header.
+func (f *Font) parseKernFormat2(buf []byte, offset, length int) ([]byte, error) {
+	const headerSize, classTableHeaderSize = 6 + 8, 4
 	if length < headerSize {
 		return nil, errInvalidKernTable
 	}
@@ -635,12 +1210,19 @@ func (f *Font) parseKernFormat0(buf []byte, offset, length int) ([]byte, error)
 	if err != nil {
 		return nil, err
 	}
-	numPairs := u16(buf)
-	if length != headerSize+entrySize*int(numPairs) {
+	leftClass := int(u16(buf[8:]))
+	rightClass := int(u16(buf[10:]))
+	array := int(u16(buf[12:]))
+	if leftClass > length-classTableHeaderSize || rightClass > length-classTableHeaderSize || array > length {
 		return nil, errInvalidKernTable
 	}
-	f.cached.kernNumPairs = int32(numPairs)
-	f.cached.kernOffset = int32(offset) + headerSize
+	f.cached.kernClasses = kernClassInfo{
+		offset:     int32(offset),
+		length:     int32(length),
+		leftClass:  int32(leftClass),
+		rightClass: int32(rightClass),
+		array:      int32(array),
+	}
 	return buf, nil
 }
 
@@ -663,16 +1245,23 @@ func (f *Font) parseMaxp(buf []byte) ([]byte, error) {
 	numGlyphs := int(u)
 
 	if f.cached.isPostScript {
+		// A variable font has a CFF2 table instead of a CFF table.
+		t, cff2 := f.cff, false
+		if t.length == 0 && f.cff2.length != 0 {
+			t, cff2 = f.cff2, true
+		}
 		p := cffParser{
 			src:    &f.src,
-			base:   int(f.cff.offset),
-			offset: int(f.cff.offset),
-			end:    int(f.cff.offset + f.cff.length),
+			base:   int(t.offset),
+			offset: int(t.offset),
+			end:    int(t.offset + t.length),
+			cff2:   cff2,
 		}
 		f.cached.locations, err = p.parse()
 		if err != nil {
 			return nil, err
 		}
+		f.cached.cff = p.info
 	} else {
 		f.cached.locations, err = parseLoca(
 			&f.src, f.loca, f.glyf.offset, f.cached.indexToLocFormat, numGlyphs)
@@ -699,6 +1288,8 @@ func (f *Font) parsePost(buf []byte) ([]byte, error) {
 		return nil, err
 	}
 	switch u {
+	case 0x10000:
+		// No-op.
 	case 0x20000:
 		if f.post.length < headerSize+2+2*uint32(f.NumGlyphs()) {
 			return nil, errInvalidPostTable
@@ -727,6 +1318,74 @@ func (f *Font) GlyphIndex(b *Buffer, r rune) (GlyphIndex, error) {
 	return f.cached.glyphIndex(f, b, r)
 }
 
+// SymbolGlyphIndex is like GlyphIndex, but for symbol fonts, such as
+// Wingdings, it also maps r in the range U+0020 to U+00FF as Windows does.
+//
+// A symbol font's cmap table, with the Windows Symbol encoding, conventionally
+// maps the character codes 0x20 to 0xFF to the Private Use Area runes U+F020
+// to U+F0FF. For such a font, if r has no glyph but r+0xF000 does then that
+// glyph is returned. For other fonts, it is equivalent to GlyphIndex.
+func (f *Font) SymbolGlyphIndex(b *Buffer, r rune) (GlyphIndex, error) {
+	x, err := f.cached.glyphIndex(f, b, r)
+	if err != nil || x != 0 || !f.cached.symbolCmap || r < 0x20 || 0xff < r {
+		return x, err
+	}
+	return f.cached.glyphIndex(f, b, 0xf000+r)
+}
+
+// IsSymbolFont returns whether f's cmap table uses the Windows Symbol
+// encoding, as per SymbolGlyphIndex.
+func (f *Font) IsSymbolFont() bool { return f.cached.symbolCmap }
+
+// GlyphIndices returns the glyph indexes for the given runes, as per
+// GlyphIndex. It is equivalent to, but faster than, calling GlyphIndex for each
+// rune, as the cmap table is consulted at most once for each distinct rune
+// below U+0100 and for each run of repeated runes.
+//
+// If b is non-nil, the glyph indexes become invalid to use once b is re-used.
+func (f *Font) GlyphIndices(b *Buffer, runes []rune) ([]GlyphIndex, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	dst := b.glyphIndices[:0]
+	if cap(dst) < len(runes) {
+		dst = make([]GlyphIndex, 0, len(runes))
+	}
+
+	// Latin-1 runes are common enough, in most text, to memoize. seen is a
+	// bitmask of which latin1 elements are valid.
+	var (
+		latin1 [256]GlyphIndex
+		seen   [256 / 64]uint64
+		prevR  = rune(-1)
+		prevX  GlyphIndex
+	)
+	for _, r := range runes {
+		if 0 <= r && r < 256 {
+			if seen[r/64]&(1<<uint(r%64)) == 0 {
+				x, err := f.cached.glyphIndex(f, b, r)
+				if err != nil {
+					return nil, err
+				}
+				latin1[r] = x
+				seen[r/64] |= 1 << uint(r%64)
+			}
+			dst = append(dst, latin1[r])
+			continue
+		}
+		if r != prevR {
+			x, err := f.cached.glyphIndex(f, b, r)
+			if err != nil {
+				return nil, err
+			}
+			prevR, prevX = r, x
+		}
+		dst = append(dst, prevX)
+	}
+	b.glyphIndices = dst
+	return dst, nil
+}
+
 func (f *Font) viewGlyphData(b *Buffer, x GlyphIndex) ([]byte, error) {
 	xx := int(x)
 	if f.NumGlyphs() <= xx {
@@ -742,7 +1401,34 @@ func (f *Font) viewGlyphData(b *Buffer, x GlyphIndex) ([]byte, error) {
 
 // LoadGlyphOptions are the options to the Font.LoadGlyph method.
 type LoadGlyphOptions struct {
-	// TODO: transform / hinting.
+	// Hinting is the hinting policy. If it is font.HintingFull and the font
+	// has TrueType hinting instructions, the glyph's instructions are run to
+	// grid-fit its outline. Compound glyphs in such fonts are not hinted.
+	//
+	// Fonts without TrueType hinting instructions, including all fonts with
+	// PostScript outlines, are instead autohinted: their stems and edges are
+	// detected and snapped to the pixel grid. font.HintingVertical snaps only
+	// the y axis and font.HintingFull snaps both axes.
+	//
+	// Hinting is ignored if Transform is non-nil and its 2x2 part is not the
+	// identity matrix, as grid-fitting a rotated, slanted or stretched outline
+	// does not line it up with the pixel grid.
+	Hinting font.Hinting
+
+	// Transform, if non-nil, is an affine transformation applied to the
+	// segments after they are scaled to ppem and hinted. Its elements are in
+	// row major order and its translation, m[2] and m[5], is in pixels. The y
+	// axis increases up, as per the segments. For example, {1, 0.2, 0, 0, 1, 0}
+	// slants the glyph to the right, for a synthetic italic.
+	Transform *f64.Aff3
+
+	// RemoveOverlaps is whether to replace the glyph's contours, which may
+	// overlap each other or themselves, by the outline of their union under
+	// the non-zero winding rule. This is done after hinting and before
+	// Transform is applied. The resultant segments are lines, approximating
+	// any curves, with filled regions to the right of each contour's
+	// direction of travel.
+	RemoveOverlaps bool
 }
 
 // LoadGlyph returns the vector segments for the x'th glyph. ppem is the number
@@ -756,18 +1442,71 @@ func (f *Font) LoadGlyph(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, opts *Load
 		b = &Buffer{}
 	}
 
+	hinting := font.HintingNone
+	if opts != nil {
+		hinting = opts.Hinting
+		if m := opts.Transform; m != nil && (m[0] != 1 || m[1] != 0 || m[3] != 0 || m[4] != 1) {
+			hinting = font.HintingNone
+		}
+	}
+
+	autohint := f.autohinted(hinting)
+	if autohint {
+		if b.autohinter == nil {
+			b.autohinter = &autohinter{}
+		}
+		if err := b.autohinter.init(b, f); err != nil {
+			return nil, err
+		}
+	}
+
+	b.segments = b.segments[:0]
+	if hinting == font.HintingFull && !autohint {
+		segments, ok, err := f.appendHintedGlyfSegments(b, x, ppem)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			// The hinted segments are already scaled.
+			b.segments = segments
+			if opts.RemoveOverlaps {
+				b.segments = removeOverlaps(b.segments)
+			}
+			if opts.Transform != nil {
+				transformSegments(b.segments, opts.Transform)
+			}
+			return b.segments, nil
+		}
+	}
+
 	buf, err := f.viewGlyphData(b, x)
 	if err != nil {
 		return nil, err
 	}
 
-	b.segments = b.segments[:0]
+	if f.cached.normalizedCoords != nil {
+		if err := f.skippedTable("gvar"); err != nil {
+			return nil, err
+		}
+	}
 	if f.cached.isPostScript {
-		b.psi.type2Charstrings.initialize(b.segments)
-		if err := b.psi.run(psContextType2Charstring, buf); err != nil {
+		segments, err := f.appendCFFSegments(b, x, buf)
+		if err != nil {
 			return nil, err
 		}
-		b.segments = b.psi.type2Charstrings.segments
+		b.segments = segments
+	} else if len(buf) >= glyfHeaderLen && int16(u16(buf)) < 0 {
+		segments, err := f.appendCompoundGlyfSegments(b, x)
+		if err != nil {
+			return nil, err
+		}
+		b.segments = segments
+	} else if f.cached.normalizedCoords != nil && f.gvar.length != 0 {
+		segments, err := f.appendVariedGlyfSegments(b, x, buf)
+		if err != nil {
+			return nil, err
+		}
+		b.segments = segments
 	} else {
 		segments, err := appendGlyfSegments(b.segments, buf)
 		if err != nil {
@@ -776,11 +1515,10 @@ func (f *Font) LoadGlyph(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, opts *Load
 		b.segments = segments
 	}
 
-	// Scale the segments. If we want to support hinting, we'll have to push
-	// the scaling computation into the PostScript / TrueType specific glyph
-	// loading code, such as the appendGlyfSegments body, since TrueType
-	// hinting bytecode works on the scaled glyph vectors. For now, though,
-	// it's simpler to scale as a post-processing step.
+	// Scale the segments. Hinted glyphs, whose TrueType hinting bytecode works
+	// on the scaled glyph vectors, are scaled by appendHintedGlyfSegments
+	// instead. For unhinted glyphs, it's simpler to scale as a
+	// post-processing step.
 	for i := range b.segments {
 		s := &b.segments[i]
 		for j := range s.Args {
@@ -788,15 +1526,45 @@ func (f *Font) LoadGlyph(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, opts *Load
 		}
 	}
 
-	// TODO: look at opts to transform / hint the Buffer.segments.
+	if autohint {
+		b.autohinter.hint(b.segments, ppem, hinting == font.HintingFull)
+	}
 
+	if opts != nil && opts.RemoveOverlaps {
+		b.segments = removeOverlaps(b.segments)
+	}
+	if opts != nil && opts.Transform != nil {
+		transformSegments(b.segments, opts.Transform)
+	}
 	return b.segments, nil
 }
 
+// transformSegments applies the affine transformation m to the segments in
+// place.
+func transformSegments(segments []Segment, m *f64.Aff3) {
+	for i := range segments {
+		s := &segments[i]
+		n := 2
+		switch s.Op {
+		case SegmentOpQuadTo:
+			n = 4
+		case SegmentOpCubeTo:
+			n = 6
+		}
+		for j := 0; j < n; j += 2 {
+			x := float64(s.Args[j+0])
+			y := float64(s.Args[j+1])
+			s.Args[j+0] = fixed.Int26_6(math.Floor(m[0]*x + m[1]*y + m[2]*64 + 0.5))
+			s.Args[j+1] = fixed.Int26_6(math.Floor(m[3]*x + m[4]*y + m[5]*64 + 0.5))
+		}
+	}
+}
+
 // GlyphName returns the name of the x'th glyph.
 //
 // Not every font contains glyph names. If not present, GlyphName will return
-// ("", nil).
+// ("", nil). Glyph names come from a version 1.0 post table, which implies the
+// standard Macintosh glyph names, or a version 2.0 post table.
 //
 // If present, the glyph name, provided by the font, is assumed to follow the
 // Adobe Glyph List Specification:
@@ -810,7 +1578,20 @@ func (f *Font) GlyphName(b *Buffer, x GlyphIndex) (string, error) {
 	if int(x) >= f.NumGlyphs() {
 		return "", ErrNotFound
 	}
-	if f.cached.postTableVersion != 0x20000 {
+	switch f.cached.postTableVersion {
+	case 0x10000:
+		// A Version 1 post table's font has exactly the standard Macintosh
+		// glyph set, in the standard order, and so its glyph names are the
+		// built-in names.
+		if x >= numBuiltInPostNames {
+			return "", nil
+		}
+		i := builtInPostNamesOffsets[x+0]
+		j := builtInPostNamesOffsets[x+1]
+		return builtInPostNamesData[i:j], nil
+	case 0x20000:
+		// No-op.
+	default:
 		return "", nil
 	}
 	if b == nil {
@@ -865,13 +1646,239 @@ func (f *Font) GlyphName(b *Buffer, x GlyphIndex) (string, error) {
 	}
 }
 
+// GlyphIndexByName returns the glyph index for the given glyph name, the
+// inverse of GlyphName. For example, in many fonts, the name "Aacute" maps to
+// the same glyph as the rune U+00C1.
+//
+// If no glyph has that name in the post table, and the name follows the Adobe
+// Glyph List Specification's "uniXXXX" or "uXXXX[XX]" conventions for a single
+// Unicode code point, then the glyph index is looked up via the cmap table.
+//
+// It returns ErrNotFound if there is no such glyph.
+func (f *Font) GlyphIndexByName(b *Buffer, name string) (GlyphIndex, error) {
+	if name == "" {
+		return 0, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	builtIn := -1
+	for i := 0; i < numBuiltInPostNames; i++ {
+		if builtInPostNamesData[builtInPostNamesOffsets[i]:builtInPostNamesOffsets[i+1]] == name {
+			builtIn = i
+			break
+		}
+	}
+
+	switch f.cached.postTableVersion {
+	case 0x10000:
+		if 0 <= builtIn && builtIn < f.NumGlyphs() {
+			return GlyphIndex(builtIn), nil
+		}
+	case 0x20000:
+		x, ok, err := f.postGlyphIndexByName(b, name, builtIn)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return x, nil
+		}
+	}
+
+	if r, ok := parseAGLUnicodeName(name); ok {
+		x, err := f.GlyphIndex(b, r)
+		if err != nil {
+			return 0, err
+		}
+		if x != 0 {
+			return x, nil
+		}
+	}
+	return 0, ErrNotFound
+}
+
+// postGlyphIndexByName returns the first glyph whose name, as per a Version 2
+// post table, is name. builtIn is name's index in the built-in names, or -1.
+func (f *Font) postGlyphIndexByName(b *Buffer, name string, builtIn int) (GlyphIndex, bool, error) {
+	const glyphNameIndexOffset = 34
+
+	// Find name's index amongst the Pascal-formatted strings, if any.
+	custom := -1
+	offset := glyphNameIndexOffset + 2*f.NumGlyphs()
+	buf, err := b.view(&f.src, int(f.post.offset)+offset, int(f.post.length)-offset)
+	if err != nil {
+		return 0, false, err
+	}
+	for i := numBuiltInPostNames; len(buf) > 0 && i <= 32767; i++ {
+		n := 1 + int(buf[0])
+		if len(buf) < n {
+			return 0, false, errInvalidPostTable
+		}
+		if string(buf[1:n]) == name {
+			custom = i
+			break
+		}
+		buf = buf[n:]
+	}
+	if builtIn < 0 && custom < 0 {
+		return 0, false, nil
+	}
+
+	buf, err = b.view(&f.src, int(f.post.offset)+glyphNameIndexOffset, 2*f.NumGlyphs())
+	if err != nil {
+		return 0, false, err
+	}
+	for x := 0; x < f.NumGlyphs(); x++ {
+		if u := int(u16(buf[2*x:])); u == builtIn || u == custom {
+			return GlyphIndex(x), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// parseAGLUnicodeName parses a glyph name of the form "uniXXXX" or
+// "uXXXX[XX]", where the Xs are upper case hexadecimal digits, as per
+// https://github.com/adobe-type-tools/agl-specification#2-the-mapping
+func parseAGLUnicodeName(name string) (rune, bool) {
+	var digits string
+	switch {
+	case len(name) == 7 && name[:3] == "uni":
+		digits = name[3:]
+	case 5 <= len(name) && len(name) <= 7 && name[0] == 'u':
+		digits = name[1:]
+	default:
+		return 0, false
+	}
+	r := rune(0)
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		switch {
+		case '0' <= c && c <= '9':
+			r = r<<4 | rune(c-'0')
+		case 'A' <= c && c <= 'F':
+			r = r<<4 | rune(c-'A'+10)
+		default:
+			return 0, false
+		}
+	}
+	if (0xd800 <= r && r < 0xe000) || r > 0x10ffff {
+		return 0, false
+	}
+	return r, true
+}
+
+// GlyphBounds returns the bounding box of the x'th glyph. ppem is the number
+// of pixels in 1 em. As per LoadGlyph's segments, the y axis increases up, so
+// that Min.Y is the bottom of the glyph. If h is font.HintingFull, the bounds
+// are rounded outwards to whole pixels.
+//
+// For TrueType fonts, the bounds come from the glyf table's per-glyph header,
+// without loading the glyph's outline. For PostScript fonts, and for TrueType
+// fonts with variations (see WithVariations), they are the bounds of the
+// outline's unhinted segments, including off-curve control points.
+//
+// A glyph without an outline, such as a space, has empty bounds. It returns
+// ErrNotFound if the glyph index is out of range.
+func (f *Font) GlyphBounds(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, h font.Hinting) (fixed.Rectangle26_6, error) {
+	if int(x) >= f.NumGlyphs() {
+		return fixed.Rectangle26_6{}, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	var bounds fixed.Rectangle26_6
+	if f.cached.isPostScript || (f.cached.normalizedCoords != nil && f.gvar.length != 0) {
+		segments, err := f.LoadGlyph(b, x, ppem, nil)
+		if err != nil {
+			return fixed.Rectangle26_6{}, err
+		}
+		bounds = Segments(segments).Bounds()
+	} else {
+		i := f.cached.locations[x+0]
+		j := f.cached.locations[x+1]
+		if j == i {
+			return fixed.Rectangle26_6{}, nil
+		}
+		if j-i < glyfHeaderLen {
+			return fixed.Rectangle26_6{}, errInvalidGlyphData
+		}
+		buf, err := b.view(&f.src, int(i), glyfHeaderLen)
+		if err != nil {
+			return fixed.Rectangle26_6{}, err
+		}
+		upem := f.cached.unitsPerEm
+		bounds.Min.X = scale(fixed.Int26_6(int16(u16(buf[2:])))*ppem, upem)
+		bounds.Min.Y = scale(fixed.Int26_6(int16(u16(buf[4:])))*ppem, upem)
+		bounds.Max.X = scale(fixed.Int26_6(int16(u16(buf[6:])))*ppem, upem)
+		bounds.Max.Y = scale(fixed.Int26_6(int16(u16(buf[8:])))*ppem, upem)
+	}
+
+	if h == font.HintingFull {
+		bounds.Min.X &^= 63
+		bounds.Min.Y &^= 63
+		bounds.Max.X = (bounds.Max.X + 63) &^ 63
+		bounds.Max.Y = (bounds.Max.Y + 63) &^ 63
+	}
+	return bounds, nil
+}
+
+// GlyphAdvance returns the advance width for the x'th glyph. ppem is the
+// number of pixels in 1 em.
+//
+// If the font has variations (see WithVariations), the advance is adjusted
+// by the gvar table's phantom point deltas.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) GlyphAdvance(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, h font.Hinting) (fixed.Int26_6, error) {
+	if int(x) >= f.NumGlyphs() {
+		return 0, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	// https://www.microsoft.com/typography/OTSPEC/hmtx.htm says that "As an
+	// optimization, the number of records can be less than the number of
+	// glyphs, in which case the advance width value of the last record applies
+	// to all remaining glyph IDs."
+	y := x
+	if n := GlyphIndex(f.cached.numHMetrics - 1); y > n {
+		y = n
+	}
+	buf, err := b.view(&f.src, int(f.hmtx.offset)+4*int(y), 2)
+	if err != nil {
+		return 0, err
+	}
+	adv := fixed.Int26_6(u16(buf))
+	if f.cached.normalizedCoords != nil && f.gvar.length != 0 && !f.cached.isPostScript {
+		delta, err := f.glyfAdvanceDelta(b, x)
+		if err != nil {
+			return 0, err
+		}
+		adv += delta
+	}
+	adv = scale(adv*ppem, f.cached.unitsPerEm)
+	if h == font.HintingFull {
+		// Quantize the fixed.Int26_6 value to the nearest pixel.
+		adv = (adv + 32) &^ 63
+	}
+	return adv, nil
+}
+
 // Kern returns the horizontal adjustment for the kerning pair (x0, x1). A
 // positive kern means to move the glyphs further apart. ppem is the number of
 // pixels in 1 em.
 //
+// The adjustment comes from the kern table if it has a supported horizontal
+// subtable, of format 0 or format 2. Otherwise, it comes from the pair
+// adjustment lookups of the GPOS table's "kern" feature, for any script and
+// language system. Otherwise, it comes from the Apple kerx table's format 0
+// and format 2 subtables.
+//
 // It returns ErrNotFound if either glyph index is out of range.
 func (f *Font) Kern(b *Buffer, x0, x1 GlyphIndex, ppem fixed.Int26_6, h font.Hinting) (fixed.Int26_6, error) {
-	// TODO: how should this work with the GPOS table and CFF fonts?
 	// https://www.microsoft.com/typography/otspec/kern.htm says that
 	// "OpenType™ fonts containing CFF outlines are not supported by the 'kern'
 	// table and must use the 'GPOS' OpenType Layout table."
@@ -879,15 +1886,44 @@ func (f *Font) Kern(b *Buffer, x0, x1 GlyphIndex, ppem fixed.Int26_6, h font.Hin
 	if n := f.NumGlyphs(); int(x0) >= n || int(x1) >= n {
 		return 0, ErrNotFound
 	}
-	// Not every font has a kern table. If it doesn't, there's no need to
-	// allocate a Buffer.
-	if f.kern.length == 0 {
+	// Not every font has a kern table or GPOS kerning. If it doesn't, there's
+	// no need to allocate a Buffer.
+	if f.kern.length == 0 && f.cached.gposKern == nil && f.cached.kerxSubtables == nil {
 		return 0, nil
 	}
 	if b == nil {
 		b = &Buffer{}
 	}
 
+	var (
+		k   int32
+		err error
+	)
+	switch {
+	case f.cached.kernOffset != 0 || f.cached.kernClasses.offset != 0:
+		k, err = f.kernTableKern(b, x0, x1)
+	case f.cached.gposKern != nil:
+		k, err = f.gposKern(b, x0, x1)
+	default:
+		k, err = f.kerxKern(b, x0, x1)
+	}
+	if err != nil || k == 0 {
+		return 0, err
+	}
+	kern := scale(fixed.Int26_6(k)*ppem, f.cached.unitsPerEm)
+	if h == font.HintingFull {
+		// Quantize the fixed.Int26_6 value to the nearest pixel.
+		kern = (kern + 32) &^ 63
+	}
+	return kern, nil
+}
+
+// kernTableKern returns the horizontal adjustment, in font units, for the
+// kerning pair (x0, x1) from the kern table.
+func (f *Font) kernTableKern(b *Buffer, x0, x1 GlyphIndex) (int32, error) {
+	if f.cached.kernClasses.offset != 0 {
+		return f.kernTableClassKern(b, x0, x1)
+	}
 	key := uint32(x0)<<16 | uint32(x1)
 	lo, hi := int32(0), f.cached.kernNumPairs
 	for lo < hi {
@@ -911,18 +1947,133 @@ func (f *Font) Kern(b *Buffer, x0, x1 GlyphIndex, ppem fixed.Int26_6, h font.Hin
 		} else if k > key {
 			hi = i
 		} else {
-			kern := fixed.Int26_6(int16(u16(buf[4:])))
-			kern = scale(kern*ppem, f.cached.unitsPerEm)
-			if h == font.HintingFull {
-				// Quantize the fixed.Int26_6 value to the nearest pixel.
-				kern = (kern + 32) &^ 63
-			}
-			return kern, nil
+			return int32(int16(u16(buf[4:]))), nil
 		}
 	}
 	return 0, nil
 }
 
+// kernTableClassKern is like kernTableKern for a format 2 kern subtable.
+//
+// The two glyphs' class values are offsets, relative to the start of the
+// subtable, that sum to the offset of the kerning value. The left class
+// values, which are pre-multiplied by the row width, should include the
+// kerning array's offset, but some fonts' values are relative to the array
+// instead, which the array's offset is added to.
+func (f *Font) kernTableClassKern(b *Buffer, x0, x1 GlyphIndex) (int32, error) {
+	k := &f.cached.kernClasses
+	left, ok, err := f.kernClass(b, k.leftClass, x0)
+	if err != nil || !ok {
+		return 0, err
+	}
+	right, ok, err := f.kernClass(b, k.rightClass, x1)
+	if err != nil || !ok {
+		return 0, err
+	}
+	o := left + right
+	if left < k.array {
+		o += k.array
+	}
+	if o+2 > k.length {
+		return 0, errInvalidKernTable
+	}
+	buf, err := b.view(&f.src, int(k.offset+o), 2)
+	if err != nil {
+		return 0, err
+	}
+	return int32(int16(u16(buf))), nil
+}
+
+// kernClass returns the value of x in the format 2 kern subtable's class table
+// at the offset o, relative to the start of the subtable, and whether the
+// class table covers x.
+func (f *Font) kernClass(b *Buffer, o int32, x GlyphIndex) (int32, bool, error) {
+	k := &f.cached.kernClasses
+	buf, err := b.view(&f.src, int(k.offset+o), 4)
+	if err != nil {
+		return 0, false, err
+	}
+	firstGlyph, nGlyphs := GlyphIndex(u16(buf)), int32(u16(buf[2:]))
+	if x < firstGlyph || int32(x-firstGlyph) >= nGlyphs {
+		return 0, false, nil
+	}
+	e := o + 4 + 2*int32(x-firstGlyph)
+	if e+2 > k.length {
+		return 0, false, errInvalidKernTable
+	}
+	buf, err = b.view(&f.src, int(k.offset+e), 2)
+	if err != nil {
+		return 0, false, err
+	}
+	return int32(u16(buf)), true, nil
+}
+
+// viewTableRecords returns f's table directory's table records, 16 bytes each.
+func (f *Font) viewTableRecords(b *Buffer) ([]byte, error) {
+	buf, err := b.view(&f.src, f.offset, 12)
+	if err != nil {
+		return nil, err
+	}
+	numTables := int(u16(buf[4:]))
+	return b.view(&f.src, f.offset+12, 16*numTables)
+}
+
+// Tables returns the tags of f's tables, in increasing order, including those
+// that this package does not otherwise read.
+func (f *Font) Tables(b *Buffer) ([]Tag, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	buf, err := f.viewTableRecords(b)
+	if err != nil {
+		return nil, err
+	}
+	numTables := len(buf) / 16
+	ret := make([]Tag, numTables)
+	for i := range ret {
+		ret[i] = Tag(u32(buf[16*i:]))
+	}
+	return ret, nil
+}
+
+// Table returns the raw data of f's table with the given tag, such as "meta"
+// or "DSIG", including tables that this package does not otherwise read.
+//
+// The returned []byte is only valid until the next call to a Font method with
+// the same Buffer, and the caller should not modify it.
+//
+// It returns ErrNotFound if f has no such table.
+func (f *Font) Table(b *Buffer, tag Tag) ([]byte, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	buf, err := f.viewTableRecords(b)
+	if err != nil {
+		return nil, err
+	}
+	numTables := len(buf) / 16
+
+	// The table records are sorted by tag, as checked by initializeTables,
+	// unless a permissive parse tolerated otherwise.
+	i := 0
+	if f.cached.unsortedTables {
+		for ; i < numTables && Tag(u32(buf[16*i:])) != tag; i++ {
+		}
+	} else {
+		i = sort.Search(numTables, func(i int) bool {
+			return Tag(u32(buf[16*i:])) >= tag
+		})
+	}
+	if i == numTables || Tag(u32(buf[16*i:])) != tag {
+		return nil, ErrNotFound
+	}
+	o, n := u32(buf[16*i+8:]), u32(buf[16*i+12:])
+	if opts := f.cached.parseOptions; opts != nil && opts.Permissive {
+		n = f.clampTableLength(o, n)
+	}
+	return b.view(&f.src, int(o), int(n))
+}
+
 // Name returns the name value keyed by the given NameID.
 //
 // It returns ErrNotFound if there is no value for that key.
@@ -1017,6 +2168,30 @@ type Buffer struct {
 	// psi is a PostScript interpreter for when the Font is an OpenType/CFF
 	// font.
 	psi psInterpreter
+	// points and ends hold a glyph's decoded points and contour end point
+	// indexes, for when a glyph's points need adjusting before being
+	// converted to segments.
+	points []glyfPoint
+	ends   []int
+	// deltas, tupleDeltas, varPoints, varDeltas and touched are scratch
+	// space for applying the gvar table's variation deltas to points.
+	deltas      []varDelta
+	tupleDeltas []varDelta
+	varPoints   []uint16
+	varDeltas   []int32
+	touched     []bool
+	// compoundData holds a copy of a compound glyph's component records, for
+	// each level of nesting, as loading the components can overwrite buf.
+	compoundData [maxCompoundDepth][]byte
+	// hinter is a TrueType hinting bytecode interpreter, for when a glyph is
+	// loaded with font.HintingFull. It caches the results of running a
+	// Font's font and control value programs.
+	hinter *hinter
+	// autohinter grid-fits glyphs from fonts that have no TrueType hinting
+	// instructions. It caches a Font's blue zones.
+	autohinter *autohinter
+	// glyphIndices holds the glyph indexes returned by Font.GlyphIndices.
+	glyphIndices []GlyphIndex
 }
 
 func (b *Buffer) view(src *source, offset, length int) ([]byte, error) {
@@ -1037,6 +2212,44 @@ type Segment struct {
 	Args [6]fixed.Int26_6
 }
 
+// Segments is a slice of Segment.
+type Segments []Segment
+
+// Bounds returns the bounds of the segments' points, including off-curve
+// control points. It returns an empty rectangle if s is empty.
+func (s Segments) Bounds() (bounds fixed.Rectangle26_6) {
+	first := true
+	for _, seg := range s {
+		n := 2
+		switch seg.Op {
+		case SegmentOpQuadTo:
+			n = 4
+		case SegmentOpCubeTo:
+			n = 6
+		}
+		for j := 0; j < n; j += 2 {
+			p := fixed.Point26_6{X: seg.Args[j+0], Y: seg.Args[j+1]}
+			if first {
+				bounds.Min, bounds.Max, first = p, p, false
+				continue
+			}
+			if bounds.Min.X > p.X {
+				bounds.Min.X = p.X
+			}
+			if bounds.Min.Y > p.Y {
+				bounds.Min.Y = p.Y
+			}
+			if bounds.Max.X < p.X {
+				bounds.Max.X = p.X
+			}
+			if bounds.Max.Y < p.Y {
+				bounds.Max.Y = p.Y
+			}
+		}
+	}
+	return bounds
+}
+
 // SegmentOp is a vector path segment's operator.
 type SegmentOp uint32
 
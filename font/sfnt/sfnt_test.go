@@ -6,12 +6,17 @@ package sfnt
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"testing"
 
+	"golang.org/x/image/font"
 	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/f64"
 	"golang.org/x/image/math/fixed"
 )
 
@@ -73,6 +78,57 @@ func TestTrueTypeParseReaderAt(t *testing.T) {
 	testTrueType(t, f)
 }
 
+// countingReaderAt is an io.ReaderAt that counts the bytes read, and records
+// the largest offset read up to.
+type countingReaderAt struct {
+	r   io.ReaderAt
+	n   int
+	end int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if end := off + int64(len(p)); c.end < end {
+		c.end = end
+	}
+	n, err := c.r.ReadAt(p, off)
+	c.n += n
+	return n, err
+}
+
+func TestTrueTypeParseReaderAtSize(t *testing.T) {
+	r := &countingReaderAt{r: bytes.NewReader(goregular.TTF)}
+	f, err := ParseReaderAtSize(r, int64(len(goregular.TTF)))
+	if err != nil {
+		t.Fatalf("ParseReaderAtSize: %v", err)
+	}
+	testTrueType(t, f)
+
+	// Loading a glyph should not read the whole font.
+	x, err := f.GlyphIndex(nil, 'G')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	if _, err := f.LoadGlyph(nil, x, fixed.I(12), nil); err != nil {
+		t.Fatalf("LoadGlyph: %v", err)
+	}
+	if got, limit := r.n, len(goregular.TTF)/4; got > limit {
+		t.Errorf("bytes read: got %d, want <= %d", got, limit)
+	}
+
+	// With a truncated size, parsing the font or loading its glyphs may fail,
+	// but nothing past that size should be read.
+	r.end = 0
+	size := int64(len(goregular.TTF) / 2)
+	if f, err := ParseReaderAtSize(r, size); err == nil {
+		for x := 0; x < f.NumGlyphs(); x++ {
+			f.LoadGlyph(nil, GlyphIndex(x), fixed.I(12), nil)
+		}
+	}
+	if r.end > size {
+		t.Errorf("truncated: read up to offset %d, want <= %d", r.end, size)
+	}
+}
+
 func testTrueType(t *testing.T, f *Font) {
 	if got, want := f.UnitsPerEm(), Units(2048); got != want {
 		t.Errorf("UnitsPerEm: got %d, want %d", got, want)
@@ -141,6 +197,36 @@ func TestGoRegularGlyphIndex(t *testing.T) {
 	}
 }
 
+func TestGlyphIndices(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	runes := []rune("Hello, \u03bd\u03be\u03be\u03bf! \u0200\u2000\u2000 $$ Hello\U0001f600.")
+
+	var b Buffer
+	got, err := f.GlyphIndices(&b, runes)
+	if err != nil {
+		t.Fatalf("GlyphIndices: %v", err)
+	}
+	if len(got) != len(runes) {
+		t.Fatalf("GlyphIndices: got %d glyph indexes, want %d", len(got), len(runes))
+	}
+	for i, r := range runes {
+		want, err := f.GlyphIndex(&b, r)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphIndex: %v", r, err)
+		}
+		if got[i] != want {
+			t.Errorf("i=%d, r=%q: got %d, want %d", i, r, got[i], want)
+		}
+	}
+
+	if got, err := f.GlyphIndices(nil, nil); err != nil || len(got) != 0 {
+		t.Errorf("GlyphIndices(nil, nil): got %v, %v, want no glyph indexes", got, err)
+	}
+}
+
 func TestGlyphIndex(t *testing.T) {
 	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/cmapTest.ttf"))
 	if err != nil {
@@ -325,6 +411,241 @@ func TestPostScriptSegments(t *testing.T) {
 	testSegments(t, "CFFTest.otf", wants)
 }
 
+// testCFF2Table returns a CFF2 table, for CFFTest.otf's 5 glyphs, whose
+// charstrings use global and local subroutines, the blend operator with a
+// single region that peaks at the maximum of the one variation axis, and
+// some of the less common Type 2 Charstring operators.
+func testCFF2Table() []byte {
+	i32 := func(v int) []byte {
+		return []byte{29, byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	}
+	index := func(objects ...[]byte) []byte {
+		b := concat(be32(uint32(len(objects))), []byte{1, 1})
+		o := 1
+		for _, x := range objects {
+			o += len(x)
+			b = append(b, byte(o))
+		}
+		return concat(b, concat(objects...))
+	}
+
+	gsubrs := index(
+		[]byte{139, 239, 5}, // 0 100 rlineto.
+	)
+	charStrings := index(
+		// 0 0 rmoveto.
+		[]byte{139, 139, 21},
+		// 100 0 rmoveto 200 100 1 blend 0 rlineto -107 callgsubr.
+		[]byte{239, 139, 21, 247, 92, 239, 140, 16, 139, 5, 32, 29},
+		// 0 vsindex 100 100 rmoveto -107 callsubr.
+		[]byte{139, 15, 239, 239, 21, 32, 10},
+		// 0 0 rmoveto 10 20 30 40 50 60 70 80 rcurveline.
+		[]byte{139, 139, 21, 149, 159, 169, 179, 189, 199, 209, 219, 24},
+		// 0 10 hstemhm 20 30 hintmask 5 5 rmoveto 1.5 0 rlineto.
+		[]byte{139, 149, 18, 159, 169, 19, 0xc0, 144, 144, 21, 255, 0x00, 0x01, 0x80, 0x00, 139, 5},
+	)
+	subrs := index(
+		[]byte{149, 159, 169, 179, 27}, // 10 20 30 40 hhcurveto.
+	)
+	// -10 0 5 3 2 blend BlueValues 13 Subrs.
+	private := concat([]byte{129, 139, 144, 142, 141, 23, 6}, i32(13), []byte{19})
+
+	const headerSize, topDictLength, fdArrayLength = 5, 19, 18
+	charStringsOffset := headerSize + topDictLength + len(gsubrs)
+	fdArrayOffset := charStringsOffset + len(charStrings)
+	privateOffset := fdArrayOffset + fdArrayLength
+	vstoreOffset := privateOffset + len(private) + len(subrs)
+
+	return concat(
+		[]byte{2, 0, headerSize, 0, topDictLength},
+		i32(charStringsOffset), []byte{17},
+		i32(fdArrayOffset), []byte{12, 36},
+		i32(vstoreOffset), []byte{24},
+		gsubrs,
+		charStrings,
+		index(concat(i32(len(private)), i32(privateOffset), []byte{18})),
+		private,
+		subrs,
+		// The variation store: its length, its header, its region list and
+		// its item variation data.
+		be16(30, 1), be32(12), be16(1), be32(22),
+		be16(1, 1, 0, 0x4000, 0x4000),
+		be16(0, 0, 1, 0),
+	)
+}
+
+// testCFFSeacTable returns a CFF table whose fourth and fifth glyphs compose
+// an "A" and an "acute" with the deprecated seac operator.
+func testCFFSeacTable() []byte {
+	i32 := func(v int) []byte {
+		return []byte{29, byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	}
+	index := func(objects ...[]byte) []byte {
+		if len(objects) == 0 {
+			return be16(0)
+		}
+		b := concat(be16(len(objects)), []byte{1, 1})
+		o := 1
+		for _, x := range objects {
+			o += len(x)
+			b = append(b, byte(o))
+		}
+		return concat(b, concat(objects...))
+	}
+
+	charStrings := index(
+		// endchar.
+		[]byte{14},
+		// 0 0 rmoveto 100 0 rlineto 0 100 rlineto endchar.
+		[]byte{139, 139, 21, 239, 139, 5, 139, 239, 5, 14},
+		// 0 0 rmoveto 10 10 rlineto endchar.
+		[]byte{139, 139, 21, 149, 149, 5, 14},
+		// 500 50 200 65 194 endchar, with a width.
+		[]byte{248, 136, 189, 247, 92, 204, 247, 86, 14},
+		// 0 0 65 194 endchar, without a width.
+		[]byte{139, 139, 204, 247, 86, 14},
+	)
+	// The custom format 0 charset maps the glyphs to the SIDs (String IDs)
+	// of "A", "acute", "Aacute" and "Acircumflex". The Standard Encoding
+	// codes for "A" and "acute" are 65 and 194.
+	charset := concat([]byte{0}, be16(34, 125, 203, 204))
+
+	const headerSize, nameIndexLength, topDictIndexLength = 4, 6, 28
+	charStringsOffset := headerSize + nameIndexLength + topDictIndexLength + 2 + 2
+	charsetOffset := charStringsOffset + len(charStrings)
+
+	return concat(
+		[]byte{1, 0, headerSize, 1},
+		index([]byte("T")),
+		index(concat(
+			i32(charsetOffset), []byte{15},
+			i32(charStringsOffset), []byte{17},
+			i32(0), i32(charsetOffset), []byte{18},
+		)),
+		index(), // The String INDEX.
+		index(), // The Global Subr INDEX.
+		charStrings,
+		charset,
+	)
+}
+
+func TestCFFSeac(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"CFF ": testCFFSeacTable(),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		x    GlyphIndex
+		want []Segment
+	}{{
+		x: 3,
+		want: []Segment{
+			moveTo(0, 0),
+			lineTo(100, 0),
+			lineTo(100, 100),
+			moveTo(50, 200),
+			lineTo(60, 210),
+		},
+	}, {
+		x: 4,
+		want: []Segment{
+			moveTo(0, 0),
+			lineTo(100, 0),
+			lineTo(100, 100),
+			moveTo(0, 0),
+			lineTo(10, 10),
+		},
+	}}
+
+	var b Buffer
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	for _, tc := range testCases {
+		got, err := f.LoadGlyph(&b, tc.x, ppem, nil)
+		if err != nil {
+			t.Errorf("x=%d: LoadGlyph: %v", tc.x, err)
+			continue
+		}
+		if err := checkSegmentsEqual(got, tc.want); err != nil {
+			t.Errorf("x=%d: %v", tc.x, err)
+		}
+	}
+}
+
+func TestCFF2(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data = withTables(t, data, map[string][]byte{
+		"CFF ": nil,
+		"CFF2": testCFF2Table(),
+		"fvar": testFvarTable,
+	})
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// Parsing from an io.ReaderAt exercises viewing the subroutines without
+	// overwriting the charstring being interpreted.
+	fr, err := ParseReaderAt(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseReaderAt: %v", err)
+	}
+
+	wants := func(x fixed.Int26_6) [][]Segment {
+		return [][]Segment{{
+			moveTo(0, 0),
+		}, {
+			moveTo(100, 0),
+			lineTo(100+x, 0),
+			lineTo(100+x, 100),
+		}, {
+			moveTo(100, 100),
+			cubeTo(110, 100, 130, 130, 170, 130),
+		}, {
+			moveTo(0, 0),
+			cubeTo(10, 20, 40, 60, 90, 120),
+			lineTo(160, 200),
+		}, {
+			moveTo(5, 5),
+			lineTo(7, 5),
+		}}
+	}
+	testCases := []struct {
+		wght float64
+		x    fixed.Int26_6
+	}{
+		{400, 200},
+		{650, 250},
+		{900, 300},
+		{100, 200},
+	}
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	var b Buffer
+	for _, tc := range testCases {
+		for _, f := range []*Font{f, fr} {
+			g := f.WithVariations(Variation{MustParseTag("wght"), tc.wght})
+			for i, want := range wants(tc.x) {
+				got, err := g.LoadGlyph(&b, GlyphIndex(i), ppem, nil)
+				if err != nil {
+					t.Errorf("wght=%v, i=%d: LoadGlyph: %v", tc.wght, i, err)
+					continue
+				}
+				if err := checkSegmentsEqual(got, want); err != nil {
+					t.Errorf("wght=%v, i=%d: %v", tc.wght, i, err)
+				}
+			}
+		}
+	}
+}
+
 func TestTrueTypeSegments(t *testing.T) {
 	// wants' vectors correspond 1-to-1 to what's in the glyfTest.sfd file,
 	// although FontForge's SFD format stores quadratic Bézier curves as cubics
@@ -421,6 +742,194 @@ func testSegments(t *testing.T, filename string, wants [][]Segment) {
 	}
 }
 
+// withGlyfData returns the font data src, which must have a short format loca
+// table, with some of its glyphs' glyf data replaced.
+func withGlyfData(t *testing.T, src []byte, glyphs map[GlyphIndex][]byte) []byte {
+	f, err := Parse(src)
+	if err != nil {
+		t.Fatalf("withGlyfData: Parse: %v", err)
+	}
+	if f.cached.indexToLocFormat {
+		t.Fatalf("withGlyfData: unsupported long format loca table")
+	}
+	var glyf, loca []byte
+	for i := 0; i < f.NumGlyphs(); i++ {
+		loca = appendU16(loca, uint16(len(glyf)/2))
+		data, ok := glyphs[GlyphIndex(i)]
+		if !ok {
+			data = src[f.cached.locations[i]:f.cached.locations[i+1]]
+		}
+		glyf = append(glyf, data...)
+		if len(glyf)%2 != 0 {
+			glyf = append(glyf, 0)
+		}
+	}
+	loca = appendU16(loca, uint16(len(glyf)/2))
+	return withTables(t, src, map[string][]byte{
+		"glyf": glyf,
+		"loca": loca,
+	})
+}
+
+func TestCompoundGlyph(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	const (
+		xy       = flagArgsAreXYValues
+		more     = flagMoreComponents
+		words    = flagArg1And2AreWords
+		oneScale = flagWeHaveAScale
+	)
+	f, err := Parse(withGlyfData(t, data, map[GlyphIndex][]byte{
+		// A simple glyph: a 100 unit square with its bottom left corner at
+		// the origin.
+		1: concat(
+			be16(1, 0, 0, 100, 100, 3, 0),
+			[]byte{0x01, 0x01, 0x01, 0x01},
+			be16(0, 100, 0, -100),
+			be16(0, 0, 100, 0),
+		),
+		// A compound glyph of three squares. The first is offset by (10, 20).
+		// The second's point 0 is aligned to the glyph's point 2, the first
+		// square's top right corner. The third is scaled by 0.5 and its point
+		// 2 is aligned to the glyph's point 6, the second square's top right
+		// corner.
+		2: concat(
+			be16(-1, 10, 20, 210, 220),
+			be16(xy|more, 1), []byte{10, 20},
+			be16(more, 1), []byte{2, 0},
+			be16(oneScale, 1), []byte{6, 2}, be16(0x2000),
+		),
+		// A compound glyph whose only component is the previous compound
+		// glyph, offset by (1000, -1000).
+		3: concat(
+			be16(-1, 1010, -980, 1210, -780),
+			be16(xy|words, 2, 1000, -1000),
+		),
+		// A compound glyph that contains itself.
+		4: concat(
+			be16(-1, 0, 0, 0, 0),
+			be16(xy, 4), []byte{0, 0},
+		),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	square := func(x, y, size fixed.Int26_6) []Segment {
+		return []Segment{
+			moveTo(x, y),
+			lineTo(x+size, y),
+			lineTo(x+size, y+size),
+			lineTo(x, y+size),
+			lineTo(x, y),
+		}
+	}
+	var want2, want3 []Segment
+	want2 = append(want2, square(10, 20, 100)...)
+	want2 = append(want2, square(110, 120, 100)...)
+	want2 = append(want2, square(160, 170, 50)...)
+	want3 = append(want3, square(1010, -980, 100)...)
+	want3 = append(want3, square(1110, -880, 100)...)
+	want3 = append(want3, square(1160, -830, 50)...)
+
+	var b Buffer
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	for x, want := range map[GlyphIndex][]Segment{2: want2, 3: want3} {
+		got, err := f.LoadGlyph(&b, x, ppem, nil)
+		if err != nil {
+			t.Errorf("x=%d: LoadGlyph: %v", x, err)
+			continue
+		}
+		if err := checkSegmentsEqual(got, want); err != nil {
+			t.Errorf("x=%d: %v", x, err)
+		}
+	}
+	if _, err := f.LoadGlyph(&b, 4, ppem, nil); err == nil {
+		t.Errorf("x=4: LoadGlyph: got nil error, want non-nil")
+	}
+}
+
+func TestKernTable(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	// Subtables in the unsupported formats 1 and 3, and vertical subtables,
+	// are skipped.
+	format1 := be16(0, 10, 0x0101, 0, 0)
+	format3 := be16(0, 10, 0x0301, 0, 0)
+	vertical := be16(0, 20, 0x0000, 1, 6, 0, 0, 1, 2, -99)
+	format0 := be16(0, 20, 0x0001, 1, 6, 0, 0, 1, 2, -7)
+	// The first left class value includes the array's offset, 30. The
+	// second is relative to the array.
+	format2 := concat(
+		be16(0, 38, 0x0201),    // version, length, coverage.
+		be16(4, 14, 22, 30),    // rowWidth, leftClassTable, rightClassTable, array.
+		be16(1, 2, 30, 4),      // leftClassTable.
+		be16(2, 2, 0, 2),       // rightClassTable.
+		be16(-10, -20, 30, 40), // array.
+	)
+
+	testCases := []struct {
+		desc   string
+		tables map[string][]byte
+		want   map[[2]GlyphIndex]fixed.Int26_6
+	}{{
+		desc:   "format 0",
+		tables: map[string][]byte{"kern": concat(be16(0, 3), format3, vertical, format0)},
+		want: map[[2]GlyphIndex]fixed.Int26_6{
+			{1, 2}: -7,
+			{2, 1}: 0,
+		},
+	}, {
+		desc:   "format 2",
+		tables: map[string][]byte{"kern": concat(be16(0, 2), format1, format2)},
+		want: map[[2]GlyphIndex]fixed.Int26_6{
+			{0, 2}: 0,
+			{1, 1}: 0,
+			{1, 2}: -10,
+			{1, 3}: -20,
+			{1, 4}: 0,
+			{2, 2}: 30,
+			{2, 3}: 40,
+			{3, 2}: 0,
+		},
+	}, {
+		desc: "no supported subtable",
+		tables: map[string][]byte{
+			"kern": concat(be16(0, 1), vertical),
+			"GPOS": testGPOSTable(),
+		},
+		want: map[[2]GlyphIndex]fixed.Int26_6{
+			{1, 2}: -55,
+		},
+	}}
+
+	for _, tc := range testCases {
+		f, err := Parse(withTables(t, data, tc.tables))
+		if err != nil {
+			t.Errorf("%s: Parse: %v", tc.desc, err)
+			continue
+		}
+		ppem := fixed.Int26_6(f.UnitsPerEm())
+		var b Buffer
+		for pair, want := range tc.want {
+			got, err := f.Kern(&b, pair[0], pair[1], ppem, font.HintingNone)
+			if err != nil {
+				t.Errorf("%s: Kern(%d, %d): %v", tc.desc, pair[0], pair[1], err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%s: Kern(%d, %d): got %d, want %d", tc.desc, pair[0], pair[1], got, want)
+			}
+		}
+	}
+}
+
 func TestPPEM(t *testing.T) {
 	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
 	if err != nil {
@@ -475,6 +984,143 @@ func TestPPEM(t *testing.T) {
 	}
 }
 
+func TestLoadGlyphTransform(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var b Buffer
+	x, err := f.GlyphIndex(&b, '1')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+
+	testCases := []struct {
+		desc string
+		m    f64.Aff3
+		want []Segment
+	}{{
+		desc: "identity",
+		m:    f64.Aff3{1, 0, 0, 0, 1, 0},
+		want: []Segment{
+			moveTo(77, 0),
+			lineTo(77, 614),
+			lineTo(230, 614),
+			lineTo(230, 0),
+			lineTo(77, 0),
+		},
+	}, {
+		desc: "translate",
+		m:    f64.Aff3{1, 0, 1, 0, 1, -0.5},
+		want: []Segment{
+			moveTo(141, -32),
+			lineTo(141, 582),
+			lineTo(294, 582),
+			lineTo(294, -32),
+			lineTo(141, -32),
+		},
+	}, {
+		desc: "slant",
+		m:    f64.Aff3{1, 0.25, 0, 0, 1, 0},
+		want: []Segment{
+			moveTo(77, 0),
+			lineTo(231, 614),
+			lineTo(384, 614),
+			lineTo(230, 0),
+			lineTo(77, 0),
+		},
+	}, {
+		desc: "rotate",
+		m:    f64.Aff3{0, -1, 0, 1, 0, 0},
+		want: []Segment{
+			moveTo(0, 77),
+			lineTo(-614, 77),
+			lineTo(-614, 230),
+			lineTo(0, 230),
+			lineTo(0, 77),
+		},
+	}}
+
+	for _, tc := range testCases {
+		got, err := f.LoadGlyph(&b, x, fixed.I(12), &LoadGlyphOptions{Transform: &tc.m})
+		if err != nil {
+			t.Errorf("%s: LoadGlyph: %v", tc.desc, err)
+			continue
+		}
+		if err := checkSegmentsEqual(got, tc.want); err != nil {
+			t.Errorf("%s: %v", tc.desc, err)
+		}
+	}
+}
+
+func TestLoadGlyphTransformHinting(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var b Buffer
+	x, err := f.GlyphIndex(&b, 'a')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	load := func(opts *LoadGlyphOptions) []Segment {
+		segments, err := f.LoadGlyph(&b, x, fixed.I(12), opts)
+		if err != nil {
+			t.Fatalf("LoadGlyph: %v", err)
+		}
+		return append([]Segment(nil), segments...)
+	}
+
+	// A translation is applied after hinting.
+	hinted := load(&LoadGlyphOptions{Hinting: font.HintingFull})
+	got := load(&LoadGlyphOptions{
+		Hinting:   font.HintingFull,
+		Transform: &f64.Aff3{1, 0, 2, 0, 1, 3},
+	})
+	want := transformed(hinted, func(x, y fixed.Int26_6) (fixed.Int26_6, fixed.Int26_6) {
+		return x + 2*64, y + 3*64
+	})
+	if err := checkSegmentsEqual(got, want); err != nil {
+		t.Errorf("translate: %v", err)
+	}
+
+	// Other transformations disable hinting.
+	unhinted := load(nil)
+	got = load(&LoadGlyphOptions{
+		Hinting:   font.HintingFull,
+		Transform: &f64.Aff3{2, 0, 0, 0, 1, 0},
+	})
+	want = transformed(unhinted, func(x, y fixed.Int26_6) (fixed.Int26_6, fixed.Int26_6) {
+		return 2 * x, y
+	})
+	if err := checkSegmentsEqual(got, want); err != nil {
+		t.Errorf("scale: %v", err)
+	}
+}
+
+// transformed returns a copy of segments with each point mapped by fn.
+func transformed(segments []Segment, fn func(x, y fixed.Int26_6) (fixed.Int26_6, fixed.Int26_6)) []Segment {
+	dst := make([]Segment, len(segments))
+	for i, s := range segments {
+		dst[i].Op = s.Op
+		n := 2
+		switch s.Op {
+		case SegmentOpQuadTo:
+			n = 4
+		case SegmentOpCubeTo:
+			n = 6
+		}
+		for j := 0; j < n; j += 2 {
+			dst[i].Args[j], dst[i].Args[j+1] = fn(s.Args[j], s.Args[j+1])
+		}
+	}
+	return dst
+}
+
 func TestGlyphName(t *testing.T) {
 	f, err := Parse(goregular.TTF)
 	if err != nil {
@@ -515,6 +1161,93 @@ func TestGlyphName(t *testing.T) {
 	}
 }
 
+func TestGlyphNamePostVersion1(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	post := make([]byte, 32)
+	post[1] = 0x01 // A version of 0x00010000.
+	f, err := Parse(withTables(t, data, map[string][]byte{
+		"post": post,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	want := []string{".notdef", ".null", "nonmarkingreturn", "space", "exclam"}
+	var b Buffer
+	for i, w := range want {
+		got, err := f.GlyphName(&b, GlyphIndex(i))
+		if err != nil {
+			t.Errorf("x=%d: GlyphName: %v", i, err)
+			continue
+		}
+		if got != w {
+			t.Errorf("x=%d: got %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestGlyphIndexByName(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		want rune
+	}{
+		{".notdef", '\ufffe'},
+		{"exclam", '!'},
+		{"Adieresis", '\u00c4'},
+		{"dagger", '\u2020'},
+		{"gopher", '\uf800'},
+		{"uni0041", 'A'},
+		{"u00C4", '\u00c4'},
+	}
+
+	var b Buffer
+	for _, tc := range testCases {
+		want, err := f.GlyphIndex(&b, tc.want)
+		if err != nil {
+			t.Errorf("name=%q: GlyphIndex: %v", tc.name, err)
+			continue
+		}
+		got, err := f.GlyphIndexByName(&b, tc.name)
+		if err != nil {
+			t.Errorf("name=%q: GlyphIndexByName: %v", tc.name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("name=%q: got %d, want %d", tc.name, got, want)
+		}
+	}
+
+	for _, name := range []string{"", "nosuchglyph", "uni0041A", "uni004a", "u1F600", "uD800"} {
+		if _, err := f.GlyphIndexByName(&b, name); err != ErrNotFound {
+			t.Errorf("name=%q: got %v, want %v", name, err, ErrNotFound)
+		}
+	}
+
+	// Every glyph name should map back to its glyph.
+	for x := GlyphIndex(0); int(x) < f.NumGlyphs(); x++ {
+		name, err := f.GlyphName(&b, x)
+		if err != nil {
+			t.Fatalf("x=%d: GlyphName: %v", x, err)
+		}
+		got, err := f.GlyphIndexByName(&b, name)
+		if err != nil {
+			t.Errorf("x=%d, name=%q: GlyphIndexByName: %v", x, name, err)
+			continue
+		}
+		if got != x {
+			t.Errorf("x=%d, name=%q: got %d", x, name, got)
+		}
+	}
+}
+
 func TestBuiltInPostNames(t *testing.T) {
 	testCases := []struct {
 		x    GlyphIndex
@@ -548,3 +1281,423 @@ func TestBuiltInPostNames(t *testing.T) {
 		}
 	}
 }
+
+func TestGlyphBounds(t *testing.T) {
+	cff, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"goregular", goregular.TTF},
+		{"CFFTest", cff},
+	} {
+		f, err := Parse(tc.data)
+		if err != nil {
+			t.Fatalf("%s: Parse: %v", tc.name, err)
+		}
+		// With ppem equal to the units per em, the glyf table's bounds are
+		// those of the outline's points.
+		ppem := fixed.I(int(f.UnitsPerEm()))
+		var b Buffer
+		for x := 0; x < f.NumGlyphs(); x++ {
+			segments, err := f.LoadGlyph(&b, GlyphIndex(x), ppem, nil)
+			if err != nil {
+				t.Fatalf("%s: LoadGlyph(%d): %v", tc.name, x, err)
+			}
+			want := Segments(segments).Bounds()
+			got, err := f.GlyphBounds(&b, GlyphIndex(x), ppem, font.HintingNone)
+			if err != nil {
+				t.Fatalf("%s: GlyphBounds(%d): %v", tc.name, x, err)
+			}
+			if got != want {
+				t.Errorf("%s: GlyphBounds(%d): got %v, want %v", tc.name, x, got, want)
+			}
+		}
+	}
+
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	x, err := f.GlyphIndex(nil, 'g')
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+	got, err := f.GlyphBounds(nil, x, fixed.I(12), font.HintingFull)
+	if err != nil {
+		t.Fatalf("GlyphBounds: %v", err)
+	}
+	if got.Min.X&63 != 0 || got.Min.Y&63 != 0 || got.Max.X&63 != 0 || got.Max.Y&63 != 0 {
+		t.Errorf("GlyphBounds: HintingFull: got %v, want whole pixels", got)
+	}
+	if got.Min.Y >= 0 || got.Max.Y <= 0 {
+		t.Errorf("GlyphBounds: 'g' should straddle the baseline, got %v", got)
+	}
+	if _, err := f.GlyphBounds(nil, GlyphIndex(f.NumGlyphs()), fixed.I(12), font.HintingNone); err != ErrNotFound {
+		t.Errorf("GlyphBounds: out of range: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestTable(t *testing.T) {
+	meta := []byte("\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x10\x00\x00\x00\x00")
+	data := withTables(t, goregular.TTF, map[string][]byte{
+		"meta": meta,
+	})
+	fonts := map[string]func() (*Font, error){
+		"Parse":         func() (*Font, error) { return Parse(data) },
+		"ParseReaderAt": func() (*Font, error) { return ParseReaderAt(bytes.NewReader(data)) },
+	}
+	for name, parse := range fonts {
+		f, err := parse()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var b Buffer
+		tags, err := f.Tables(&b)
+		if err != nil {
+			t.Fatalf("%s: Tables: %v", name, err)
+		}
+		if !sort.SliceIsSorted(tags, func(i, j int) bool { return tags[i] < tags[j] }) {
+			t.Errorf("%s: Tables: not sorted: %v", name, tags)
+		}
+		found := false
+		for _, tag := range tags {
+			if tag == MustParseTag("meta") {
+				found = true
+			}
+			if _, err := f.Table(&b, tag); err != nil {
+				t.Errorf("%s: Table(%q): %v", name, tag, err)
+			}
+		}
+		if !found {
+			t.Errorf("%s: Tables: no meta table in %v", name, tags)
+		}
+
+		got, err := f.Table(&b, MustParseTag("meta"))
+		if err != nil {
+			t.Errorf("%s: Table(meta): %v", name, err)
+		} else if !bytes.Equal(got, meta) {
+			t.Errorf("%s: Table(meta): got %x, want %x", name, got, meta)
+		}
+		if _, err := f.Table(&b, MustParseTag("DSIG")); err != ErrNotFound {
+			t.Errorf("%s: Table(DSIG): got %v, want %v", name, err, ErrNotFound)
+		}
+	}
+}
+
+// withTables returns a copy of the SFNT font data src with the given tables
+// added or replaced. The returned font's table checksums are not valid, but
+// this package ignores them.
+func withTables(t *testing.T, src []byte, tables map[string][]byte) []byte {
+	all := map[string][]byte{}
+	numTables := int(u16(src[4:]))
+	for i := 0; i < numTables; i++ {
+		r := src[12+16*i:]
+		o, n := u32(r[8:]), u32(r[12:])
+		all[string(r[:4])] = src[o : o+n]
+	}
+	for tag, data := range tables {
+		if len(tag) != 4 {
+			t.Fatalf("withTables: invalid tag %q", tag)
+		}
+		all[tag] = data
+	}
+	tags := make([]string, 0, len(all))
+	for tag := range all {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	dst := append([]byte(nil), src[:4]...)
+	dst = append(dst, byte(len(tags)>>8), byte(len(tags)), 0, 0, 0, 0, 0, 0)
+	offset := 12 + 16*len(tags)
+	for _, tag := range tags {
+		n := len(all[tag])
+		dst = append(dst, tag...)
+		dst = append(dst, 0, 0, 0, 0)
+		dst = append(dst, byte(offset>>24), byte(offset>>16), byte(offset>>8), byte(offset))
+		dst = append(dst, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+		offset += (n + 3) &^ 3
+	}
+	for _, tag := range tags {
+		dst = append(dst, all[tag]...)
+		for len(dst)&3 != 0 {
+			dst = append(dst, 0)
+		}
+	}
+	return dst
+}
+
+func TestParseWithOptions(t *testing.T) {
+	src, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	// record returns the table record for the given tag in data.
+	record := func(data []byte, tag string) []byte {
+		for i := 0; i < int(u16(data[4:])); i++ {
+			if r := data[12+16*i:]; string(r[:4]) == tag {
+				return r[:16]
+			}
+		}
+		t.Fatalf("no %q table", tag)
+		return nil
+	}
+	// modify returns a copy of src, modified by fn.
+	modify := func(src []byte, fn func(data []byte)) []byte {
+		data := append([]byte(nil), src...)
+		fn(data)
+		return data
+	}
+	invalidGPOS := withTables(t, goregular.TTF, map[string][]byte{
+		"GPOS": {0x00, 0x02, 0x00, 0x00, 0x00, 0x0a, 0x00, 0x0a, 0x00, 0x0a},
+	})
+
+	testCases := []struct {
+		desc string
+		data []byte
+		// wantErr is the error that a non-permissive parse returns, or nil
+		// if such a parse succeeds.
+		wantErr error
+		// wantWarnings is the errors of the warnings that a permissive parse
+		// records.
+		wantWarnings []error
+		wantTag      Tag
+	}{{
+		desc: "valid",
+		data: src,
+	}, {
+		desc: "unsorted",
+		data: modify(src, func(data []byte) {
+			var tmp [16]byte
+			copy(tmp[:], data[12:28])
+			copy(data[12:28], data[28:44])
+			copy(data[28:44], tmp[:])
+		}),
+		wantErr:      errInvalidTableTagOrder,
+		wantWarnings: []error{errInvalidTableTagOrder},
+		wantTag:      Tag(u32(src[12:])),
+	}, {
+		desc: "misaligned",
+		data: modify(src, func(data []byte) {
+			r := record(data, "name")
+			binary.BigEndian.PutUint32(r[8:], u32(r[8:])+2)
+			binary.BigEndian.PutUint32(r[12:], u32(r[12:])-2)
+		}),
+		wantErr:      errInvalidTableOffset,
+		wantWarnings: []error{errInvalidTableOffset},
+		wantTag:      MustParseTag("name"),
+	}, {
+		desc: "missing padding",
+		data: modify(src, func(data []byte) {
+			var last []byte
+			for i := 0; i < int(u16(data[4:])); i++ {
+				if r := data[12+16*i:]; last == nil || u32(r[8:]) > u32(last[8:]) {
+					last = r
+				}
+			}
+			binary.BigEndian.PutUint32(last[12:], uint32(len(data))-u32(last[8:])+2)
+		}),
+		wantWarnings: []error{errMissingTablePadding},
+	}, {
+		desc: "overlapping",
+		data: modify(src, func(data []byte) {
+			copy(record(data, "name")[8:16], record(data, "post")[8:16])
+		}),
+		wantWarnings: []error{errOverlappingTables},
+	}, {
+		// Optional tables that can't be parsed are skipped, even by a
+		// non-permissive parse.
+		desc:         "invalid GPOS",
+		data:         invalidGPOS,
+		wantWarnings: []error{errInvalidGPOSTable},
+	}, {
+		desc: "invalid head",
+		data: withTables(t, src, map[string][]byte{
+			"head": {0x00},
+		}),
+		wantErr: errInvalidHeadTable,
+		wantTag: MustParseTag("head"),
+	}}
+
+	for _, tc := range testCases {
+		_, err := ParseWithOptions(tc.data, nil)
+		if tc.wantErr == nil {
+			if err != nil {
+				t.Errorf("%s: non-permissive: %v", tc.desc, err)
+			}
+		} else if e, ok := err.(*TableError); !ok || e.Err != tc.wantErr || e.Tag != tc.wantTag {
+			t.Errorf("%s: non-permissive: got %v, want %v for the %q table", tc.desc, err, tc.wantErr, tc.wantTag)
+		}
+
+		f, err := ParseWithOptions(tc.data, &ParseOptions{Permissive: true})
+		if len(tc.wantWarnings) == 0 && tc.wantErr != nil {
+			// A required table is invalid.
+			if e, ok := err.(*TableError); !ok || e.Err != tc.wantErr {
+				t.Errorf("%s: permissive: got %v, want %v", tc.desc, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: permissive: %v", tc.desc, err)
+			continue
+		}
+		warnings := f.ParseWarnings()
+		if len(warnings) != len(tc.wantWarnings) {
+			t.Errorf("%s: permissive: got warnings %v, want %v", tc.desc, warnings, tc.wantWarnings)
+			continue
+		}
+		for i, w := range warnings {
+			if e, ok := w.(*TableError); !ok || e.Err != tc.wantWarnings[i] {
+				t.Errorf("%s: permissive: warning #%d: got %v, want %v", tc.desc, i, w, tc.wantWarnings[i])
+			}
+		}
+		for i := 0; i < int(u16(tc.data[4:])); i++ {
+			tag := Tag(u32(tc.data[12+16*i:]))
+			if _, err := f.Table(nil, tag); err != nil {
+				t.Errorf("%s: permissive: Table(%q): %v", tc.desc, tag, err)
+			}
+		}
+	}
+}
+
+func TestParseSkippedTables(t *testing.T) {
+	// header returns a table of n bytes that starts with the given bytes,
+	// typically an unsupported version.
+	header := func(n int, b ...byte) []byte {
+		return append(b, make([]byte, n-len(b))...)
+	}
+	testCases := []struct {
+		tag     string
+		data    []byte
+		wantErr error
+		// call calls the method that uses the table.
+		call func(f *Font, x GlyphIndex) error
+	}{{
+		tag:     "CBLC",
+		data:    header(8, 0x00, 0x02),
+		wantErr: errUnsupportedCBLCTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.CBDTGlyph(nil, x, fixed.I(16))
+			return err
+		},
+	}, {
+		tag:     "COLR",
+		data:    header(14, 0x00, 0x02),
+		wantErr: errUnsupportedCOLRTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.ColorLayers(nil, x)
+			return err
+		},
+	}, {
+		tag:     "GDEF",
+		data:    header(12, 0x00, 0x02),
+		wantErr: errUnsupportedGDEFTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.GlyphClass(nil, x)
+			return err
+		},
+	}, {
+		tag:     "GPOS",
+		data:    header(10, 0x00, 0x02),
+		wantErr: errInvalidGPOSTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.CursiveAnchors(nil, x)
+			return err
+		},
+	}, {
+		tag:     "GSUB",
+		data:    header(10, 0x00, 0x02),
+		wantErr: errInvalidGSUBTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.SubstituteGlyph(nil, x, MustParseTag("smcp"))
+			return err
+		},
+	}, {
+		tag:     "STAT",
+		data:    header(20, 0x00, 0x02),
+		wantErr: errUnsupportedSTATTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.StyleName(nil)
+			return err
+		},
+	}, {
+		tag:     "SVG ",
+		data:    header(10, 0x00, 0x01),
+		wantErr: errUnsupportedSVGTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, _, _, err := f.SVGDocument(nil, x)
+			return err
+		},
+	}, {
+		tag:     "fvar",
+		data:    header(16, 0x00, 0x02),
+		wantErr: errUnsupportedFvarTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.NamedInstances(nil)
+			return err
+		},
+	}, {
+		tag:     "sbix",
+		data:    header(8, 0x00, 0x02),
+		wantErr: errUnsupportedSbixTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.SbixGlyph(nil, x, fixed.I(16))
+			return err
+		},
+	}, {
+		tag:     "trak",
+		data:    header(12, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01),
+		wantErr: errUnsupportedTrakTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.Tracking(nil, 0, fixed.I(16), font.HintingNone)
+			return err
+		},
+	}, {
+		tag:     "vhea",
+		data:    header(36, 0x00, 0x02),
+		wantErr: errUnsupportedVheaTable,
+		call: func(f *Font, x GlyphIndex) error {
+			_, err := f.GlyphVerticalAdvance(nil, x, fixed.I(16), font.HintingNone)
+			return err
+		},
+	}}
+
+	for _, tc := range testCases {
+		tables := map[string][]byte{tc.tag: tc.data}
+		if tc.tag == "CBLC" {
+			// A CBLC table's bitmaps are in the CBDT table.
+			tables["CBDT"] = header(4, 0x00, 0x03)
+		}
+		f, err := Parse(withTables(t, goregular.TTF, tables))
+		if err != nil {
+			t.Errorf("%q: Parse: %v", tc.tag, err)
+			continue
+		}
+		wantTag := MustParseTag(tc.tag)
+		warnings := f.ParseWarnings()
+		if len(warnings) != 1 {
+			t.Errorf("%q: got warnings %v, want 1", tc.tag, warnings)
+		} else if e, ok := warnings[0].(*TableError); !ok || e.Err != tc.wantErr || e.Tag != wantTag {
+			t.Errorf("%q: got warning %v, want %v", tc.tag, warnings[0], tc.wantErr)
+		}
+
+		// The rest of the font is still usable.
+		x, err := f.GlyphIndex(nil, 'A')
+		if err != nil || x == 0 {
+			t.Errorf("%q: GlyphIndex: got %d, %v", tc.tag, x, err)
+			continue
+		}
+		if _, err := f.LoadGlyph(nil, x, fixed.I(16), nil); err != nil {
+			t.Errorf("%q: LoadGlyph: %v", tc.tag, err)
+		}
+
+		err = tc.call(f, x)
+		if e, ok := err.(*TableError); !ok || e.Err != tc.wantErr || e.Tag != wantTag {
+			t.Errorf("%q: got %v, want %v", tc.tag, err, tc.wantErr)
+		}
+	}
+}
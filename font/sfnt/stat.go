@@ -0,0 +1,383 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// The STAT (Style Attributes) table describes how the positions along a
+// font's design axes are labeled, such as "SemiBold" for a weight of 600, and
+// which of those labels are elided from a style name, such as "Regular".
+//
+// The relevant specification is:
+//	- https://www.microsoft.com/typography/otspec/stat.htm
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// maxSTATAxisValues is not part of the specification, but is a limitation
+// used by this implementation.
+const maxSTATAxisValues = 1024
+
+// StyleAxis is one of a STAT table's design axes. A design axis may or may not
+// also be a variation axis.
+type StyleAxis struct {
+	// Tag identifies the axis, such as "wght".
+	Tag Tag
+	// NameID is the name table entry for the axis' display name.
+	NameID NameID
+	// Ordering is the axis' position when combining axis value names into a
+	// style name. Lower values come first.
+	Ordering int
+}
+
+// AxisValue is a STAT table's label for a position, or a range of positions,
+// in the design space.
+type AxisValue struct {
+	// Format is the axis value table's format: 1, 2, 3 or 4.
+	Format int
+	// Variations are the nominal values that are labeled. There is one
+	// element for formats 1, 2 and 3 and one or more elements, each for a
+	// different axis, for format 4.
+	Variations []Variation
+	// Min and Max are, for format 2, the range of values that are labeled,
+	// on the sole axis. For other formats, they equal the nominal value of
+	// the first axis.
+	Min, Max float64
+	// LinkedValue is, for format 3, the value of the style-linked
+	// counterpart, such as 700 (Bold) for 400 (Regular). HasLinkedValue is
+	// whether the axis value is format 3.
+	LinkedValue    float64
+	HasLinkedValue bool
+	// NameID is the name table entry for the label, such as "SemiBold".
+	NameID NameID
+	// Elidable is whether the label is typically omitted from a style name,
+	// such as "Regular".
+	Elidable bool
+	// OlderSibling is whether the label applies to a sibling font in the
+	// same family that predates the variable font.
+	OlderSibling bool
+}
+
+// statInfo is the parsed STAT table.
+type statInfo struct {
+	axes                 []StyleAxis
+	values               []AxisValue
+	elidedFallbackNameID NameID
+}
+
+// StyleAxes returns the design axes of f's STAT table, in the order that the
+// table lists them. It returns nil if f has no STAT table.
+func (f *Font) StyleAxes() []StyleAxis {
+	if len(f.cached.stat.axes) == 0 {
+		return nil
+	}
+	return append([]StyleAxis(nil), f.cached.stat.axes...)
+}
+
+// AxisValues returns the axis values of f's STAT table, in the order that the
+// table lists them. It returns nil if f has no STAT table.
+func (f *Font) AxisValues() []AxisValue {
+	if len(f.cached.stat.values) == 0 {
+		return nil
+	}
+	ret := make([]AxisValue, len(f.cached.stat.values))
+	for i, v := range f.cached.stat.values {
+		ret[i] = v
+		ret[i].Variations = append([]Variation(nil), v.Variations...)
+	}
+	return ret
+}
+
+// ElidedFallbackNameID returns the name table entry for the style name to use
+// when every axis value name is elided, such as "Regular". It returns 2 (the
+// subfamily name) if f's STAT table doesn't say otherwise.
+func (f *Font) ElidedFallbackNameID() NameID {
+	return f.cached.stat.elidedFallbackNameID
+}
+
+// StyleName returns the style name, such as "SemiBold Condensed", for the
+// given point in the design space, built from the names of f's STAT table
+// axis values.
+//
+// Axes that aren't mentioned take their fvar default values, if any. For each
+// axis, a format 4 axis value that exactly matches several axes takes
+// priority. Otherwise, an axis value whose nominal value or format 2 range
+// matches is used, or failing that, the axis value whose nominal value is
+// nearest. Elidable names are omitted.
+//
+// It returns ErrNotFound if f has no STAT table.
+func (f *Font) StyleName(b *Buffer, vs ...Variation) (string, error) {
+	if err := f.skippedTable("STAT"); err != nil {
+		return "", err
+	}
+	stat := &f.cached.stat
+	if len(stat.axes) == 0 {
+		return "", ErrNotFound
+	}
+
+	// Find the value on each design axis.
+	values := make([]float64, len(stat.axes))
+	known := make([]bool, len(stat.axes))
+	for i, a := range stat.axes {
+		for _, va := range f.cached.variationAxes {
+			if va.Tag == a.Tag {
+				values[i], known[i] = va.Default, true
+			}
+		}
+		for _, v := range vs {
+			if v.Tag == a.Tag {
+				values[i], known[i] = v.Value, true
+			}
+		}
+	}
+	axisIndex := func(tag Tag) int {
+		for i, a := range stat.axes {
+			if a.Tag == tag {
+				return i
+			}
+		}
+		return -1
+	}
+
+	type part struct {
+		ordering int
+		value    *AxisValue
+	}
+	var parts []part
+	done := make([]bool, len(stat.axes))
+
+	// Look for format 4 axis values that match exactly.
+	for i := range stat.values {
+		v := &stat.values[i]
+		if v.Format != 4 {
+			continue
+		}
+		ok, ordering := true, math.MaxInt32
+		for _, x := range v.Variations {
+			j := axisIndex(x.Tag)
+			if j < 0 || done[j] || !known[j] || values[j] != x.Value {
+				ok = false
+				break
+			}
+			if o := stat.axes[j].Ordering; o < ordering {
+				ordering = o
+			}
+		}
+		if !ok {
+			continue
+		}
+		for _, x := range v.Variations {
+			done[axisIndex(x.Tag)] = true
+		}
+		parts = append(parts, part{ordering, v})
+	}
+
+	// Look for format 1, 2 or 3 axis values for the remaining axes.
+	for j, a := range stat.axes {
+		if done[j] || !known[j] {
+			continue
+		}
+		var best *AxisValue
+		bestDist := math.Inf(+1)
+		for i := range stat.values {
+			v := &stat.values[i]
+			if v.Format == 4 || v.Variations[0].Tag != a.Tag {
+				continue
+			}
+			dist := math.Abs(values[j] - v.Variations[0].Value)
+			if v.Format == 2 && v.Min <= values[j] && values[j] <= v.Max {
+				dist = 0
+			}
+			if dist < bestDist {
+				best, bestDist = v, dist
+			}
+		}
+		if best != nil {
+			parts = append(parts, part{a.Ordering, best})
+		}
+	}
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].ordering < parts[j].ordering
+	})
+
+	var names []string
+	for _, p := range parts {
+		if p.value.Elidable {
+			continue
+		}
+		name, err := f.Name(b, p.value.NameID)
+		if err != nil {
+			return "", err
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return f.Name(b, stat.elidedFallbackNameID)
+	}
+	return strings.Join(names, " "), nil
+}
+
+func (f *Font) parseSTAT(buf []byte) ([]byte, error) {
+	// https://www.microsoft.com/typography/otspec/stat.htm
+
+	f.cached.stat.elidedFallbackNameID = NameIDSubfamily
+	if f.stat.length == 0 {
+		return buf, nil
+	}
+	const headerSize, designAxisSize = 20, 8
+	if f.stat.length < headerSize-2 {
+		return nil, errInvalidSTATTable
+	}
+	buf, err := f.src.view(buf, int(f.stat.offset), headerSize-2)
+	if err != nil {
+		return nil, err
+	}
+	if major := u16(buf); major != 1 {
+		return nil, errUnsupportedSTATTable
+	}
+	minor := u16(buf[2:])
+	axisSize := uint32(u16(buf[4:]))
+	axisCount := uint32(u16(buf[6:]))
+	axesOffset := u32(buf[8:])
+	valueCount := uint32(u16(buf[12:]))
+	valueOffsetsOffset := u32(buf[14:])
+	if axisCount > maxVariationAxes || valueCount > maxSTATAxisValues {
+		return nil, errUnsupportedSTATTable
+	}
+	if minor >= 1 {
+		if f.stat.length < headerSize {
+			return nil, errInvalidSTATTable
+		}
+		u, err := f.src.u16(buf, f.stat, headerSize-2)
+		if err != nil {
+			return nil, err
+		}
+		f.cached.stat.elidedFallbackNameID = NameID(u)
+	}
+	if axisCount == 0 {
+		return buf, nil
+	}
+	if axisSize < designAxisSize || axesOffset > f.stat.length ||
+		(f.stat.length-axesOffset)/axisSize < axisCount {
+		return nil, errInvalidSTATTable
+	}
+
+	buf, err = f.src.view(buf, int(f.stat.offset+axesOffset), int(axisCount*axisSize))
+	if err != nil {
+		return nil, err
+	}
+	axes := make([]StyleAxis, axisCount)
+	for i := range axes {
+		b := buf[uint32(i)*axisSize:]
+		axes[i] = StyleAxis{
+			Tag:      Tag(u32(b)),
+			NameID:   NameID(u16(b[4:])),
+			Ordering: int(u16(b[6:])),
+		}
+	}
+
+	if valueCount == 0 {
+		f.cached.stat.axes = axes
+		return buf, nil
+	}
+	if valueOffsetsOffset > f.stat.length || (f.stat.length-valueOffsetsOffset)/2 < valueCount {
+		return nil, errInvalidSTATTable
+	}
+	offsets, err := f.src.view(nil, int(f.stat.offset+valueOffsetsOffset), int(2*valueCount))
+	if err != nil {
+		return nil, err
+	}
+	values := make([]AxisValue, 0, valueCount)
+	for i := uint32(0); i < valueCount; i++ {
+		offset := valueOffsetsOffset + uint32(u16(offsets[2*i:]))
+		v, ok, err := f.parseSTATAxisValue(buf, offset, axes)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values = append(values, v)
+		}
+	}
+	f.cached.stat.axes = axes
+	f.cached.stat.values = values
+	return buf, nil
+}
+
+// parseSTATAxisValue parses the axis value table at the given offset in the
+// STAT table. It returns ok == false for unknown formats, which are ignored.
+func (f *Font) parseSTATAxisValue(buf []byte, offset uint32, axes []StyleAxis) (v AxisValue, ok bool, err error) {
+	if offset > f.stat.length || f.stat.length-offset < 8 {
+		return AxisValue{}, false, errInvalidSTATTable
+	}
+	buf, err = f.src.view(buf, int(f.stat.offset+offset), 8)
+	if err != nil {
+		return AxisValue{}, false, err
+	}
+	format := u16(buf)
+	size := uint32(0)
+	switch format {
+	case 1:
+		size = 12
+	case 2:
+		size = 20
+	case 3:
+		size = 16
+	case 4:
+		size = 8 + 6*uint32(u16(buf[2:]))
+		if u16(buf[2:]) == 0 {
+			return AxisValue{}, false, errInvalidSTATTable
+		}
+	default:
+		return AxisValue{}, false, nil
+	}
+	if f.stat.length-offset < size {
+		return AxisValue{}, false, errInvalidSTATTable
+	}
+	buf, err = f.src.view(buf, int(f.stat.offset+offset), int(size))
+	if err != nil {
+		return AxisValue{}, false, err
+	}
+
+	v = AxisValue{Format: int(format)}
+	var flags uint16
+	if format == 4 {
+		flags = u16(buf[4:])
+		v.NameID = NameID(u16(buf[6:]))
+		v.Variations = make([]Variation, u16(buf[2:]))
+		for i := range v.Variations {
+			b := buf[8+6*i:]
+			j := int(u16(b))
+			if j >= len(axes) {
+				return AxisValue{}, false, errInvalidSTATTable
+			}
+			v.Variations[i] = Variation{Tag: axes[j].Tag, Value: fixed16Dot16(u32(b[2:]))}
+		}
+	} else {
+		j := int(u16(buf[2:]))
+		if j >= len(axes) {
+			return AxisValue{}, false, errInvalidSTATTable
+		}
+		flags = u16(buf[4:])
+		v.NameID = NameID(u16(buf[6:]))
+		v.Variations = []Variation{{Tag: axes[j].Tag, Value: fixed16Dot16(u32(buf[8:]))}}
+	}
+	v.Min = v.Variations[0].Value
+	v.Max = v.Variations[0].Value
+	switch format {
+	case 2:
+		v.Min = fixed16Dot16(u32(buf[12:]))
+		v.Max = fixed16Dot16(u32(buf[16:]))
+		if v.Min > v.Max {
+			return AxisValue{}, false, errInvalidSTATTable
+		}
+	case 3:
+		v.LinkedValue = fixed16Dot16(u32(buf[12:]))
+		v.HasLinkedValue = true
+	}
+	v.OlderSibling = flags&0x0001 != 0
+	v.Elidable = flags&0x0002 != 0
+	return v, true, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// testSTATTable returns a version 1.1 STAT table with "wght" and "ital" design
+// axes and axis values of every format, plus one of an unknown format.
+func testSTATTable() []byte {
+	const wght, ital = 0x77676874, 0x6974616c
+	return concat(
+		be16(1, 1, 8, 2), be32(20), // version, designAxisSize, designAxisCount, designAxesOffset.
+		be16(7), be32(36), be16(267), // axisValueCount, offsetToAxisValueOffsets, elidedFallbackNameID.
+
+		be32(wght), be16(256, 0),
+		be32(ital), be16(257, 1),
+
+		be16(14, 34, 54, 66, 82, 94, 114),
+
+		be16(2, 0, 2, 261), be32(400<<16, 350<<16, 450<<16), // Regular, elidable.
+		be16(2, 0, 0, 262), be32(600<<16, 550<<16, 650<<16), // SemiBold.
+		be16(1, 0, 0, 263), be32(700<<16), // Bold.
+		be16(3, 1, 2, 264), be32(0, 1<<16), // Roman, elidable, linked to Italic.
+		be16(1, 1, 0, 265), be32(1<<16), // Italic.
+		be16(4, 2, 0, 266), be16(0), be32(700<<16), be16(1), be32(1<<16), // Bold Italic.
+		be16(9, 0, 0, 0),
+	)
+}
+
+func TestSTAT(t *testing.T) {
+	f, err := Parse(withTables(t, goregular.TTF, map[string][]byte{
+		"STAT": testSTATTable(),
+		"fvar": testFvarTable,
+		"name": testNameTable(map[NameID]string{
+			261: "Regular",
+			262: "SemiBold",
+			263: "Bold",
+			264: "Roman",
+			265: "Italic",
+			266: "BoldItalic",
+			267: "Standard",
+		}),
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	wantAxes := []StyleAxis{{0x77676874, 256, 0}, {0x6974616c, 257, 1}}
+	if got := f.StyleAxes(); !reflect.DeepEqual(got, wantAxes) {
+		t.Errorf("StyleAxes: got %v, want %v", got, wantAxes)
+	}
+	if got, want := f.ElidedFallbackNameID(), NameID(267); got != want {
+		t.Errorf("ElidedFallbackNameID: got %d, want %d", got, want)
+	}
+	values := f.AxisValues()
+	if len(values) != 6 {
+		t.Fatalf("AxisValues: got %d values, want 6", len(values))
+	}
+	wantRoman := AxisValue{
+		Format:         3,
+		Variations:     []Variation{{0x6974616c, 0}},
+		LinkedValue:    1,
+		HasLinkedValue: true,
+		NameID:         264,
+		Elidable:       true,
+	}
+	if got := values[3]; !reflect.DeepEqual(got, wantRoman) {
+		t.Errorf("AxisValues[3]: got %+v, want %+v", got, wantRoman)
+	}
+	if got := values[0]; got.Min != 350 || got.Max != 450 {
+		t.Errorf("AxisValues[0]: got range [%v, %v], want [350, 450]", got.Min, got.Max)
+	}
+
+	testCases := []struct {
+		vs   []Variation
+		want string
+	}{
+		{nil, "Standard"},
+		{[]Variation{{0x77676874, 650}}, "SemiBold"},
+		{[]Variation{{0x77676874, 600}, {0x6974616c, 0}}, "SemiBold"},
+		{[]Variation{{0x77676874, 900}}, "Bold"},
+		{[]Variation{{0x77676874, 400}, {0x6974616c, 1}}, "Italic"},
+		{[]Variation{{0x77676874, 600}, {0x6974616c, 1}}, "SemiBold Italic"},
+		{[]Variation{{0x77676874, 700}, {0x6974616c, 1}}, "BoldItalic"},
+	}
+	for _, tc := range testCases {
+		got, err := f.StyleName(nil, tc.vs...)
+		if err != nil {
+			t.Errorf("%v: %v", tc.vs, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%v: got %q, want %q", tc.vs, got, tc.want)
+		}
+	}
+}
+
+func TestNoSTAT(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := f.StyleAxes(); got != nil {
+		t.Errorf("StyleAxes: got %v, want nil", got)
+	}
+	if got := f.ElidedFallbackNameID(); got != NameIDSubfamily {
+		t.Errorf("ElidedFallbackNameID: got %d, want %d", got, NameIDSubfamily)
+	}
+	if _, err := f.StyleName(nil); err != ErrNotFound {
+		t.Errorf("StyleName: got %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"sort"
+)
+
+// Subset returns the data for a new font that holds only those glyphs of f
+// that are needed to draw the given runes and glyphs. The .notdef glyph, glyph
+// index 0, is always kept, as are the components of any kept compound glyph.
+//
+// The kept glyphs keep their relative order, but are renumbered from 0. The
+// new font's cmap table maps the given runes, and only those runes, to their
+// new glyph indexes. Glyph names, if any, are kept.
+//
+// The new font holds the cmap, cvt, fpgm, gasp, glyf, head, hhea, hmtx, loca,
+// maxp, name, OS/2, post and prep tables. Other tables, such as the GPOS,
+// GSUB and kern tables that refer to glyphs by index, or the tables for font
+// variations, are dropped. The new font is for f's default instance.
+//
+// Only fonts with TrueType outlines (glyf tables) are supported.
+//
+// It returns ErrNotFound if a glyph index is out of range.
+func Subset(f *Font, runes []rune, glyphs []GlyphIndex) ([]byte, error) {
+	if f.cached.isPostScript {
+		// TODO: support fonts with CFF outlines.
+		return nil, errUnsupportedCFFSubset
+	}
+	b := &Buffer{}
+
+	// Find the glyphs to keep, and map the runes to them.
+	keep := map[GlyphIndex]bool{0: true}
+	for _, x := range glyphs {
+		if int(x) >= f.NumGlyphs() {
+			return nil, ErrNotFound
+		}
+		keep[x] = true
+	}
+	runeGlyphs := map[rune]GlyphIndex{}
+	for _, r := range runes {
+		x, err := f.GlyphIndex(b, r)
+		if err != nil {
+			return nil, err
+		}
+		if x != 0 {
+			runeGlyphs[r] = x
+			keep[x] = true
+		}
+	}
+
+	// Copy the glyph data, adding the components of compound glyphs to the
+	// set of glyphs to keep.
+	glyphData := map[GlyphIndex][]byte{}
+	for pending := sortedGlyphIndexes(keep); len(pending) > 0; {
+		x := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		buf, err := f.viewGlyphData(b, x)
+		if err != nil {
+			return nil, err
+		}
+		data := append([]byte(nil), buf...)
+		glyphData[x] = data
+		if err := forEachGlyfComponent(data, func(i int) error {
+			y := GlyphIndex(u16(data[i:]))
+			if int(y) >= f.NumGlyphs() {
+				return errInvalidGlyphData
+			}
+			if !keep[y] {
+				keep[y] = true
+				pending = append(pending, y)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	olds := sortedGlyphIndexes(keep)
+	news := make(map[GlyphIndex]GlyphIndex, len(olds))
+	for i, x := range olds {
+		news[x] = GlyphIndex(i)
+	}
+
+	// Build the glyf and loca tables, remapping compound glyphs' components.
+	var glyf []byte
+	locations := make([]uint32, 0, len(olds)+1)
+	for _, x := range olds {
+		data := glyphData[x]
+		if err := forEachGlyfComponent(data, func(i int) error {
+			putU16(data[i:], uint16(news[GlyphIndex(u16(data[i:]))]))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		locations = append(locations, uint32(len(glyf)))
+		glyf = append(glyf, data...)
+		for len(glyf)&3 != 0 {
+			glyf = append(glyf, 0)
+		}
+	}
+	locations = append(locations, uint32(len(glyf)))
+	longLoca := len(glyf) > 2*0xffff
+	var loca []byte
+	for _, l := range locations {
+		if longLoca {
+			loca = appendU32(loca, l)
+		} else {
+			loca = appendU16(loca, uint16(l/2))
+		}
+	}
+
+	// Build the hmtx table, with one long metric per glyph.
+	var hmtx []byte
+	for _, x := range olds {
+		adv, lsb, err := f.hmtxMetrics(b, x)
+		if err != nil {
+			return nil, err
+		}
+		hmtx = appendU16(hmtx, adv)
+		hmtx = appendU16(hmtx, lsb)
+	}
+
+	// Modify copies of the head, hhea and maxp tables.
+	head, err := f.copyTable(b, f.head)
+	if err != nil {
+		return nil, err
+	}
+	if longLoca {
+		putU16(head[50:], 1)
+	} else {
+		putU16(head[50:], 0)
+	}
+	hhea, err := f.copyTable(b, f.hhea)
+	if err != nil {
+		return nil, err
+	}
+	putU16(hhea[34:], uint16(len(olds)))
+	maxp, err := f.copyTable(b, f.maxp)
+	if err != nil {
+		return nil, err
+	}
+	putU16(maxp[4:], uint16(len(olds)))
+
+	newRuneGlyphs := make(map[rune]GlyphIndex, len(runeGlyphs))
+	for r, x := range runeGlyphs {
+		newRuneGlyphs[r] = news[x]
+	}
+	cmap, err := writeCmap(newRuneGlyphs)
+	if err != nil {
+		return nil, err
+	}
+	post, err := f.subsetPost(b, olds)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := []taggedTable{
+		{MustParseTag("cmap"), cmap},
+		{MustParseTag("glyf"), glyf},
+		{tagHead, head},
+		{MustParseTag("hhea"), hhea},
+		{MustParseTag("hmtx"), hmtx},
+		{MustParseTag("loca"), loca},
+		{MustParseTag("maxp"), maxp},
+		{MustParseTag("post"), post},
+	}
+	for _, t := range []struct {
+		tag string
+		t   table
+	}{
+		{"OS/2", f.os2},
+		{"cvt ", f.cvt},
+		{"fpgm", f.fpgm},
+		{"gasp", f.gasp},
+		{"name", f.name},
+		{"prep", f.prep},
+	} {
+		if t.t.length == 0 {
+			continue
+		}
+		data, err := f.copyTable(b, t.t)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, taggedTable{MustParseTag(t.tag), data})
+	}
+	return writeSFNT(0x00010000, tables), nil
+}
+
+func sortedGlyphIndexes(m map[GlyphIndex]bool) []GlyphIndex {
+	ret := make([]GlyphIndex, 0, len(m))
+	for x := range m {
+		ret = append(ret, x)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// copyTable returns a copy of the table t's data.
+func (f *Font) copyTable(b *Buffer, t table) ([]byte, error) {
+	buf, err := b.view(&f.src, int(t.offset), int(t.length))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), buf...), nil
+}
+
+// hmtxMetrics returns the x'th glyph's advance width and left side bearing,
+// in font units, from the hmtx table.
+func (f *Font) hmtxMetrics(b *Buffer, x GlyphIndex) (adv, lsb uint16, err error) {
+	n := GlyphIndex(f.cached.numHMetrics)
+	y := x
+	if y >= n {
+		y = n - 1
+	}
+	buf, err := b.view(&f.src, int(f.hmtx.offset)+4*int(y), 4)
+	if err != nil {
+		return 0, 0, err
+	}
+	adv, lsb = u16(buf), u16(buf[2:])
+	if x >= n {
+		offset := 4*int(n) + 2*int(x-n)
+		if uint32(offset+2) > f.hmtx.length {
+			return 0, 0, errInvalidHmtxTable
+		}
+		buf, err = b.view(&f.src, int(f.hmtx.offset)+offset, 2)
+		if err != nil {
+			return 0, 0, err
+		}
+		lsb = u16(buf)
+	}
+	return adv, lsb, nil
+}
+
+// subsetPost returns a post table for the glyphs olds, in order. It is a
+// version 2.0 table if f has glyph names, and a version 3.0 table otherwise.
+func (f *Font) subsetPost(b *Buffer, olds []GlyphIndex) ([]byte, error) {
+	const headerSize = 32
+	buf, err := b.view(&f.src, int(f.post.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	post := append([]byte(nil), buf...)
+	if v := f.cached.postTableVersion; v != 0x10000 && v != 0x20000 {
+		putU32(post, 0x30000)
+		return post, nil
+	}
+	putU32(post, 0x20000)
+
+	builtIn := make(map[string]uint16, numBuiltInPostNames)
+	for i := 0; i < numBuiltInPostNames; i++ {
+		builtIn[builtInPostNamesData[builtInPostNamesOffsets[i]:builtInPostNamesOffsets[i+1]]] = uint16(i)
+	}
+	post = appendU16(post, uint16(len(olds)))
+	var names []byte
+	numNames := 0
+	for _, x := range olds {
+		name, err := f.GlyphName(b, x)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := builtIn[name]; ok {
+			post = appendU16(post, i)
+			continue
+		}
+		if len(name) > 255 {
+			return nil, errInvalidPostTable
+		}
+		post = appendU16(post, uint16(numBuiltInPostNames+numNames))
+		names = append(names, uint8(len(name)))
+		names = append(names, name...)
+		numNames++
+	}
+	return append(post, names...), nil
+}
+
+// forEachGlyfComponent calls fn with the offset, within the glyph data, of
+// each of a compound glyph's component glyph indexes. It does nothing for a
+// simple glyph.
+func forEachGlyfComponent(data []byte, fn func(i int) error) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if len(data) < glyfHeaderLen {
+		return errInvalidGlyphData
+	}
+	if int16(u16(data)) >= 0 {
+		return nil
+	}
+	for i := glyfHeaderLen; ; {
+		if len(data)-i < 4 {
+			return errInvalidGlyphData
+		}
+		flags := u16(data[i:])
+		if err := fn(i + 2); err != nil {
+			return err
+		}
+		i += 4
+		if flags&flagArg1And2AreWords != 0 {
+			i += 4
+		} else {
+			i += 2
+		}
+		switch {
+		case flags&flagWeHaveAScale != 0:
+			i += 2
+		case flags&flagWeHaveAnXAndYScale != 0:
+			i += 4
+		case flags&flagWeHaveATwoByTwo != 0:
+			i += 8
+		}
+		if flags&flagMoreComponents == 0 {
+			if i > len(data) {
+				return errInvalidGlyphData
+			}
+			return nil
+		}
+	}
+}
+
+// writeCmap returns a cmap table that maps each rune in m to its glyph. It has
+// a Windows Unicode BMP (format 4) subtable, and a Windows Unicode full
+// repertoire (format 12) subtable if any rune is outside of the BMP.
+func writeCmap(m map[rune]GlyphIndex) ([]byte, error) {
+	runes := make([]rune, 0, len(m))
+	for r := range m {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	// Group the runes into ranges of consecutive runes that map to consecutive
+	// glyphs.
+	type group struct {
+		lo, hi rune
+		x      GlyphIndex
+	}
+	var bmp, full []group
+	for _, r := range runes {
+		x := m[r]
+		if n := len(full); n > 0 && full[n-1].hi+1 == r &&
+			full[n-1].x+GlyphIndex(r-full[n-1].lo) == x && (r <= 0xffff) == (full[n-1].hi <= 0xffff) {
+			full[n-1].hi = r
+		} else {
+			full = append(full, group{r, r, x})
+		}
+	}
+	for _, g := range full {
+		if g.hi < 0xffff {
+			bmp = append(bmp, g)
+		}
+	}
+
+	// The format 4 subtable's last segment must map 0xFFFF.
+	segCountX2 := 2 * (len(bmp) + 1)
+	if 16+4*segCountX2 > 0xffff {
+		return nil, errUnsupportedNumberOfCmapSegments
+	}
+	searchRange, entrySelector := 2, 0
+	for 2*searchRange <= segCountX2 {
+		searchRange *= 2
+		entrySelector++
+	}
+	f4 := appendU16(nil, 4)
+	f4 = appendU16(f4, uint16(16+4*segCountX2))
+	f4 = appendU16(f4, 0)
+	f4 = appendU16(f4, uint16(segCountX2))
+	f4 = appendU16(f4, uint16(searchRange))
+	f4 = appendU16(f4, uint16(entrySelector))
+	f4 = appendU16(f4, uint16(segCountX2-searchRange))
+	for _, g := range bmp {
+		f4 = appendU16(f4, uint16(g.hi))
+	}
+	f4 = appendU16(f4, 0xffff)
+	f4 = appendU16(f4, 0) // reservedPad.
+	for _, g := range bmp {
+		f4 = appendU16(f4, uint16(g.lo))
+	}
+	f4 = appendU16(f4, 0xffff)
+	for _, g := range bmp {
+		f4 = appendU16(f4, uint16(g.x)-uint16(g.lo))
+	}
+	f4 = appendU16(f4, 1)
+	for i := 0; i <= len(bmp); i++ {
+		f4 = appendU16(f4, 0) // idRangeOffset.
+	}
+
+	var f12 []byte
+	if len(runes) > 0 && runes[len(runes)-1] > 0xffff {
+		f12 = appendU16(nil, 12)
+		f12 = appendU16(f12, 0)
+		f12 = appendU32(f12, uint32(16+12*len(full)))
+		f12 = appendU32(f12, 0)
+		f12 = appendU32(f12, uint32(len(full)))
+		for _, g := range full {
+			f12 = appendU32(f12, uint32(g.lo))
+			f12 = appendU32(f12, uint32(g.hi))
+			f12 = appendU32(f12, uint32(g.x))
+		}
+	}
+
+	numSubtables := 1
+	if f12 != nil {
+		numSubtables = 2
+	}
+	const headerSize, entrySize = 4, 8
+	cmap := appendU16(nil, 0)
+	cmap = appendU16(cmap, uint16(numSubtables))
+	offset := uint32(headerSize + entrySize*numSubtables)
+	cmap = append(cmap, 0x00, 0x03, 0x00, 0x01)
+	cmap = appendU32(cmap, offset)
+	if f12 != nil {
+		cmap = append(cmap, 0x00, 0x03, 0x00, 0x0a)
+		cmap = appendU32(cmap, offset+uint32(len(f4)))
+	}
+	cmap = append(cmap, f4...)
+	return append(cmap, f12...), nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestSubset(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var b Buffer
+	gopher, err := f.GlyphIndex(&b, '\uf800') // U+F800 <Private Use>, the gopher.
+	if err != nil {
+		t.Fatalf("GlyphIndex: %v", err)
+	}
+
+	runes := []rune("Go!\u00c4")
+	data, err := Subset(f, runes, []GlyphIndex{gopher})
+	if err != nil {
+		t.Fatalf("Subset: %v", err)
+	}
+	if got, want := checksum(data), uint32(0xb1b0afba); got != want {
+		t.Errorf("checksum: got %#08x, want %#08x", got, want)
+	}
+	g, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse subset: %v", err)
+	}
+	if got, want := g.NumGlyphs(), 1+len(runes)+1; got < want {
+		t.Errorf("NumGlyphs: got %d, want at least %d", got, want)
+	}
+	if x, err := g.GlyphIndex(&b, 'x'); err != nil || x != 0 {
+		t.Errorf("GlyphIndex('x'): got %d, %v, want 0, nil", x, err)
+	}
+
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	for _, r := range runes {
+		x0, err := f.GlyphIndex(&b, r)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphIndex: %v", r, err)
+		}
+		x1, err := g.GlyphIndex(&b, r)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphIndex (subset): %v", r, err)
+		}
+		if x1 == 0 {
+			t.Errorf("r=%q: no glyph in the subset", r)
+			continue
+		}
+
+		name0, err := f.GlyphName(&b, x0)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphName: %v", r, err)
+		}
+		name1, err := g.GlyphName(&b, x1)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphName (subset): %v", r, err)
+		}
+		if name0 != name1 {
+			t.Errorf("r=%q: GlyphName: got %q, want %q", r, name1, name0)
+		}
+
+		adv0, err := f.GlyphAdvance(&b, x0, ppem, font.HintingNone)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphAdvance: %v", r, err)
+		}
+		adv1, err := g.GlyphAdvance(&b, x1, ppem, font.HintingNone)
+		if err != nil {
+			t.Fatalf("r=%q: GlyphAdvance (subset): %v", r, err)
+		}
+		if adv0 != adv1 {
+			t.Errorf("r=%q: GlyphAdvance: got %v, want %v", r, adv1, adv0)
+		}
+
+		data0, err := f.viewGlyphData(&b, x0)
+		if err != nil {
+			t.Fatalf("r=%q: viewGlyphData: %v", r, err)
+		}
+		data0 = append([]byte(nil), data0...)
+		data1, err := g.viewGlyphData(&b, x1)
+		if err != nil {
+			t.Fatalf("r=%q: viewGlyphData (subset): %v", r, err)
+		}
+		if string(data0) != string(data1[:len(data0)]) {
+			t.Errorf("r=%q: glyph data differs", r)
+		}
+	}
+
+	if _, err := Subset(f, nil, []GlyphIndex{GlyphIndex(f.NumGlyphs())}); err != ErrNotFound {
+		t.Errorf("Subset with an out of range glyph: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestForEachGlyfComponent(t *testing.T) {
+	data := concat(
+		be16(0xffff, 0, 0, 0, 0), // numberOfContours, xMin, yMin, xMax, yMax.
+		be16(flagArg1And2AreWords|flagMoreComponents, 3, 10, 20),
+		be16(flagWeHaveAScale, 7), []byte{1, 2}, be16(0x4000),
+	)
+	var got []int
+	err := forEachGlyfComponent(data, func(i int) error {
+		got = append(got, int(u16(data[i:])))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEachGlyfComponent: %v", err)
+	}
+	if want := []int{3, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if err := forEachGlyfComponent(data[:len(data)-1], func(int) error { return nil }); err != errInvalidGlyphData {
+		t.Errorf("truncated data: got %v, want %v", err, errInvalidGlyphData)
+	}
+}
+
+func TestWriteCmap(t *testing.T) {
+	m := map[rune]GlyphIndex{
+		'a':          1,
+		'b':          2,
+		'c':          3,
+		'z':          5,
+		'\u4e2d':     4,
+		0x1f600:      6,
+		0x1f601:      7,
+		0x10fffd:     8,
+		'\U0001f602': 2,
+	}
+	cmap, err := writeCmap(m)
+	if err != nil {
+		t.Fatalf("writeCmap: %v", err)
+	}
+	for _, format := range []uint16{4, 12} {
+		f := &Font{
+			src:  source{b: cmap},
+			cmap: table{0, uint32(len(cmap))},
+		}
+		// Select the subtable of the given format.
+		offset := u32(cmap[8:])
+		if format == 12 {
+			offset = u32(cmap[16:])
+		}
+		length := uint32(len(cmap)) - offset
+		if _, err := f.makeCachedGlyphIndex(nil, offset, length, format, pidWindows, psidWindowsUCS4); err != nil {
+			t.Fatalf("format %d: makeCachedGlyphIndex: %v", format, err)
+		}
+		for r := rune(0); r <= 0x10ffff; r++ {
+			want := m[r]
+			if format == 4 && r > 0xffff {
+				want = 0
+			}
+			got, err := f.GlyphIndex(nil, r)
+			if err != nil {
+				t.Fatalf("format %d: r=%U: %v", format, r, err)
+			}
+			if got != want {
+				t.Fatalf("format %d: r=%U: got %d, want %d", format, r, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements the SVG (Scalable Vector Graphics) table, as described
+// at https://www.microsoft.com/typography/otspec/svg.htm
+
+// svgInfo holds the location of the SVG table's document list.
+type svgInfo struct {
+	numDocuments int32
+	documentList uint32
+}
+
+func (f *Font) parseSVG(buf []byte) ([]byte, error) {
+	if f.svg.length == 0 {
+		return buf, nil
+	}
+	const headerSize = 10
+	if f.svg.length < headerSize {
+		return nil, errInvalidSVGTable
+	}
+	buf, err := f.src.view(buf, int(f.svg.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if u16(buf) != 0 {
+		return nil, errUnsupportedSVGTable
+	}
+	documentList := u32(buf[2:])
+	if documentList > f.svg.length-2 {
+		return nil, errInvalidSVGTable
+	}
+	u, err := f.src.u16(buf, f.svg, int(documentList))
+	if err != nil {
+		return nil, err
+	}
+	const recordSize = 12
+	if uint32(u)*recordSize > f.svg.length-documentList-2 {
+		return nil, errInvalidSVGTable
+	}
+	f.cached.svg = svgInfo{
+		numDocuments: int32(u),
+		documentList: documentList,
+	}
+	return buf, nil
+}
+
+// SVGDocument returns the SVG document, from the SVG table, that holds the
+// glyph x, and the range of glyphs, from first to last inclusive, that the
+// document holds. The document's element with the id "glyphN", where N is x
+// in decimal, is x's glyph description.
+//
+// The document data may be gzip-compressed, in which case it starts with the
+// bytes "\x1f\x8b". It is nil if x has no SVG document.
+//
+// If b is non-nil, the data becomes invalid to use once b is re-used.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) SVGDocument(b *Buffer, x GlyphIndex) (data []byte, first, last GlyphIndex, err error) {
+	if err := f.skippedTable("SVG "); err != nil {
+		return nil, 0, 0, err
+	}
+	if int(x) >= f.NumGlyphs() {
+		return nil, 0, 0, ErrNotFound
+	}
+	s := f.cached.svg
+	if s.numDocuments == 0 {
+		return nil, 0, 0, nil
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+
+	const recordSize = 12
+	for lo, hi := int32(0), s.numDocuments; lo < hi; {
+		i := (lo + hi) / 2
+		buf, err := b.view(&f.src, int(f.svg.offset+s.documentList)+2+recordSize*int(i), recordSize)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		first, last := GlyphIndex(u16(buf)), GlyphIndex(u16(buf[2:]))
+		if x < first {
+			hi = i
+		} else if x > last {
+			lo = i + 1
+		} else {
+			// The document's offset is relative to the start of the
+			// document list.
+			offset, length := u32(buf[4:]), u32(buf[8:])
+			if offset > f.svg.length-s.documentList || length > f.svg.length-s.documentList-offset {
+				return nil, 0, 0, errInvalidSVGTable
+			}
+			data, err := b.view(&f.src, int(f.svg.offset+s.documentList+offset), int(length))
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			return data, first, last, nil
+		}
+	}
+	return nil, 0, 0, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// This file implements converting glyph outlines to SVG (Scalable Vector
+// Graphics) path data, as described at
+// https://www.w3.org/TR/SVG/paths.html#PathData
+
+import (
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// AppendSVGPathData appends to dst the SVG path data for the segments, such
+// as those returned by LoadGlyph, and returns the extended buffer.
+//
+// The segments' coordinates are in 26.6 fixed point pixels and the path
+// data's coordinates are in pixels. The segments' y axis increases up and
+// SVG's y axis increases down, so the y coordinates are negated. Each
+// contour is closed.
+func AppendSVGPathData(dst []byte, segments []Segment) []byte {
+	for i, s := range segments {
+		n := 0
+		switch s.Op {
+		case SegmentOpMoveTo:
+			if i != 0 {
+				dst = append(dst, 'Z')
+			}
+			dst, n = append(dst, 'M'), 1
+		case SegmentOpLineTo:
+			dst, n = append(dst, 'L'), 1
+		case SegmentOpQuadTo:
+			dst, n = append(dst, 'Q'), 2
+		case SegmentOpCubeTo:
+			dst, n = append(dst, 'C'), 3
+		}
+		for j := 0; j < n; j++ {
+			if j != 0 {
+				dst = append(dst, ' ')
+			}
+			dst = appendSVGNumber(dst, s.Args[2*j+0])
+			dst = append(dst, ' ')
+			dst = appendSVGNumber(dst, -s.Args[2*j+1])
+		}
+	}
+	if len(segments) != 0 {
+		dst = append(dst, 'Z')
+	}
+	return dst
+}
+
+// appendSVGNumber appends x, in pixels, as a decimal number. A 26.6 fixed
+// point number's fractional part has at most 6 decimal digits.
+func appendSVGNumber(dst []byte, x fixed.Int26_6) []byte {
+	return strconv.AppendFloat(dst, float64(x)/64, 'f', -1, 64)
+}
+
+// GlyphSVG returns a standalone SVG document that draws the x'th glyph, in
+// font units. Its viewBox spans the glyph's advance width horizontally and,
+// vertically, the ascent and descent of the font's hhea table, with the
+// baseline at y=0.
+//
+// It returns ErrNotFound if the glyph index is out of range.
+func (f *Font) GlyphSVG(b *Buffer, x GlyphIndex) ([]byte, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	hhea, err := f.HorizontalHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	// A ppem of unitsPerEm pixels gives coordinates in font units.
+	ppem := fixed.I(int(f.cached.unitsPerEm))
+	advance, err := f.GlyphAdvance(b, x, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := f.LoadGlyph(b, x, ppem, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 `)
+	dst = strconv.AppendInt(dst, int64(-hhea.Ascent), 10)
+	dst = append(dst, ' ')
+	dst = appendSVGNumber(dst, advance)
+	dst = append(dst, ' ')
+	dst = strconv.AppendInt(dst, int64(hhea.Ascent-hhea.Descent), 10)
+	dst = append(dst, `"><path d="`...)
+	dst = AppendSVGPathData(dst, segments)
+	dst = append(dst, `"/></svg>`...)
+	return dst, nil
+}
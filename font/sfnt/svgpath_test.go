@@ -0,0 +1,69 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendSVGPathData(t *testing.T) {
+	segments := []Segment{
+		moveTo(0, 0),
+		lineTo(64, -32),
+		quadTo(96, 1, 128, 64),
+		moveTo(-640, 0),
+		cubeTo(0, 64, 64, 128, 128, 192),
+	}
+	got := string(AppendSVGPathData([]byte("d="), segments))
+	want := "d=M0 0L1 0.5Q1.5 -0.015625 2 -1ZM-10 0C0 -1 1 -2 2 -3Z"
+	if got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+
+	if got := AppendSVGPathData(nil, nil); len(got) != 0 {
+		t.Errorf("no segments: got %q, want empty", got)
+	}
+}
+
+func TestGlyphSVG(t *testing.T) {
+	testCases := []struct {
+		filename string
+		x        GlyphIndex
+		want     string
+	}{{
+		filename: "glyfTest.ttf",
+		x:        4,
+		want: `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 -1638 819 1638">` +
+			`<path d="M205 0L205 -1638L614 -1638L614 0L205 0Z"/></svg>`,
+	}, {
+		filename: "CFFTest.otf",
+		x:        2,
+		want: `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 -800 400 800">` +
+			`<path d="M100 0L300 0L300 -800L100 -800Z"/></svg>`,
+	}}
+	for _, tc := range testCases {
+		data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/" + tc.filename))
+		if err != nil {
+			t.Fatalf("%s: ReadFile: %v", tc.filename, err)
+		}
+		f, err := Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse: %v", tc.filename, err)
+		}
+		got, err := f.GlyphSVG(nil, tc.x)
+		if err != nil {
+			t.Errorf("%s: GlyphSVG: %v", tc.filename, err)
+			continue
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s:\ngot  %s\nwant %s", tc.filename, got, tc.want)
+		}
+		if _, err := f.GlyphSVG(nil, 0xffff); err != ErrNotFound {
+			t.Errorf("%s: GlyphSVG(nil, 0xffff): got %v, want %v", tc.filename, err, ErrNotFound)
+		}
+	}
+}
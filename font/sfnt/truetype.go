@@ -5,9 +5,16 @@
 package sfnt
 
 import (
+	"math"
+
 	"golang.org/x/image/math/fixed"
 )
 
+// maxCompoundDepth is not part of the specification, but is a limitation used
+// by this implementation, which also rejects compound glyphs that (directly
+// or indirectly) contain themselves.
+const maxCompoundDepth = 8
+
 // Flags for simple (non-compound) glyphs.
 //
 // See https://www.microsoft.com/typography/OTSPEC/glyf.htm
@@ -23,6 +30,8 @@ const (
 	flagThisXIsSame          = 1 << 4 // 0x0010
 	flagPositiveYShortVector = 1 << 5 // 0x0020
 	flagThisYIsSame          = 1 << 5 // 0x0020
+
+	flagOverlapSimple = 1 << 6 // 0x0040
 )
 
 // Flags for compound glyphs.
@@ -113,7 +122,8 @@ func appendGlyfSegments(dst []Segment, data []byte) ([]Segment, error) {
 		return nil, errInvalidGlyphData
 	}
 
-	// TODO: support compound glyphs.
+	// Loading a compound glyph's components needs the Font. See
+	// Font.appendCompoundGlyfSegments.
 	if numContours < 0 {
 		return nil, errUnsupportedCompoundGlyph
 	}
@@ -215,10 +225,286 @@ func findXYIndexes(data []byte, index, numPoints int) (xIndex, yIndex int32, ok
 	return int32(index), int32(index + xDataLen), true
 }
 
+// glyfPoint is a decoded glyf point, in font units.
+type glyfPoint struct {
+	x, y int16
+	on   bool
+}
+
+// decodeGlyfPoints appends the points and the (inclusive) contour end point
+// indexes of a simple (non-compound) glyph to dstPoints and dstEnds. For
+// compound or empty glyphs, it appends nothing.
+func decodeGlyfPoints(dstPoints []glyfPoint, dstEnds []int, data []byte) ([]glyfPoint, []int, error) {
+	if len(data) == 0 {
+		return dstPoints, dstEnds, nil
+	}
+	if len(data) < glyfHeaderLen {
+		return nil, nil, errInvalidGlyphData
+	}
+	numContours := int16(u16(data))
+	if numContours <= 0 {
+		if numContours < -1 {
+			return nil, nil, errInvalidGlyphData
+		}
+		return dstPoints, dstEnds, nil
+	}
+	index := glyfHeaderLen + 2*int(numContours)
+	if index+2 > len(data) {
+		return nil, nil, errInvalidGlyphData
+	}
+	prevEnd := -1
+	for i := 0; i < int(numContours); i++ {
+		end := int(u16(data[glyfHeaderLen+2*i:]))
+		if end <= prevEnd {
+			return nil, nil, errInvalidGlyphData
+		}
+		dstEnds = append(dstEnds, end)
+		prevEnd = end
+	}
+	numPoints := 1 + prevEnd
+
+	index += 2 + int(u16(data[index:]))
+	if index > len(data) {
+		return nil, nil, errInvalidGlyphData
+	}
+	xIndex, yIndex, ok := findXYIndexes(data, index, numPoints)
+	if !ok {
+		return nil, nil, errInvalidGlyphData
+	}
+	g := glyfIter{
+		data:      data,
+		flagIndex: int32(index),
+		xIndex:    xIndex,
+		yIndex:    yIndex,
+		nPoints:   int32(numPoints),
+	}
+	for g.nextPoint() {
+		dstPoints = append(dstPoints, glyfPoint{x: g.x, y: g.y, on: g.on})
+	}
+	return dstPoints, dstEnds, nil
+}
+
+// appendGlyfPointSegments appends to dst the segments for the given points
+// and contour end point indexes, as returned by decodeGlyfPoints.
+func appendGlyfPointSegments(dst []Segment, points []glyfPoint, ends []int) ([]Segment, error) {
+	g := glyfIter{
+		points:      points,
+		ends:        ends,
+		prevEnd:     -1,
+		numContours: int32(len(ends)),
+	}
+	for g.nextContour() {
+		for g.nextSegment() {
+			dst = append(dst, g.seg)
+		}
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+	return dst, nil
+}
+
+// appendCompoundGlyfSegments appends to b.segments the segments of the x'th
+// glyph, which is a compound glyph.
+func (f *Font) appendCompoundGlyfSegments(b *Buffer, x GlyphIndex) ([]Segment, error) {
+	points, ends, err := f.appendGlyfPoints(b, x, b.points[:0], b.ends[:0], 0)
+	if err != nil {
+		return nil, err
+	}
+	b.points, b.ends = points, ends
+	return appendGlyfPointSegments(b.segments, points, ends)
+}
+
+// appendGlyfPoints appends the points and the (inclusive) contour end point
+// indexes of the x'th glyph to dstPoints and dstEnds, like decodeGlyfPoints,
+// but it also applies any gvar table deltas and, for a compound glyph,
+// appends its components' points. The contour end point indexes are
+// relative to the start of dstPoints, not to the x'th glyph's first point.
+//
+// depth is the compound glyph nesting depth.
+func (f *Font) appendGlyfPoints(b *Buffer, x GlyphIndex, dstPoints []glyfPoint, dstEnds []int, depth int) ([]glyfPoint, []int, error) {
+	data, err := f.viewGlyphData(b, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, m := len(dstPoints), len(dstEnds)
+	if len(data) < glyfHeaderLen || int16(u16(data)) >= 0 {
+		dstPoints, dstEnds, err = decodeGlyfPoints(dstPoints, dstEnds, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if f.cached.normalizedCoords != nil && f.gvar.length != 0 && len(dstPoints) > n {
+			points := dstPoints[n:]
+			deltas, err := f.glyphVariationDeltas(b, x, points, dstEnds[m:], len(points)+4)
+			if err != nil {
+				return nil, nil, err
+			}
+			for i := range points {
+				d := deltas[i]
+				points[i].x = clampInt16(float64(points[i].x) + math.Floor(d.x+0.5))
+				points[i].y = clampInt16(float64(points[i].y) + math.Floor(d.y+0.5))
+			}
+		}
+		for i := m; i < len(dstEnds); i++ {
+			dstEnds[i] += n
+		}
+		return dstPoints, dstEnds, nil
+	}
+
+	if depth >= maxCompoundDepth {
+		return nil, nil, errUnsupportedCompoundGlyph
+	}
+	// Loading the components may overwrite the data slice, so we copy the
+	// component records first.
+	b.compoundData[depth] = append(b.compoundData[depth][:0], data[glyfHeaderLen:]...)
+	data = b.compoundData[depth]
+
+	for {
+		if len(data) < 4 {
+			return nil, nil, errInvalidGlyphData
+		}
+		flags := u16(data)
+		component := GlyphIndex(u16(data[2:]))
+		data = data[4:]
+
+		// arg1 and arg2 are either an (x, y) offset or, if the
+		// flagArgsAreXYValues bit is not set, the indexes of two points to
+		// align: one in the glyph so far and one in the component.
+		var arg1, arg2 int32
+		if flags&flagArg1And2AreWords != 0 {
+			if len(data) < 4 {
+				return nil, nil, errInvalidGlyphData
+			}
+			arg1, arg2 = int32(u16(data)), int32(u16(data[2:]))
+			if flags&flagArgsAreXYValues != 0 {
+				arg1, arg2 = int32(int16(arg1)), int32(int16(arg2))
+			}
+			data = data[4:]
+		} else {
+			if len(data) < 2 {
+				return nil, nil, errInvalidGlyphData
+			}
+			arg1, arg2 = int32(data[0]), int32(data[1])
+			if flags&flagArgsAreXYValues != 0 {
+				arg1, arg2 = int32(int8(arg1)), int32(int8(arg2))
+			}
+			data = data[2:]
+		}
+
+		// The transform is [xx, yx, xy, yy], so that a point (x, y) maps to
+		// (xx*x + xy*y, yx*x + yy*y).
+		transform, scaled := [4]float64{1, 0, 0, 1}, true
+		switch {
+		case flags&flagWeHaveAScale != 0:
+			if len(data) < 2 {
+				return nil, nil, errInvalidGlyphData
+			}
+			transform[0] = f2Dot14(u16(data))
+			transform[3] = transform[0]
+			data = data[2:]
+		case flags&flagWeHaveAnXAndYScale != 0:
+			if len(data) < 4 {
+				return nil, nil, errInvalidGlyphData
+			}
+			transform[0] = f2Dot14(u16(data))
+			transform[3] = f2Dot14(u16(data[2:]))
+			data = data[4:]
+		case flags&flagWeHaveATwoByTwo != 0:
+			if len(data) < 8 {
+				return nil, nil, errInvalidGlyphData
+			}
+			transform[0] = f2Dot14(u16(data))
+			transform[1] = f2Dot14(u16(data[2:]))
+			transform[2] = f2Dot14(u16(data[4:]))
+			transform[3] = f2Dot14(u16(data[6:]))
+			data = data[8:]
+		default:
+			scaled = false
+		}
+
+		start := len(dstPoints)
+		dstPoints, dstEnds, err = f.appendGlyfPoints(b, component, dstPoints, dstEnds, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		points := dstPoints[start:]
+		if scaled {
+			for i, p := range points {
+				fx, fy := float64(p.x), float64(p.y)
+				points[i].x = clampInt16(math.Floor(transform[0]*fx + transform[2]*fy + 0.5))
+				points[i].y = clampInt16(math.Floor(transform[1]*fx + transform[3]*fy + 0.5))
+			}
+		}
+
+		var dx, dy int32
+		if flags&flagArgsAreXYValues != 0 {
+			dx, dy = arg1, arg2
+			// The offset is only transformed if the font asks for Apple's
+			// behavior. Microsoft's behavior, the default, is not to.
+			if scaled && flags&flagScaledComponentOffset != 0 && flags&flagUnscaledComponentOffset == 0 {
+				fx, fy := float64(dx), float64(dy)
+				dx = int32(math.Floor(transform[0]*fx + transform[2]*fy + 0.5))
+				dy = int32(math.Floor(transform[1]*fx + transform[3]*fy + 0.5))
+			}
+		} else {
+			// The first point index is relative to the compound glyph's
+			// points so far, which start at n, and the second is relative to
+			// the component's points.
+			if int(arg1) >= start-n || int(arg2) >= len(points) {
+				return nil, nil, errInvalidGlyphData
+			}
+			p, q := dstPoints[n+int(arg1)], points[arg2]
+			dx, dy = int32(p.x)-int32(q.x), int32(p.y)-int32(q.y)
+		}
+		if dx != 0 || dy != 0 {
+			for i := range points {
+				points[i].x = clampInt16(float64(int32(points[i].x) + dx))
+				points[i].y = clampInt16(float64(int32(points[i].y) + dy))
+			}
+		}
+
+		if flags&flagMoreComponents == 0 {
+			return dstPoints, dstEnds, nil
+		}
+	}
+}
+
+// appendHintedSegments appends to dst the segments for the given hinted
+// points and contour end point indexes. Unlike appendGlyfPointSegments, the
+// segments are already scaled.
+func appendHintedSegments(dst []Segment, points []hintPoint, ends []int) ([]Segment, error) {
+	g := glyfIter{
+		hinted:      points,
+		ends:        ends,
+		prevEnd:     -1,
+		numContours: int32(len(ends)),
+	}
+	for g.nextContour() {
+		for g.nextSegment() {
+			dst = append(dst, g.seg)
+		}
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+	return dst, nil
+}
+
 type glyfIter struct {
 	data []byte
 	err  error
 
+	// points and ends, if non-nil, are pre-decoded points and contour end
+	// point indexes, used instead of the data slice's encoded points.
+	points     []glyfPoint
+	ends       []int
+	pointIndex int32
+
+	// hinted, if non-nil, are hinted points, used instead of points. Their
+	// coordinates, hx and hy, are already in 26.6 fixed point pixels.
+	hinted []hintPoint
+	hx, hy int32
+
 	// Various indices into the data slice. See the "Decoding those points in
 	// row order" comment above.
 	flagIndex int32
@@ -282,8 +568,13 @@ func (g *glyfIter) nextContour() (ok bool) {
 	}
 	g.c++
 
-	end := int32(u16(g.data[g.endIndex:]))
-	g.endIndex += 2
+	end := int32(0)
+	if g.ends != nil {
+		end = int32(g.ends[g.c-1])
+	} else {
+		end = int32(u16(g.data[g.endIndex:]))
+		g.endIndex += 2
+	}
 	if end <= g.prevEnd {
 		g.err = errInvalidGlyphData
 		return false
@@ -370,6 +661,12 @@ func (g *glyfIter) nextSegment() (ok bool) {
 			X: fixed.Int26_6(g.x),
 			Y: fixed.Int26_6(g.y),
 		}
+		if g.hinted != nil {
+			p = fixed.Point26_6{
+				X: fixed.Int26_6(g.hx),
+				Y: fixed.Int26_6(g.hy),
+			}
+		}
 
 		if !g.firstOnCurveValid {
 			if g.on {
@@ -458,6 +755,28 @@ func (g *glyfIter) nextPoint() (ok bool) {
 	}
 	g.p++
 
+	if g.hinted != nil {
+		if int(g.pointIndex) >= len(g.hinted) {
+			g.err = errInvalidGlyphData
+			return false
+		}
+		p := g.hinted[g.pointIndex]
+		g.pointIndex++
+		g.hx, g.hy, g.on = p.x, p.y, p.on
+		return true
+	}
+
+	if g.points != nil {
+		if int(g.pointIndex) >= len(g.points) {
+			g.err = errInvalidGlyphData
+			return false
+		}
+		p := g.points[g.pointIndex]
+		g.pointIndex++
+		g.x, g.y, g.on = p.x, p.y, p.on
+		return true
+	}
+
 	if g.repeats > 0 {
 		g.repeats--
 	} else {
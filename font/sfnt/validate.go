@@ -0,0 +1,124 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"fmt"
+)
+
+// ValidationProblem is a structural problem with a font's data, as found by
+// Font.Validate. It implements the error interface.
+type ValidationProblem struct {
+	// Tag is the tag of the table with the problem, or zero if the problem is
+	// with the font as a whole.
+	Tag Tag
+	// Description describes the problem.
+	Description string
+}
+
+func (p ValidationProblem) Error() string {
+	if p.Tag == 0 {
+		return "sfnt: " + p.Description
+	}
+	return fmt.Sprintf("sfnt: %q table: %s", p.Tag, p.Description)
+}
+
+// Validate checks f's data for corruption that Parse does not detect, as Parse
+// only reads the tables, and the parts of tables, that f's methods need. It
+// returns the problems found, or nil if there are none. The returned error is
+// non-nil if f's table directory could not be read.
+//
+// It checks that each table is within the font data and that its checksum
+// matches its table record. Unless f is part of a Collection, whose fonts
+// share tables, it checks the head table's checkSumAdjustment. It also checks
+// that the loca table's glyph locations are in increasing order and within
+// the glyf table.
+//
+// For WOFF and WOFF2 data, the checks are of the decoded SFNT data.
+func (f *Font) Validate(b *Buffer) ([]ValidationProblem, error) {
+	if b == nil {
+		b = &Buffer{}
+	}
+	buf, err := f.viewTableRecords(b)
+	if err != nil {
+		return nil, err
+	}
+	// Copy the table directory, as the table views below may re-use its
+	// memory.
+	dir := append([]byte(nil), buf...)
+
+	var problems []ValidationProblem
+	problem := func(tag Tag, format string, args ...interface{}) {
+		problems = append(problems, ValidationProblem{
+			Tag:         tag,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	// fontSum is the checksum of the whole font, which is the sum of the
+	// offset table, the table records and the tables' checksums.
+	buf, err = b.view(&f.src, f.offset, 12)
+	if err != nil {
+		return nil, err
+	}
+	fontSum := checksum(buf) + checksum(dir)
+	fontSumOK := f.offset == 0
+
+	var headAdjustment uint32
+	for r := dir; len(r) >= 16; r = r[16:] {
+		tag := Tag(u32(r))
+		want, o, n := u32(r[4:]), u32(r[8:]), u32(r[12:])
+		data, err := b.view(&f.src, int(o), int(n))
+		if err != nil {
+			problem(tag, "table (offset %d, length %d) is not within the font data", o, n)
+			fontSumOK = false
+			continue
+		}
+		got := checksumPadded(data)
+		if tag == tagHead && len(data) >= 12 {
+			// The head table's checksum is computed with a zero
+			// checkSumAdjustment.
+			headAdjustment = u32(data[8:])
+			got -= headAdjustment
+		}
+		if got != want {
+			problem(tag, "checksum is 0x%08x, table record says 0x%08x", got, want)
+		}
+		fontSum += got
+	}
+	if fontSumOK && f.head.length >= 12 {
+		if want := 0xb1b0afba - fontSum; headAdjustment != want {
+			problem(tagHead, "checkSumAdjustment is 0x%08x, want 0x%08x", headAdjustment, want)
+		}
+	}
+
+	if !f.cached.isPostScript && f.glyf.length != 0 {
+		locations := f.cached.locations
+		end := f.glyf.offset + f.glyf.length
+		for i := 1; i < len(locations); i++ {
+			if locations[i] < locations[i-1] {
+				problem(MustParseTag("loca"), "location of glyph %d is before that of glyph %d", i, i-1)
+				break
+			}
+		}
+		if n := len(locations); n > 0 && locations[n-1] > end {
+			problem(MustParseTag("loca"), "glyph locations extend past the end of the glyf table")
+		}
+	}
+	return problems, nil
+}
+
+// checksumPadded is like checksum, but len(b) need not be a multiple of 4. It
+// is implicitly padded with zeroes.
+func checksumPadded(b []byte) uint32 {
+	n := len(b) &^ 3
+	sum := checksum(b[:n])
+	if n < len(b) {
+		var tail [4]byte
+		copy(tail[:], b[n:])
+		sum += u32(tail[:])
+	}
+	return sum
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestValidate(t *testing.T) {
+	glyfTest, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	cffTest, err := ioutil.ReadFile(filepath.FromSlash("../testdata/CFFTest.otf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	// corruptGlyf flips a bit in the glyf table, without updating the
+	// checksums.
+	corruptGlyf := func(src []byte) []byte {
+		f, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		dst := append([]byte(nil), src...)
+		dst[f.glyf.offset+f.glyf.length/2] ^= 0x01
+		return dst
+	}
+
+	// moveLoca moves glyph 2's location to that of glyph 6, so that the
+	// locations are not in increasing order, and updates the checksums.
+	moveLoca := func(src []byte) []byte {
+		f, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		b, err := NewBuilder(f)
+		if err != nil {
+			t.Fatalf("NewBuilder: %v", err)
+		}
+		loca := append([]byte(nil), b.Table(MustParseTag("loca"))...)
+		if f.cached.indexToLocFormat {
+			t.Fatalf("moveLoca: unsupported long format loca table")
+		}
+		copy(loca[4:6], loca[12:14])
+		b.SetTable(MustParseTag("loca"), loca)
+		dst, err := b.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes: %v", err)
+		}
+		return dst
+	}
+
+	testCases := []struct {
+		desc string
+		data []byte
+		want []Tag
+	}{
+		{"goregular", goregular.TTF, nil},
+		{"glyfTest", glyfTest, nil},
+		{"CFFTest", cffTest, nil},
+		{"corrupt glyf", corruptGlyf(glyfTest), []Tag{MustParseTag("glyf"), tagHead}},
+		{"unordered loca", moveLoca(goregular.TTF), []Tag{MustParseTag("loca")}},
+	}
+	for _, tc := range testCases {
+		f, err := Parse(tc.data)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", tc.desc, err)
+			continue
+		}
+		problems, err := f.Validate(nil)
+		if err != nil {
+			t.Errorf("%s: Validate: %v", tc.desc, err)
+			continue
+		}
+		got := make([]Tag, len(problems))
+		for i, p := range problems {
+			got[i] = p.Tag
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want problems with %q", tc.desc, problems, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want problems with %q", tc.desc, problems, tc.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,896 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+// OpenType Font Variations let a single font file describe a continuous
+// design space, such as every weight between Thin and Black. The fvar table
+// lists the variation axes. The avar table optionally distorts each axis. The
+// gvar table holds, per glyph, a number of "tuple variations": sets of point
+// deltas that apply, with some scaling factor, at particular regions of the
+// design space.
+//
+// The relevant specifications are:
+//	- https://www.microsoft.com/typography/otspec/otvaroverview.htm
+//	- https://www.microsoft.com/typography/otspec/fvar.htm
+//	- https://www.microsoft.com/typography/otspec/avar.htm
+//	- https://www.microsoft.com/typography/otspec/gvar.htm
+
+import (
+	"math"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// VariationAxis is a variation axis of a variable font, such as its weight
+// ("wght") or width ("wdth"). Its values are in user-space coordinates, such
+// as 400 for a regular weight and 700 for a bold weight.
+type VariationAxis struct {
+	// Tag identifies the axis, such as "wght".
+	Tag Tag
+	// Min, Default and Max are the axis' range and default value.
+	Min, Default, Max float64
+	// NameID is the name table entry for the axis' display name.
+	NameID NameID
+	// Hidden is whether the font recommends not exposing the axis in user
+	// interfaces.
+	Hidden bool
+}
+
+// Variation is a user-space coordinate on a variation axis.
+type Variation struct {
+	Tag   Tag
+	Value float64
+}
+
+// NamedInstance is a named instance of a variable font, such as "Light" or
+// "Bold": a predefined point in the design space.
+type NamedInstance struct {
+	// Name is the instance's subfamily name, such as "Bold".
+	Name string
+	// SubfamilyNameID is the name table entry for Name.
+	SubfamilyNameID NameID
+	// PostScriptNameID is the name table entry for the instance's PostScript
+	// name. It is 0xffff if there is no such entry.
+	PostScriptNameID NameID
+	// Variations are the instance's coordinates, one per variation axis, in
+	// the same order as Font.VariationAxes.
+	Variations []Variation
+}
+
+// fvarInstance is a parsed fvar table InstanceRecord.
+type fvarInstance struct {
+	subfamilyNameID  NameID
+	postScriptNameID NameID
+	coords           []float64
+}
+
+// avarMapping is an avar table axis value map entry, in normalized
+// coordinates.
+type avarMapping struct {
+	from, to float64
+}
+
+// gvarInfo holds the location of the gvar table's sub-structures.
+type gvarInfo struct {
+	axisCount    int32
+	longOffsets  bool
+	offsetsBase  uint32
+	dataBase     uint32
+	sharedTuples []float64
+}
+
+// varDelta is a point delta, in font units.
+type varDelta struct {
+	x, y float64
+}
+
+// fixed16Dot16 converts a 16.16 fixed point value to a float64.
+func fixed16Dot16(u uint32) float64 { return float64(int32(u)) / (1 << 16) }
+
+// f2Dot14 converts a 2.14 fixed point value to a float64.
+func f2Dot14(u uint16) float64 { return float64(int16(u)) / (1 << 14) }
+
+// VariationAxes returns the variation axes of f, in the order that the fvar
+// table lists them. It returns nil if f is not a variable font.
+func (f *Font) VariationAxes() []VariationAxis {
+	if len(f.cached.variationAxes) == 0 {
+		return nil
+	}
+	return append([]VariationAxis(nil), f.cached.variationAxes...)
+}
+
+// NamedInstances returns the named instances of f, in the order that the fvar
+// table lists them. It returns nil if f is not a variable font.
+//
+// An instance's Name is empty if the name table has no suitable entry for its
+// SubfamilyNameID.
+func (f *Font) NamedInstances(b *Buffer) ([]NamedInstance, error) {
+	if err := f.skippedTable("fvar"); err != nil {
+		return nil, err
+	}
+	if len(f.cached.namedInstances) == 0 {
+		return nil, nil
+	}
+	axes := f.cached.variationAxes
+	ret := make([]NamedInstance, len(f.cached.namedInstances))
+	for i, x := range f.cached.namedInstances {
+		name, err := f.Name(b, x.subfamilyNameID)
+		if err != nil && err != ErrNotFound && err != errUnsupportedPlatformEncoding {
+			return nil, err
+		}
+		vs := make([]Variation, len(axes))
+		for j, a := range axes {
+			vs[j] = Variation{Tag: a.Tag, Value: x.coords[j]}
+		}
+		ret[i] = NamedInstance{
+			Name:             name,
+			SubfamilyNameID:  x.subfamilyNameID,
+			PostScriptNameID: x.postScriptNameID,
+			Variations:       vs,
+		}
+	}
+	return ret, nil
+}
+
+// WithVariations returns a copy of f whose glyph outlines and metrics are
+// those of the given point in the design space. Axes that aren't mentioned
+// take their default values, values outside an axis' range are clamped to
+// that range and variations whose tags don't match any axis are ignored.
+//
+// The returned Font shares the underlying data with f. Calling WithVariations
+// with no arguments returns the default instance.
+func (f *Font) WithVariations(vs ...Variation) *Font {
+	g := new(Font)
+	*g = *f
+	g.cached.normalizedCoords = nil
+
+	axes := f.cached.variationAxes
+	if len(axes) == 0 || len(vs) == 0 {
+		return g
+	}
+	coords := make([]float64, len(axes))
+	nonZero := false
+	for i, a := range axes {
+		v := a.Default
+		for _, x := range vs {
+			if x.Tag == a.Tag {
+				v = x.Value
+			}
+		}
+		c := normalizeAxisValue(a, v)
+		if i < len(f.cached.avarSegments) {
+			c = applyAvarSegments(f.cached.avarSegments[i], c)
+		}
+		// Round to the nearest F2Dot14 value, as per the "Coordinate Scales
+		// and Normalization" section of the specification.
+		c = math.Floor(c*(1<<14)+0.5) / (1 << 14)
+		coords[i] = c
+		nonZero = nonZero || c != 0
+	}
+	if nonZero {
+		g.cached.normalizedCoords = coords
+	}
+	return g
+}
+
+// normalizeAxisValue maps the user-space value v to the normalized [-1, +1]
+// range, with 0 corresponding to the axis' default.
+func normalizeAxisValue(a VariationAxis, v float64) float64 {
+	switch {
+	case v < a.Min:
+		v = a.Min
+	case v > a.Max:
+		v = a.Max
+	}
+	switch {
+	case v < a.Default:
+		if a.Default == a.Min {
+			return 0
+		}
+		return -(a.Default - v) / (a.Default - a.Min)
+	case v > a.Default:
+		if a.Default == a.Max {
+			return 0
+		}
+		return (v - a.Default) / (a.Max - a.Default)
+	}
+	return 0
+}
+
+// applyAvarSegments maps the normalized coordinate c through the piecewise
+// linear function described by an avar table segment map.
+func applyAvarSegments(m []avarMapping, c float64) float64 {
+	if len(m) == 0 {
+		return c
+	}
+	if c <= m[0].from {
+		return m[0].to
+	}
+	for i := 1; i < len(m); i++ {
+		if c <= m[i].from {
+			p, q := m[i-1], m[i]
+			if q.from == p.from {
+				return q.to
+			}
+			return p.to + (q.to-p.to)*(c-p.from)/(q.from-p.from)
+		}
+	}
+	return m[len(m)-1].to
+}
+
+func (f *Font) parseFvar(buf []byte) ([]byte, error) {
+	// https://www.microsoft.com/typography/otspec/fvar.htm
+
+	if f.fvar.length == 0 {
+		return buf, nil
+	}
+	const headerSize = 16
+	if f.fvar.length < headerSize {
+		return nil, errInvalidFvarTable
+	}
+	buf, err := f.src.view(buf, int(f.fvar.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if major := u16(buf); major != 1 {
+		return nil, errUnsupportedFvarTable
+	}
+	axesOffset := uint32(u16(buf[4:]))
+	axisCount := uint32(u16(buf[8:]))
+	axisSize := uint32(u16(buf[10:]))
+	instanceCount := uint32(u16(buf[12:]))
+	instanceSize := uint32(u16(buf[14:]))
+	if axisCount > maxVariationAxes {
+		return nil, errUnsupportedNumberOfVariationAxes
+	}
+	if axisSize < 20 || f.fvar.length < axesOffset || (f.fvar.length-axesOffset)/axisSize < axisCount {
+		return nil, errInvalidFvarTable
+	}
+	// The "InstanceRecord" section of the specification says that the
+	// instanceSize is either (4*axisCount + 4) or, when the optional
+	// postScriptNameID is present, (4*axisCount + 6).
+	instancesOffset := axesOffset + axisCount*axisSize
+	if instanceCount != 0 {
+		if instanceSize != 4*axisCount+4 && instanceSize != 4*axisCount+6 {
+			return nil, errInvalidFvarTable
+		}
+		if (f.fvar.length-instancesOffset)/instanceSize < instanceCount {
+			return nil, errInvalidFvarTable
+		}
+	}
+	buf, err = f.src.view(buf, int(f.fvar.offset+axesOffset), int(axisCount*axisSize))
+	if err != nil {
+		return nil, err
+	}
+	axes := make([]VariationAxis, axisCount)
+	for i := range axes {
+		b := buf[uint32(i)*axisSize:]
+		a := VariationAxis{
+			Tag:     Tag(u32(b)),
+			Min:     fixed16Dot16(u32(b[4:])),
+			Default: fixed16Dot16(u32(b[8:])),
+			Max:     fixed16Dot16(u32(b[12:])),
+			Hidden:  u16(b[16:])&0x0001 != 0,
+			NameID:  NameID(u16(b[18:])),
+		}
+		if a.Min > a.Default || a.Default > a.Max {
+			return nil, errInvalidFvarTable
+		}
+		axes[i] = a
+	}
+	f.cached.variationAxes = axes
+
+	if instanceCount == 0 {
+		return buf, nil
+	}
+	buf, err = f.src.view(buf, int(f.fvar.offset+instancesOffset), int(instanceCount*instanceSize))
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]fvarInstance, instanceCount)
+	for i := range instances {
+		b := buf[uint32(i)*instanceSize:]
+		x := fvarInstance{
+			subfamilyNameID:  NameID(u16(b)),
+			postScriptNameID: 0xffff,
+			coords:           make([]float64, axisCount),
+		}
+		for j := range x.coords {
+			x.coords[j] = fixed16Dot16(u32(b[4+4*j:]))
+		}
+		if instanceSize == 4*axisCount+6 {
+			x.postScriptNameID = NameID(u16(b[4+4*axisCount:]))
+		}
+		instances[i] = x
+	}
+	f.cached.namedInstances = instances
+	return buf, nil
+}
+
+func (f *Font) parseAvar(buf []byte) ([]byte, error) {
+	// https://www.microsoft.com/typography/otspec/avar.htm
+
+	if f.avar.length == 0 || len(f.cached.variationAxes) == 0 {
+		return buf, nil
+	}
+	const headerSize = 8
+	if f.avar.length < headerSize {
+		return nil, errInvalidAvarTable
+	}
+	buf, err := f.src.view(buf, int(f.avar.offset), int(f.avar.length))
+	if err != nil {
+		return nil, err
+	}
+	if int(u16(buf[6:])) != len(f.cached.variationAxes) {
+		return nil, errInvalidAvarTable
+	}
+	segments := make([][]avarMapping, len(f.cached.variationAxes))
+	b := buf[headerSize:]
+	for i := range segments {
+		if len(b) < 2 {
+			return nil, errInvalidAvarTable
+		}
+		n := int(u16(b))
+		b = b[2:]
+		if len(b) < 4*n {
+			return nil, errInvalidAvarTable
+		}
+		m := make([]avarMapping, n)
+		for j := range m {
+			m[j] = avarMapping{
+				from: f2Dot14(u16(b[4*j:])),
+				to:   f2Dot14(u16(b[4*j+2:])),
+			}
+			if j > 0 && m[j].from < m[j-1].from {
+				return nil, errInvalidAvarTable
+			}
+		}
+		b = b[4*n:]
+		segments[i] = m
+	}
+	f.cached.avarSegments = segments
+	return buf, nil
+}
+
+func (f *Font) parseGvar(buf []byte) ([]byte, error) {
+	// https://www.microsoft.com/typography/otspec/gvar.htm
+
+	if f.gvar.length == 0 || f.cached.isPostScript {
+		return buf, nil
+	}
+	const headerSize = 20
+	if f.gvar.length < headerSize {
+		return nil, errInvalidGvarTable
+	}
+	buf, err := f.src.view(buf, int(f.gvar.offset), headerSize)
+	if err != nil {
+		return nil, err
+	}
+	if major := u16(buf); major != 1 {
+		return nil, errUnsupportedGvarTable
+	}
+	axisCount := int32(u16(buf[4:]))
+	sharedTupleCount := uint32(u16(buf[6:]))
+	sharedTuplesOffset := u32(buf[8:])
+	glyphCount := int(u16(buf[12:]))
+	longOffsets := u16(buf[14:])&0x0001 != 0
+	dataOffset := u32(buf[16:])
+
+	if int(axisCount) != len(f.cached.variationAxes) || glyphCount != f.NumGlyphs() {
+		return nil, errInvalidGvarTable
+	}
+	offsetsLength := uint32(glyphCount+1) * 2
+	if longOffsets {
+		offsetsLength *= 2
+	}
+	if f.gvar.length-headerSize < offsetsLength || f.gvar.length < dataOffset {
+		return nil, errInvalidGvarTable
+	}
+
+	tuplesLength := 2 * uint32(axisCount) * sharedTupleCount
+	if f.gvar.length < sharedTuplesOffset || f.gvar.length-sharedTuplesOffset < tuplesLength {
+		return nil, errInvalidGvarTable
+	}
+	buf, err = f.src.view(buf, int(f.gvar.offset+sharedTuplesOffset), int(tuplesLength))
+	if err != nil {
+		return nil, err
+	}
+	sharedTuples := make([]float64, tuplesLength/2)
+	for i := range sharedTuples {
+		sharedTuples[i] = f2Dot14(u16(buf[2*i:]))
+	}
+
+	f.cached.gvar = gvarInfo{
+		axisCount:    axisCount,
+		longOffsets:  longOffsets,
+		offsetsBase:  f.gvar.offset + headerSize,
+		dataBase:     f.gvar.offset + dataOffset,
+		sharedTuples: sharedTuples,
+	}
+	return buf, nil
+}
+
+// viewGlyphVariationData returns the gvar table's GlyphVariationData for the
+// x'th glyph. It returns a nil slice if the glyph has no variations.
+func (f *Font) viewGlyphVariationData(b *Buffer, x GlyphIndex) ([]byte, error) {
+	g := &f.cached.gvar
+	var lo, hi uint32
+	if g.longOffsets {
+		buf, err := b.view(&f.src, int(g.offsetsBase)+4*int(x), 8)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi = u32(buf), u32(buf[4:])
+	} else {
+		buf, err := b.view(&f.src, int(g.offsetsBase)+2*int(x), 4)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi = 2*uint32(u16(buf)), 2*uint32(u16(buf[2:]))
+	}
+	if lo > hi || f.gvar.offset+f.gvar.length-g.dataBase < hi {
+		return nil, errInvalidGvarTable
+	}
+	if lo == hi {
+		return nil, nil
+	}
+	return b.view(&f.src, int(g.dataBase+lo), int(hi-lo))
+}
+
+// appendVariedGlyfSegments is like appendGlyfSegments, but applies the gvar
+// table's deltas, for f's normalized coordinates, to the glyph's points.
+func (f *Font) appendVariedGlyfSegments(b *Buffer, x GlyphIndex, data []byte) ([]Segment, error) {
+	points, ends, err := decodeGlyfPoints(b.points[:0], b.ends[:0], data)
+	if err != nil {
+		return nil, err
+	}
+	b.points, b.ends = points, ends
+	if len(points) == 0 {
+		// The glyph is empty.
+		return b.segments, nil
+	}
+
+	// The data slice may be invalidated by the b.view calls made when
+	// computing the deltas, but it is no longer needed.
+	deltas, err := f.glyphVariationDeltas(b, x, points, ends, len(points)+4)
+	if err != nil {
+		return nil, err
+	}
+	for i := range points {
+		d := deltas[i]
+		points[i].x = clampInt16(float64(points[i].x) + math.Floor(d.x+0.5))
+		points[i].y = clampInt16(float64(points[i].y) + math.Floor(d.y+0.5))
+	}
+	return appendGlyfPointSegments(b.segments, points, ends)
+}
+
+func clampInt16(x float64) int16 {
+	switch {
+	case x < math.MinInt16:
+		return math.MinInt16
+	case x > math.MaxInt16:
+		return math.MaxInt16
+	}
+	return int16(x)
+}
+
+// glyfAdvanceDelta returns the change in the x'th glyph's advance width, in
+// font units, from the gvar table's phantom point deltas.
+func (f *Font) glyfAdvanceDelta(b *Buffer, x GlyphIndex) (fixed.Int26_6, error) {
+	data, err := f.viewGlyphData(b, x)
+	if err != nil {
+		return 0, err
+	}
+	numPoints, err := glyfNumPoints(data)
+	if err != nil {
+		return 0, err
+	}
+	points, ends := b.points[:0], b.ends[:0]
+	if len(data) >= glyfHeaderLen && int16(u16(data)) > 0 {
+		points, ends, err = decodeGlyfPoints(points, ends, data)
+		if err != nil {
+			return 0, err
+		}
+	}
+	b.points, b.ends = points, ends
+	deltas, err := f.glyphVariationDeltas(b, x, points, ends, numPoints+4)
+	if err != nil {
+		return 0, err
+	}
+	// The first two phantom points are the glyph's left and right side
+	// bearing points.
+	d := deltas[numPoints+1].x - deltas[numPoints].x
+	return fixed.Int26_6(math.Floor(d + 0.5)), nil
+}
+
+// glyfNumPoints returns the number of points in the glyph data: the number of
+// outline points for a simple glyph and the number of components for a
+// compound glyph, as per the gvar specification's "Point numbers and
+// processing for composite glyphs".
+func glyfNumPoints(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	if len(data) < glyfHeaderLen {
+		return 0, errInvalidGlyphData
+	}
+	numContours := int16(u16(data))
+	if numContours >= 0 {
+		if numContours == 0 {
+			return 0, nil
+		}
+		i := glyfHeaderLen + 2*int(numContours)
+		if i > len(data) {
+			return 0, errInvalidGlyphData
+		}
+		return 1 + int(u16(data[i-2:])), nil
+	}
+	n := 0
+	for b := data[glyfHeaderLen:]; ; {
+		if len(b) < 4 {
+			return 0, errInvalidGlyphData
+		}
+		flags := u16(b)
+		size := 4 + 2
+		if flags&flagArg1And2AreWords != 0 {
+			size += 2
+		}
+		switch {
+		case flags&flagWeHaveAScale != 0:
+			size += 2
+		case flags&flagWeHaveAnXAndYScale != 0:
+			size += 4
+		case flags&flagWeHaveATwoByTwo != 0:
+			size += 8
+		}
+		if len(b) < size {
+			return 0, errInvalidGlyphData
+		}
+		b = b[size:]
+		n++
+		if flags&flagMoreComponents == 0 {
+			return n, nil
+		}
+	}
+}
+
+// glyphVariationDeltas returns the interpolated point deltas for the x'th
+// glyph, given its original points and contour ends. numPoints includes the
+// four phantom points, and may exceed len(points) (e.g. for compound glyphs),
+// in which case the excess points have no coordinates to interpolate from.
+func (f *Font) glyphVariationDeltas(b *Buffer, x GlyphIndex, points []glyfPoint, ends []int, numPoints int) ([]varDelta, error) {
+	if cap(b.deltas) < numPoints {
+		b.deltas = make([]varDelta, numPoints)
+	}
+	deltas := b.deltas[:numPoints]
+	for i := range deltas {
+		deltas[i] = varDelta{}
+	}
+
+	data, err := f.viewGlyphVariationData(b, x)
+	if err != nil || data == nil {
+		return deltas, err
+	}
+	if len(data) < 4 {
+		return nil, errInvalidGvarTable
+	}
+	tupleCount := u16(data)
+	serialized := int(u16(data[2:]))
+	if serialized > len(data) {
+		return nil, errInvalidGvarTable
+	}
+	headers, body := data[4:serialized], data[serialized:]
+
+	var sharedPoints []uint16
+	sharedAll := false
+	if tupleCount&0x8000 != 0 {
+		var n int
+		sharedPoints, sharedAll, n, err = unpackPointNumbers(b.varPoints[:0], body)
+		if err != nil {
+			return nil, err
+		}
+		b.varPoints = sharedPoints
+		body = body[n:]
+	}
+
+	axisCount := int(f.cached.gvar.axisCount)
+	coords := f.cached.normalizedCoords
+	var peak, start, end [maxVariationAxes]float64
+	for i, n := 0, int(tupleCount&0x0fff); i < n; i++ {
+		if len(headers) < 4 {
+			return nil, errInvalidGvarTable
+		}
+		size := int(u16(headers))
+		index := u16(headers[2:])
+		headers = headers[4:]
+		if size > len(body) {
+			return nil, errInvalidGvarTable
+		}
+		tuple := body[:size]
+		body = body[size:]
+
+		if index&0x8000 != 0 {
+			if len(headers) < 2*axisCount {
+				return nil, errInvalidGvarTable
+			}
+			for j := 0; j < axisCount; j++ {
+				peak[j] = f2Dot14(u16(headers[2*j:]))
+			}
+			headers = headers[2*axisCount:]
+		} else {
+			k := int(index&0x0fff) * axisCount
+			if k+axisCount > len(f.cached.gvar.sharedTuples) {
+				return nil, errInvalidGvarTable
+			}
+			copy(peak[:axisCount], f.cached.gvar.sharedTuples[k:])
+		}
+		intermediate := index&0x4000 != 0
+		if intermediate {
+			if len(headers) < 4*axisCount {
+				return nil, errInvalidGvarTable
+			}
+			for j := 0; j < axisCount; j++ {
+				start[j] = f2Dot14(u16(headers[2*j:]))
+				end[j] = f2Dot14(u16(headers[2*(axisCount+j):]))
+			}
+			headers = headers[4*axisCount:]
+		}
+
+		scalar := tupleScalar(coords, peak[:axisCount], start[:axisCount], end[:axisCount], intermediate)
+		if scalar == 0 {
+			continue
+		}
+
+		pts, all := sharedPoints, sharedAll
+		if index&0x2000 != 0 {
+			var n int
+			pts, all, n, err = unpackPointNumbers(b.varPoints[:0], tuple)
+			if err != nil {
+				return nil, err
+			}
+			b.varPoints = pts
+			tuple = tuple[n:]
+		}
+		count := len(pts)
+		if all {
+			count = numPoints
+		}
+		raw, err := unpackDeltas(b.varDeltas[:0], tuple, 2*count)
+		if err != nil {
+			return nil, err
+		}
+		b.varDeltas = raw
+		xs, ys := raw[:count], raw[count:]
+
+		if all {
+			for j := range deltas {
+				deltas[j].x += scalar * float64(xs[j])
+				deltas[j].y += scalar * float64(ys[j])
+			}
+			continue
+		}
+		if err := applySparseDeltas(b, deltas, points, ends, pts, xs, ys, scalar); err != nil {
+			return nil, err
+		}
+	}
+	return deltas, nil
+}
+
+// tupleScalar returns the scalar factor for a tuple variation, as per the
+// "Algorithm for calculating scalars" in the otvaroverview specification.
+func tupleScalar(coords, peak, start, end []float64, intermediate bool) float64 {
+	scalar := 1.0
+	for i, p := range peak {
+		if p == 0 {
+			continue
+		}
+		v := 0.0
+		if i < len(coords) {
+			v = coords[i]
+		}
+		if v == p {
+			continue
+		}
+		if intermediate {
+			s, e := start[i], end[i]
+			if s > p || p > e || (s < 0 && e > 0) {
+				continue
+			}
+			if v < s || v > e {
+				return 0
+			}
+			if v < p {
+				scalar *= (v - s) / (p - s)
+			} else {
+				scalar *= (e - v) / (e - p)
+			}
+			continue
+		}
+		if v == 0 || v < math.Min(0, p) || v > math.Max(0, p) {
+			return 0
+		}
+		scalar *= v / p
+	}
+	return scalar
+}
+
+// applySparseDeltas adds scalar times the explicit deltas (xs, ys) for the
+// given point numbers, and the inferred deltas for the remaining points, to
+// dst. The inferred deltas are interpolated as per the gvar specification's
+// "Inferred deltas for un-referenced point numbers".
+func applySparseDeltas(b *Buffer, dst []varDelta, points []glyfPoint, ends []int, pts []uint16, xs, ys []int32, scalar float64) error {
+	n := len(dst)
+	if cap(b.touched) < n {
+		b.touched = make([]bool, n)
+	}
+	touched := b.touched[:n]
+	for i := range touched {
+		touched[i] = false
+	}
+	if cap(b.tupleDeltas) < n {
+		b.tupleDeltas = make([]varDelta, n)
+	}
+	tuple := b.tupleDeltas[:n]
+	for i := range tuple {
+		tuple[i] = varDelta{}
+	}
+	for j, p := range pts {
+		if int(p) >= n {
+			return errInvalidGvarTable
+		}
+		tuple[p].x += float64(xs[j])
+		tuple[p].y += float64(ys[j])
+		touched[p] = true
+	}
+
+	start := 0
+	for _, end := range ends {
+		if end >= len(points) {
+			return errInvalidGlyphData
+		}
+		interpolateContour(tuple[start:end+1], touched[start:end+1], points[start:end+1])
+		start = end + 1
+	}
+
+	for i := range dst {
+		dst[i].x += scalar * tuple[i].x
+		dst[i].y += scalar * tuple[i].y
+	}
+	return nil
+}
+
+// interpolateContour infers the deltas of a contour's untouched points from
+// the deltas of the touched points either side.
+func interpolateContour(deltas []varDelta, touched []bool, points []glyfPoint) {
+	first := -1
+	for i, t := range touched {
+		if t {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return
+	}
+	n := len(points)
+	for i := first; ; {
+		// Find the next touched point j after i, cyclically.
+		j := (i + 1) % n
+		for !touched[j] {
+			j = (j + 1) % n
+		}
+		for k := (i + 1) % n; k != j; k = (k + 1) % n {
+			deltas[k].x = interpolateDelta(
+				float64(points[i].x), float64(points[j].x), deltas[i].x, deltas[j].x, float64(points[k].x))
+			deltas[k].y = interpolateDelta(
+				float64(points[i].y), float64(points[j].y), deltas[i].y, deltas[j].y, float64(points[k].y))
+		}
+		if j == first {
+			break
+		}
+		i = j
+	}
+}
+
+func interpolateDelta(c1, c2, d1, d2, c float64) float64 {
+	if c1 == c2 {
+		if d1 == d2 {
+			return d1
+		}
+		return 0
+	}
+	if c1 > c2 {
+		c1, c2 = c2, c1
+		d1, d2 = d2, d1
+	}
+	switch {
+	case c <= c1:
+		return d1
+	case c >= c2:
+		return d2
+	}
+	return d1 + (c-c1)*(d2-d1)/(c2-c1)
+}
+
+// unpackPointNumbers decodes packed point numbers, as per the gvar
+// specification's "Packed point numbers". all is whether the data means all
+// of the glyph's points, in which case the returned slice is empty.
+func unpackPointNumbers(dst []uint16, data []byte) (points []uint16, all bool, n int, err error) {
+	if len(data) < 1 {
+		return nil, false, 0, errInvalidGvarTable
+	}
+	count := int(data[0])
+	n = 1
+	if count&0x80 != 0 {
+		if len(data) < 2 {
+			return nil, false, 0, errInvalidGvarTable
+		}
+		count = int(u16(data)) & 0x7fff
+		n = 2
+	}
+	if count == 0 {
+		return dst, true, n, nil
+	}
+	p := uint16(0)
+	for len(dst) < count {
+		if n >= len(data) {
+			return nil, false, 0, errInvalidGvarTable
+		}
+		control := data[n]
+		n++
+		runCount := int(control&0x7f) + 1
+		words := control&0x80 != 0
+		for i := 0; i < runCount && len(dst) < count; i++ {
+			if words {
+				if n+2 > len(data) {
+					return nil, false, 0, errInvalidGvarTable
+				}
+				p += u16(data[n:])
+				n += 2
+			} else {
+				if n+1 > len(data) {
+					return nil, false, 0, errInvalidGvarTable
+				}
+				p += uint16(data[n])
+				n++
+			}
+			dst = append(dst, p)
+		}
+	}
+	return dst, false, n, nil
+}
+
+// unpackDeltas decodes count packed deltas, as per the gvar specification's
+// "Packed deltas".
+func unpackDeltas(dst []int32, data []byte, count int) ([]int32, error) {
+	n := 0
+	for len(dst) < count {
+		if n >= len(data) {
+			return nil, errInvalidGvarTable
+		}
+		control := data[n]
+		n++
+		runCount := int(control&0x3f) + 1
+		for i := 0; i < runCount && len(dst) < count; i++ {
+			switch {
+			case control&0x80 != 0:
+				dst = append(dst, 0)
+			case control&0x40 != 0:
+				if n+2 > len(data) {
+					return nil, errInvalidGvarTable
+				}
+				dst = append(dst, int32(int16(u16(data[n:]))))
+				n += 2
+			default:
+				if n+1 > len(data) {
+					return nil, errInvalidGvarTable
+				}
+				dst = append(dst, int32(int8(data[n])))
+				n++
+			}
+		}
+	}
+	return dst, nil
+}
@@ -0,0 +1,359 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestParseTag(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want Tag
+		ok   bool
+	}{
+		{"wght", 0x77676874, true},
+		{"OS/2", 0x4f532f32, true},
+		{"cvt", 0x63767420, true},
+		{"", 0, false},
+		{"toolong", 0, false},
+		{"a\x00bc", 0, false},
+	}
+	for _, tc := range testCases {
+		got, err := ParseTag(tc.s)
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("%q: ok: got %t, want %t", tc.s, ok, tc.ok)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q: got %#08x, want %#08x", tc.s, got, tc.want)
+		}
+		if tc.ok && len(tc.s) == 4 && got.String() != tc.s {
+			t.Errorf("%q: String: got %q", tc.s, got.String())
+		}
+	}
+}
+
+// testFvarTable is an fvar table with a single "wght" axis, ranging from 100
+// to 900 with a default of 400, and two named instances.
+var testFvarTable = []byte{
+	0x00, 0x01, 0x00, 0x00, // majorVersion, minorVersion
+	0x00, 0x10, 0x00, 0x02, // axesArrayOffset, reserved
+	0x00, 0x01, 0x00, 0x14, // axisCount, axisSize
+	0x00, 0x02, 0x00, 0x08, // instanceCount, instanceSize
+	'w', 'g', 'h', 't', // axisTag
+	0x00, 0x64, 0x00, 0x00, // minValue = 100
+	0x01, 0x90, 0x00, 0x00, // defaultValue = 400
+	0x03, 0x84, 0x00, 0x00, // maxValue = 900
+	0x00, 0x00, 0x01, 0x00, // flags, axisNameID
+	0x01, 0x01, 0x00, 0x00, // subfamilyNameID, flags
+	0x01, 0x2c, 0x00, 0x00, // coordinates = {300}
+	0x01, 0x02, 0x00, 0x00, // subfamilyNameID, flags
+	0x02, 0xbc, 0x00, 0x00, // coordinates = {700}
+}
+
+// testNameTable returns a name table with Windows platform, UCS-2 encoded,
+// English (United States) entries for the given names.
+//
+// Any extra entries are appended after those, with their raw values, and if
+// any extra entry has language tags, the table is a version 1 table with
+// those language-tag records.
+func testNameTable(names map[NameID]string, extra ...testNameEntries) []byte {
+	ids := make([]int, 0, len(names))
+	for id := range names {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	var e testNameEntries
+	for _, id := range ids {
+		e.records = append(e.records, NameRecord{
+			PlatformID: pidWindows,
+			EncodingID: psidWindowsUCS2,
+			LanguageID: 0x0409,
+			NameID:     NameID(id),
+		})
+		e.values = append(e.values, utf16BE(names[NameID(id)]))
+	}
+	for _, x := range extra {
+		e.records = append(e.records, x.records...)
+		e.values = append(e.values, x.values...)
+		e.langTags = append(e.langTags, x.langTags...)
+	}
+
+	version, stringOffset := uint16(0), 6+12*len(e.records)
+	if len(e.langTags) > 0 {
+		version, stringOffset = 1, stringOffset+2+4*len(e.langTags)
+	}
+	b := appendU16(nil, version)
+	b = appendU16(b, uint16(len(e.records)))
+	b = appendU16(b, uint16(stringOffset))
+	var strs []byte
+	for i, r := range e.records {
+		b = appendU16(b, r.PlatformID)
+		b = appendU16(b, r.EncodingID)
+		b = appendU16(b, r.LanguageID)
+		b = appendU16(b, uint16(r.NameID))
+		b = appendU16(b, uint16(len(e.values[i])))
+		b = appendU16(b, uint16(len(strs)))
+		strs = append(strs, e.values[i]...)
+	}
+	if version == 1 {
+		b = appendU16(b, uint16(len(e.langTags)))
+		for _, tag := range e.langTags {
+			v := utf16BE(tag)
+			b = appendU16(b, uint16(len(v)))
+			b = appendU16(b, uint16(len(strs)))
+			strs = append(strs, v...)
+		}
+	}
+	return append(b, strs...)
+}
+
+// testNameEntries are extra entries for testNameTable. Each record's value is
+// the raw, encoded string in values, and the record's own Value is ignored.
+type testNameEntries struct {
+	records  []NameRecord
+	values   [][]byte
+	langTags []string
+}
+
+// testGvarTable returns a gvar table, for glyfTest.ttf's 5 glyphs, that
+// varies the "one" glyph (glyph index 4). At the maximum weight, it moves the
+// left edge by 100 units, the right edge by 200 units and increases the
+// advance width by 50 units. At the minimum weight, it moves only points 0
+// and 2 (the left and right edge) explicitly, leaving points 1 and 3 to be
+// inferred.
+func testGvarTable() []byte {
+	glyph := []byte{
+		0x00, 0x02, 0x00, 0x10, // tupleVariationCount, dataOffset
+
+		// Tuple #0: peak at wght = +1.0, all points.
+		0x00, 0x13, 0xa0, 0x00, // variationDataSize, tupleIndex
+		0x40, 0x00, // peakTuple
+
+		// Tuple #1: peak at wght = -1.0, private point numbers.
+		0x00, 0x08, 0xa0, 0x00, // variationDataSize, tupleIndex
+		0xc0, 0x00, // peakTuple
+
+		// Tuple #0's serialized data.
+		0x00, // All points.
+		0x47, // 8 x deltas, as words.
+		0x00, 0x64, 0x00, 0x64, 0x00, 0xc8, 0x00, 0xc8,
+		0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00,
+		0x87, // 8 zero y deltas.
+
+		// Tuple #1's serialized data.
+		0x02, 0x01, 0x00, 0x02, // Point numbers 0 and 2.
+		0x01, 0xce, 0x9c, // 2 x deltas, -50 and -100, as bytes.
+		0x81, // 2 zero y deltas.
+
+		0x00, // Padding.
+	}
+
+	const numGlyphs, headerSize = 5, 20
+	offsetsSize := 2 * (numGlyphs + 1)
+	dataOffset := headerSize + offsetsSize
+	b := []byte{
+		0x00, 0x01, 0x00, 0x00, // majorVersion, minorVersion
+		0x00, 0x01, 0x00, 0x00, // axisCount, sharedTupleCount
+		0x00, 0x00, 0x00, byte(dataOffset), // sharedTuplesOffset
+		0x00, numGlyphs, 0x00, 0x00, // glyphCount, flags
+		0x00, 0x00, 0x00, byte(dataOffset), // glyphVariationDataArrayOffset
+	}
+	for i := 0; i <= numGlyphs; i++ {
+		o := 0
+		if i == numGlyphs {
+			o = len(glyph) / 2
+		}
+		b = append(b, byte(o>>8), byte(o))
+	}
+	return append(b, glyph...)
+}
+
+func TestVariations(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data = withTables(t, data, map[string][]byte{
+		"fvar": testFvarTable,
+		"gvar": testGvarTable(),
+	})
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	wantAxes := []VariationAxis{{
+		Tag:     MustParseTag("wght"),
+		Min:     100,
+		Default: 400,
+		Max:     900,
+		NameID:  256,
+	}}
+	if got := f.VariationAxes(); len(got) != 1 || got[0] != wantAxes[0] {
+		t.Fatalf("VariationAxes: got %v, want %v", got, wantAxes)
+	}
+
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	const x = 4
+	baseAdvance, err := f.GlyphAdvance(nil, x, ppem, font.HintingNone)
+	if err != nil {
+		t.Fatalf("GlyphAdvance: %v", err)
+	}
+
+	testCases := []struct {
+		wght         float64
+		left, right  fixed.Int26_6
+		advanceDelta fixed.Int26_6
+	}{
+		{400, 205, 614, 0},
+		{900, 305, 814, 50},
+		{650, 255, 714, 25},
+		{2000, 305, 814, 50},
+		{100, 155, 514, 0},
+		{250, 180, 564, 0},
+	}
+
+	var b Buffer
+	for _, tc := range testCases {
+		g := f.WithVariations(Variation{MustParseTag("wght"), tc.wght})
+		got, err := g.LoadGlyph(&b, x, ppem, nil)
+		if err != nil {
+			t.Errorf("wght=%v: LoadGlyph: %v", tc.wght, err)
+			continue
+		}
+		want := []Segment{
+			moveTo(tc.left, 0),
+			lineTo(tc.left, 1638),
+			lineTo(tc.right, 1638),
+			lineTo(tc.right, 0),
+			lineTo(tc.left, 0),
+		}
+		if err := checkSegmentsEqual(got, want); err != nil {
+			t.Errorf("wght=%v: %v", tc.wght, err)
+			continue
+		}
+
+		advance, err := g.GlyphAdvance(&b, x, ppem, font.HintingNone)
+		if err != nil {
+			t.Errorf("wght=%v: GlyphAdvance: %v", tc.wght, err)
+			continue
+		}
+		if got, want := advance-baseAdvance, tc.advanceDelta; got != want {
+			t.Errorf("wght=%v: advance delta: got %d, want %d", tc.wght, got, want)
+		}
+	}
+
+	// Other glyphs have no variation data, and should be unaffected.
+	g := f.WithVariations(Variation{MustParseTag("wght"), 900})
+	for i := 0; i < f.NumGlyphs(); i++ {
+		if i == x {
+			continue
+		}
+		want, err := f.LoadGlyph(&b, GlyphIndex(i), ppem, nil)
+		if err != nil {
+			t.Fatalf("i=%d: LoadGlyph: %v", i, err)
+		}
+		want = append([]Segment(nil), want...)
+		got, err := g.LoadGlyph(&b, GlyphIndex(i), ppem, nil)
+		if err != nil {
+			t.Fatalf("i=%d: LoadGlyph (varied): %v", i, err)
+		}
+		if err := checkSegmentsEqual(got, want); err != nil {
+			t.Errorf("i=%d: %v", i, err)
+		}
+	}
+}
+
+func TestNamedInstances(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data = withTables(t, data, map[string][]byte{
+		"fvar": testFvarTable,
+		"name": testNameTable(map[NameID]string{
+			NameIDFamily: "glyfTest",
+			256:          "Weight",
+			257:          "Light",
+		}),
+	})
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := f.NamedInstances(nil)
+	if err != nil {
+		t.Fatalf("NamedInstances: %v", err)
+	}
+	wght := MustParseTag("wght")
+	want := []NamedInstance{{
+		Name:             "Light",
+		SubfamilyNameID:  257,
+		PostScriptNameID: 0xffff,
+		Variations:       []Variation{{wght, 300}},
+	}, {
+		// There is no name table entry for 258.
+		Name:             "",
+		SubfamilyNameID:  258,
+		PostScriptNameID: 0xffff,
+		Variations:       []Variation{{wght, 700}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NamedInstances:\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestNoVariations(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := f.VariationAxes(); got != nil {
+		t.Errorf("VariationAxes: got %v, want nil", got)
+	}
+	if got, err := f.NamedInstances(nil); got != nil || err != nil {
+		t.Errorf("NamedInstances: got %v, %v, want nil, nil", got, err)
+	}
+	g := f.WithVariations(Variation{MustParseTag("wght"), 700})
+	if g.cached.normalizedCoords != nil {
+		t.Errorf("WithVariations: got non-nil normalized coordinates")
+	}
+}
+
+func TestAvarSegments(t *testing.T) {
+	m := []avarMapping{
+		{-1, -1},
+		{0, 0},
+		{0.5, 0.75},
+		{1, 1},
+	}
+	testCases := []struct {
+		c, want float64
+	}{
+		{-1, -1},
+		{-0.5, -0.5},
+		{0, 0},
+		{0.25, 0.375},
+		{0.5, 0.75},
+		{0.75, 0.875},
+		{1, 1},
+	}
+	for _, tc := range testCases {
+		if got := applyAvarSegments(m, tc.c); got != tc.want {
+			t.Errorf("c=%v: got %v, want %v", tc.c, got, tc.want)
+		}
+	}
+}
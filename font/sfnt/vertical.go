@@ -0,0 +1,159 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// This file implements the vhea (Vertical Header) and vmtx (Vertical Metrics)
+// tables, as described at
+// https://www.microsoft.com/typography/otspec/vhea.htm and
+// https://www.microsoft.com/typography/otspec/vmtx.htm
+
+// VerticalMetrics holds the metrics for laying out vertical text, such as
+// vertical CJK text, where lines of text run top to bottom.
+type VerticalMetrics struct {
+	// Ascent is the distance from the vertical baseline, the center line of
+	// a vertical line of text, to the line's right edge.
+	Ascent fixed.Int26_6
+
+	// Descent is the distance from the vertical baseline to the line's left
+	// edge. The value is typically positive.
+	Descent fixed.Int26_6
+
+	// LineGap is the recommended extra space between two vertical lines of
+	// text.
+	LineGap fixed.Int26_6
+}
+
+// vheaInfo holds the parsed vhea table.
+type vheaInfo struct {
+	ascent, descent, lineGap int16
+	numVMetrics              int32
+}
+
+func (f *Font) parseVhea(buf []byte) ([]byte, error) {
+	if f.vhea.length == 0 {
+		return buf, nil
+	}
+	if f.vhea.length != 36 {
+		return nil, errInvalidVheaTable
+	}
+	buf, err := f.src.view(buf, int(f.vhea.offset), 36)
+	if err != nil {
+		return nil, err
+	}
+	// Versions 1.0 and 1.1 have the same layout, although version 1.0 calls
+	// the line metrics ascent, descent and lineGap.
+	if v := u32(buf); v != 0x00010000 && v != 0x00011000 {
+		return nil, errUnsupportedVheaTable
+	}
+	v := vheaInfo{
+		ascent:      int16(u16(buf[4:])),
+		descent:     int16(u16(buf[6:])),
+		lineGap:     int16(u16(buf[8:])),
+		numVMetrics: int32(u16(buf[34:])),
+	}
+	// The vmtx table holds numVMetrics (advance height, top side bearing)
+	// pairs, followed by a top side bearing for each of the remaining glyphs.
+	n := f.NumGlyphs()
+	if v.numVMetrics == 0 || int(v.numVMetrics) > n ||
+		f.vmtx.length < 4*uint32(v.numVMetrics)+2*uint32(n-int(v.numVMetrics)) {
+		return nil, errInvalidVmtxTable
+	}
+	f.cached.vhea = v
+	return buf, nil
+}
+
+// VerticalMetrics returns the metrics for laying out vertical text, from the
+// vhea table. ppem is the number of pixels in 1 em.
+//
+// It returns ErrNotFound if the font has no vertical metrics.
+func (f *Font) VerticalMetrics(b *Buffer, ppem fixed.Int26_6, h font.Hinting) (VerticalMetrics, error) {
+	if err := f.skippedTable("vhea"); err != nil {
+		return VerticalMetrics{}, err
+	}
+	v := f.cached.vhea
+	if v.numVMetrics == 0 {
+		return VerticalMetrics{}, ErrNotFound
+	}
+	return VerticalMetrics{
+		Ascent:  f.scaleVertical(v.ascent, ppem, h),
+		Descent: -f.scaleVertical(v.descent, ppem, h),
+		LineGap: f.scaleVertical(v.lineGap, ppem, h),
+	}, nil
+}
+
+// GlyphVerticalAdvance returns the advance height for the x'th glyph, from the
+// vmtx table. ppem is the number of pixels in 1 em.
+//
+// It returns ErrNotFound if the glyph index is out of range or if the font
+// has no vertical metrics.
+func (f *Font) GlyphVerticalAdvance(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, h font.Hinting) (fixed.Int26_6, error) {
+	if err := f.skippedTable("vhea"); err != nil {
+		return 0, err
+	}
+	if int(x) >= f.NumGlyphs() || f.cached.vhea.numVMetrics == 0 {
+		return 0, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	// As for the hmtx table, the advance height of the last record applies to
+	// all remaining glyph IDs.
+	y := x
+	if n := GlyphIndex(f.cached.vhea.numVMetrics - 1); y > n {
+		y = n
+	}
+	buf, err := b.view(&f.src, int(f.vmtx.offset)+4*int(y), 2)
+	if err != nil {
+		return 0, err
+	}
+	adv := scale(fixed.Int26_6(u16(buf))*ppem, f.cached.unitsPerEm)
+	if h == font.HintingFull {
+		// Quantize the fixed.Int26_6 value to the nearest pixel.
+		adv = (adv + 32) &^ 63
+	}
+	return adv, nil
+}
+
+// GlyphTopSideBearing returns the top side bearing for the x'th glyph, from
+// the vmtx table: the distance from the glyph's vertical origin to the top of
+// its bounding box. ppem is the number of pixels in 1 em.
+//
+// It returns ErrNotFound if the glyph index is out of range or if the font
+// has no vertical metrics.
+func (f *Font) GlyphTopSideBearing(b *Buffer, x GlyphIndex, ppem fixed.Int26_6, h font.Hinting) (fixed.Int26_6, error) {
+	if err := f.skippedTable("vhea"); err != nil {
+		return 0, err
+	}
+	if int(x) >= f.NumGlyphs() || f.cached.vhea.numVMetrics == 0 {
+		return 0, ErrNotFound
+	}
+	if b == nil {
+		b = &Buffer{}
+	}
+	offset := 4*int(x) + 2
+	if n := f.cached.vhea.numVMetrics; int32(x) >= n {
+		offset = 4*int(n) + 2*(int(x)-int(n))
+	}
+	buf, err := b.view(&f.src, int(f.vmtx.offset)+offset, 2)
+	if err != nil {
+		return 0, err
+	}
+	return f.scaleVertical(int16(u16(buf)), ppem, h), nil
+}
+
+// scaleVertical converts v from font units to pixels, for the given ppem.
+func (f *Font) scaleVertical(v int16, ppem fixed.Int26_6, h font.Hinting) fixed.Int26_6 {
+	ret := scale(fixed.Int26_6(v)*ppem, f.cached.unitsPerEm)
+	if h == font.HintingFull {
+		// Quantize the fixed.Int26_6 value to the nearest pixel.
+		ret = (ret + 32) &^ 63
+	}
+	return ret
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestVerticalMetrics(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, err := f.VerticalMetrics(nil, fixed.I(10), font.HintingNone); err != ErrNotFound {
+		t.Fatalf("VerticalMetrics without a vhea table: got %v, want %v", err, ErrNotFound)
+	}
+
+	// glyfTest.ttf has 5 glyphs. The vmtx table has 3
+	// long metrics, followed by 2 top side bearings.
+	vhea := make([]byte, 36)
+	copy(vhea, be16(0x0001, 0x1000, 1024, -1024, 256))
+	copy(vhea[34:], be16(3))
+	vmtx := be16(
+		2048, 100,
+		1024, -200,
+		3072, 300,
+		400,
+		-500,
+	)
+	f, err = Parse(withTables(t, data, map[string][]byte{
+		"vhea": vhea,
+		"vmtx": vmtx,
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	gotMetrics, err := f.VerticalMetrics(nil, ppem, font.HintingNone)
+	if err != nil {
+		t.Fatalf("VerticalMetrics: %v", err)
+	}
+	wantMetrics := VerticalMetrics{Ascent: 1024, Descent: 1024, LineGap: 256}
+	if gotMetrics != wantMetrics {
+		t.Errorf("VerticalMetrics: got %+v, want %+v", gotMetrics, wantMetrics)
+	}
+
+	wantAdvances := []fixed.Int26_6{2048, 1024, 3072, 3072, 3072}
+	wantBearings := []fixed.Int26_6{100, -200, 300, 400, -500}
+	for i := range wantAdvances {
+		x := GlyphIndex(i)
+		if got, err := f.GlyphVerticalAdvance(nil, x, ppem, font.HintingNone); err != nil {
+			t.Errorf("GlyphVerticalAdvance(%d): %v", x, err)
+		} else if got != wantAdvances[i] {
+			t.Errorf("GlyphVerticalAdvance(%d): got %v, want %v", x, got, wantAdvances[i])
+		}
+		if got, err := f.GlyphTopSideBearing(nil, x, ppem, font.HintingNone); err != nil {
+			t.Errorf("GlyphTopSideBearing(%d): %v", x, err)
+		} else if got != wantBearings[i] {
+			t.Errorf("GlyphTopSideBearing(%d): got %v, want %v", x, got, wantBearings[i])
+		}
+	}
+	if _, err := f.GlyphVerticalAdvance(nil, 5, ppem, font.HintingNone); err != ErrNotFound {
+		t.Errorf("GlyphVerticalAdvance(5): got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestVerticalGlyph(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.FromSlash("../testdata/glyfTest.ttf"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	// vert maps glyph 1 to 2, and vrt2 maps glyph 1 to 3.
+	vert := concat(
+		be16(2, 8, 1, 2), // substFormat, coverage, substituteGlyphIDs.
+		be16(1, 1, 1),    // coverage.
+	)
+	vrt2 := concat(
+		be16(2, 8, 1, 3), // substFormat, coverage, substituteGlyphIDs.
+		be16(1, 1, 1),    // coverage.
+	)
+	scripts := []testScript{{"hani", map[string][]int{"dflt": {0, 1}}}}
+
+	testCases := []struct {
+		desc string
+		gsub []byte
+		want []GlyphIndex
+	}{{
+		desc: "no GSUB",
+		want: []GlyphIndex{0, 1, 2, 3, 4},
+	}, {
+		desc: "vert",
+		gsub: testLayoutTable(scripts, []testFeature{
+			{"vert", []int{0}},
+		}, []testLookup{
+			{gsubLookupTypeSingle, vert},
+		}),
+		want: []GlyphIndex{0, 2, 2, 3, 4},
+	}, {
+		desc: "vert and vrt2",
+		gsub: testLayoutTable(scripts, []testFeature{
+			{"vert", []int{0}},
+			{"vrt2", []int{1}},
+		}, []testLookup{
+			{gsubLookupTypeSingle, vert},
+			{gsubLookupTypeSingle, vrt2},
+		}),
+		want: []GlyphIndex{0, 3, 2, 3, 4},
+	}}
+	for _, tc := range testCases {
+		src := data
+		if tc.gsub != nil {
+			src = withTables(t, data, map[string][]byte{"GSUB": tc.gsub})
+		}
+		f, err := Parse(src)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", tc.desc, err)
+			continue
+		}
+		for i, want := range tc.want {
+			x := GlyphIndex(i)
+			got, err := f.VerticalGlyph(nil, x)
+			if err != nil {
+				t.Errorf("%s: VerticalGlyph(%d): %v", tc.desc, x, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%s: VerticalGlyph(%d): got %d, want %d", tc.desc, x, got, want)
+			}
+		}
+	}
+}
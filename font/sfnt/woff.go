@@ -0,0 +1,86 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+)
+
+// This file implements decoding WOFF (Web Open Font Format) 1.0 font data, as
+// described at https://www.w3.org/TR/WOFF/
+
+// woffSignature is the "wOFF" signature that starts WOFF 1.0 font data.
+const woffSignature = 0x774f4646
+
+// decodeWOFF returns the SFNT font data that the WOFF 1.0 font data in src
+// wraps. WOFF's extended metadata and private data blocks are ignored.
+func decodeWOFF(src *source) ([]byte, error) {
+	const headerSize, entrySize = 44, 20
+	buf, err := src.view(nil, 0, headerSize)
+	if err != nil {
+		return nil, errInvalidWOFF
+	}
+	flavor := u32(buf[4:])
+	length := u32(buf[8:])
+	numTables := int(u16(buf[12:]))
+	if numTables == 0 || numTables > maxNumTables {
+		return nil, errUnsupportedNumberOfTables
+	}
+	if u16(buf[14:]) != 0 {
+		return nil, errInvalidWOFF
+	}
+	buf, err = src.view(nil, headerSize, entrySize*numTables)
+	if err != nil {
+		return nil, errInvalidWOFF
+	}
+	// Copy the table directory, as later views may re-use its memory.
+	dir := append([]byte(nil), buf...)
+
+	tables := make([]taggedTable, numTables)
+	totalLength := uint32(0)
+	for i := range tables {
+		e := dir[entrySize*i:]
+		offset, compLength, origLength := u32(e[4:]), u32(e[8:]), u32(e[12:])
+		if offset > length || compLength > length-offset || compLength > origLength {
+			return nil, errInvalidWOFF
+		}
+		if origLength > maxTableLength || totalLength > maxTableOffset-origLength {
+			return nil, errUnsupportedTableOffsetLength
+		}
+		totalLength += origLength
+
+		data, err := src.view(nil, int(offset), int(compLength))
+		if err != nil {
+			return nil, errInvalidWOFF
+		}
+		if compLength == origLength {
+			data = append([]byte(nil), data...)
+		} else if data, err = woffDecompress(data, origLength); err != nil {
+			return nil, err
+		}
+		tables[i] = taggedTable{Tag(u32(e)), data}
+	}
+	return writeSFNT(flavor, tables), nil
+}
+
+// woffDecompress returns the zlib decompression of data, which must be exactly
+// n bytes long.
+func woffDecompress(data []byte, n uint32) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errInvalidWOFF
+	}
+	dst := make([]byte, n)
+	if _, err := io.ReadFull(r, dst); err != nil {
+		return nil, errInvalidWOFF
+	}
+	// Check that there is no more data.
+	if m, err := r.Read(make([]byte, 1)); m != 0 || err != io.EOF {
+		return nil, errInvalidWOFF
+	}
+	return dst, nil
+}
@@ -0,0 +1,688 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"golang.org/x/image/font/sfnt/internal/brotli"
+)
+
+// This file implements decoding WOFF (Web Open Font Format) 2.0 font data, as
+// described at https://www.w3.org/TR/WOFF2/
+
+// woff2Signature is the "wOF2" signature that starts WOFF 2.0 font data.
+const woff2Signature = 0x774f4632
+
+// woff2KnownTags are the tags that a WOFF 2.0 table directory entry can refer
+// to by index, 4 bytes per tag.
+const woff2KnownTags = "" +
+	"cmapheadhheahmtxmaxpnameOS/2postcvt fpgmglyflocaprepCFF VORGEBDT" +
+	"EBLCgasphdmxkernLTSHPCLTVDMXvheavmtxBASEGDEFGPOSGSUBEBSCJSTFMATH" +
+	"CBDTCBLCCOLRCPALSVG sbixacntavarbdatblocbslncvarfdscfeatfmtxfvar" +
+	"gvarhstyjustlcarmortmorxopbdproptrakZapfSilfGlatGlocFeatSill"
+
+var (
+	tagGlyf = MustParseTag("glyf")
+	tagHhea = MustParseTag("hhea")
+	tagHmtx = MustParseTag("hmtx")
+	tagLoca = MustParseTag("loca")
+)
+
+// woff2Table is a WOFF 2.0 table directory entry, and the table's data in the
+// decompressed stream.
+type woff2Table struct {
+	tag         Tag
+	origLength  uint32
+	transformed bool
+	data        []byte
+
+	// reconstructed is whether a transformed table's transform has been
+	// reversed. For a glyf table, xMins holds each glyph's xMin.
+	reconstructed bool
+	xMins         []int16
+}
+
+// decodeWOFF2 returns the SFNT font data that the WOFF 2.0 font data in src
+// wraps. If the WOFF 2.0 font data is a collection, the SFNT font data is too.
+// WOFF 2.0's extended metadata and private data blocks are ignored.
+func decodeWOFF2(src *source) ([]byte, error) {
+	const headerSize = 48
+	buf, err := src.view(nil, 0, headerSize)
+	if err != nil {
+		return nil, errInvalidWOFF2
+	}
+	flavor := u32(buf[4:])
+	length := u32(buf[8:])
+	numTables := int(u16(buf[12:]))
+	compLength := u32(buf[20:])
+	isCollection := flavor == 0x74746366 // "ttcf".
+	if numTables == 0 || numTables > maxNumTables {
+		return nil, errUnsupportedNumberOfTables
+	}
+	if u16(buf[14:]) != 0 || length < headerSize {
+		return nil, errInvalidWOFF2
+	}
+
+	// The table directory's entries have variable length, of at most 15
+	// bytes: a flags byte, an optional tag and two UIntBase128 values. A
+	// collection directory, of unknown length, may follow.
+	dirLength := 15 * numTables
+	if n := int(length - headerSize); dirLength > n || isCollection {
+		dirLength = n
+	}
+	buf, err = src.view(nil, headerSize, dirLength)
+	if err != nil {
+		return nil, errInvalidWOFF2
+	}
+	r := woff2Reader{b: buf}
+	tables := make([]woff2Table, numTables)
+	totalLength := uint32(0)
+	for i := range tables {
+		t := &tables[i]
+		flags := r.u8()
+		if j := int(flags & 0x3f); j == 0x3f {
+			t.tag = Tag(r.u32())
+		} else {
+			t.tag = Tag(u32([]byte(woff2KnownTags[4*j:])))
+		}
+		t.origLength = r.uintBase128()
+		// For the glyf and loca tables, transform version 0 means the
+		// transformed format and version 3 means the null transform. For other
+		// tables, version 0 means the null transform.
+		version := flags >> 6
+		if t.tag == tagGlyf || t.tag == tagLoca {
+			t.transformed = version == 0
+			if version != 0 && version != 3 {
+				return nil, errUnsupportedWOFF2
+			}
+		} else {
+			t.transformed = version != 0
+			if t.transformed && (t.tag != tagHmtx || version != 1) {
+				return nil, errUnsupportedWOFF2
+			}
+		}
+		n := t.origLength
+		if t.transformed {
+			n = r.uintBase128()
+		}
+		if r.err {
+			return nil, errInvalidWOFF2
+		}
+		if t.origLength > maxTableLength || n > maxTableLength || totalLength > maxTableOffset-n {
+			return nil, errUnsupportedTableOffsetLength
+		}
+		totalLength += n
+		t.data = make([]byte, n)
+	}
+
+	// Each font lists the indexes of its tables. A single font uses all of
+	// the tables.
+	var fonts []collectionFont
+	if isCollection {
+		if fonts, err = woff2CollectionDirectory(&r, numTables); err != nil {
+			return nil, err
+		}
+	} else {
+		indexes := make([]int, numTables)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		fonts = []collectionFont{{flavor, indexes}}
+	}
+
+	// All of the tables are compressed as one Brotli stream, which follows
+	// the table directory.
+	offset := headerSize + dirLength - len(r.b)
+	if uint32(offset) > length || compLength > length-uint32(offset) {
+		return nil, errInvalidWOFF2
+	}
+	buf, err = src.view(nil, offset, int(compLength))
+	if err != nil {
+		return nil, errInvalidWOFF2
+	}
+	data, err := brotli.Decode(buf, int(totalLength))
+	if err != nil || len(data) != int(totalLength) {
+		return nil, errInvalidWOFF2
+	}
+	for i := range tables {
+		t := &tables[i]
+		t.data, data = data[:len(t.data)], data[len(t.data):]
+	}
+
+	for _, font := range fonts {
+		if err := woff2ReverseTransforms(tables, font.tables); err != nil {
+			return nil, err
+		}
+	}
+
+	sfntTables := make([]taggedTable, len(tables))
+	for i, t := range tables {
+		// A reconstructed glyf table can differ in length from the original,
+		// as a glyph's flags and coordinates can be encoded in several ways.
+		if uint32(len(t.data)) != t.origLength && !(t.tag == tagGlyf && t.transformed) {
+			return nil, errInvalidWOFF2
+		}
+		sfntTables[i] = taggedTable{t.tag, t.data}
+	}
+	if isCollection {
+		return writeCollection(sfntTables, fonts), nil
+	}
+	return writeSFNT(flavor, sfntTables), nil
+}
+
+// woff2CollectionDirectory returns the fonts listed by the WOFF 2.0
+// collection directory that r holds. Each font's tables are indexes into the
+// numTables tables of the table directory.
+func woff2CollectionDirectory(r *woff2Reader, numTables int) ([]collectionFont, error) {
+	if version := r.u32(); version != 0x00010000 && version != 0x00020000 {
+		return nil, errInvalidWOFF2
+	}
+	numFonts := int(r.u255UInt16())
+	if r.err {
+		return nil, errInvalidWOFF2
+	}
+	if numFonts == 0 || numFonts > maxNumFonts {
+		return nil, errUnsupportedNumberOfFonts
+	}
+	fonts := make([]collectionFont, numFonts)
+	for i := range fonts {
+		n := int(r.u255UInt16())
+		fonts[i].version = r.u32()
+		if r.err || n == 0 || n > numTables {
+			return nil, errInvalidWOFF2
+		}
+		fonts[i].tables = make([]int, n)
+		for j := range fonts[i].tables {
+			k := int(r.u255UInt16())
+			if r.err || k >= numTables {
+				return nil, errInvalidWOFF2
+			}
+			fonts[i].tables[j] = k
+		}
+	}
+	return fonts, nil
+}
+
+// woff2ReverseTransforms reverses the transforms of one font's tables, given
+// as indexes into tables. Tables shared with a previous font are only
+// reconstructed once. The hmtx transform depends on the glyf table, whose
+// transform produces the loca table too.
+func woff2ReverseTransforms(tables []woff2Table, indexes []int) error {
+	var glyf, loca, hhea, hmtx *woff2Table
+	for _, i := range indexes {
+		switch t := &tables[i]; t.tag {
+		case tagGlyf:
+			glyf = t
+		case tagLoca:
+			loca = t
+		case tagHhea:
+			hhea = t
+		case tagHmtx:
+			hmtx = t
+		}
+	}
+	if glyf != nil && glyf.transformed {
+		if loca == nil || !loca.transformed {
+			return errInvalidWOFF2
+		}
+		if !glyf.reconstructed {
+			if len(loca.data) != 0 || loca.reconstructed {
+				return errInvalidWOFF2
+			}
+			g, l, x, err := woff2ReconstructGlyf(glyf.data)
+			if err != nil {
+				return err
+			}
+			if uint32(len(l)) != loca.origLength {
+				return errInvalidWOFF2
+			}
+			glyf.data, loca.data, glyf.xMins = g, l, x
+			glyf.reconstructed, loca.reconstructed = true, true
+		}
+	} else if loca != nil && loca.transformed {
+		return errInvalidWOFF2
+	}
+	if hmtx != nil && hmtx.transformed && !hmtx.reconstructed {
+		if glyf == nil || glyf.xMins == nil || hhea == nil || len(hhea.data) < 36 {
+			return errInvalidWOFF2
+		}
+		numHMetrics := int(u16(hhea.data[34:]))
+		h, err := woff2ReconstructHmtx(hmtx.data, numHMetrics, glyf.xMins)
+		if err != nil {
+			return err
+		}
+		if uint32(len(h)) != hmtx.origLength {
+			return errInvalidWOFF2
+		}
+		hmtx.data, hmtx.reconstructed = h, true
+	}
+	return nil
+}
+
+// woff2ReconstructGlyf returns the glyf and loca tables, and each glyph's
+// xMin, for the transformed glyf table data.
+func woff2ReconstructGlyf(data []byte) (glyf, loca []byte, xMins []int16, err error) {
+	const headerSize = 36
+	if len(data) < headerSize {
+		return nil, nil, nil, errInvalidWOFF2
+	}
+	optionFlags := u16(data[2:])
+	numGlyphs := int(u16(data[4:]))
+	indexFormat := u16(data[6:])
+	if indexFormat > 1 {
+		return nil, nil, nil, errInvalidWOFF2
+	}
+	// The header is followed by seven streams: the number of contours, the
+	// number of points, the point flags, the glyph data, the composite glyph
+	// data, the bounding boxes and the instructions.
+	var streams [7]woff2Reader
+	rest := woff2Reader{b: data[headerSize:]}
+	for i := range streams {
+		streams[i].b = rest.bytes(int(u32(data[8+4*i:])))
+	}
+	if rest.err {
+		return nil, nil, nil, errInvalidWOFF2
+	}
+	nContours, nPoints, flags, glyphs, composites, bboxes, instructions :=
+		&streams[0], &streams[1], &streams[2], &streams[3], &streams[4], &streams[5], &streams[6]
+	bboxBitmap := bboxes.bytes(4 * ((numGlyphs + 31) / 32))
+	var overlapBitmap []byte
+	if optionFlags&1 != 0 {
+		overlapBitmap = rest.bytes((numGlyphs + 7) / 8)
+	}
+	if bboxes.err || rest.err {
+		return nil, nil, nil, errInvalidWOFF2
+	}
+
+	locaEntrySize := 2 << indexFormat
+	loca = make([]byte, 0, locaEntrySize*(numGlyphs+1))
+	xMins = make([]int16, numGlyphs)
+	var endPts []uint16
+	var points []woff2Point
+	for i := 0; i < numGlyphs; i++ {
+		if indexFormat == 0 {
+			loca = appendU16(loca, uint16(len(glyf)/2))
+		} else {
+			loca = appendU32(loca, uint32(len(glyf)))
+		}
+		hasBBox := bboxBitmap[i/8]&(0x80>>uint(i%8)) != 0
+		var bbox [8]byte
+		if hasBBox {
+			copy(bbox[:], bboxes.bytes(8))
+		}
+
+		n := int16(nContours.u16())
+		switch {
+		case n == 0:
+			// An empty glyph.
+			if hasBBox {
+				return nil, nil, nil, errInvalidWOFF2
+			}
+
+		case n < 0:
+			// A compound glyph, which must have an explicit bounding box.
+			if n != -1 || !hasBBox {
+				return nil, nil, nil, errInvalidWOFF2
+			}
+			start, haveInstructions := composites.b, false
+			for more := true; more && !composites.err; {
+				flags := composites.u16()
+				haveInstructions = haveInstructions || flags&flagWeHaveInstructions != 0
+				more = flags&flagMoreComponents != 0
+				size := 4
+				if flags&flagArg1And2AreWords != 0 {
+					size += 2
+				}
+				switch {
+				case flags&flagWeHaveAScale != 0:
+					size += 2
+				case flags&flagWeHaveAnXAndYScale != 0:
+					size += 4
+				case flags&flagWeHaveATwoByTwo != 0:
+					size += 8
+				}
+				composites.bytes(size)
+			}
+			glyf = appendU16(glyf, 0xffff)
+			glyf = append(glyf, bbox[:]...)
+			glyf = append(glyf, start[:len(start)-len(composites.b)]...)
+			if haveInstructions {
+				m := glyphs.u255UInt16()
+				glyf = appendU16(glyf, m)
+				glyf = append(glyf, instructions.bytes(int(m))...)
+			}
+
+		default:
+			// A simple glyph.
+			endPts, points = endPts[:0], points[:0]
+			numPoints := 0
+			for j := int16(0); j < n; j++ {
+				numPoints += int(nPoints.u255UInt16())
+				if numPoints > 0xffff || numPoints == 0 {
+					return nil, nil, nil, errInvalidWOFF2
+				}
+				endPts = append(endPts, uint16(numPoints-1))
+			}
+			if nPoints.err {
+				return nil, nil, nil, errInvalidWOFF2
+			}
+			x, y := int32(0), int32(0)
+			xMin, yMin, xMax, yMax := int32(0), int32(0), int32(0), int32(0)
+			for j := 0; j < numPoints; j++ {
+				p, ok := glyphs.triplet(flags.u8())
+				if !ok || flags.err {
+					return nil, nil, nil, errInvalidWOFF2
+				}
+				points = append(points, p)
+				x, y = x+p.dx, y+p.dy
+				if j == 0 || x < xMin {
+					xMin = x
+				}
+				if j == 0 || x > xMax {
+					xMax = x
+				}
+				if j == 0 || y < yMin {
+					yMin = y
+				}
+				if j == 0 || y > yMax {
+					yMax = y
+				}
+			}
+			if !hasBBox {
+				putU16(bbox[0:], uint16(xMin))
+				putU16(bbox[2:], uint16(yMin))
+				putU16(bbox[4:], uint16(xMax))
+				putU16(bbox[6:], uint16(yMax))
+			}
+			m := glyphs.u255UInt16()
+			overlap := overlapBitmap != nil && overlapBitmap[i/8]&(0x80>>uint(i%8)) != 0
+
+			glyf = appendU16(glyf, uint16(n))
+			glyf = append(glyf, bbox[:]...)
+			for _, e := range endPts {
+				glyf = appendU16(glyf, e)
+			}
+			glyf = appendU16(glyf, m)
+			glyf = append(glyf, instructions.bytes(int(m))...)
+			glyf = woff2AppendPoints(glyf, points, overlap)
+		}
+
+		for _, s := range streams {
+			if s.err {
+				return nil, nil, nil, errInvalidWOFF2
+			}
+		}
+		if len(glyf) > maxTableLength {
+			return nil, nil, nil, errUnsupportedTableOffsetLength
+		}
+		xMins[i] = int16(u16(bbox[:]))
+		for len(glyf)&3 != 0 {
+			glyf = append(glyf, 0)
+		}
+	}
+	if indexFormat == 0 {
+		if len(glyf) > 2*0xffff {
+			return nil, nil, nil, errInvalidWOFF2
+		}
+		loca = appendU16(loca, uint16(len(glyf)/2))
+	} else {
+		loca = appendU32(loca, uint32(len(glyf)))
+	}
+	return glyf, loca, xMins, nil
+}
+
+// woff2Point is a simple glyph's point, as a delta from the previous point.
+type woff2Point struct {
+	dx, dy  int32
+	onCurve bool
+}
+
+// woff2AppendPoints appends the flags and coordinates of a simple glyph's
+// points to dst, in the glyf table's format.
+func woff2AppendPoints(dst []byte, points []woff2Point, overlap bool) []byte {
+	lastFlag, lastFlagIndex, repeat := -1, 0, 0
+	for i, p := range points {
+		f := 0
+		if p.onCurve {
+			f |= flagOnCurve
+		}
+		if i == 0 && overlap {
+			f |= flagOverlapSimple
+		}
+		switch {
+		case p.dx == 0:
+			f |= flagThisXIsSame
+		case -0xff <= p.dx && p.dx <= 0xff:
+			f |= flagXShortVector
+			if p.dx > 0 {
+				f |= flagPositiveXShortVector
+			}
+		}
+		switch {
+		case p.dy == 0:
+			f |= flagThisYIsSame
+		case -0xff <= p.dy && p.dy <= 0xff:
+			f |= flagYShortVector
+			if p.dy > 0 {
+				f |= flagPositiveYShortVector
+			}
+		}
+		if f == lastFlag && repeat < 0xff {
+			dst[lastFlagIndex] |= flagRepeat
+			repeat++
+			continue
+		}
+		if repeat != 0 {
+			dst = append(dst, uint8(repeat))
+			repeat = 0
+		}
+		lastFlag, lastFlagIndex = f, len(dst)
+		dst = append(dst, uint8(f))
+	}
+	if repeat != 0 {
+		dst = append(dst, uint8(repeat))
+	}
+	for _, p := range points {
+		if p.dx < -0xff || 0xff < p.dx {
+			dst = appendU16(dst, uint16(p.dx))
+		} else if p.dx < 0 {
+			dst = append(dst, uint8(-p.dx))
+		} else if p.dx > 0 {
+			dst = append(dst, uint8(p.dx))
+		}
+	}
+	for _, p := range points {
+		if p.dy < -0xff || 0xff < p.dy {
+			dst = appendU16(dst, uint16(p.dy))
+		} else if p.dy < 0 {
+			dst = append(dst, uint8(-p.dy))
+		} else if p.dy > 0 {
+			dst = append(dst, uint8(p.dy))
+		}
+	}
+	return dst
+}
+
+// woff2ReconstructHmtx returns the hmtx table for the transformed hmtx table
+// data. Omitted left side bearings equal the glyphs' xMin values.
+func woff2ReconstructHmtx(data []byte, numHMetrics int, xMins []int16) ([]byte, error) {
+	numGlyphs := len(xMins)
+	if numHMetrics == 0 || numHMetrics > numGlyphs {
+		return nil, errInvalidWOFF2
+	}
+	r := woff2Reader{b: data}
+	flags := r.u8()
+	// Bit 0 means that the proportional glyphs' lsb values are omitted, and
+	// bit 1 means that the monospaced glyphs' lsb values are omitted. At
+	// least one must be set, and the other bits are reserved.
+	if flags&^3 != 0 || flags&3 == 0 {
+		return nil, errInvalidWOFF2
+	}
+	advances := r.bytes(2 * numHMetrics)
+	var lsbs, monoLSBs []byte
+	if flags&1 == 0 {
+		lsbs = r.bytes(2 * numHMetrics)
+	}
+	if flags&2 == 0 {
+		monoLSBs = r.bytes(2 * (numGlyphs - numHMetrics))
+	}
+	if r.err || len(r.b) != 0 {
+		return nil, errInvalidWOFF2
+	}
+
+	dst := make([]byte, 0, 4*numHMetrics+2*(numGlyphs-numHMetrics))
+	for i := 0; i < numHMetrics; i++ {
+		dst = append(dst, advances[2*i:2*i+2]...)
+		if lsbs != nil {
+			dst = append(dst, lsbs[2*i:2*i+2]...)
+		} else {
+			dst = appendU16(dst, uint16(xMins[i]))
+		}
+	}
+	for i := numHMetrics; i < numGlyphs; i++ {
+		if monoLSBs != nil {
+			j := 2 * (i - numHMetrics)
+			dst = append(dst, monoLSBs[j:j+2]...)
+		} else {
+			dst = appendU16(dst, uint16(xMins[i]))
+		}
+	}
+	return dst, nil
+}
+
+// woff2Reader reads WOFF 2.0 data types from a byte slice. Reading past the
+// end of the slice sets err and returns zero values.
+type woff2Reader struct {
+	b   []byte
+	err bool
+}
+
+func (r *woff2Reader) bytes(n int) []byte {
+	if n < 0 || n > len(r.b) {
+		r.err = true
+		return nil
+	}
+	ret := r.b[:n]
+	r.b = r.b[n:]
+	return ret
+}
+
+func (r *woff2Reader) u8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *woff2Reader) u16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return u16(b)
+	}
+	return 0
+}
+
+func (r *woff2Reader) u32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return u32(b)
+	}
+	return 0
+}
+
+// uintBase128 reads a UIntBase128 value: a big-endian base 128 number of at
+// most 5 bytes, with no leading zeroes, that fits in a uint32.
+func (r *woff2Reader) uintBase128() uint32 {
+	v := uint32(0)
+	for i := 0; i < 5; i++ {
+		b := r.u8()
+		if r.err || (i == 0 && b == 0x80) || v&0xfe000000 != 0 {
+			r.err = true
+			return 0
+		}
+		v = v<<7 | uint32(b&0x7f)
+		if b&0x80 == 0 {
+			return v
+		}
+	}
+	r.err = true
+	return 0
+}
+
+// u255UInt16 reads a 255UInt16 value: a variable length encoding of a uint16.
+func (r *woff2Reader) u255UInt16() uint16 {
+	const (
+		oneMoreByteCode2 = 254
+		oneMoreByteCode1 = 255
+		wordCode         = 253
+		lowestUCode      = 253
+	)
+	switch b := r.u8(); b {
+	case wordCode:
+		return r.u16()
+	case oneMoreByteCode1:
+		return uint16(r.u8()) + lowestUCode
+	case oneMoreByteCode2:
+		return uint16(r.u8()) + 2*lowestUCode
+	default:
+		return uint16(b)
+	}
+}
+
+// triplet reads a point's coordinates from the glyph stream, encoded as per
+// the given flag from the flag stream. It returns false if the encoding is
+// invalid.
+func (r *woff2Reader) triplet(flag uint8) (p woff2Point, ok bool) {
+	p.onCurve = flag&0x80 == 0
+	flag &= 0x7f
+	withSign := func(flag uint8, v int32) int32 {
+		if flag&1 != 0 {
+			return v
+		}
+		return -v
+	}
+	switch {
+	case flag < 10:
+		b := r.bytes(1)
+		if b == nil {
+			return p, false
+		}
+		p.dy = withSign(flag, int32(flag&14)<<7+int32(b[0]))
+	case flag < 20:
+		b := r.bytes(1)
+		if b == nil {
+			return p, false
+		}
+		p.dx = withSign(flag, int32((flag-10)&14)<<7+int32(b[0]))
+	case flag < 84:
+		b := r.bytes(1)
+		if b == nil {
+			return p, false
+		}
+		b0 := int32(flag - 20)
+		p.dx = withSign(flag, 1+b0&0x30+int32(b[0]>>4))
+		p.dy = withSign(flag>>1, 1+(b0&0x0c)<<2+int32(b[0]&0x0f))
+	case flag < 120:
+		b := r.bytes(2)
+		if b == nil {
+			return p, false
+		}
+		b0 := int32(flag - 84)
+		p.dx = withSign(flag, 1+(b0/12)<<8+int32(b[0]))
+		p.dy = withSign(flag>>1, 1+((b0%12)>>2)<<8+int32(b[1]))
+	case flag < 124:
+		b := r.bytes(3)
+		if b == nil {
+			return p, false
+		}
+		p.dx = withSign(flag, int32(b[0])<<4+int32(b[1]>>4))
+		p.dy = withSign(flag>>1, int32(b[1]&0x0f)<<8+int32(b[2]))
+	default:
+		b := r.bytes(4)
+		if b == nil {
+			return p, false
+		}
+		p.dx = withSign(flag, int32(u16(b)))
+		p.dy = withSign(flag>>1, int32(u16(b[2:])))
+	}
+	return p, true
+}
@@ -0,0 +1,574 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt/internal/brotli"
+	"golang.org/x/image/math/fixed"
+)
+
+// testBrotli returns a Brotli stream that holds src in uncompressed
+// meta-blocks.
+func testBrotli(src []byte) []byte {
+	// The first byte's low bit is zero, for a 16 bit window. Each meta-block
+	// header is ISLAST = 0, MNIBBLES = 4, MLEN-1 and ISUNCOMPRESSED = 1,
+	// padded to a byte boundary.
+	var dst []byte
+	shift := uint(1)
+	for len(src) > 0 {
+		n := len(src)
+		if n > 1<<16 {
+			n = 1 << 16
+		}
+		h := uint32(n-1)<<3 | 1<<19
+		h <<= shift
+		dst = append(dst, uint8(h), uint8(h>>8), uint8(h>>16))
+		dst = append(dst, src[:n]...)
+		src, shift = src[n:], 0
+	}
+	// The last meta-block is empty: ISLAST = 1, ISLASTEMPTY = 1.
+	return append(dst, uint8(3<<shift))
+}
+
+func appendUIntBase128(b []byte, v uint32) []byte {
+	n := uint(1)
+	for n < 5 && v>>(7*n) != 0 {
+		n++
+	}
+	for i := n; i > 0; i-- {
+		c := uint8(v>>(7*(i-1))) & 0x7f
+		if i > 1 {
+			c |= 0x80
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func append255UInt16(b []byte, v uint16) []byte {
+	switch {
+	case v < 253:
+		return append(b, uint8(v))
+	case v < 2*253:
+		return append(b, 255, uint8(v-253))
+	case v < 3*253:
+		return append(b, 254, uint8(v-2*253))
+	}
+	return append(b, 253, uint8(v>>8), uint8(v))
+}
+
+// testWOFF2 returns the WOFF 2.0 encoding of the SFNT font data src. If
+// transform is true, the glyf, loca and hmtx tables are transformed.
+func testWOFF2(t *testing.T, src []byte, transform bool) []byte {
+	dir, data, numTables := testWOFF2Tables(t, src, transform)
+	return testWOFF2Header(u32(src), numTables, len(src), dir, testBrotli(data))
+}
+
+// testWOFF2Collection returns the WOFF 2.0 encoding of a collection of the
+// SFNT fonts in srcs, followed by a font that shares the first font's tables.
+func testWOFF2Collection(t *testing.T, srcs [][]byte, transform bool) []byte {
+	var dir, data, fonts []byte
+	numTables := 0
+	for _, src := range srcs {
+		d0, d1, n := testWOFF2Tables(t, src, transform)
+		dir = append(dir, d0...)
+		data = append(data, d1...)
+		fonts = append255UInt16(fonts, uint16(n))
+		fonts = append(fonts, src[:4]...)
+		for i := 0; i < n; i++ {
+			fonts = append255UInt16(fonts, uint16(numTables+i))
+		}
+		numTables += n
+	}
+	// The last font shares the first font's tables.
+	n := int(u16(srcs[0][4:]))
+	fonts = append255UInt16(fonts, uint16(n))
+	fonts = append(fonts, srcs[0][:4]...)
+	for i := 0; i < n; i++ {
+		fonts = append255UInt16(fonts, uint16(i))
+	}
+
+	dir = appendU32(dir, 0x00010000)
+	dir = append255UInt16(dir, uint16(len(srcs)+1))
+	dir = append(dir, fonts...)
+	return testWOFF2Header(0x74746366, numTables, 0, dir, testBrotli(data))
+}
+
+// testWOFF2Header returns the WOFF 2.0 header followed by the directory and
+// compressed data.
+func testWOFF2Header(flavor uint32, numTables, sfntLength int, dir, comp []byte) []byte {
+	const headerSize = 48
+	dst := appendU32(nil, woff2Signature)
+	dst = appendU32(dst, flavor)
+	dst = appendU32(dst, uint32(headerSize+len(dir)+len(comp)))
+	dst = appendU16(dst, uint16(numTables))
+	dst = appendU16(dst, 0)
+	dst = appendU32(dst, uint32(sfntLength))
+	dst = appendU32(dst, uint32(len(comp)))
+	dst = append(dst, make([]byte, headerSize-len(dst))...)
+	dst = append(dst, dir...)
+	return append(dst, comp...)
+}
+
+// testWOFF2Tables returns the WOFF 2.0 table directory entries and table data
+// for the SFNT font data src, and the number of tables.
+func testWOFF2Tables(t *testing.T, src []byte, transform bool) (dir, data []byte, numTables int) {
+	f, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b, err := NewBuilder(f)
+	if err != nil {
+		t.Fatalf("NewBuilder: %v", err)
+	}
+	tags := b.Tags()
+	// The loca table must immediately follow the glyf table.
+	for i, tag := range tags {
+		if tag == tagLoca {
+			tags = append(tags[:i], tags[i+1:]...)
+			break
+		}
+	}
+	for i, tag := range tags {
+		if tag == tagGlyf {
+			tags = append(tags[:i+1], append([]Tag{tagLoca}, tags[i+1:]...)...)
+			break
+		}
+	}
+
+	var xMins []int16
+	for _, tag := range tags {
+		table := b.Table(tag)
+		flags := uint8(0x3f)
+		for j := 0; j < len(woff2KnownTags); j += 4 {
+			if woff2KnownTags[j:j+4] == tag.String() {
+				flags = uint8(j / 4)
+			}
+		}
+		transformed := transform && (tag == tagGlyf || tag == tagLoca || tag == tagHmtx)
+		if !transformed && (tag == tagGlyf || tag == tagLoca) {
+			flags |= 3 << 6
+		}
+		var tData []byte
+		switch {
+		case !transformed:
+			tData = table
+		case tag == tagGlyf:
+			tData, xMins = testTransformGlyf(t, f)
+		case tag == tagHmtx:
+			tData = testTransformHmtx(f, table, xMins)
+			flags |= 1 << 6
+		}
+		dir = append(dir, flags)
+		if flags&0x3f == 0x3f {
+			dir = appendU32(dir, uint32(tag))
+		}
+		dir = appendUIntBase128(dir, uint32(len(table)))
+		if transformed {
+			dir = appendUIntBase128(dir, uint32(len(tData)))
+		}
+		data = append(data, tData...)
+	}
+	return dir, data, len(tags)
+}
+
+// testTransformGlyf returns f's transformed glyf table data, and each glyph's
+// xMin. f must not have compound glyphs.
+func testTransformGlyf(t *testing.T, f *Font) ([]byte, []int16) {
+	numGlyphs := f.NumGlyphs()
+	var nContours, nPoints, flags, glyphs, bboxes, instructions []byte
+	bboxBitmap := make([]byte, 4*((numGlyphs+31)/32))
+	xMins := make([]int16, numGlyphs)
+	var b Buffer
+	for i := 0; i < numGlyphs; i++ {
+		g, err := f.viewGlyphData(&b, GlyphIndex(i))
+		if err != nil {
+			t.Fatalf("glyph #%d: viewGlyphData: %v", i, err)
+		}
+		if len(g) == 0 {
+			nContours = appendU16(nContours, 0)
+			continue
+		}
+		n := int(int16(u16(g)))
+		if n < 0 {
+			t.Fatalf("glyph #%d: unsupported compound glyph", i)
+		}
+		xMins[i] = int16(u16(g[2:]))
+		points, ends, err := decodeGlyfPoints(nil, nil, g)
+		if err != nil {
+			t.Fatalf("glyph #%d: decodeGlyfPoints: %v", i, err)
+		}
+		nContours = appendU16(nContours, uint16(n))
+		prevEnd := -1
+		for _, e := range ends {
+			nPoints = append255UInt16(nPoints, uint16(e-prevEnd))
+			prevEnd = e
+		}
+		x, y := 0, 0
+		for _, p := range points {
+			flag, data := testTriplet(int(p.x)-x, int(p.y)-y, p.on)
+			flags = append(flags, flag)
+			glyphs = append(glyphs, data...)
+			x, y = int(p.x), int(p.y)
+		}
+		instructionsLength := u16(g[glyfHeaderLen+2*n:])
+		glyphs = append255UInt16(glyphs, instructionsLength)
+		j := glyfHeaderLen + 2*n + 2
+		instructions = append(instructions, g[j:j+int(instructionsLength)]...)
+
+		// Explicitly encode the bounding box if it isn't the points' bounds.
+		xMin, yMin, xMax, yMax := 0, 0, 0, 0
+		for j, p := range points {
+			if j == 0 || int(p.x) < xMin {
+				xMin = int(p.x)
+			}
+			if j == 0 || int(p.x) > xMax {
+				xMax = int(p.x)
+			}
+			if j == 0 || int(p.y) < yMin {
+				yMin = int(p.y)
+			}
+			if j == 0 || int(p.y) > yMax {
+				yMax = int(p.y)
+			}
+		}
+		if xMin != int(int16(u16(g[2:]))) || yMin != int(int16(u16(g[4:]))) ||
+			xMax != int(int16(u16(g[6:]))) || yMax != int(int16(u16(g[8:]))) {
+			bboxBitmap[i/8] |= 0x80 >> uint(i%8)
+			bboxes = append(bboxes, g[2:10]...)
+		}
+	}
+	bboxes = append(bboxBitmap, bboxes...)
+
+	indexFormat := uint16(0)
+	if f.cached.indexToLocFormat {
+		indexFormat = 1
+	}
+	dst := appendU16(nil, 0)
+	dst = appendU16(dst, 0)
+	dst = appendU16(dst, uint16(numGlyphs))
+	dst = appendU16(dst, indexFormat)
+	streams := [][]byte{nContours, nPoints, flags, glyphs, nil, bboxes, instructions}
+	for _, s := range streams {
+		dst = appendU32(dst, uint32(len(s)))
+	}
+	for _, s := range streams {
+		dst = append(dst, s...)
+	}
+	return dst, xMins
+}
+
+// testTriplet returns the WOFF 2.0 triplet encoding of a point.
+func testTriplet(dx, dy int, onCurve bool) (flag uint8, data []byte) {
+	if !onCurve {
+		flag = 0x80
+	}
+	x, y := dx, dy
+	xSign, ySign := uint8(1), uint8(1)
+	if x < 0 {
+		x, xSign = -x, 0
+	}
+	if y < 0 {
+		y, ySign = -y, 0
+	}
+	xySigns := xSign + 2*ySign
+	switch {
+	case x == 0 && y < 1280:
+		flag += uint8((y&0xf00)>>7) + ySign
+		data = []byte{uint8(y)}
+	case y == 0 && x < 1280:
+		flag += 10 + uint8((x&0xf00)>>7) + xSign
+		data = []byte{uint8(x)}
+	case x < 65 && y < 65:
+		flag += 20 + uint8((x-1)&0x30) + uint8(((y-1)&0x30)>>2) + xySigns
+		data = []byte{uint8((x-1)&0xf)<<4 | uint8((y-1)&0xf)}
+	case x < 769 && y < 769:
+		flag += 84 + 12*uint8(((x-1)&0x300)>>8) + uint8(((y-1)&0x300)>>6) + xySigns
+		data = []byte{uint8(x - 1), uint8(y - 1)}
+	case x < 4096 && y < 4096:
+		flag += 120 + xySigns
+		data = []byte{uint8(x >> 4), uint8(x&0xf)<<4 | uint8(y>>8), uint8(y)}
+	default:
+		flag += 124 + xySigns
+		data = []byte{uint8(x >> 8), uint8(x), uint8(y >> 8), uint8(y)}
+	}
+	return flag, data
+}
+
+// testTransformHmtx returns the transformed hmtx table data. Left side
+// bearings are omitted if they all equal the glyphs' xMin values.
+func testTransformHmtx(f *Font, hmtx []byte, xMins []int16) []byte {
+	numHMetrics := int(f.cached.numHMetrics)
+	omit := true
+	for i, xMin := range xMins {
+		lsb := 4*numHMetrics + 2*(i-numHMetrics)
+		if i < numHMetrics {
+			lsb = 4*i + 2
+		}
+		omit = omit && int16(u16(hmtx[lsb:])) == xMin
+	}
+	// Flag bit 0 means that the proportional glyphs' lsb values are omitted,
+	// and bit 1 means that the monospaced glyphs' lsb values are omitted.
+	dst := []byte{3}
+	if !omit {
+		dst[0] = 2
+	}
+	for i := 0; i < numHMetrics; i++ {
+		dst = append(dst, hmtx[4*i:4*i+2]...)
+	}
+	if !omit {
+		for i := 0; i < numHMetrics; i++ {
+			dst = append(dst, hmtx[4*i+2:4*i+4]...)
+		}
+	}
+	return dst
+}
+
+func TestParseWOFF2(t *testing.T) {
+	want, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	wantB, err := NewBuilder(want)
+	if err != nil {
+		t.Fatalf("NewBuilder: %v", err)
+	}
+
+	for _, transform := range []bool{false, true} {
+		woff2 := testWOFF2(t, goregular.TTF, transform)
+		f, err := Parse(woff2)
+		if err != nil {
+			t.Errorf("transform=%t: Parse: %v", transform, err)
+			continue
+		}
+		gotB, err := NewBuilder(f)
+		if err != nil {
+			t.Errorf("transform=%t: NewBuilder: %v", transform, err)
+			continue
+		}
+		for _, tag := range wantB.Tags() {
+			// The glyf and loca tables can differ, and so can the head table's
+			// checksum adjustment.
+			if tag == tagGlyf || tag == tagLoca || tag == tagHead {
+				continue
+			}
+			if !bytes.Equal(gotB.Table(tag), wantB.Table(tag)) {
+				t.Errorf("transform=%t: table %q differs", transform, tag)
+			}
+		}
+
+		// The glyf table's bytes can differ, but the glyphs must not.
+		var b0, b1 Buffer
+		for i := 0; i < want.NumGlyphs(); i++ {
+			g0, err0 := want.viewGlyphData(&b0, GlyphIndex(i))
+			g1, err1 := f.viewGlyphData(&b1, GlyphIndex(i))
+			if err0 != nil || err1 != nil {
+				t.Fatalf("transform=%t: glyph #%d: viewGlyphData: %v, %v", transform, i, err0, err1)
+			}
+			if len(g0) == 0 || len(g1) == 0 {
+				if len(g0) != len(g1) {
+					t.Errorf("transform=%t: glyph #%d: empty glyph mismatch", transform, i)
+				}
+				continue
+			}
+			if !bytes.Equal(g0[:glyfHeaderLen], g1[:glyfHeaderLen]) {
+				t.Errorf("transform=%t: glyph #%d: header differs", transform, i)
+			}
+			p0, e0, err0 := decodeGlyfPoints(nil, nil, g0)
+			p1, e1, err1 := decodeGlyfPoints(nil, nil, g1)
+			if err0 != nil || err1 != nil {
+				t.Fatalf("transform=%t: glyph #%d: decodeGlyfPoints: %v, %v", transform, i, err0, err1)
+			}
+			if !testEqualGlyfPoints(p0, p1) || len(e0) != len(e1) {
+				t.Errorf("transform=%t: glyph #%d: points differ", transform, i)
+			}
+		}
+
+		var b Buffer
+		got, err := f.Name(&b, NameIDFull)
+		if err != nil || got != "Go Regular" {
+			t.Errorf("transform=%t: Name: got %q, %v, want %q", transform, got, err, "Go Regular")
+		}
+
+		// Truncated data should be rejected.
+		if _, err := Parse(woff2[:len(woff2)-100]); err == nil {
+			t.Errorf("transform=%t: Parse truncated WOFF2 data: got nil error, want non-nil", transform)
+		}
+	}
+}
+
+func TestParseWOFF2Collection(t *testing.T) {
+	srcs := [][]byte{goregular.TTF, gobold.TTF}
+	for _, transform := range []bool{false, true} {
+		c, err := ParseCollection(testWOFF2Collection(t, srcs, transform))
+		if err != nil {
+			t.Errorf("transform=%t: ParseCollection: %v", transform, err)
+			continue
+		}
+		if got, want := c.NumFonts(), len(srcs)+1; got != want {
+			t.Errorf("transform=%t: NumFonts: got %d, want %d", transform, got, want)
+			continue
+		}
+		for i := 0; i < c.NumFonts(); i++ {
+			// The last font shares the first font's tables.
+			src := srcs[i%len(srcs)]
+			want, err := Parse(src)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			f, err := c.Font(i)
+			if err != nil {
+				t.Errorf("transform=%t: Font(%d): %v", transform, i, err)
+				continue
+			}
+			for _, id := range []NameID{NameIDFull, NameIDVersion} {
+				got, err := f.Name(nil, id)
+				if err != nil {
+					t.Errorf("transform=%t: font #%d: Name: %v", transform, i, err)
+					continue
+				}
+				if w, _ := want.Name(nil, id); got != w {
+					t.Errorf("transform=%t: font #%d: Name(%d): got %q, want %q", transform, i, id, got, w)
+				}
+			}
+			var b0, b1 Buffer
+			for x := 0; x < want.NumGlyphs(); x++ {
+				g0, err0 := want.LoadGlyph(&b0, GlyphIndex(x), fixed.I(12), nil)
+				g1, err1 := f.LoadGlyph(&b1, GlyphIndex(x), fixed.I(12), nil)
+				if err0 != nil || err1 != nil {
+					t.Fatalf("transform=%t: font #%d: glyph #%d: LoadGlyph: %v, %v", transform, i, x, err0, err1)
+				}
+				if err := checkSegmentsEqual(g1, g0); err != nil {
+					t.Errorf("transform=%t: font #%d: glyph #%d: %v", transform, i, x, err)
+					break
+				}
+			}
+		}
+	}
+}
+
+func testEqualGlyfPoints(p, q []glyfPoint) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWOFF2ReconstructGlyf(t *testing.T) {
+	// Three glyphs: an empty glyph, a simple glyph (a triangle with one
+	// off-curve point and a one byte instruction) and a compound glyph (with
+	// one component and explicit bounds).
+	var (
+		nContours    = []byte{0x00, 0x00, 0x00, 0x01, 0xff, 0xff}
+		nPoints      = []byte{0x03}
+		flags        = []byte{0x0b, 0x01, 0x8a}
+		glyphs       = []byte{0x64, 0x64, 0x64, 0x01}
+		composites   = []byte{0x00, 0x06, 0x00, 0x01, 0x0a, 0xf6}
+		bboxes       = []byte{0x20, 0x00, 0x00, 0x00, 0x00, 0x0a, 0xff, 0xf6, 0x00, 0x6e, 0x00, 0x64}
+		instructions = []byte{0xb0}
+	)
+	build := func() []byte {
+		data := appendU16(nil, 0)
+		data = appendU16(data, 0)
+		data = appendU16(data, 3)
+		data = appendU16(data, 0)
+		streams := [][]byte{nContours, nPoints, flags, glyphs, composites, bboxes, instructions}
+		for _, s := range streams {
+			data = appendU32(data, uint32(len(s)))
+		}
+		for _, s := range streams {
+			data = append(data, s...)
+		}
+		return data
+	}
+
+	glyf, loca, xMins, err := woff2ReconstructGlyf(build())
+	if err != nil {
+		t.Fatalf("woff2ReconstructGlyf: %v", err)
+	}
+	wantGlyf := []byte{
+		// The simple glyph, whose points are (100, 0), (100, 100) and an
+		// off-curve (0, 100).
+		0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64, 0x00, 0x64,
+		0x00, 0x02,
+		0x00, 0x01, 0xb0,
+		0x33, 0x35, 0x22,
+		0x64, 0x64,
+		0x64,
+		0x00, 0x00, 0x00,
+		// The compound glyph.
+		0xff, 0xff, 0x00, 0x0a, 0xff, 0xf6, 0x00, 0x6e, 0x00, 0x64,
+		0x00, 0x06, 0x00, 0x01, 0x0a, 0xf6,
+	}
+	if !bytes.Equal(glyf, wantGlyf) {
+		t.Errorf("glyf:\ngot  % x\nwant % x", glyf, wantGlyf)
+	}
+	wantLoca := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x00, 0x14}
+	if !bytes.Equal(loca, wantLoca) {
+		t.Errorf("loca:\ngot  % x\nwant % x", loca, wantLoca)
+	}
+	if got, want := xMins, []int16{0, 0, 10}; len(got) != 3 || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("xMins: got %v, want %v", got, want)
+	}
+
+	// An empty glyph must not have an explicit bounding box.
+	bboxes = append([]byte{0xa0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, bboxes[4:]...)
+	if _, _, _, err := woff2ReconstructGlyf(build()); err == nil {
+		t.Errorf("explicit bounds for an empty glyph: got nil error, want non-nil")
+	}
+}
+
+func TestWOFF2Reader(t *testing.T) {
+	testCases := []struct {
+		b    []byte
+		want uint32
+		ok   bool
+	}{
+		{[]byte{0x3f}, 63, true},
+		{[]byte{0x81, 0x00}, 128, true},
+		{[]byte{0x8f, 0xff, 0xff, 0xff, 0x7f}, 0xffffffff, true},
+		{[]byte{0x80, 0x01}, 0, false},
+		{[]byte{0x90, 0x80, 0x80, 0x80, 0x00}, 0, false},
+		{[]byte{0x81, 0x80, 0x80, 0x80, 0x80, 0x00}, 0, false},
+		{[]byte{0x81}, 0, false},
+	}
+	for _, tc := range testCases {
+		r := woff2Reader{b: tc.b}
+		got := r.uintBase128()
+		if got != tc.want || r.err == tc.ok {
+			t.Errorf("uintBase128(% x): got %d, %t, want %d, %t", tc.b, got, !r.err, tc.want, tc.ok)
+		}
+	}
+
+	for _, v := range []uint16{0, 1, 252, 253, 505, 506, 758, 759, 0xffff} {
+		r := woff2Reader{b: append255UInt16(nil, v)}
+		if got := r.u255UInt16(); got != v || r.err || len(r.b) != 0 {
+			t.Errorf("u255UInt16: got %d, want %d", got, v)
+		}
+	}
+}
+
+func TestTestBrotli(t *testing.T) {
+	src := make([]byte, 1<<17+10)
+	for i := range src {
+		src[i] = uint8(i * 7)
+	}
+	for _, n := range []int{0, 1, 1 << 16, len(src)} {
+		got, err := brotli.Decode(testBrotli(src[:n]), n)
+		if err != nil || !bytes.Equal(got, src[:n]) {
+			t.Errorf("n=%d: round trip failed: %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bytes"
+	"compress/zlib"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// testWOFF returns the WOFF 1.0 encoding of the SFNT font data src. Tables
+// are compressed if that makes them smaller.
+func testWOFF(t *testing.T, src []byte) []byte {
+	numTables := int(u16(src[4:]))
+	const headerSize, entrySize = 44, 20
+	dir := make([]byte, 0, entrySize*numTables)
+	var data []byte
+	for i := 0; i < numTables; i++ {
+		r := src[12+16*i:]
+		table := src[u32(r[8:]) : u32(r[8:])+u32(r[12:])]
+
+		var c bytes.Buffer
+		w := zlib.NewWriter(&c)
+		if _, err := w.Write(table); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		comp := c.Bytes()
+		if len(comp) >= len(table) {
+			comp = table
+		}
+
+		dir = append(dir, r[:4]...)
+		dir = appendU32(dir, uint32(headerSize+entrySize*numTables+len(data)))
+		dir = appendU32(dir, uint32(len(comp)))
+		dir = appendU32(dir, uint32(len(table)))
+		dir = append(dir, r[4:8]...)
+		data = append(data, comp...)
+		for len(data)&3 != 0 {
+			data = append(data, 0)
+		}
+	}
+	dst := appendU32(nil, woffSignature)
+	dst = append(dst, src[:4]...)
+	dst = appendU32(dst, uint32(headerSize+len(dir)+len(data)))
+	dst = appendU16(dst, uint16(numTables))
+	dst = appendU16(dst, 0)
+	dst = appendU32(dst, uint32(len(src)))
+	dst = append(dst, make([]byte, headerSize-len(dst))...)
+	dst = append(dst, dir...)
+	return append(dst, data...)
+}
+
+func TestParseWOFF(t *testing.T) {
+	woff := testWOFF(t, goregular.TTF)
+	if len(woff) >= len(goregular.TTF) {
+		t.Fatalf("WOFF data is not compressed: %d bytes, SFNT data is %d bytes", len(woff), len(goregular.TTF))
+	}
+
+	want, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	for _, tc := range []struct {
+		desc  string
+		parse func() (*Font, error)
+	}{
+		{"Parse", func() (*Font, error) { return Parse(woff) }},
+		{"ParseReaderAt", func() (*Font, error) { return ParseReaderAt(bytes.NewReader(woff)) }},
+	} {
+		f, err := tc.parse()
+		if err != nil {
+			t.Errorf("%s: %v", tc.desc, err)
+			continue
+		}
+		if got, want := f.NumGlyphs(), want.NumGlyphs(); got != want {
+			t.Errorf("%s: NumGlyphs: got %d, want %d", tc.desc, got, want)
+		}
+		var b Buffer
+		for _, r := range "Go!" {
+			x0, err0 := want.GlyphIndex(&b, r)
+			x1, err1 := f.GlyphIndex(&b, r)
+			if err0 != nil || err1 != nil || x0 != x1 {
+				t.Errorf("%s: GlyphIndex(%q): got %d, %v, want %d, %v", tc.desc, r, x1, err1, x0, err0)
+			}
+		}
+		got, err := f.Name(&b, NameIDFull)
+		if err != nil || got != "Go Regular" {
+			t.Errorf("%s: Name: got %q, %v, want %q", tc.desc, got, err, "Go Regular")
+		}
+	}
+
+	// Truncated data should be rejected.
+	if _, err := Parse(woff[:len(woff)-100]); err == nil {
+		t.Errorf("Parse truncated WOFF data: got nil error, want non-nil")
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"sort"
+)
+
+// This file implements writing SFNT font data, as described at
+// https://www.microsoft.com/typography/otspec/otff.htm
+
+var (
+	tagCFF  = MustParseTag("CFF ")
+	tagCFF2 = MustParseTag("CFF2")
+	tagHead = MustParseTag("head")
+)
+
+// Builder assembles SFNT font data from a set of tables. It computes the table
+// directory and the checksums, so that modifying or removing a table, such as
+// replacing the name table or removing the hinting tables, gives a valid font.
+//
+// The zero value is an empty Builder, ready to use.
+type Builder struct {
+	tables map[Tag][]byte
+}
+
+// NewBuilder returns a Builder that holds all of f's tables, including those
+// that this package does not otherwise read.
+func NewBuilder(f *Font) (*Builder, error) {
+	buf, err := f.src.view(nil, f.offset, 12)
+	if err != nil {
+		return nil, err
+	}
+	numTables := int(u16(buf[4:]))
+	buf, err = f.src.view(nil, f.offset+12, 16*numTables)
+	if err != nil {
+		return nil, err
+	}
+	// Copy the table directory, as later views may re-use its memory.
+	dir := append([]byte(nil), buf...)
+
+	b := &Builder{tables: make(map[Tag][]byte, numTables)}
+	for ; len(dir) > 0; dir = dir[16:] {
+		o, n := u32(dir[8:]), u32(dir[12:])
+		data, err := f.src.view(nil, int(o), int(n))
+		if err != nil {
+			return nil, err
+		}
+		b.tables[Tag(u32(dir))] = append([]byte(nil), data...)
+	}
+	return b, nil
+}
+
+// Tags returns the tags of b's tables, in increasing order.
+func (b *Builder) Tags() []Tag {
+	ret := make([]Tag, 0, len(b.tables))
+	for tag := range b.tables {
+		ret = append(ret, tag)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// Table returns the data of b's table with the given tag, or nil if b has no
+// such table. The caller should not modify the returned slice.
+func (b *Builder) Table(tag Tag) []byte {
+	return b.tables[tag]
+}
+
+// SetTable sets the data of b's table with the given tag, replacing any
+// previous data. b retains data, so the caller should not modify it after
+// SetTable returns.
+func (b *Builder) SetTable(tag Tag, data []byte) {
+	if b.tables == nil {
+		b.tables = map[Tag][]byte{}
+	}
+	b.tables[tag] = data
+}
+
+// RemoveTable removes b's table with the given tag, if any.
+func (b *Builder) RemoveTable(tag Tag) {
+	delete(b.tables, tag)
+}
+
+// Bytes returns the SFNT font data for b's tables. The font has PostScript
+// outlines, and an "OTTO" sfnt version, if it has a CFF or CFF2 table, and
+// TrueType outlines otherwise.
+//
+// Bytes does not check that the tables themselves are valid.
+func (b *Builder) Bytes() ([]byte, error) {
+	if len(b.tables) > maxNumTables {
+		return nil, errUnsupportedNumberOfTables
+	}
+	tables := make([]taggedTable, 0, len(b.tables))
+	for tag, data := range b.tables {
+		if len(data) > maxTableLength {
+			return nil, errUnsupportedTableOffsetLength
+		}
+		tables = append(tables, taggedTable{tag, data})
+	}
+	version := uint32(0x00010000)
+	if b.tables[tagCFF] != nil || b.tables[tagCFF2] != nil {
+		version = 0x4f54544f // "OTTO".
+	}
+	return writeSFNT(version, tables), nil
+}
+
+// taggedTable is a table's tag and data.
+type taggedTable struct {
+	tag  Tag
+	data []byte
+}
+
+// writeSFNT returns SFNT font data, with the given sfnt version, holding the
+// given tables. The table directory is sorted by tag, each table is padded to
+// a 4 byte boundary and the table checksums are computed. If there is a head
+// table, the written copy's checkSumAdjustment field is computed too.
+func writeSFNT(version uint32, tables []taggedTable) []byte {
+	tables = append([]taggedTable(nil), tables...)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].tag < tables[j].tag })
+
+	numTables := len(tables)
+	const headerSize, recordSize = 12, 16
+	size := headerSize + recordSize*numTables
+	for _, t := range tables {
+		size += (len(t.data) + 3) &^ 3
+	}
+	dst := make([]byte, headerSize+recordSize*numTables, size)
+	putOffsetTable(dst, version, numTables)
+
+	headOffset := -1
+	for i, t := range tables {
+		offset := len(dst)
+		if t.tag == tagHead && len(t.data) >= 12 {
+			headOffset = offset
+		}
+		dst = append(dst, t.data...)
+		for len(dst)&3 != 0 {
+			dst = append(dst, 0)
+		}
+		if offset == headOffset {
+			// The head table's checksum is computed with a zero
+			// checkSumAdjustment.
+			putU32(dst[offset+8:], 0)
+		}
+		r := dst[headerSize+recordSize*i:]
+		putU32(r[0:], uint32(t.tag))
+		putU32(r[4:], checksum(dst[offset:]))
+		putU32(r[8:], uint32(offset))
+		putU32(r[12:], uint32(len(t.data)))
+	}
+	if headOffset >= 0 {
+		putU32(dst[headOffset+8:], 0xb1b0afba-checksum(dst))
+	}
+	return dst
+}
+
+// collectionFont is a font in a font collection. Its tables are indexes into
+// the collection's tables.
+type collectionFont struct {
+	version uint32
+	tables  []int
+}
+
+// writeCollection returns SFNT font collection (TTC) data holding the given
+// fonts. Each table is written once, even if several fonts share it. Unlike
+// writeSFNT, the head tables are written as is.
+func writeCollection(tables []taggedTable, fonts []collectionFont) []byte {
+	const recordSize = 16
+	size := 12 + 4*len(fonts)
+	dirOffsets := make([]int, len(fonts))
+	for i, f := range fonts {
+		dirOffsets[i] = size
+		size += 12 + recordSize*len(f.tables)
+	}
+	tableOffsets := make([]int, len(tables))
+	for i, t := range tables {
+		tableOffsets[i] = size
+		size += (len(t.data) + 3) &^ 3
+	}
+
+	dst := make([]byte, size)
+	putU32(dst[0:], 0x74746366) // "ttcf".
+	putU32(dst[4:], 0x00010000)
+	putU32(dst[8:], uint32(len(fonts)))
+	for i, t := range tables {
+		copy(dst[tableOffsets[i]:], t.data)
+	}
+	for i, f := range fonts {
+		putU32(dst[12+4*i:], uint32(dirOffsets[i]))
+		indexes := append([]int(nil), f.tables...)
+		sort.Slice(indexes, func(i, j int) bool { return tables[indexes[i]].tag < tables[indexes[j]].tag })
+
+		dir := dst[dirOffsets[i]:]
+		putOffsetTable(dir, f.version, len(indexes))
+		for j, k := range indexes {
+			t, offset := tables[k], tableOffsets[k]
+			r := dir[12+recordSize*j:]
+			putU32(r[0:], uint32(t.tag))
+			putU32(r[4:], checksum(dst[offset:offset+(len(t.data)+3)&^3]))
+			putU32(r[8:], uint32(offset))
+			putU32(r[12:], uint32(len(t.data)))
+		}
+	}
+	return dst
+}
+
+// putOffsetTable writes the 12 byte offset table, the start of a font's table
+// directory, to dst.
+func putOffsetTable(dst []byte, version uint32, numTables int) {
+	const recordSize = 16
+	entrySelector := 0
+	for 2<<uint(entrySelector) <= numTables {
+		entrySelector++
+	}
+	searchRange := recordSize << uint(entrySelector)
+
+	putU32(dst[0:], version)
+	putU16(dst[4:], uint16(numTables))
+	putU16(dst[6:], uint16(searchRange))
+	putU16(dst[8:], uint16(entrySelector))
+	putU16(dst[10:], uint16(recordSize*numTables-searchRange))
+}
+
+// checksum returns the sum of b's big-endian uint32 values. len(b) must be a
+// multiple of 4.
+func checksum(b []byte) (sum uint32) {
+	for ; len(b) >= 4; b = b[4:] {
+		sum += u32(b)
+	}
+	return sum
+}
+
+func putU16(b []byte, v uint16) {
+	_ = b[1] // Bounds check hint to compiler.
+	b[0] = uint8(v >> 8)
+	b[1] = uint8(v >> 0)
+}
+
+func putU32(b []byte, v uint32) {
+	_ = b[3] // Bounds check hint to compiler.
+	b[0] = uint8(v >> 24)
+	b[1] = uint8(v >> 16)
+	b[2] = uint8(v >> 8)
+	b[3] = uint8(v >> 0)
+}
+
+func appendU16(b []byte, v uint16) []byte {
+	return append(b, uint8(v>>8), uint8(v>>0))
+}
+
+func appendU32(b []byte, v uint32) []byte {
+	return append(b, uint8(v>>24), uint8(v>>16), uint8(v>>8), uint8(v>>0))
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sfnt
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestBuilder(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b, err := NewBuilder(f)
+	if err != nil {
+		t.Fatalf("NewBuilder: %v", err)
+	}
+
+	var wantTags []Tag
+	numTables := int(u16(goregular.TTF[4:]))
+	for i := 0; i < numTables; i++ {
+		wantTags = append(wantTags, Tag(u32(goregular.TTF[12+16*i:])))
+	}
+	if got := b.Tags(); !reflect.DeepEqual(got, wantTags) {
+		t.Fatalf("Tags: got %v, want %v", got, wantTags)
+	}
+
+	tagName := MustParseTag("name")
+	tagPrep := MustParseTag("prep")
+	b.SetTable(tagName, testNameTable(map[NameID]string{
+		NameIDFamily: "Renamed",
+	}))
+	b.RemoveTable(tagPrep)
+	if got := b.Table(tagPrep); got != nil {
+		t.Errorf("Table(prep) after RemoveTable: got %d bytes, want nil", len(got))
+	}
+
+	data, err := b.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if got, want := checksum(data), uint32(0xb1b0afba); got != want {
+		t.Errorf("checksum: got %#08x, want %#08x", got, want)
+	}
+	g, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse (rebuilt): %v", err)
+	}
+	if g.prep.length != 0 {
+		t.Errorf("prep table was not removed")
+	}
+	if got, err := g.Name(nil, NameIDFamily); err != nil || got != "Renamed" {
+		t.Errorf("Name: got %q, %v, want %q, nil", got, err, "Renamed")
+	}
+
+	// The other tables should be unchanged.
+	c, err := NewBuilder(g)
+	if err != nil {
+		t.Fatalf("NewBuilder (rebuilt): %v", err)
+	}
+	for _, tag := range b.Tags() {
+		got, want := c.Table(tag), b.Table(tag)
+		if tag == tagHead {
+			// Ignore the checkSumAdjustment field.
+			got = append([]byte(nil), got...)
+			copy(got[8:12], want[8:12])
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("table %q differs", tag)
+		}
+	}
+}
+
+func TestBuilderZeroValue(t *testing.T) {
+	var b Builder
+	b.SetTable(MustParseTag("CFF "), []byte{1, 2, 3})
+	b.SetTable(MustParseTag("abcd"), []byte{4, 5, 6, 7, 8})
+	data, err := b.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	want := []byte{
+		'O', 'T', 'T', 'O', 0x00, 0x02, 0x00, 0x20, 0x00, 0x01, 0x00, 0x00,
+		'C', 'F', 'F', ' ', 0x01, 0x02, 0x03, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x03,
+		'a', 'b', 'c', 'd', 0x0c, 0x05, 0x06, 0x07, 0x00, 0x00, 0x00, 0x30, 0x00, 0x00, 0x00, 0x05,
+		0x01, 0x02, 0x03, 0x00,
+		0x04, 0x05, 0x06, 0x07, 0x08, 0x00, 0x00, 0x00,
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("got\n% x\nwant\n% x", data, want)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shaping
+
+import (
+	"unicode"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// joiningType is a rune's Arabic joining type, as per the Unicode Standard's
+// ArabicShaping.txt.
+type joiningType uint8
+
+const (
+	joiningNone        joiningType = iota // U: does not join.
+	joiningRight                          // R: joins to the previous rune only.
+	joiningDual                           // D: joins to the previous and next runes.
+	joiningCausing                        // C: joins to both, but does not change shape.
+	joiningTransparent                    // T: is skipped when joining.
+)
+
+// joiningRanges are the joining types of the Arabic block's runes, other
+// than marks, which are transparent. Runes that are not listed do not join.
+var joiningRanges = [...]struct {
+	lo, hi rune
+	t      joiningType
+}{
+	{0x0622, 0x0625, joiningRight},
+	{0x0626, 0x0626, joiningDual},
+	{0x0627, 0x0627, joiningRight},
+	{0x0628, 0x0628, joiningDual},
+	{0x0629, 0x0629, joiningRight},
+	{0x062a, 0x062e, joiningDual},
+	{0x062f, 0x0632, joiningRight},
+	{0x0633, 0x063f, joiningDual},
+	{0x0640, 0x0640, joiningCausing}, // Tatweel.
+	{0x0641, 0x0647, joiningDual},
+	{0x0648, 0x0648, joiningRight},
+	{0x0649, 0x064a, joiningDual},
+	{0x066e, 0x066f, joiningDual},
+	{0x0671, 0x0673, joiningRight},
+	{0x0675, 0x0677, joiningRight},
+	{0x0678, 0x0687, joiningDual},
+	{0x0688, 0x0699, joiningRight},
+	{0x069a, 0x06bf, joiningDual},
+	{0x06c0, 0x06c0, joiningRight},
+	{0x06c1, 0x06c2, joiningDual},
+	{0x06c3, 0x06cb, joiningRight},
+	{0x06cc, 0x06cc, joiningDual},
+	{0x06cd, 0x06cd, joiningRight},
+	{0x06ce, 0x06ce, joiningDual},
+	{0x06cf, 0x06cf, joiningRight},
+	{0x06d0, 0x06d1, joiningDual},
+	{0x06d2, 0x06d3, joiningRight},
+	{0x06d5, 0x06d5, joiningRight},
+	{0x06ee, 0x06ef, joiningRight},
+	{0x06fa, 0x06fc, joiningDual},
+	{0x06ff, 0x06ff, joiningDual},
+}
+
+// joining returns r's joining type.
+func joining(r rune) joiningType {
+	switch {
+	case r == 0x200d: // Zero width joiner.
+		return joiningCausing
+	case r == 0x200c: // Zero width non-joiner.
+		return joiningNone
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return joiningTransparent
+	}
+	lo, hi := 0, len(joiningRanges)
+	for lo < hi {
+		m := lo + (hi-lo)/2
+		switch g := joiningRanges[m]; {
+		case r < g.lo:
+			hi = m
+		case g.hi < r:
+			lo = m + 1
+		default:
+			return g.t
+		}
+	}
+	return joiningNone
+}
+
+// form is the contextual form of a joining rune's glyph.
+type form uint8
+
+const (
+	formNone form = iota
+	formIsolated
+	formInitial
+	formMedial
+	formFinal
+)
+
+var formFeatures = [...]sfnt.Tag{
+	formIsolated: sfnt.MustParseTag("isol"),
+	formInitial:  sfnt.MustParseTag("init"),
+	formMedial:   sfnt.MustParseTag("medi"),
+	formFinal:    sfnt.MustParseTag("fina"),
+}
+
+// joiningForms returns the contextual form of each of the runes, in logical
+// order. Transparent runes are skipped when finding a rune's neighbors. Runes
+// that do not join, and transparent runes, have formNone.
+func joiningForms(runes []rune) []form {
+	types := make([]joiningType, len(runes))
+	for i, r := range runes {
+		types[i] = joining(r)
+	}
+	forms := make([]form, len(runes))
+	prev := -1
+	for i, t := range types {
+		if t == joiningTransparent {
+			continue
+		}
+		next := i + 1
+		for next < len(types) && types[next] == joiningTransparent {
+			next++
+		}
+		before := prev >= 0 && joinsNext(types[prev]) && joinsPrevious(t)
+		after := next < len(types) && joinsNext(t) && joinsPrevious(types[next])
+		prev = i
+		if t != joiningRight && t != joiningDual {
+			continue
+		}
+		switch {
+		case before && after:
+			forms[i] = formMedial
+		case before:
+			forms[i] = formFinal
+		case after:
+			forms[i] = formInitial
+		default:
+			forms[i] = formIsolated
+		}
+	}
+	return forms
+}
+
+// joinsNext returns whether a rune with joining type t can join to the next
+// rune.
+func joinsNext(t joiningType) bool {
+	return t == joiningDual || t == joiningCausing
+}
+
+// joinsPrevious returns whether a rune with joining type t can join to the
+// previous rune.
+func joinsPrevious(t joiningType) bool {
+	return t == joiningRight || t == joiningDual || t == joiningCausing
+}
@@ -0,0 +1,377 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shaping
+
+import (
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// indicScript is a script whose syllables are reordered before they are
+// shaped.
+type indicScript uint8
+
+const (
+	scriptNone indicScript = iota
+	scriptDevanagari
+	scriptKhmer
+)
+
+// indicCategory is the part that a rune plays in a syllable.
+type indicCategory uint8
+
+const (
+	catOther     indicCategory = iota
+	catConsonant               // A consonant, other than ra.
+	catRa                      // Ra, which can form a reph or a post-base form.
+	catNukta                   // A nukta, which modifies a consonant.
+	catVirama                  // A virama, or Khmer's coeng, which joins consonants.
+	catVowel                   // An independent vowel.
+	catMatra                   // A dependent vowel sign.
+	catPreMatra                // A dependent vowel sign that is drawn before its consonants.
+	catModifier                // A sign such as an anusvara or a visarga.
+	catJoiner                  // A zero width joiner or non-joiner.
+)
+
+// indicCategoryOf returns r's script and category.
+func indicCategoryOf(r rune) (indicScript, indicCategory) {
+	switch {
+	case r == 0x200c || r == 0x200d:
+		return scriptNone, catJoiner
+	case 0x0900 <= r && r <= 0x097f:
+		return scriptDevanagari, devanagariCategory(r)
+	case 0x1780 <= r && r <= 0x17ff:
+		return scriptKhmer, khmerCategory(r)
+	}
+	return scriptNone, catOther
+}
+
+func devanagariCategory(r rune) indicCategory {
+	switch {
+	case r == 0x0930:
+		return catRa
+	case 0x0915 <= r && r <= 0x0939, 0x0958 <= r && r <= 0x095f, 0x0978 <= r && r <= 0x097f:
+		return catConsonant
+	case r == 0x093c:
+		return catNukta
+	case r == 0x094d:
+		return catVirama
+	case 0x0904 <= r && r <= 0x0914, 0x0960 <= r && r <= 0x0961, 0x0972 <= r && r <= 0x0977:
+		return catVowel
+	case r == 0x093f, r == 0x094e:
+		return catPreMatra
+	case 0x093a <= r && r <= 0x093b, 0x093e <= r && r <= 0x094c, r == 0x094f,
+		0x0955 <= r && r <= 0x0957, 0x0962 <= r && r <= 0x0963:
+		return catMatra
+	case 0x0900 <= r && r <= 0x0903:
+		return catModifier
+	}
+	return catOther
+}
+
+func khmerCategory(r rune) indicCategory {
+	switch {
+	case r == 0x179a:
+		return catRa
+	case 0x1780 <= r && r <= 0x17b3:
+		// The independent vowels take subscript consonants, like consonants.
+		return catConsonant
+	case r == 0x17d2:
+		return catVirama
+	case 0x17c1 <= r && r <= 0x17c3:
+		return catPreMatra
+	case 0x17b6 <= r && r <= 0x17c5:
+		return catMatra
+	case 0x17c6 <= r && r <= 0x17d1, r == 0x17d3, r == 0x17dd:
+		return catModifier
+	}
+	return catOther
+}
+
+// indicRole is the part that a rune's glyphs play in a reordered syllable. It
+// selects the GSUB features that are applied to them.
+type indicRole uint8
+
+const (
+	roleReph indicRole = 1 << iota // A Devanagari ra and virama that form a reph.
+	rolePref                       // A Khmer coeng and ro, which are drawn before the base.
+	rolePre                        // The consonants and vowel signs before the base.
+	roleBase                       // The base consonant or independent vowel.
+	rolePost                       // Everything after the base.
+
+	roleAll = roleReph | rolePref | rolePre | roleBase | rolePost
+)
+
+// indicSyllable is a syllable of a Devanagari or Khmer run of text.
+type indicSyllable struct {
+	// start and end are the syllable's runes' indexes.
+	start, end int
+	script     indicScript
+	// reordered is whether the syllable's glyphs are not in logical order,
+	// after Shape reorders them.
+	reordered bool
+	// pref is whether a Khmer syllable has a coeng and ro that move before
+	// the base.
+	pref bool
+}
+
+// indicSyllables splits the Devanagari and Khmer parts of the runes into
+// syllables. It returns the syllables, the order, as rune indexes, that the
+// runes are mapped to glyphs in, which reorders each syllable, and each rune's
+// role.
+func indicSyllables(runes []rune) (syllables []indicSyllable, order []int, roles []indicRole) {
+	order = make([]int, 0, len(runes))
+	roles = make([]indicRole, len(runes))
+	for i := 0; i < len(runes); {
+		script, _ := indicCategoryOf(runes[i])
+		if script == scriptNone {
+			order = append(order, i)
+			i++
+			continue
+		}
+		s := indicSyllable{start: i, end: syllableEnd(runes, i), script: script}
+		order = reorderSyllable(order, runes, &s, roles)
+		syllables = append(syllables, s)
+		i = s.end
+	}
+	return syllables, order, roles
+}
+
+// syllableEnd returns the end of the syllable that starts with runes[i]. A
+// consonant syllable is a cluster of consonants, joined by viramas, followed by
+// vowel signs and modifiers. A vowel syllable is an independent vowel followed
+// by vowel signs and modifiers.
+func syllableEnd(runes []rune, i int) int {
+	script, c := indicCategoryOf(runes[i])
+	cat := func(j int) indicCategory {
+		if j >= len(runes) {
+			return catOther
+		}
+		s, c := indicCategoryOf(runes[j])
+		if s != script && c != catJoiner {
+			return catOther
+		}
+		return c
+	}
+
+	switch c {
+	case catConsonant, catRa:
+		for i++; ; i++ {
+			if cat(i) == catNukta {
+				i++
+			}
+			if cat(i) != catVirama {
+				break
+			}
+			i++
+			if cat(i) == catJoiner {
+				i++
+			}
+			if c := cat(i); c != catConsonant && c != catRa {
+				// The syllable ends with a virama.
+				return i
+			}
+		}
+	case catVowel:
+		i++
+		if cat(i) == catNukta {
+			i++
+		}
+	default:
+		return i + 1
+	}
+	for {
+		switch cat(i) {
+		case catNukta, catMatra, catPreMatra, catModifier:
+			i++
+		default:
+			return i
+		}
+	}
+}
+
+// reorderSyllable appends the syllable's rune indexes to order, in the order
+// that their glyphs are drawn, and sets their roles and the syllable's
+// reordered and pref fields.
+//
+// A Devanagari syllable's pre-base vowel sign moves before its consonants,
+// after any reph. A Khmer syllable's coeng and ro move before its base, and
+// its pre-base vowel signs move before those.
+func reorderSyllable(order []int, runes []rune, s *indicSyllable, roles []indicRole) []int {
+	cats := make([]indicCategory, s.end-s.start)
+	for i := range cats {
+		_, cats[i] = indicCategoryOf(runes[s.start+i])
+	}
+	isConsonant := func(i int) bool {
+		return i < len(cats) && (cats[i] == catConsonant || cats[i] == catRa)
+	}
+
+	// Find the reph and the base. Indexes are relative to s.start.
+	first, base := 0, 0
+	if s.script == scriptDevanagari {
+		if len(cats) >= 3 && cats[0] == catRa && cats[1] == catVirama && isConsonant(2) {
+			roles[s.start], roles[s.start+1] = roleReph, roleReph
+			first = 2
+		}
+		// The base is the last consonant, unless that is a ra that forms a
+		// below-base form with the virama before it.
+		base = first
+		for i := first; i < len(cats); i++ {
+			if isConsonant(i) {
+				base = i
+			}
+		}
+		if base > first && cats[base] == catRa && cats[base-1] == catVirama {
+			for i := base - 2; i >= first; i-- {
+				if isConsonant(i) {
+					base = i
+					break
+				}
+			}
+		}
+	}
+
+	var pre, pref, rest []int
+	for i, c := range cats {
+		k := s.start + i
+		switch {
+		case roles[k] == roleReph:
+			continue
+		case c == catPreMatra:
+			roles[k] = rolePre
+			pre = append(pre, k)
+			continue
+		case s.script == scriptKhmer && pref == nil && c == catVirama && i+1 < len(cats) && cats[i+1] == catRa:
+			roles[k], roles[k+1] = rolePref, rolePref
+			pref = append(pref, k, k+1)
+			continue
+		case len(pref) == 2 && pref[1] == k:
+			continue
+		case i < base:
+			roles[k] = rolePre
+		case i == base || (i == base+1 && c == catNukta):
+			roles[k] = roleBase
+		default:
+			roles[k] = rolePost
+		}
+		rest = append(rest, k)
+	}
+
+	// A reph moves once it is formed, by shapeSyllable.
+	s.reordered = first != 0 || len(pre) != 0 || len(pref) != 0
+	s.pref = len(pref) != 0
+	if first != 0 {
+		order = append(order, s.start, s.start+1)
+	}
+	order = append(order, pre...)
+	order = append(order, pref...)
+	return append(order, rest...)
+}
+
+// featureStage is a set of GSUB features whose lookups are applied together,
+// to the glyphs with the given roles.
+type featureStage struct {
+	roles    indicRole
+	features []sfnt.Tag
+}
+
+var (
+	devanagariBasicStages = []featureStage{
+		{roleAll, parseTags("nukt akhn")},
+		{roleReph, parseTags("rphf")},
+		{rolePre, parseTags("half")},
+		{rolePost, parseTags("blwf pstf")},
+		{roleBase | rolePost, parseTags("vatu")},
+		{rolePre | roleBase | rolePost, parseTags("cjct")},
+	}
+	devanagariPresentationStage = featureStage{roleAll, parseTags("pres abvs blws psts haln")}
+
+	khmerBasicStages = []featureStage{
+		{rolePref, parseTags("pref")},
+		{rolePost, parseTags("blwf abvf pstf")},
+	}
+	// The "cfar" feature is only for syllables with a coeng and ro.
+	khmerCfarStage         = featureStage{rolePost, parseTags("cfar")}
+	khmerPresentationStage = featureStage{roleAll, parseTags("pres abvs blws psts")}
+)
+
+// parseTags parses the space-separated tags.
+func parseTags(s string) []sfnt.Tag {
+	var tags []sfnt.Tag
+	for _, t := range strings.Fields(s) {
+		tags = append(tags, sfnt.MustParseTag(t))
+	}
+	return tags
+}
+
+// shapeSyllable applies the GSUB features of the syllable's script to its
+// glyphs, which are in the order given by reorderSyllable. The glyphs'
+// Clusters are rune indexes.
+//
+// Each feature only applies to the glyphs whose roles it is for, such as the
+// half forms to the consonants before the base. A Devanagari reph, once formed,
+// moves after the syllable's vowel signs, before its modifiers.
+func shapeSyllable(b *sfnt.Buffer, f *sfnt.Font, glyphs []sfnt.LayoutGlyph, s indicSyllable, runes []rune, roles []indicRole) ([]sfnt.LayoutGlyph, error) {
+	var stages []featureStage
+	switch s.script {
+	case scriptDevanagari:
+		stages = devanagariBasicStages
+	case scriptKhmer:
+		stages = khmerBasicStages
+		if s.pref {
+			stages = append(stages[:len(stages):len(stages)], khmerCfarStage)
+		}
+	}
+	var err error
+	for _, st := range stages {
+		if glyphs, err = substituteRoles(b, f, glyphs, roles, st); err != nil {
+			return nil, err
+		}
+	}
+
+	presentation := khmerPresentationStage
+	if s.script == scriptDevanagari {
+		presentation = devanagariPresentationStage
+		if len(glyphs) > 1 && roles[glyphs[0].Cluster] == roleReph && roles[glyphs[1].Cluster] != roleReph {
+			// Move the reph after everything but the trailing modifiers.
+			reph, i := glyphs[0], len(glyphs)
+			for i > 1 {
+				if _, c := indicCategoryOf(runes[glyphs[i-1].Cluster]); c != catModifier {
+					break
+				}
+				i--
+			}
+			copy(glyphs, glyphs[1:i])
+			glyphs[i-1] = reph
+		}
+	}
+	return substituteRoles(b, f, glyphs, roles, presentation)
+}
+
+// substituteRoles applies the stage's GSUB features to the span of glyphs,
+// from the first to the last, whose runes have one of the stage's roles.
+func substituteRoles(b *sfnt.Buffer, f *sfnt.Font, glyphs []sfnt.LayoutGlyph, roles []indicRole, st featureStage) ([]sfnt.LayoutGlyph, error) {
+	lo, hi := -1, 0
+	for i, g := range glyphs {
+		if roles[g.Cluster]&st.roles != 0 {
+			if lo < 0 {
+				lo = i
+			}
+			hi = i + 1
+		}
+	}
+	if lo < 0 {
+		return glyphs, nil
+	}
+	span, err := f.SubstituteGlyphs(b, append([]sfnt.LayoutGlyph(nil), glyphs[lo:hi]...), st.features...)
+	if err != nil {
+		return nil, err
+	}
+	dst := make([]sfnt.LayoutGlyph, 0, lo+len(span)+len(glyphs)-hi)
+	dst = append(dst, glyphs[:lo]...)
+	dst = append(dst, span...)
+	return append(dst, glyphs[hi:]...), nil
+}
@@ -0,0 +1,379 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package shaping converts text to a run of positioned glyphs, using an SFNT
+// font's OpenType layout tables.
+//
+// Drawing text one rune at a time, as font.Drawer does, is fine for scripts
+// like Latin, but other scripts need more. Arabic joins its letters, and each
+// letter's glyph depends on whether it joins to its neighbors. Devanagari and
+// Khmer draw some vowel signs and consonants before the consonants that they
+// follow, and form conjuncts from clusters of consonants. Many scripts place
+// marks, such as accents and vowel signs, relative to their base glyphs.
+//
+// Shape chooses each glyph's contextual form, reorders Devanagari and Khmer
+// syllables, applies the GSUB features that those scripts need as well as
+// ligatures and contextual alternates, kerns glyph pairs, connects cursive
+// glyphs and attaches marks to their bases.
+//
+// It is not a complete shaping engine. It does not apply reverse chaining
+// substitutions, or attach marks to ligatures' components, and it does not
+// select the lookups for the text's script and language. Scripts that reorder
+// like Devanagari, such as Bengali and Tamil, are not reordered.
+package shaping // import "golang.org/x/image/font/shaping"
+
+import (
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Direction is the direction of a run of text.
+type Direction int
+
+const (
+	// DirectionAuto means the direction of the text's first strongly
+	// directional rune, or left to right if there is no such rune.
+	DirectionAuto Direction = iota
+	// DirectionLeftToRight is for scripts such as Latin.
+	DirectionLeftToRight
+	// DirectionRightToLeft is for scripts such as Arabic and Hebrew.
+	DirectionRightToLeft
+)
+
+// Options are optional arguments to Shape.
+type Options struct {
+	// Direction is the text's direction.
+	Direction Direction
+
+	// Features are additional GSUB features, such as "smcp" (small capitals),
+	// to apply to the glyphs, as per sfnt.Font.SubstituteGlyphs.
+	Features []sfnt.Tag
+
+	// Hinting is the hinting policy for the glyphs' advances and kerns, as
+	// per sfnt.Font.GlyphAdvance.
+	Hinting font.Hinting
+}
+
+// Glyph is a positioned glyph.
+type Glyph struct {
+	// GlyphIndex is the glyph in the font.
+	GlyphIndex sfnt.GlyphIndex
+
+	// Cluster is the byte offset, in the shaped text, of the rune that the
+	// glyph is for. A ligature is for the first of its runes, and the glyphs
+	// of a reordered Devanagari or Khmer syllable are all for the syllable's
+	// first rune.
+	Cluster int
+
+	// Advance is how far the dot moves right after drawing the glyph. Offset
+	// is where the glyph is drawn relative to the dot. As for the font
+	// package, the y axis increases down.
+	Advance fixed.Int26_6
+	Offset  fixed.Point26_6
+}
+
+// Shape returns the glyphs for text, which should be a single run of one
+// script and direction, such as a single line of a single bidirectional text
+// level.
+//
+// ppem is the number of pixels in 1 em. The glyphs are returned in visual
+// order, left to right, even for right-to-left text, so that they can be
+// drawn by starting at the left of the run and moving the dot right by each
+// glyph's advance.
+//
+// Runes that the font has no glyph for are shaped as its missing glyph,
+// glyph index 0.
+func Shape(b *sfnt.Buffer, f *sfnt.Font, ppem fixed.Int26_6, text string, opts *Options) ([]Glyph, error) {
+	if b == nil {
+		b = &sfnt.Buffer{}
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	var (
+		runes   []rune
+		offsets []int
+	)
+	for i, r := range text {
+		runes = append(runes, r)
+		offsets = append(offsets, i)
+	}
+	forms := joiningForms(runes)
+	syllables, order, roles := indicSyllables(runes)
+
+	// A reordered syllable's glyphs are all for the syllable's first rune.
+	clusters := append([]int(nil), offsets...)
+	for _, s := range syllables {
+		if s.reordered {
+			for i := s.start; i < s.end; i++ {
+				clusters[i] = offsets[s.start]
+			}
+		}
+	}
+
+	// Map the runes to glyphs and apply the substitutions, in logical order,
+	// except within the reordered syllables. Until the glyphs are positioned,
+	// their Clusters are rune indexes.
+	lg := make([]sfnt.LayoutGlyph, len(runes))
+	for i, j := range order {
+		x, err := f.GlyphIndex(b, runes[j])
+		if err != nil {
+			return nil, err
+		}
+		lg[i] = sfnt.LayoutGlyph{GlyphIndex: x, Cluster: j}
+	}
+	lg, err := f.SubstituteGlyphs(b, lg, preFeatures...)
+	if err != nil {
+		return nil, err
+	}
+	for i, g := range lg {
+		if fm := forms[g.Cluster]; fm != formNone {
+			if lg[i].GlyphIndex, err = f.SubstituteGlyph(b, g.GlyphIndex, formFeatures[fm]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(syllables) != 0 {
+		if lg, err = shapeSyllables(b, f, lg, syllables, runes, roles); err != nil {
+			return nil, err
+		}
+	}
+	features := append(postFeatures[:len(postFeatures):len(postFeatures)], opts.Features...)
+	if lg, err = f.SubstituteGlyphs(b, lg, features...); err != nil {
+		return nil, err
+	}
+
+	glyphs := make([]Glyph, len(lg))
+	for i, g := range lg {
+		advance, err := f.GlyphAdvance(b, g.GlyphIndex, ppem, opts.Hinting)
+		if err != nil {
+			return nil, err
+		}
+		glyphs[i] = Glyph{GlyphIndex: g.GlyphIndex, Cluster: clusters[g.Cluster], Advance: advance}
+	}
+
+	rtl := opts.Direction == DirectionRightToLeft ||
+		(opts.Direction == DirectionAuto && firstStrongIsRTL(runes))
+	if err := position(b, f, ppem, glyphs, lg, runes, forms, rtl, opts.Hinting); err != nil {
+		return nil, err
+	}
+	return glyphs, nil
+}
+
+var (
+	// preFeatures are applied before the joining forms and the syllables'
+	// features, and postFeatures after them.
+	preFeatures  = parseTags("locl ccmp")
+	postFeatures = parseTags("rlig calt liga clig")
+)
+
+// shapeSyllables applies shapeSyllable to each syllable's glyphs. Glyphs that
+// are not in a syllable are unchanged.
+func shapeSyllables(b *sfnt.Buffer, f *sfnt.Font, glyphs []sfnt.LayoutGlyph, syllables []indicSyllable, runes []rune, roles []indicRole) ([]sfnt.LayoutGlyph, error) {
+	syllableOf := make([]int, len(runes))
+	for i := range syllableOf {
+		syllableOf[i] = -1
+	}
+	for i, s := range syllables {
+		for j := s.start; j < s.end; j++ {
+			syllableOf[j] = i
+		}
+	}
+
+	dst := make([]sfnt.LayoutGlyph, 0, len(glyphs))
+	for i := 0; i < len(glyphs); {
+		s := syllableOf[glyphs[i].Cluster]
+		j := i + 1
+		for j < len(glyphs) && syllableOf[glyphs[j].Cluster] == s {
+			j++
+		}
+		if s < 0 {
+			dst = append(dst, glyphs[i:j]...)
+		} else {
+			g, err := shapeSyllable(b, f, glyphs[i:j], syllables[s], runes, roles)
+			if err != nil {
+				return nil, err
+			}
+			dst = append(dst, g...)
+		}
+		i = j
+	}
+	return dst, nil
+}
+
+// markAttachment is a glyph's attachment, if it is a mark, to an earlier
+// glyph in logical order.
+type markAttachment struct {
+	isMark bool
+	// to is the index of the glyph that the mark attaches to, or -1 if the
+	// glyph is not an attached mark.
+	to      int
+	anchors sfnt.MarkAnchors
+}
+
+// position kerns the glyphs, connects the cursive attachment anchors of those
+// that join, and attaches marks to their bases. The glyphs, and the
+// corresponding layout glyphs, whose Clusters are rune indexes, are in
+// logical order. position reverses the glyphs, for right-to-left text, so that
+// they are in visual order.
+//
+// The gap between two visually adjacent glyphs is set by the advance of the
+// left one, which is the logically later one for right-to-left text. Marks are
+// skipped when kerning and connecting glyphs.
+func position(b *sfnt.Buffer, f *sfnt.Font, ppem fixed.Int26_6, glyphs []Glyph, lg []sfnt.LayoutGlyph, runes []rune, forms []form, rtl bool, h font.Hinting) error {
+	upem := fixed.Int26_6(f.UnitsPerEm())
+	scale := func(u sfnt.Units) fixed.Int26_6 {
+		x := fixed.Int26_6(u) * ppem
+		if x >= 0 {
+			x += upem / 2
+		} else {
+			x -= upem / 2
+		}
+		return x / upem
+	}
+
+	// Attach each mark to the mark before it, or else to the nearest base
+	// before it. Attached marks do not advance the dot.
+	marks := make([]markAttachment, len(glyphs))
+	base := -1
+	for i, g := range lg {
+		m, err := isMark(b, f, g.GlyphIndex, runes[g.Cluster])
+		if err != nil {
+			return err
+		}
+		marks[i] = markAttachment{isMark: m, to: -1}
+		if !m {
+			base = i
+			continue
+		}
+		if i > 0 && marks[i-1].isMark {
+			a, ok, err := f.MarkAnchors(b, lg[i-1].GlyphIndex, g.GlyphIndex, true)
+			if err != nil {
+				return err
+			}
+			if ok {
+				marks[i].to, marks[i].anchors = i-1, a
+			}
+		}
+		if marks[i].to < 0 && base >= 0 {
+			a, ok, err := f.MarkAnchors(b, lg[base].GlyphIndex, g.GlyphIndex, false)
+			if err != nil {
+				return err
+			}
+			if ok {
+				marks[i].to, marks[i].anchors = base, a
+			}
+		}
+		if marks[i].to >= 0 {
+			glyphs[i].Advance = 0
+		}
+	}
+
+	prev := -1
+	var prevAnchors sfnt.CursiveAnchors
+	for i := range glyphs {
+		if marks[i].isMark {
+			continue
+		}
+		curr, err := f.CursiveAnchors(b, glyphs[i].GlyphIndex)
+		if err != nil {
+			return err
+		}
+		if prev < 0 {
+			prev, prevAnchors = i, curr
+			continue
+		}
+		p, q := &glyphs[prev], &glyphs[i]
+		left := p
+		if rtl {
+			left = q
+		}
+
+		// Only glyphs that join, and have anchors, are connected. The
+		// previous glyph's exit anchor meets this glyph's entry anchor.
+		pf, qf := forms[lg[prev].Cluster], forms[lg[i].Cluster]
+		joined := (pf == formInitial || pf == formMedial) && (qf == formMedial || qf == formFinal)
+		if joined && prevAnchors.HasExit && curr.HasEntry {
+			exitX, exitY := scale(prevAnchors.Exit.X), scale(prevAnchors.Exit.Y)
+			entryX, entryY := scale(curr.Entry.X), scale(curr.Entry.Y)
+			if rtl {
+				left.Advance = entryX - exitX
+			} else {
+				left.Advance = exitX - entryX
+			}
+			if h == font.HintingFull {
+				left.Advance = (left.Advance + 32) &^ 63
+			}
+			// The y axis increases down.
+			q.Offset.Y = p.Offset.Y - (exitY - entryY)
+		} else {
+			k, err := f.Kern(b, p.GlyphIndex, q.GlyphIndex, ppem, h)
+			if err != nil {
+				return err
+			}
+			left.Advance += k
+		}
+		prev, prevAnchors = i, curr
+	}
+
+	visual := func(i int) int { return i }
+	if rtl {
+		for i, j := 0, len(glyphs)-1; i < j; i, j = i+1, j-1 {
+			glyphs[i], glyphs[j] = glyphs[j], glyphs[i]
+		}
+		visual = func(i int) int { return len(glyphs) - 1 - i }
+	}
+
+	// Place each attached mark so that its anchor meets the anchor of the
+	// glyph that it attaches to. That glyph is attached first, if it is a
+	// mark itself.
+	dots := make([]fixed.Int26_6, len(glyphs))
+	for i := 1; i < len(glyphs); i++ {
+		dots[i] = dots[i-1] + glyphs[i-1].Advance
+	}
+	for i, m := range marks {
+		if m.to < 0 {
+			continue
+		}
+		g, to := visual(i), visual(m.to)
+		glyphs[g].Offset = fixed.Point26_6{
+			X: dots[to] + glyphs[to].Offset.X + scale(m.anchors.Base.X) - scale(m.anchors.Mark.X) - dots[g],
+			// The y axis increases down.
+			Y: glyphs[to].Offset.Y - (scale(m.anchors.Base.Y) - scale(m.anchors.Mark.Y)),
+		}
+	}
+	return nil
+}
+
+// isMark returns whether the glyph, for the rune r, is a mark: it is a mark in
+// the font's GDEF table or, if that does not classify it, r is a nonspacing or
+// enclosing mark.
+func isMark(b *sfnt.Buffer, f *sfnt.Font, x sfnt.GlyphIndex, r rune) (bool, error) {
+	c, err := f.GlyphClass(b, x)
+	if err != nil {
+		return false, err
+	}
+	if c == sfnt.GlyphClassNone {
+		return unicode.In(r, unicode.Mn, unicode.Me), nil
+	}
+	return c == sfnt.GlyphClassMark, nil
+}
+
+// firstStrongIsRTL returns whether the first of the runes that belongs to a
+// script with a strong direction belongs to a right-to-left script.
+func firstStrongIsRTL(runes []rune) bool {
+	for _, r := range runes {
+		switch {
+		case unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko):
+			return true
+		case unicode.IsLetter(r):
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,537 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shaping
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func be16(b []byte, vs ...int) []byte {
+	for _, v := range vs {
+		b = append(b, byte(v>>8), byte(v))
+	}
+	return b
+}
+
+func be32(b []byte, vs ...int) []byte {
+	for _, v := range vs {
+		b = append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	return b
+}
+
+func glyphIndex(t *testing.T, f *sfnt.Font, r rune) sfnt.GlyphIndex {
+	x, err := f.GlyphIndex(&sfnt.Buffer{}, r)
+	if err != nil || x == 0 {
+		t.Fatalf("GlyphIndex(%q): got %d, %v", r, x, err)
+	}
+	return x
+}
+
+// testCmap returns a cmap table with a format 12 subtable that maps the given
+// runes to glyphs.
+func testCmap(m map[rune]sfnt.GlyphIndex) []byte {
+	var runes []int
+	for r := range m {
+		runes = append(runes, int(r))
+	}
+	sort.Ints(runes)
+	b := be16(nil, 0, 1, 3, 10)
+	b = be32(b, 12)
+	b = be16(b, 12, 0)
+	b = be32(b, 16+12*len(runes), 0, len(runes))
+	for _, r := range runes {
+		b = be32(b, r, r, int(m[rune(r)]))
+	}
+	return b
+}
+
+// testLayoutTable returns a GSUB or GPOS table whose features each have one
+// lookup, given by the feature's lookup table.
+func testLayoutTable(lookups map[string][]byte) []byte {
+	var tags []string
+	for tag := range lookups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	featureList := be16(nil, len(tags))
+	var featureTables, lookupList, lookupTables []byte
+	lookupList = be16(lookupList, len(tags))
+	for i, tag := range tags {
+		featureList = append(featureList, tag...)
+		featureList = be16(featureList, 2+6*len(tags)+len(featureTables))
+		featureTables = be16(featureTables, 0, 1, i)
+		lookupList = be16(lookupList, 2+2*len(tags)+len(lookupTables))
+		lookupTables = append(lookupTables, lookups[tag]...)
+	}
+	featureList = append(featureList, featureTables...)
+	lookupList = append(lookupList, lookupTables...)
+
+	const headerSize, scriptListSize = 10, 2
+	b := be16(nil, 1, 0, headerSize, headerSize+scriptListSize, headerSize+scriptListSize+len(featureList))
+	b = be16(b, 0)
+	b = append(b, featureList...)
+	return append(b, lookupList...)
+}
+
+// testGSUB returns a GSUB table whose features each have one single
+// substitution lookup.
+func testGSUB(features map[string]map[sfnt.GlyphIndex]sfnt.GlyphIndex) []byte {
+	lookups := map[string][]byte{}
+	for tag, m := range features {
+		var xs []int
+		for x := range m {
+			xs = append(xs, int(x))
+		}
+		sort.Ints(xs)
+		l := be16(nil, 1, 0, 1, 8)
+		l = be16(l, 2, 6+2*len(xs), len(xs))
+		for _, x := range xs {
+			l = be16(l, int(m[sfnt.GlyphIndex(x)]))
+		}
+		l = be16(l, 1, len(xs))
+		lookups[tag] = be16(l, xs...)
+	}
+	return testLayoutTable(lookups)
+}
+
+// testLigatureGSUB returns a GSUB table whose features each have one ligature
+// substitution lookup. Each ligature's glyphs are its components followed by
+// the glyph that replaces them. The ligatures that start with the same glyph
+// are tried in the given order.
+func testLigatureGSUB(features map[string][][]sfnt.GlyphIndex) []byte {
+	lookups := map[string][]byte{}
+	for tag, ligatures := range features {
+		sets := map[int][][]sfnt.GlyphIndex{}
+		var firsts []int
+		for _, l := range ligatures {
+			x := int(l[0])
+			if sets[x] == nil {
+				firsts = append(firsts, x)
+			}
+			sets[x] = append(sets[x], l)
+		}
+		sort.Ints(firsts)
+
+		// The subtable's header is followed by the ligature sets, each of
+		// which is followed by its ligatures, and then the coverage table.
+		var body []byte
+		offset := 6 + 2*len(firsts)
+		var setOffsets []int
+		for _, x := range firsts {
+			setOffsets = append(setOffsets, offset+len(body))
+			set := be16(nil, len(sets[x]))
+			var ligs []byte
+			for _, l := range sets[x] {
+				set = be16(set, 2+2*len(sets[x])+len(ligs))
+				ligs = be16(ligs, int(l[len(l)-1]), len(l)-1)
+				for _, c := range l[1 : len(l)-1] {
+					ligs = be16(ligs, int(c))
+				}
+			}
+			body = append(append(body, set...), ligs...)
+		}
+		l := be16(nil, 4, 0, 1, 8)
+		l = be16(l, 1, offset+len(body), len(firsts))
+		l = be16(l, setOffsets...)
+		l = append(l, body...)
+		l = be16(l, 1, len(firsts))
+		lookups[tag] = be16(l, firsts...)
+	}
+	return testLayoutTable(lookups)
+}
+
+// testMarkLookup returns a GPOS mark-to-base or mark-to-mark lookup table,
+// depending on lookupType, that attaches mark to base. The anchors are x, y
+// pairs.
+func testMarkLookup(lookupType int, base, mark sfnt.GlyphIndex, baseAnchor, markAnchor [2]int) []byte {
+	l := be16(nil, lookupType, 0, 1, 8)
+	l = be16(l, 1, 34, 40, 1, 12, 24)            // posFormat, coverages, markClassCount, arrays.
+	l = be16(l, 1, 0, 6)                         // markArray.
+	l = be16(l, 1, markAnchor[0], markAnchor[1]) // mark anchor.
+	l = be16(l, 1, 4)                            // baseArray.
+	l = be16(l, 1, baseAnchor[0], baseAnchor[1]) // base anchor.
+	l = be16(l, 1, 1, int(mark))                 // mark coverage.
+	return be16(l, 1, 1, int(base))              // base coverage.
+}
+
+// testGDEF returns a GDEF table that gives the glyphs' classes.
+func testGDEF(classes map[sfnt.GlyphIndex]sfnt.GlyphClass) []byte {
+	var xs []int
+	for x := range classes {
+		xs = append(xs, int(x))
+	}
+	sort.Ints(xs)
+	b := be16(nil, 1, 0, 12, 0, 0, 0)
+	b = be16(b, 2, len(xs))
+	for _, x := range xs {
+		b = be16(b, x, x, int(classes[sfnt.GlyphIndex(x)]))
+	}
+	return b
+}
+
+// testFont returns goregular with the given tables replaced.
+func testFont(t *testing.T, tables map[string][]byte) *sfnt.Font {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b, err := sfnt.NewBuilder(f)
+	if err != nil {
+		t.Fatalf("NewBuilder: %v", err)
+	}
+	for tag, data := range tables {
+		b.SetTable(sfnt.MustParseTag(tag), data)
+	}
+	data, err := b.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if f, err = sfnt.Parse(data); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return f
+}
+
+// checkShape checks the glyphs and clusters that text is shaped to.
+func checkShape(t *testing.T, f *sfnt.Font, text string, opts *Options, want []sfnt.GlyphIndex, wantClusters []int) {
+	t.Helper()
+	glyphs, err := Shape(nil, f, fixed.I(20), text, opts)
+	if err != nil {
+		t.Errorf("%q: Shape: %v", text, err)
+		return
+	}
+	if len(glyphs) != len(want) {
+		t.Errorf("%q: got %d glyphs, want %d", text, len(glyphs), len(want))
+		return
+	}
+	for i, g := range glyphs {
+		if g.GlyphIndex != want[i] || g.Cluster != wantClusters[i] {
+			t.Errorf("%q: glyph #%d: got %d (cluster %d), want %d (cluster %d)",
+				text, i, g.GlyphIndex, g.Cluster, want[i], wantClusters[i])
+		}
+	}
+}
+
+func TestJoiningForms(t *testing.T) {
+	const (
+		alef  = '\u0627'
+		beh   = '\u0628'
+		fatha = '\u064e'
+		zwj   = '\u200d'
+		zwnj  = '\u200c'
+	)
+	testCases := []struct {
+		runes []rune
+		want  []form
+	}{
+		{[]rune{beh}, []form{formIsolated}},
+		{[]rune{beh, beh}, []form{formInitial, formFinal}},
+		{[]rune{beh, beh, beh}, []form{formInitial, formMedial, formFinal}},
+		// Alef only joins to the previous letter.
+		{[]rune{beh, alef, beh}, []form{formInitial, formFinal, formIsolated}},
+		{[]rune{alef, alef}, []form{formIsolated, formIsolated}},
+		// Marks are transparent.
+		{[]rune{beh, fatha, beh}, []form{formInitial, formNone, formFinal}},
+		// Spaces and non-joiners break the joining, and joiners cause it.
+		{[]rune{beh, ' ', beh}, []form{formIsolated, formNone, formIsolated}},
+		{[]rune{beh, zwnj, beh}, []form{formIsolated, formNone, formIsolated}},
+		{[]rune{beh, zwj}, []form{formInitial, formNone}},
+		{[]rune{'a', 'b'}, []form{formNone, formNone}},
+	}
+	for _, tc := range testCases {
+		got := joiningForms(tc.runes)
+		if len(got) != len(tc.want) {
+			t.Errorf("%q: got %v, want %v", tc.runes, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%q: got %v, want %v", tc.runes, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestShapeArabic(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// Stand in for the Arabic letters alef and beh, and their contextual
+	// forms, with the glyphs for Latin letters and digits.
+	alef, beh, space := glyphIndex(t, f, 'a'), glyphIndex(t, f, 'b'), glyphIndex(t, f, ' ')
+	behIsol, behInit, behMedi, behFina := glyphIndex(t, f, '1'), glyphIndex(t, f, '2'), glyphIndex(t, f, '3'), glyphIndex(t, f, '4')
+	alefFina := glyphIndex(t, f, '5')
+
+	f = testFont(t, map[string][]byte{
+		"cmap": testCmap(map[rune]sfnt.GlyphIndex{
+			' ':      space,
+			'\u0627': alef,
+			'\u0628': beh,
+		}),
+		"GSUB": testGSUB(map[string]map[sfnt.GlyphIndex]sfnt.GlyphIndex{
+			"fina": {alef: alefFina, beh: behFina},
+			"init": {beh: behInit},
+			"isol": {beh: behIsol},
+			"medi": {beh: behMedi},
+		}),
+	})
+
+	testCases := []struct {
+		text         string
+		want         []sfnt.GlyphIndex
+		wantClusters []int
+	}{
+		// The glyphs are in visual order, so that the first letter is last.
+		{"\u0628\u0628\u0628", []sfnt.GlyphIndex{behFina, behMedi, behInit}, []int{4, 2, 0}},
+		{"\u0628\u0627", []sfnt.GlyphIndex{alefFina, behInit}, []int{2, 0}},
+		{"\u0628 \u0627", []sfnt.GlyphIndex{alef, space, behIsol}, []int{3, 2, 0}},
+	}
+	for _, tc := range testCases {
+		checkShape(t, f, tc.text, nil, tc.want, tc.wantClusters)
+	}
+
+	// Forcing the direction keeps the logical order.
+	glyphs, err := Shape(nil, f, fixed.I(20), "\u0628\u0627", &Options{Direction: DirectionLeftToRight})
+	if err != nil {
+		t.Fatalf("Shape: %v", err)
+	}
+	if len(glyphs) != 2 || glyphs[0].GlyphIndex != behInit || glyphs[1].GlyphIndex != alefFina {
+		t.Errorf("left to right: got %v, want glyphs %d, %d", glyphs, behInit, alefFina)
+	}
+}
+
+func TestShapeLatin(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	const text = "AVATAR"
+	glyphs, err := Shape(nil, f, fixed.I(20), text, &Options{Hinting: font.HintingFull})
+	if err != nil {
+		t.Fatalf("Shape: %v", err)
+	}
+	if len(glyphs) != len(text) {
+		t.Fatalf("got %d glyphs, want %d", len(glyphs), len(text))
+	}
+	// The advances include the kerns between each pair.
+	var b sfnt.Buffer
+	for i, g := range glyphs {
+		x := glyphIndex(t, f, rune(text[i]))
+		want, err := f.GlyphAdvance(&b, x, fixed.I(20), font.HintingFull)
+		if err != nil {
+			t.Fatalf("GlyphAdvance: %v", err)
+		}
+		if i+1 < len(text) {
+			k, err := f.Kern(&b, x, glyphIndex(t, f, rune(text[i+1])), fixed.I(20), font.HintingFull)
+			if err != nil {
+				t.Fatalf("Kern: %v", err)
+			}
+			want += k
+		}
+		if g.GlyphIndex != x || g.Cluster != i || g.Advance != want || g.Offset != (fixed.Point26_6{}) {
+			t.Errorf("glyph #%d: got %+v, want glyph %d, cluster %d, advance %v", i, g, x, i, want)
+		}
+	}
+}
+
+func TestShapeLigatures(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ff, fi, tt := glyphIndex(t, f, 'f'), glyphIndex(t, f, 'i'), glyphIndex(t, f, 't')
+	fiLig, ttLig := glyphIndex(t, f, 'L'), glyphIndex(t, f, 'T')
+	f = testFont(t, map[string][]byte{
+		"GSUB": testLigatureGSUB(map[string][][]sfnt.GlyphIndex{
+			"dlig": {{tt, tt, ttLig}},
+			"liga": {{ff, fi, fiLig}},
+		}),
+	})
+
+	checkShape(t, f, "fit", nil, []sfnt.GlyphIndex{fiLig, tt}, []int{0, 2})
+	checkShape(t, f, "tt", nil, []sfnt.GlyphIndex{tt, tt}, []int{0, 1})
+	// Options.Features can enable ligatures.
+	checkShape(t, f, "tt", &Options{Features: []sfnt.Tag{sfnt.MustParseTag("dlig")}},
+		[]sfnt.GlyphIndex{ttLig}, []int{0})
+}
+
+func TestShapeDevanagari(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// Stand in for the Devanagari letters and their forms with the glyphs for
+	// Latin letters.
+	ka, ra, virama := glyphIndex(t, f, 'k'), glyphIndex(t, f, 'r'), glyphIndex(t, f, 'v')
+	i, anusvara := glyphIndex(t, f, 'i'), glyphIndex(t, f, 'n')
+	reph, halfKa, rakar := glyphIndex(t, f, 'R'), glyphIndex(t, f, 'K'), glyphIndex(t, f, 'B')
+	f = testFont(t, map[string][]byte{
+		"cmap": testCmap(map[rune]sfnt.GlyphIndex{
+			'\u0902': anusvara,
+			'\u0915': ka,
+			'\u0930': ra,
+			'\u093f': i,
+			'\u094d': virama,
+		}),
+		"GSUB": testLigatureGSUB(map[string][][]sfnt.GlyphIndex{
+			"blwf": {{virama, ra, rakar}},
+			"half": {{ka, virama, halfKa}},
+			"rphf": {{ra, virama, reph}},
+		}),
+	})
+
+	testCases := []struct {
+		text         string
+		want         []sfnt.GlyphIndex
+		wantClusters []int
+	}{
+		{"\u0915\u0915", []sfnt.GlyphIndex{ka, ka}, []int{0, 3}},
+		// The vowel sign i is drawn before its consonant.
+		{"\u0915\u093f", []sfnt.GlyphIndex{i, ka}, []int{0, 0}},
+		// A ra and virama form a reph, drawn after the consonants and vowel
+		// signs, but before the anusvara.
+		{"\u0930\u094d\u0915", []sfnt.GlyphIndex{ka, reph}, []int{0, 0}},
+		{"\u0930\u094d\u0915\u0902", []sfnt.GlyphIndex{ka, reph, anusvara}, []int{0, 0, 0}},
+		{"\u0930\u094d\u0915\u093f\u0902", []sfnt.GlyphIndex{i, ka, reph, anusvara}, []int{0, 0, 0, 0}},
+		// The consonants before the base take half forms, and the vowel sign
+		// i is drawn before all of them.
+		{"\u0915\u094d\u0915\u093f", []sfnt.GlyphIndex{i, halfKa, ka}, []int{0, 0, 0}},
+		// A virama and ra after the base take a below-base form, and the base
+		// does not take a half form.
+		{"\u0915\u094d\u0930", []sfnt.GlyphIndex{ka, rakar}, []int{0, 3}},
+		{"\u0915\u094d", []sfnt.GlyphIndex{ka, virama}, []int{0, 3}},
+	}
+	for _, tc := range testCases {
+		checkShape(t, f, tc.text, nil, tc.want, tc.wantClusters)
+	}
+}
+
+func TestShapeKhmer(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// Stand in for the Khmer letters and their forms with the glyphs for
+	// Latin letters.
+	ka, coeng, ro := glyphIndex(t, f, 'k'), glyphIndex(t, f, 'c'), glyphIndex(t, f, 'r')
+	e, aa := glyphIndex(t, f, 'e'), glyphIndex(t, f, 'a')
+	coengRo, coengKa := glyphIndex(t, f, 'R'), glyphIndex(t, f, 'K')
+	f = testFont(t, map[string][]byte{
+		"cmap": testCmap(map[rune]sfnt.GlyphIndex{
+			'\u1780': ka,
+			'\u179a': ro,
+			'\u17b6': aa,
+			'\u17c1': e,
+			'\u17d2': coeng,
+		}),
+		"GSUB": testLigatureGSUB(map[string][][]sfnt.GlyphIndex{
+			"blwf": {{coeng, ka, coengKa}},
+			"pref": {{coeng, ro, coengRo}},
+		}),
+	})
+
+	testCases := []struct {
+		text         string
+		want         []sfnt.GlyphIndex
+		wantClusters []int
+	}{
+		{"\u1780\u17b6", []sfnt.GlyphIndex{ka, aa}, []int{0, 3}},
+		// The vowel sign e is drawn before its consonants.
+		{"\u1780\u17c1", []sfnt.GlyphIndex{e, ka}, []int{0, 0}},
+		// A coeng and ro are drawn before the base, after the vowel sign e.
+		{"\u1780\u17d2\u179a", []sfnt.GlyphIndex{coengRo, ka}, []int{0, 0}},
+		{"\u1780\u17d2\u179a\u17c1", []sfnt.GlyphIndex{e, coengRo, ka}, []int{0, 0, 0}},
+		{"\u1780\u17d2\u1780", []sfnt.GlyphIndex{ka, coengKa}, []int{0, 3}},
+	}
+	for _, tc := range testCases {
+		checkShape(t, f, tc.text, nil, tc.want, tc.wantClusters)
+	}
+}
+
+func TestShapeMarks(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	a, b := glyphIndex(t, f, 'a'), glyphIndex(t, f, 'b')
+	acute, grave := glyphIndex(t, f, '\''), glyphIndex(t, f, '`')
+	f = testFont(t, map[string][]byte{
+		"cmap": testCmap(map[rune]sfnt.GlyphIndex{
+			'a':      a,
+			'b':      b,
+			'\u0300': grave,
+			'\u0301': acute,
+		}),
+		// The grave is not classified, but U+0300 is a nonspacing mark.
+		"GDEF": testGDEF(map[sfnt.GlyphIndex]sfnt.GlyphClass{
+			a:     sfnt.GlyphClassBase,
+			b:     sfnt.GlyphClassBase,
+			acute: sfnt.GlyphClassMark,
+		}),
+		"GPOS": testLayoutTable(map[string][]byte{
+			"mark": testMarkLookup(4, a, acute, [2]int{500, 1400}, [2]int{100, 0}),
+			"mkmk": testMarkLookup(6, acute, grave, [2]int{100, 300}, [2]int{0, -100}),
+		}),
+	})
+
+	// With this ppem, a font unit is 1/64th of a pixel.
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+	advance := func(x sfnt.GlyphIndex) fixed.Int26_6 {
+		adv, err := f.GlyphAdvance(nil, x, ppem, font.HintingNone)
+		if err != nil {
+			t.Fatalf("GlyphAdvance: %v", err)
+		}
+		return adv
+	}
+
+	testCases := []struct {
+		text string
+		dir  Direction
+		want []Glyph
+	}{{
+		text: "a\u0301\u0300",
+		want: []Glyph{
+			{GlyphIndex: a, Cluster: 0, Advance: advance(a)},
+			{GlyphIndex: acute, Cluster: 1, Offset: fixed.Point26_6{X: 400 - advance(a), Y: -1400}},
+			{GlyphIndex: grave, Cluster: 3, Offset: fixed.Point26_6{X: 500 - advance(a), Y: -1800}},
+		},
+	}, {
+		// Marks that cannot be attached keep their advance.
+		text: "b\u0301",
+		want: []Glyph{
+			{GlyphIndex: b, Cluster: 0, Advance: advance(b)},
+			{GlyphIndex: acute, Cluster: 1, Advance: advance(acute)},
+		},
+	}, {
+		// The mark is visually before its base in right-to-left text.
+		text: "a\u0301",
+		dir:  DirectionRightToLeft,
+		want: []Glyph{
+			{GlyphIndex: acute, Cluster: 1, Offset: fixed.Point26_6{X: 400, Y: -1400}},
+			{GlyphIndex: a, Cluster: 0, Advance: advance(a)},
+		},
+	}}
+	for _, tc := range testCases {
+		glyphs, err := Shape(nil, f, ppem, tc.text, &Options{Direction: tc.dir})
+		if err != nil {
+			t.Errorf("%q: Shape: %v", tc.text, err)
+			continue
+		}
+		if !reflect.DeepEqual(glyphs, tc.want) {
+			t.Errorf("%q:\ngot  %+v\nwant %+v", tc.text, glyphs, tc.want)
+		}
+	}
+}
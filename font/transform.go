@@ -0,0 +1,101 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+	"golang.org/x/image/math/fixed"
+)
+
+// DrawStringTransform is like DrawString, but draws s transformed by m, such
+// as to rotate or scale it.
+//
+// The text is laid out as for DrawString, starting at the dot, and then
+// transformed so that a point p in that layout is drawn at dot + M(p - dot) +
+// t, where M is m's linear part, {m[0], m[1], m[3], m[4]}, and t is m's
+// translation, {m[2], m[5]}, both in pixels. For example, an m of
+// {cos(θ), -sin(θ), 0, sin(θ), cos(θ), 0} rotates the text clockwise by θ
+// around the dot, as the y axis increases down.
+//
+// The dot is advanced along the transformed baseline, by M applied to the
+// text's advance, so that another DrawStringTransform call with the same m
+// continues the text. The Src image is sampled in the untransformed layout's
+// coordinate space, which does not matter for an *image.Uniform.
+//
+// The glyph masks are transformed with the draw package's ApproxBiLinear
+// interpolator.
+func (d *Drawer) DrawStringTransform(s string, m f64.Aff3) {
+	if m[0] == 1 && m[1] == 0 && m[3] == 0 && m[4] == 1 {
+		// Only translate the text, which DrawString can do without
+		// resampling the glyph masks.
+		t := fixed.Point26_6{X: floatToFix(m[2]), Y: floatToFix(m[5])}
+		d.Dot = d.Dot.Add(t)
+		d.DrawString(s)
+		d.Dot = d.Dot.Sub(t)
+		return
+	}
+
+	// s2d maps the layout's coordinates to the Dst's coordinates.
+	ox, oy := fixToFloat(d.Dot.X), fixToFloat(d.Dot.Y)
+	s2d := f64.Aff3{
+		m[0], m[1], m[2] + ox - m[0]*ox - m[1]*oy,
+		m[3], m[4], m[5] + oy - m[3]*ox - m[4]*oy,
+	}
+
+	dot := d.Dot
+	prevC := rune(-1)
+	for _, c := range s {
+		if c == '\t' {
+			dot.X = d.Dot.X + d.nextTabStop(dot.X-d.Dot.X)
+			prevC = -1
+			continue
+		}
+		if prevC >= 0 {
+			dot.X += d.Face.Kern(prevC, c)
+		}
+		dr, mask, maskp, advance, ok := d.Face.Glyph(dot, c)
+		if !ok {
+			continue
+		}
+		d.drawGlyphTransform(s2d, dr, mask, maskp)
+		dot.X += advance + d.spacing(c)
+		prevC = c
+	}
+
+	advance := fixToFloat(dot.X - d.Dot.X)
+	d.Dot.X += floatToFix(m[0] * advance)
+	d.Dot.Y += floatToFix(m[3] * advance)
+}
+
+// drawGlyphTransform draws a glyph mask, as returned by Face.Glyph, with the
+// glyph's destination rectangle dr transformed by s2d.
+func (d *Drawer) drawGlyphTransform(s2d f64.Aff3, dr image.Rectangle, mask image.Image, maskp image.Point) {
+	if s2d[0] == 1 && s2d[1] == 0 && s2d[3] == 0 && s2d[4] == 1 &&
+		s2d[2] == math.Trunc(s2d[2]) && s2d[5] == math.Trunc(s2d[5]) {
+		// Translating by whole pixels does not need resampling.
+		dr = dr.Add(image.Point{X: int(s2d[2]), Y: int(s2d[5])})
+		d.drawGlyph(dr, mask, maskp)
+		return
+	}
+	xdraw.ApproxBiLinear.Transform(d.Dst, s2d, d.Src, dr, xdraw.Over, &xdraw.Options{
+		SrcMask:  mask,
+		SrcMaskP: maskp.Sub(dr.Min),
+	})
+}
+
+func fixToFloat(x fixed.Int26_6) float64 {
+	return float64(x) / 64
+}
+
+func floatToFix(x float64) fixed.Int26_6 {
+	if x < 0 {
+		return -fixed.Int26_6(0.5 - x*64)
+	}
+	return fixed.Int26_6(0.5 + x*64)
+}
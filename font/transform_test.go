@@ -0,0 +1,65 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/f64"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestDrawStringTransform(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		m       f64.Aff3
+		opaque  []image.Point
+		clear   []image.Point
+		wantDot fixed.Point26_6
+	}{{
+		desc:    "translate",
+		m:       f64.Aff3{1, 0, 2, 0, 1, 3},
+		opaque:  []image.Point{{12, 13}, {21, 13}},
+		clear:   []image.Point{{10, 10}, {12, 10}},
+		wantDot: fixed.P(20, 10),
+	}, {
+		// The y axis increases down, so this rotates clockwise.
+		desc:    "rotate 90 degrees",
+		m:       f64.Aff3{0, -1, 0, 1, 0, 0},
+		opaque:  []image.Point{{9, 12}, {9, 17}},
+		clear:   []image.Point{{12, 10}, {11, 12}, {8, 12}},
+		wantDot: fixed.P(10, 20),
+	}, {
+		desc:    "scale",
+		m:       f64.Aff3{2, 0, 0, 0, 2, 0},
+		opaque:  []image.Point{{12, 10}, {28, 11}},
+		clear:   []image.Point{{12, 12}, {31, 10}},
+		wantDot: fixed.P(30, 10),
+	}}
+	for _, tc := range testCases {
+		dst := image.NewAlpha(image.Rect(0, 0, 32, 32))
+		d := &Drawer{
+			Dst:  dst,
+			Src:  image.Opaque,
+			Face: rangeFace{lo: 'a', hi: 'z', advance: fixed.I(5), closed: new(int)},
+			Dot:  fixed.P(10, 10),
+		}
+		d.DrawStringTransform("ab", tc.m)
+		for _, p := range tc.opaque {
+			if a := dst.AlphaAt(p.X, p.Y).A; a != 0xff {
+				t.Errorf("%s: alpha at %v: got %#02x, want 0xff", tc.desc, p, a)
+			}
+		}
+		for _, p := range tc.clear {
+			if a := dst.AlphaAt(p.X, p.Y).A; a != 0 {
+				t.Errorf("%s: alpha at %v: got %#02x, want 0x00", tc.desc, p, a)
+			}
+		}
+		if d.Dot != tc.wantDot {
+			t.Errorf("%s: dot: got %v, want %v", tc.desc, d.Dot, tc.wantDot)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// Truncate returns s if it is at most maxAdvance wide when drawn with f, as
+// per MeasureString. Otherwise, it returns the longest prefix of s that, with
+// an ellipsis appended, is at most maxAdvance wide, with that ellipsis
+// appended. The ellipsis is U+2026 HORIZONTAL ELLIPSIS if f has a glyph for
+// it, and "..." otherwise.
+//
+// The width includes the kerning between the prefix's last rune and the
+// ellipsis. s is only cut between grapheme clusters, as per
+// NextGraphemeCluster, and spaces at the end of the prefix are dropped. If
+// not even the ellipsis fits, Truncate returns the empty string.
+func Truncate(f Face, s string, maxAdvance fixed.Int26_6) string {
+	if MeasureString(f, s) <= maxAdvance {
+		return s
+	}
+	ellipsis := "\u2026"
+	if _, ok := f.GlyphAdvance('\u2026'); !ok {
+		ellipsis = "..."
+	}
+	ellipsisR, _ := utf8.DecodeRuneInString(ellipsis)
+	ellipsisAdvance := MeasureString(f, ellipsis)
+
+	result := ""
+	if ellipsisAdvance <= maxAdvance {
+		result = ellipsis
+	}
+	var (
+		advance fixed.Int26_6
+		prevC   = rune(-1)
+	)
+	for i := 0; i < len(s); {
+		n := NextGraphemeCluster(s[i:])
+		for _, c := range s[i : i+n] {
+			a, ok := f.GlyphAdvance(c)
+			if !ok {
+				continue
+			}
+			if prevC >= 0 {
+				advance += f.Kern(prevC, c)
+			}
+			advance += a
+			prevC = c
+		}
+		i += n
+		if advance > maxAdvance {
+			break
+		}
+		if prevC < 0 || unicode.IsSpace(lastRune(s[:i])) {
+			continue
+		}
+		if advance+f.Kern(prevC, ellipsisR)+ellipsisAdvance <= maxAdvance {
+			result = s[:i] + ellipsis
+		}
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// ellipsisFace is a rangeFace that also has a 6 pixel wide U+2026 HORIZONTAL
+// ELLIPSIS glyph, which kerns 2 pixels closer to an 'o'.
+type ellipsisFace struct {
+	rangeFace
+}
+
+func (f ellipsisFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if r == '\u2026' {
+		return fixed.I(6), true
+	}
+	return f.rangeFace.GlyphAdvance(r)
+}
+
+func (f ellipsisFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	if r0 == 'o' && r1 == '\u2026' {
+		return -fixed.I(2)
+	}
+	return 0
+}
+
+func TestTruncate(t *testing.T) {
+	ascii := rangeFace{lo: ' ', hi: 'z', advance: fixed.I(5), closed: new(int)}
+	ellipsis := ellipsisFace{ascii}
+
+	testCases := []struct {
+		desc string
+		f    Face
+		s    string
+		max  int
+		want string
+	}{
+		{"fits", ascii, "hello", 25, "hello"},
+		{"empty", ascii, "", 0, ""},
+		{"dots", ascii, "hello world", 30, "hel..."},
+		{"ellipsis kerned", ellipsis, "hello world", 30, "hello\u2026"},
+		{"ellipsis", ellipsis, "hello world", 28, "hell\u2026"},
+		{"trailing space", ascii, "ab cde", 29, "ab..."},
+		{"cluster", ascii, "e\u0301e\u0301e\u0301e\u0301e\u0301", 20, "e\u0301..."},
+		{"only ellipsis", ascii, "hello", 15, "..."},
+		{"too narrow", ascii, "hello", 10, ""},
+	}
+	for _, tc := range testCases {
+		if got := Truncate(tc.f, tc.s, fixed.I(tc.max)); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// VerticalFace is a Face that can also lay out vertical text, where lines of
+// text run top to bottom, as for traditional Chinese, Japanese and Mongolian.
+//
+// In vertical text, the dot is a glyph's vertical origin, which is centered
+// horizontally on the line, at the top of the glyph's em box.
+type VerticalFace interface {
+	Face
+
+	// VerticalGlyph is like Glyph, but for vertical text: dot is the vertical
+	// origin, and advance is how far the dot moves down. The glyph may be a
+	// vertical alternate, such as a rotated bracket, of r's glyph.
+	VerticalGlyph(dot fixed.Point26_6, r rune) (
+		dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool)
+
+	// VerticalGlyphAdvance is like GlyphAdvance, but returns how far the dot
+	// moves down in vertical text.
+	VerticalGlyphAdvance(r rune) (advance fixed.Int26_6, ok bool)
+}
+
+// DrawStringVertical draws s at the dot, top to bottom, and advances the
+// dot's location downwards. The dot is the vertical origin of the glyphs,
+// centered horizontally on the line.
+//
+// If the drawer's Face is a VerticalFace, its vertical metrics and glyphs are
+// used. Otherwise, the horizontal glyphs are centered on the line and stacked
+// one line height, Metrics.Ascent plus Metrics.Descent, apart. Glyphs are not
+// kerned, and the LetterSpacing and WordSpacing are added after each glyph.
+func (d *Drawer) DrawStringVertical(s string) {
+	for _, c := range s {
+		dr, mask, maskp, advance, ok := d.verticalGlyph(d.Dot, c)
+		if !ok {
+			continue
+		}
+		d.drawGlyph(dr, mask, maskp)
+		d.Dot.Y += advance + d.spacing(c)
+	}
+}
+
+// MeasureStringVertical returns how far dot would advance downwards by
+// drawing s with DrawStringVertical.
+func (d *Drawer) MeasureStringVertical(s string) (advance fixed.Int26_6) {
+	for _, c := range s {
+		if a, ok := verticalGlyphAdvance(d.Face, c); ok {
+			advance += a + d.spacing(c)
+		}
+	}
+	return advance
+}
+
+// verticalGlyph is like VerticalFace.VerticalGlyph, falling back to the
+// horizontal glyph if the drawer's Face is not a VerticalFace.
+func (d *Drawer) verticalGlyph(dot fixed.Point26_6, c rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return verticalGlyph(d.Face, dot, c)
+}
+
+// verticalGlyph is like VerticalFace.VerticalGlyph, falling back to
+// stackedGlyph if f is not a VerticalFace.
+func verticalGlyph(f Face, dot fixed.Point26_6, c rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	if vf, ok := f.(VerticalFace); ok {
+		return vf.VerticalGlyph(dot, c)
+	}
+	return stackedGlyph(f, dot, c)
+}
+
+// verticalGlyphAdvance is like VerticalFace.VerticalGlyphAdvance, falling
+// back to stackedGlyph's advance if f is not a VerticalFace.
+func verticalGlyphAdvance(f Face, c rune) (advance fixed.Int26_6, ok bool) {
+	if vf, ok := f.(VerticalFace); ok {
+		return vf.VerticalGlyphAdvance(c)
+	}
+	if _, ok := f.GlyphAdvance(c); !ok {
+		return 0, false
+	}
+	m := f.Metrics()
+	return m.Ascent + m.Descent, true
+}
+
+// stackedGlyph returns f's horizontal glyph for c, centered on a vertical
+// line at dot, with an advance of f's line height.
+func stackedGlyph(f Face, dot fixed.Point26_6, c rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	a, ok := f.GlyphAdvance(c)
+	if !ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	m := f.Metrics()
+	dot.X -= a / 2
+	dot.Y += m.Ascent
+	dr, mask, maskp, _, ok = f.Glyph(dot, c)
+	return dr, mask, maskp, m.Ascent + m.Descent, ok
+}
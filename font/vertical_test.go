@@ -0,0 +1,94 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package font
+
+import (
+	"image"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// verticalFace is a VerticalFace whose vertical glyphs are 1 pixel wide and
+// 3 pixels tall, below the dot.
+type verticalFace struct {
+	rangeFace
+}
+
+func (f verticalFace) VerticalGlyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	p := image.Point{X: dot.X.Floor(), Y: dot.Y.Floor()}
+	return image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 3))}, image.Opaque, image.Point{}, fixed.I(4), true
+}
+
+func (f verticalFace) VerticalGlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if r < f.lo || f.hi < r {
+		return 0, false
+	}
+	return fixed.I(4), true
+}
+
+func TestDrawStringVertical(t *testing.T) {
+	face := rangeFace{
+		lo: 'a', hi: 'z', advance: fixed.I(6),
+		metrics: Metrics{Height: fixed.I(12), Ascent: fixed.I(8), Descent: fixed.I(2)},
+		closed:  new(int),
+	}
+	testCases := []struct {
+		desc        string
+		face        Face
+		wantOpaque  []image.Point
+		wantPixels  int
+		wantAdvance fixed.Int26_6
+	}{{
+		// Horizontal glyphs are centered on the dot, and one ascent plus
+		// descent apart.
+		desc:        "Face",
+		face:        face,
+		wantOpaque:  []image.Point{{7, 18}, {12, 18}, {7, 28}},
+		wantPixels:  12,
+		wantAdvance: fixed.I(20),
+	}, {
+		desc:        "VerticalFace",
+		face:        verticalFace{face},
+		wantOpaque:  []image.Point{{10, 10}, {10, 12}, {10, 14}, {10, 16}},
+		wantPixels:  6,
+		wantAdvance: fixed.I(8),
+	}}
+	for _, tc := range testCases {
+		dst := image.NewAlpha(image.Rect(0, 0, 32, 32))
+		d := &Drawer{
+			Dst:  dst,
+			Src:  image.Opaque,
+			Face: tc.face,
+			Dot:  fixed.P(10, 10),
+		}
+		// The face has no glyph for 'A', which is skipped.
+		const s = "aAb"
+		if got := d.MeasureStringVertical(s); got != tc.wantAdvance {
+			t.Errorf("%s: MeasureStringVertical: got %v, want %v", tc.desc, got, tc.wantAdvance)
+		}
+		d.DrawStringVertical(s)
+		if want := fixed.P(10, 10).Add(fixed.Point26_6{Y: tc.wantAdvance}); d.Dot != want {
+			t.Errorf("%s: dot: got %v, want %v", tc.desc, d.Dot, want)
+		}
+		n := 0
+		for _, a := range dst.Pix {
+			if a != 0 {
+				n++
+			}
+		}
+		for _, p := range tc.wantOpaque {
+			if dst.AlphaAt(p.X, p.Y).A != 0xff {
+				t.Errorf("%s: alpha at %v: got 0, want 0xff", tc.desc, p)
+			}
+		}
+		if n != tc.wantPixels {
+			t.Errorf("%s: opaque pixels: got %d, want %d", tc.desc, n, tc.wantPixels)
+		}
+	}
+}